@@ -0,0 +1,88 @@
+package events
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "manage the stored events of ZITADEL",
+		Long:  "manage the stored events of ZITADEL",
+	}
+
+	cmd.AddCommand(
+		newRevisions(),
+		newRewrite(),
+	)
+
+	return cmd
+}
+
+func newRevisions() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revisions",
+		Short: "lists the payload revisions of the stored events",
+		Long: `lists the count of stored events per event type and payload revision.
+Outdated revisions are upcasted on every read until they are rewritten by "zitadel events rewrite".`,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := MustNewConfig(viper.GetViper())
+			es := mustNewEventstore(config)
+
+			revisions, err := es.EventRevisions(cmd.Context())
+			logging.OnError(err).Fatal("unable to list revisions")
+			err = printRevisions(cmd.OutOrStdout(), revisions)
+			logging.OnError(err).Fatal("unable to print revisions")
+		},
+	}
+}
+
+func newRewrite() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewrite",
+		Short: "rewrites the payloads of outdated revisions",
+		Long: `rewrites the stored payloads of events with an outdated revision to the latest revision of their event type.
+The rewrite can run while ZITADEL is running.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := MustNewConfig(viper.GetViper())
+			es := mustNewEventstore(config)
+
+			err := es.RewriteEvents(cmd.Context())
+			logging.OnError(err).Fatal("rewrite failed")
+		},
+	}
+}
+
+func mustNewEventstore(config *Config) *eventstore.Eventstore {
+	queryDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeQuery)
+	logging.OnError(err).Fatal("unable to connect to database")
+	esPusherDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeEventPusher)
+	logging.OnError(err).Fatal("unable to connect to database")
+
+	esV3 := new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.Pusher = esV3
+	config.Eventstore.Querier = old_es.NewCRDB(queryDBClient)
+	config.Eventstore.Rewriter = esV3
+	return eventstore.NewEventstore(config.Eventstore)
+}
+
+func printRevisions(w io.Writer, revisions []*eventstore.EventRevisionCount) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "EVENT TYPE\tREVISION\tLATEST\tCOUNT")
+	for _, revision := range revisions {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", revision.EventType, revision.Revision, eventstore.EventRevision(revision.EventType), revision.Count)
+	}
+	return tw.Flush()
+}
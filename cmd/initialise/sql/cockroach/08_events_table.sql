@@ -13,6 +13,7 @@ CREATE TABLE IF NOT EXISTS eventstore.events2 (
     
     , "position" DECIMAL NOT NULL
     , in_tx_order INTEGER NOT NULL
+    , payload_revision SMALLINT NOT NULL DEFAULT 0
 
     , PRIMARY KEY (instance_id, aggregate_type, aggregate_id, "sequence")
 	, INDEX es_active_instances (created_at DESC) STORING ("position")
@@ -0,0 +1,43 @@
+package instance
+
+import (
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/internal/config/hook"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
+	"github.com/zitadel/zitadel/internal/database"
+	static_config "github.com/zitadel/zitadel/internal/static/config"
+)
+
+type Config struct {
+	Database       database.Config
+	Sharding       *database.ShardingConfig
+	Log            *logging.Config
+	KMS            *kms.Config
+	EncryptionKeys *encryption.EncryptionKeyConfig
+	AssetStorage   static_config.AssetStorageConfig
+}
+
+func MustNewConfig(v *viper.Viper) *Config {
+	config := new(Config)
+	err := v.Unmarshal(config,
+		viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+			hook.Base64ToBytesHookFunc(),
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToTimeHookFunc(time.RFC3339),
+			mapstructure.StringToSliceHookFunc(","),
+			database.DecodeHook,
+		)),
+	)
+	logging.OnError(err).Fatal("unable to read default config")
+
+	err = config.Log.SetLogger()
+	logging.OnError(err).Fatal("unable to set logger")
+
+	return config
+}
@@ -0,0 +1,318 @@
+package instance
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/build"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	selectEventsStmt = `SELECT to_json(e)::TEXT, e."position"::TEXT, e.in_tx_order FROM eventstore.events2 e` +
+		` WHERE e.instance_id = $1 AND (e."position", e.in_tx_order) > ($2::DECIMAL, $3)` +
+		` ORDER BY e."position", e.in_tx_order LIMIT $4`
+	// cockroach reads the snapshot in the past to prevent restarts of the long running transaction
+	setSnapshotTimestampStmt = `SET TRANSACTION AS OF SYSTEM TIME '-1ms'`
+	// events are exported by [exporter.exportEvents] and snapshots are recreated on demand
+	selectEventstoreTablesStmt = `SELECT c.table_name, c.column_name FROM information_schema.columns c` +
+		` JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name` +
+		` WHERE t.table_type = 'BASE TABLE' AND c.table_schema = 'eventstore'` +
+		` AND c.is_generated = 'NEVER' AND c.column_name <> 'rowid'` +
+		` AND c.table_name NOT IN ('events', 'events2', 'snapshots')` +
+		` ORDER BY c.table_name, c.ordinal_position`
+	selectDomainsStmt = `SELECT domain FROM projections.instance_domains WHERE instance_id = $1 ORDER BY domain`
+	instanceIDColumn  = "instance_id"
+)
+
+type exporter struct {
+	client       *database.DB
+	storage      static.Storage
+	transcrypter *crypto.Transcrypter
+	batchSize    uint32
+	out          *writer
+}
+
+// querier is implemented by [database.DB] and [snapshot]
+type querier interface {
+	QueryContext(ctx context.Context, scan func(rows *sql.Rows) error, query string, args ...any) error
+	QueryRowContext(ctx context.Context, scan func(row *sql.Row) error, query string, args ...any) error
+}
+
+// snapshot reads all records of the export from the same state of the database,
+// so the events, the eventstore rows and the projection counts match even if the instance changes during the export.
+type snapshot struct {
+	*sql.Tx
+}
+
+func beginSnapshot(ctx context.Context, client *database.DB) (*snapshot, error) {
+	tx, err := client.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INST-Ex6Sn", "unable to begin snapshot")
+	}
+	if client.Type() == "cockroach" {
+		if _, err = tx.ExecContext(ctx, setSnapshotTimestampStmt); err != nil {
+			rollbackErr := tx.Rollback()
+			logging.OnError(rollbackErr).Debug("unable to rollback snapshot")
+			return nil, zerrors.ThrowInternal(err, "INST-Ex7Sn", "unable to begin snapshot")
+		}
+	}
+	return &snapshot{Tx: tx}, nil
+}
+
+func (s *snapshot) QueryContext(ctx context.Context, scan func(rows *sql.Rows) error, query string, args ...any) error {
+	rows, err := s.Tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := rows.Close()
+		logging.OnError(closeErr).Info("rows.Close failed")
+	}()
+
+	if err = scan(rows); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+func (s *snapshot) QueryRowContext(ctx context.Context, scan func(row *sql.Row) error, query string, args ...any) error {
+	row := s.Tx.QueryRowContext(ctx, query, args...)
+	if err := scan(row); err != nil {
+		return err
+	}
+	return row.Err()
+}
+
+// export writes all records of the instance.
+// The records stored in the database are read from one snapshot,
+// only the assets are read from the asset storage after the snapshot was taken.
+func (e *exporter) export(ctx context.Context, instanceID string, encryptedKey []byte, keyProvider string) (err error) {
+	snapshot, err := beginSnapshot(ctx, e.client)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// the snapshot is read only, so it's rolled back in any case
+		rollbackErr := snapshot.Rollback()
+		logging.OnError(rollbackErr).Debug("unable to rollback snapshot")
+	}()
+	domains, err := e.domains(ctx, snapshot, instanceID)
+	if err != nil {
+		return err
+	}
+	err = e.out.write(&record{
+		Type: recordTypeHeader,
+		Header: &header{
+			FormatVersion:  formatVersion,
+			ZitadelVersion: build.Version(),
+			InstanceID:     instanceID,
+			ExportedAt:     time.Now().UTC(),
+			Domains:        domains,
+			Key:            encryptedKey,
+			KeyProvider:    keyProvider,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err = e.exportEvents(ctx, snapshot, instanceID); err != nil {
+		return err
+	}
+	tables, err := eventstoreTables(ctx, snapshot)
+	if err != nil {
+		return err
+	}
+	for _, t := range tables {
+		if err = e.exportTable(ctx, snapshot, instanceID, t); err != nil {
+			return err
+		}
+	}
+	counts, err := projectionCounts(ctx, snapshot, instanceID)
+	if err != nil {
+		return err
+	}
+	if err = e.exportAssets(ctx, instanceID); err != nil {
+		return err
+	}
+	return e.out.write(&record{Type: recordTypeProjections, Projections: counts})
+}
+
+func (e *exporter) domains(ctx context.Context, q querier, instanceID string) (domains []string, err error) {
+	err = q.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var domain string
+			if err := rows.Scan(&domain); err != nil {
+				return err
+			}
+			domains = append(domains, domain)
+		}
+		return rows.Err()
+	}, selectDomainsStmt, instanceID)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INST-Ex1Dm", "unable to read domains")
+	}
+	return domains, nil
+}
+
+// exportEvents streams the events ordered by their position in chunks of the batch size
+func (e *exporter) exportEvents(ctx context.Context, q querier, instanceID string) error {
+	var (
+		position  = "0"
+		inTxOrder int32
+	)
+	for {
+		events := make([]json.RawMessage, 0, e.batchSize)
+		err := q.QueryContext(ctx, func(rows *sql.Rows) error {
+			for rows.Next() {
+				var event []byte
+				if err := rows.Scan(&event, &position, &inTxOrder); err != nil {
+					return err
+				}
+				event, err := e.transcrypt(event)
+				if err != nil {
+					return err
+				}
+				events = append(events, event)
+			}
+			return rows.Err()
+		}, selectEventsStmt, instanceID, position, inTxOrder, e.batchSize)
+		if err != nil {
+			return zerrors.ThrowInternal(err, "INST-Ex2Ev", "unable to read events")
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		if err = e.out.write(&record{Type: recordTypeEvents, Events: events}); err != nil {
+			return err
+		}
+	}
+}
+
+// exportTable streams the rows of the instance in chunks of the batch size
+func (e *exporter) exportTable(ctx context.Context, q querier, instanceID string, t *table) error {
+	write := func(rows []json.RawMessage) error {
+		if len(rows) == 0 {
+			return nil
+		}
+		return e.out.write(&record{Type: recordTypeRows, Table: t.name, Rows: rows})
+	}
+	err := q.QueryContext(ctx, func(rows *sql.Rows) error {
+		batch := make([]json.RawMessage, 0, e.batchSize)
+		for rows.Next() {
+			var row []byte
+			if err := rows.Scan(&row); err != nil {
+				return err
+			}
+			row, err := e.transcrypt(row)
+			if err != nil {
+				return err
+			}
+			if batch = append(batch, row); len(batch) < int(e.batchSize) {
+				continue
+			}
+			if err = write(batch); err != nil {
+				return err
+			}
+			batch = make([]json.RawMessage, 0, e.batchSize)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return write(batch)
+	}, "SELECT to_json(r)::TEXT FROM (SELECT "+t.columnList()+" FROM "+t.identifier()+" WHERE instance_id = $1) r", instanceID)
+	if err != nil {
+		return zerrors.ThrowInternalf(err, "INST-Ex3Tb", "unable to export %s", t.identifier())
+	}
+	return nil
+}
+
+func (e *exporter) exportAssets(ctx context.Context, instanceID string) error {
+	assets, err := e.storage.ListObjects(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	for _, info := range assets {
+		data, _, err := e.storage.GetObject(ctx, instanceID, info.ResourceOwner, info.Name)
+		if err != nil {
+			return err
+		}
+		err = e.out.write(&record{
+			Type: recordTypeAsset,
+			Asset: &asset{
+				ResourceOwner: info.ResourceOwner,
+				Name:          info.Name,
+				ContentType:   info.ContentType,
+				ObjectType:    info.ObjectType,
+				Data:          data,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transcrypt encrypts the secrets of the document by the export key
+func (e *exporter) transcrypt(document []byte) ([]byte, error) {
+	transcrypted, changed, err := e.transcrypter.TranscryptJSON(document)
+	if err != nil || !changed {
+		return document, err
+	}
+	return transcrypted, nil
+}
+
+type table struct {
+	name    string
+	columns []string
+}
+
+func (t *table) identifier() string {
+	return "eventstore." + quoteIdentifier(t.name)
+}
+
+func (t *table) columnList() string {
+	columns := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		columns[i] = quoteIdentifier(column)
+	}
+	return strings.Join(columns, ", ")
+}
+
+// eventstoreTables returns the tables of the eventstore besides the events which contain rows of instances
+func eventstoreTables(ctx context.Context, client querier) ([]*table, error) {
+	var tables []*table
+	err := client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var name, column string
+			if err := rows.Scan(&name, &column); err != nil {
+				return err
+			}
+			if len(tables) == 0 || tables[len(tables)-1].name != name {
+				tables = append(tables, &table{name: name})
+			}
+			tables[len(tables)-1].columns = append(tables[len(tables)-1].columns, column)
+		}
+		return rows.Err()
+	}, selectEventstoreTablesStmt)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INST-Ex4Tb", "unable to read tables")
+	}
+	return slices.DeleteFunc(tables, func(t *table) bool {
+		return !slices.Contains(t.columns, instanceIDColumn)
+	}), nil
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
@@ -0,0 +1,200 @@
+package instance
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// formatVersion is increased on breaking changes of the export file
+const formatVersion = 1
+
+type recordType string
+
+const (
+	recordTypeHeader      recordType = "header"
+	recordTypeEvents      recordType = "events"
+	recordTypeRows        recordType = "rows"
+	recordTypeAsset       recordType = "asset"
+	recordTypeProjections recordType = "projections"
+	recordTypeTrailer     recordType = "trailer"
+)
+
+// record is a single line of the export file.
+// The file starts with the header and ends with the trailer,
+// events and rows are split into chunks so large instances are streamed.
+type record struct {
+	Type        recordType        `json:"type"`
+	Header      *header           `json:"header,omitempty"`
+	Events      []json.RawMessage `json:"events,omitempty"`
+	Table       string            `json:"table,omitempty"`
+	Rows        []json.RawMessage `json:"rows,omitempty"`
+	Asset       *asset            `json:"asset,omitempty"`
+	Projections map[string]int    `json:"projections,omitempty"`
+	Trailer     *trailer          `json:"trailer,omitempty"`
+}
+
+type header struct {
+	FormatVersion  int       `json:"formatVersion"`
+	ZitadelVersion string    `json:"zitadelVersion"`
+	InstanceID     string    `json:"instanceId"`
+	ExportedAt     time.Time `json:"exportedAt"`
+	Domains        []string  `json:"domains,omitempty"`
+	// Key encrypts the secrets of the export, it's encrypted by the masterkey of the target
+	// or wrapped by the KMS of the target if KeyProvider is set
+	Key         []byte `json:"key"`
+	KeyProvider string `json:"keyProvider,omitempty"`
+}
+
+type asset struct {
+	ResourceOwner string            `json:"resourceOwner"`
+	Name          string            `json:"name"`
+	ContentType   string            `json:"contentType"`
+	ObjectType    static.ObjectType `json:"objectType"`
+	Data          []byte            `json:"data"`
+}
+
+// trailer contains the counts of the records, so truncated files are detected
+type trailer struct {
+	Events int `json:"events"`
+	Rows   int `json:"rows"`
+	Assets int `json:"assets"`
+}
+
+type writer struct {
+	encoder *json.Encoder
+	closers []io.Closer
+	trailer trailer
+}
+
+// createFile creates the export file, it's gzipped if the name ends with .gz
+func createFile(name string) (*writer, error) {
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INST-Fl1Cr", "unable to create file")
+	}
+	if !strings.HasSuffix(name, ".gz") {
+		return newWriter(file, file), nil
+	}
+	compressed := gzip.NewWriter(file)
+	return newWriter(compressed, compressed, file), nil
+}
+
+func newWriter(w io.Writer, closers ...io.Closer) *writer {
+	return &writer{encoder: json.NewEncoder(w), closers: closers}
+}
+
+func (w *writer) write(r *record) error {
+	switch r.Type {
+	case recordTypeEvents:
+		w.trailer.Events += len(r.Events)
+	case recordTypeRows:
+		w.trailer.Rows += len(r.Rows)
+	case recordTypeAsset:
+		w.trailer.Assets++
+	}
+	if err := w.encoder.Encode(r); err != nil {
+		return zerrors.ThrowInternalf(err, "INST-Fl2Wr", "unable to write %s", r.Type)
+	}
+	return nil
+}
+
+// close writes the trailer and closes the file
+func (w *writer) close() (err error) {
+	trailer := w.trailer
+	err = w.write(&record{Type: recordTypeTrailer, Trailer: &trailer})
+	for _, closer := range w.closers {
+		if closeErr := closer.Close(); err == nil && closeErr != nil {
+			err = zerrors.ThrowInternal(closeErr, "INST-Fl3Cl", "unable to close file")
+		}
+	}
+	return err
+}
+
+type reader struct {
+	decoder *json.Decoder
+	closers []io.Closer
+	counts  trailer
+	done    bool
+}
+
+// openFile opens the export file, it's gunzipped if the name ends with .gz
+func openFile(name string) (*reader, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INST-Fl4Op", "unable to open file")
+	}
+	if !strings.HasSuffix(name, ".gz") {
+		return newReader(file, file), nil
+	}
+	decompressed, err := gzip.NewReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, zerrors.ThrowInvalidArgument(err, "INST-Fl5Gz", "file is not gzipped")
+	}
+	return newReader(decompressed, decompressed, file), nil
+}
+
+func newReader(r io.Reader, closers ...io.Closer) *reader {
+	return &reader{decoder: json.NewDecoder(r), closers: closers}
+}
+
+// readHeader reads the first record of the file
+func (r *reader) readHeader() (*header, error) {
+	first, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	if first == nil || first.Type != recordTypeHeader || first.Header == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "INST-Fl6Hd", "file does not start with a header")
+	}
+	if first.Header.FormatVersion != formatVersion {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "INST-Fl7Vr", "unsupported format version %d", first.Header.FormatVersion)
+	}
+	return first.Header, nil
+}
+
+// read returns the next record, nil is returned after the trailer.
+// The counts of the trailer are checked against the read records.
+func (r *reader) read() (*record, error) {
+	if r.done {
+		return nil, nil
+	}
+	next := new(record)
+	if err := r.decoder.Decode(next); err != nil {
+		if err == io.EOF {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Fl8Tr", "file is truncated")
+		}
+		return nil, zerrors.ThrowInvalidArgument(err, "INST-Fl9Rd", "unable to read record")
+	}
+	switch next.Type {
+	case recordTypeEvents:
+		r.counts.Events += len(next.Events)
+	case recordTypeRows:
+		r.counts.Rows += len(next.Rows)
+	case recordTypeAsset:
+		r.counts.Assets++
+	case recordTypeTrailer:
+		r.done = true
+		if next.Trailer == nil || *next.Trailer != r.counts {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Fl0Ct", "counts of the file don't match its trailer")
+		}
+		return nil, nil
+	}
+	return next, nil
+}
+
+func (r *reader) close() error {
+	for _, closer := range r.closers {
+		if err := closer.Close(); err != nil {
+			return zerrors.ThrowInternal(err, "INST-Fl1Cl", "unable to close file")
+		}
+	}
+	return nil
+}
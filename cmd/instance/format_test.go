@@ -0,0 +1,81 @@
+package instance
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_writer_reader(t *testing.T) {
+	var buf bytes.Buffer
+	w := newWriter(&buf)
+	require.NoError(t, w.write(&record{Type: recordTypeHeader, Header: &header{FormatVersion: formatVersion, InstanceID: "instance", Key: []byte("key")}}))
+	require.NoError(t, w.write(&record{Type: recordTypeEvents, Events: []json.RawMessage{json.RawMessage(`{"sequence":1}`), json.RawMessage(`{"sequence":2}`)}}))
+	require.NoError(t, w.write(&record{Type: recordTypeRows, Table: "unique_constraints", Rows: []json.RawMessage{json.RawMessage(`{"unique_type":"username"}`)}}))
+	require.NoError(t, w.write(&record{Type: recordTypeAsset, Asset: &asset{ResourceOwner: "org", Name: "avatar", ObjectType: static.ObjectTypeUserAvatar, Data: []byte("image")}}))
+	require.NoError(t, w.write(&record{Type: recordTypeProjections, Projections: map[string]int{"users14": 1}}))
+	require.NoError(t, w.close())
+	assert.Equal(t, trailer{Events: 2, Rows: 1, Assets: 1}, w.trailer)
+
+	r := newReader(&buf)
+	h, err := r.readHeader()
+	require.NoError(t, err)
+	assert.Equal(t, "instance", h.InstanceID)
+	assert.Equal(t, []byte("key"), h.Key)
+
+	var types []recordType
+	for {
+		next, err := r.read()
+		require.NoError(t, err)
+		if next == nil {
+			break
+		}
+		types = append(types, next.Type)
+	}
+	assert.Equal(t, []recordType{recordTypeEvents, recordTypeRows, recordTypeAsset, recordTypeProjections}, types)
+	assert.Equal(t, trailer{Events: 2, Rows: 1, Assets: 1}, r.counts)
+}
+
+func Test_reader_invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "no header",
+			input: `{"type":"events","events":[{"sequence":1}]}` + "\n",
+		},
+		{
+			name:  "unsupported version",
+			input: `{"type":"header","header":{"formatVersion":0,"instanceId":"instance"}}` + "\n",
+		},
+		{
+			name:  "truncated",
+			input: `{"type":"header","header":{"formatVersion":1,"instanceId":"instance"}}` + "\n" + `{"type":"events","events":[{"sequence":1}]}` + "\n",
+		},
+		{
+			name: "trailer mismatch",
+			input: `{"type":"header","header":{"formatVersion":1,"instanceId":"instance"}}` + "\n" +
+				`{"type":"events","events":[{"sequence":1}]}` + "\n" +
+				`{"type":"trailer","trailer":{"events":2,"rows":0,"assets":0}}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newReader(bytes.NewBufferString(tt.input))
+			_, err := r.readHeader()
+			for err == nil {
+				var next *record
+				next, err = r.read()
+				require.False(t, err == nil && next == nil, "expected an error before the end of the file")
+			}
+			assert.True(t, zerrors.IsErrorInvalidArgument(err))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package instance
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// rows of other instances are skipped and detected by the count of inserted rows
+	insertEventsStmt = `INSERT INTO eventstore.events2 SELECT * FROM json_populate_recordset(NULL::eventstore.events2, $1::JSON)` +
+		` WHERE instance_id = $2`
+	selectTakenDomainStmt = `SELECT domain FROM projections.instance_domains WHERE domain = ANY($1) AND instance_id <> $2 LIMIT 1`
+)
+
+type importer struct {
+	client       *database.DB
+	storage      static.Storage
+	transcrypter *crypto.Transcrypter
+}
+
+// importInstance writes the records following the header to the target.
+// The instance must not exist on the target.
+func (i *importer) importInstance(ctx context.Context, h *header, in *reader) error {
+	if err := i.checkNew(ctx, h); err != nil {
+		return err
+	}
+	tables, err := eventstoreTables(ctx, i.client)
+	if err != nil {
+		return err
+	}
+	for {
+		next, err := in.read()
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			return nil
+		}
+		switch next.Type {
+		case recordTypeEvents:
+			err = i.insert(ctx, h.InstanceID, "eventstore.events2", insertEventsStmt, next.Events)
+		case recordTypeRows:
+			err = i.importRows(ctx, h.InstanceID, tables, next)
+		case recordTypeAsset:
+			err = i.importAsset(ctx, h.InstanceID, next.Asset)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// checkNew ensures that neither the instance nor one of its domains exists on the target
+func (i *importer) checkNew(ctx context.Context, h *header) error {
+	events, err := countEvents(ctx, i.client, h.InstanceID)
+	if err != nil {
+		return err
+	}
+	if events > 0 {
+		return zerrors.ThrowAlreadyExistsf(nil, "INST-Im1Ex", "instance %s already exists on the target", h.InstanceID)
+	}
+	if len(h.Domains) == 0 {
+		return nil
+	}
+	for _, shard := range i.client.Shards() {
+		var taken string
+		err = i.client.QueryRowContext(database.WithShard(ctx, shard), func(row *sql.Row) error {
+			return row.Scan(&taken)
+		}, selectTakenDomainStmt, database.TextArray[string](h.Domains), h.InstanceID)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return zerrors.ThrowInternal(err, "INST-Im2Dm", "unable to check domains")
+		}
+		return zerrors.ThrowAlreadyExistsf(nil, "INST-Im3Dm", "domain %s is used by another instance on the target", taken)
+	}
+	return nil
+}
+
+func (i *importer) importRows(ctx context.Context, instanceID string, tables []*table, r *record) error {
+	idx := slices.IndexFunc(tables, func(t *table) bool {
+		return t.name == r.Table
+	})
+	if idx < 0 {
+		return zerrors.ThrowInvalidArgumentf(nil, "INST-Im4Tb", "table %s does not exist on the target", r.Table)
+	}
+	t := tables[idx]
+	insertStmt := "INSERT INTO " + t.identifier() + " (" + t.columnList() + ") SELECT " + t.columnList() +
+		" FROM json_populate_recordset(NULL::" + t.identifier() + ", $1::JSON) WHERE instance_id = $2"
+	return i.insert(ctx, instanceID, t.identifier(), insertStmt, r.Rows)
+}
+
+// insert encrypts the secrets of the rows by the keys of the target and inserts them
+func (i *importer) insert(ctx context.Context, instanceID, table, stmt string, rows []json.RawMessage) error {
+	objects := make([]string, len(rows))
+	for idx, row := range rows {
+		transcrypted, changed, err := i.transcrypter.TranscryptJSON(row)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			transcrypted = row
+		}
+		objects[idx] = string(transcrypted)
+	}
+	res, err := i.client.ExecContext(ctx, stmt, "["+strings.Join(objects, ",")+"]", instanceID)
+	if err != nil {
+		return zerrors.ThrowInternalf(err, "INST-Im5Wr", "unable to insert into %s", table)
+	}
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return zerrors.ThrowInternalf(err, "INST-Im6Wr", "unable to insert into %s", table)
+	}
+	if int(inserted) != len(rows) {
+		return zerrors.ThrowInvalidArgumentf(nil, "INST-Im7Ct", "%d rows of %s belong to another instance", len(rows)-int(inserted), table)
+	}
+	return nil
+}
+
+func (i *importer) importAsset(ctx context.Context, instanceID string, a *asset) error {
+	if a == nil {
+		return zerrors.ThrowInvalidArgument(nil, "INST-Im8As", "asset record is empty")
+	}
+	_, err := i.storage.PutObject(ctx, instanceID, "", a.ResourceOwner, a.Name, a.ContentType, a.ObjectType, bytes.NewReader(a.Data), int64(len(a.Data)))
+	return err
+}
@@ -0,0 +1,245 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	flagBatchSize       = "batch-size"
+	flagTargetMasterKey = "target-masterkey"
+	flagTargetKMS       = "target-kms"
+	flagShard           = "shard"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "instance",
+		Short: "migrate instances between deployments of ZITADEL",
+		Long: `exports an instance with its events, assets and secrets into a file and imports it into another deployment.
+The secrets are re-encrypted by the encryption keys of the target.`,
+	}
+	key.AddMasterKeyFlag(cmd)
+	cmd.AddCommand(
+		newExport(),
+		newImport(),
+		newVerify(),
+	)
+	return cmd
+}
+
+func newExport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <instance-id> <file>",
+		Short: "exports an instance into a file",
+		Long: `exports the events, the eventstore rows, the assets and the counts of the projected rows of the instance.
+Events and rows are streamed in chunks of the batch size, the file is gzipped if its name ends with .gz.
+The secrets are encrypted by a key generated for the export which is encrypted by the masterkey of the target.
+If the target wraps its keys by the same KMS key as the source, the export key can be wrapped by the KMS instead.
+The events, the rows and the counts are read from one snapshot of the database, so the instance can be exported while it's in use.
+Only the assets are read after the snapshot was taken.`,
+		Example: `zitadel instance export 840498034930840 instance.ndjson.gz --masterkey <source> --target-masterkey <target>
+zitadel instance export 840498034930840 instance.ndjson.gz --target-kms`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKeyOrKMS(cmd, config.KMS)
+			if err != nil {
+				return err
+			}
+			targetMasterKey, _ := cmd.Flags().GetString(flagTargetMasterKey)
+			if targetMasterKey == "" {
+				targetMasterKey = masterKey
+			}
+			provider, err := config.KMS.NewProvider()
+			if err != nil {
+				return err
+			}
+			var targetProvider kms.Provider
+			if targetKMS, _ := cmd.Flags().GetBool(flagTargetKMS); targetKMS {
+				if provider == nil {
+					return zerrors.ThrowInvalidArgument(nil, "INST-Cm5Km", "no kms configured to wrap the export key")
+				}
+				targetProvider = provider
+			}
+			exportKey, err := newExportKey()
+			if err != nil {
+				return err
+			}
+			encryptedKey, keyProvider, err := encryptExportKey(cmd.Context(), targetProvider, targetMasterKey, args[0], exportKey)
+			if err != nil {
+				return err
+			}
+			client, err := connect(config)
+			if err != nil {
+				return err
+			}
+			storage, err := cryptoDB.NewKeyStorage(client, masterKey, provider)
+			if err != nil {
+				return err
+			}
+			transcrypter, err := exportTranscrypter(config.EncryptionKeys, storage, exportKey)
+			if err != nil {
+				return err
+			}
+			assets, err := config.AssetStorage.NewStorage(client)
+			if err != nil {
+				return err
+			}
+			out, err := createFile(args[1])
+			if err != nil {
+				return err
+			}
+			batchSize, _ := cmd.Flags().GetUint32(flagBatchSize)
+			e := &exporter{
+				client:       client,
+				storage:      assets,
+				transcrypter: transcrypter,
+				batchSize:    batchSize,
+				out:          out,
+			}
+			err = e.export(instanceContext(cmd.Context(), args[0]), args[0], encryptedKey, keyProvider)
+			if closeErr := out.close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+			cmd.Printf("%d event(s), %d row(s) and %d asset(s) exported\n", out.trailer.Events, out.trailer.Rows, out.trailer.Assets)
+			return nil
+		},
+	}
+	cmd.Flags().Uint32(flagBatchSize, 1000, "count of events and rows per chunk")
+	cmd.Flags().String(flagTargetMasterKey, "", "masterkey of the target deployment, the masterkey of the source is used if omitted")
+	cmd.Flags().Bool(flagTargetKMS, false, "wraps the export key by the configured KMS instead of the masterkey, the target must use the same KMS key")
+	return cmd
+}
+
+func newImport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "imports an exported instance",
+		Long: `imports the events, the eventstore rows and the assets of an exported instance.
+The instance and its domains must not exist on the target. The masterkey must be the target masterkey of the export,
+it's not required if the export key is wrapped by the KMS of the target.
+The projections are built by ZITADEL as soon as the instance is imported, verify the import afterwards.
+If the import fails, the partially imported instance must be removed before it's retried.`,
+		Example: `zitadel instance import instance.ndjson.gz --masterkey <target>`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKeyOrKMS(cmd, config.KMS)
+			if err != nil {
+				return err
+			}
+			provider, err := config.KMS.NewProvider()
+			if err != nil {
+				return err
+			}
+			in, err := openFile(args[0])
+			if err != nil {
+				return err
+			}
+			defer in.close()
+			h, err := in.readHeader()
+			if err != nil {
+				return err
+			}
+			exportKey, err := decryptExportKey(cmd.Context(), provider, masterKey, h)
+			if err != nil {
+				return err
+			}
+			client, err := connect(config)
+			if err != nil {
+				return err
+			}
+			storage, err := cryptoDB.NewKeyStorage(client, masterKey, provider)
+			if err != nil {
+				return err
+			}
+			transcrypter, err := importTranscrypter(config.EncryptionKeys, storage, exportKey)
+			if err != nil {
+				return err
+			}
+			assets, err := config.AssetStorage.NewStorage(client)
+			if err != nil {
+				return err
+			}
+			ctx := instanceContext(cmd.Context(), h.InstanceID)
+			if shard, _ := cmd.Flags().GetString(flagShard); shard != "" {
+				if err = client.SetPlacement(ctx, &database.Placement{InstanceID: h.InstanceID, Shard: shard}); err != nil {
+					return err
+				}
+			}
+			i := &importer{
+				client:       client,
+				storage:      assets,
+				transcrypter: transcrypter,
+			}
+			if err = i.importInstance(ctx, h, in); err != nil {
+				return err
+			}
+			cmd.Printf("instance %s imported: %d event(s), %d row(s) and %d asset(s)\n", h.InstanceID, in.counts.Events, in.counts.Rows, in.counts.Assets)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagShard, "", "shard the instance is placed on, the default shard is used if omitted")
+	return cmd
+}
+
+func newVerify() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify <file>",
+		Short: "compares an imported instance with its export",
+		Long: `compares the count of events, the sequences of the aggregates and the counts of the projected rows of the imported instance with the export.
+The projections of the target must have reduced the imported events, e.g. after ZITADEL served the instance.`,
+		Example: `zitadel instance verify instance.ndjson.gz`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			in, err := openFile(args[0])
+			if err != nil {
+				return err
+			}
+			defer in.close()
+			client, err := connect(config)
+			if err != nil {
+				return err
+			}
+			result, err := verify(database.WithPrimary(cmd.Context()), client, in)
+			if err != nil {
+				return err
+			}
+			result.print(cmd.OutOrStdout())
+			if !result.ok() {
+				return zerrors.ThrowInternal(nil, "INST-Cm4Vf", "imported instance differs from the export")
+			}
+			cmd.Println("imported instance matches the export")
+			return nil
+		},
+	}
+}
+
+func connect(config *Config) (*database.DB, error) {
+	return database.ConnectSharded(config.Database, config.Sharding, dialect.DBPurposeQuery, instanceID)
+}
+
+// instanceContext routes the queries to the primary of the shard the instance is placed on
+func instanceContext(ctx context.Context, instanceID string) context.Context {
+	return database.WithPrimary(authz.WithInstanceID(ctx, instanceID))
+}
+
+// instanceID resolves the instance of the context to route its queries to the shard the instance is placed on
+func instanceID(ctx context.Context) string {
+	return authz.GetInstance(ctx).InstanceID()
+}
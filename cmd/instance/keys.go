@@ -0,0 +1,130 @@
+package instance
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// exportKeyIDPrefix is prepended to the name of the key config to build the key id of the exported secrets
+const exportKeyIDPrefix = "exported."
+
+type namedKeyConfig struct {
+	name   string
+	config *crypto.KeyConfig
+}
+
+// keyConfigs returns the configured keys in a stable order,
+// because secrets of keys shared by multiple configs are exported with the key of the first config
+func keyConfigs(config *encryption.EncryptionKeyConfig) ([]*namedKeyConfig, error) {
+	if config == nil {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "INST-Ky1Cf", "no encryption keys configured")
+	}
+	configs := []*namedKeyConfig{
+		{name: "domainVerification", config: config.DomainVerification},
+		{name: "idpConfig", config: config.IDPConfig},
+		{name: "oidc", config: config.OIDC},
+		{name: "saml", config: config.SAML},
+		{name: "otp", config: config.OTP},
+		{name: "sms", config: config.SMS},
+		{name: "smtp", config: config.SMTP},
+		{name: "user", config: config.User},
+		{name: "personalData", config: config.PersonalData},
+	}
+	named := make([]*namedKeyConfig, 0, len(configs))
+	for _, config := range configs {
+		if config.config != nil {
+			named = append(named, config)
+		}
+	}
+	return named, nil
+}
+
+// newExportKey generates the key which encrypts the secrets of an export
+func newExportKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", zerrors.ThrowInternal(err, "INST-Ky2Gn", "unable to generate export key")
+	}
+	return string(key), nil
+}
+
+// encryptExportKey wraps the export key by the KMS if a provider is passed, otherwise it's encrypted by the masterkey of the target.
+// The name of the provider is returned so the import knows how to decrypt the key.
+func encryptExportKey(ctx context.Context, provider kms.Provider, targetMasterKey, instanceID, exportKey string) (_ []byte, keyProvider string, err error) {
+	if provider != nil {
+		wrapped, err := provider.Wrap(ctx, exportKeyIDPrefix+instanceID, []byte(exportKey))
+		if err != nil {
+			return nil, "", zerrors.ThrowInternal(err, "INST-Ky3Wr", "unable to wrap the export key by the kms")
+		}
+		return wrapped, provider.Name(), nil
+	}
+	if targetMasterKey == "" {
+		return nil, "", zerrors.ThrowInvalidArgument(nil, "INST-Cm1Mk", "the masterkey of the target is required")
+	}
+	encrypted, err := crypto.EncryptAES([]byte(exportKey), targetMasterKey)
+	if err != nil {
+		return nil, "", zerrors.ThrowInvalidArgument(err, "INST-Cm2Mk", "unable to encrypt the export key by the masterkey of the target")
+	}
+	return encrypted, "", nil
+}
+
+// decryptExportKey unwraps the export key by the KMS of the target if it was wrapped by a KMS, otherwise it's decrypted by the masterkey
+func decryptExportKey(ctx context.Context, provider kms.Provider, masterKey string, h *header) (string, error) {
+	if h.KeyProvider != "" {
+		if provider == nil || provider.Name() != h.KeyProvider {
+			return "", zerrors.ThrowPreconditionFailedf(nil, "INST-Ky4Kp", "the export key is wrapped by kms provider %s which is not configured", h.KeyProvider)
+		}
+		exportKey, err := provider.Unwrap(ctx, exportKeyIDPrefix+h.InstanceID, h.Key)
+		if err != nil {
+			return "", zerrors.ThrowInvalidArgument(err, "INST-Ky5Uw", "unable to unwrap the export key, the kms key must be the one of the export")
+		}
+		return string(exportKey), nil
+	}
+	if masterKey == "" {
+		return "", zerrors.ThrowInvalidArgument(nil, "INST-Ky6Mk", "the export key is encrypted by a masterkey, the masterkey is required")
+	}
+	exportKey, err := crypto.DecryptAES(h.Key, masterKey)
+	if err != nil {
+		return "", zerrors.ThrowInvalidArgument(err, "INST-Cm3Mk", "unable to decrypt the export key, the masterkey must be the target masterkey of the export")
+	}
+	return string(exportKey), nil
+}
+
+// exportTranscrypter encrypts the secrets of the source by the export key
+func exportTranscrypter(config *encryption.EncryptionKeyConfig, storage crypto.KeyStorage, exportKey string) (*crypto.Transcrypter, error) {
+	configs, err := keyConfigs(config)
+	if err != nil {
+		return nil, err
+	}
+	transcrypter := crypto.NewTranscrypter()
+	for _, config := range configs {
+		alg, err := crypto.NewAESCrypto(config.config, storage)
+		if err != nil {
+			return nil, err
+		}
+		transcrypter.Map(alg, crypto.NewStaticAESCrypto(exportKeyIDPrefix+config.name, exportKey))
+	}
+	return transcrypter, nil
+}
+
+// importTranscrypter encrypts the exported secrets by the keys of the target
+func importTranscrypter(config *encryption.EncryptionKeyConfig, storage crypto.KeyStorage, exportKey string) (*crypto.Transcrypter, error) {
+	configs, err := keyConfigs(config)
+	if err != nil {
+		return nil, err
+	}
+	transcrypter := crypto.NewTranscrypter()
+	for _, config := range configs {
+		alg, err := crypto.NewAESCrypto(config.config, storage)
+		if err != nil {
+			return nil, err
+		}
+		transcrypter.Map(crypto.NewStaticAESCrypto(exportKeyIDPrefix+config.name, exportKey), alg)
+	}
+	return transcrypter, nil
+}
@@ -0,0 +1,154 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+type testKeyStorage crypto.Keys
+
+func (s testKeyStorage) ReadKeys() (crypto.Keys, error) {
+	return crypto.Keys(s), nil
+}
+
+func (s testKeyStorage) ReadKey(id string) (*crypto.Key, error) {
+	return &crypto.Key{ID: id, Value: s[id]}, nil
+}
+
+func (s testKeyStorage) CreateKeys(context.Context, ...*crypto.Key) error {
+	return nil
+}
+
+func Test_transcrypters(t *testing.T) {
+	sourceKeys := testKeyStorage{"sourceUserKey": "sourcepassphrasewhichneedstobe32"}
+	source := &encryption.EncryptionKeyConfig{User: &crypto.KeyConfig{EncryptionKeyID: "sourceUserKey"}}
+	targetKeys := testKeyStorage{"targetUserKey": "targetpassphrasewhichneedstobe32"}
+	target := &encryption.EncryptionKeyConfig{User: &crypto.KeyConfig{EncryptionKeyID: "targetUserKey"}}
+
+	sourceAlg, err := crypto.NewAESCrypto(source.User, sourceKeys)
+	require.NoError(t, err)
+	secret, err := crypto.Encrypt([]byte("secret"), sourceAlg)
+	require.NoError(t, err)
+	event, err := json.Marshal(map[string]any{"payload": map[string]any{"secret": secret}})
+	require.NoError(t, err)
+
+	exportKey, err := newExportKey()
+	require.NoError(t, err)
+	exporter, err := exportTranscrypter(source, sourceKeys, exportKey)
+	require.NoError(t, err)
+	exported, changed, err := exporter.TranscryptJSON(event)
+	require.NoError(t, err)
+	require.True(t, changed)
+	assert.Contains(t, string(exported), exportKeyIDPrefix+"user")
+
+	importer, err := importTranscrypter(target, targetKeys, exportKey)
+	require.NoError(t, err)
+	imported, changed, err := importer.TranscryptJSON(exported)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	var parsed struct {
+		Payload struct {
+			Secret *crypto.CryptoValue `json:"secret"`
+		} `json:"payload"`
+	}
+	require.NoError(t, json.Unmarshal(imported, &parsed))
+	assert.Equal(t, "targetUserKey", parsed.Payload.Secret.KeyID)
+	targetAlg, err := crypto.NewAESCrypto(target.User, targetKeys)
+	require.NoError(t, err)
+	decrypted, err := crypto.DecryptString(parsed.Payload.Secret, targetAlg)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	_, _, err = importer.TranscryptJSON(event)
+	assert.Error(t, err, "secrets of the source must not be imported")
+}
+
+func Test_keyConfigs(t *testing.T) {
+	_, err := keyConfigs(nil)
+	assert.Error(t, err)
+
+	configs, err := keyConfigs(&encryption.EncryptionKeyConfig{
+		OIDC: &crypto.KeyConfig{EncryptionKeyID: "oidc"},
+		User: &crypto.KeyConfig{EncryptionKeyID: "user"},
+	})
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.Equal(t, "oidc", configs[0].name)
+	assert.Equal(t, "user", configs[1].name)
+}
+
+// testProvider reverses the key material instead of wrapping it by a key encryption key
+type testProvider string
+
+func (p testProvider) Name() string {
+	return string(p)
+}
+
+func (p testProvider) Wrap(_ context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	return []byte(keyID + ":" + reverse(string(plaintext))), nil
+}
+
+func (p testProvider) Unwrap(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	plaintext, ok := strings.CutPrefix(string(wrapped), keyID+":")
+	if !ok {
+		return nil, assert.AnError
+	}
+	return []byte(reverse(plaintext)), nil
+}
+
+func reverse(s string) string {
+	reversed := []byte(s)
+	slices.Reverse(reversed)
+	return string(reversed)
+}
+
+func Test_exportKey(t *testing.T) {
+	ctx := context.Background()
+	exportKey, err := newExportKey()
+	require.NoError(t, err)
+
+	t.Run("masterkey", func(t *testing.T) {
+		encrypted, keyProvider, err := encryptExportKey(ctx, nil, "targetmasterkeywhichneedstobe32b", "instance", exportKey)
+		require.NoError(t, err)
+		assert.Empty(t, keyProvider)
+		h := &header{InstanceID: "instance", Key: encrypted, KeyProvider: keyProvider}
+
+		decrypted, err := decryptExportKey(ctx, testProvider("vault"), "targetmasterkeywhichneedstobe32b", h)
+		require.NoError(t, err)
+		assert.Equal(t, exportKey, decrypted)
+
+		_, err = decryptExportKey(ctx, testProvider("vault"), "", h)
+		assert.Error(t, err, "masterkey of the target is required")
+	})
+	t.Run("masterkey missing", func(t *testing.T) {
+		_, _, err := encryptExportKey(ctx, nil, "", "instance", exportKey)
+		assert.Error(t, err)
+	})
+	t.Run("kms", func(t *testing.T) {
+		wrapped, keyProvider, err := encryptExportKey(ctx, testProvider("vault"), "", "instance", exportKey)
+		require.NoError(t, err)
+		assert.Equal(t, "vault", keyProvider)
+		h := &header{InstanceID: "instance", Key: wrapped, KeyProvider: keyProvider}
+
+		decrypted, err := decryptExportKey(ctx, testProvider("vault"), "", h)
+		require.NoError(t, err)
+		assert.Equal(t, exportKey, decrypted)
+
+		_, err = decryptExportKey(ctx, nil, "targetmasterkeywhichneedstobe32b", h)
+		assert.Error(t, err, "kms of the target is required")
+		_, err = decryptExportKey(ctx, testProvider("pkcs11"), "", h)
+		assert.Error(t, err, "kms provider of the target must match")
+		_, err = decryptExportKey(ctx, testProvider("vault"), "", &header{InstanceID: "other", Key: wrapped, KeyProvider: keyProvider})
+		assert.Error(t, err, "export key of another instance")
+	})
+}
@@ -0,0 +1,219 @@
+package instance
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// the tables of the handlers don't contain projected rows
+	selectProjectionTablesStmt = `SELECT c.table_name FROM information_schema.columns c` +
+		` JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name` +
+		` WHERE t.table_type = 'BASE TABLE' AND c.table_schema = 'projections' AND c.column_name = 'instance_id'` +
+		` AND c.table_name NOT IN ('current_states', 'current_sequences', 'locks', 'failed_events')` +
+		` ORDER BY c.table_name`
+	countEventsStmt      = `SELECT COUNT(*) FROM eventstore.events2 WHERE instance_id = $1`
+	selectAggregatesStmt = `SELECT aggregate_type, aggregate_id, MAX("sequence") FROM eventstore.events2` +
+		` WHERE instance_id = $1 GROUP BY aggregate_type, aggregate_id`
+)
+
+// projectionCounts returns the count of projected rows of the instance per table.
+// Shadow and retired tables of rebuilds are ignored.
+func projectionCounts(ctx context.Context, client querier, instanceID string) (map[string]int, error) {
+	var tables []string
+	err := client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			if !strings.HasSuffix(name, "_shadow") && !strings.HasSuffix(name, "_retired") {
+				tables = append(tables, name)
+			}
+		}
+		return rows.Err()
+	}, selectProjectionTablesStmt)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INST-Vf1Tb", "unable to read projections")
+	}
+	counts := make(map[string]int, len(tables))
+	for _, name := range tables {
+		var count int
+		err = client.QueryRowContext(ctx, func(row *sql.Row) error {
+			return row.Scan(&count)
+		}, "SELECT COUNT(*) FROM projections."+quoteIdentifier(name)+" WHERE instance_id = $1", instanceID)
+		if err != nil {
+			return nil, zerrors.ThrowInternalf(err, "INST-Vf2Ct", "unable to count rows of %s", name)
+		}
+		counts[name] = count
+	}
+	return counts, nil
+}
+
+func countEvents(ctx context.Context, client *database.DB, instanceID string) (count int, err error) {
+	err = client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&count)
+	}, countEventsStmt, instanceID)
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "INST-Vf3Ev", "unable to count events")
+	}
+	return count, nil
+}
+
+// aggregateSequences returns the sequence of the latest event per aggregate of the instance
+func aggregateSequences(ctx context.Context, client *database.DB, instanceID string) (map[string]int, error) {
+	sequences := make(map[string]int)
+	err := client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var (
+				aggregateType, aggregateID string
+				sequence                   int
+			)
+			if err := rows.Scan(&aggregateType, &aggregateID, &sequence); err != nil {
+				return err
+			}
+			sequences[aggregateName(aggregateType, aggregateID)] = sequence
+		}
+		return rows.Err()
+	}, selectAggregatesStmt, instanceID)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INST-Vf4Ag", "unable to read aggregates")
+	}
+	return sequences, nil
+}
+
+func aggregateName(aggregateType, aggregateID string) string {
+	return "aggregate " + aggregateType + " " + aggregateID
+}
+
+// exportedEvent contains the fields of an exported event which identify its aggregate
+type exportedEvent struct {
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+	Sequence      int    `json:"sequence"`
+}
+
+// addSequences sets the sequence of the latest event per aggregate of the exported events
+func addSequences(sequences map[string]int, events []json.RawMessage) error {
+	for _, raw := range events {
+		var event exportedEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "INST-Vf5Ev", "unable to read exported event")
+		}
+		name := aggregateName(event.AggregateType, event.AggregateID)
+		if event.Sequence > sequences[name] {
+			sequences[name] = event.Sequence
+		}
+	}
+	return nil
+}
+
+type difference struct {
+	name           string
+	source, target int
+}
+
+// verification is the result of comparing the export with the imported instance
+type verification struct {
+	differences []*difference
+	// missing contains the projections which don't exist on the target, e.g. because of another version
+	missing []string
+}
+
+func (v *verification) ok() bool {
+	return len(v.differences) == 0 && len(v.missing) == 0
+}
+
+func (v *verification) print(w io.Writer) {
+	for _, d := range v.differences {
+		fmt.Fprintf(w, "%s: %d exported, %d imported\n", d.name, d.source, d.target)
+	}
+	for _, name := range v.missing {
+		fmt.Fprintf(w, "%s: missing on target\n", name)
+	}
+}
+
+// compare compares the counts and the sequences of the aggregates of the export with the ones of the target.
+// Aggregates missing on the target are reported with sequence 0.
+func compare(events, targetEvents int, sequences, targetSequences, projections, targetProjections map[string]int) *verification {
+	v := new(verification)
+	if events != targetEvents {
+		v.differences = append(v.differences, &difference{name: "events", source: events, target: targetEvents})
+	}
+	aggregates := make([]string, 0, len(sequences))
+	for name := range sequences {
+		aggregates = append(aggregates, name)
+	}
+	slices.Sort(aggregates)
+	for _, name := range aggregates {
+		if sequences[name] != targetSequences[name] {
+			v.differences = append(v.differences, &difference{name: name, source: sequences[name], target: targetSequences[name]})
+		}
+	}
+	names := make([]string, 0, len(projections))
+	for name := range projections {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		target, ok := targetProjections[name]
+		if !ok {
+			v.missing = append(v.missing, name)
+			continue
+		}
+		if projections[name] != target {
+			v.differences = append(v.differences, &difference{name: name, source: projections[name], target: target})
+		}
+	}
+	return v
+}
+
+// verify reads the counts and the sequences of the aggregates of the export and compares them with the imported instance
+func verify(ctx context.Context, client *database.DB, in *reader) (*verification, error) {
+	h, err := in.readHeader()
+	if err != nil {
+		return nil, err
+	}
+	ctx = authz.WithInstanceID(ctx, h.InstanceID)
+	var projections map[string]int
+	sequences := make(map[string]int)
+	for {
+		next, err := in.read()
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			break
+		}
+		switch next.Type {
+		case recordTypeEvents:
+			if err = addSequences(sequences, next.Events); err != nil {
+				return nil, err
+			}
+		case recordTypeProjections:
+			projections = next.Projections
+		}
+	}
+	targetEvents, err := countEvents(ctx, client, h.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	targetSequences, err := aggregateSequences(ctx, client, h.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	targetProjections, err := projectionCounts(ctx, client, h.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	return compare(in.counts.Events, targetEvents, sequences, targetSequences, projections, targetProjections), nil
+}
@@ -0,0 +1,72 @@
+package instance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_compare(t *testing.T) {
+	tests := []struct {
+		name              string
+		events            int
+		targetEvents      int
+		sequences         map[string]int
+		targetSequences   map[string]int
+		projections       map[string]int
+		targetProjections map[string]int
+		want              *verification
+	}{
+		{
+			name:              "equal",
+			events:            3,
+			targetEvents:      3,
+			sequences:         map[string]int{"aggregate user 1": 2, "aggregate org 2": 1},
+			targetSequences:   map[string]int{"aggregate user 1": 2, "aggregate org 2": 1},
+			projections:       map[string]int{"users14": 2, "orgs1": 1},
+			targetProjections: map[string]int{"users14": 2, "orgs1": 1, "new_projection": 0},
+			want:              &verification{},
+		},
+		{
+			name:              "differences",
+			events:            3,
+			targetEvents:      2,
+			sequences:         map[string]int{"aggregate user 1": 1, "aggregate user 3": 1, "aggregate org 2": 1},
+			targetSequences:   map[string]int{"aggregate user 1": 1, "aggregate org 2": 2},
+			projections:       map[string]int{"users14": 2, "orgs1": 1, "apps7": 1},
+			targetProjections: map[string]int{"users14": 1, "orgs1": 1},
+			want: &verification{
+				differences: []*difference{
+					{name: "events", source: 3, target: 2},
+					{name: "aggregate org 2", source: 1, target: 2},
+					{name: "aggregate user 3", source: 1, target: 0},
+					{name: "users14", source: 2, target: 1},
+				},
+				missing: []string{"apps7"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compare(tt.events, tt.targetEvents, tt.sequences, tt.targetSequences, tt.projections, tt.targetProjections)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, len(tt.want.differences) == 0 && len(tt.want.missing) == 0, got.ok())
+		})
+	}
+}
+
+func Test_addSequences(t *testing.T) {
+	sequences := map[string]int{"aggregate user 1": 1}
+	err := addSequences(sequences, []json.RawMessage{
+		json.RawMessage(`{"aggregate_type":"user","aggregate_id":"1","sequence":2,"payload":{"userName":"gigi"}}`),
+		json.RawMessage(`{"aggregate_type":"org","aggregate_id":"1","sequence":1}`),
+		json.RawMessage(`{"aggregate_type":"user","aggregate_id":"1","sequence":3}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"aggregate user 1": 3, "aggregate org 1": 1}, sequences)
+
+	err = addSequences(sequences, []json.RawMessage{json.RawMessage(`[]`)})
+	assert.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/database/dialect"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -22,6 +23,7 @@ const (
 
 type Config struct {
 	Database database.Config
+	KMS      *kms.Config
 }
 
 func New() *cobra.Command {
@@ -31,6 +33,8 @@ func New() *cobra.Command {
 	}
 	AddMasterKeyFlag(cmd)
 	cmd.AddCommand(newKey())
+	cmd.AddCommand(newWrap())
+	cmd.AddCommand(newReencrypt())
 	return cmd
 }
 
@@ -38,7 +42,7 @@ func newKey() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "new [keyID=key]... [-f file]",
 		Short: "create new encryption key(s)",
-		Long: `create new encryption key(s) (encrypted by the provided master key)
+		Long: `create new encryption key(s) (encrypted by the provided master key or wrapped by the configured KMS)
 provide key(s) by YAML file and/or by argument
 Requirements:
 - cockroachdb`,
@@ -66,11 +70,11 @@ new -f keys.yaml key2=anotherkey`,
 			if err := viper.Unmarshal(config); err != nil {
 				return err
 			}
-			masterKey, err := MasterKey(cmd)
+			masterKey, err := MasterKeyOrKMS(cmd, config.KMS)
 			if err != nil {
 				return err
 			}
-			storage, err := keyStorage(config.Database, masterKey)
+			storage, err := keyStorage(config, masterKey)
 			if err != nil {
 				return err
 			}
@@ -81,6 +85,40 @@ new -f keys.yaml key2=anotherkey`,
 	return cmd
 }
 
+func newWrap() *cobra.Command {
+	return &cobra.Command{
+		Use:   "wrap",
+		Short: "wrap the encryption keys with the KMS",
+		Long: `wraps the encryption keys encrypted by the provided master key with the configured KMS
+afterwards ZITADEL no longer requires the master key
+Requirements:
+- cockroachdb`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := new(Config)
+			if err := viper.Unmarshal(config); err != nil {
+				return err
+			}
+			if !config.KMS.Enabled() {
+				return zerrors.ThrowPreconditionFailed(nil, "KEY-Kms1P", "no kms provider configured")
+			}
+			masterKey, err := MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			storage, err := keyStorage(config, masterKey)
+			if err != nil {
+				return err
+			}
+			wrapped, err := storage.WrapKeys(cmd.Context())
+			if err != nil {
+				return err
+			}
+			cmd.Printf("%d key(s) wrapped by %s\n", wrapped, config.KMS.Provider)
+			return nil
+		},
+	}
+}
+
 func keysFromArgs(args []string) ([]*crypto.Key, error) {
 	keys := make([]*crypto.Key, len(args))
 	for i, arg := range args {
@@ -123,10 +161,14 @@ func openFile(fileName string) (io.Reader, error) {
 	return file, nil
 }
 
-func keyStorage(config database.Config, masterKey string) (crypto.KeyStorage, error) {
-	db, err := database.Connect(config, false, dialect.DBPurposeQuery)
+func keyStorage(config *Config, masterKey string) (*cryptoDB.Database, error) {
+	provider, err := config.KMS.NewProvider()
+	if err != nil {
+		return nil, err
+	}
+	db, err := database.Connect(config.Database, false, dialect.DBPurposeQuery)
 	if err != nil {
 		return nil, err
 	}
-	return cryptoDB.NewKeyStorage(db, masterKey)
+	return cryptoDB.NewKeyStorage(db, masterKey, provider)
 }
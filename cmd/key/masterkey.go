@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 )
 
 const (
@@ -49,6 +51,19 @@ func MasterKey(cmd *cobra.Command) (string, error) {
 	return string(data), nil
 }
 
+// MasterKeyOrKMS returns the masterkey like [MasterKey].
+// If the encryption keys are wrapped by a KMS the masterkey is optional
+// and only required to read the encryption keys which are not wrapped yet.
+func MasterKeyOrKMS(cmd *cobra.Command, config *kms.Config) (string, error) {
+	masterKeyFile, _ := cmd.Flags().GetString(flagMasterKey)
+	masterKeyFromArg, _ := cmd.Flags().GetString(flagMasterKeyArg)
+	masterKeyFromEnv, _ := cmd.Flags().GetBool(flagMasterKeyEnv)
+	if config.Enabled() && masterKeyFile == "" && masterKeyFromArg == "" && !masterKeyFromEnv {
+		return "", nil
+	}
+	return MasterKey(cmd)
+}
+
 func checkSingleFlag(masterKeyFile, masterKeyFromArg string, masterKeyFromEnv bool) error {
 	var flags int
 	if masterKeyFile != "" {
@@ -0,0 +1,261 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	flagBatchSize = "batch-size"
+)
+
+type ReencryptConfig struct {
+	Database       database.Config
+	KMS            *kms.Config
+	EncryptionKeys *encryption.EncryptionKeyConfig
+}
+
+func newReencrypt() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reencrypt",
+		Short: "re-encrypt secrets encrypted by retired encryption keys",
+		Long: `encrypts the secrets stored in the events and projections with the current encryption key
+if they are encrypted by a retired key listed in the DecryptionKeyIDs of the EncryptionKeys
+secrets are processed in small transactions, so ZITADEL can keep running
+remove the retired keys from the DecryptionKeyIDs only after the command finished
+Requirements:
+- cockroachdb`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := new(ReencryptConfig)
+			if err := viper.Unmarshal(config); err != nil {
+				return err
+			}
+			masterKey, err := MasterKeyOrKMS(cmd, config.KMS)
+			if err != nil {
+				return err
+			}
+			storage, err := keyStorage(&Config{Database: config.Database, KMS: config.KMS}, masterKey)
+			if err != nil {
+				return err
+			}
+			reencrypter, err := newReencrypter(config.EncryptionKeys, storage)
+			if err != nil {
+				return err
+			}
+			db, err := database.Connect(config.Database, false, dialect.DBPurposeQuery)
+			if err != nil {
+				return err
+			}
+			batchSize, _ := cmd.Flags().GetUint32(flagBatchSize)
+			events, err := reencryptEvents(cmd.Context(), db, reencrypter, batchSize)
+			if err != nil {
+				return err
+			}
+			values, err := reencryptColumns(cmd.Context(), db, reencrypter)
+			if err != nil {
+				return err
+			}
+			cmd.Printf("%d event(s) and %d other value(s) re-encrypted\n", events, values)
+			return nil
+		},
+	}
+	cmd.Flags().Uint32(flagBatchSize, 100, "count of events re-encrypted per transaction")
+	return cmd
+}
+
+func newReencrypter(config *encryption.EncryptionKeyConfig, storage crypto.KeyStorage) (*crypto.Reencrypter, error) {
+	if config == nil {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "KEY-Re1Cf", "no encryption keys configured")
+	}
+	keyConfigs := []*crypto.KeyConfig{
+		config.DomainVerification,
+		config.IDPConfig,
+		config.OIDC,
+		config.SAML,
+		config.OTP,
+		config.SMS,
+		config.SMTP,
+		config.User,
+		config.PersonalData,
+	}
+	algorithms := make([]crypto.EncryptionAlgorithm, 0, len(keyConfigs))
+	for _, keyConfig := range keyConfigs {
+		if keyConfig == nil {
+			continue
+		}
+		alg, err := crypto.NewAESCrypto(keyConfig, storage)
+		if err != nil {
+			return nil, err
+		}
+		algorithms = append(algorithms, alg)
+	}
+	return crypto.NewReencrypter(algorithms...), nil
+}
+
+const (
+	// the payload is only parsed if it could contain an encrypted value
+	selectEventsStmt = `SELECT instance_id, aggregate_type, aggregate_id, "sequence", payload FROM eventstore.events2` +
+		` WHERE payload::TEXT ILIKE '%"keyid"%' AND (instance_id, aggregate_type, aggregate_id, "sequence") > ($1, $2, $3, $4)` +
+		` ORDER BY instance_id, aggregate_type, aggregate_id, "sequence" LIMIT $5`
+	updateEventStmt = `UPDATE eventstore.events2 SET payload = $1` +
+		` WHERE instance_id = $2 AND aggregate_type = $3 AND aggregate_id = $4 AND "sequence" = $5`
+	// events2 is processed in batches by [reencryptEvents]
+	selectJSONColumnsStmt = `SELECT table_schema, table_name, column_name FROM information_schema.columns` +
+		` WHERE table_schema IN ('eventstore', 'projections', 'auth', 'adminapi') AND data_type = 'jsonb'` +
+		` AND NOT (table_schema = 'eventstore' AND table_name = 'events2')` +
+		` ORDER BY table_schema, table_name, column_name`
+)
+
+type eventPosition struct {
+	instanceID    string
+	aggregateType string
+	aggregateID   string
+	sequence      uint64
+}
+
+type reencryptedEvent struct {
+	eventPosition
+	payload []byte
+}
+
+// reencryptEvents re-encrypts the payloads of the events, each batch is updated in its own transaction
+func reencryptEvents(ctx context.Context, db *database.DB, reencrypter *crypto.Reencrypter, batchSize uint32) (count int, err error) {
+	var position eventPosition
+	for {
+		var (
+			events []*reencryptedEvent
+			read   uint32
+		)
+		err = db.QueryContext(ctx, func(rows *sql.Rows) error {
+			for rows.Next() {
+				var payload []byte
+				if err := rows.Scan(&position.instanceID, &position.aggregateType, &position.aggregateID, &position.sequence, &payload); err != nil {
+					return err
+				}
+				read++
+				reencrypted, changed, err := reencrypter.ReencryptJSON(payload)
+				if err != nil {
+					return err
+				}
+				if changed {
+					events = append(events, &reencryptedEvent{eventPosition: position, payload: reencrypted})
+				}
+			}
+			return rows.Err()
+		}, selectEventsStmt, position.instanceID, position.aggregateType, position.aggregateID, position.sequence, batchSize)
+		if err != nil {
+			return count, zerrors.ThrowInternal(err, "KEY-Re2Ev", "unable to read events")
+		}
+		if err = updateEvents(ctx, db, events); err != nil {
+			return count, err
+		}
+		count += len(events)
+		if read < batchSize {
+			return count, nil
+		}
+	}
+}
+
+func updateEvents(ctx context.Context, db *database.DB, events []*reencryptedEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "KEY-Re3Tx", "unable to begin transaction")
+	}
+	for _, event := range events {
+		if _, err = tx.ExecContext(ctx, updateEventStmt, event.payload, event.instanceID, event.aggregateType, event.aggregateID, event.sequence); err != nil {
+			_ = tx.Rollback()
+			return zerrors.ThrowInternal(err, "KEY-Re4Ev", "unable to update event")
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return zerrors.ThrowInternal(err, "KEY-Re5Cm", "unable to commit transaction")
+	}
+	return nil
+}
+
+type jsonColumn struct {
+	schema, table, column string
+}
+
+// reencryptColumns re-encrypts the values of all other json columns, e.g. secrets in projections.
+// Each value is replaced only if it wasn't changed in the meantime.
+func reencryptColumns(ctx context.Context, db *database.DB, reencrypter *crypto.Reencrypter) (count int, err error) {
+	var columns []*jsonColumn
+	err = db.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			column := new(jsonColumn)
+			if err := rows.Scan(&column.schema, &column.table, &column.column); err != nil {
+				return err
+			}
+			columns = append(columns, column)
+		}
+		return rows.Err()
+	}, selectJSONColumnsStmt)
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "KEY-Re6Cl", "unable to read columns")
+	}
+	for _, column := range columns {
+		reencrypted, err := reencryptColumn(ctx, db, reencrypter, column)
+		if err != nil {
+			return count, err
+		}
+		count += reencrypted
+	}
+	return count, nil
+}
+
+func reencryptColumn(ctx context.Context, db *database.DB, reencrypter *crypto.Reencrypter, column *jsonColumn) (count int, err error) {
+	table := quoteIdentifier(column.schema) + "." + quoteIdentifier(column.table)
+	name := quoteIdentifier(column.column)
+	var values [][]byte
+	err = db.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var value []byte
+			if err := rows.Scan(&value); err != nil {
+				return err
+			}
+			values = append(values, value)
+		}
+		return rows.Err()
+	}, "SELECT DISTINCT "+name+" FROM "+table+" WHERE "+name+"::TEXT ILIKE '%\"keyid\"%'")
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "KEY-Re7Vl", "unable to read values")
+	}
+	for _, value := range values {
+		reencrypted, changed, err := reencrypter.ReencryptJSON(value)
+		if err != nil {
+			return count, err
+		}
+		if !changed {
+			continue
+		}
+		res, err := db.ExecContext(ctx, "UPDATE "+table+" SET "+name+" = $1 WHERE "+name+" = $2", reencrypted, value)
+		if err != nil {
+			return count, zerrors.ThrowInternal(err, "KEY-Re8Vl", "unable to update values")
+		}
+		updated, err := res.RowsAffected()
+		if err != nil {
+			return count, zerrors.ThrowInternal(err, "KEY-Re9Vl", "unable to update values")
+		}
+		count += int(updated)
+	}
+	return count, nil
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
@@ -0,0 +1,17 @@
+package projections
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "projections",
+		Short: "manage the projections of ZITADEL",
+		Long:  "manage the projections of ZITADEL",
+	}
+
+	cmd.AddCommand(newRebuild())
+
+	return cmd
+}
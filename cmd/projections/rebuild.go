@@ -0,0 +1,111 @@
+package projections
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+func newRebuild() *cobra.Command {
+	var instanceIDs []string
+	cmd := &cobra.Command{
+		Use:   "rebuild <name>",
+		Short: "rebuilds a projection without downtime",
+		Long: `rebuilds a projection into shadow tables while ZITADEL keeps serving queries from the current tables.
+As soon as the shadow tables reduced all events, they replace the current tables.
+If instances are provided only the rows of these instances are replaced.
+
+Example: zitadel projections rebuild projections.users14 --instance 840498034930840`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			config := MustNewConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKeyOrKMS(cmd, config.KMS)
+			logging.OnError(err).Panic("No master key provided")
+
+			ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer cancel()
+
+			err = Rebuild(ctx, config, masterKey, args[0], instanceIDs)
+			logging.WithFields("projection", args[0]).OnError(err).Fatal("rebuild failed")
+		},
+	}
+	cmd.Flags().StringArrayVar(&instanceIDs, "instance", nil, "id of the instance to rebuild, all instances are rebuilt if omitted")
+	key.AddMasterKeyFlag(cmd)
+
+	return cmd
+}
+
+// instanceID resolves the instance of the context to route its queries to the shard the instance is placed on
+func instanceID(ctx context.Context) string {
+	return authz.GetInstance(ctx).InstanceID()
+}
+
+func Rebuild(ctx context.Context, config *Config, masterKey, name string, instanceIDs []string) error {
+	queryDBClient, err := database.ConnectSharded(config.Database, config.Sharding, dialect.DBPurposeQuery, instanceID)
+	if err != nil {
+		return err
+	}
+	esPusherDBClient, err := database.ConnectSharded(config.Database, config.Sharding, dialect.DBPurposeEventPusher, instanceID)
+	if err != nil {
+		return err
+	}
+	projectionDBClient, err := database.ConnectSharded(config.Database, config.Sharding, dialect.DBPurposeProjectionSpooler, instanceID)
+	if err != nil {
+		return err
+	}
+
+	kmsProvider, err := config.KMS.NewProvider()
+	if err != nil {
+		return err
+	}
+	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey, kmsProvider)
+	if err != nil {
+		return err
+	}
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	if err != nil {
+		return err
+	}
+
+	esV3 := new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.Querier = old_es.NewCRDB(queryDBClient)
+	config.Eventstore.Pusher = esV3
+	// the personal data must be decrypted to rebuild the projections
+	config.Eventstore.PersonalDataStorage = esV3
+	config.Eventstore.PersonalDataKeyAlgorithm = keys.PersonalData
+	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
+
+	err = projection.Create(ctx, projectionDBClient, eventstoreClient, config.Projections, keys.OIDC, keys.SAML, config.SystemAPIUsers)
+	if err != nil {
+		return err
+	}
+	return projection.Rebuild(ctx, name, instanceIDs, logProgress)
+}
+
+func logProgress(progress *handler.RebuildProgress) {
+	for _, instance := range progress.Instances {
+		logging.WithFields(
+			"projection", progress.Projection,
+			"instance", instance.InstanceID,
+			"position", instance.Position,
+			"head", instance.Head,
+		).Info("rebuild progress")
+	}
+}
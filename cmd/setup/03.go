@@ -13,6 +13,7 @@ import (
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
 	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -31,6 +32,7 @@ type FirstInstance struct {
 	smtpEncryptionKey *crypto.KeyConfig
 	oidcEncryptionKey *crypto.KeyConfig
 	masterKey         string
+	kmsProvider       kms.Provider
 	db                *database.DB
 	es                *eventstore.Eventstore
 	defaults          systemdefaults.SystemDefaults
@@ -78,6 +80,7 @@ func (mig *FirstInstance) Execute(ctx context.Context, _ eventstore.Event) error
 		nil,
 		nil,
 		nil,
+		nil,
 		0,
 		0,
 		0,
@@ -125,7 +128,7 @@ func (mig *FirstInstance) Execute(ctx context.Context, _ eventstore.Event) error
 }
 
 func (mig *FirstInstance) verifyEncryptionKeys(ctx context.Context) (*crypto_db.Database, error) {
-	keyStorage, err := crypto_db.NewKeyStorage(mig.db, mig.masterKey)
+	keyStorage, err := crypto_db.NewKeyStorage(mig.db, mig.masterKey, mig.kmsProvider)
 	if err != nil {
 		return nil, fmt.Errorf("cannot start key storage: %w", err)
 	}
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 23.sql
+	addConsentRequiredToOIDCApps string
+)
+
+type AddConsentRequiredToOIDCApps struct {
+	dbClient *database.DB
+}
+
+func (mig *AddConsentRequiredToOIDCApps) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addConsentRequiredToOIDCApps)
+	return err
+}
+
+func (mig *AddConsentRequiredToOIDCApps) String() string {
+	return "23_add_consent_required_to_oidc_apps"
+}
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS projections.apps6_oidc_configs ADD COLUMN IF NOT EXISTS consent_required BOOLEAN DEFAULT false;
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 24.sql
+	addCertificateBoundTokens string
+)
+
+type AddCertificateBoundTokens struct {
+	dbClient *database.DB
+}
+
+func (mig *AddCertificateBoundTokens) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addCertificateBoundTokens)
+	return err
+}
+
+func (mig *AddCertificateBoundTokens) String() string {
+	return "24_add_certificate_bound_tokens"
+}
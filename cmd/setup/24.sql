@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS projections.apps6_oidc_configs ADD COLUMN IF NOT EXISTS tls_client_auth_subject_dn TEXT DEFAULT '';
+ALTER TABLE IF EXISTS auth.tokens ADD COLUMN IF NOT EXISTS certificate_thumbprint TEXT NULL;
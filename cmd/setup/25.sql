@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+    instance_id TEXT NOT NULL
+    , snapshot_type TEXT NOT NULL
+    , snapshot_key TEXT NOT NULL
+
+    , version INT2 NOT NULL
+    , "position" DECIMAL NOT NULL
+    , payload JSONB NOT NULL
+    , created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+
+    , PRIMARY KEY (instance_id, snapshot_type, snapshot_key)
+);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 26.sql
+	addEventPayloadRevision string
+)
+
+type AddEventPayloadRevision struct {
+	dbClient *database.DB
+}
+
+func (mig *AddEventPayloadRevision) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addEventPayloadRevision)
+	return err
+}
+
+func (mig *AddEventPayloadRevision) String() string {
+	return "26_add_event_payload_revision"
+}
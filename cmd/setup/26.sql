@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS eventstore.events2 ADD COLUMN IF NOT EXISTS payload_revision SMALLINT NOT NULL DEFAULT 0;
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 27.sql
+	addPersonalDataKeys string
+)
+
+type AddPersonalDataKeys struct {
+	dbClient *database.DB
+}
+
+func (mig *AddPersonalDataKeys) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addPersonalDataKeys)
+	return err
+}
+
+func (mig *AddPersonalDataKeys) String() string {
+	return "27_add_personal_data_keys"
+}
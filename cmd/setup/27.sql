@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS eventstore.personal_data_keys (
+    instance_id TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+
+    , "key" JSONB NOT NULL
+    , created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+
+    , PRIMARY KEY (instance_id, aggregate_id)
+);
+
+CREATE TABLE IF NOT EXISTS eventstore.personal_data_erasures (
+    instance_id TEXT NOT NULL
+    , aggregate_type TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+
+    , erased_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    , erased_by TEXT NOT NULL
+    , events JSONB NOT NULL
+    , plaintext_events JSONB NOT NULL DEFAULT '{}'
+
+    , PRIMARY KEY (instance_id, aggregate_id)
+);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 28.sql
+	addInstancePlacements string
+)
+
+type AddInstancePlacements struct {
+	dbClient *database.DB
+}
+
+func (mig *AddInstancePlacements) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addInstancePlacements)
+	return err
+}
+
+func (mig *AddInstancePlacements) String() string {
+	return "28_add_instance_placements"
+}
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS system.instance_placements (
+    instance_id TEXT NOT NULL
+    , shard TEXT NOT NULL
+    , locked BOOLEAN NOT NULL DEFAULT FALSE
+    , change_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+
+    , PRIMARY KEY (instance_id)
+);
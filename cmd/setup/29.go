@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 29.sql
+	addProjectionRebuilds string
+)
+
+type AddProjectionRebuilds struct {
+	dbClient *database.DB
+}
+
+func (mig *AddProjectionRebuilds) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addProjectionRebuilds)
+	return err
+}
+
+func (mig *AddProjectionRebuilds) String() string {
+	return "29_add_projection_rebuilds"
+}
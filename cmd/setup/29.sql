@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS projections.rebuilds (
+    projection_name TEXT NOT NULL
+    , instance_ids TEXT[]
+    , started_at TIMESTAMPTZ NOT NULL
+    , heartbeat TIMESTAMPTZ NOT NULL
+    , finished_at TIMESTAMPTZ
+    , error TEXT
+
+    , PRIMARY KEY (projection_name)
+);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 30.sql
+	addTokenAuthorizationDetails string
+)
+
+type AddTokenAuthorizationDetails struct {
+	dbClient *database.DB
+}
+
+func (mig *AddTokenAuthorizationDetails) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addTokenAuthorizationDetails)
+	return err
+}
+
+func (mig *AddTokenAuthorizationDetails) String() string {
+	return "30_add_token_authorization_details"
+}
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 31.sql
+	addReuseGracePeriodToOIDCApps string
+)
+
+type AddReuseGracePeriodToOIDCApps struct {
+	dbClient *database.DB
+}
+
+func (mig *AddReuseGracePeriodToOIDCApps) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addReuseGracePeriodToOIDCApps)
+	return err
+}
+
+func (mig *AddReuseGracePeriodToOIDCApps) String() string {
+	return "31_add_refresh_token_reuse_grace_period_to_oidc_apps"
+}
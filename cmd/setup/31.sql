@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS projections.apps7_oidc_configs ADD COLUMN IF NOT EXISTS refresh_token_reuse_grace_period BIGINT DEFAULT 0;
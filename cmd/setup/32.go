@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 32.sql
+	addInstancePlacementLockOwner string
+)
+
+type AddInstancePlacementLockOwner struct {
+	dbClient *database.DB
+}
+
+func (mig *AddInstancePlacementLockOwner) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addInstancePlacementLockOwner)
+	return err
+}
+
+func (mig *AddInstancePlacementLockOwner) String() string {
+	return "32_add_instance_placement_lock_owner"
+}
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS system.instance_placements
+    ADD COLUMN IF NOT EXISTS lock_owner TEXT
+    , ADD COLUMN IF NOT EXISTS lock_target TEXT
+    , ADD COLUMN IF NOT EXISTS lock_expiry TIMESTAMPTZ;
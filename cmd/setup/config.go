@@ -15,9 +15,11 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/oidc"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/cache/connector"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/hook"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -36,18 +38,21 @@ type Config struct {
 	ExternalSecure  bool
 	Log             *logging.Config
 	EncryptionKeys  *encryption.EncryptionKeyConfig
+	KMS             *kms.Config
 	DefaultInstance command.InstanceSetup
 	Machine         *id.Config
 	Projections     projection.Config
+	Caches          *connector.CachesConfig
 	Eventstore      *eventstore.Config
 
-	InitProjections InitProjections
-	AssetStorage    static_config.AssetStorageConfig
-	OIDC            oidc.Config
-	Login           login.Config
-	WebAuthNName    string
-	Telemetry       *handlers.TelemetryPusherConfig
-	SystemAPIUsers  map[string]*authz.SystemAPIUser
+	InitProjections   InitProjections
+	AssetStorage      static_config.AssetStorageConfig
+	OIDC              oidc.Config
+	Login             login.Config
+	WebAuthNName      string
+	Telemetry         *handlers.TelemetryPusherConfig
+	PushNotifications *handlers.PushNotifierConfig
+	SystemAPIUsers    map[string]*authz.SystemAPIUser
 }
 
 type InitProjections struct {
@@ -103,6 +108,16 @@ type Steps struct {
 	s20AddByUserSessionIndex        *AddByUserIndexToSession
 	s21AddBlockFieldToLimits        *AddBlockFieldToLimits
 	s22ActiveInstancesIndex         *ActiveInstanceEvents
+	s23AddConsentRequiredToOIDCApps *AddConsentRequiredToOIDCApps
+	s24AddCertificateBoundTokens    *AddCertificateBoundTokens
+	s25AddEventstoreSnapshots       *AddEventstoreSnapshots
+	s26AddEventPayloadRevision      *AddEventPayloadRevision
+	s27AddPersonalDataKeys          *AddPersonalDataKeys
+	s28AddInstancePlacements        *AddInstancePlacements
+	s29AddProjectionRebuilds        *AddProjectionRebuilds
+	s30AddTokenAuthorizationDetails *AddTokenAuthorizationDetails
+	s31AddReuseGracePeriodToApps    *AddReuseGracePeriodToOIDCApps
+	s32AddPlacementLockOwner        *AddInstancePlacementLockOwner
 }
 
 func MustNewSteps(v *viper.Viper) *Steps {
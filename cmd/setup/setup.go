@@ -21,6 +21,7 @@ import (
 	auth_view "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/view"
 	"github.com/zitadel/zitadel/internal/authz"
 	authz_es "github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/eventstore"
+	"github.com/zitadel/zitadel/internal/cache/connector"
 	"github.com/zitadel/zitadel/internal/command"
 	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
@@ -60,7 +61,7 @@ Requirements:
 			config := MustNewConfig(viper.GetViper())
 			steps := MustNewSteps(viper.New())
 
-			masterKey, err := key.MasterKey(cmd)
+			masterKey, err := key.MasterKeyOrKMS(cmd, config.KMS)
 			logging.OnError(err).Panic("No master key provided")
 
 			Setup(config, steps, masterKey)
@@ -98,8 +99,18 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	projectionDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeProjectionSpooler)
 	logging.OnError(err).Fatal("unable to connect to database")
 
+	kmsProvider, err := config.KMS.NewProvider()
+	logging.OnError(err).Fatal("unable to start kms provider")
+	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey, kmsProvider)
+	logging.OnError(err).Fatal("unable to start key storage")
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	logging.OnError(err).Fatal("unable to ensure encryption keys")
+
+	esV3 := new_es.NewEventstore(esPusherDBClient)
 	config.Eventstore.Querier = old_es.NewCRDB(queryDBClient)
-	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.Pusher = esV3
+	config.Eventstore.PersonalDataStorage = esV3
+	config.Eventstore.PersonalDataKeyAlgorithm = keys.PersonalData
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
 	logging.OnError(err).Fatal("unable to start eventstore")
 
@@ -111,6 +122,7 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.FirstInstance.smtpEncryptionKey = config.EncryptionKeys.SMTP
 	steps.FirstInstance.oidcEncryptionKey = config.EncryptionKeys.OIDC
 	steps.FirstInstance.masterKey = masterKey
+	steps.FirstInstance.kmsProvider = kmsProvider
 	steps.FirstInstance.db = queryDBClient
 	steps.FirstInstance.es = eventstoreClient
 	steps.FirstInstance.defaults = config.SystemDefaults
@@ -135,6 +147,16 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.s20AddByUserSessionIndex = &AddByUserIndexToSession{dbClient: queryDBClient}
 	steps.s21AddBlockFieldToLimits = &AddBlockFieldToLimits{dbClient: queryDBClient}
 	steps.s22ActiveInstancesIndex = &ActiveInstanceEvents{dbClient: queryDBClient}
+	steps.s23AddConsentRequiredToOIDCApps = &AddConsentRequiredToOIDCApps{dbClient: queryDBClient}
+	steps.s24AddCertificateBoundTokens = &AddCertificateBoundTokens{dbClient: queryDBClient}
+	steps.s25AddEventstoreSnapshots = &AddEventstoreSnapshots{dbClient: esPusherDBClient}
+	steps.s26AddEventPayloadRevision = &AddEventPayloadRevision{dbClient: esPusherDBClient}
+	steps.s27AddPersonalDataKeys = &AddPersonalDataKeys{dbClient: esPusherDBClient}
+	steps.s28AddInstancePlacements = &AddInstancePlacements{dbClient: queryDBClient}
+	steps.s29AddProjectionRebuilds = &AddProjectionRebuilds{dbClient: queryDBClient}
+	steps.s30AddTokenAuthorizationDetails = &AddTokenAuthorizationDetails{dbClient: queryDBClient}
+	steps.s31AddReuseGracePeriodToApps = &AddReuseGracePeriodToOIDCApps{dbClient: queryDBClient}
+	steps.s32AddPlacementLockOwner = &AddInstancePlacementLockOwner{dbClient: queryDBClient}
 
 	err = projection.Create(ctx, projectionDBClient, eventstoreClient, config.Projections, nil, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -153,6 +175,13 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 		},
 	}
 
+	// the eventstore reads and writes the payload revision, so the column must exist before the first migration is verified
+	err = steps.s26AddEventPayloadRevision.Execute(ctx, nil)
+	logging.WithFields("name", steps.s26AddEventPayloadRevision.String()).OnError(err).Fatal("migration failed")
+	// the first instance pushes personal data, so the data keys must be storable before it's created
+	err = steps.s27AddPersonalDataKeys.Execute(ctx, nil)
+	logging.WithFields("name", steps.s27AddPersonalDataKeys.String()).OnError(err).Fatal("migration failed")
+
 	err = migration.Migrate(ctx, eventstoreClient, steps.s14NewEventsTable)
 	logging.WithFields("name", steps.s14NewEventsTable.String()).OnError(err).Fatal("migration failed")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s1ProjectionTable)
@@ -185,6 +214,16 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.WithFields("name", steps.s20AddByUserSessionIndex.String()).OnError(err).Fatal("migration failed")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s22ActiveInstancesIndex)
 	logging.WithFields("name", steps.s22ActiveInstancesIndex.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s25AddEventstoreSnapshots)
+	logging.WithFields("name", steps.s25AddEventstoreSnapshots.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s26AddEventPayloadRevision)
+	logging.WithFields("name", steps.s26AddEventPayloadRevision.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s27AddPersonalDataKeys)
+	logging.WithFields("name", steps.s27AddPersonalDataKeys.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s28AddInstancePlacements)
+	logging.WithFields("name", steps.s28AddInstancePlacements.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s29AddProjectionRebuilds)
+	logging.WithFields("name", steps.s29AddProjectionRebuilds.String()).OnError(err).Fatal("migration failed")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
@@ -196,6 +235,16 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.WithFields("name", steps.s18AddLowerFieldsToLoginNames.String()).OnError(err).Fatal("migration failed")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s21AddBlockFieldToLimits)
 	logging.WithFields("name", steps.s21AddBlockFieldToLimits.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s23AddConsentRequiredToOIDCApps)
+	logging.WithFields("name", steps.s23AddConsentRequiredToOIDCApps.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s24AddCertificateBoundTokens)
+	logging.WithFields("name", steps.s24AddCertificateBoundTokens.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s30AddTokenAuthorizationDetails)
+	logging.WithFields("name", steps.s30AddTokenAuthorizationDetails.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s31AddReuseGracePeriodToApps)
+	logging.WithFields("name", steps.s31AddReuseGracePeriodToApps.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s32AddPlacementLockOwner)
+	logging.WithFields("name", steps.s32AddPlacementLockOwner.String()).OnError(err).Fatal("migration failed")
 
 	// projection initialization must be done last, since the steps above might add required columns to the projections
 	if config.InitProjections.Enabled {
@@ -204,7 +253,7 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 			eventstoreClient,
 			queryDBClient,
 			projectionDBClient,
-			masterKey,
+			keys,
 			config,
 		)
 	}
@@ -220,18 +269,12 @@ func initProjections(
 	eventstoreClient *eventstore.Eventstore,
 	queryDBClient,
 	projectionDBClient *database.DB,
-	masterKey string,
+	keys *encryption.EncryptionKeys,
 	config *Config,
 ) {
 	logging.Info("init-projections is currently in beta")
 
-	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey)
-	logging.OnError(err).Fatal("unable to start key storage")
-
-	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
-	logging.OnError(err).Fatal("unable to ensure encryption keys")
-
-	err = projection.Create(
+	err := projection.Create(
 		ctx,
 		queryDBClient,
 		eventstoreClient,
@@ -250,7 +293,7 @@ func initProjections(
 		logging.WithFields("name", p.String()).OnError(err).Fatal("migration failed")
 	}
 
-	staticStorage, err := config.AssetStorage.NewStorage(queryDBClient.DB)
+	staticStorage, err := config.AssetStorage.NewStorage(queryDBClient)
 	logging.OnError(err).Fatal("unable to start asset storage")
 
 	adminView, err := admin_view.StartView(queryDBClient)
@@ -291,6 +334,7 @@ func initProjections(
 		},
 		0,   // not needed for projections
 		nil, // not needed for projections
+		connector.StartConnectors(ctx, config.Caches),
 		false,
 	)
 	logging.OnError(err).Fatal("unable to start queries")
@@ -340,6 +384,7 @@ func initProjections(
 		&http.Client{},
 		permissionCheck,
 		sessionTokenVerifier,
+		queries.OrgDomainIDPRoutingsByDomain,
 		config.OIDC.DefaultAccessTokenLifetime,
 		config.OIDC.DefaultRefreshTokenExpiration,
 		config.OIDC.DefaultRefreshTokenIdleExpiration,
@@ -351,7 +396,9 @@ func initProjections(
 		config.Projections.Customizations["notifications"],
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["telemetry"],
+		config.Projections.Customizations["notificationspush"],
 		*config.Telemetry,
+		*config.PushNotifications,
 		config.ExternalDomain,
 		config.ExternalPort,
 		config.ExternalSecure,
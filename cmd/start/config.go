@@ -18,57 +18,71 @@ import (
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
+	"github.com/zitadel/zitadel/internal/cache/connector"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/hook"
 	"github.com/zitadel/zitadel/internal/config/network"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/idp/ldapsync"
+	"github.com/zitadel/zitadel/internal/idp/samlrefresh"
+	"github.com/zitadel/zitadel/internal/keyrotation"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	static_config "github.com/zitadel/zitadel/internal/static/config"
 	metrics "github.com/zitadel/zitadel/internal/telemetry/metrics/config"
 	tracing "github.com/zitadel/zitadel/internal/telemetry/tracing/config"
+	"github.com/zitadel/zitadel/internal/webauthn"
 )
 
 type Config struct {
-	Log               *logging.Config
-	Port              uint16
-	ExternalPort      uint16
-	ExternalDomain    string
-	ExternalSecure    bool
-	TLS               network.TLS
-	HTTP2HostHeader   string
-	HTTP1HostHeader   string
-	WebAuthNName      string
-	Database          database.Config
-	Tracing           tracing.Config
-	Metrics           metrics.Config
-	Projections       projection.Config
-	Auth              auth_es.Config
-	Admin             admin_es.Config
-	UserAgentCookie   *middleware.UserAgentCookieConfig
-	OIDC              oidc.Config
-	SAML              saml.Config
-	Login             login.Config
-	Console           console.Config
-	AssetStorage      static_config.AssetStorageConfig
-	InternalAuthZ     internal_authz.Config
-	SystemDefaults    systemdefaults.SystemDefaults
-	EncryptionKeys    *encryption.EncryptionKeyConfig
-	DefaultInstance   command.InstanceSetup
-	AuditLogRetention time.Duration
-	SystemAPIUsers    map[string]*internal_authz.SystemAPIUser
-	CustomerPortal    string
-	Machine           *id.Config
-	Actions           *actions.Config
-	Eventstore        *eventstore.Config
-	LogStore          *logstore.Configs
-	Quotas            *QuotasConfig
-	Telemetry         *handlers.TelemetryPusherConfig
+	Log                 *logging.Config
+	Port                uint16
+	ExternalPort        uint16
+	ExternalDomain      string
+	ExternalSecure      bool
+	TLS                 network.TLS
+	HTTP2HostHeader     string
+	HTTP1HostHeader     string
+	WebAuthNName        string
+	WebAuthNMetadata    webauthn.MetadataConfig
+	Database            database.Config
+	Sharding            *database.ShardingConfig
+	Tracing             tracing.Config
+	Metrics             metrics.Config
+	Projections         projection.Config
+	Caches              *connector.CachesConfig
+	Auth                auth_es.Config
+	Admin               admin_es.Config
+	UserAgentCookie     *middleware.UserAgentCookieConfig
+	OIDC                oidc.Config
+	SAML                saml.Config
+	Login               login.Config
+	Console             console.Config
+	AssetStorage        static_config.AssetStorageConfig
+	InternalAuthZ       internal_authz.Config
+	SystemDefaults      systemdefaults.SystemDefaults
+	EncryptionKeys      *encryption.EncryptionKeyConfig
+	KMS                 *kms.Config
+	DefaultInstance     command.InstanceSetup
+	AuditLogRetention   time.Duration
+	SystemAPIUsers      map[string]*internal_authz.SystemAPIUser
+	CustomerPortal      string
+	Machine             *id.Config
+	Actions             *actions.Config
+	Eventstore          *eventstore.Config
+	LogStore            *logstore.Configs
+	Quotas              *QuotasConfig
+	Telemetry           *handlers.TelemetryPusherConfig
+	PushNotifications   *handlers.PushNotifierConfig
+	LDAPSync            *ldapsync.Config
+	SAMLMetadataRefresh *samlrefresh.Config
+	KeyRotation         *keyrotation.Config
 }
 
 type QuotasConfig struct {
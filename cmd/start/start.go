@@ -56,6 +56,7 @@ import (
 	"github.com/zitadel/zitadel/internal/authz"
 	authz_repo "github.com/zitadel/zitadel/internal/authz/repository"
 	authz_es "github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/eventstore"
+	"github.com/zitadel/zitadel/internal/cache/connector"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
@@ -67,6 +68,9 @@ import (
 	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/idp/ldapsync"
+	"github.com/zitadel/zitadel/internal/idp/samlrefresh"
+	"github.com/zitadel/zitadel/internal/keyrotation"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
@@ -75,6 +79,8 @@ import (
 	"github.com/zitadel/zitadel/internal/net"
 	"github.com/zitadel/zitadel/internal/notification"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/sharding"
 	"github.com/zitadel/zitadel/internal/static"
 	"github.com/zitadel/zitadel/internal/webauthn"
 	"github.com/zitadel/zitadel/openapi"
@@ -93,7 +99,7 @@ Requirements:
 				return err
 			}
 			config := MustNewConfig(viper.GetViper())
-			masterKey, err := key.MasterKey(cmd)
+			masterKey, err := key.MasterKeyOrKMS(cmd, config.KMS)
 			if err != nil {
 				return err
 			}
@@ -121,25 +127,43 @@ type Server struct {
 	Shutdown   chan<- os.Signal
 }
 
+// instanceID resolves the instance of the context to route its queries to the shard the instance is placed on
+func instanceID(ctx context.Context) string {
+	return internal_authz.GetInstance(ctx).InstanceID()
+}
+
+// instanceMover moves instances between shards, all projections are triggered before their rows are copied
+func instanceMover(client *database.DB, pushTimeout time.Duration) *sharding.Mover {
+	projections := make([]sharding.Projection, 0, len(projection.Projections()))
+	for _, p := range projection.Projections() {
+		projections = append(projections, p)
+	}
+	return sharding.NewMover(client, projections, 0, pushTimeout)
+}
+
 func startZitadel(ctx context.Context, config *Config, masterKey string, server chan<- *Server) error {
 	showBasicInformation(config)
 
 	i18n.MustLoadSupportedLanguagesFromDir()
 
-	queryDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeQuery)
+	queryDBClient, err := database.ConnectSharded(config.Database, config.Sharding, dialect.DBPurposeQuery, instanceID)
 	if err != nil {
 		return fmt.Errorf("cannot start DB client for queries: %w", err)
 	}
-	esPusherDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeEventPusher)
+	esPusherDBClient, err := database.ConnectSharded(config.Database, config.Sharding, dialect.DBPurposeEventPusher, instanceID)
 	if err != nil {
 		return fmt.Errorf("cannot start client for event store pusher: %w", err)
 	}
-	projectionDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeProjectionSpooler)
+	projectionDBClient, err := database.ConnectSharded(config.Database, config.Sharding, dialect.DBPurposeProjectionSpooler, instanceID)
 	if err != nil {
 		return fmt.Errorf("cannot start client for projection spooler: %w", err)
 	}
 
-	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey)
+	kmsProvider, err := config.KMS.NewProvider()
+	if err != nil {
+		return fmt.Errorf("cannot start kms provider: %w", err)
+	}
+	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey, kmsProvider)
 	if err != nil {
 		return fmt.Errorf("cannot start key storage: %w", err)
 	}
@@ -148,8 +172,13 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		return err
 	}
 
-	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient)
+	esV3 := new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.Pusher = esV3
 	config.Eventstore.Querier = old_es.NewCRDB(queryDBClient)
+	config.Eventstore.SnapshotStorage = esV3
+	config.Eventstore.Rewriter = esV3
+	config.Eventstore.PersonalDataStorage = esV3
+	config.Eventstore.PersonalDataKeyAlgorithm = keys.PersonalData
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
 
 	sessionTokenVerifier := internal_authz.SessionTokenVerifier(keys.OIDC)
@@ -174,6 +203,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		},
 		config.AuditLogRetention,
 		config.SystemAPIUsers,
+		connector.StartConnectors(ctx, config.Caches),
 		true,
 	)
 	if err != nil {
@@ -188,13 +218,18 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		return internal_authz.CheckPermission(ctx, authZRepo, config.InternalAuthZ.RolePermissionMappings, permission, orgID, resourceID)
 	}
 
-	storage, err := config.AssetStorage.NewStorage(queryDBClient.DB)
+	storage, err := config.AssetStorage.NewStorage(queryDBClient)
 	if err != nil {
 		return fmt.Errorf("cannot start asset storage client: %w", err)
 	}
+	webAuthNMetadata, err := webauthn.LoadMetadata(ctx, config.WebAuthNMetadata)
+	if err != nil {
+		return fmt.Errorf("cannot load webauthn metadata: %w", err)
+	}
 	webAuthNConfig := &webauthn.Config{
 		DisplayName:    config.WebAuthNName,
 		ExternalSecure: config.ExternalSecure,
+		Metadata:       webAuthNMetadata,
 	}
 	commands, err := command.StartCommands(
 		eventstoreClient,
@@ -216,6 +251,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		&http.Client{},
 		permissionCheck,
 		sessionTokenVerifier,
+		queries.OrgDomainIDPRoutingsByDomain,
 		config.OIDC.DefaultAccessTokenLifetime,
 		config.OIDC.DefaultRefreshTokenExpiration,
 		config.OIDC.DefaultRefreshTokenIdleExpiration,
@@ -244,7 +280,9 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		config.Projections.Customizations["notifications"],
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["telemetry"],
+		config.Projections.Customizations["notificationspush"],
 		*config.Telemetry,
+		*config.PushNotifications,
 		config.ExternalDomain,
 		config.ExternalPort,
 		config.ExternalSecure,
@@ -259,6 +297,37 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	)
 	notification.Start(ctx)
 
+	ldapsync.Register(
+		ctx,
+		config.Projections.Customizations["ldapsync"],
+		*config.LDAPSync,
+		commands,
+		queries,
+		keys.User,
+	)
+	ldapsync.Start(ctx)
+
+	samlrefresh.Register(
+		ctx,
+		config.Projections.Customizations["samlmetadatarefresh"],
+		*config.SAMLMetadataRefresh,
+		commands,
+		queries,
+	)
+	samlrefresh.Start(ctx)
+
+	keyrotation.Register(
+		ctx,
+		config.Projections.Customizations["keyrotation"],
+		*config.KeyRotation,
+		commands,
+		queries,
+		config.OIDC.SigningKeyAlgorithm,
+	)
+	keyrotation.Start(ctx)
+
+	eventstoreClient.StartRewrite(ctx)
+
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
 	if err != nil {
@@ -376,7 +445,7 @@ func startAPIs(
 		return fmt.Errorf("error starting admin repo: %w", err)
 	}
 
-	if err := apis.RegisterServer(ctx, system.CreateServer(commands, queries, config.Database.DatabaseName(), config.DefaultInstance, config.ExternalDomain), tlsConfig); err != nil {
+	if err := apis.RegisterServer(ctx, system.CreateServer(commands, queries, config.Database.DatabaseName(), config.DefaultInstance, config.ExternalDomain, instanceMover(dbClient, config.Eventstore.PushTimeout), config.OIDC.SigningKeyAlgorithm), tlsConfig); err != nil {
 		return err
 	}
 	if err := apis.RegisterServer(ctx, admin.CreateServer(config.Database.DatabaseName(), commands, queries, config.SystemDefaults, config.ExternalSecure, keys.User, config.AuditLogRetention), tlsConfig); err != nil {
@@ -455,7 +524,9 @@ func startAPIs(
 		store,
 		consolePath,
 		oidcServer.AuthCallbackURL(),
-		provider.AuthCallbackURL(samlProvider),
+		oidcServer.AuthErrorCallbackURL(),
+		provider.AuthCallbackURL(samlProvider.Provider),
+		saml.WSFedAuthCallbackURL,
 		config.ExternalSecure,
 		userAgentInterceptor,
 		op.NewIssuerInterceptor(oidcServer.IssuerFromRequest).Handler,
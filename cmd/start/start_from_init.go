@@ -26,15 +26,15 @@ Requirements:
 			err := tls.ModeFromFlag(cmd)
 			logging.OnError(err).Fatal("invalid tlsMode")
 
-			masterKey, err := key.MasterKey(cmd)
-			logging.OnError(err).Panic("No master key provided")
-
 			initialise.InitAll(cmd.Context(), initialise.MustNewConfig(viper.GetViper()))
 
 			err = setup.BindInitProjections(cmd)
 			logging.OnError(err).Fatal("unable to bind \"init-projections\" flag")
 
 			setupConfig := setup.MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKeyOrKMS(cmd, setupConfig.KMS)
+			logging.OnError(err).Panic("No master key provided")
+
 			setupSteps := setup.MustNewSteps(viper.New())
 			setup.Setup(setupConfig, setupSteps, masterKey)
 
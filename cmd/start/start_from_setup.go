@@ -26,13 +26,13 @@ Requirements:
 			err := tls.ModeFromFlag(cmd)
 			logging.OnError(err).Fatal("invalid tlsMode")
 
-			masterKey, err := key.MasterKey(cmd)
-			logging.OnError(err).Panic("No master key provided")
-
 			err = setup.BindInitProjections(cmd)
 			logging.OnError(err).Fatal("unable to bind \"init-projections\" flag")
 
 			setupConfig := setup.MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKeyOrKMS(cmd, setupConfig.KMS)
+			logging.OnError(err).Panic("No master key provided")
+
 			setupSteps := setup.MustNewSteps(viper.New())
 			setup.Setup(setupConfig, setupSteps, masterKey)
 
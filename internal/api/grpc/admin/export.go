@@ -811,6 +811,10 @@ func (s *Server) getProjectsAndApps(ctx context.Context, org string) ([]*v1_pb.D
 						ClockSkew:                durationpb.New(app.OIDCConfig.ClockSkew),
 						AdditionalOrigins:        app.OIDCConfig.AdditionalOrigins,
 						SkipNativeAppSuccessPage: app.OIDCConfig.SkipNativeAppSuccessPage,
+						ConsentRequired:          app.OIDCConfig.ConsentRequired,
+						TlsClientAuthSubjectDn:   app.OIDCConfig.TLSClientAuthSubjectDN,
+
+						RefreshTokenReuseGracePeriod: durationpb.New(app.OIDCConfig.RefreshTokenReuseGracePeriod),
 					},
 				})
 			}
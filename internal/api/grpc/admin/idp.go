@@ -2,6 +2,7 @@ package admin
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	idp_grpc "github.com/zitadel/zitadel/internal/api/grpc/idp"
@@ -405,6 +406,43 @@ func (s *Server) UpdateLDAPProvider(ctx context.Context, req *admin_pb.UpdateLDA
 	}, nil
 }
 
+func (s *Server) SetLDAPProviderSync(ctx context.Context, req *admin_pb.SetLDAPProviderSyncRequest) (*admin_pb.SetLDAPProviderSyncResponse, error) {
+	details, err := s.command.SetInstanceLDAPSync(ctx, req.Id, idp_grpc.LDAPSyncToDomain(req.Sync))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetLDAPProviderSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetLDAPProviderSync(ctx context.Context, req *admin_pb.GetLDAPProviderSyncRequest) (*admin_pb.GetLDAPProviderSyncResponse, error) {
+	sync, err := s.query.LDAPSyncByIDPID(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetLDAPProviderSyncResponse{
+		Details: object_pb.ToViewDetailsPb(sync.Sequence, sync.CreationDate, sync.ChangeDate, sync.ResourceOwner),
+		Sync:    idp_grpc.LDAPSyncToPb(sync),
+		LastRun: idp_grpc.LDAPSyncLastRunToPb(sync),
+	}, nil
+}
+
+func (s *Server) ListLDAPProviderSyncRuns(ctx context.Context, req *admin_pb.ListLDAPProviderSyncRunsRequest) (*admin_pb.ListLDAPProviderSyncRunsResponse, error) {
+	queries, err := listLDAPProviderSyncRunsToQuery(authz.GetInstance(ctx).InstanceID(), req)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := s.query.SearchLDAPSyncRuns(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListLDAPProviderSyncRunsResponse{
+		Result:  idp_grpc.LDAPSyncRunsToPb(runs.Runs),
+		Details: object_pb.ToListDetails(runs.Count, runs.Sequence, runs.LastRun),
+	}, nil
+}
+
 func (s *Server) AddAppleProvider(ctx context.Context, req *admin_pb.AddAppleProviderRequest) (*admin_pb.AddAppleProviderResponse, error) {
 	id, details, err := s.command.AddInstanceAppleProvider(ctx, addAppleProviderToCommand(req))
 	if err != nil {
@@ -457,6 +495,145 @@ func (s *Server) RegenerateSAMLProviderCertificate(ctx context.Context, req *adm
 	}, nil
 }
 
+func (s *Server) SetSAMLProviderMetadataRefresh(ctx context.Context, req *admin_pb.SetSAMLProviderMetadataRefreshRequest) (*admin_pb.SetSAMLProviderMetadataRefreshResponse, error) {
+	details, err := s.command.SetInstanceSAMLMetadataRefresh(ctx, req.Id, idp_grpc.SAMLMetadataRefreshToDomain(req.Refresh))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetSAMLProviderMetadataRefreshResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetSAMLProviderMetadataRefresh(ctx context.Context, req *admin_pb.GetSAMLProviderMetadataRefreshRequest) (*admin_pb.GetSAMLProviderMetadataRefreshResponse, error) {
+	refresh, err := s.query.SAMLMetadataRefreshByIDPID(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetSAMLProviderMetadataRefreshResponse{
+		Details:      object_pb.ToViewDetailsPb(refresh.Sequence, refresh.CreationDate, refresh.ChangeDate, refresh.ResourceOwner),
+		Refresh:      idp_grpc.SAMLMetadataRefreshToPb(refresh),
+		Certificates: idp_grpc.SAMLMetadataRefreshCertificatesToPb(refresh),
+		LastRun:      idp_grpc.SAMLMetadataRefreshLastRunToPb(refresh),
+	}, nil
+}
+
+func (s *Server) RefreshSAMLProviderMetadata(ctx context.Context, req *admin_pb.RefreshSAMLProviderMetadataRequest) (*admin_pb.RefreshSAMLProviderMetadataResponse, error) {
+	report, err := s.command.RefreshSAMLProviderMetadata(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RefreshSAMLProviderMetadataResponse{
+		Run: idp_grpc.SAMLMetadataRefreshReportToPb(report),
+	}, nil
+}
+
+func (s *Server) AddBitbucketProvider(ctx context.Context, req *admin_pb.AddBitbucketProviderRequest) (*admin_pb.AddBitbucketProviderResponse, error) {
+	id, details, err := s.command.AddInstanceBitbucketProvider(ctx, addBitbucketProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddBitbucketProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateBitbucketProvider(ctx context.Context, req *admin_pb.UpdateBitbucketProviderRequest) (*admin_pb.UpdateBitbucketProviderResponse, error) {
+	details, err := s.command.UpdateInstanceBitbucketProvider(ctx, req.Id, updateBitbucketProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateBitbucketProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddSlackProvider(ctx context.Context, req *admin_pb.AddSlackProviderRequest) (*admin_pb.AddSlackProviderResponse, error) {
+	id, details, err := s.command.AddInstanceSlackProvider(ctx, addSlackProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddSlackProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateSlackProvider(ctx context.Context, req *admin_pb.UpdateSlackProviderRequest) (*admin_pb.UpdateSlackProviderResponse, error) {
+	details, err := s.command.UpdateInstanceSlackProvider(ctx, req.Id, updateSlackProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSlackProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddDiscordProvider(ctx context.Context, req *admin_pb.AddDiscordProviderRequest) (*admin_pb.AddDiscordProviderResponse, error) {
+	id, details, err := s.command.AddInstanceDiscordProvider(ctx, addDiscordProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddDiscordProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateDiscordProvider(ctx context.Context, req *admin_pb.UpdateDiscordProviderRequest) (*admin_pb.UpdateDiscordProviderResponse, error) {
+	details, err := s.command.UpdateInstanceDiscordProvider(ctx, req.Id, updateDiscordProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateDiscordProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddLinkedInProvider(ctx context.Context, req *admin_pb.AddLinkedInProviderRequest) (*admin_pb.AddLinkedInProviderResponse, error) {
+	id, details, err := s.command.AddInstanceLinkedInProvider(ctx, addLinkedInProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddLinkedInProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateLinkedInProvider(ctx context.Context, req *admin_pb.UpdateLinkedInProviderRequest) (*admin_pb.UpdateLinkedInProviderResponse, error) {
+	details, err := s.command.UpdateInstanceLinkedInProvider(ctx, req.Id, updateLinkedInProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateLinkedInProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddOktaProvider(ctx context.Context, req *admin_pb.AddOktaProviderRequest) (*admin_pb.AddOktaProviderResponse, error) {
+	id, details, err := s.command.AddInstanceOktaProvider(ctx, addOktaProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddOktaProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddKeycloakProvider(ctx context.Context, req *admin_pb.AddKeycloakProviderRequest) (*admin_pb.AddKeycloakProviderResponse, error) {
+	id, details, err := s.command.AddInstanceKeycloakProvider(ctx, addKeycloakProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddKeycloakProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) DeleteProvider(ctx context.Context, req *admin_pb.DeleteProviderRequest) (*admin_pb.DeleteProviderResponse, error) {
 	details, err := s.command.DeleteInstanceProvider(ctx, req.Id)
 	if err != nil {
@@ -466,3 +643,45 @@ func (s *Server) DeleteProvider(ctx context.Context, req *admin_pb.DeleteProvide
 		Details: object_pb.DomainToChangeDetailsPb(details),
 	}, nil
 }
+
+func (s *Server) SetProviderRoleMapping(ctx context.Context, req *admin_pb.SetProviderRoleMappingRequest) (*admin_pb.SetProviderRoleMappingResponse, error) {
+	details, err := s.command.SetInstanceIDPRoleMapping(ctx, req.Id, idp_grpc.IDPRoleMappingToDomain(req.Mapping))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderRoleMappingResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderRoleMapping(ctx context.Context, req *admin_pb.GetProviderRoleMappingRequest) (*admin_pb.GetProviderRoleMappingResponse, error) {
+	mapping, err := s.query.IDPRoleMappingByIDPID(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderRoleMappingResponse{
+		Details: object_pb.ToViewDetailsPb(mapping.Sequence, mapping.CreationDate, mapping.ChangeDate, mapping.ResourceOwner),
+		Mapping: idp_grpc.IDPRoleMappingToPb(mapping),
+	}, nil
+}
+
+func (s *Server) SetProviderAutoLinking(ctx context.Context, req *admin_pb.SetProviderAutoLinkingRequest) (*admin_pb.SetProviderAutoLinkingResponse, error) {
+	details, err := s.command.SetInstanceIDPAutoLinking(ctx, req.Id, idp_grpc.IDPAutoLinkingToDomain(req.AutoLinking))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderAutoLinkingResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderAutoLinking(ctx context.Context, req *admin_pb.GetProviderAutoLinkingRequest) (*admin_pb.GetProviderAutoLinkingResponse, error) {
+	linking, err := s.query.IDPAutoLinkingByIDPID(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderAutoLinkingResponse{
+		Details:     object_pb.ToViewDetailsPb(linking.Sequence, linking.CreationDate, linking.ChangeDate, linking.ResourceOwner),
+		AutoLinking: idp_grpc.IDPAutoLinkingToPb(linking),
+	}, nil
+}
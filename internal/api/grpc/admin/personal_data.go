@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"context"
+
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListPersonalDataErasures(ctx context.Context, _ *admin_pb.ListPersonalDataErasuresRequest) (*admin_pb.ListPersonalDataErasuresResponse, error) {
+	erasures, err := s.query.PersonalDataErasures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListPersonalDataErasuresResponse{
+		Result: personalDataErasuresToPb(erasures),
+	}, nil
+}
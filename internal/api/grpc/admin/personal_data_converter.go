@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func personalDataErasuresToPb(erasures []*query.PersonalDataErasure) []*admin_pb.PersonalDataErasure {
+	result := make([]*admin_pb.PersonalDataErasure, len(erasures))
+	for i, erasure := range erasures {
+		result[i] = &admin_pb.PersonalDataErasure{
+			AggregateType: erasure.AggregateType,
+			AggregateId:   erasure.AggregateID,
+			ErasedAt:      timestamppb.New(erasure.ErasedAt),
+			ErasedBy:      erasure.ErasedBy,
+			Events:        erasedPersonalDataToPb(erasure.Events),
+		}
+	}
+	return result
+}
+
+func erasedPersonalDataToPb(events []*query.ErasedPersonalData) []*admin_pb.ErasedPersonalData {
+	result := make([]*admin_pb.ErasedPersonalData, len(events))
+	for i, event := range events {
+		result[i] = &admin_pb.ErasedPersonalData{
+			EventType:      event.EventType,
+			Count:          event.Count,
+			PlaintextCount: event.PlaintextCount,
+			Fields:         event.Fields,
+		}
+	}
+	return result
+}
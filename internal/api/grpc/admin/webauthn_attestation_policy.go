@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetWebAuthNAttestationPolicy(ctx context.Context, _ *admin_pb.GetWebAuthNAttestationPolicyRequest) (*admin_pb.GetWebAuthNAttestationPolicyResponse, error) {
+	policy, err := s.query.DefaultWebAuthNAttestationPolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetWebAuthNAttestationPolicyResponse{Policy: policy_grpc.ModelWebAuthNAttestationPolicyToPb(policy)}, nil
+}
+
+func (s *Server) UpdateWebAuthNAttestationPolicy(ctx context.Context, req *admin_pb.UpdateWebAuthNAttestationPolicyRequest) (*admin_pb.UpdateWebAuthNAttestationPolicyResponse, error) {
+	details, err := s.command.SetDefaultWebAuthNAttestationPolicy(ctx, policy_grpc.WebAuthNAttestationPolicyToDomain(
+		req.GetRequireAttestation(),
+		req.GetRequireMetadata(),
+		req.GetAllowedAaguids(),
+		req.GetDeniedAaguids(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateWebAuthNAttestationPolicyResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -3,6 +3,7 @@ package idp
 import (
 	"github.com/crewjam/saml"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -384,6 +385,14 @@ func providerTypeToPb(idpType domain.IDPType) idp_pb.ProviderType {
 		return idp_pb.ProviderType_PROVIDER_TYPE_APPLE
 	case domain.IDPTypeSAML:
 		return idp_pb.ProviderType_PROVIDER_TYPE_SAML
+	case domain.IDPTypeBitbucket:
+		return idp_pb.ProviderType_PROVIDER_TYPE_BITBUCKET
+	case domain.IDPTypeSlack:
+		return idp_pb.ProviderType_PROVIDER_TYPE_SLACK
+	case domain.IDPTypeDiscord:
+		return idp_pb.ProviderType_PROVIDER_TYPE_DISCORD
+	case domain.IDPTypeLinkedIn:
+		return idp_pb.ProviderType_PROVIDER_TYPE_LINKEDIN
 	case domain.IDPTypeUnspecified:
 		return idp_pb.ProviderType_PROVIDER_TYPE_UNSPECIFIED
 	default:
@@ -448,6 +457,22 @@ func configToPb(config *query.IDPTemplate) *idp_pb.ProviderConfig {
 		samlConfigToPb(providerConfig, config.SAMLIDPTemplate)
 		return providerConfig
 	}
+	if config.BitbucketIDPTemplate != nil {
+		bitbucketConfigToPb(providerConfig, config.BitbucketIDPTemplate)
+		return providerConfig
+	}
+	if config.SlackIDPTemplate != nil {
+		slackConfigToPb(providerConfig, config.SlackIDPTemplate)
+		return providerConfig
+	}
+	if config.DiscordIDPTemplate != nil {
+		discordConfigToPb(providerConfig, config.DiscordIDPTemplate)
+		return providerConfig
+	}
+	if config.LinkedInIDPTemplate != nil {
+		linkedinConfigToPb(providerConfig, config.LinkedInIDPTemplate)
+		return providerConfig
+	}
 	return providerConfig
 }
 
@@ -620,6 +645,42 @@ func samlConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.SAMLI
 	}
 }
 
+func bitbucketConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.BitbucketIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Bitbucket{
+		Bitbucket: &idp_pb.BitbucketConfig{
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func slackConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.SlackIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Slack{
+		Slack: &idp_pb.SlackConfig{
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func discordConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.DiscordIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Discord{
+		Discord: &idp_pb.DiscordConfig{
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func linkedinConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.LinkedInIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Linkedin{
+		Linkedin: &idp_pb.LinkedInConfig{
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
 func bindingToPb(binding string) idp_pb.SAMLBinding {
 	switch binding {
 	case "":
@@ -634,3 +695,260 @@ func bindingToPb(binding string) idp_pb.SAMLBinding {
 		return idp_pb.SAMLBinding_SAML_BINDING_UNSPECIFIED
 	}
 }
+
+func LDAPSyncToDomain(sync *idp_pb.LDAPSync) *domain.LDAPSync {
+	mappings := make([]*domain.LDAPGroupMapping, len(sync.GetGroupMappings()))
+	for i, mapping := range sync.GetGroupMappings() {
+		mappings[i] = &domain.LDAPGroupMapping{
+			GroupDN:   mapping.GetGroupDn(),
+			ProjectID: mapping.GetProjectId(),
+			RoleKeys:  mapping.GetRoleKeys(),
+		}
+	}
+	return &domain.LDAPSync{
+		Enabled:           sync.GetEnabled(),
+		Interval:          sync.GetInterval().AsDuration(),
+		Filter:            sync.GetFilter(),
+		OrgID:             sync.GetOrgId(),
+		DeactivateMissing: sync.GetDeactivateMissing(),
+		NestedGroups:      sync.GetNestedGroups(),
+		GroupMappings:     mappings,
+	}
+}
+
+func LDAPSyncToPb(sync *query.LDAPSync) *idp_pb.LDAPSync {
+	mappings := make([]*idp_pb.LDAPGroupMapping, len(sync.GroupMappings))
+	for i, mapping := range sync.GroupMappings {
+		mappings[i] = &idp_pb.LDAPGroupMapping{
+			GroupDn:   mapping.GroupDN,
+			ProjectId: mapping.ProjectID,
+			RoleKeys:  mapping.RoleKeys,
+		}
+	}
+	return &idp_pb.LDAPSync{
+		Enabled:           sync.Enabled,
+		Interval:          durationpb.New(sync.Interval),
+		Filter:            sync.Filter,
+		OrgId:             sync.OrgID,
+		DeactivateMissing: sync.DeactivateMissing,
+		NestedGroups:      sync.NestedGroups,
+		GroupMappings:     mappings,
+	}
+}
+
+// LDAPSyncLastRunToPb returns the last run of the synchronization, nil if it never ran.
+func LDAPSyncLastRunToPb(sync *query.LDAPSync) *idp_pb.LDAPSyncRun {
+	if sync.LastRunStartedAt.IsZero() {
+		return nil
+	}
+	return &idp_pb.LDAPSyncRun{
+		StartedAt:  timestamppb.New(sync.LastRunStartedAt),
+		FinishedAt: timestamppb.New(sync.LastRunFinishedAt),
+		Error:      sync.LastRunError,
+	}
+}
+
+func LDAPSyncRunsToPb(runs []*query.LDAPSyncRun) []*idp_pb.LDAPSyncRun {
+	resp := make([]*idp_pb.LDAPSyncRun, len(runs))
+	for i, run := range runs {
+		resp[i] = &idp_pb.LDAPSyncRun{
+			Details:       obj_grpc.ChangeToDetailsPb(run.Sequence, run.FinishedAt, run.ResourceOwner),
+			StartedAt:     timestamppb.New(run.StartedAt),
+			FinishedAt:    timestamppb.New(run.FinishedAt),
+			UsersFound:    run.UsersFound,
+			Created:       run.Created,
+			Updated:       run.Updated,
+			Deactivated:   run.Deactivated,
+			Reactivated:   run.Reactivated,
+			GrantsAdded:   run.GrantsAdded,
+			GrantsChanged: run.GrantsChanged,
+			GrantsRemoved: run.GrantsRemoved,
+			Failed:        run.Failed,
+			Error:         run.Error,
+		}
+	}
+	return resp
+}
+
+func IDPRoleMappingToDomain(mapping *idp_pb.IDPRoleMapping) *domain.IDPRoleMapping {
+	var rules []*domain.IDPRoleRule
+	for _, rule := range mapping.GetRules() {
+		rules = append(rules, &domain.IDPRoleRule{
+			Value:     rule.GetValue(),
+			ProjectID: rule.GetProjectId(),
+			RoleKeys:  rule.GetRoleKeys(),
+		})
+	}
+	return &domain.IDPRoleMapping{
+		Claim: mapping.GetClaim(),
+		Mode:  idpRoleMappingModeToDomain(mapping.GetMode()),
+		Rules: rules,
+	}
+}
+
+func idpRoleMappingModeToDomain(mode idp_pb.IDPRoleMappingMode) domain.IDPRoleMappingMode {
+	switch mode {
+	case idp_pb.IDPRoleMappingMode_IDP_ROLE_MAPPING_MODE_ADD_ONLY:
+		return domain.IDPRoleMappingModeAddOnly
+	case idp_pb.IDPRoleMappingMode_IDP_ROLE_MAPPING_MODE_RECONCILE:
+		return domain.IDPRoleMappingModeReconcile
+	default:
+		return domain.IDPRoleMappingModeUnspecified
+	}
+}
+
+func IDPRoleMappingToPb(mapping *query.IDPRoleMapping) *idp_pb.IDPRoleMapping {
+	rules := make([]*idp_pb.IDPRoleRule, len(mapping.Rules))
+	for i, rule := range mapping.Rules {
+		rules[i] = &idp_pb.IDPRoleRule{
+			Value:     rule.Value,
+			ProjectId: rule.ProjectID,
+			RoleKeys:  rule.RoleKeys,
+		}
+	}
+	return &idp_pb.IDPRoleMapping{
+		Claim: mapping.Claim,
+		Mode:  idpRoleMappingModeToPb(mapping.Mode),
+		Rules: rules,
+	}
+}
+
+func idpRoleMappingModeToPb(mode domain.IDPRoleMappingMode) idp_pb.IDPRoleMappingMode {
+	switch mode {
+	case domain.IDPRoleMappingModeAddOnly:
+		return idp_pb.IDPRoleMappingMode_IDP_ROLE_MAPPING_MODE_ADD_ONLY
+	case domain.IDPRoleMappingModeReconcile:
+		return idp_pb.IDPRoleMappingMode_IDP_ROLE_MAPPING_MODE_RECONCILE
+	case domain.IDPRoleMappingModeUnspecified:
+		fallthrough
+	default:
+		return idp_pb.IDPRoleMappingMode_IDP_ROLE_MAPPING_MODE_UNSPECIFIED
+	}
+}
+
+func SAMLMetadataRefreshToDomain(refresh *idp_pb.SAMLMetadataRefresh) *domain.SAMLMetadataRefresh {
+	return &domain.SAMLMetadataRefresh{
+		Enabled:             refresh.GetEnabled(),
+		URL:                 refresh.GetUrl(),
+		MetadataCertificate: refresh.GetMetadataCertificate(),
+		Interval:            refresh.GetInterval().AsDuration(),
+		RolloverPeriod:      refresh.GetRolloverPeriod().AsDuration(),
+		ExpiryWarning:       refresh.GetExpiryWarning().AsDuration(),
+	}
+}
+
+func SAMLMetadataRefreshToPb(refresh *query.SAMLMetadataRefresh) *idp_pb.SAMLMetadataRefresh {
+	return &idp_pb.SAMLMetadataRefresh{
+		Enabled:             refresh.Enabled,
+		Url:                 refresh.URL,
+		MetadataCertificate: refresh.MetadataCertificate,
+		Interval:            durationpb.New(refresh.Interval),
+		RolloverPeriod:      durationpb.New(refresh.RolloverPeriod),
+		ExpiryWarning:       durationpb.New(refresh.ExpiryWarning),
+	}
+}
+
+func SAMLCertificatesToPb(certificates []*domain.SAMLCertificate) []*idp_pb.SAMLCertificate {
+	resp := make([]*idp_pb.SAMLCertificate, len(certificates))
+	for i, certificate := range certificates {
+		resp[i] = &idp_pb.SAMLCertificate{
+			Certificate: certificate.Certificate,
+			NotAfter:    timestamppb.New(certificate.NotAfter),
+		}
+		if certificate.IsRetired() {
+			resp[i].RetiredAt = timestamppb.New(certificate.RetiredAt)
+		}
+	}
+	return resp
+}
+
+// SAMLMetadataRefreshCertificatesToPb returns the accepted certificates of the refresh including the raised expiry alerts.
+func SAMLMetadataRefreshCertificatesToPb(refresh *query.SAMLMetadataRefresh) []*idp_pb.SAMLCertificate {
+	resp := SAMLCertificatesToPb(refresh.Certificates)
+	for i, certificate := range refresh.Certificates {
+		if alertedAt := refresh.ExpiryAlertedAt(certificate.Certificate); !alertedAt.IsZero() {
+			resp[i].ExpiryAlertedAt = timestamppb.New(alertedAt)
+		}
+	}
+	return resp
+}
+
+// SAMLMetadataRefreshLastRunToPb returns the last run of the refresh, nil if it never ran.
+func SAMLMetadataRefreshLastRunToPb(refresh *query.SAMLMetadataRefresh) *idp_pb.SAMLMetadataRefreshRun {
+	if refresh.LastRunStartedAt.IsZero() {
+		return nil
+	}
+	return &idp_pb.SAMLMetadataRefreshRun{
+		StartedAt:    timestamppb.New(refresh.LastRunStartedAt),
+		FinishedAt:   timestamppb.New(refresh.LastRunFinishedAt),
+		Certificates: SAMLMetadataRefreshCertificatesToPb(refresh),
+		Error:        refresh.LastRunError,
+	}
+}
+
+func SAMLMetadataRefreshReportToPb(report *domain.SAMLMetadataRefreshReport) *idp_pb.SAMLMetadataRefreshRun {
+	return &idp_pb.SAMLMetadataRefreshRun{
+		StartedAt:       timestamppb.New(report.StartedAt),
+		MetadataChanged: report.MetadataChanged,
+		Certificates:    SAMLCertificatesToPb(report.Certificates),
+		Error:           report.Error,
+	}
+}
+
+func IDPAutoLinkingToDomain(linking *idp_pb.IDPAutoLinking) *domain.IDPAutoLinking {
+	return &domain.IDPAutoLinking{
+		Option:     autoLinkingOptionToDomain(linking.GetOption()),
+		TrustLevel: idpTrustLevelToDomain(linking.GetTrustLevel()),
+	}
+}
+
+func autoLinkingOptionToDomain(option idp_pb.AutoLinkingOption) domain.AutoLinkingOption {
+	switch option {
+	case idp_pb.AutoLinkingOption_AUTO_LINKING_OPTION_USERNAME:
+		return domain.AutoLinkingOptionUsername
+	case idp_pb.AutoLinkingOption_AUTO_LINKING_OPTION_EMAIL:
+		return domain.AutoLinkingOptionEmail
+	default:
+		return domain.AutoLinkingOptionUnspecified
+	}
+}
+
+func idpTrustLevelToDomain(level idp_pb.IDPTrustLevel) domain.IDPTrustLevel {
+	switch level {
+	case idp_pb.IDPTrustLevel_IDP_TRUST_LEVEL_VERIFIED_EMAIL:
+		return domain.IDPTrustLevelVerifiedEmail
+	case idp_pb.IDPTrustLevel_IDP_TRUST_LEVEL_FULL:
+		return domain.IDPTrustLevelFull
+	default:
+		return domain.IDPTrustLevelUnspecified
+	}
+}
+
+func IDPAutoLinkingToPb(linking *query.IDPAutoLinking) *idp_pb.IDPAutoLinking {
+	return &idp_pb.IDPAutoLinking{
+		Option:     autoLinkingOptionToPb(linking.Option),
+		TrustLevel: idpTrustLevelToPb(linking.TrustLevel),
+	}
+}
+
+func autoLinkingOptionToPb(option domain.AutoLinkingOption) idp_pb.AutoLinkingOption {
+	switch option {
+	case domain.AutoLinkingOptionUsername:
+		return idp_pb.AutoLinkingOption_AUTO_LINKING_OPTION_USERNAME
+	case domain.AutoLinkingOptionEmail:
+		return idp_pb.AutoLinkingOption_AUTO_LINKING_OPTION_EMAIL
+	default:
+		return idp_pb.AutoLinkingOption_AUTO_LINKING_OPTION_UNSPECIFIED
+	}
+}
+
+func idpTrustLevelToPb(level domain.IDPTrustLevel) idp_pb.IDPTrustLevel {
+	switch level {
+	case domain.IDPTrustLevelVerifiedEmail:
+		return idp_pb.IDPTrustLevel_IDP_TRUST_LEVEL_VERIFIED_EMAIL
+	case domain.IDPTrustLevelFull:
+		return idp_pb.IDPTrustLevel_IDP_TRUST_LEVEL_FULL
+	default:
+		return idp_pb.IDPTrustLevel_IDP_TRUST_LEVEL_UNSPECIFIED
+	}
+}
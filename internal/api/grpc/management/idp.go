@@ -2,6 +2,7 @@ package management
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	idp_grpc "github.com/zitadel/zitadel/internal/api/grpc/idp"
@@ -397,6 +398,43 @@ func (s *Server) UpdateLDAPProvider(ctx context.Context, req *mgmt_pb.UpdateLDAP
 	}, nil
 }
 
+func (s *Server) SetLDAPProviderSync(ctx context.Context, req *mgmt_pb.SetLDAPProviderSyncRequest) (*mgmt_pb.SetLDAPProviderSyncResponse, error) {
+	details, err := s.command.SetOrgLDAPSync(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.LDAPSyncToDomain(req.Sync))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetLDAPProviderSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetLDAPProviderSync(ctx context.Context, req *mgmt_pb.GetLDAPProviderSyncRequest) (*mgmt_pb.GetLDAPProviderSyncResponse, error) {
+	sync, err := s.query.LDAPSyncByIDPID(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetLDAPProviderSyncResponse{
+		Details: object_pb.ToViewDetailsPb(sync.Sequence, sync.CreationDate, sync.ChangeDate, sync.ResourceOwner),
+		Sync:    idp_grpc.LDAPSyncToPb(sync),
+		LastRun: idp_grpc.LDAPSyncLastRunToPb(sync),
+	}, nil
+}
+
+func (s *Server) ListLDAPProviderSyncRuns(ctx context.Context, req *mgmt_pb.ListLDAPProviderSyncRunsRequest) (*mgmt_pb.ListLDAPProviderSyncRunsResponse, error) {
+	queries, err := listLDAPProviderSyncRunsToQuery(authz.GetCtxData(ctx).OrgID, req)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := s.query.SearchLDAPSyncRuns(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListLDAPProviderSyncRunsResponse{
+		Result:  idp_grpc.LDAPSyncRunsToPb(runs.Runs),
+		Details: object_pb.ToListDetails(runs.Count, runs.Sequence, runs.LastRun),
+	}, nil
+}
+
 func (s *Server) AddAppleProvider(ctx context.Context, req *mgmt_pb.AddAppleProviderRequest) (*mgmt_pb.AddAppleProviderResponse, error) {
 	id, details, err := s.command.AddOrgAppleProvider(ctx, authz.GetCtxData(ctx).OrgID, addAppleProviderToCommand(req))
 	if err != nil {
@@ -449,6 +487,145 @@ func (s *Server) RegenerateSAMLProviderCertificate(ctx context.Context, req *mgm
 	}, nil
 }
 
+func (s *Server) SetSAMLProviderMetadataRefresh(ctx context.Context, req *mgmt_pb.SetSAMLProviderMetadataRefreshRequest) (*mgmt_pb.SetSAMLProviderMetadataRefreshResponse, error) {
+	details, err := s.command.SetOrgSAMLMetadataRefresh(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.SAMLMetadataRefreshToDomain(req.Refresh))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetSAMLProviderMetadataRefreshResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetSAMLProviderMetadataRefresh(ctx context.Context, req *mgmt_pb.GetSAMLProviderMetadataRefreshRequest) (*mgmt_pb.GetSAMLProviderMetadataRefreshResponse, error) {
+	refresh, err := s.query.SAMLMetadataRefreshByIDPID(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetSAMLProviderMetadataRefreshResponse{
+		Details:      object_pb.ToViewDetailsPb(refresh.Sequence, refresh.CreationDate, refresh.ChangeDate, refresh.ResourceOwner),
+		Refresh:      idp_grpc.SAMLMetadataRefreshToPb(refresh),
+		Certificates: idp_grpc.SAMLMetadataRefreshCertificatesToPb(refresh),
+		LastRun:      idp_grpc.SAMLMetadataRefreshLastRunToPb(refresh),
+	}, nil
+}
+
+func (s *Server) RefreshSAMLProviderMetadata(ctx context.Context, req *mgmt_pb.RefreshSAMLProviderMetadataRequest) (*mgmt_pb.RefreshSAMLProviderMetadataResponse, error) {
+	report, err := s.command.RefreshSAMLProviderMetadata(ctx, authz.GetCtxData(ctx).OrgID, req.Id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RefreshSAMLProviderMetadataResponse{
+		Run: idp_grpc.SAMLMetadataRefreshReportToPb(report),
+	}, nil
+}
+
+func (s *Server) AddBitbucketProvider(ctx context.Context, req *mgmt_pb.AddBitbucketProviderRequest) (*mgmt_pb.AddBitbucketProviderResponse, error) {
+	id, details, err := s.command.AddOrgBitbucketProvider(ctx, authz.GetCtxData(ctx).OrgID, addBitbucketProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddBitbucketProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateBitbucketProvider(ctx context.Context, req *mgmt_pb.UpdateBitbucketProviderRequest) (*mgmt_pb.UpdateBitbucketProviderResponse, error) {
+	details, err := s.command.UpdateOrgBitbucketProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateBitbucketProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateBitbucketProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddSlackProvider(ctx context.Context, req *mgmt_pb.AddSlackProviderRequest) (*mgmt_pb.AddSlackProviderResponse, error) {
+	id, details, err := s.command.AddOrgSlackProvider(ctx, authz.GetCtxData(ctx).OrgID, addSlackProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddSlackProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateSlackProvider(ctx context.Context, req *mgmt_pb.UpdateSlackProviderRequest) (*mgmt_pb.UpdateSlackProviderResponse, error) {
+	details, err := s.command.UpdateOrgSlackProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateSlackProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateSlackProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddDiscordProvider(ctx context.Context, req *mgmt_pb.AddDiscordProviderRequest) (*mgmt_pb.AddDiscordProviderResponse, error) {
+	id, details, err := s.command.AddOrgDiscordProvider(ctx, authz.GetCtxData(ctx).OrgID, addDiscordProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddDiscordProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateDiscordProvider(ctx context.Context, req *mgmt_pb.UpdateDiscordProviderRequest) (*mgmt_pb.UpdateDiscordProviderResponse, error) {
+	details, err := s.command.UpdateOrgDiscordProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateDiscordProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateDiscordProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddLinkedInProvider(ctx context.Context, req *mgmt_pb.AddLinkedInProviderRequest) (*mgmt_pb.AddLinkedInProviderResponse, error) {
+	id, details, err := s.command.AddOrgLinkedInProvider(ctx, authz.GetCtxData(ctx).OrgID, addLinkedInProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddLinkedInProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateLinkedInProvider(ctx context.Context, req *mgmt_pb.UpdateLinkedInProviderRequest) (*mgmt_pb.UpdateLinkedInProviderResponse, error) {
+	details, err := s.command.UpdateOrgLinkedInProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateLinkedInProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateLinkedInProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddOktaProvider(ctx context.Context, req *mgmt_pb.AddOktaProviderRequest) (*mgmt_pb.AddOktaProviderResponse, error) {
+	id, details, err := s.command.AddOrgOktaProvider(ctx, authz.GetCtxData(ctx).OrgID, addOktaProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddOktaProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddKeycloakProvider(ctx context.Context, req *mgmt_pb.AddKeycloakProviderRequest) (*mgmt_pb.AddKeycloakProviderResponse, error) {
+	id, details, err := s.command.AddOrgKeycloakProvider(ctx, authz.GetCtxData(ctx).OrgID, addKeycloakProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddKeycloakProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) DeleteProvider(ctx context.Context, req *mgmt_pb.DeleteProviderRequest) (*mgmt_pb.DeleteProviderResponse, error) {
 	details, err := s.command.DeleteOrgProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
 	if err != nil {
@@ -458,3 +635,45 @@ func (s *Server) DeleteProvider(ctx context.Context, req *mgmt_pb.DeleteProvider
 		Details: object_pb.DomainToChangeDetailsPb(details),
 	}, nil
 }
+
+func (s *Server) SetProviderRoleMapping(ctx context.Context, req *mgmt_pb.SetProviderRoleMappingRequest) (*mgmt_pb.SetProviderRoleMappingResponse, error) {
+	details, err := s.command.SetOrgIDPRoleMapping(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.IDPRoleMappingToDomain(req.Mapping))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderRoleMappingResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderRoleMapping(ctx context.Context, req *mgmt_pb.GetProviderRoleMappingRequest) (*mgmt_pb.GetProviderRoleMappingResponse, error) {
+	mapping, err := s.query.IDPRoleMappingByIDPID(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderRoleMappingResponse{
+		Details: object_pb.ToViewDetailsPb(mapping.Sequence, mapping.CreationDate, mapping.ChangeDate, mapping.ResourceOwner),
+		Mapping: idp_grpc.IDPRoleMappingToPb(mapping),
+	}, nil
+}
+
+func (s *Server) SetProviderAutoLinking(ctx context.Context, req *mgmt_pb.SetProviderAutoLinkingRequest) (*mgmt_pb.SetProviderAutoLinkingResponse, error) {
+	details, err := s.command.SetOrgIDPAutoLinking(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.IDPAutoLinkingToDomain(req.AutoLinking))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderAutoLinkingResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderAutoLinking(ctx context.Context, req *mgmt_pb.GetProviderAutoLinkingRequest) (*mgmt_pb.GetProviderAutoLinkingResponse, error) {
+	linking, err := s.query.IDPAutoLinkingByIDPID(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderAutoLinkingResponse{
+		Details:     object_pb.ToViewDetailsPb(linking.Sequence, linking.CreationDate, linking.ChangeDate, linking.ResourceOwner),
+		AutoLinking: idp_grpc.IDPAutoLinkingToPb(linking),
+	}, nil
+}
@@ -483,6 +483,110 @@ func updateSAMLProviderToCommand(req *mgmt_pb.UpdateSAMLProviderRequest) command
 	}
 }
 
+func addBitbucketProviderToCommand(req *mgmt_pb.AddBitbucketProviderRequest) command.BitbucketProvider {
+	return command.BitbucketProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateBitbucketProviderToCommand(req *mgmt_pb.UpdateBitbucketProviderRequest) command.BitbucketProvider {
+	return command.BitbucketProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func addSlackProviderToCommand(req *mgmt_pb.AddSlackProviderRequest) command.SlackProvider {
+	return command.SlackProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateSlackProviderToCommand(req *mgmt_pb.UpdateSlackProviderRequest) command.SlackProvider {
+	return command.SlackProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func addDiscordProviderToCommand(req *mgmt_pb.AddDiscordProviderRequest) command.DiscordProvider {
+	return command.DiscordProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateDiscordProviderToCommand(req *mgmt_pb.UpdateDiscordProviderRequest) command.DiscordProvider {
+	return command.DiscordProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func addLinkedInProviderToCommand(req *mgmt_pb.AddLinkedInProviderRequest) command.LinkedInProvider {
+	return command.LinkedInProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateLinkedInProviderToCommand(req *mgmt_pb.UpdateLinkedInProviderRequest) command.LinkedInProvider {
+	return command.LinkedInProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func addOktaProviderToCommand(req *mgmt_pb.AddOktaProviderRequest) command.OktaProvider {
+	return command.OktaProvider{
+		Name:                  req.Name,
+		Domain:                req.Domain,
+		AuthorizationServerID: req.AuthorizationServerId,
+		ClientID:              req.ClientId,
+		ClientSecret:          req.ClientSecret,
+		Scopes:                req.Scopes,
+		IDPOptions:            idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func addKeycloakProviderToCommand(req *mgmt_pb.AddKeycloakProviderRequest) command.KeycloakProvider {
+	return command.KeycloakProvider{
+		Name:         req.Name,
+		BaseURL:      req.BaseUrl,
+		Realm:        req.Realm,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
 func bindingToCommand(binding idp_pb.SAMLBinding) string {
 	switch binding {
 	case idp_pb.SAMLBinding_SAML_BINDING_UNSPECIFIED:
@@ -497,3 +601,24 @@ func bindingToCommand(binding idp_pb.SAMLBinding) string {
 		return ""
 	}
 }
+
+func listLDAPProviderSyncRunsToQuery(resourceOwner string, req *mgmt_pb.ListLDAPProviderSyncRunsRequest) (*query.LDAPSyncRunSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	idpIDQuery, err := query.NewLDAPSyncRunIDPIDSearchQuery(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	resourceOwnerQuery, err := query.NewLDAPSyncRunResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &query.LDAPSyncRunSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.LDAPSyncRunSequenceCol,
+		},
+		Queries: []query.SearchQuery{idpIDQuery, resourceOwnerQuery},
+	}, nil
+}
@@ -251,6 +251,36 @@ func (s *Server) SetPrimaryOrgDomain(ctx context.Context, req *mgmt_pb.SetPrimar
 	}, nil
 }
 
+func (s *Server) ListOrgDomainIDPRoutings(ctx context.Context, _ *mgmt_pb.ListOrgDomainIDPRoutingsRequest) (*mgmt_pb.ListOrgDomainIDPRoutingsResponse, error) {
+	routings, err := s.query.OrgDomainIDPRoutings(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListOrgDomainIDPRoutingsResponse{
+		Result: org_grpc.DomainIDPRoutingsToPb(routings),
+	}, nil
+}
+
+func (s *Server) SetOrgDomainIDPRouting(ctx context.Context, req *mgmt_pb.SetOrgDomainIDPRoutingRequest) (*mgmt_pb.SetOrgDomainIDPRoutingResponse, error) {
+	details, err := s.command.SetOrgDomainIDPRouting(ctx, authz.GetCtxData(ctx).OrgID, req.GetDomain(), SetOrgDomainIDPRoutingRequestToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetOrgDomainIDPRoutingResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveOrgDomainIDPRouting(ctx context.Context, req *mgmt_pb.RemoveOrgDomainIDPRoutingRequest) (*mgmt_pb.RemoveOrgDomainIDPRoutingResponse, error) {
+	details, err := s.command.RemoveOrgDomainIDPRouting(ctx, authz.GetCtxData(ctx).OrgID, req.GetDomain())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveOrgDomainIDPRoutingResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) ListOrgMemberRoles(ctx context.Context, _ *mgmt_pb.ListOrgMemberRolesRequest) (*mgmt_pb.ListOrgMemberRolesResponse, error) {
 	instance, err := s.query.Instance(ctx, false)
 	if err != nil {
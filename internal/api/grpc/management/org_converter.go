@@ -67,6 +67,13 @@ func SetPrimaryOrgDomainRequestToDomain(ctx context.Context, req *mgmt_pb.SetPri
 	}
 }
 
+func SetOrgDomainIDPRoutingRequestToDomain(req *mgmt_pb.SetOrgDomainIDPRoutingRequest) *domain.OrgDomainIDPRouting {
+	return &domain.OrgDomainIDPRouting{
+		IDPIDs: req.GetIdpIds(),
+		Force:  req.GetForce(),
+	}
+}
+
 func UpdateOrgMemberRequestToDomain(ctx context.Context, req *mgmt_pb.UpdateOrgMemberRequest) *domain.Member {
 	return domain.NewMember(authz.GetCtxData(ctx).OrgID, req.UserId, req.Roles...)
 }
@@ -0,0 +1,51 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetWebAuthNAttestationPolicy(ctx context.Context, _ *mgmt_pb.GetWebAuthNAttestationPolicyRequest) (*mgmt_pb.GetWebAuthNAttestationPolicyResponse, error) {
+	policy, err := s.query.WebAuthNAttestationPolicyByOrg(ctx, true, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetWebAuthNAttestationPolicyResponse{Policy: policy_grpc.ModelWebAuthNAttestationPolicyToPb(policy)}, nil
+}
+
+func (s *Server) GetDefaultWebAuthNAttestationPolicy(ctx context.Context, _ *mgmt_pb.GetDefaultWebAuthNAttestationPolicyRequest) (*mgmt_pb.GetDefaultWebAuthNAttestationPolicyResponse, error) {
+	policy, err := s.query.DefaultWebAuthNAttestationPolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultWebAuthNAttestationPolicyResponse{Policy: policy_grpc.ModelWebAuthNAttestationPolicyToPb(policy)}, nil
+}
+
+func (s *Server) UpdateCustomWebAuthNAttestationPolicy(ctx context.Context, req *mgmt_pb.UpdateCustomWebAuthNAttestationPolicyRequest) (*mgmt_pb.UpdateCustomWebAuthNAttestationPolicyResponse, error) {
+	details, err := s.command.SetOrgWebAuthNAttestationPolicy(ctx, authz.GetCtxData(ctx).OrgID, policy_grpc.WebAuthNAttestationPolicyToDomain(
+		req.GetRequireAttestation(),
+		req.GetRequireMetadata(),
+		req.GetAllowedAaguids(),
+		req.GetDeniedAaguids(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateCustomWebAuthNAttestationPolicyResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ResetWebAuthNAttestationPolicyToDefault(ctx context.Context, _ *mgmt_pb.ResetWebAuthNAttestationPolicyToDefaultRequest) (*mgmt_pb.ResetWebAuthNAttestationPolicyToDefaultResponse, error) {
+	details, err := s.command.RemoveOrgWebAuthNAttestationPolicy(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetWebAuthNAttestationPolicyToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -332,6 +332,56 @@ func (s *Server) RemoveProjectRole(ctx context.Context, req *mgmt_pb.RemoveProje
 	}, nil
 }
 
+func (s *Server) ListProjectAuthorizationDetailTypes(ctx context.Context, req *mgmt_pb.ListProjectAuthorizationDetailTypesRequest) (*mgmt_pb.ListProjectAuthorizationDetailTypesResponse, error) {
+	queries := listProjectAuthorizationDetailTypesRequestToModel(req)
+	err := queries.AppendMyResourceOwnerQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	err = queries.AppendProjectIDQuery(req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.query.SearchAuthorizationDetailTypes(ctx, true, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListProjectAuthorizationDetailTypesResponse{
+		Result:  project_grpc.AuthorizationDetailTypesToPb(types.AuthorizationDetailTypes),
+		Details: object_grpc.ToListDetails(types.Count, types.Sequence, types.LastRun),
+	}, nil
+}
+
+func (s *Server) AddProjectAuthorizationDetailType(ctx context.Context, req *mgmt_pb.AddProjectAuthorizationDetailTypeRequest) (*mgmt_pb.AddProjectAuthorizationDetailTypeResponse, error) {
+	details, err := s.command.AddProjectAuthorizationDetailType(ctx, AddProjectAuthorizationDetailTypeRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddProjectAuthorizationDetailTypeResponse{
+		Details: object_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateProjectAuthorizationDetailType(ctx context.Context, req *mgmt_pb.UpdateProjectAuthorizationDetailTypeRequest) (*mgmt_pb.UpdateProjectAuthorizationDetailTypeResponse, error) {
+	details, err := s.command.ChangeProjectAuthorizationDetailType(ctx, UpdateProjectAuthorizationDetailTypeRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateProjectAuthorizationDetailTypeResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveProjectAuthorizationDetailType(ctx context.Context, req *mgmt_pb.RemoveProjectAuthorizationDetailTypeRequest) (*mgmt_pb.RemoveProjectAuthorizationDetailTypeResponse, error) {
+	details, err := s.command.RemoveProjectAuthorizationDetailType(ctx, req.ProjectId, req.Type, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveProjectAuthorizationDetailTypeResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) ListProjectMemberRoles(ctx context.Context, _ *mgmt_pb.ListProjectMemberRolesRequest) (*mgmt_pb.ListProjectMemberRolesResponse, error) {
 	roles, err := s.query.GetProjectMemberRoles(ctx)
 	if err != nil {
@@ -246,6 +246,46 @@ func (s *Server) RegenerateAPIClientSecret(ctx context.Context, req *mgmt_pb.Reg
 	}, nil
 }
 
+func (s *Server) GetAppClaimMappings(ctx context.Context, req *mgmt_pb.GetAppClaimMappingsRequest) (*mgmt_pb.GetAppClaimMappingsResponse, error) {
+	mappings, err := s.query.AppClaimMappingsByID(ctx, req.ProjectId, req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetAppClaimMappingsResponse{
+		ClaimMappings: project_grpc.ClaimMappingsToPb(mappings.ClaimMappings),
+	}, nil
+}
+
+func (s *Server) SetAppClaimMappings(ctx context.Context, req *mgmt_pb.SetAppClaimMappingsRequest) (*mgmt_pb.SetAppClaimMappingsResponse, error) {
+	details, err := s.command.SetApplicationClaimMappings(ctx, req.ProjectId, req.AppId, project_grpc.ClaimMappingsToDomain(req.ClaimMappings), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetAppClaimMappingsResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetSAMLAppSSOSettings(ctx context.Context, req *mgmt_pb.GetSAMLAppSSOSettingsRequest) (*mgmt_pb.GetSAMLAppSSOSettingsResponse, error) {
+	settings, err := s.query.SAMLSSOSettingsByID(ctx, req.ProjectId, req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetSAMLAppSSOSettingsResponse{
+		Settings: project_grpc.SAMLSSOSettingsToPb(&settings.SAMLSSOSettings),
+	}, nil
+}
+
+func (s *Server) SetSAMLAppSSOSettings(ctx context.Context, req *mgmt_pb.SetSAMLAppSSOSettingsRequest) (*mgmt_pb.SetSAMLAppSSOSettingsResponse, error) {
+	details, err := s.command.SetSAMLSSOSettings(ctx, req.ProjectId, req.AppId, project_grpc.SAMLSSOSettingsToDomain(req.Settings), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetSAMLAppSSOSettingsResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) GetAppKey(ctx context.Context, req *mgmt_pb.GetAppKeyRequest) (*mgmt_pb.GetAppKeyResponse, error) {
 	resourceOwner, err := query.NewAuthNKeyResourceOwnerQuery(authz.GetCtxData(ctx).OrgID)
 	if err != nil {
@@ -57,6 +57,10 @@ func AddOIDCAppRequestToDomain(req *mgmt_pb.AddOIDCAppRequest) *domain.OIDCApp {
 		ClockSkew:                req.ClockSkew.AsDuration(),
 		AdditionalOrigins:        req.AdditionalOrigins,
 		SkipNativeAppSuccessPage: req.SkipNativeAppSuccessPage,
+		ConsentRequired:          req.ConsentRequired,
+		TLSClientAuthSubjectDN:   req.TlsClientAuthSubjectDn,
+
+		RefreshTokenReuseGracePeriod: req.RefreshTokenReuseGracePeriod.AsDuration(),
 	}
 }
 
@@ -108,6 +112,10 @@ func UpdateOIDCAppConfigRequestToDomain(app *mgmt_pb.UpdateOIDCAppConfigRequest)
 		ClockSkew:                app.ClockSkew.AsDuration(),
 		AdditionalOrigins:        app.AdditionalOrigins,
 		SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+		ConsentRequired:          app.ConsentRequired,
+		TLSClientAuthSubjectDN:   app.TlsClientAuthSubjectDn,
+
+		RefreshTokenReuseGracePeriod: app.RefreshTokenReuseGracePeriod.AsDuration(),
 	}
 }
 
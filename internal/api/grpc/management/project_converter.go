@@ -59,6 +59,30 @@ func AddProjectRoleRequestToDomain(req *mgmt_pb.AddProjectRoleRequest) *domain.P
 	}
 }
 
+func AddProjectAuthorizationDetailTypeRequestToDomain(req *mgmt_pb.AddProjectAuthorizationDetailTypeRequest) *domain.AuthorizationDetailType {
+	return &domain.AuthorizationDetailType{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.ProjectId,
+		},
+		Type:        req.Type,
+		DisplayName: req.DisplayName,
+		Actions:     req.Actions,
+		Locations:   req.Locations,
+	}
+}
+
+func UpdateProjectAuthorizationDetailTypeRequestToDomain(req *mgmt_pb.UpdateProjectAuthorizationDetailTypeRequest) *domain.AuthorizationDetailType {
+	return &domain.AuthorizationDetailType{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.ProjectId,
+		},
+		Type:        req.Type,
+		DisplayName: req.DisplayName,
+		Actions:     req.Actions,
+		Locations:   req.Locations,
+	}
+}
+
 func BulkAddProjectRolesRequestToDomain(req *mgmt_pb.BulkAddProjectRolesRequest) []*domain.ProjectRole {
 	roles := make([]*domain.ProjectRole, len(req.Roles))
 	for i, role := range req.Roles {
@@ -149,6 +173,17 @@ func listProjectRolesRequestToModel(req *mgmt_pb.ListProjectRolesRequest) (*quer
 	}, nil
 }
 
+func listProjectAuthorizationDetailTypesRequestToModel(req *mgmt_pb.ListProjectAuthorizationDetailTypesRequest) *query.AuthorizationDetailTypeSearchQueries {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.AuthorizationDetailTypeSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+	}
+}
+
 func listGrantedProjectRolesRequestToModel(req *mgmt_pb.ListGrantedProjectRolesRequest) (*query.ProjectRoleSearchQueries, error) {
 	offset, limit, asc := object.ListQueryToModel(req.Query)
 	queries, err := proj_grpc.RoleQueriesToModel(req.Queries)
@@ -2,6 +2,7 @@ package oidc
 
 import (
 	"context"
+	"slices"
 
 	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v3/pkg/op"
@@ -102,6 +103,9 @@ func (s *Server) failAuthRequest(ctx context.Context, authRequestID string, ae *
 }
 
 func (s *Server) linkSessionToAuthRequest(ctx context.Context, authRequestID string, session *oidc_pb.Session) (*oidc_pb.CreateCallbackResponse, error) {
+	if err := s.checkConsent(ctx, authRequestID, session); err != nil {
+		return nil, err
+	}
 	details, aar, err := s.command.LinkSessionToAuthRequest(ctx, authRequestID, session.GetSessionId(), session.GetSessionToken(), true)
 	if err != nil {
 		return nil, err
@@ -123,6 +127,47 @@ func (s *Server) linkSessionToAuthRequest(ctx context.Context, authRequestID str
 	}, nil
 }
 
+// checkConsent ensures the user consented to the requested scopes, if the application requires consent.
+// If the login UI confirms the consent of the user, it's persisted together with the previously granted scopes.
+func (s *Server) checkConsent(ctx context.Context, authRequestID string, session *oidc_pb.Session) error {
+	authReq, err := s.query.AuthRequestByID(ctx, true, authRequestID, true)
+	if err != nil {
+		return err
+	}
+	app, err := s.query.AppByOIDCClientID(ctx, authReq.ClientID)
+	if err != nil {
+		return err
+	}
+	if app.OIDCConfig == nil || !app.OIDCConfig.ConsentRequired {
+		return nil
+	}
+	userSession, err := s.query.SessionByID(ctx, true, session.GetSessionId(), session.GetSessionToken())
+	if err != nil {
+		return err
+	}
+	consent, err := s.query.UserConsentByClientID(ctx, true, userSession.UserFactor.UserID, authReq.ClientID)
+	if err != nil && !zerrors.IsNotFound(err) {
+		return err
+	}
+	if session.GetConsentGiven() {
+		scopes := authReq.Scope
+		if consent != nil {
+			scopes = append(slices.Clone(consent.Scopes), scopes...)
+		}
+		_, err = s.command.GrantUserConsent(ctx, userSession.UserFactor.UserID, userSession.UserFactor.ResourceOwner, authReq.ClientID, app.ProjectID, scopes)
+		return err
+	}
+	if consent == nil || slices.Contains(authReq.Prompt, domain.PromptConsent) {
+		return zerrors.ThrowPreconditionFailed(nil, "OIDCv2-Eix0u", "Errors.AuthRequest.ConsentRequired")
+	}
+	for _, scope := range authReq.Scope {
+		if !slices.Contains(consent.Scopes, scope) {
+			return zerrors.ThrowPreconditionFailed(nil, "OIDCv2-ooC6a", "Errors.AuthRequest.ConsentRequired")
+		}
+	}
+	return nil
+}
+
 func errorReasonToDomain(errorReason oidc_pb.ErrorReason) domain.OIDCErrorReason {
 	switch errorReason {
 	case oidc_pb.ErrorReason_ERROR_REASON_UNSPECIFIED:
@@ -165,6 +165,28 @@ func DomainToPb(d *query.Domain) *org_pb.Domain {
 	}
 }
 
+func DomainIDPRoutingsToPb(routings []*query.OrgDomainIDPRouting) []*org_pb.DomainIDPRouting {
+	r := make([]*org_pb.DomainIDPRouting, len(routings))
+	for i, routing := range routings {
+		r[i] = DomainIDPRoutingToPb(routing)
+	}
+	return r
+}
+
+func DomainIDPRoutingToPb(r *query.OrgDomainIDPRouting) *org_pb.DomainIDPRouting {
+	return &org_pb.DomainIDPRouting{
+		DomainName: r.Domain,
+		IdpIds:     r.IDPIDs,
+		Force:      r.Force,
+		Details: object.ToViewDetailsPb(
+			r.Sequence,
+			r.CreationDate,
+			r.ChangeDate,
+			r.OrgID,
+		),
+	}
+}
+
 func DomainValidationTypeToDomain(validationType org_pb.DomainValidationType) domain.OrgDomainValidationType {
 	switch validationType {
 	case org_pb.DomainValidationType_DOMAIN_VALIDATION_TYPE_HTTP:
@@ -0,0 +1,33 @@
+package policy
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelWebAuthNAttestationPolicyToPb(policy *query.WebAuthNAttestationPolicy) *policy_pb.WebAuthNAttestationPolicy {
+	return &policy_pb.WebAuthNAttestationPolicy{
+		IsDefault:          policy.IsDefault,
+		RequireAttestation: policy.RequireAttestation,
+		RequireMetadata:    policy.RequireMetadata,
+		AllowedAaguids:     policy.AllowedAAGUIDs,
+		DeniedAaguids:      policy.DeniedAAGUIDs,
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
+
+func WebAuthNAttestationPolicyToDomain(requireAttestation, requireMetadata bool, allowedAAGUIDs, deniedAAGUIDs []string) *domain.WebAuthNAttestationPolicy {
+	return &domain.WebAuthNAttestationPolicy{
+		RequireAttestation: requireAttestation,
+		RequireMetadata:    requireMetadata,
+		AllowedAAGUIDs:     allowedAAGUIDs,
+		DeniedAAGUIDs:      deniedAAGUIDs,
+	}
+}
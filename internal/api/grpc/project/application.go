@@ -61,6 +61,10 @@ func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 			AdditionalOrigins:        app.AdditionalOrigins,
 			AllowedOrigins:           app.AllowedOrigins,
 			SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+			ConsentRequired:          app.ConsentRequired,
+			TlsClientAuthSubjectDn:   app.TLSClientAuthSubjectDN,
+
+			RefreshTokenReuseGracePeriod: durationpb.New(app.RefreshTokenReuseGracePeriod),
 		},
 	}
 }
@@ -198,6 +202,10 @@ func OIDCAuthMethodTypeToPb(authType domain.OIDCAuthMethodType) app_pb.OIDCAuthM
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_NONE
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_TLS_CLIENT_AUTH
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_SELF_SIGNED_TLS_CLIENT_AUTH
 	default:
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_BASIC
 	}
@@ -213,6 +221,10 @@ func OIDCAuthMethodTypeToDomain(authType app_pb.OIDCAuthMethodType) domain.OIDCA
 		return domain.OIDCAuthMethodTypeNone
 	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT:
 		return domain.OIDCAuthMethodTypePrivateKeyJWT
+	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_TLS_CLIENT_AUTH:
+		return domain.OIDCAuthMethodTypeTLSClientAuth
+	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_SELF_SIGNED_TLS_CLIENT_AUTH:
+		return domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth
 	default:
 		return domain.OIDCAuthMethodTypeBasic
 	}
@@ -306,3 +318,170 @@ func AppQueryToModel(appQuery *app_pb.AppQuery) (query.SearchQuery, error) {
 		return nil, zerrors.ThrowInvalidArgument(nil, "APP-Add46", "List.Query.Invalid")
 	}
 }
+
+func SAMLSSOSettingsToPb(settings *domain.SAMLSSOSettings) *app_pb.SAMLSSOSettings {
+	return &app_pb.SAMLSSOSettings{
+		IdpInitiatedEnabled: settings.IDPInitiatedEnabled,
+		RelayState:          settings.RelayState,
+		WsFedEnabled:        settings.WSFedEnabled,
+		WsFedReplyUrls:      settings.WSFedReplyURLs,
+	}
+}
+
+func SAMLSSOSettingsToDomain(settings *app_pb.SAMLSSOSettings) *domain.SAMLSSOSettings {
+	if settings == nil {
+		return nil
+	}
+	return &domain.SAMLSSOSettings{
+		IDPInitiatedEnabled: settings.GetIdpInitiatedEnabled(),
+		RelayState:          settings.GetRelayState(),
+		WSFedEnabled:        settings.GetWsFedEnabled(),
+		WSFedReplyURLs:      settings.GetWsFedReplyUrls(),
+	}
+}
+
+func ClaimMappingsToPb(mappings []*domain.ClaimMapping) []*app_pb.ClaimMapping {
+	result := make([]*app_pb.ClaimMapping, len(mappings))
+	for i, mapping := range mappings {
+		result[i] = &app_pb.ClaimMapping{
+			Claim:          mapping.Claim,
+			Source:         claimMappingSourceToPb(mapping.Source),
+			Key:            mapping.Key,
+			Value:          mapping.Value,
+			Transformation: claimMappingTransformationToPb(mapping.Transformation),
+			Separator:      mapping.Separator,
+			Pattern:        mapping.Pattern,
+			Replacement:    mapping.Replacement,
+			Targets:        claimMappingTargetsToPb(mapping.Targets),
+		}
+	}
+	return result
+}
+
+func ClaimMappingsToDomain(mappings []*app_pb.ClaimMapping) []*domain.ClaimMapping {
+	result := make([]*domain.ClaimMapping, len(mappings))
+	for i, mapping := range mappings {
+		result[i] = &domain.ClaimMapping{
+			Claim:          mapping.GetClaim(),
+			Source:         claimMappingSourceToDomain(mapping.GetSource()),
+			Key:            mapping.GetKey(),
+			Value:          mapping.GetValue(),
+			Transformation: claimMappingTransformationToDomain(mapping.GetTransformation()),
+			Separator:      mapping.GetSeparator(),
+			Pattern:        mapping.GetPattern(),
+			Replacement:    mapping.GetReplacement(),
+			Targets:        claimMappingTargetsToDomain(mapping.GetTargets()),
+		}
+	}
+	return result
+}
+
+func claimMappingSourceToPb(source domain.ClaimMappingSource) app_pb.ClaimMappingSource {
+	switch source {
+	case domain.ClaimMappingSourceUserField:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_USER_FIELD
+	case domain.ClaimMappingSourceUserMetadata:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_USER_METADATA
+	case domain.ClaimMappingSourceOrgField:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_ORG_FIELD
+	case domain.ClaimMappingSourceRoles:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_ROLES
+	case domain.ClaimMappingSourceStatic:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_STATIC
+	case domain.ClaimMappingSourceUnspecified:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_UNSPECIFIED
+	default:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_UNSPECIFIED
+	}
+}
+
+func claimMappingSourceToDomain(source app_pb.ClaimMappingSource) domain.ClaimMappingSource {
+	switch source {
+	case app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_USER_FIELD:
+		return domain.ClaimMappingSourceUserField
+	case app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_USER_METADATA:
+		return domain.ClaimMappingSourceUserMetadata
+	case app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_ORG_FIELD:
+		return domain.ClaimMappingSourceOrgField
+	case app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_ROLES:
+		return domain.ClaimMappingSourceRoles
+	case app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_STATIC:
+		return domain.ClaimMappingSourceStatic
+	case app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_UNSPECIFIED:
+		return domain.ClaimMappingSourceUnspecified
+	default:
+		return domain.ClaimMappingSourceUnspecified
+	}
+}
+
+func claimMappingTransformationToPb(transformation domain.ClaimMappingTransformation) app_pb.ClaimMappingTransformation {
+	switch transformation {
+	case domain.ClaimMappingTransformationLowercase:
+		return app_pb.ClaimMappingTransformation_CLAIM_MAPPING_TRANSFORMATION_LOWERCASE
+	case domain.ClaimMappingTransformationJoin:
+		return app_pb.ClaimMappingTransformation_CLAIM_MAPPING_TRANSFORMATION_JOIN
+	case domain.ClaimMappingTransformationRegex:
+		return app_pb.ClaimMappingTransformation_CLAIM_MAPPING_TRANSFORMATION_REGEX
+	case domain.ClaimMappingTransformationNone:
+		return app_pb.ClaimMappingTransformation_CLAIM_MAPPING_TRANSFORMATION_NONE
+	default:
+		return app_pb.ClaimMappingTransformation_CLAIM_MAPPING_TRANSFORMATION_NONE
+	}
+}
+
+func claimMappingTransformationToDomain(transformation app_pb.ClaimMappingTransformation) domain.ClaimMappingTransformation {
+	switch transformation {
+	case app_pb.ClaimMappingTransformation_CLAIM_MAPPING_TRANSFORMATION_LOWERCASE:
+		return domain.ClaimMappingTransformationLowercase
+	case app_pb.ClaimMappingTransformation_CLAIM_MAPPING_TRANSFORMATION_JOIN:
+		return domain.ClaimMappingTransformationJoin
+	case app_pb.ClaimMappingTransformation_CLAIM_MAPPING_TRANSFORMATION_REGEX:
+		return domain.ClaimMappingTransformationRegex
+	case app_pb.ClaimMappingTransformation_CLAIM_MAPPING_TRANSFORMATION_NONE:
+		return domain.ClaimMappingTransformationNone
+	default:
+		return domain.ClaimMappingTransformationNone
+	}
+}
+
+func claimMappingTargetsToPb(targets []domain.ClaimMappingTarget) []app_pb.ClaimMappingTarget {
+	result := make([]app_pb.ClaimMappingTarget, len(targets))
+	for i, target := range targets {
+		switch target {
+		case domain.ClaimMappingTargetIDToken:
+			result[i] = app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_ID_TOKEN
+		case domain.ClaimMappingTargetAccessToken:
+			result[i] = app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_ACCESS_TOKEN
+		case domain.ClaimMappingTargetUserinfo:
+			result[i] = app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_USERINFO
+		case domain.ClaimMappingTargetIntrospection:
+			result[i] = app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_INTROSPECTION
+		case domain.ClaimMappingTargetSAMLResponse:
+			result[i] = app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_SAML_RESPONSE
+		case domain.ClaimMappingTargetUnspecified:
+			result[i] = app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_UNSPECIFIED
+		}
+	}
+	return result
+}
+
+func claimMappingTargetsToDomain(targets []app_pb.ClaimMappingTarget) []domain.ClaimMappingTarget {
+	result := make([]domain.ClaimMappingTarget, len(targets))
+	for i, target := range targets {
+		switch target {
+		case app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_ID_TOKEN:
+			result[i] = domain.ClaimMappingTargetIDToken
+		case app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_ACCESS_TOKEN:
+			result[i] = domain.ClaimMappingTargetAccessToken
+		case app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_USERINFO:
+			result[i] = domain.ClaimMappingTargetUserinfo
+		case app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_INTROSPECTION:
+			result[i] = domain.ClaimMappingTargetIntrospection
+		case app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_SAML_RESPONSE:
+			result[i] = domain.ClaimMappingTargetSAMLResponse
+		case app_pb.ClaimMappingTarget_CLAIM_MAPPING_TARGET_UNSPECIFIED:
+			result[i] = domain.ClaimMappingTargetUnspecified
+		}
+	}
+	return result
+}
@@ -155,3 +155,26 @@ func RoleViewToPb(role *query.ProjectRole) *proj_pb.Role {
 		),
 	}
 }
+
+func AuthorizationDetailTypesToPb(types []*query.AuthorizationDetailType) []*proj_pb.AuthorizationDetailType {
+	o := make([]*proj_pb.AuthorizationDetailType, len(types))
+	for i, detailType := range types {
+		o[i] = AuthorizationDetailTypeToPb(detailType)
+	}
+	return o
+}
+
+func AuthorizationDetailTypeToPb(detailType *query.AuthorizationDetailType) *proj_pb.AuthorizationDetailType {
+	return &proj_pb.AuthorizationDetailType{
+		Type:        detailType.Type,
+		DisplayName: detailType.DisplayName,
+		Actions:     detailType.Actions,
+		Locations:   detailType.Locations,
+		Details: object.ToViewDetailsPb(
+			detailType.Sequence,
+			detailType.CreationDate,
+			detailType.ChangeDate,
+			detailType.ResourceOwner,
+		),
+	}
+}
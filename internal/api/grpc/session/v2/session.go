@@ -114,6 +114,16 @@ func (s *Server) DeleteSession(ctx context.Context, req *session.DeleteSessionRe
 	}, nil
 }
 
+func (s *Server) RespondPushChallenge(ctx context.Context, req *session.RespondPushChallengeRequest) (*session.RespondPushChallengeResponse, error) {
+	details, err := s.command.RespondPushChallenge(ctx, req.GetSessionId(), req.GetChallengeId(), req.GetDeviceId(), req.GetApprove(), req.GetNumber(), req.GetSignature())
+	if err != nil {
+		return nil, err
+	}
+	return &session.RespondPushChallengeResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
 func sessionsToPb(sessions []*query.Session) []*session.Session {
 	s := make([]*session.Session, len(sessions))
 	for i, session := range sessions {
@@ -179,6 +189,7 @@ func factorsToPb(s *query.Session) *session.Factors {
 		Totp:     totpFactorToPb(s.TOTPFactor),
 		OtpSms:   otpFactorToPb(s.OTPSMSFactor),
 		OtpEmail: otpFactorToPb(s.OTPEmailFactor),
+		Push:     pushFactorToPb(s.PushFactor),
 	}
 }
 
@@ -228,6 +239,15 @@ func otpFactorToPb(factor query.SessionOTPFactor) *session.OTPFactor {
 	}
 }
 
+func pushFactorToPb(factor query.SessionPushFactor) *session.PushFactor {
+	if factor.PushCheckedAt.IsZero() {
+		return nil
+	}
+	return &session.PushFactor{
+		VerifiedAt: timestamppb.New(factor.PushCheckedAt),
+	}
+}
+
 func userFactorToPb(factor query.SessionUserFactor) *session.UserFactor {
 	if factor.UserID == "" || factor.UserCheckedAt.IsZero() {
 		return nil
@@ -371,6 +391,9 @@ func (s *Server) checksToCommand(ctx context.Context, checks *session.Checks) ([
 	if otp := checks.GetOtpEmail(); otp != nil {
 		sessionChecks = append(sessionChecks, command.CheckOTPEmail(otp.GetCode()))
 	}
+	if push := checks.GetPush(); push != nil {
+		sessionChecks = append(sessionChecks, command.CheckPush())
+	}
 	return sessionChecks, nil
 }
 
@@ -397,6 +420,11 @@ func (s *Server) challengesToCommand(challenges *session.RequestChallenges, cmds
 		resp.OtpEmail = challenge
 		cmds = append(cmds, cmd)
 	}
+	if req := challenges.GetPush(); req != nil {
+		challenge, cmd := s.createPushChallengeCommand()
+		resp.Push = challenge
+		cmds = append(cmds, cmd)
+	}
 	return resp, cmds, nil
 }
 
@@ -451,6 +479,11 @@ func (s *Server) createOTPEmailChallengeCommand(req *session.RequestChallenges_O
 	}
 }
 
+func (s *Server) createPushChallengeCommand() (*session.Challenges_Push, command.SessionCommand) {
+	challenge := new(session.Challenges_Push)
+	return challenge, s.command.CreatePushChallenge(&challenge.ChallengeId, &challenge.Number)
+}
+
 func userCheck(user *session.CheckUser) (userSearch, error) {
 	if user == nil {
 		return nil, nil
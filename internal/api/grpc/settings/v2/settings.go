@@ -10,6 +10,7 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
 	object_pb "github.com/zitadel/zitadel/pkg/grpc/object/v2beta"
 	"github.com/zitadel/zitadel/pkg/grpc/settings/v2beta"
 )
@@ -116,6 +117,34 @@ func (s *Server) GetActiveIdentityProviders(ctx context.Context, req *settings.G
 	}, nil
 }
 
+func (s *Server) GetIdentityProviderRouting(ctx context.Context, req *settings.GetIdentityProviderRoutingRequest) (*settings.GetIdentityProviderRoutingResponse, error) {
+	routingDomain := domain.LoginNameDomain(req.GetLoginName())
+	if routingDomain == "" {
+		return &settings.GetIdentityProviderRoutingResponse{}, nil
+	}
+	routing, err := s.query.DomainIDPRoutingByDomain(ctx, routingDomain)
+	if zerrors.IsNotFound(err) {
+		return &settings.GetIdentityProviderRoutingResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.query.IDPLoginPolicyLinks(ctx, routing.OrgID, &query.IDPLoginPolicyLinksSearchQuery{}, false)
+	if err != nil {
+		return nil, err
+	}
+	return &settings.GetIdentityProviderRoutingResponse{
+		Details: &object_pb.Details{
+			Sequence:      routing.Sequence,
+			ChangeDate:    timestamppb.New(routing.ChangeDate),
+			ResourceOwner: routing.OrgID,
+		},
+		OrgId:             routing.OrgID,
+		IdentityProviders: identityProvidersToPb(routedIdentityProviders(routing, links.Links)),
+		Force:             routing.Force,
+	}, nil
+}
+
 func (s *Server) GetGeneralSettings(ctx context.Context, _ *settings.GetGeneralSettingsRequest) (*settings.GetGeneralSettingsResponse, error) {
 	instance := authz.GetInstance(ctx)
 	return &settings.GetGeneralSettingsResponse{
@@ -169,6 +169,20 @@ func identityProvidersToPb(idps []*query.IDPLoginPolicyLink) []*settings.Identit
 	return providers
 }
 
+// routedIdentityProviders returns the active identity providers of the routing in the order of the routing.
+func routedIdentityProviders(routing *query.OrgDomainIDPRouting, idps []*query.IDPLoginPolicyLink) []*query.IDPLoginPolicyLink {
+	routed := make([]*query.IDPLoginPolicyLink, 0, len(routing.IDPIDs))
+	for _, id := range routing.IDPIDs {
+		for _, idp := range idps {
+			if idp.IDPID == id {
+				routed = append(routed, idp)
+				break
+			}
+		}
+	}
+	return routed
+}
+
 func identityProviderToPb(idp *query.IDPLoginPolicyLink) *settings.IdentityProvider {
 	return &settings.IdentityProvider{
 		Id:   idp.IDPID,
@@ -201,6 +215,14 @@ func idpTypeToPb(idpType domain.IDPType) settings.IdentityProviderType {
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_GITLAB_SELF_HOSTED
 	case domain.IDPTypeGoogle:
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_GOOGLE
+	case domain.IDPTypeBitbucket:
+		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_BITBUCKET
+	case domain.IDPTypeSlack:
+		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_SLACK
+	case domain.IDPTypeDiscord:
+		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_DISCORD
+	case domain.IDPTypeLinkedIn:
+		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_LINKEDIN
 	default:
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_UNSPECIFIED
 	}
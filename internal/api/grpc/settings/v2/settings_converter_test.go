@@ -385,6 +385,20 @@ func Test_identityProvidersToPb(t *testing.T) {
 	}
 }
 
+func Test_routedIdentityProviders(t *testing.T) {
+	routing := &query.OrgDomainIDPRouting{
+		OrgDomainIDPRouting: domain.OrgDomainIDPRouting{
+			IDPIDs: []string{"3", "1", "2"},
+		},
+	}
+	idps := []*query.IDPLoginPolicyLink{
+		{IDPID: "1"},
+		{IDPID: "3"},
+	}
+	got := routedIdentityProviders(routing, idps)
+	assert.Equal(t, []*query.IDPLoginPolicyLink{{IDPID: "3"}, {IDPID: "1"}}, got)
+}
+
 func Test_idpTypeToPb(t *testing.T) {
 	type args struct {
 		idpType domain.IDPType
@@ -437,6 +451,22 @@ func Test_idpTypeToPb(t *testing.T) {
 			args: args{domain.IDPTypeGoogle},
 			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_GOOGLE,
 		},
+		{
+			args: args{domain.IDPTypeBitbucket},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_BITBUCKET,
+		},
+		{
+			args: args{domain.IDPTypeSlack},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_SLACK,
+		},
+		{
+			args: args{domain.IDPTypeDiscord},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_DISCORD,
+		},
+		{
+			args: args{domain.IDPTypeLinkedIn},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_LINKEDIN,
+		},
 		{
 			args: args{99},
 			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_UNSPECIFIED,
@@ -93,6 +93,23 @@ func (s *Server) RemoveInstance(ctx context.Context, req *system_pb.RemoveInstan
 	}, nil
 }
 
+func (s *Server) RevokeInstanceKeys(ctx context.Context, req *system_pb.RevokeInstanceKeysRequest) (*system_pb.RevokeInstanceKeysResponse, error) {
+	details, err := s.command.RevokeKeyPairs(ctx, req.Reason, req.PrePublishSuccessor, s.signingKeyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.RevokeInstanceKeysResponse{
+		Details: object.ChangeToDetailsPb(details.Sequence, details.EventDate, details.ResourceOwner),
+	}, nil
+}
+
+func (s *Server) MoveInstance(ctx context.Context, req *system_pb.MoveInstanceRequest) (*system_pb.MoveInstanceResponse, error) {
+	if err := s.mover.Start(ctx, req.InstanceId, req.Shard); err != nil {
+		return nil, err
+	}
+	return &system_pb.MoveInstanceResponse{}, nil
+}
+
 func (s *Server) ListIAMMembers(ctx context.Context, req *system_pb.ListIAMMembersRequest) (*system_pb.ListIAMMembersResponse, error) {
 	queries, err := ListIAMMembersRequestToQuery(req)
 	if err != nil {
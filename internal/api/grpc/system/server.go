@@ -8,6 +8,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/sharding"
 	"github.com/zitadel/zitadel/pkg/grpc/system"
 )
 
@@ -24,6 +25,9 @@ type Server struct {
 	query           *query.Queries
 	defaultInstance command.InstanceSetup
 	externalDomain  string
+	mover           *sharding.Mover
+
+	signingKeyAlgorithm string
 }
 
 type Config struct {
@@ -36,6 +40,8 @@ func CreateServer(
 	database string,
 	defaultInstance command.InstanceSetup,
 	externalDomain string,
+	mover *sharding.Mover,
+	signingKeyAlgorithm string,
 ) *Server {
 	return &Server{
 		command:         command,
@@ -43,6 +49,9 @@ func CreateServer(
 		database:        database,
 		defaultInstance: defaultInstance,
 		externalDomain:  externalDomain,
+		mover:           mover,
+
+		signingKeyAlgorithm: signingKeyAlgorithm,
 	}
 }
 
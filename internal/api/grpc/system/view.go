@@ -22,3 +22,19 @@ func (s *Server) ClearView(ctx context.Context, req *system_pb.ClearViewRequest)
 	}
 	return &system_pb.ClearViewResponse{}, nil
 }
+
+func (s *Server) RebuildView(ctx context.Context, req *system_pb.RebuildViewRequest) (*system_pb.RebuildViewResponse, error) {
+	err := s.query.RebuildProjection(ctx, req.ViewName, req.InstanceIds)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.RebuildViewResponse{}, nil
+}
+
+func (s *Server) GetViewRebuildProgress(ctx context.Context, req *system_pb.GetViewRebuildProgressRequest) (*system_pb.GetViewRebuildProgressResponse, error) {
+	state, err := s.query.ProjectionRebuildState(ctx, req.ViewName)
+	if err != nil {
+		return nil, err
+	}
+	return RebuildStateToPb(state), nil
+}
@@ -4,6 +4,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
 	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
 )
 
@@ -23,3 +24,25 @@ func CurrentSequenceToPb(database string, currentSequence *query.CurrentState) *
 		LastSuccessfulSpoolerRun: timestamppb.New(currentSequence.LastRun),
 	}
 }
+
+func RebuildStateToPb(state *projection.RebuildState) *system_pb.GetViewRebuildProgressResponse {
+	resp := &system_pb.GetViewRebuildProgressResponse{
+		Running: state.Running,
+	}
+	if state.Err != nil {
+		resp.Error = state.Err.Error()
+	}
+	if state.Progress == nil {
+		return resp
+	}
+	resp.CaughtUp = state.Progress.CaughtUp()
+	resp.Instances = make([]*system_pb.ViewRebuildInstanceProgress, len(state.Progress.Instances))
+	for i, instance := range state.Progress.Instances {
+		resp.Instances[i] = &system_pb.ViewRebuildInstanceProgress{
+			InstanceId: instance.InstanceID,
+			Position:   instance.Position,
+			Head:       instance.Head,
+		}
+	}
+	return resp
+}
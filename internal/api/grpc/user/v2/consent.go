@@ -0,0 +1,50 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	user "github.com/zitadel/zitadel/pkg/grpc/user/v2beta"
+)
+
+func (s *Server) ListConsents(ctx context.Context, req *user.ListConsentsRequest) (*user.ListConsentsResponse, error) {
+	consents, err := s.query.SearchUserConsents(ctx, req.GetUserId(), &query.UserConsentSearchQueries{})
+	if err != nil {
+		return nil, err
+	}
+	return &user.ListConsentsResponse{
+		Details: object.ToListDetails(consents.SearchResponse),
+		Result:  consentsToPb(consents.Consents),
+	}, nil
+}
+
+func (s *Server) RevokeConsent(ctx context.Context, req *user.RevokeConsentRequest) (*user.RevokeConsentResponse, error) {
+	details, err := s.command.RevokeUserConsent(ctx, req.GetUserId(), req.GetClientId(), "")
+	if err != nil {
+		return nil, err
+	}
+	return &user.RevokeConsentResponse{Details: object.DomainToDetailsPb(details)}, nil
+}
+
+func consentsToPb(consents []*query.UserConsent) []*user.Consent {
+	c := make([]*user.Consent, len(consents))
+	for i, consent := range consents {
+		c[i] = consentToPb(consent)
+	}
+	return c
+}
+
+func consentToPb(consent *query.UserConsent) *user.Consent {
+	return &user.Consent{
+		Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+			Sequence:      consent.Sequence,
+			EventDate:     consent.ChangeDate,
+			ResourceOwner: consent.ResourceOwner,
+		}),
+		ClientId:  consent.ClientID,
+		ProjectId: consent.ProjectID,
+		Scopes:    consent.Scopes,
+	}
+}
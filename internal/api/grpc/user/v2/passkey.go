@@ -8,6 +8,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
 	object_pb "github.com/zitadel/zitadel/pkg/grpc/object/v2beta"
 	user "github.com/zitadel/zitadel/pkg/grpc/user/v2beta"
@@ -121,3 +122,40 @@ func passkeyCodeDetailsToPb(details *domain.PasskeyCodeDetails, err error) (*use
 		},
 	}, nil
 }
+
+func (s *Server) ListPasskeys(ctx context.Context, req *user.ListPasskeysRequest) (*user.ListPasskeysResponse, error) {
+	passkeys, err := s.query.ListUserPasskeys(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	return &user.ListPasskeysResponse{
+		Details: object.ToListDetails(passkeys.SearchResponse),
+		Result:  passkeysToPb(passkeys.AuthMethods),
+	}, nil
+}
+
+func passkeysToPb(methods []*query.AuthMethod) []*user.Passkey {
+	passkeys := make([]*user.Passkey, len(methods))
+	for i, method := range methods {
+		passkeys[i] = &user.Passkey{
+			Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+				Sequence:      method.Sequence,
+				EventDate:     method.ChangeDate,
+				ResourceOwner: method.ResourceOwner,
+			}),
+			Id:   method.TokenID,
+			Name: method.Name,
+		}
+	}
+	return passkeys
+}
+
+func (s *Server) RemovePasskey(ctx context.Context, req *user.RemovePasskeyRequest) (*user.RemovePasskeyResponse, error) {
+	objectDetails, err := s.command.RemoveUserPasskey(ctx, req.GetUserId(), authz.GetCtxData(ctx).OrgID, req.GetPasskeyId())
+	if err != nil {
+		return nil, err
+	}
+	return &user.RemovePasskeyResponse{
+		Details: object.DomainToDetailsPb(objectDetails),
+	}, nil
+}
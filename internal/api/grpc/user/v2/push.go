@@ -0,0 +1,37 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	user "github.com/zitadel/zitadel/pkg/grpc/user/v2beta"
+)
+
+func (s *Server) AddPushDevice(ctx context.Context, req *user.AddPushDeviceRequest) (*user.AddPushDeviceResponse, error) {
+	registration, err := s.command.AddHumanPushDevice(ctx, req.GetUserId(), authz.GetCtxData(ctx).OrgID, req.GetName(), req.GetPushToken(), req.GetPublicKey())
+	if err != nil {
+		return nil, err
+	}
+	return &user.AddPushDeviceResponse{
+		Details:   object.DomainToDetailsPb(registration.ObjectDetails),
+		DeviceId:  registration.DeviceID,
+		Challenge: registration.Challenge,
+	}, nil
+}
+
+func (s *Server) VerifyPushDevice(ctx context.Context, req *user.VerifyPushDeviceRequest) (*user.VerifyPushDeviceResponse, error) {
+	objectDetails, err := s.command.VerifyHumanPushDevice(ctx, req.GetUserId(), authz.GetCtxData(ctx).OrgID, req.GetDeviceId(), req.GetSignature())
+	if err != nil {
+		return nil, err
+	}
+	return &user.VerifyPushDeviceResponse{Details: object.DomainToDetailsPb(objectDetails)}, nil
+}
+
+func (s *Server) RemovePushDevice(ctx context.Context, req *user.RemovePushDeviceRequest) (*user.RemovePushDeviceResponse, error) {
+	objectDetails, err := s.command.RemoveHumanPushDevice(ctx, req.GetUserId(), authz.GetCtxData(ctx).OrgID, req.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	return &user.RemovePushDeviceResponse{Details: object.DomainToDetailsPb(objectDetails)}, nil
+}
@@ -486,6 +486,11 @@ func (s *Server) ldapLogin(ctx context.Context, idpID, username, password string
 	if err != nil {
 		return nil, "", nil, err
 	}
+	if userID != "" {
+		if _, err = s.command.SyncIDPUserGrants(ctx, idpID, userID, externalUser, session); err != nil {
+			return nil, "", nil, err
+		}
+	}
 
 	attributes := make(map[string][]string, 0)
 	for _, item := range session.Entry.Attributes {
@@ -645,6 +650,8 @@ func authMethodTypeToPb(methodType domain.UserAuthMethodType) user.Authenticatio
 		return user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_OTP_SMS
 	case domain.UserAuthMethodTypeOTPEmail:
 		return user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_OTP_EMAIL
+	case domain.UserAuthMethodTypePush:
+		return user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_PUSH
 	case domain.UserAuthMethodTypeUnspecified:
 		return user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_UNSPECIFIED
 	default:
@@ -18,18 +18,23 @@ import (
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/form"
 	"github.com/zitadel/zitadel/internal/idp"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/bitbucket"
+	"github.com/zitadel/zitadel/internal/idp/providers/discord"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
 	"github.com/zitadel/zitadel/internal/idp/providers/google"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/idp/providers/linkedin"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
 	saml2 "github.com/zitadel/zitadel/internal/idp/providers/saml"
+	"github.com/zitadel/zitadel/internal/idp/providers/slack"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -262,6 +267,15 @@ func (h *Handler) handleACS(w http.ResponseWriter, r *http.Request) {
 	userID, err := h.checkExternalUser(ctx, intent.IDPID, idpUser.GetID())
 	logging.WithFields("intent", intent.AggregateID).OnError(err).Error("could not check if idp user already exists")
 
+	if userID == "" {
+		userID, err = h.tryAutoLinkExternalUser(ctx, intent.IDPID, idpUser)
+		logging.WithFields("intent", intent.AggregateID).OnError(err).Error("auto linking failed")
+	}
+	if err = h.syncUserGrants(ctx, intent, userID, idpUser, &session); err != nil {
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+
 	token, err := h.commands.SucceedSAMLIDPIntent(ctx, intent, idpUser, userID, session.Assertion)
 	if err != nil {
 		redirectToFailureURLErr(w, r, intent, zerrors.ThrowInternal(err, "IDP-JdD3g", "Errors.Intent.TokenCreationFailed"))
@@ -317,6 +331,14 @@ func (h *Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
 		userID, err = h.tryMigrateExternalUser(ctx, intent.IDPID, idpUser, idpSession)
 		logging.WithFields("intent", intent.AggregateID).OnError(err).Error("migration check failed")
 	}
+	if userID == "" {
+		userID, err = h.tryAutoLinkExternalUser(ctx, intent.IDPID, idpUser)
+		logging.WithFields("intent", intent.AggregateID).OnError(err).Error("auto linking failed")
+	}
+	if err = h.syncUserGrants(ctx, intent, userID, idpUser, idpSession); err != nil {
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
 
 	token, err := h.commands.SucceedIDPIntent(ctx, intent, idpUser, idpSession, userID)
 	if err != nil {
@@ -326,6 +348,21 @@ func (h *Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
 	redirectToSuccessURL(w, r, intent, token, userID)
 }
 
+// syncUserGrants grants the roles mapped by the role mapping of the provider to the user the federated user is linked to.
+// The intent fails if the grants can't be synced.
+func (h *Handler) syncUserGrants(ctx context.Context, intent *command.IDPIntentWriteModel, userID string, idpUser idp.User, idpSession idp.Session) error {
+	if userID == "" {
+		return nil
+	}
+	_, err := h.commands.SyncIDPUserGrants(ctx, intent.IDPID, userID, idpUser, idpSession)
+	if err == nil {
+		return nil
+	}
+	cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
+	logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
+	return err
+}
+
 func (h *Handler) tryMigrateExternalUser(ctx context.Context, idpID string, idpUser idp.User, idpSession idp.Session) (userID string, err error) {
 	migration, ok := idpSession.(idp.SessionSupportsMigration)
 	if !ok {
@@ -342,6 +379,51 @@ func (h *Handler) tryMigrateExternalUser(ctx context.Context, idpID string, idpU
 	return userID, h.commands.MigrateUserIDP(ctx, userID, "", idpID, previousID, idpUser.GetID())
 }
 
+// tryAutoLinkExternalUser links the external user to the existing user with the same username or email address,
+// if the IDP has the automatic linking enabled and is trusted to assert it.
+// It returns the id of the linked user or an empty string if no or more than one user matched.
+func (h *Handler) tryAutoLinkExternalUser(ctx context.Context, idpID string, idpUser idp.User) (userID string, err error) {
+	provider, err := h.queries.IDPTemplateByID(ctx, false, idpID, false)
+	if err != nil {
+		return "", err
+	}
+	if !provider.IsLinkingAllowed {
+		return "", nil
+	}
+	linking, err := h.queries.IDPAutoLinkingByIDPID(ctx, provider.ID, provider.ResourceOwner)
+	if zerrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	value, ok := linking.LinkingValue(idpUser.GetPreferredUsername(), string(idpUser.GetEmail()), idpUser.IsEmailVerified())
+	if !ok {
+		return "", nil
+	}
+	// users must only be linked to providers of their own organization
+	var resourceOwner string
+	if provider.OwnerType == domain.IdentityProviderTypeOrg {
+		resourceOwner = provider.ResourceOwner
+	}
+	user, err := h.queries.IDPAutoLinkingUser(ctx, linking.Option, value, resourceOwner)
+	if zerrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	_, err = h.commands.AutoLinkUserIDP(ctx, user.ID, user.ResourceOwner, &command.AddLink{
+		IDPID:         idpID,
+		DisplayName:   idpUser.GetPreferredUsername(),
+		IDPExternalID: idpUser.GetID(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return user.ID, nil
+}
+
 func (h *Handler) parseCallbackRequest(r *http.Request) (*externalIDPCallbackData, error) {
 	data := new(externalIDPCallbackData)
 	err := h.parser.Parse(r, data)
@@ -395,6 +477,14 @@ func (h *Handler) fetchIDPUserFromCode(ctx context.Context, identityProvider idp
 	case *azuread.Provider:
 		session = &azuread.Session{Provider: provider, Code: code}
 	case *github.Provider:
+		session = &github.Session{Provider: provider, Code: code}
+	case *bitbucket.Provider:
+		session = &bitbucket.Session{Provider: provider, Code: code}
+	case *slack.Provider:
+		session = &oauth.Session{Provider: provider.Provider, Code: code}
+	case *discord.Provider:
+		session = &oauth.Session{Provider: provider.Provider, Code: code}
+	case *linkedin.Provider:
 		session = &oauth.Session{Provider: provider.Provider, Code: code}
 	case *gitlab.Provider:
 		session = &openid.Session{Provider: provider.Provider, Code: code}
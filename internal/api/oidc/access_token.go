@@ -10,6 +10,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/op"
 
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/user/model"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -25,6 +26,9 @@ type accessToken struct {
 	tokenCreation   time.Time
 	tokenExpiration time.Time
 	isPAT           bool
+
+	authorizationDetails  domain.AuthorizationDetails
+	certificateThumbprint string
 }
 
 var ErrInvalidTokenFormat = errors.New("invalid token format")
@@ -73,6 +77,9 @@ func accessTokenV1(tokenID, subject string, token *model.TokenView) *accessToken
 		tokenCreation:   token.CreationDate,
 		tokenExpiration: token.Expiration,
 		isPAT:           token.IsPAT,
+
+		certificateThumbprint: token.CertificateThumbprint,
+		authorizationDetails:  token.AuthorizationDetails,
 	}
 }
 
@@ -86,6 +93,9 @@ func accessTokenV2(tokenID, subject string, token *query.OIDCSessionAccessTokenR
 		scope:           token.Scope,
 		tokenCreation:   token.AccessTokenCreation,
 		tokenExpiration: token.AccessTokenExpiration,
+
+		authorizationDetails:  token.AuthorizationDetails,
+		certificateThumbprint: token.CertificateThumbprint,
 	}
 }
 
@@ -14,6 +14,8 @@ const (
 	OTP = "otp"
 	// UserPresence states that the end users presence has been verified (e.g. passkey and u2f)
 	UserPresence = "user"
+	// SWK states that the possession of a software-secured key has been proven (e.g. push approval on a device)
+	SWK = "swk"
 )
 
 // AuthMethodTypesToAMR maps zitadel auth method types to Authentication Method Reference Values
@@ -40,6 +42,9 @@ func AuthMethodTypesToAMR(methodTypes []domain.UserAuthMethodType) []string {
 			// a user could use multiple (t)otp, which is a factor, but still will be returned as a single `otp` entry
 			otp++
 			factors++
+		case domain.UserAuthMethodTypePush:
+			amr = append(amr, SWK)
+			factors++
 		case domain.UserAuthMethodTypeIDP:
 			// no AMR value according to specification
 			factors++
@@ -66,6 +66,13 @@ func TestAMR(t *testing.T) {
 			},
 			[]string{OTP},
 		},
+		{
+			"password and push checked",
+			args{
+				[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword, domain.UserAuthMethodTypePush},
+			},
+			[]string{PWD, SWK, MFA},
+		},
 		{
 			"multiple (t)otp checked",
 			args{
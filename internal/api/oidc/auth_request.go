@@ -55,6 +55,10 @@ func (o *OPStorage) createAuthRequestLoginClient(ctx context.Context, req *oidc.
 		return nil, err
 	}
 	audience = domain.AddAudScopeToAudience(ctx, audience, scope)
+	authorizationDetails, err := o.assertAuthorizationDetails(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
 	authRequest := &command.AuthRequest{
 		LoginClient:   loginClient,
 		ClientID:      req.ClientID,
@@ -68,6 +72,8 @@ func (o *OPStorage) createAuthRequestLoginClient(ctx context.Context, req *oidc.
 		Prompt:        PromptToBusiness(req.Prompt),
 		UILocales:     UILocalesToBusiness(req.UILocales),
 		MaxAge:        MaxAgeToBusiness(req.MaxAge),
+
+		AuthorizationDetails: authorizationDetails,
 	}
 	if req.LoginHint != "" {
 		authRequest.LoginHint = &req.LoginHint
@@ -93,6 +99,16 @@ func (o *OPStorage) createAuthRequest(ctx context.Context, req *oidc.AuthRequest
 		return nil, zerrors.ThrowPreconditionFailed(err, "OIDC-Gqrfg", "Errors.Internal")
 	}
 	authRequest := CreateAuthRequestToBusiness(ctx, req, userAgentID, userID)
+	if len(requestedAuthorizationDetails(ctx)) > 0 {
+		project, err := o.query.ProjectByClientID(ctx, req.ClientID)
+		if err != nil {
+			return nil, err
+		}
+		authRequest.Request.(*domain.AuthRequestOIDC).AuthorizationDetails, err = o.assertAuthorizationDetails(ctx, project.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
 	resp, err := o.repo.CreateAuthRequest(ctx, authRequest)
 	if err != nil {
 		return nil, err
@@ -208,16 +224,28 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 		span.EndWithError(err)
 	}()
 
-	var userAgentID, applicationID, userOrgID string
+	var (
+		userAgentID, applicationID, userOrgID string
+		authorizationDetails                  domain.AuthorizationDetails
+	)
 	switch authReq := req.(type) {
 	case *AuthRequest:
 		userAgentID = authReq.AgentID
 		applicationID = authReq.ApplicationID
 		userOrgID = authReq.UserOrgID
+		authorizationDetails, err = narrowAuthorizationDetails(ctx, authReq.GetAuthorizationDetails())
+		if err != nil {
+			return "", time.Time{}, err
+		}
 	case *AuthRequestV2:
 		// trigger activity log for authentication for user
 		activity.Trigger(ctx, "", authReq.CurrentAuthRequest.UserID, activity.OIDCAccessToken)
-		return o.command.AddOIDCSessionAccessToken(setContextUserSystem(ctx), authReq.GetID())
+		tokenID, expiration, err := o.command.AddOIDCSessionAccessToken(setContextUserSystem(ctx), authReq.GetID(), requestedAuthorizationDetails(ctx), certificateThumbprintFromContext(ctx))
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		setGrantedAuthorizationDetails(ctx, grantedOrRequested(authReq.AuthorizationDetails, requestedAuthorizationDetails(ctx)))
+		return tokenID, expiration, nil
 	case op.IDTokenRequest:
 		applicationID = authReq.GetClientID()
 	}
@@ -227,10 +255,11 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 		return "", time.Time{}, err
 	}
 
-	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime) //PLANNED: lifetime from client
+	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime, certificateThumbprintFromContext(ctx), authorizationDetails) //PLANNED: lifetime from client
 	if err != nil {
 		return "", time.Time{}, err
 	}
+	setGrantedAuthorizationDetails(ctx, resp.AuthorizationDetails)
 
 	// trigger activity log for authentication for user
 	activity.Trigger(ctx, userOrgID, req.GetSubject(), activity.OIDCAccessToken)
@@ -249,11 +278,30 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	case *AuthRequestV2:
 		// trigger activity log for authentication for user
 		activity.Trigger(ctx, "", tokenReq.GetSubject(), activity.OIDCRefreshToken)
-		return o.command.AddOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.GetID())
+		accessTokenID, newRefreshToken, expiration, err := o.command.AddOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.GetID(), requestedAuthorizationDetails(ctx), certificateThumbprintFromContext(ctx))
+		if err != nil {
+			return "", "", time.Time{}, err
+		}
+		setGrantedAuthorizationDetails(ctx, grantedOrRequested(tokenReq.AuthorizationDetails, requestedAuthorizationDetails(ctx)))
+		return accessTokenID, newRefreshToken, expiration, nil
 	case *RefreshTokenRequestV2:
 		// trigger activity log for authentication for user
 		activity.Trigger(ctx, "", tokenReq.GetSubject(), activity.OIDCRefreshToken)
-		return o.command.ExchangeOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.OIDCSessionWriteModel.AggregateID, refreshToken, tokenReq.RequestedScopes)
+		reuseGracePeriod, err := o.refreshTokenReuseGracePeriodOfClient(ctx, tokenReq.GetClientID())
+		if err != nil {
+			return "", "", time.Time{}, err
+		}
+		accessTokenID, newRefreshToken, expiration, err := o.command.ExchangeOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.OIDCSessionWriteModel.AggregateID, refreshToken, tokenReq.RequestedScopes, requestedAuthorizationDetails(ctx), certificateThumbprintFromContext(ctx), reuseGracePeriod)
+		if err != nil {
+			return "", "", time.Time{}, err
+		}
+		setGrantedAuthorizationDetails(ctx, grantedOrRequested(tokenReq.OIDCSessionWriteModel.AuthorizationDetails, requestedAuthorizationDetails(ctx)))
+		return accessTokenID, newRefreshToken, expiration, nil
+	}
+	// the details of a new refresh token are granted by the auth request, renewed ones keep their details
+	var grantedAuthorizationDetails domain.AuthorizationDetails
+	if authReq, ok := req.(*AuthRequest); ok {
+		grantedAuthorizationDetails = authReq.GetAuthorizationDetails()
 	}
 
 	userAgentID, applicationID, userOrgID, authTime, authMethodsReferences := getInfoFromRequest(req)
@@ -269,22 +317,44 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
+	var reuseGracePeriod time.Duration
+	if refreshToken != "" {
+		reuseGracePeriod, err = o.refreshTokenReuseGracePeriodOfClient(ctx, applicationID)
+		if err != nil {
+			return "", "", time.Time{}, err
+		}
+	}
 
 	resp, token, err := o.command.AddAccessAndRefreshToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(),
 		refreshToken, req.GetAudience(), scopes, authMethodsReferences, accessTokenLifetime,
-		refreshTokenIdleExpiration, refreshTokenExpiration, authTime) //PLANNED: lifetime from client
+		refreshTokenIdleExpiration, refreshTokenExpiration, reuseGracePeriod, authTime, certificateThumbprintFromContext(ctx),
+		grantedAuthorizationDetails, requestedAuthorizationDetails(ctx)) //PLANNED: lifetime from client
 	if err != nil {
 		if zerrors.IsErrorInvalidArgument(err) {
 			err = oidc.ErrInvalidGrant().WithParent(err)
 		}
 		return "", "", time.Time{}, err
 	}
+	setGrantedAuthorizationDetails(ctx, resp.AuthorizationDetails)
 
 	// trigger activity log for authentication for user
 	activity.Trigger(ctx, userOrgID, req.GetSubject(), activity.OIDCRefreshToken)
 	return resp.TokenID, token, resp.Expiration, nil
 }
 
+// refreshTokenReuseGracePeriodOfClient returns the refresh token reuse grace period of the application,
+// or the configured default if the application doesn't set one.
+func (o *OPStorage) refreshTokenReuseGracePeriodOfClient(ctx context.Context, clientID string) (time.Duration, error) {
+	client, err := o.query.GetOIDCClientByID(ctx, clientID, false)
+	if err != nil {
+		return 0, err
+	}
+	if client.RefreshTokenReuseGracePeriod > 0 {
+		return client.RefreshTokenReuseGracePeriod, nil
+	}
+	return o.refreshTokenReuseGracePeriod, nil
+}
+
 func getInfoFromRequest(req op.TokenRequest) (string, string, string, time.Time, []string) {
 	switch r := req.(type) {
 	case *AuthRequest:
@@ -310,7 +380,8 @@ func (o *OPStorage) TokenRequestByRefreshToken(ctx context.Context, refreshToken
 		return nil, err
 	}
 	if strings.HasPrefix(plainToken, command.IDPrefixV2) {
-		oidcSession, err := o.command.OIDCSessionByRefreshToken(ctx, plainToken)
+		// the token itself is checked on the exchange to be able to detect the reuse of an already rotated token
+		oidcSession, err := o.command.OIDCSessionForRefreshTokenGrant(ctx, plainToken)
 		if err != nil {
 			return nil, err
 		}
@@ -319,7 +390,13 @@ func (o *OPStorage) TokenRequestByRefreshToken(ctx context.Context, refreshToken
 		return &RefreshTokenRequestV2{OIDCSessionWriteModel: oidcSession}, nil
 	}
 
-	tokenView, err := o.repo.RefreshTokenByToken(ctx, refreshToken)
+	// the token is only resolved by its id here,
+	// the token itself is checked on renewal to be able to detect the reuse of an already rotated token
+	userID, tokenID, _, err := domain.FromRefreshToken(refreshToken, o.encAlg)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "OIDC-ahC6o", "Errors.User.RefreshToken.Invalid")
+	}
+	tokenView, err := o.repo.RefreshTokenByID(ctx, tokenID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -554,6 +631,18 @@ func (o *OPStorage) getOIDCSettings(ctx context.Context) (accessTokenLifetime, i
 	return o.defaultAccessTokenLifetime, o.defaultIdTokenLifetime, o.defaultRefreshTokenIdleExpiration, o.defaultRefreshTokenExpiration, nil
 }
 
+// AuthErrorCallbackURL builds the url, which returns the error to the client
+// and is used by the login UI if the user aborts an auth request (e.g. denies the consent).
+func (s *Server) AuthErrorCallbackURL() func(ctx context.Context, authReq *domain.AuthRequest, reason, description string) (string, error) {
+	return func(ctx context.Context, authReq *domain.AuthRequest, reason, description string) (string, error) {
+		req, err := AuthRequestFromBusiness(authReq)
+		if err != nil {
+			return "", err
+		}
+		return CreateErrorCallbackURL(req, reason, description, "", s.Provider())
+	}
+}
+
 func CreateErrorCallbackURL(authReq op.AuthRequest, reason, description, uri string, authorizer op.Authorizer) (string, error) {
 	e := struct {
 		Error       string `schema:"error"`
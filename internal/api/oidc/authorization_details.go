@@ -0,0 +1,108 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// invalidAuthorizationDetails is the error code defined by RFC 9396
+// for invalid, unknown or not allowed authorization details.
+const invalidAuthorizationDetails = "invalid_authorization_details"
+
+type requestedAuthorizationDetailsKey struct{}
+
+type grantedAuthorizationDetailsKey struct{}
+
+// grantedAuthorizationDetails passes the authorization details of a created access token
+// to the claims of a JWT access token, since the OIDC library only provides the scopes there.
+type grantedAuthorizationDetails struct {
+	details domain.AuthorizationDetails
+}
+
+// contextWithRequestedAuthorizationDetails parses the `authorization_details` parameter of an auth or token request
+// and sets them on the context, so they are available when the OIDC library calls the [OPStorage].
+func contextWithRequestedAuthorizationDetails(ctx context.Context, form url.Values) (context.Context, error) {
+	details, err := domain.ParseAuthorizationDetails(form.Get(domain.AuthorizationDetailsParam))
+	if err != nil {
+		return nil, errInvalidAuthorizationDetails(err)
+	}
+	if len(details) == 0 {
+		return ctx, nil
+	}
+	return context.WithValue(ctx, requestedAuthorizationDetailsKey{}, details), nil
+}
+
+func requestedAuthorizationDetails(ctx context.Context) domain.AuthorizationDetails {
+	details, _ := ctx.Value(requestedAuthorizationDetailsKey{}).(domain.AuthorizationDetails)
+	return details
+}
+
+func contextWithGrantedAuthorizationDetails(ctx context.Context) context.Context {
+	return context.WithValue(ctx, grantedAuthorizationDetailsKey{}, new(grantedAuthorizationDetails))
+}
+
+func setGrantedAuthorizationDetails(ctx context.Context, details domain.AuthorizationDetails) {
+	if granted, ok := ctx.Value(grantedAuthorizationDetailsKey{}).(*grantedAuthorizationDetails); ok {
+		granted.details = details
+	}
+}
+
+func grantedAuthorizationDetailsFromContext(ctx context.Context) domain.AuthorizationDetails {
+	granted, ok := ctx.Value(grantedAuthorizationDetailsKey{}).(*grantedAuthorizationDetails)
+	if !ok {
+		return nil
+	}
+	return granted.details
+}
+
+// assertAuthorizationDetails validates the requested authorization details
+// against the types registered on the project.
+func (o *OPStorage) assertAuthorizationDetails(ctx context.Context, projectID string) (domain.AuthorizationDetails, error) {
+	details := requestedAuthorizationDetails(ctx)
+	if len(details) == 0 {
+		return nil, nil
+	}
+	types, err := o.query.AuthorizationDetailTypesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err = domain.ValidateAuthorizationDetails(details, types.ToDomain()); err != nil {
+		return nil, errInvalidAuthorizationDetails(err)
+	}
+	return details, nil
+}
+
+// narrowAuthorizationDetails returns the details requested on the token endpoint,
+// which must be part of the granted ones. If none were requested, all granted details are returned.
+func narrowAuthorizationDetails(ctx context.Context, granted domain.AuthorizationDetails) (domain.AuthorizationDetails, error) {
+	requested := requestedAuthorizationDetails(ctx)
+	if len(requested) == 0 {
+		return granted, nil
+	}
+	if !granted.Contains(requested) {
+		return nil, errInvalidAuthorizationDetails(
+			zerrors.ThrowInvalidArgument(nil, "OIDC-Ohs3e", "Errors.OIDCSession.AuthorizationDetailsNotGranted"),
+		)
+	}
+	return requested, nil
+}
+
+// grantedOrRequested returns the requested authorization details,
+// which were already checked against the granted ones by the command side.
+func grantedOrRequested(granted, requested domain.AuthorizationDetails) domain.AuthorizationDetails {
+	if len(requested) > 0 {
+		return requested
+	}
+	return granted
+}
+
+func errInvalidAuthorizationDetails(parent error) *oidc.Error {
+	return (&oidc.Error{
+		ErrorType: invalidAuthorizationDetails,
+	}).WithParent(parent).WithDescription("invalid authorization_details")
+}
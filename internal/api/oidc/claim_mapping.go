@@ -0,0 +1,42 @@
+package oidc
+
+import (
+	"context"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// setUserInfoClaimMappings adds the claims of the client's claim mappings for the target to the userInfo.
+// Claims which are already set (e.g. by the scopes or actions) are not overwritten.
+func setUserInfoClaimMappings(ctx context.Context, q *query.Queries, userInfo *oidc.UserInfo, userID, clientID string, target domain.ClaimMappingTarget) error {
+	claims, err := q.ClaimMappingClaims(ctx, clientID, userID, target)
+	if err != nil {
+		return err
+	}
+	for claim, value := range claims {
+		if _, ok := userInfo.Claims[claim]; ok {
+			continue
+		}
+		userInfo.AppendClaims(claim, value)
+	}
+	return nil
+}
+
+// appendClaimMappings adds the claims of the client's claim mappings for the target to the claims.
+// Claims which are already set are not overwritten.
+func appendClaimMappings(ctx context.Context, q *query.Queries, claims map[string]interface{}, userID, clientID string, target domain.ClaimMappingTarget) (map[string]interface{}, error) {
+	mappedClaims, err := q.ClaimMappingClaims(ctx, clientID, userID, target)
+	if err != nil {
+		return nil, err
+	}
+	for claim, value := range mappedClaims {
+		if _, ok := claims[claim]; ok {
+			continue
+		}
+		claims = appendClaim(claims, claim, value)
+	}
+	return claims, nil
+}
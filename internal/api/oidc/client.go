@@ -129,7 +129,13 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 		if err = o.isOriginAllowed(ctx, token.ClientID, origin); err != nil {
 			return err
 		}
-		return o.setUserinfo(ctx, userInfo, token.UserID, token.ClientID, token.Scope, nil)
+		if err = checkCertificateBinding(ctx, token.CertificateThumbprint); err != nil {
+			return err
+		}
+		if err = o.setUserinfo(ctx, userInfo, token.UserID, token.ClientID, token.Scope, nil); err != nil {
+			return err
+		}
+		return setUserInfoClaimMappings(ctx, o.query, userInfo, token.UserID, token.ClientID, domain.ClaimMappingTargetUserinfo)
 	}
 
 	token, err := o.repo.TokenByIDs(ctx, subject, tokenID)
@@ -141,7 +147,13 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 			return err
 		}
 	}
-	return o.setUserinfo(ctx, userInfo, token.UserID, token.ApplicationID, token.Scopes, nil)
+	if err = checkCertificateBinding(ctx, token.CertificateThumbprint); err != nil {
+		return err
+	}
+	if err = o.setUserinfo(ctx, userInfo, token.UserID, token.ApplicationID, token.Scopes, nil); err != nil {
+		return err
+	}
+	return setUserInfoClaimMappings(ctx, o.query, userInfo, token.UserID, token.ApplicationID, domain.ClaimMappingTargetUserinfo)
 }
 
 func (o *OPStorage) SetUserinfoFromScopes(ctx context.Context, userInfo *oidc.UserInfo, userID, applicationID string, scopes []string) (err error) {
@@ -162,7 +174,10 @@ func (o *OPStorage) SetUserinfoFromScopes(ctx context.Context, userInfo *oidc.Us
 			}
 		}
 	}
-	return o.setUserinfo(ctx, userInfo, userID, applicationID, scopes, nil)
+	if err = o.setUserinfo(ctx, userInfo, userID, applicationID, scopes, nil); err != nil {
+		return err
+	}
+	return setUserInfoClaimMappings(ctx, o.query, userInfo, userID, applicationID, domain.ClaimMappingTargetIDToken)
 }
 
 // SetUserinfoFromRequest extends the SetUserinfoFromScopes during the id_token generation.
@@ -194,7 +209,7 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 			return zerrors.ThrowPermissionDenied(nil, "OIDC-Adfg5", "client not found")
 		}
 		return o.introspect(ctx, introspection,
-			tokenID, token.UserID, token.ClientID, clientID, projectID,
+			tokenID, token.UserID, token.ClientID, clientID, projectID, token.CertificateThumbprint,
 			token.Audience, token.Scope,
 			token.AccessTokenCreation, token.AccessTokenExpiration)
 	}
@@ -214,7 +229,7 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 		}
 	}
 	return o.introspect(ctx, introspection,
-		token.ID, token.UserID, token.ApplicationID, clientID, projectID,
+		token.ID, token.UserID, token.ApplicationID, clientID, projectID, token.CertificateThumbprint,
 		token.Audience, token.Scopes,
 		token.CreationDate, token.Expiration)
 }
@@ -266,7 +281,7 @@ func (o *OPStorage) isOriginAllowed(ctx context.Context, clientID, origin string
 func (o *OPStorage) introspect(
 	ctx context.Context,
 	introspection *oidc.IntrospectionResponse,
-	tokenID, subject, tokenClientID, introspectionClientID, introspectionProjectID string,
+	tokenID, subject, tokenClientID, introspectionClientID, introspectionProjectID, certificateThumbprint string,
 	audience, scope []string,
 	tokenCreation, tokenExpiration time.Time,
 ) (err error) {
@@ -280,6 +295,10 @@ func (o *OPStorage) introspect(
 			if err != nil {
 				return err
 			}
+			err = setUserInfoClaimMappings(ctx, o.query, userInfo, subject, tokenClientID, domain.ClaimMappingTargetIntrospection)
+			if err != nil {
+				return err
+			}
 			introspection.SetUserInfo(userInfo)
 			introspection.Scope = scope
 			introspection.ClientID = tokenClientID
@@ -290,6 +309,12 @@ func (o *OPStorage) introspect(
 			introspection.Audience = audience
 			introspection.Issuer = op.IssuerFromContext(ctx)
 			introspection.JWTID = tokenID
+			if certificateThumbprint != "" {
+				if introspection.Claims == nil {
+					introspection.Claims = make(map[string]any)
+				}
+				introspection.Claims[ClaimConfirmation] = confirmationClaim(certificateThumbprint)
+			}
 			return nil
 		}
 	}
@@ -658,8 +683,18 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 			claims = appendClaim(claims, fmt.Sprintf(ClaimProjectRolesFormat, projectID), roles)
 		}
 	}
+	if authorizationDetails := grantedAuthorizationDetailsFromContext(ctx); len(authorizationDetails) > 0 {
+		claims = appendClaim(claims, domain.AuthorizationDetailsClaim, authorizationDetails)
+	}
+	if thumbprint := certificateThumbprintFromContext(ctx); thumbprint != "" {
+		claims = appendClaim(claims, ClaimConfirmation, confirmationClaim(thumbprint))
+	}
 
-	return o.privateClaimsFlows(ctx, userID, userGrants, claims)
+	claims, err = o.privateClaimsFlows(ctx, userID, userGrants, claims)
+	if err != nil {
+		return nil, err
+	}
+	return appendClaimMappings(ctx, o.query, claims, userID, clientID, domain.ClaimMappingTargetAccessToken)
 }
 
 func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userGrants *query.UserGrants, claims map[string]interface{}) (map[string]interface{}, error) {
@@ -986,7 +1021,8 @@ func (s *Server) VerifyClient(ctx context.Context, r *op.Request[op.ClientCreden
 	if err != nil {
 		return nil, err
 	}
-	client, err := s.query.GetOIDCClientByID(ctx, clientID, assertion)
+	// keys are also needed to verify a self signed client certificate
+	client, err := s.query.GetOIDCClientByID(ctx, clientID, assertion || clientCertificateFromContext(ctx) != nil)
 	if zerrors.IsNotFound(err) {
 		return nil, oidc.ErrInvalidClient().WithParent(err).WithDescription("client not found")
 	}
@@ -1008,6 +1044,10 @@ func (s *Server) VerifyClient(ctx context.Context, r *op.Request[op.ClientCreden
 		err = s.verifyClientSecret(ctx, client, r.Data.ClientSecret)
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		err = s.verifyClientAssertion(ctx, client, r.Data.ClientAssertion)
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		err = s.verifyTLSClientAuth(ctx, client)
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		err = verifySelfSignedTLSClientAuth(ctx, client)
 	case domain.OIDCAuthMethodTypeNone:
 	}
 	if err != nil {
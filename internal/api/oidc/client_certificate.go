@@ -0,0 +1,228 @@
+package oidc
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	AuthMethodTLSClientAuth           oidc.AuthMethod = "tls_client_auth"
+	AuthMethodSelfSignedTLSClientAuth oidc.AuthMethod = "self_signed_tls_client_auth"
+
+	// ClaimConfirmation is the confirmation claim defined by RFC 7800,
+	// containing the thumbprint of the certificate the token is bound to (RFC 8705).
+	ClaimConfirmation   = "cnf"
+	ConfirmationX5TS256 = "x5t#S256"
+)
+
+// ClientCertificateConfig configures mutual TLS client authentication
+// and certificate-bound access tokens (RFC 8705).
+type ClientCertificateConfig struct {
+	Enabled bool
+	// TrustedProxyHeader is the header in which a TLS terminating proxy forwards
+	// the URL encoded PEM of the client certificate.
+	TrustedProxyHeader string
+	// TrustedProxies are the networks (CIDR) of the proxies allowed to set the TrustedProxyHeader.
+	// The header is ignored on requests of any other peer.
+	TrustedProxies []string
+	// CACertificates are paths to PEM files of the authorities
+	// trusted to issue certificates for tls_client_auth.
+	CACertificates []string
+}
+
+var errInvalidClientCertificate = errors.New("invalid client certificate")
+
+func (c *ClientCertificateConfig) certPool() (*x509.CertPool, error) {
+	if c == nil || !c.Enabled || len(c.CACertificates) == 0 {
+		return nil, nil
+	}
+	pool := x509.NewCertPool()
+	for _, path := range c.CACertificates {
+		ca, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, zerrors.ThrowInternalf(nil, "OIDC-Ohd5e", "no certificates found in %s", path)
+		}
+	}
+	return pool, nil
+}
+
+func (c *ClientCertificateConfig) trustedProxyNetworks() ([]*net.IPNet, error) {
+	if c == nil || !c.Enabled || c.TrustedProxyHeader == "" {
+		return nil, nil
+	}
+	if len(c.TrustedProxies) == 0 {
+		return nil, zerrors.ThrowInternal(nil, "OIDC-Ahn7u", "trusted proxies must be set to use the trusted proxy header")
+	}
+	networks := make([]*net.IPNet, len(c.TrustedProxies))
+	for i, proxy := range c.TrustedProxies {
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, zerrors.ThrowInternalf(err, "OIDC-ooM4x", "invalid trusted proxy %s", proxy)
+		}
+		networks[i] = network
+	}
+	return networks, nil
+}
+
+type clientCertificateKey struct{}
+
+type certificateThumbprintKey struct{}
+
+// clientCertificateHandler sets the certificate presented by the client on the request context.
+// It's taken from the TLS connection, or if not present, from the header set by a trusted proxy.
+func clientCertificateHandler(config *ClientCertificateConfig, trustedProxies []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if config == nil || !config.Enabled {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cert, err := clientCertificateFromRequest(r, config.TrustedProxyHeader, trustedProxies)
+			logging.OnError(err).Warn("unable to parse client certificate")
+			if cert != nil {
+				r = r.WithContext(context.WithValue(r.Context(), clientCertificateKey{}, cert))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func clientCertificateFromRequest(r *http.Request, trustedProxyHeader string, trustedProxies []*net.IPNet) (*x509.Certificate, error) {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return r.TLS.PeerCertificates[0], nil
+	}
+	if trustedProxyHeader == "" || !isTrustedProxy(r.RemoteAddr, trustedProxies) {
+		return nil, nil
+	}
+	header := r.Header.Get(trustedProxyHeader)
+	if header == "" {
+		return nil, nil
+	}
+	unescaped, err := url.QueryUnescape(header)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(unescaped))
+	if block == nil {
+		return nil, errInvalidClientCertificate
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// isTrustedProxy checks the peer of the connection to be in one of the trusted proxy networks.
+func isTrustedProxy(remoteAddr string, trustedProxies []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func clientCertificateFromContext(ctx context.Context) *x509.Certificate {
+	cert, _ := ctx.Value(clientCertificateKey{}).(*x509.Certificate)
+	return cert
+}
+
+// contextWithCertificateBinding sets the thumbprint of the client certificate on the context,
+// if the client authenticated using mutual TLS.
+// The tokens created during the request will then be bound to the certificate.
+func contextWithCertificateBinding(ctx context.Context, client op.Client) context.Context {
+	c, ok := client.(*Client)
+	if !ok || !c.client.AuthMethodType.IsTLSClientAuth() {
+		return ctx
+	}
+	thumbprint := domain.CertificateThumbprint(clientCertificateFromContext(ctx))
+	if thumbprint == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, certificateThumbprintKey{}, thumbprint)
+}
+
+func certificateThumbprintFromContext(ctx context.Context) string {
+	thumbprint, _ := ctx.Value(certificateThumbprintKey{}).(string)
+	return thumbprint
+}
+
+// checkCertificateBinding ensures a certificate-bound token is used
+// together with the certificate it was issued to.
+func checkCertificateBinding(ctx context.Context, thumbprint string) error {
+	if thumbprint == "" {
+		return nil
+	}
+	if domain.CertificateThumbprint(clientCertificateFromContext(ctx)) != thumbprint {
+		return zerrors.ThrowPermissionDenied(nil, "OIDC-Bai4o", "token is bound to a different client certificate")
+	}
+	return nil
+}
+
+func confirmationClaim(thumbprint string) map[string]string {
+	return map[string]string{ConfirmationX5TS256: thumbprint}
+}
+
+// verifyTLSClientAuth verifies the client certificate to be issued by one of the trusted authorities
+// and to match the subject registered on the client.
+func (s *Server) verifyTLSClientAuth(ctx context.Context, client *query.OIDCClient) error {
+	cert := clientCertificateFromContext(ctx)
+	if cert == nil {
+		return oidc.ErrInvalidClient().WithDescription("no client certificate")
+	}
+	if s.clientCAs == nil {
+		return oidc.ErrInvalidClient().WithDescription("tls_client_auth is not configured")
+	}
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:     s.clientCAs,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return oidc.ErrInvalidClient().WithParent(err).WithDescription("invalid client certificate")
+	}
+	if cert.Subject.String() != client.TLSClientAuthSubjectDN {
+		return oidc.ErrInvalidClient().WithDescription("client certificate subject does not match")
+	}
+	return nil
+}
+
+// verifySelfSignedTLSClientAuth verifies the public key of the client certificate
+// to be one of the keys registered on the client.
+func verifySelfSignedTLSClientAuth(ctx context.Context, client *query.OIDCClient) error {
+	cert := clientCertificateFromContext(ctx)
+	if cert == nil {
+		return oidc.ErrInvalidClient().WithDescription("no client certificate")
+	}
+	for _, key := range client.PublicKeys {
+		publicKey, err := crypto.BytesToPublicKey(key)
+		if err != nil || publicKey == nil {
+			continue
+		}
+		if publicKey.Equal(cert.PublicKey) {
+			return nil
+		}
+	}
+	return oidc.ErrInvalidClient().WithDescription("client certificate does not match a registered key")
+}
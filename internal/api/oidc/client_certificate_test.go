@@ -0,0 +1,337 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func testCertificate(t *testing.T, subject pkix.Name, parent *x509.Certificate, parentKey *rsa.PrivateKey, isCA bool) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func publicKeyPEM(t *testing.T, key *rsa.PublicKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func Test_clientCertificateFromRequest(t *testing.T) {
+	cert, _ := testCertificate(t, pkix.Name{CommonName: "client"}, nil, nil, false)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	// httptest.NewRequest uses 192.0.2.1 as remote address
+	_, trustedProxy, err := net.ParseCIDR("192.0.2.0/24")
+	require.NoError(t, err)
+	trustedProxies := []*net.IPNet{trustedProxy}
+
+	tests := []struct {
+		name    string
+		request func() *http.Request
+		header  string
+		proxies []*net.IPNet
+		want    *x509.Certificate
+		wantErr bool
+	}{
+		{
+			name: "no certificate",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/oauth/v2/token", nil)
+			},
+			header: "x-client-cert",
+		},
+		{
+			name: "tls connection",
+			request: func() *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/oauth/v2/token", nil)
+				r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+				return r
+			},
+			want: cert,
+		},
+		{
+			name: "proxy header not trusted",
+			request: func() *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/oauth/v2/token", nil)
+				r.Header.Set("x-client-cert", url.QueryEscape(string(certPEM)))
+				return r
+			},
+		},
+		{
+			name: "trusted proxy header",
+			request: func() *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/oauth/v2/token", nil)
+				r.Header.Set("x-client-cert", url.QueryEscape(string(certPEM)))
+				return r
+			},
+			header:  "x-client-cert",
+			proxies: trustedProxies,
+			want:    cert,
+		},
+		{
+			name: "proxy header from untrusted peer",
+			request: func() *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/oauth/v2/token", nil)
+				r.RemoteAddr = "198.51.100.1:1234"
+				r.Header.Set("x-client-cert", url.QueryEscape(string(certPEM)))
+				return r
+			},
+			header:  "x-client-cert",
+			proxies: trustedProxies,
+		},
+		{
+			name: "invalid proxy header",
+			request: func() *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/oauth/v2/token", nil)
+				r.Header.Set("x-client-cert", "invalid")
+				return r
+			},
+			header:  "x-client-cert",
+			proxies: trustedProxies,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := clientCertificateFromRequest(tt.request(), tt.header, tt.proxies)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_checkCertificateBinding(t *testing.T) {
+	cert, _ := testCertificate(t, pkix.Name{CommonName: "client"}, nil, nil, false)
+	other, _ := testCertificate(t, pkix.Name{CommonName: "other"}, nil, nil, false)
+
+	tests := []struct {
+		name       string
+		cert       *x509.Certificate
+		thumbprint string
+		wantErr    bool
+	}{
+		{
+			name: "unbound token",
+			cert: cert,
+		},
+		{
+			name:       "bound token without certificate",
+			thumbprint: domain.CertificateThumbprint(cert),
+			wantErr:    true,
+		},
+		{
+			name:       "bound token with other certificate",
+			cert:       other,
+			thumbprint: domain.CertificateThumbprint(cert),
+			wantErr:    true,
+		},
+		{
+			name:       "bound token with certificate",
+			cert:       cert,
+			thumbprint: domain.CertificateThumbprint(cert),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.cert != nil {
+				ctx = context.WithValue(ctx, clientCertificateKey{}, tt.cert)
+			}
+			err := checkCertificateBinding(ctx, tt.thumbprint)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServer_verifyTLSClientAuth(t *testing.T) {
+	ca, caKey := testCertificate(t, pkix.Name{CommonName: "ca"}, nil, nil, true)
+	cert, _ := testCertificate(t, pkix.Name{CommonName: "client", Organization: []string{"ZITADEL"}}, ca, caKey, false)
+	untrusted, _ := testCertificate(t, pkix.Name{CommonName: "client", Organization: []string{"ZITADEL"}}, nil, nil, false)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	tests := []struct {
+		name      string
+		clientCAs *x509.CertPool
+		cert      *x509.Certificate
+		subjectDN string
+		wantErr   bool
+	}{
+		{
+			name:      "no certificate",
+			clientCAs: pool,
+			subjectDN: "CN=client,O=ZITADEL",
+			wantErr:   true,
+		},
+		{
+			name:      "not configured",
+			cert:      cert,
+			subjectDN: "CN=client,O=ZITADEL",
+			wantErr:   true,
+		},
+		{
+			name:      "untrusted certificate",
+			clientCAs: pool,
+			cert:      untrusted,
+			subjectDN: "CN=client,O=ZITADEL",
+			wantErr:   true,
+		},
+		{
+			name:      "subject mismatch",
+			clientCAs: pool,
+			cert:      cert,
+			subjectDN: "CN=other,O=ZITADEL",
+			wantErr:   true,
+		},
+		{
+			name:      "ok",
+			clientCAs: pool,
+			cert:      cert,
+			subjectDN: "CN=client,O=ZITADEL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.cert != nil {
+				ctx = context.WithValue(ctx, clientCertificateKey{}, tt.cert)
+			}
+			s := &Server{clientCAs: tt.clientCAs}
+			err := s.verifyTLSClientAuth(ctx, &query.OIDCClient{TLSClientAuthSubjectDN: tt.subjectDN})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_verifySelfSignedTLSClientAuth(t *testing.T) {
+	cert, key := testCertificate(t, pkix.Name{CommonName: "client"}, nil, nil, false)
+	_, otherKey := testCertificate(t, pkix.Name{CommonName: "other"}, nil, nil, false)
+
+	tests := []struct {
+		name       string
+		cert       *x509.Certificate
+		publicKeys map[string][]byte
+		wantErr    bool
+	}{
+		{
+			name:       "no certificate",
+			publicKeys: map[string][]byte{"key1": publicKeyPEM(t, &key.PublicKey)},
+			wantErr:    true,
+		},
+		{
+			name:       "unknown key",
+			cert:       cert,
+			publicKeys: map[string][]byte{"key1": publicKeyPEM(t, &otherKey.PublicKey)},
+			wantErr:    true,
+		},
+		{
+			name: "registered key",
+			cert: cert,
+			publicKeys: map[string][]byte{
+				"key1": publicKeyPEM(t, &otherKey.PublicKey),
+				"key2": publicKeyPEM(t, &key.PublicKey),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.cert != nil {
+				ctx = context.WithValue(ctx, clientCertificateKey{}, tt.cert)
+			}
+			err := verifySelfSignedTLSClientAuth(ctx, &query.OIDCClient{PublicKeys: tt.publicKeys})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClientCertificateConfig_trustedProxyNetworks(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *ClientCertificateConfig
+		want    int
+		wantErr bool
+	}{
+		{
+			name:   "no header",
+			config: &ClientCertificateConfig{Enabled: true},
+		},
+		{
+			name:    "header without proxies",
+			config:  &ClientCertificateConfig{Enabled: true, TrustedProxyHeader: "x-client-cert"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid proxy",
+			config:  &ClientCertificateConfig{Enabled: true, TrustedProxyHeader: "x-client-cert", TrustedProxies: []string{"10.0.0.1"}},
+			wantErr: true,
+		},
+		{
+			name:   "proxies",
+			config: &ClientCertificateConfig{Enabled: true, TrustedProxyHeader: "x-client-cert", TrustedProxies: []string{"10.0.0.0/8", "fd00::/8"}},
+			want:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.trustedProxyNetworks()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, got, tt.want)
+		})
+	}
+}
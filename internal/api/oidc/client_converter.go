@@ -171,6 +171,10 @@ func authMethodToOIDC(authType domain.OIDCAuthMethodType) oidc.AuthMethod {
 		return oidc.AuthMethodNone
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		return oidc.AuthMethodPrivateKeyJWT
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		return AuthMethodTLSClientAuth
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		return AuthMethodSelfSignedTLSClientAuth
 	default:
 		return oidc.AuthMethodBasic
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/op"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -103,6 +104,9 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 	if err != nil {
 		return nil, err
 	}
+	if err = setUserInfoClaimMappings(ctx, s.query, userInfo, token.userID, token.clientID, domain.ClaimMappingTargetIntrospection); err != nil {
+		return nil, err
+	}
 	introspectionResp := &oidc.IntrospectionResponse{
 		Active:     true,
 		Scope:      token.scope,
@@ -116,6 +120,18 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 		JWTID:      token.tokenID,
 	}
 	introspectionResp.SetUserInfo(userInfo)
+	if len(token.authorizationDetails) > 0 {
+		if introspectionResp.Claims == nil {
+			introspectionResp.Claims = make(map[string]any)
+		}
+		introspectionResp.Claims[domain.AuthorizationDetailsClaim] = token.authorizationDetails
+	}
+	if token.certificateThumbprint != "" {
+		if introspectionResp.Claims == nil {
+			introspectionResp.Claims = make(map[string]any)
+		}
+		introspectionResp.Claims[ClaimConfirmation] = confirmationClaim(token.certificateThumbprint)
+	}
 	return op.NewResponse(introspectionResp), nil
 }
 
@@ -55,16 +55,25 @@ type publicKeyCache struct {
 	mtx          sync.RWMutex
 	instanceKeys map[string]map[string]*cachedPublicKey
 	queryKey     func(ctx context.Context, keyID string) (query.PublicKey, error)
+	revokedKeys  func(ctx context.Context, keyIDs ...string) ([]string, error)
 	clock        clockwork.Clock
 }
 
 // newPublicKeyCache initializes a keySetCache starts a purging Go routine.
 // The purge routine deletes all public keys that are older than maxAge.
 // When the passed context is done, the purge routine will terminate.
-func newPublicKeyCache(background context.Context, maxAge time.Duration, queryKey func(ctx context.Context, keyID string) (query.PublicKey, error)) *publicKeyCache {
+// Cached keys are checked by revokedKeys on every use,
+// because revocations on other nodes don't purge the keys of this cache.
+func newPublicKeyCache(
+	background context.Context,
+	maxAge time.Duration,
+	queryKey func(ctx context.Context, keyID string) (query.PublicKey, error),
+	revokedKeys func(ctx context.Context, keyIDs ...string) ([]string, error),
+) *publicKeyCache {
 	k := &publicKeyCache{
 		instanceKeys: make(map[string]map[string]*cachedPublicKey),
 		queryKey:     queryKey,
+		revokedKeys:  revokedKeys,
 		clock:        clockwork.FromContext(background), // defaults to real clock
 	}
 	go k.purgeOnInterval(background, k.clock.NewTicker(maxAge/5), maxAge)
@@ -107,6 +116,13 @@ func (k *publicKeyCache) setKey(instanceID, keyID string, cachedKey *cachedPubli
 	k.instanceKeys[instanceID] = map[string]*cachedPublicKey{keyID: cachedKey}
 }
 
+func (k *publicKeyCache) deleteKey(instanceID, keyID string) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+
+	delete(k.instanceKeys[instanceID], keyID)
+}
+
 func (k *publicKeyCache) getKey(ctx context.Context, keyID string) (_ *cachedPublicKey, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -118,6 +134,14 @@ func (k *publicKeyCache) getKey(ctx context.Context, keyID string) (_ *cachedPub
 	k.mtx.RUnlock()
 
 	if ok {
+		revoked, err := k.revokedKeys(ctx, keyID)
+		if err != nil {
+			return nil, err
+		}
+		if len(revoked) > 0 {
+			k.deleteKey(instanceID, keyID)
+			return nil, zerrors.ThrowNotFound(nil, "OIDC-Rv0kd", "Errors.Key.NotFound")
+		}
 		key.setLastUse(k.clock.Now())
 	} else {
 		newKey, err := k.queryKey(ctx, keyID)
@@ -406,8 +430,10 @@ func (o *OPStorage) getMaxKeySequence(ctx context.Context) (float64, error) {
 	)
 }
 
+// selectSigningKey returns the key expiring first.
+// Successors generated by the key rotation are published with the public keys before they are used for signing.
 func selectSigningKey(keys []query.PrivateKey) query.PrivateKey {
-	return keys[len(keys)-1]
+	return keys[0]
 }
 
 func setOIDCCtx(ctx context.Context) context.Context {
@@ -14,6 +14,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 type publicKey struct {
@@ -83,6 +84,10 @@ func queryKeyDB(_ context.Context, keyID string) (query.PublicKey, error) {
 	return nil, errors.New("not found")
 }
 
+func noRevokedKeys(context.Context, ...string) ([]string, error) {
+	return nil, nil
+}
+
 func Test_publicKeyCache(t *testing.T) {
 	background, cancel := context.WithCancel(
 		clockwork.AddToContext(context.Background(), clock),
@@ -91,7 +96,7 @@ func Test_publicKeyCache(t *testing.T) {
 
 	// create an empty cache with a purge go routine, runs every minute.
 	// keys are cached for at least 1 Hour after last use.
-	cache := newPublicKeyCache(background, time.Hour, queryKeyDB)
+	cache := newPublicKeyCache(background, time.Hour, queryKeyDB, noRevokedKeys)
 	ctx := authz.NewMockContext("instanceID", "orgID", "userID")
 
 	// query error
@@ -152,10 +157,36 @@ func Test_publicKeyCache(t *testing.T) {
 	cache.mtx.RUnlock()
 }
 
+func Test_publicKeyCache_revoked(t *testing.T) {
+	background, cancel := context.WithCancel(
+		clockwork.AddToContext(context.Background(), clock),
+	)
+	defer cancel()
+
+	var revoked []string
+	cache := newPublicKeyCache(background, time.Hour, queryKeyDB, func(context.Context, ...string) ([]string, error) {
+		return revoked, nil
+	})
+	ctx := authz.NewMockContext("instanceID", "orgID", "userID")
+
+	_, err := cache.getKey(ctx, "key2")
+	require.NoError(t, err)
+
+	// revoked on another node, the cached key must no longer be used
+	revoked = []string{"key2"}
+	_, err = cache.getKey(ctx, "key2")
+	require.True(t, zerrors.IsNotFound(err))
+
+	cache.mtx.RLock()
+	_, ok := cache.instanceKeys["instanceID"]["key2"]
+	cache.mtx.RUnlock()
+	assert.False(t, ok)
+}
+
 func Test_oidcKeySet_VerifySignature(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	cache := newPublicKeyCache(ctx, time.Second, queryKeyDB)
+	cache := newPublicKeyCache(ctx, time.Second, queryKeyDB, noRevokedKeys)
 
 	tests := []struct {
 		name string
@@ -36,6 +36,7 @@ type Config struct {
 	DefaultIdTokenLifetime            time.Duration
 	DefaultRefreshTokenIdleExpiration time.Duration
 	DefaultRefreshTokenExpiration     time.Duration
+	RefreshTokenReuseGracePeriod      time.Duration
 	UserAgentCookieConfig             *middleware.UserAgentCookieConfig
 	Cache                             *middleware.CacheConfig
 	CustomEndpoints                   *EndpointConfig
@@ -44,6 +45,7 @@ type Config struct {
 	DefaultLogoutURLV2                string
 	Features                          Features
 	PublicKeyCacheMaxAge              time.Duration
+	ClientCertificate                 ClientCertificateConfig
 }
 
 type EndpointConfig struct {
@@ -80,6 +82,7 @@ type OPStorage struct {
 	signingKeyAlgorithm               string
 	defaultRefreshTokenIdleExpiration time.Duration
 	defaultRefreshTokenExpiration     time.Duration
+	refreshTokenReuseGracePeriod      time.Duration
 	encAlg                            crypto.EncryptionAlgorithm
 	locker                            crdb.Locker
 	assetAPIPrefix                    func(ctx context.Context) string
@@ -104,8 +107,16 @@ func NewServer(
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "OIDC-EGrqd", "cannot create op config: %w")
 	}
+	clientCAs, err := config.ClientCertificate.certPool()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "OIDC-ieV0e", "cannot load client certificate authorities")
+	}
+	trustedProxies, err := config.ClientCertificate.trustedProxyNetworks()
+	if err != nil {
+		return nil, err
+	}
 	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure)
-	keyCache := newPublicKeyCache(context.TODO(), config.PublicKeyCacheMaxAge, query.GetPublicKeyByID)
+	keyCache := newPublicKeyCache(context.TODO(), config.PublicKeyCacheMaxAge, query.GetPublicKeyByID, query.RevokedKeyIDs)
 	accessTokenKeySet := newOidcKeySet(keyCache, withKeyExpiryCheck(true))
 	idTokenHintKeySet := newOidcKeySet(keyCache)
 
@@ -143,6 +154,8 @@ func NewServer(
 		hashAlg:                    crypto.NewBCrypt(10), // as we are only verifying in oidc, the cost is already part of the hash string and the config here is irrelevant.
 		signingKeyAlgorithm:        config.SigningKeyAlgorithm,
 		assetAPIPrefix:             assets.AssetAPI(externalSecure),
+		clientCertificateEnabled:   config.ClientCertificate.Enabled,
+		clientCAs:                  clientCAs,
 	}
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 	server.Handler = op.RegisterLegacyServer(server,
@@ -151,6 +164,7 @@ func NewServer(
 			middleware.MetricsHandler(metricTypes),
 			middleware.TelemetryHandler(),
 			middleware.NoCacheInterceptor().Handler,
+			clientCertificateHandler(&config.ClientCertificate, trustedProxies),
 			instanceHandler,
 			userAgentCookie,
 			http_utils.CopyHeadersToContext,
@@ -207,6 +221,7 @@ func newStorage(config Config, command *command.Commands, query *query.Queries,
 		defaultIdTokenLifetime:            config.DefaultIdTokenLifetime,
 		defaultRefreshTokenIdleExpiration: config.DefaultRefreshTokenIdleExpiration,
 		defaultRefreshTokenExpiration:     config.DefaultRefreshTokenExpiration,
+		refreshTokenReuseGracePeriod:      config.RefreshTokenReuseGracePeriod,
 		encAlg:                            encAlg,
 		locker:                            crdb.NewLocker(db.DB, locksTable, signingKey),
 		assetAPIPrefix:                    assets.AssetAPI(externalSecure),
@@ -2,6 +2,7 @@ package oidc
 
 import (
 	"context"
+	"crypto/x509"
 	"net/http"
 	"time"
 
@@ -39,6 +40,9 @@ type Server struct {
 	hashAlg             crypto.HashAlgorithm
 	signingKeyAlgorithm string
 	assetAPIPrefix      func(ctx context.Context) string
+
+	clientCertificateEnabled bool
+	clientCAs                *x509.CertPool
 }
 
 func endpoints(endpointConfig *EndpointConfig) op.Endpoints {
@@ -123,7 +127,21 @@ func (s *Server) Discovery(ctx context.Context, r *op.Request[struct{}]) (_ *op.
 	if len(allowedLanguages) == 0 {
 		allowedLanguages = i18n.SupportedLanguages()
 	}
-	return op.NewResponse(s.createDiscoveryConfig(ctx, allowedLanguages)), nil
+	config := s.createDiscoveryConfig(ctx, allowedLanguages)
+	if !s.clientCertificateEnabled {
+		return op.NewResponse(config), nil
+	}
+	config.TokenEndpointAuthMethodsSupported = append(config.TokenEndpointAuthMethodsSupported, AuthMethodTLSClientAuth, AuthMethodSelfSignedTLSClientAuth)
+	return op.NewResponse(&discoveryConfiguration{
+		DiscoveryConfiguration:                config,
+		TLSClientCertificateBoundAccessTokens: true,
+	}), nil
+}
+
+// discoveryConfiguration extends the discovery by the metadata of RFC 8705.
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	TLSClientCertificateBoundAccessTokens bool `json:"tls_client_certificate_bound_access_tokens,omitempty"`
 }
 
 func (s *Server) Keys(ctx context.Context, r *op.Request[struct{}]) (_ *op.Response, err error) {
@@ -144,6 +162,10 @@ func (s *Server) Authorize(ctx context.Context, r *op.ClientRequest[oidc.AuthReq
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	ctx, err = contextWithRequestedAuthorizationDetails(ctx, r.Form)
+	if err != nil {
+		return op.TryErrorRedirect(ctx, r.Data, err, s.Provider().Encoder(), s.Provider().Logger())
+	}
 	return s.LegacyServer.Authorize(ctx, r)
 }
 
@@ -158,14 +180,24 @@ func (s *Server) CodeExchange(ctx context.Context, r *op.ClientRequest[oidc.Acce
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.CodeExchange(ctx, r)
+	ctx, err = contextWithRequestedAuthorizationDetails(ctx, r.Form)
+	if err != nil {
+		return nil, err
+	}
+	ctx = contextWithCertificateBinding(ctx, r.Client)
+	return s.LegacyServer.CodeExchange(contextWithGrantedAuthorizationDetails(ctx), r)
 }
 
 func (s *Server) RefreshToken(ctx context.Context, r *op.ClientRequest[oidc.RefreshTokenRequest]) (_ *op.Response, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.RefreshToken(ctx, r)
+	ctx, err = contextWithRequestedAuthorizationDetails(ctx, r.Form)
+	if err != nil {
+		return nil, err
+	}
+	ctx = contextWithCertificateBinding(ctx, r.Client)
+	return s.LegacyServer.RefreshToken(contextWithGrantedAuthorizationDetails(ctx), r)
 }
 
 func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGrantRequest]) (_ *op.Response, err error) {
@@ -0,0 +1,97 @@
+package saml
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/xml"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// handleIDPInitiated starts an IdP-initiated login for the SAML app.
+// After the login, the library sends an unsolicited response (without InResponseTo)
+// to the HTTP-POST assertion consumer service of the app together with the configured relay state.
+func (h *ssoHandler) handleIDPInitiated(w http.ResponseWriter, r *http.Request) {
+	authRequest, err := h.storage.createIDPInitiatedAuthRequest(r.Context(), mux.Vars(r)[appIDParam])
+	if err != nil {
+		writeSSOError(w, r, err)
+		return
+	}
+	http.Redirect(w, r, h.storage.defaultLoginURL+authRequest.ID, http.StatusFound)
+}
+
+func (p *Storage) createIDPInitiatedAuthRequest(ctx context.Context, appID string) (_ *domain.AuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "SAML-Lai9u", "no user agent id")
+	}
+	app, err := p.query.AppByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if app.State != domain.AppStateActive || app.SAMLConfig == nil {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "SAML-Wie3a", "app is not an active saml app")
+	}
+	settings, err := p.query.SAMLSSOSettingsByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.IDPInitiatedEnabled {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "SAML-oo7Ae", "Errors.Project.App.SAMLSSO.IDPInitiatedDisabled")
+	}
+	acsURL, err := postAssertionConsumerServiceURL(app.SAMLConfig.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return p.repo.CreateAuthRequest(ctx, &domain.AuthRequest{
+		CreationDate:  time.Now(),
+		AgentID:       userAgentID,
+		ApplicationID: app.ID,
+		CallbackURI:   acsURL,
+		TransferState: settings.RelayState,
+		InstanceID:    authz.GetInstance(ctx).InstanceID(),
+		Request: &domain.AuthRequestSAML{
+			BindingType: provider.PostBinding,
+			Issuer:      app.SAMLConfig.EntityID,
+		},
+	})
+}
+
+// postAssertionConsumerServiceURL returns the location of the (default) HTTP-POST assertion consumer service
+// of the service provider metadata, as an unsolicited response can only be posted.
+func postAssertionConsumerServiceURL(metadata []byte) (string, error) {
+	entity, err := xml.ParseMetadataXmlIntoStruct(metadata)
+	if err != nil {
+		return "", zerrors.ThrowPreconditionFailed(err, "SAML-ahW3e", "Errors.Project.App.SAMLMetadataFormat")
+	}
+	if entity.SPSSODescriptor == nil {
+		return "", zerrors.ThrowPreconditionFailed(nil, "SAML-Thoo6", "Errors.Project.App.SAMLSSO.PostACSMissing")
+	}
+	var location string
+	for _, acs := range entity.SPSSODescriptor.AssertionConsumerService {
+		if acs.Binding != provider.PostBinding {
+			continue
+		}
+		if acs.IsDefault == "true" {
+			return acs.Location, nil
+		}
+		if location == "" {
+			location = acs.Location
+		}
+	}
+	if location == "" {
+		return "", zerrors.ThrowPreconditionFailed(nil, "SAML-eeM7o", "Errors.Project.App.SAMLSSO.PostACSMissing")
+	}
+	return location, nil
+}
@@ -27,6 +27,18 @@ type Config struct {
 	ProviderConfig *provider.Config
 }
 
+// Provider is the SAML identity provider of ZITADEL.
+// Next to the SP-initiated flows of the library, it serves the IdP-initiated launch and the WS-Federation endpoints.
+type Provider struct {
+	*provider.Provider
+	handler http.Handler
+}
+
+// HttpHandler returns the handler serving the additional endpoints as well as the ones of the library.
+func (p *Provider) HttpHandler() http.Handler {
+	return p.handler
+}
+
 func NewProvider(
 	conf Config,
 	externalSecure bool,
@@ -40,7 +52,7 @@ func NewProvider(
 	instanceHandler,
 	userAgentCookie func(http.Handler) http.Handler,
 	accessHandler *middleware.AccessInterceptor,
-) (*provider.Provider, error) {
+) (*Provider, error) {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 
 	provStorage, err := newStorage(
@@ -56,29 +68,37 @@ func NewProvider(
 		return nil, err
 	}
 
+	interceptors := []provider.HttpInterceptor{
+		middleware.MetricsHandler(metricTypes),
+		middleware.TelemetryHandler(),
+		middleware.NoCacheInterceptor().Handler,
+		instanceHandler,
+		userAgentCookie,
+		accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(conf.ProviderConfig)),
+		http_utils.CopyHeadersToContext,
+		middleware.ActivityHandler,
+	}
 	options := []provider.Option{
-		provider.WithHttpInterceptors(
-			middleware.MetricsHandler(metricTypes),
-			middleware.TelemetryHandler(),
-			middleware.NoCacheInterceptor().Handler,
-			instanceHandler,
-			userAgentCookie,
-			accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(conf.ProviderConfig)),
-			http_utils.CopyHeadersToContext,
-			middleware.ActivityHandler,
-		),
+		provider.WithHttpInterceptors(interceptors...),
 		provider.WithCustomTimeFormat("2006-01-02T15:04:05.999Z"),
 	}
 	if !externalSecure {
 		options = append(options, provider.WithAllowInsecure())
 	}
 
-	return provider.NewProvider(
+	samlProvider, err := provider.NewProvider(
 		provStorage,
 		HandlerPrefix,
 		conf.ProviderConfig,
 		options...,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Provider: samlProvider,
+		handler:  newSSOHandler(provStorage, conf.ProviderConfig, samlProvider, interceptors),
+	}, nil
 }
 
 func newStorage(
@@ -102,15 +122,20 @@ func newStorage(
 	}, nil
 }
 
+func metadataPath(config *provider.Config) string {
+	if config.MetadataConfig != nil && config.MetadataConfig.Path != "" {
+		return config.MetadataConfig.Path
+	}
+	return provider.DefaultMetadataEndpoint
+}
+
 func publicAuthPathPrefixes(config *provider.Config) []string {
-	metadataEndpoint := HandlerPrefix + provider.DefaultMetadataEndpoint
+	metadataEndpoint := HandlerPrefix + metadataPath(config)
 	certificateEndpoint := HandlerPrefix + provider.DefaultCertificateEndpoint
 	ssoEndpoint := HandlerPrefix + provider.DefaultSingleSignOnEndpoint
-	if config.MetadataConfig != nil && config.MetadataConfig.Path != "" {
-		metadataEndpoint = HandlerPrefix + config.MetadataConfig.Path
-	}
+	wsFedMetadataEndpoint := HandlerPrefix + WSFedMetadataEndpoint
 	if config.IDPConfig == nil || config.IDPConfig.Endpoints == nil {
-		return []string{metadataEndpoint, certificateEndpoint, ssoEndpoint}
+		return []string{metadataEndpoint, certificateEndpoint, ssoEndpoint, wsFedMetadataEndpoint}
 	}
 	if config.IDPConfig.Endpoints.Certificate != nil && config.IDPConfig.Endpoints.Certificate.Relative() != "" {
 		certificateEndpoint = HandlerPrefix + config.IDPConfig.Endpoints.Certificate.Relative()
@@ -118,5 +143,5 @@ func publicAuthPathPrefixes(config *provider.Config) []string {
 	if config.IDPConfig.Endpoints.SingleSignOn != nil && config.IDPConfig.Endpoints.SingleSignOn.Relative() != "" {
 		ssoEndpoint = HandlerPrefix + config.IDPConfig.Endpoints.SingleSignOn.Relative()
 	}
-	return []string{metadataEndpoint, certificateEndpoint, ssoEndpoint}
+	return []string{metadataEndpoint, certificateEndpoint, ssoEndpoint, wsFedMetadataEndpoint}
 }
@@ -0,0 +1,74 @@
+package saml
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	IDPInitiatedEndpoint  = "/idp_initiated"
+	WSFedEndpoint         = "/wsfed"
+	WSFedCallbackEndpoint = "/wsfed/callback"
+	WSFedMetadataEndpoint = "/wsfed/metadata"
+
+	appIDParam = "app_id"
+)
+
+type ssoHandler struct {
+	storage            *Storage
+	metadataPath       string
+	signatureAlgorithm string
+}
+
+// newSSOHandler serves the IdP-initiated and WS-Federation endpoints
+// and passes all other requests to the handler of the SAML library.
+func newSSOHandler(storage *Storage, config *provider.Config, samlProvider *provider.Provider, interceptors []provider.HttpInterceptor) http.Handler {
+	h := &ssoHandler{
+		storage:            storage,
+		metadataPath:       metadataPath(config),
+		signatureAlgorithm: signatureAlgorithm(config),
+	}
+	intercept := func(handler http.HandlerFunc) http.Handler {
+		var next http.Handler = handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			next = interceptors[i](next)
+		}
+		return provider.NewIssuerInterceptor(samlProvider.IssuerFromRequest).Handler(next)
+	}
+
+	router := mux.NewRouter()
+	router.Handle(IDPInitiatedEndpoint+"/{"+appIDParam+"}", intercept(h.handleIDPInitiated)).Methods(http.MethodGet)
+	router.Handle(WSFedEndpoint, intercept(h.handleWSFed)).Methods(http.MethodGet, http.MethodPost)
+	router.Handle(WSFedCallbackEndpoint, intercept(h.handleWSFedCallback)).Methods(http.MethodGet)
+	router.Handle(WSFedMetadataEndpoint, intercept(h.handleWSFedMetadata)).Methods(http.MethodGet)
+	router.PathPrefix("/").Handler(samlProvider.HttpHandler())
+	return router
+}
+
+func signatureAlgorithm(config *provider.Config) string {
+	if config.IDPConfig != nil && config.IDPConfig.SignatureAlgorithm != "" {
+		return config.IDPConfig.SignatureAlgorithm
+	}
+	return defaultSignatureAlgorithm
+}
+
+func writeSSOError(w http.ResponseWriter, r *http.Request, err error) {
+	logging.WithError(err).WithField("path", r.URL.Path).Info("saml sso request failed")
+	status := http.StatusInternalServerError
+	switch {
+	case zerrors.IsErrorInvalidArgument(err):
+		status = http.StatusBadRequest
+	case zerrors.IsNotFound(err):
+		status = http.StatusNotFound
+	case zerrors.IsPreconditionFailed(err), zerrors.IsPermissionDenied(err):
+		status = http.StatusForbidden
+	case zerrors.IsUnauthenticated(err):
+		status = http.StatusUnauthorized
+	}
+	http.Error(w, http.StatusText(status), status)
+}
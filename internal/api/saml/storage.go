@@ -28,6 +28,8 @@ import (
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
+const claimMappingAttributeNameFormat = "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"
+
 var _ provider.EntityStorage = &Storage{}
 var _ provider.IdentityProviderStorage = &Storage{}
 var _ provider.AuthStorage = &Storage{}
@@ -147,6 +149,10 @@ func (p *Storage) SetUserinfoWithUserID(ctx context.Context, applicationID strin
 	if err != nil {
 		return err
 	}
+	customAttributes, err = p.appendClaimMappingAttributes(ctx, customAttributes, user.ID, applicationID)
+	if err != nil {
+		return err
+	}
 
 	setUserinfo(user, userinfo, attributes, customAttributes)
 
@@ -327,6 +333,27 @@ func (p *Storage) getCustomAttributes(ctx context.Context, user *query.User, use
 	return customAttributes, nil
 }
 
+// appendClaimMappingAttributes adds the claim mappings of the application as custom attributes.
+// Attributes already set by actions are not overwritten.
+func (p *Storage) appendClaimMappingAttributes(ctx context.Context, customAttributes map[string]*customAttribute, userID, applicationID string) (map[string]*customAttribute, error) {
+	claims, err := p.query.ClaimMappingClaims(ctx, applicationID, userID, domain.ClaimMappingTargetSAMLResponse)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range claims {
+		if _, ok := customAttributes[name]; ok {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			customAttributes = appendCustomAttribute(customAttributes, name, claimMappingAttributeNameFormat, []string{v})
+		case []string:
+			customAttributes = appendCustomAttribute(customAttributes, name, claimMappingAttributeNameFormat, v)
+		}
+	}
+	return customAttributes, nil
+}
+
 func (p *Storage) getGrants(ctx context.Context, userID, applicationID string) (*query.UserGrants, error) {
 	projectID, err := p.query.ProjectIDFromClientID(ctx, applicationID)
 	if err != nil {
@@ -0,0 +1,506 @@
+package saml
+
+import (
+	"context"
+	"encoding/base64"
+	"html/template"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/google/uuid"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	wsFedParamAction  = "wa"
+	wsFedParamRealm   = "wtrealm"
+	wsFedParamReply   = "wreply"
+	wsFedParamContext = "wctx"
+
+	wsFedActionSignIn         = "wsignin1.0"
+	wsFedActionSignOut        = "wsignout1.0"
+	wsFedActionSignOutCleanup = "wsignoutcleanup1.0"
+
+	wsFedNamespace          = "http://docs.oasis-open.org/wsfed/federation/200706"
+	wsTrustNamespace        = "http://schemas.xmlsoap.org/ws/2005/02/trust"
+	wsUtilityNamespace      = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-utility-1.0.xsd"
+	wsPolicyNamespace       = "http://schemas.xmlsoap.org/ws/2004/09/policy"
+	wsAddressNamespace      = "http://www.w3.org/2005/08/addressing"
+	wsClaimsNamespace       = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims"
+	saml11Namespace         = "urn:oasis:names:tc:SAML:1.0:assertion"
+	samlMetadataNS          = "urn:oasis:names:tc:SAML:2.0:metadata"
+	xmlSchemaInstanceNS     = "http://www.w3.org/2001/XMLSchema-instance"
+	bearerConfirmation      = "urn:oasis:names:tc:SAML:1.0:cm:bearer"
+	passwordAuthMethod      = "urn:oasis:names:tc:SAML:1.0:am:password"
+	hardwareTokenAuthMethod = "urn:oasis:names:tc:SAML:1.0:am:HardwareToken"
+	multipleAuthMethod      = "http://schemas.microsoft.com/claims/multipleauthn"
+	unspecifiedAuthMethod   = "urn:oasis:names:tc:SAML:1.0:am:unspecified"
+	unspecifiedNameIDFmt    = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+
+	defaultSignatureAlgorithm = dsig.RSASHA256SignatureMethod
+	wsFedTimeFormat           = "2006-01-02T15:04:05.999Z"
+	wsFedTokenLifetime        = 5 * time.Minute
+)
+
+var wsFedPostTemplate = template.Must(template.New("wsfed").Parse(`<!DOCTYPE html>
+<html lang="en">
+<body onload="document.getElementById('wsfedpost').submit()">
+<noscript>
+<p>
+<strong>Note:</strong> Since your browser does not support JavaScript,
+you must press the Continue button once to proceed.
+</p>
+</noscript>
+<form action="{{ .Reply }}" method="post" id="wsfedpost">
+<div>
+<input type="hidden" name="wa" value="wsignin1.0"/>
+<input type="hidden" name="wresult" value="{{ .Result }}"/>
+{{ if .Context }}<input type="hidden" name="wctx" value="{{ .Context }}"/>{{ end }}
+</div>
+<noscript>
+<div>
+<input type="submit" value="Continue"/>
+</div>
+</noscript>
+</form>
+</body>
+</html>`))
+
+// WSFedAuthCallbackURL builds the url for the redirect (with the requestID) after a successful login
+// of a WS-Federation sign-in request
+func WSFedAuthCallbackURL(ctx context.Context, requestID string) string {
+	return provider.IssuerFromContext(ctx) + WSFedCallbackEndpoint + "?id=" + requestID
+}
+
+// handleWSFed handles the sign-in and sign-out requests of the WS-Federation passive requestor profile.
+// The realm (wtrealm) of the request is the entity ID of the SAML app.
+func (h *ssoHandler) handleWSFed(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeSSOError(w, r, zerrors.ThrowInvalidArgument(err, "SAML-Bo5ie", "invalid ws-federation request"))
+		return
+	}
+	switch r.Form.Get(wsFedParamAction) {
+	case wsFedActionSignIn:
+		authRequest, err := h.storage.createWSFedAuthRequest(r.Context(), r.Form.Get(wsFedParamRealm), r.Form.Get(wsFedParamReply), r.Form.Get(wsFedParamContext))
+		if err != nil {
+			writeSSOError(w, r, err)
+			return
+		}
+		http.Redirect(w, r, h.storage.defaultLoginURL+authRequest.ID, http.StatusFound)
+	case wsFedActionSignOut, wsFedActionSignOutCleanup:
+		reply, err := h.storage.wsFedSignOut(r.Context(), r.Form.Get(wsFedParamRealm), r.Form.Get(wsFedParamReply))
+		if err != nil {
+			writeSSOError(w, r, err)
+			return
+		}
+		if reply == "" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, reply, http.StatusFound)
+	default:
+		writeSSOError(w, r, zerrors.ThrowInvalidArgument(nil, "SAML-ohG4u", "unsupported ws-federation action"))
+	}
+}
+
+// handleWSFedCallback posts the signed SAML 1.1 token of the logged in user to the reply url of the request.
+func (h *ssoHandler) handleWSFedCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authRequest, wsFedRequest, err := h.storage.wsFedAuthRequestByID(ctx, r.FormValue("id"))
+	if err != nil {
+		writeSSOError(w, r, err)
+		return
+	}
+	attributes := new(wsFedAttributes)
+	if err = h.storage.SetUserinfoWithUserID(ctx, authRequest.ApplicationID, attributes, authRequest.UserID, nil); err != nil {
+		writeSSOError(w, r, err)
+		return
+	}
+	signingContext, err := h.signingContext(ctx)
+	if err != nil {
+		writeSSOError(w, r, err)
+		return
+	}
+	authTime := authRequest.AuthTime
+	if authTime.IsZero() {
+		authTime = time.Now()
+	}
+	result, err := createWSFedResponse(h.entityID(ctx), wsFedRequest.Realm, attributes, authRequest.UserAuthMethodTypes(), authTime, time.Now(), signingContext)
+	if err != nil {
+		writeSSOError(w, r, zerrors.ThrowInternal(err, "SAML-ieQu5", "Errors.Internal"))
+		return
+	}
+	err = wsFedPostTemplate.Execute(w, struct {
+		Reply   string
+		Result  string
+		Context string
+	}{
+		Reply:   wsFedRequest.Reply,
+		Result:  result,
+		Context: wsFedRequest.Context,
+	})
+	if err != nil {
+		writeSSOError(w, r, zerrors.ThrowInternal(err, "SAML-Aej2i", "Errors.Internal"))
+	}
+}
+
+// handleWSFedMetadata returns the federation metadata document of the security token service,
+// which relying parties (e.g. SharePoint or ADFS) use to set up the trust.
+func (h *ssoHandler) handleWSFedMetadata(w http.ResponseWriter, r *http.Request) {
+	certAndKey, err := h.storage.GetResponseSigningKey(r.Context())
+	if err != nil {
+		writeSSOError(w, r, err)
+		return
+	}
+	metadata, err := createWSFedMetadata(h.entityID(r.Context()), provider.IssuerFromContext(r.Context())+WSFedEndpoint, certAndKey.Certificate)
+	if err != nil {
+		writeSSOError(w, r, zerrors.ThrowInternal(err, "SAML-ooy0E", "Errors.Internal"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, err = w.Write(metadata)
+	if err != nil {
+		writeSSOError(w, r, zerrors.ThrowInternal(err, "SAML-Zei1a", "Errors.Internal"))
+	}
+}
+
+func (h *ssoHandler) entityID(ctx context.Context) string {
+	return provider.IssuerFromContext(ctx) + h.metadataPath
+}
+
+func (h *ssoHandler) signingContext(ctx context.Context) (*dsig.SigningContext, error) {
+	certAndKey, err := h.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tlsCert, err := signature.ParseTlsKeyPair(certAndKey.Certificate, certAndKey.Key)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "SAML-Ahth5", "Errors.Internal")
+	}
+	signingContext, err := signature.GetSigningContext(tlsCert, h.signatureAlgorithm)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "SAML-Tie8o", "Errors.Internal")
+	}
+	return signingContext, nil
+}
+
+func (p *Storage) createWSFedAuthRequest(ctx context.Context, realm, reply, wsFedContext string) (_ *domain.AuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "SAML-Xae8d", "no user agent id")
+	}
+	if realm == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SAML-Eeng1", "wtrealm missing")
+	}
+	settings, err := p.wsFedSettings(ctx, realm)
+	if err != nil {
+		return nil, err
+	}
+	reply, ok = settings.WSFedReplyURL(reply)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SAML-aiM0o", "Errors.Project.App.SAMLSSO.ReplyURLInvalid")
+	}
+	return p.repo.CreateAuthRequest(ctx, &domain.AuthRequest{
+		CreationDate:  time.Now(),
+		AgentID:       userAgentID,
+		ApplicationID: settings.AppID,
+		CallbackURI:   reply,
+		TransferState: wsFedContext,
+		InstanceID:    authz.GetInstance(ctx).InstanceID(),
+		Request: &domain.AuthRequestWSFed{
+			Realm:   realm,
+			Reply:   reply,
+			Context: wsFedContext,
+		},
+	})
+}
+
+func (p *Storage) wsFedAuthRequestByID(ctx context.Context, id string) (_ *domain.AuthRequest, _ *domain.AuthRequestWSFed, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return nil, nil, zerrors.ThrowPreconditionFailed(nil, "SAML-Oi3ee", "no user agent id")
+	}
+	if id == "" {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "SAML-Ve2ai", "Errors.AuthRequest.NotFound")
+	}
+	authRequest, err := p.repo.AuthRequestByIDCheckLoggedIn(ctx, id, userAgentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	wsFedRequest, ok := authRequest.Request.(*domain.AuthRequestWSFed)
+	if !ok {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "SAML-Loo8i", "Errors.AuthRequest.NotFound")
+	}
+	return authRequest, wsFedRequest, nil
+}
+
+// wsFedSignOut terminates all sessions of the user agent.
+// The user is only redirected to the reply url if it is allowed for the realm.
+func (p *Storage) wsFedSignOut(ctx context.Context, realm, reply string) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return "", zerrors.ThrowPreconditionFailed(nil, "SAML-eiB4o", "no user agent id")
+	}
+	userIDs, err := p.repo.UserSessionUserIDsByAgentID(ctx, userAgentID)
+	if err != nil {
+		return "", err
+	}
+	if len(userIDs) > 0 {
+		if err = p.command.HumansSignOut(authz.SetCtxData(ctx, authz.CtxData{UserID: userIDs[0]}), userAgentID, userIDs); err != nil {
+			return "", err
+		}
+	}
+	if realm == "" || reply == "" {
+		return "", nil
+	}
+	settings, err := p.wsFedSettings(ctx, realm)
+	if err != nil {
+		return "", err
+	}
+	reply, ok = settings.WSFedReplyURL(reply)
+	if !ok {
+		return "", zerrors.ThrowInvalidArgument(nil, "SAML-Ij5ah", "Errors.Project.App.SAMLSSO.ReplyURLInvalid")
+	}
+	return reply, nil
+}
+
+func (p *Storage) wsFedSettings(ctx context.Context, realm string) (*query.SAMLSSOSettings, error) {
+	app, err := p.query.AppBySAMLEntityID(ctx, realm)
+	if err != nil {
+		return nil, err
+	}
+	if app.State != domain.AppStateActive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "SAML-ahL1e", "app is not active")
+	}
+	settings, err := p.query.SAMLSSOSettingsByAppID(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.WSFedEnabled {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "SAML-Sai7e", "Errors.Project.App.SAMLSSO.WSFedDisabled")
+	}
+	return settings, nil
+}
+
+// wsFedAttributes collects the user information as claims of the WS-Federation token.
+type wsFedAttributes struct {
+	email     string
+	fullName  string
+	givenName string
+	surname   string
+	userID    string
+	username  string
+	custom    []*wsFedAttribute
+}
+
+type wsFedAttribute struct {
+	namespace string
+	name      string
+	values    []string
+}
+
+func (a *wsFedAttributes) SetEmail(value string) {
+	a.email = value
+}
+
+func (a *wsFedAttributes) SetFullName(value string) {
+	a.fullName = value
+}
+
+func (a *wsFedAttributes) SetGivenName(value string) {
+	a.givenName = value
+}
+
+func (a *wsFedAttributes) SetSurname(value string) {
+	a.surname = value
+}
+
+func (a *wsFedAttributes) SetUserID(value string) {
+	a.userID = value
+}
+
+func (a *wsFedAttributes) SetUsername(value string) {
+	a.username = value
+}
+
+// SetCustomAttribute adds the attribute as claim.
+// SAML 1.1 attributes consist of a namespace and a name, so claim type URIs are split at the last slash,
+// other names are put into the claims namespace.
+func (a *wsFedAttributes) SetCustomAttribute(name, _, _ string, values []string) {
+	namespace := wsClaimsNamespace
+	if i := strings.LastIndex(name, "/"); i > 0 && i < len(name)-1 {
+		namespace, name = name[:i], name[i+1:]
+	}
+	a.custom = append(a.custom, &wsFedAttribute{
+		namespace: namespace,
+		name:      name,
+		values:    values,
+	})
+}
+
+func (a *wsFedAttributes) attributes() []*wsFedAttribute {
+	attributes := make([]*wsFedAttribute, 0, 5+len(a.custom))
+	for _, claim := range []struct{ name, value string }{
+		{"emailaddress", a.email},
+		{"givenname", a.givenName},
+		{"surname", a.surname},
+		{"name", a.fullName},
+		{"upn", a.username},
+	} {
+		if claim.value == "" {
+			continue
+		}
+		attributes = append(attributes, &wsFedAttribute{namespace: wsClaimsNamespace, name: claim.name, values: []string{claim.value}})
+	}
+	custom := append([]*wsFedAttribute(nil), a.custom...)
+	sort.Slice(custom, func(i, j int) bool {
+		if custom[i].namespace != custom[j].namespace {
+			return custom[i].namespace < custom[j].namespace
+		}
+		return custom[i].name < custom[j].name
+	})
+	return append(attributes, custom...)
+}
+
+// createWSFedResponse creates the wresult of the sign-in response:
+// a RequestSecurityTokenResponse containing an enveloped signed SAML 1.1 assertion.
+func createWSFedResponse(issuer, realm string, attributes *wsFedAttributes, authMethods []domain.UserAuthMethodType, authTime, now time.Time, signingContext *dsig.SigningContext) (string, error) {
+	now = now.UTC()
+	expires := now.Add(wsFedTokenLifetime)
+
+	assertion := etree.NewElement("saml:Assertion")
+	assertion.CreateAttr("xmlns:saml", saml11Namespace)
+	assertion.CreateAttr("MajorVersion", "1")
+	assertion.CreateAttr("MinorVersion", "1")
+	assertion.CreateAttr("AssertionID", "_"+uuid.NewString())
+	assertion.CreateAttr("Issuer", issuer)
+	assertion.CreateAttr("IssueInstant", now.Format(wsFedTimeFormat))
+
+	conditions := assertion.CreateElement("saml:Conditions")
+	conditions.CreateAttr("NotBefore", now.Format(wsFedTimeFormat))
+	conditions.CreateAttr("NotOnOrAfter", expires.Format(wsFedTimeFormat))
+	conditions.CreateElement("saml:AudienceRestrictionCondition").CreateElement("saml:Audience").SetText(realm)
+
+	attributeStatement := assertion.CreateElement("saml:AttributeStatement")
+	appendWSFedSubject(attributeStatement, attributes)
+	for _, attribute := range attributes.attributes() {
+		attr := attributeStatement.CreateElement("saml:Attribute")
+		attr.CreateAttr("AttributeName", attribute.name)
+		attr.CreateAttr("AttributeNamespace", attribute.namespace)
+		for _, value := range attribute.values {
+			attr.CreateElement("saml:AttributeValue").SetText(value)
+		}
+	}
+
+	authenticationStatement := assertion.CreateElement("saml:AuthenticationStatement")
+	authenticationStatement.CreateAttr("AuthenticationMethod", wsFedAuthenticationMethod(authMethods))
+	authenticationStatement.CreateAttr("AuthenticationInstant", authTime.UTC().Format(wsFedTimeFormat))
+	appendWSFedSubject(authenticationStatement, attributes)
+
+	signingContext.IdAttribute = "AssertionID"
+	signedAssertion, err := signingContext.SignEnveloped(assertion)
+	if err != nil {
+		return "", err
+	}
+
+	response := etree.NewElement("t:RequestSecurityTokenResponse")
+	response.CreateAttr("xmlns:t", wsTrustNamespace)
+	lifetime := response.CreateElement("t:Lifetime")
+	created := lifetime.CreateElement("wsu:Created")
+	created.CreateAttr("xmlns:wsu", wsUtilityNamespace)
+	created.SetText(now.Format(wsFedTimeFormat))
+	expiresElement := lifetime.CreateElement("wsu:Expires")
+	expiresElement.CreateAttr("xmlns:wsu", wsUtilityNamespace)
+	expiresElement.SetText(expires.Format(wsFedTimeFormat))
+	appliesTo := response.CreateElement("wsp:AppliesTo")
+	appliesTo.CreateAttr("xmlns:wsp", wsPolicyNamespace)
+	endpointReference := appliesTo.CreateElement("wsa:EndpointReference")
+	endpointReference.CreateAttr("xmlns:wsa", wsAddressNamespace)
+	endpointReference.CreateElement("wsa:Address").SetText(realm)
+	response.CreateElement("t:RequestedSecurityToken").AddChild(signedAssertion)
+	response.CreateElement("t:TokenType").SetText(saml11Namespace)
+	response.CreateElement("t:RequestType").SetText(wsTrustNamespace + "/Issue")
+	response.CreateElement("t:KeyType").SetText("http://schemas.xmlsoap.org/ws/2005/05/identity/NoProofKey")
+
+	doc := etree.NewDocument()
+	doc.SetRoot(response)
+	return doc.WriteToString()
+}
+
+// wsFedAuthenticationMethod returns the AuthenticationMethod of the assertion for the methods the user authenticated with.
+// Multiple factors are reported the way ADFS does, so relying parties like SharePoint can require MFA.
+func wsFedAuthenticationMethod(methods []domain.UserAuthMethodType) string {
+	if domain.HasMFA(methods) {
+		return multipleAuthMethod
+	}
+	switch {
+	case slices.Contains(methods, domain.UserAuthMethodTypePassword):
+		return passwordAuthMethod
+	case slices.Contains(methods, domain.UserAuthMethodTypeU2F):
+		return hardwareTokenAuthMethod
+	default:
+		return unspecifiedAuthMethod
+	}
+}
+
+func appendWSFedSubject(parent *etree.Element, attributes *wsFedAttributes) {
+	subject := parent.CreateElement("saml:Subject")
+	nameIdentifier := subject.CreateElement("saml:NameIdentifier")
+	nameIdentifier.CreateAttr("Format", unspecifiedNameIDFmt)
+	nameIdentifier.SetText(attributes.username)
+	subject.CreateElement("saml:SubjectConfirmation").CreateElement("saml:ConfirmationMethod").SetText(bearerConfirmation)
+}
+
+// createWSFedMetadata creates the federation metadata with the security token service role,
+// which offers SAML 1.1 tokens signed by the response signing certificate.
+func createWSFedMetadata(entityID, passiveEndpoint string, certificate []byte) ([]byte, error) {
+	entityDescriptor := etree.NewElement("md:EntityDescriptor")
+	entityDescriptor.CreateAttr("xmlns:md", samlMetadataNS)
+	entityDescriptor.CreateAttr("xmlns:fed", wsFedNamespace)
+	entityDescriptor.CreateAttr("xmlns:xsi", xmlSchemaInstanceNS)
+	entityDescriptor.CreateAttr("xmlns:wsa", wsAddressNamespace)
+	entityDescriptor.CreateAttr("ID", "_"+uuid.NewString())
+	entityDescriptor.CreateAttr("entityID", entityID)
+
+	role := entityDescriptor.CreateElement("md:RoleDescriptor")
+	role.CreateAttr("xsi:type", "fed:SecurityTokenServiceType")
+	role.CreateAttr("protocolSupportEnumeration", wsFedNamespace)
+
+	keyDescriptor := role.CreateElement("md:KeyDescriptor")
+	keyDescriptor.CreateAttr("use", "signing")
+	keyInfo := keyDescriptor.CreateElement("ds:KeyInfo")
+	keyInfo.CreateAttr("xmlns:ds", dsig.Namespace)
+	keyInfo.CreateElement("ds:X509Data").CreateElement("ds:X509Certificate").SetText(base64.StdEncoding.EncodeToString(certificate))
+
+	role.CreateElement("fed:TokenTypesOffered").CreateElement("fed:TokenType").CreateAttr("Uri", saml11Namespace)
+	role.CreateElement("fed:PassiveRequestorEndpoint").
+		CreateElement("wsa:EndpointReference").
+		CreateElement("wsa:Address").SetText(passiveEndpoint)
+
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	doc.AddChild(entityDescriptor)
+	return doc.WriteToBytes()
+}
@@ -0,0 +1,155 @@
+package saml
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider/signature"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func TestWSFedAttributes_SetCustomAttribute(t *testing.T) {
+	attributes := new(wsFedAttributes)
+	attributes.SetEmail("user@example.com")
+	attributes.SetUsername("user@example.com")
+	attributes.SetCustomAttribute("http://schemas.microsoft.com/ws/2008/06/identity/claims/role", "", "", []string{"admin", "user"})
+	attributes.SetCustomAttribute("department", "", "", []string{"it"})
+
+	got := attributes.attributes()
+	assert.Equal(t, []*wsFedAttribute{
+		{namespace: wsClaimsNamespace, name: "emailaddress", values: []string{"user@example.com"}},
+		{namespace: wsClaimsNamespace, name: "upn", values: []string{"user@example.com"}},
+		{namespace: "http://schemas.microsoft.com/ws/2008/06/identity/claims", name: "role", values: []string{"admin", "user"}},
+		{namespace: wsClaimsNamespace, name: "department", values: []string{"it"}},
+	}, got)
+}
+
+func TestCreateWSFedResponse(t *testing.T) {
+	tlsCert := testTLSCertificate(t)
+	signingContext, err := signature.GetSigningContext(tlsCert, dsig.RSASHA256SignatureMethod)
+	require.NoError(t, err)
+
+	attributes := new(wsFedAttributes)
+	attributes.SetUsername("user@example.com")
+	attributes.SetGivenName("Given")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	result, err := createWSFedResponse("https://idp.example.com/saml/v2/metadata", "urn:sharepoint:portal", attributes, []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword}, now, now, signingContext)
+	require.NoError(t, err)
+
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromString(result))
+	assertion := doc.FindElement("/RequestSecurityTokenResponse/RequestedSecurityToken/Assertion")
+	require.NotNil(t, assertion)
+	assert.Equal(t, "https://idp.example.com/saml/v2/metadata", assertion.SelectAttrValue("Issuer", ""))
+	assert.Equal(t, "urn:sharepoint:portal", assertion.FindElement("./Conditions/AudienceRestrictionCondition/Audience").Text())
+	assert.Equal(t, "2024-01-02T03:09:05Z", assertion.FindElement("./Conditions").SelectAttrValue("NotOnOrAfter", ""))
+	assert.Equal(t, "user@example.com", assertion.FindElement("./AttributeStatement/Subject/NameIdentifier").Text())
+	assert.Equal(t, "Given", assertion.FindElement("./AttributeStatement/Attribute[@AttributeName='givenname']/AttributeValue").Text())
+	assert.Equal(t, passwordAuthMethod, assertion.FindElement("./AuthenticationStatement").SelectAttrValue("AuthenticationMethod", ""))
+	assert.Equal(t, "urn:sharepoint:portal", doc.FindElement("/RequestSecurityTokenResponse/AppliesTo/EndpointReference/Address").Text())
+
+	validationContext := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: []*x509.Certificate{tlsCert.Leaf}})
+	validationContext.IdAttribute = "AssertionID"
+	_, err = validationContext.Validate(assertion)
+	assert.NoError(t, err)
+}
+
+func TestWSFedAuthenticationMethod(t *testing.T) {
+	tests := []struct {
+		name    string
+		methods []domain.UserAuthMethodType
+		want    string
+	}{
+		{
+			name: "no methods, unspecified",
+			want: unspecifiedAuthMethod,
+		},
+		{
+			name:    "idp, unspecified",
+			methods: []domain.UserAuthMethodType{domain.UserAuthMethodTypeIDP},
+			want:    unspecifiedAuthMethod,
+		},
+		{
+			name:    "password",
+			methods: []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+			want:    passwordAuthMethod,
+		},
+		{
+			name:    "u2f, hardware token",
+			methods: []domain.UserAuthMethodType{domain.UserAuthMethodTypeU2F},
+			want:    hardwareTokenAuthMethod,
+		},
+		{
+			name:    "password and totp, multiple",
+			methods: []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword, domain.UserAuthMethodTypeTOTP},
+			want:    multipleAuthMethod,
+		},
+		{
+			name:    "passwordless, multiple",
+			methods: []domain.UserAuthMethodType{domain.UserAuthMethodTypePasswordless},
+			want:    multipleAuthMethod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, wsFedAuthenticationMethod(tt.methods))
+		})
+	}
+}
+
+func TestCreateWSFedMetadata(t *testing.T) {
+	metadata, err := createWSFedMetadata("https://idp.example.com/saml/v2/metadata", "https://idp.example.com/saml/v2/wsfed", []byte("cert"))
+	require.NoError(t, err)
+
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromBytes(metadata))
+	assert.Equal(t, "https://idp.example.com/saml/v2/metadata", doc.Root().SelectAttrValue("entityID", ""))
+	role := doc.FindElement("/EntityDescriptor/RoleDescriptor")
+	require.NotNil(t, role)
+	assert.Equal(t, "fed:SecurityTokenServiceType", role.SelectAttrValue("xsi:type", ""))
+	assert.Equal(t, "Y2VydA==", role.FindElement("./KeyDescriptor/KeyInfo/X509Data/X509Certificate").Text())
+	assert.Equal(t, "https://idp.example.com/saml/v2/wsfed", role.FindElement("./PassiveRequestorEndpoint/EndpointReference/Address").Text())
+}
+
+func TestPostAssertionConsumerServiceURL(t *testing.T) {
+	metadata := []byte(`<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://sp.example.com/metadata">
+  <md:SPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://sp.example.com/redirect" index="0" isDefault="true"/>
+    <md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://sp.example.com/post" index="1"/>
+  </md:SPSSODescriptor>
+</md:EntityDescriptor>`)
+	got, err := postAssertionConsumerServiceURL(metadata)
+	require.NoError(t, err)
+	assert.Equal(t, "https://sp.example.com/post", got)
+}
+
+func testTLSCertificate(t *testing.T) tls.Certificate {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "zitadel"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	tlsCert, err := signature.ParseTlsKeyPair(der, key)
+	require.NoError(t, err)
+	tlsCert.Leaf, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return tlsCert
+}
@@ -0,0 +1,134 @@
+package login
+
+import (
+	"net/http"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	tmplConsent = "consent"
+)
+
+type consentFormData struct {
+	Deny bool `schema:"deny"`
+}
+
+type consentData struct {
+	baseData
+	profileData
+	AuthorizationDetails []*consentAuthorizationDetail
+	Scopes               []*consentScope
+}
+
+type consentAuthorizationDetail struct {
+	*domain.AuthorizationDetail
+	DisplayName string
+}
+
+type consentScope struct {
+	Scope string
+	// DescriptionKey is the i18n key of the description of a standard scope.
+	// It's empty for all other scopes, which are displayed as requested.
+	DescriptionKey string
+	Claims         []string
+}
+
+// consentStandardScopes describes the standard OIDC scopes and the claims they release.
+var consentStandardScopes = map[string]consentScope{
+	"openid":         {DescriptionKey: "Consent.Scopes.OpenID", Claims: []string{"sub"}},
+	"profile":        {DescriptionKey: "Consent.Scopes.Profile", Claims: []string{"name", "given_name", "family_name", "nickname", "preferred_username", "gender", "locale", "picture"}},
+	"email":          {DescriptionKey: "Consent.Scopes.Email", Claims: []string{"email", "email_verified"}},
+	"phone":          {DescriptionKey: "Consent.Scopes.Phone", Claims: []string{"phone_number", "phone_number_verified"}},
+	"address":        {DescriptionKey: "Consent.Scopes.Address", Claims: []string{"address"}},
+	"offline_access": {DescriptionKey: "Consent.Scopes.OfflineAccess"},
+}
+
+func (l *Login) handleConsent(w http.ResponseWriter, r *http.Request) {
+	data := new(consentFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if data.Deny {
+		l.denyConsent(w, r, authReq)
+		return
+	}
+	if err = l.authRepo.GiveConsent(r.Context(), authReq.ID, authReq.AgentID); err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+// denyConsent returns the access_denied error to the client and terminates the auth request.
+func (l *Login) denyConsent(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
+	if _, ok := authReq.Request.(*domain.AuthRequestOIDC); !ok {
+		l.renderError(w, r, authReq, zerrors.ThrowPreconditionFailed(nil, "LOGIN-Quae4", "Errors.AuthRequest.RequestTypeNotSupported"))
+		return
+	}
+	callback, err := l.oidcErrorCallbackURL(r.Context(), authReq, string(oidc.AccessDenied), "the user denied the consent")
+	if err != nil {
+		l.renderInternalError(w, r, authReq, err)
+		return
+	}
+	logging.WithFields("authRequestID", authReq.ID).OnError(l.authRepo.DeleteAuthRequest(r.Context(), authReq.ID)).Warn("unable to delete denied auth request")
+	http.Redirect(w, r, callback, http.StatusFound)
+}
+
+func (l *Login) renderConsent(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, step *domain.ConsentStep, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	translator := l.getTranslator(r.Context(), authReq)
+	data := consentData{
+		baseData:             l.getBaseData(r, authReq, translator, "Consent.Title", "Consent.Description", errID, errMessage),
+		profileData:          l.getProfileData(authReq),
+		AuthorizationDetails: l.consentAuthorizationDetails(r, authReq, step.AuthorizationDetails),
+		Scopes:               consentScopes(step.Scopes),
+	}
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplConsent], data, nil)
+}
+
+// consentAuthorizationDetails maps the requested authorization details to the display names
+// of the types registered on the project. If they cannot be loaded, the type itself is displayed.
+func (l *Login) consentAuthorizationDetails(r *http.Request, authReq *domain.AuthRequest, details domain.AuthorizationDetails) []*consentAuthorizationDetail {
+	displayNames := make(map[string]string, len(details))
+	if project, err := l.query.ProjectByClientID(r.Context(), authReq.ApplicationID); err == nil {
+		if types, err := l.query.AuthorizationDetailTypesByProjectID(r.Context(), project.ID); err == nil {
+			for _, detailType := range types.AuthorizationDetailTypes {
+				displayNames[detailType.Type] = detailType.DisplayName
+			}
+		}
+	}
+	consentDetails := make([]*consentAuthorizationDetail, len(details))
+	for i, detail := range details {
+		consentDetails[i] = &consentAuthorizationDetail{
+			AuthorizationDetail: detail,
+			DisplayName:         detail.Type,
+		}
+		if displayName := displayNames[detail.Type]; displayName != "" {
+			consentDetails[i].DisplayName = displayName
+		}
+	}
+	return consentDetails
+}
+
+func consentScopes(scopes []string) []*consentScope {
+	consentScopes := make([]*consentScope, len(scopes))
+	for i, scope := range scopes {
+		standardScope := consentStandardScopes[scope]
+		consentScopes[i] = &consentScope{
+			Scope:          scope,
+			DescriptionKey: standardScope.DescriptionKey,
+			Claims:         standardScope.Claims,
+		}
+	}
+	return consentScopes
+}
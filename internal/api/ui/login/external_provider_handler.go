@@ -21,15 +21,19 @@ import (
 	"github.com/zitadel/zitadel/internal/idp"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/bitbucket"
+	"github.com/zitadel/zitadel/internal/idp/providers/discord"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
 	"github.com/zitadel/zitadel/internal/idp/providers/google"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/idp/providers/linkedin"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml/requesttracker"
+	"github.com/zitadel/zitadel/internal/idp/providers/slack"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -162,6 +166,14 @@ func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domai
 		provider, err = l.azureProvider(r.Context(), identityProvider)
 	case domain.IDPTypeGitHub:
 		provider, err = l.githubProvider(r.Context(), identityProvider)
+	case domain.IDPTypeBitbucket:
+		provider, err = l.bitbucketProvider(r.Context(), identityProvider)
+	case domain.IDPTypeSlack:
+		provider, err = l.slackProvider(r.Context(), identityProvider)
+	case domain.IDPTypeDiscord:
+		provider, err = l.discordProvider(r.Context(), identityProvider)
+	case domain.IDPTypeLinkedIn:
+		provider, err = l.linkedinProvider(r.Context(), identityProvider)
 	case domain.IDPTypeGitHubEnterprise:
 		provider, err = l.githubEnterpriseProvider(r.Context(), identityProvider)
 	case domain.IDPTypeGitLab:
@@ -277,14 +289,42 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 			l.externalAuthFailed(w, r, authReq, nil, nil, err)
 			return
 		}
-		session = &oauth.Session{Provider: provider.(*github.Provider).Provider, Code: data.Code}
+		session = &github.Session{Provider: provider.(*github.Provider), Code: data.Code}
+	case domain.IDPTypeBitbucket:
+		provider, err = l.bitbucketProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = &bitbucket.Session{Provider: provider.(*bitbucket.Provider), Code: data.Code}
+	case domain.IDPTypeSlack:
+		provider, err = l.slackProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = &oauth.Session{Provider: provider.(*slack.Provider).Provider, Code: data.Code}
+	case domain.IDPTypeDiscord:
+		provider, err = l.discordProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = &oauth.Session{Provider: provider.(*discord.Provider).Provider, Code: data.Code}
+	case domain.IDPTypeLinkedIn:
+		provider, err = l.linkedinProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = &oauth.Session{Provider: provider.(*linkedin.Provider).Provider, Code: data.Code}
 	case domain.IDPTypeGitHubEnterprise:
 		provider, err = l.githubEnterpriseProvider(r.Context(), identityProvider)
 		if err != nil {
 			l.externalAuthFailed(w, r, authReq, nil, nil, err)
 			return
 		}
-		session = &oauth.Session{Provider: provider.(*github.Provider).Provider, Code: data.Code}
+		session = &github.Session{Provider: provider.(*github.Provider), Code: data.Code}
 	case domain.IDPTypeGitLab:
 		provider, err = l.gitlabProvider(r.Context(), identityProvider)
 		if err != nil {
@@ -393,6 +433,18 @@ func (l *Login) handleExternalUserAuthenticated(
 	callback func(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest),
 ) {
 	externalUser := mapIDPUserToExternalUser(user, provider.ID)
+	roleMapping, err := l.idpRoleMapping(r.Context(), provider)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if roleMapping.IsEnabled() {
+		externalUser.Groups, err = idp.SessionClaimValues(r.Context(), session, user, roleMapping.Claim)
+		if err != nil {
+			l.renderError(w, r, authReq, err)
+			return
+		}
+	}
 	// ensure the linked IDP is added to the login policy
 	if err := l.authRepo.SelectExternalIDP(r.Context(), authReq.ID, provider.ID, authReq.AgentID); err != nil {
 		l.renderError(w, r, authReq, err)
@@ -415,7 +467,6 @@ func (l *Login) handleExternalUserAuthenticated(
 			externalErr = nil
 		}
 	}
-	var err error
 	// read current auth request state (incl. authorized user)
 	authReq, err = l.authRepo.AuthRequestByID(r.Context(), authReq.ID, authReq.AgentID)
 	if err != nil {
@@ -427,6 +478,22 @@ func (l *Login) handleExternalUserAuthenticated(
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	// if no user is linked, try to link an existing user automatically (if enabled on the IDP)
+	if zerrors.IsNotFound(externalErr) {
+		linked, err := l.autoLinkExternalUser(r, authReq, provider, externalUser)
+		if err != nil {
+			l.renderError(w, r, authReq, err)
+			return
+		}
+		if linked {
+			externalErr = nil
+			authReq, err = l.authRepo.AuthRequestByID(r.Context(), authReq.ID, authReq.AgentID)
+			if err != nil {
+				l.renderError(w, r, authReq, err)
+				return
+			}
+		}
+	}
 	// if action is done and no user linked then link or register
 	if zerrors.IsNotFound(externalErr) {
 		l.externalUserNotExisting(w, r, authReq, provider, externalUser, externalUserChange)
@@ -446,6 +513,10 @@ func (l *Login) handleExternalUserAuthenticated(
 			return
 		}
 	}
+	if err = l.syncExternalUserGrants(r.Context(), roleMapping, authReq.UserID, authReq.UserOrgID, externalUser.Groups); err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
 	callback(w, r, authReq)
 }
 
@@ -482,7 +553,7 @@ func (l *Login) externalUserNotExisting(w http.ResponseWriter, r *http.Request,
 		l.renderExternalNotFoundOption(w, r, authReq, orgIAMPolicy, human, idpLink, err)
 		return
 	}
-	if changed || len(externalUser.Metadatas) > 0 {
+	if changed || len(externalUser.Metadatas) > 0 || len(externalUser.Groups) > 0 {
 		if err := l.authRepo.SetLinkingUser(r.Context(), authReq, externalUser); err != nil {
 			l.renderError(w, r, authReq, err)
 			return
@@ -677,6 +748,10 @@ func (l *Login) registerExternalUser(w http.ResponseWriter, r *http.Request, aut
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	if err = l.syncRegisteredExternalUserGrants(r.Context(), authReq.UserID, resourceOwner, externalUser); err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
 	l.renderNextStep(w, r, authReq)
 }
 
@@ -870,6 +945,13 @@ func (l *Login) ldapProvider(ctx context.Context, identityProvider *query.IDPTem
 	if identityProvider.LDAPIDPTemplate.LDAPAttributes.ProfileAttribute != "" {
 		opts = append(opts, ldap.WithProfileAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.ProfileAttribute))
 	}
+	roleMapping, err := l.idpRoleMapping(ctx, identityProvider)
+	if err != nil {
+		return nil, err
+	}
+	if roleMapping.IsEnabled() {
+		opts = append(opts, ldap.WithAdditionalAttributes(roleMapping.Claim))
+	}
 	return ldap.New(
 		identityProvider.Name,
 		identityProvider.Servers,
@@ -1038,6 +1120,58 @@ func (l *Login) githubProvider(ctx context.Context, identityProvider *query.IDPT
 	)
 }
 
+func (l *Login) bitbucketProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*bitbucket.Provider, error) {
+	secret, err := crypto.DecryptString(identityProvider.BitbucketIDPTemplate.ClientSecret, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return bitbucket.New(
+		identityProvider.BitbucketIDPTemplate.ClientID,
+		secret,
+		l.baseURL(ctx)+EndpointExternalLoginCallback,
+		identityProvider.BitbucketIDPTemplate.Scopes,
+	)
+}
+
+func (l *Login) slackProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*slack.Provider, error) {
+	secret, err := crypto.DecryptString(identityProvider.SlackIDPTemplate.ClientSecret, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return slack.New(
+		identityProvider.SlackIDPTemplate.ClientID,
+		secret,
+		l.baseURL(ctx)+EndpointExternalLoginCallback,
+		identityProvider.SlackIDPTemplate.Scopes,
+	)
+}
+
+func (l *Login) discordProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*discord.Provider, error) {
+	secret, err := crypto.DecryptString(identityProvider.DiscordIDPTemplate.ClientSecret, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return discord.New(
+		identityProvider.DiscordIDPTemplate.ClientID,
+		secret,
+		l.baseURL(ctx)+EndpointExternalLoginCallback,
+		identityProvider.DiscordIDPTemplate.Scopes,
+	)
+}
+
+func (l *Login) linkedinProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*linkedin.Provider, error) {
+	secret, err := crypto.DecryptString(identityProvider.LinkedInIDPTemplate.ClientSecret, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return linkedin.New(
+		identityProvider.LinkedInIDPTemplate.ClientID,
+		secret,
+		l.baseURL(ctx)+EndpointExternalLoginCallback,
+		identityProvider.LinkedInIDPTemplate.Scopes,
+	)
+}
+
 func (l *Login) githubEnterpriseProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*github.Provider, error) {
 	secret, err := crypto.DecryptString(identityProvider.GitHubIDPTemplate.ClientSecret, l.idpConfigAlg)
 	if err != nil {
@@ -1111,6 +1245,81 @@ func (l *Login) appendUserGrants(ctx context.Context, userGrants []*domain.UserG
 	return nil
 }
 
+// idpRoleMapping returns the mapping of claim values to project roles of the provider or nil if none is set
+func (l *Login) idpRoleMapping(ctx context.Context, provider *query.IDPTemplate) (*domain.IDPRoleMapping, error) {
+	mapping, err := l.query.IDPRoleMappingByIDPID(ctx, provider.ID, provider.ResourceOwner)
+	if zerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mapping.IDPRoleMapping, nil
+}
+
+// autoLinkExternalUser links the external user to the existing user with the same username or email address,
+// if the IDP has the automatic linking enabled and is trusted to assert it.
+// It returns false if no or more than one user matched, so the user can decide on how to proceed.
+func (l *Login) autoLinkExternalUser(r *http.Request, authReq *domain.AuthRequest, provider *query.IDPTemplate, externalUser *domain.ExternalUser) (bool, error) {
+	if !provider.IsLinkingAllowed {
+		return false, nil
+	}
+	linking, err := l.query.IDPAutoLinkingByIDPID(r.Context(), provider.ID, provider.ResourceOwner)
+	if zerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	value, ok := linking.LinkingValue(externalUser.PreferredUsername, string(externalUser.Email), externalUser.IsEmailVerified)
+	if !ok {
+		return false, nil
+	}
+	resourceOwner := authReq.RequestedOrgID
+	// users must only be linked to providers of their own organization
+	if provider.OwnerType == domain.IdentityProviderTypeOrg {
+		if resourceOwner != "" && resourceOwner != provider.ResourceOwner {
+			return false, nil
+		}
+		resourceOwner = provider.ResourceOwner
+	}
+	user, err := l.query.IDPAutoLinkingUser(r.Context(), linking.Option, value, resourceOwner)
+	if zerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	err = l.authRepo.AutoLinkExternalUser(setContext(r.Context(), user.ResourceOwner), authReq.ID, authReq.AgentID, user.ID, externalUser, domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// syncRegisteredExternalUserGrants grants the mapped roles to a newly registered user
+func (l *Login) syncRegisteredExternalUserGrants(ctx context.Context, userID, resourceOwner string, externalUser *domain.ExternalUser) error {
+	if len(externalUser.Groups) == 0 {
+		return nil
+	}
+	provider, err := l.query.IDPTemplateByID(ctx, false, externalUser.IDPConfigID, false)
+	if err != nil {
+		return err
+	}
+	roleMapping, err := l.idpRoleMapping(ctx, provider)
+	if err != nil {
+		return err
+	}
+	return l.syncExternalUserGrants(ctx, roleMapping, userID, resourceOwner, externalUser.Groups)
+}
+
+// syncExternalUserGrants keeps the user grants of the user in sync with the roles mapped from the claim values (groups)
+// Depending on the mode of the mapping, roles and grants of unmatched values are kept or removed.
+func (l *Login) syncExternalUserGrants(ctx context.Context, roleMapping *domain.IDPRoleMapping, userID, resourceOwner string, groups []string) error {
+	_, err := l.command.SyncIDPRoleMappingUserGrants(setContext(ctx, resourceOwner), roleMapping, userID, resourceOwner, groups)
+	return err
+}
+
 func (l *Login) externalAuthFailed(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, tokens *oidc.Tokens[*oidc.IDTokenClaims], user idp.User, err error) {
 	if authReq == nil {
 		l.renderLogin(w, r, authReq, err)
@@ -1133,6 +1342,10 @@ func tokens(session idp.Session) *oidc.Tokens[*oidc.IDTokenClaims] {
 		return s.Tokens
 	case *azuread.Session:
 		return s.Tokens()
+	case *github.Session:
+		return s.Tokens()
+	case *bitbucket.Session:
+		return s.Tokens()
 	case *apple.Session:
 		return s.Tokens
 	}
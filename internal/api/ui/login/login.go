@@ -25,21 +25,23 @@ import (
 )
 
 type Login struct {
-	endpoint            string
-	router              http.Handler
-	renderer            *Renderer
-	parser              *form.Parser
-	command             *command.Commands
-	query               *query.Queries
-	staticStorage       static.Storage
-	authRepo            auth_repository.Repository
-	externalSecure      bool
-	consolePath         string
-	oidcAuthCallbackURL func(context.Context, string) string
-	samlAuthCallbackURL func(context.Context, string) string
-	idpConfigAlg        crypto.EncryptionAlgorithm
-	userCodeAlg         crypto.EncryptionAlgorithm
-	featureCheck        feature.Checker
+	endpoint             string
+	router               http.Handler
+	renderer             *Renderer
+	parser               *form.Parser
+	command              *command.Commands
+	query                *query.Queries
+	staticStorage        static.Storage
+	authRepo             auth_repository.Repository
+	externalSecure       bool
+	consolePath          string
+	oidcAuthCallbackURL  func(context.Context, string) string
+	oidcErrorCallbackURL func(context.Context, *domain.AuthRequest, string, string) (string, error)
+	samlAuthCallbackURL  func(context.Context, string) string
+	wsfedAuthCallbackURL func(context.Context, string) string
+	idpConfigAlg         crypto.EncryptionAlgorithm
+	userCodeAlg          crypto.EncryptionAlgorithm
+	featureCheck         feature.Checker
 }
 
 type Config struct {
@@ -65,7 +67,9 @@ func CreateLogin(config Config,
 	staticStorage static.Storage,
 	consolePath string,
 	oidcAuthCallbackURL func(context.Context, string) string,
+	oidcErrorCallbackURL func(context.Context, *domain.AuthRequest, string, string) (string, error),
 	samlAuthCallbackURL func(context.Context, string) string,
+	wsfedAuthCallbackURL func(context.Context, string) string,
 	externalSecure bool,
 	userAgentCookie,
 	issuerInterceptor,
@@ -79,17 +83,19 @@ func CreateLogin(config Config,
 	featureCheck feature.Checker,
 ) (*Login, error) {
 	login := &Login{
-		oidcAuthCallbackURL: oidcAuthCallbackURL,
-		samlAuthCallbackURL: samlAuthCallbackURL,
-		externalSecure:      externalSecure,
-		consolePath:         consolePath,
-		command:             command,
-		query:               query,
-		staticStorage:       staticStorage,
-		authRepo:            authRepo,
-		idpConfigAlg:        idpConfigAlg,
-		userCodeAlg:         userCodeAlg,
-		featureCheck:        featureCheck,
+		oidcAuthCallbackURL:  oidcAuthCallbackURL,
+		oidcErrorCallbackURL: oidcErrorCallbackURL,
+		samlAuthCallbackURL:  samlAuthCallbackURL,
+		wsfedAuthCallbackURL: wsfedAuthCallbackURL,
+		externalSecure:       externalSecure,
+		consolePath:          consolePath,
+		command:              command,
+		query:                query,
+		staticStorage:        staticStorage,
+		authRepo:             authRepo,
+		idpConfigAlg:         idpConfigAlg,
+		userCodeAlg:          userCodeAlg,
+		featureCheck:         featureCheck,
 	}
 	csrfInterceptor := createCSRFInterceptor(config.CSRFCookieName, csrfCookieKey, externalSecure, login.csrfErrorHandler())
 	cacheInterceptor := createCacheInterceptor(config.Cache.MaxAge, config.Cache.SharedMaxAge, assetCache)
@@ -1,6 +1,7 @@
 package login
 
 import (
+	"encoding/base64"
 	"net/http"
 
 	"github.com/zitadel/logging"
@@ -20,6 +21,12 @@ type loginData struct {
 	Register  bool   `schema:"register"`
 }
 
+type loginNameData struct {
+	userData
+	// PasskeyAssertionData is the (base64 encoded) challenge for the passkey autofill of the login name input
+	PasskeyAssertionData string
+}
+
 func LoginLink(origin, orgID string) string {
 	return externalLink(origin) + EndpointLogin + "?orgID=" + orgID
 }
@@ -100,7 +107,10 @@ func (l *Login) renderLogin(w http.ResponseWriter, r *http.Request, authReq *dom
 		return
 	}
 	translator := l.getTranslator(r.Context(), authReq)
-	data := l.getUserData(r, authReq, translator, "Login.Title", "Login.Description", errID, errMessage)
+	data := &loginNameData{
+		userData:             l.getUserData(r, authReq, translator, "Login.Title", "Login.Description", errID, errMessage),
+		PasskeyAssertionData: l.passkeyAssertionData(r, authReq),
+	}
 	funcs := map[string]interface{}{
 		"hasUsernamePasswordLogin": func() bool {
 			return authReq != nil && authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowUsernamePassword
@@ -115,6 +125,22 @@ func (l *Login) renderLogin(w http.ResponseWriter, r *http.Request, authReq *dom
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplLogin], data, funcs)
 }
 
+// passkeyAssertionData begins a login with a discoverable credential, so the browser can offer the passkeys
+// of the user directly in the login name input (conditional mediation).
+// The login name page must still work without, so errors are only logged.
+func (l *Login) passkeyAssertionData(r *http.Request, authReq *domain.AuthRequest) string {
+	if authReq == nil || authReq.LoginPolicy == nil || authReq.LoginPolicy.PasswordlessType != domain.PasswordlessTypeAllowed {
+		return ""
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	webAuthNLogin, err := l.authRepo.BeginPasskeyLogin(r.Context(), authReq.ID, userAgentID)
+	if err != nil {
+		logging.WithFields("authRequestID", authReq.ID).OnError(err).Warn("unable to begin passkey login")
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(webAuthNLogin.CredentialAssertionData)
+}
+
 func singleIDPAllowed(authReq *domain.AuthRequest) bool {
 	return authReq != nil && authReq.LoginPolicy != nil && !authReq.LoginPolicy.AllowUsernamePassword && authReq.LoginPolicy.AllowExternalIDP && len(authReq.AllowedExternalIDPs) == 1
 }
@@ -0,0 +1,48 @@
+package login
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// handlePasskeyLogin verifies the assertion of a discoverable credential, which was selected
+// from the autofill of the login name input, and continues the login with the identified user.
+func (l *Login) handlePasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	formData := new(webAuthNFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, formData)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	credData, err := base64.URLEncoding.DecodeString(formData.CredentialData)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	err = l.authRepo.VerifyPasskeyLogin(r.Context(), authReq.ID, userAgentID, credData, domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	// the user is only known after the verification
+	authReq, err = l.authRepo.AuthRequestByID(r.Context(), authReq.ID, userAgentID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodPasswordless, nil)
+	if actionErr == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	} else if actionErr != nil {
+		err = actionErr
+	}
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
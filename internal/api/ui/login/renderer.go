@@ -83,6 +83,7 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		tmplLDAPLogin:                    "ldap_login.html",
 		tmplDeviceAuthUserCode:           "device_usercode.html",
 		tmplDeviceAuthAction:             "device_action.html",
+		tmplConsent:                      "consent.html",
 	}
 	funcs := map[string]interface{}{
 		"resourceUrl": func(file string) string {
@@ -154,6 +155,9 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		"passwordlessPromptUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointPasswordlessPrompt)
 		},
+		"passkeyLoginUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointPasskeyLogin)
+		},
 		"passwordResetUrl": func(id string) string {
 			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s", EndpointPasswordReset, QueryAuthRequestID, id))
 		},
@@ -166,6 +170,9 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		"mfaPromptUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointMFAPrompt)
 		},
+		"consentUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointConsent)
+		},
 		"mfaPromptChangeUrl": func(id string, provider domain.MFAType) string {
 			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s&%s=%v", EndpointMFAPrompt, QueryAuthRequestID, id, "provider", provider))
 		},
@@ -322,6 +329,8 @@ func (l *Login) chooseNextStep(w http.ResponseWriter, r *http.Request, authReq *
 		l.renderExternalNotFoundOption(w, r, authReq, nil, nil, nil, err)
 	case *domain.ExternalLoginStep:
 		l.handleExternalLoginStep(w, r, authReq, step.SelectedIDPConfigID)
+	case *domain.ConsentStep:
+		l.renderConsent(w, r, authReq, step, err)
 	case *domain.GrantRequiredStep:
 		l.renderInternalError(w, r, authReq, zerrors.ThrowPreconditionFailed(nil, "APP-asb43", "Errors.User.GrantRequired"))
 	case *domain.ProjectRequiredStep:
@@ -0,0 +1,65 @@
+document.addEventListener("DOMContentLoaded", conditionalLogin);
+
+// conditionalLogin offers the passkeys of the user in the autofill of the login name input,
+// so the user can log in without entering the login name first
+async function conditionalLogin() {
+  let form = document.getElementById("passkey-form");
+  if (
+    !form ||
+    !window.PublicKeyCredential ||
+    !PublicKeyCredential.isConditionalMediationAvailable ||
+    !(await PublicKeyCredential.isConditionalMediationAvailable())
+  ) {
+    return;
+  }
+
+  let makeAssertionOptions = JSON.parse(
+    atob(
+      form
+        .querySelector("[name=credentialAssertionData]")
+        .value.replace(/-/g, "+")
+        .replace(/_/g, "/")
+    )
+  );
+  makeAssertionOptions.publicKey.challenge = bufferDecode(
+    makeAssertionOptions.publicKey.challenge,
+    "publicKey.challenge"
+  );
+  navigator.credentials
+    .get({
+      mediation: "conditional",
+      publicKey: makeAssertionOptions.publicKey,
+    })
+    .then(function (credential) {
+      verifyPasskeyAssertion(form, credential);
+    })
+    .catch(function (err) {
+      // the autofill is optional, the user can still log in with the login name
+      console.log(err);
+    });
+}
+
+function verifyPasskeyAssertion(form, assertedCredential) {
+  let authData = new Uint8Array(assertedCredential.response.authenticatorData);
+  let clientDataJSON = new Uint8Array(
+    assertedCredential.response.clientDataJSON
+  );
+  let rawId = new Uint8Array(assertedCredential.rawId);
+  let sig = new Uint8Array(assertedCredential.response.signature);
+  let userHandle = new Uint8Array(assertedCredential.response.userHandle);
+
+  let data = JSON.stringify({
+    id: assertedCredential.id,
+    rawId: bufferEncode(rawId),
+    type: assertedCredential.type,
+    response: {
+      authenticatorData: bufferEncode(authData),
+      clientDataJSON: bufferEncode(clientDataJSON),
+      signature: bufferEncode(sig),
+      userHandle: bufferEncode(userHandle),
+    },
+  });
+
+  form.querySelector("[name=credentialData]").value = btoa(data);
+  form.submit();
+}
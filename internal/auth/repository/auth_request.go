@@ -36,9 +36,13 @@ type AuthRequestRepository interface {
 	VerifyPasswordlessInitCodeSetup(ctx context.Context, userID, resourceOwner, userAgentID, tokenName, codeID, verificationCode string, credentialData []byte) (err error)
 	BeginPasswordlessLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (*domain.WebAuthNLogin, error)
 	VerifyPasswordless(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, credentialData []byte, info *domain.BrowserInfo) error
+	BeginPasskeyLogin(ctx context.Context, authRequestID, userAgentID string) (*domain.WebAuthNLogin, error)
+	VerifyPasskeyLogin(ctx context.Context, authRequestID, userAgentID string, credentialData []byte, info *domain.BrowserInfo) error
 
 	LinkExternalUsers(ctx context.Context, authReqID, userAgentID string, info *domain.BrowserInfo) error
+	AutoLinkExternalUser(ctx context.Context, authReqID, userAgentID, userID string, externalUser *domain.ExternalUser, info *domain.BrowserInfo) error
 	AutoRegisterExternalUser(ctx context.Context, user *domain.Human, externalIDP *domain.UserIDPLink, orgMemberRoles []string, authReqID, userAgentID, resourceOwner string, metadatas []*domain.Metadata, info *domain.BrowserInfo) error
 	ResetLinkingUsers(ctx context.Context, authReqID, userAgentID string) error
 	ResetSelectedIDP(ctx context.Context, authReqID, userAgentID string) error
+	GiveConsent(ctx context.Context, authReqID, userAgentID string) error
 }
@@ -2,6 +2,7 @@ package eventstore
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	user_model "github.com/zitadel/zitadel/internal/user/model"
 	user_view_model "github.com/zitadel/zitadel/internal/user/repository/view/model"
+	"github.com/zitadel/zitadel/internal/webauthn"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -45,10 +47,12 @@ type AuthRequestRepo struct {
 	PrivacyPolicyProvider     privacyPolicyProvider
 	IDPProviderViewProvider   idpProviderViewProvider
 	IDPUserLinksProvider      idpUserLinksProvider
+	DomainIDPRoutingProvider  domainIDPRoutingProvider
 	UserGrantProvider         userGrantProvider
 	ProjectProvider           projectProvider
 	ApplicationProvider       applicationProvider
 	CustomTextProvider        customTextProvider
+	UserConsentProvider       userConsentProvider
 
 	FeatureCheck feature.Checker
 
@@ -89,12 +93,17 @@ type idpUserLinksProvider interface {
 	IDPUserLinks(ctx context.Context, queries *query.IDPUserLinksSearchQuery, withOwnerRemoved bool) (*query.IDPUserLinks, error)
 }
 
+type domainIDPRoutingProvider interface {
+	DomainIDPRoutingByDomain(ctx context.Context, orgDomain string) (*query.OrgDomainIDPRouting, error)
+}
+
 type userEventProvider interface {
 	UserEventsByID(ctx context.Context, id string, changeDate time.Time, eventTypes []eventstore.EventType) ([]eventstore.Event, error)
 }
 
 type userCommandProvider interface {
 	BulkAddedUserIDPLinks(ctx context.Context, userID, resourceOwner string, externalIDPs []*domain.UserIDPLink) error
+	GrantUserConsent(ctx context.Context, userID, resourceOwner, clientID, projectID string, scopes []string) (*domain.ObjectDetails, error)
 }
 
 type orgViewProvider interface {
@@ -116,6 +125,10 @@ type applicationProvider interface {
 	AppByOIDCClientID(context.Context, string) (*query.App, error)
 }
 
+type userConsentProvider interface {
+	UserConsentByClientID(ctx context.Context, shouldTriggerBulk bool, userID, clientID string) (*query.UserConsent, error)
+}
+
 type customTextProvider interface {
 	CustomTextListByTemplate(ctx context.Context, aggregateID string, text string, withOwnerRemoved bool) (texts *query.CustomTexts, err error)
 }
@@ -509,6 +522,73 @@ func (repo *AuthRequestRepo) VerifyPasswordless(ctx context.Context, userID, res
 	return repo.Command.HumanFinishPasswordlessLogin(ctx, userID, resourceOwner, credentialData, request)
 }
 
+// BeginPasskeyLogin creates a challenge for a discoverable credential (passkey) and keeps it on the auth request,
+// so the user can log in without selecting or entering the username first.
+func (repo *AuthRequestRepo) BeginPasskeyLogin(ctx context.Context, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequest(ctx, authRequestID, userAgentID)
+	if err != nil {
+		return nil, err
+	}
+	if request.LoginPolicy == nil || request.LoginPolicy.PasswordlessType != domain.PasswordlessTypeAllowed {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "EVENT-ieY5u", "Errors.Org.LoginPolicy.PasswordlessNotAllowed")
+	}
+	login, err = repo.Command.BeginPasskeyLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	request.PasskeyChallenge = login
+	if err = repo.AuthRequests.UpdateAuthRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return login, nil
+}
+
+// VerifyPasskeyLogin identifies the user by the user handle of the passkey assertion,
+// selects the user for the auth request and verifies the assertion against the challenge of the request.
+func (repo *AuthRequestRepo) VerifyPasskeyLogin(ctx context.Context, authRequestID, userAgentID string, credentialData []byte, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequest(ctx, authRequestID, userAgentID)
+	if err != nil {
+		return err
+	}
+	if request.PasskeyChallenge == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "EVENT-Ooj1e", "Errors.User.WebAuthN.NotFound")
+	}
+	userID, err := webauthn.UserIDFromAssertion(credentialData)
+	if err != nil {
+		return err
+	}
+	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, userID, false)
+	if err != nil {
+		return err
+	}
+	if request.RequestedOrgID != "" && request.RequestedOrgID != user.ResourceOwner {
+		return zerrors.ThrowPreconditionFailed(nil, "EVENT-Ahx9e", "Errors.User.NotAllowedOrg")
+	}
+	if err = repo.checkLoginPolicyWithResourceOwner(ctx, request, user.ResourceOwner); err != nil {
+		return err
+	}
+	if request.LoginPolicy.PasswordlessType != domain.PasswordlessTypeAllowed {
+		return zerrors.ThrowPreconditionFailed(nil, "EVENT-eiB4k", "Errors.Org.LoginPolicy.PasswordlessNotAllowed")
+	}
+	// the challenge must only be used once
+	challenge := request.PasskeyChallenge
+	request.PasskeyChallenge = nil
+	if err = repo.Command.HumanFinishPasskeyLogin(ctx, user.ID, user.ResourceOwner, credentialData, challenge, request.WithCurrentInfo(info)); err != nil {
+		logging.OnError(repo.AuthRequests.UpdateAuthRequest(ctx, request)).WithField("authRequestID", request.ID).Warn("unable to remove passkey challenge")
+		return err
+	}
+	username := user.UserName
+	if request.RequestedOrgID == "" {
+		username = user.PreferredLoginName
+	}
+	request.SetUserInfo(user.ID, username, user.PreferredLoginName, user.DisplayName, user.AvatarKey, user.ResourceOwner)
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
 func (repo *AuthRequestRepo) LinkExternalUsers(ctx context.Context, authReqID, userAgentID string, info *domain.BrowserInfo) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -528,6 +608,40 @@ func (repo *AuthRequestRepo) LinkExternalUsers(ctx context.Context, authReqID, u
 	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
 }
 
+// AutoLinkExternalUser links the external user to the (already matched) existing user
+// and continues the login with it, as if the link had existed before.
+func (repo *AuthRequestRepo) AutoLinkExternalUser(ctx context.Context, authReqID, userAgentID, userID string, externalUser *domain.ExternalUser, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequest(ctx, authReqID, userAgentID)
+	if err != nil {
+		return err
+	}
+	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, userID, false)
+	if err != nil {
+		return err
+	}
+	_, err = repo.Command.AutoLinkUserIDP(ctx, user.ID, user.ResourceOwner, &command.AddLink{
+		IDPID:         externalUser.IDPConfigID,
+		DisplayName:   externalUser.PreferredUsername,
+		IDPExternalID: externalUser.ExternalUserID,
+	})
+	if err != nil {
+		return err
+	}
+	username := user.UserName
+	if request.RequestedOrgID == "" {
+		username = user.PreferredLoginName
+	}
+	request.SetUserInfo(user.ID, username, user.PreferredLoginName, user.DisplayName, user.AvatarKey, user.ResourceOwner)
+	err = repo.Command.UserIDPLoginChecked(ctx, request.UserOrgID, request.UserID, request.WithCurrentInfo(info))
+	if err != nil {
+		return err
+	}
+	request.LinkingUsers = nil
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
 func (repo *AuthRequestRepo) ResetLinkingUsers(ctx context.Context, authReqID, userAgentID string) error {
 	request, err := repo.getAuthRequest(ctx, authReqID, userAgentID)
 	if err != nil {
@@ -538,6 +652,20 @@ func (repo *AuthRequestRepo) ResetLinkingUsers(ctx context.Context, authReqID, u
 	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
 }
 
+func (repo *AuthRequestRepo) GiveConsent(ctx context.Context, authReqID, userAgentID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequest(ctx, authReqID, userAgentID)
+	if err != nil {
+		return err
+	}
+	request.ConsentGiven = true
+	if err = repo.grantConsent(ctx, request); err != nil {
+		return err
+	}
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
 func (repo *AuthRequestRepo) ResetSelectedIDP(ctx context.Context, authReqID, userAgentID string) error {
 	request, err := repo.getAuthRequest(ctx, authReqID, userAgentID)
 	if err != nil {
@@ -746,6 +874,11 @@ func (repo *AuthRequestRepo) checkLoginName(ctx context.Context, request *domain
 	if err != nil && !zerrors.IsNotFound(err) {
 		return err
 	}
+	// check if the domain of the loginname is routed to identity providers (home realm discovery)
+	routed, errRouting := repo.checkIDPRouting(ctx, request, loginName, user)
+	if errRouting != nil || routed {
+		return errRouting
+	}
 	// if there's an active (human) user, let's use it
 	if user != nil && !user.HumanView.IsZero() && domain.UserState(user.State).NotDisabled() {
 		request.SetUserInfo(user.ID, loginName, user.PreferredLoginName, "", "", user.ResourceOwner)
@@ -817,6 +950,101 @@ func (repo *AuthRequestRepo) checkDomainDiscovery(ctx context.Context, request *
 	return true, nil
 }
 
+// checkIDPRouting redirects the user to the identity provider the domain of the loginname is routed to.
+// Existing users are only redirected by routings of their own organization
+// and if they are linked to one of the providers or the routing is forced,
+// unknown users are always redirected, so they can be registered or linked after the external login.
+func (repo *AuthRequestRepo) checkIDPRouting(ctx context.Context, request *domain.AuthRequest, loginName string, user *user_view_model.UserView) (bool, error) {
+	if len(request.LinkingUsers) > 0 {
+		return false, nil
+	}
+	if user != nil && (user.HumanView.IsZero() || !domain.UserState(user.State).NotDisabled()) {
+		return false, nil
+	}
+	routingDomain := domain.LoginNameDomain(loginName)
+	if routingDomain == "" {
+		return false, nil
+	}
+	routing, err := repo.DomainIDPRoutingProvider.DomainIDPRoutingByDomain(ctx, routingDomain)
+	if err != nil {
+		if zerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if user == nil {
+		return repo.checkIDPRoutingUnknownUser(ctx, request, loginName, routing)
+	}
+	if routing.OrgID != user.ResourceOwner {
+		return false, nil
+	}
+	allowed := allowedRoutedIDPs(routing, request.AllowedExternalIDPs)
+	if len(allowed.IDPIDs) == 0 {
+		return false, nil
+	}
+	links, err := checkExternalIDPsOfUser(ctx, repo.IDPUserLinksProvider, user.ID)
+	if err != nil {
+		return false, err
+	}
+	linked := make([]string, len(links.Links))
+	for i, link := range links.Links {
+		linked[i] = link.IDPID
+	}
+	if !allowed.Force && !allowed.IsLinked(linked) {
+		return false, nil
+	}
+	request.SetUserInfo(user.ID, loginName, user.PreferredLoginName, "", "", user.ResourceOwner)
+	request.SelectedIDPConfigID = allowed.RoutedIDP(linked)
+	return true, nil
+}
+
+func (repo *AuthRequestRepo) checkIDPRoutingUnknownUser(ctx context.Context, request *domain.AuthRequest, loginName string, routing *query.OrgDomainIDPRouting) (bool, error) {
+	// users of other organizations cannot be redirected
+	if request.RequestedOrgID != "" && request.RequestedOrgID != routing.OrgID {
+		return false, nil
+	}
+	_, idpProviders, err := repo.getLoginPolicyAndIDPProviders(ctx, routing.OrgID)
+	if err != nil {
+		return false, err
+	}
+	allowed := allowedRoutedIDPs(routing, idpProviders)
+	if len(allowed.IDPIDs) == 0 {
+		return false, nil
+	}
+	if request.RequestedOrgID == "" {
+		org, err := repo.OrgViewProvider.OrgByID(ctx, false, routing.OrgID)
+		if err != nil {
+			return false, err
+		}
+		request.SetOrgInformation(org.ID, org.Name, org.Domain, false)
+	}
+	// clear all potentially existing user information and only set the loginname as hint for the provider
+	request.SetUserInfo("", "", "", "", "", routing.OrgID)
+	if err = repo.fillPolicies(ctx, request); err != nil {
+		return false, err
+	}
+	request.LoginHint = strings.ToLower(loginName)
+	request.SelectedIDPConfigID = allowed.RoutedIDP(nil)
+	return true, nil
+}
+
+// allowedRoutedIDPs returns the routing reduced to the providers allowed by the login policy, keeping their order.
+func allowedRoutedIDPs(routing *query.OrgDomainIDPRouting, idpProviders []*domain.IDPProvider) *domain.OrgDomainIDPRouting {
+	allowed := &domain.OrgDomainIDPRouting{
+		IDPIDs: make([]string, 0, len(routing.IDPIDs)),
+		Force:  routing.Force,
+	}
+	for _, id := range routing.IDPIDs {
+		for _, provider := range idpProviders {
+			if provider.IDPConfigID == id {
+				allowed.IDPIDs = append(allowed.IDPIDs, id)
+				break
+			}
+		}
+	}
+	return allowed
+}
+
 func (repo *AuthRequestRepo) checkLoginNameInput(ctx context.Context, request *domain.AuthRequest, loginNameInput string) (*user_view_model.UserView, error) {
 	// always check the loginname first
 	user, err := repo.View.UserByLoginName(ctx, loginNameInput, request.InstanceID)
@@ -1061,7 +1289,6 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 	if request.LinkingUsers != nil && len(request.LinkingUsers) != 0 {
 		return append(steps, &domain.LinkUsersStep{}), nil
 	}
-	//PLANNED: consent step
 
 	missing, err := projectRequired(ctx, request, repo.ProjectProvider)
 	if err != nil {
@@ -1079,6 +1306,14 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		return append(steps, &domain.GrantRequiredStep{}), nil
 	}
 
+	consentStep, err := repo.consentStep(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if consentStep != nil {
+		return append(steps, consentStep), nil
+	}
+
 	ok, err = repo.hasSucceededPage(ctx, request, repo.ApplicationProvider)
 	if err != nil {
 		return nil, err
@@ -1358,6 +1593,84 @@ func (repo *AuthRequestRepo) hasSucceededPage(ctx context.Context, request *doma
 	return app.OIDCConfig.AppType == domain.OIDCApplicationTypeNative && !app.OIDCConfig.SkipNativeAppSuccessPage, nil
 }
 
+// consentStep returns the step asking the user to consent to the requested authorization details
+// and, if the app requires consent, to the requested scopes not granted yet.
+func (repo *AuthRequestRepo) consentStep(ctx context.Context, request *domain.AuthRequest) (*domain.ConsentStep, error) {
+	if request.ConsentGiven {
+		return nil, nil
+	}
+	scopes, err := repo.scopesRequiringConsent(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	details := request.GetAuthorizationDetails()
+	if len(details) == 0 && len(scopes) == 0 {
+		return nil, nil
+	}
+	return &domain.ConsentStep{
+		AuthorizationDetails: details,
+		Scopes:               scopes,
+	}, nil
+}
+
+// scopesRequiringConsent returns all requested scopes if the app requires consent
+// and the user did not already grant all of them or the client explicitly asked for consent.
+func (repo *AuthRequestRepo) scopesRequiringConsent(ctx context.Context, request *domain.AuthRequest) ([]string, error) {
+	oidcRequest, ok := request.Request.(*domain.AuthRequestOIDC)
+	if !ok {
+		return nil, nil
+	}
+	app, err := repo.ApplicationProvider.AppByOIDCClientID(ctx, request.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	if !app.OIDCConfig.ConsentRequired {
+		return nil, nil
+	}
+	if domain.IsPrompt(request.Prompt, domain.PromptConsent) {
+		return oidcRequest.Scopes, nil
+	}
+	consent, err := repo.UserConsentProvider.UserConsentByClientID(ctx, false, request.UserID, request.ApplicationID)
+	if zerrors.IsNotFound(err) {
+		return oidcRequest.Scopes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, scope := range oidcRequest.Scopes {
+		if !slices.Contains(consent.Scopes, scope) {
+			return oidcRequest.Scopes, nil
+		}
+	}
+	return nil, nil
+}
+
+// grantConsent persists the consent to the requested scopes if the app requires consent.
+// Scopes granted on previous authorizations are kept.
+func (repo *AuthRequestRepo) grantConsent(ctx context.Context, request *domain.AuthRequest) error {
+	oidcRequest, ok := request.Request.(*domain.AuthRequestOIDC)
+	if !ok {
+		return nil
+	}
+	app, err := repo.ApplicationProvider.AppByOIDCClientID(ctx, request.ApplicationID)
+	if err != nil {
+		return err
+	}
+	if !app.OIDCConfig.ConsentRequired {
+		return nil
+	}
+	scopes := oidcRequest.Scopes
+	consent, err := repo.UserConsentProvider.UserConsentByClientID(ctx, false, request.UserID, request.ApplicationID)
+	if err != nil && !zerrors.IsNotFound(err) {
+		return err
+	}
+	if consent != nil {
+		scopes = append(slices.Clone(consent.Scopes), scopes...)
+	}
+	_, err = repo.UserCommandProvider.GrantUserConsent(ctx, request.UserID, request.UserOrgID, request.ApplicationID, app.ProjectID, scopes)
+	return err
+}
+
 func (repo *AuthRequestRepo) getDomainPolicy(ctx context.Context, orgID string) (*query.DomainPolicy, error) {
 	return repo.Query.DomainPolicyByOrg(ctx, false, orgID, false)
 }
@@ -1620,7 +1933,7 @@ func linkingIDPConfigExistingInAllowedIDPs(linkingUsers []*domain.ExternalUser,
 func userGrantRequired(ctx context.Context, request *domain.AuthRequest, user *user_model.UserView, userGrantProvider userGrantProvider) (_ bool, err error) {
 	var project *query.Project
 	switch request.Request.Type() {
-	case domain.AuthRequestTypeOIDC, domain.AuthRequestTypeSAML, domain.AuthRequestTypeDevice:
+	case domain.AuthRequestTypeOIDC, domain.AuthRequestTypeSAML, domain.AuthRequestTypeDevice, domain.AuthRequestTypeWSFed:
 		project, err = userGrantProvider.ProjectByClientID(ctx, request.ApplicationID)
 		if err != nil {
 			return false, err
@@ -1641,7 +1954,7 @@ func userGrantRequired(ctx context.Context, request *domain.AuthRequest, user *u
 func projectRequired(ctx context.Context, request *domain.AuthRequest, projectProvider projectProvider) (missingGrant bool, err error) {
 	var project *query.Project
 	switch request.Request.Type() {
-	case domain.AuthRequestTypeOIDC, domain.AuthRequestTypeSAML, domain.AuthRequestTypeDevice:
+	case domain.AuthRequestTypeOIDC, domain.AuthRequestTypeSAML, domain.AuthRequestTypeDevice, domain.AuthRequestTypeWSFed:
 		project, err = projectProvider.ProjectByClientID(ctx, request.ApplicationID)
 		if err != nil {
 			return false, err
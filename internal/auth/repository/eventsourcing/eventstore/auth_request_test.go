@@ -279,6 +279,17 @@ func (m *mockApp) AppByOIDCClientID(ctx context.Context, id string) (*query.App,
 	return nil, zerrors.ThrowNotFound(nil, "ERROR", "error")
 }
 
+type mockUserConsent struct {
+	consent *query.UserConsent
+}
+
+func (m *mockUserConsent) UserConsentByClientID(ctx context.Context, shouldTriggerBulk bool, userID, clientID string) (*query.UserConsent, error) {
+	if m.consent != nil {
+		return m.consent, nil
+	}
+	return nil, zerrors.ThrowNotFound(nil, "ERROR", "error")
+}
+
 type mockIDPUserLinks struct {
 	idps []*query.IDPUserLink
 }
@@ -287,6 +298,17 @@ func (m *mockIDPUserLinks) IDPUserLinks(ctx context.Context, queries *query.IDPU
 	return &query.IDPUserLinks{Links: m.idps}, nil
 }
 
+type mockDomainIDPRouting struct {
+	routing *query.OrgDomainIDPRouting
+}
+
+func (m *mockDomainIDPRouting) DomainIDPRoutingByDomain(ctx context.Context, orgDomain string) (*query.OrgDomainIDPRouting, error) {
+	if m.routing != nil && m.routing.Domain == orgDomain {
+		return m.routing, nil
+	}
+	return nil, zerrors.ThrowNotFound(nil, "ERROR", "error")
+}
+
 func TestAuthRequestRepo_nextSteps(t *testing.T) {
 	type fields struct {
 		AuthRequests            cache.AuthRequestCache
@@ -304,6 +326,7 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 		privacyPolicyProvider   privacyPolicyProvider
 		labelPolicyProvider     labelPolicyProvider
 		customTextProvider      customTextProvider
+		userConsentProvider     userConsentProvider
 	}
 	type args struct {
 		request       *domain.AuthRequest
@@ -1643,6 +1666,206 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			[]domain.NextStep{&domain.LinkUsersStep{}},
 			nil,
 		},
+		{
+			"authorization details requested, consent step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+				userGrantProvider:    &mockUserGrants{},
+				projectProvider:      &mockProject{},
+				applicationProvider:  &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb}}},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					Request: &domain.AuthRequestOIDC{
+						AuthorizationDetails: domain.AuthorizationDetails{{Type: "payment_initiation"}},
+					},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeTOTP},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.ConsentStep{AuthorizationDetails: domain.AuthorizationDetails{{Type: "payment_initiation"}}}},
+			nil,
+		},
+		{
+			"consent required, no consent, consent step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb, ConsentRequired: true}}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+				userConsentProvider:  &mockUserConsent{},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					Request: &domain.AuthRequestOIDC{
+						Scopes: []string{"openid", "email"},
+					},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeTOTP},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.ConsentStep{Scopes: []string{"openid", "email"}}},
+			nil,
+		},
+		{
+			"consent required, scope not granted, consent step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb, ConsentRequired: true}}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+				userConsentProvider:  &mockUserConsent{consent: &query.UserConsent{Scopes: []string{"openid"}}},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					Request: &domain.AuthRequestOIDC{
+						Scopes: []string{"openid", "email"},
+					},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeTOTP},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.ConsentStep{Scopes: []string{"openid", "email"}}},
+			nil,
+		},
+		{
+			"consent required, scopes granted, redirect to callback step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb, ConsentRequired: true}}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+				userConsentProvider:  &mockUserConsent{consent: &query.UserConsent{Scopes: []string{"email", "openid", "profile"}}},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					Request: &domain.AuthRequestOIDC{
+						Scopes: []string{"openid", "email"},
+					},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeTOTP},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.RedirectToCallbackStep{}},
+			nil,
+		},
+		{
+			"consent required, scopes granted and prompt consent, consent step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb, ConsentRequired: true}}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+				userConsentProvider:  &mockUserConsent{consent: &query.UserConsent{Scopes: []string{"email", "openid"}}},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					Prompt: []domain.Prompt{domain.PromptConsent},
+					Request: &domain.AuthRequestOIDC{
+						Scopes: []string{"openid", "email"},
+					},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeTOTP},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.ConsentStep{Scopes: []string{"openid", "email"}}},
+			nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1662,6 +1885,7 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 				PrivacyPolicyProvider:     tt.fields.privacyPolicyProvider,
 				LabelPolicyProvider:       tt.fields.labelPolicyProvider,
 				CustomTextProvider:        tt.fields.customTextProvider,
+				UserConsentProvider:       tt.fields.userConsentProvider,
 			}
 			got, err := repo.nextSteps(context.Background(), tt.args.request, tt.args.checkLoggedIn)
 			if (err != nil && tt.wantErr == nil) || (tt.wantErr != nil && !tt.wantErr(err)) {
@@ -2302,3 +2526,166 @@ func Test_userByID(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthRequestRepo_checkIDPRouting(t *testing.T) {
+	routing := func(force bool) *query.OrgDomainIDPRouting {
+		return &query.OrgDomainIDPRouting{
+			OrgID:  "orgID",
+			Domain: "partner.com",
+			OrgDomainIDPRouting: domain.OrgDomainIDPRouting{
+				IDPIDs: []string{"idp1", "idp2"},
+				Force:  force,
+			},
+		}
+	}
+	user := &user_view_model.UserView{
+		ID:                 "userID",
+		PreferredLoginName: "alice@partner.com",
+		ResourceOwner:      "orgID",
+		State:              int32(user_model.UserStateActive),
+		HumanView: &user_view_model.HumanView{
+			FirstName: "Alice",
+			Email:     "alice@partner.com",
+		},
+	}
+	allowedIDPs := []*domain.IDPProvider{{IDPConfigID: "idp1"}, {IDPConfigID: "idp2"}}
+	type fields struct {
+		DomainIDPRoutingProvider domainIDPRoutingProvider
+		IDPUserLinksProvider     idpUserLinksProvider
+	}
+	type args struct {
+		request   *domain.AuthRequest
+		loginName string
+		user      *user_view_model.UserView
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantRouted   bool
+		wantSelected string
+	}{
+		{
+			name: "loginname without domain, not routed",
+			fields: fields{
+				DomainIDPRoutingProvider: &mockDomainIDPRouting{routing: routing(true)},
+			},
+			args: args{
+				request:   &domain.AuthRequest{AllowedExternalIDPs: allowedIDPs},
+				loginName: "alice",
+				user: &user_view_model.UserView{
+					ID:        "userID",
+					State:     int32(user_model.UserStateActive),
+					HumanView: &user_view_model.HumanView{FirstName: "Alice"},
+				},
+			},
+		},
+		{
+			name: "domain without routing, not routed",
+			fields: fields{
+				DomainIDPRoutingProvider: &mockDomainIDPRouting{routing: routing(true)},
+			},
+			args: args{
+				request:   &domain.AuthRequest{AllowedExternalIDPs: allowedIDPs},
+				loginName: "alice@example.com",
+				user:      user,
+			},
+		},
+		{
+			name: "user not linked, not routed",
+			fields: fields{
+				DomainIDPRoutingProvider: &mockDomainIDPRouting{routing: routing(false)},
+				IDPUserLinksProvider:     &mockIDPUserLinks{},
+			},
+			args: args{
+				request:   &domain.AuthRequest{AllowedExternalIDPs: allowedIDPs},
+				loginName: "alice@partner.com",
+				user:      user,
+			},
+		},
+		{
+			name: "providers not allowed, not routed",
+			fields: fields{
+				DomainIDPRoutingProvider: &mockDomainIDPRouting{routing: routing(true)},
+				IDPUserLinksProvider:     &mockIDPUserLinks{},
+			},
+			args: args{
+				request:   &domain.AuthRequest{AllowedExternalIDPs: []*domain.IDPProvider{{IDPConfigID: "idp3"}}},
+				loginName: "alice@partner.com",
+				user:      user,
+			},
+		},
+		{
+			name: "user linked, routed to linked provider",
+			fields: fields{
+				DomainIDPRoutingProvider: &mockDomainIDPRouting{routing: routing(false)},
+				IDPUserLinksProvider:     &mockIDPUserLinks{idps: []*query.IDPUserLink{{IDPID: "idp2"}}},
+			},
+			args: args{
+				request:   &domain.AuthRequest{AllowedExternalIDPs: allowedIDPs},
+				loginName: "alice@partner.com",
+				user:      user,
+			},
+			wantRouted:   true,
+			wantSelected: "idp2",
+		},
+		{
+			name: "forced, email domain not routed",
+			fields: fields{
+				DomainIDPRoutingProvider: &mockDomainIDPRouting{routing: routing(true)},
+				IDPUserLinksProvider:     &mockIDPUserLinks{},
+			},
+			args: args{
+				request:   &domain.AuthRequest{AllowedExternalIDPs: allowedIDPs},
+				loginName: "alice",
+				user:      user,
+			},
+		},
+		{
+			name: "forced routing of other organization, not routed",
+			fields: fields{
+				DomainIDPRoutingProvider: &mockDomainIDPRouting{routing: routing(true)},
+				IDPUserLinksProvider:     &mockIDPUserLinks{},
+			},
+			args: args{
+				request:   &domain.AuthRequest{AllowedExternalIDPs: allowedIDPs},
+				loginName: "alice@partner.com",
+				user: &user_view_model.UserView{
+					ID:            "userID",
+					ResourceOwner: "otherOrgID",
+					State:         int32(user_model.UserStateActive),
+					HumanView:     &user_view_model.HumanView{FirstName: "Alice"},
+				},
+			},
+		},
+		{
+			name: "forced, routed to first provider",
+			fields: fields{
+				DomainIDPRoutingProvider: &mockDomainIDPRouting{routing: routing(true)},
+				IDPUserLinksProvider:     &mockIDPUserLinks{},
+			},
+			args: args{
+				request:   &domain.AuthRequest{AllowedExternalIDPs: allowedIDPs},
+				loginName: "alice@partner.com",
+				user:      user,
+			},
+			wantRouted:   true,
+			wantSelected: "idp1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &AuthRequestRepo{
+				DomainIDPRoutingProvider: tt.fields.DomainIDPRoutingProvider,
+				IDPUserLinksProvider:     tt.fields.IDPUserLinksProvider,
+			}
+			routed, err := repo.checkIDPRouting(context.Background(), tt.args.request, tt.args.loginName, tt.args.user)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRouted, routed)
+			assert.Equal(t, tt.wantSelected, tt.args.request.SelectedIDPConfigID)
+			if tt.wantRouted {
+				assert.Equal(t, tt.args.user.ID, tt.args.request.UserID)
+			}
+		})
+	}
+}
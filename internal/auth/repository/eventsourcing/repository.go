@@ -71,12 +71,14 @@ func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, c
 			UserEventProvider:         &userRepo,
 			IDPProviderViewProvider:   queries,
 			IDPUserLinksProvider:      queries,
+			DomainIDPRoutingProvider:  queries,
 			LockoutPolicyViewProvider: queries,
 			LoginPolicyViewProvider:   queries,
 			UserGrantProvider:         queryView,
 			ProjectProvider:           queryView,
 			ApplicationProvider:       queries,
 			CustomTextProvider:        queries,
+			UserConsentProvider:       queries,
 			FeatureCheck:              feature.NewCheck(esV2),
 			IdGenerator:               id.SonyFlakeGenerator(),
 		},
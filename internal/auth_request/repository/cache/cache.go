@@ -29,37 +29,41 @@ func (c *AuthRequestCache) Health(ctx context.Context) error {
 }
 
 func (c *AuthRequestCache) GetAuthRequestByID(ctx context.Context, id string) (*domain.AuthRequest, error) {
-	return c.getAuthRequest("id", id, authz.GetInstance(ctx).InstanceID())
+	return c.getAuthRequest(ctx, "id", id, authz.GetInstance(ctx).InstanceID())
 }
 
 func (c *AuthRequestCache) GetAuthRequestByCode(ctx context.Context, code string) (*domain.AuthRequest, error) {
-	return c.getAuthRequest("code", code, authz.GetInstance(ctx).InstanceID())
+	return c.getAuthRequest(ctx, "code", code, authz.GetInstance(ctx).InstanceID())
 }
 
-func (c *AuthRequestCache) SaveAuthRequest(_ context.Context, request *domain.AuthRequest) error {
-	return c.saveAuthRequest(request, "INSERT INTO auth.auth_requests (id, request, instance_id, creation_date, change_date, request_type) VALUES($1, $2, $3, $4, $4, $5)", request.CreationDate, request.Request.Type())
+func (c *AuthRequestCache) SaveAuthRequest(ctx context.Context, request *domain.AuthRequest) error {
+	return c.saveAuthRequest(ctx, request, "INSERT INTO auth.auth_requests (id, request, instance_id, creation_date, change_date, request_type) VALUES($1, $2, $3, $4, $4, $5)", request.CreationDate, request.Request.Type())
 }
 
-func (c *AuthRequestCache) UpdateAuthRequest(_ context.Context, request *domain.AuthRequest) error {
+func (c *AuthRequestCache) UpdateAuthRequest(ctx context.Context, request *domain.AuthRequest) error {
 	if request.ChangeDate.IsZero() {
 		request.ChangeDate = time.Now()
 	}
-	return c.saveAuthRequest(request, "UPDATE auth.auth_requests SET request = $2, instance_id = $3, change_date = $4, code = $5 WHERE id = $1", request.ChangeDate, request.Code)
+	return c.saveAuthRequest(ctx, request, "UPDATE auth.auth_requests SET request = $2, instance_id = $3, change_date = $4, code = $5 WHERE id = $1", request.ChangeDate, request.Code)
 }
 
 func (c *AuthRequestCache) DeleteAuthRequest(ctx context.Context, id string) error {
-	_, err := c.client.Exec("DELETE FROM auth.auth_requests WHERE instance_id = $1 and id = $2", authz.GetInstance(ctx).InstanceID(), id)
+	// auth requests of instances which are moved to another shard must not be written
+	if err := c.client.CheckWritable(ctx); err != nil {
+		return err
+	}
+	_, err := c.client.ExecContext(ctx, "DELETE FROM auth.auth_requests WHERE instance_id = $1 and id = $2", authz.GetInstance(ctx).InstanceID(), id)
 	if err != nil {
 		return zerrors.ThrowInternal(err, "CACHE-dsHw3", "unable to delete auth request")
 	}
 	return nil
 }
 
-func (c *AuthRequestCache) getAuthRequest(key, value, instanceID string) (*domain.AuthRequest, error) {
+func (c *AuthRequestCache) getAuthRequest(ctx context.Context, key, value, instanceID string) (*domain.AuthRequest, error) {
 	var b []byte
 	var requestType domain.AuthRequestType
 	query := fmt.Sprintf("SELECT request, request_type FROM auth.auth_requests WHERE instance_id = $1 and %s = $2", key)
-	err := c.client.QueryRow(
+	err := c.client.QueryRowContext(ctx,
 		func(row *sql.Row) error {
 			return row.Scan(&b, &requestType)
 		},
@@ -81,12 +85,17 @@ func (c *AuthRequestCache) getAuthRequest(key, value, instanceID string) (*domai
 	return request, nil
 }
 
-func (c *AuthRequestCache) saveAuthRequest(request *domain.AuthRequest, query string, date time.Time, param interface{}) error {
+// saveAuthRequest stores the request on the shard of its instance
+func (c *AuthRequestCache) saveAuthRequest(ctx context.Context, request *domain.AuthRequest, query string, date time.Time, param interface{}) error {
+	ctx = authz.WithInstanceID(ctx, request.InstanceID)
+	if err := c.client.CheckWritable(ctx); err != nil {
+		return err
+	}
 	b, err := json.Marshal(request)
 	if err != nil {
 		return zerrors.ThrowInternal(err, "CACHE-os0GH", "Errors.Internal")
 	}
-	_, err = c.client.Exec(query, request.ID, b, request.InstanceID, date, param)
+	_, err = c.client.ExecContext(ctx, query, request.ID, b, request.InstanceID, date, param)
 	if err != nil {
 		return zerrors.ThrowInternal(err, "CACHE-su3GK", "Errors.Internal")
 	}
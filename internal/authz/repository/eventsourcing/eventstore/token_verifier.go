@@ -214,6 +214,9 @@ func authMethodsFromSession(session *query.Session) []domain.UserAuthMethodType
 	if !session.OTPEmailFactor.OTPCheckedAt.IsZero() {
 		types = append(types, domain.UserAuthMethodTypeOTPEmail)
 	}
+	if !session.PushFactor.PushCheckedAt.IsZero() {
+		types = append(types, domain.UserAuthMethodTypePush)
+	}
 	return types
 }
 
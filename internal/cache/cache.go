@@ -0,0 +1,125 @@
+// Package cache provides abstraction of cache implementations that can be used by zitadel.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Cache stores objects with a value of type `V`.
+// Objects may be referred to by one or more indices.
+// Implementations may encode the value for storage.
+// This means non-exported fields may be lost and objects
+// with function values may fail to encode.
+// See https://pkg.go.dev/encoding/json#Marshal for example.
+//
+// `I` is the type by which indices are identified,
+// typically an enum for type-safe access.
+// Indices are defined when calling the constructor of an implementation of this interface.
+// The first index must uniquely identify an object.
+// It is illegal to refer to an index not defined during construction.
+//
+// `K` is the type used as key in each index.
+// Due to the limitations in type constraints, all indices use the same key type.
+//
+// Implementations are free to use stricter type constraints or fixed typing.
+type Cache[I, K comparable, V Entry[I, K]] interface {
+	// Get an object through specified index.
+	// When the object is not found, or is no longer valid,
+	// the zero value of `V` and false is returned.
+	// Errors of the underlying connector are logged and treated as a miss.
+	Get(ctx context.Context, index I, key K) (V, bool)
+
+	// Set an object.
+	// Keys are created on each index based in the [Entry.Keys] method.
+	// The first key of the first index passed to the constructor
+	// identifies the object: an existing object with the same identity is replaced.
+	// Errors of the underlying connector are logged.
+	Set(ctx context.Context, value V)
+
+	// Invalidate an object through specified index.
+	// Implementations may choose to instantly delete the object,
+	// defer until prune or a separate cleanup routine.
+	// Invalidated object are no longer returned from Get.
+	// It is safe to call Invalidate multiple times or on non-existing entries.
+	Invalidate(ctx context.Context, index I, key ...K) error
+
+	// Delete one or more keys from a specific index.
+	// An error is returned if the index is unknown.
+	// The referred object is not invalidated and may still be accessible though
+	// other indices and keys.
+	// It is safe to call Delete multiple times or on non-existing entries
+	Delete(ctx context.Context, index I, key ...K) error
+
+	// Truncate deletes all cached objects.
+	Truncate(ctx context.Context) error
+}
+
+// Entry contains a value of type `V` to be cached.
+//
+// `I` is the type by which indices are identified,
+// typically an enum for type-safe access.
+//
+// `K` is the type used as key in an index.
+type Entry[I, K comparable] interface {
+	// Keys returns which keys map to the object in a specified index.
+	// May return nil if the index in unknown or when there are no keys.
+	// A key may be shared by multiple objects of the same index,
+	// for example an instance ID for all objects belonging to that instance.
+	// Invalidating such a key invalidates all the objects sharing it.
+	Keys(index I) (key []K)
+}
+
+type Connector string
+
+const (
+	// ConnectorNone disables caching, all lookups result in a miss.
+	ConnectorNone Connector = ""
+	// ConnectorMemory caches objects in the memory of the local process.
+	ConnectorMemory Connector = "memory"
+	// ConnectorRedis caches objects in a Redis compatible server shared by all processes.
+	ConnectorRedis Connector = "redis"
+)
+
+type Config struct {
+	// Connector to be used for the cache.
+	// Cache is disabled if the connector is empty.
+	Connector Connector
+
+	// Age since an object was added to the cache,
+	// after which the object is considered invalid.
+	// 0 disables max age checks.
+	MaxAge time.Duration
+
+	// Age since last use (Get) of an object,
+	// after which the object is considered invalid.
+	// 0 disables last use age checks.
+	LastUseAge time.Duration
+}
+
+// IsValid reports whether an object created at `created`
+// and last used at `lastUse` is still valid at `now`.
+func (c *Config) IsValid(now, created, lastUse time.Time) bool {
+	if c.MaxAge > 0 && now.Sub(created) > c.MaxAge {
+		return false
+	}
+	if c.LastUseAge > 0 && now.Sub(lastUse) > c.LastUseAge {
+		return false
+	}
+	return true
+}
+
+// IndexUnknownError is returned by a [Cache] if an index is used
+// which was not passed to the constructor.
+type IndexUnknownError[I comparable] struct {
+	index I
+}
+
+func NewIndexUnknownErr[I comparable](index I) error {
+	return IndexUnknownError[I]{index}
+}
+
+func (i IndexUnknownError[I]) Error() string {
+	return fmt.Sprintf("index %v unknown", i.index)
+}
@@ -0,0 +1,85 @@
+// Package connector provides glue between the [cache.Cache] interface and implementations from the connector sub-packages.
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/gomap"
+	"github.com/zitadel/zitadel/internal/cache/noop"
+	"github.com/zitadel/zitadel/internal/cache/redis"
+)
+
+// memoryMaxAge is the longest age objects of memory caches should be served,
+// because invalidations don't reach other processes.
+const memoryMaxAge = time.Minute
+
+type CachesConfig struct {
+	Connectors struct {
+		Memory gomap.Config
+		Redis  redis.Config
+	}
+	Instance     *cache.Config
+	Organization *cache.Config
+	LoginPolicy  *cache.Config
+	OIDCClient   *cache.Config
+	SigningKeys  *cache.Config
+}
+
+type Connectors struct {
+	Config CachesConfig
+	Redis  *redis.Connector
+}
+
+// StartConnectors creates the connectors shared by all caches.
+// The Redis connector is only created if an address is configured.
+func StartConnectors(ctx context.Context, conf *CachesConfig) Connectors {
+	if conf == nil {
+		return Connectors{}
+	}
+	connectors := Connectors{
+		Config: *conf,
+	}
+	if conf.Connectors.Redis.Addr != "" {
+		connectors.Redis = redis.NewConnector(&conf.Connectors.Redis)
+		err := connectors.Redis.Ping(ctx)
+		logging.OnError(err).Warn("cache: redis not reachable")
+	}
+	return connectors
+}
+
+// StartCache creates the cache for the purpose using the connector configured in conf.
+// Caching is disabled if conf is nil or no connector is set.
+func StartCache[I, K comparable, V cache.Entry[I, K]](purpose string, indices []I, conf *cache.Config, connectors Connectors) (cache.Cache[I, K, V], error) {
+	if conf == nil || conf.Connector == cache.ConnectorNone {
+		return noop.NewCache[I, K, V](), nil
+	}
+	var c cache.Cache[I, K, V]
+	switch conf.Connector {
+	case cache.ConnectorMemory:
+		logging.WithFields("cache", purpose, "max_age", conf.MaxAge).
+			OnError(checkMemoryMaxAge(conf)).
+			Warn("cache: stale objects are served by other processes, use redis if ZITADEL runs as multiple processes")
+		c = gomap.NewCache[I, K, V](purpose, indices, conf, connectors.Config.Connectors.Memory)
+	case cache.ConnectorRedis:
+		if connectors.Redis == nil {
+			return nil, fmt.Errorf("cache %s: redis connector is not configured", purpose)
+		}
+		c = redis.NewCache[I, K, V](purpose, indices, conf, connectors.Redis)
+	default:
+		return nil, fmt.Errorf("cache %s: unknown connector %q", purpose, conf.Connector)
+	}
+	return cache.WithMetrics(purpose, conf.Connector, c), nil
+}
+
+// checkMemoryMaxAge reports ages which let processes serve invalidated objects for too long.
+func checkMemoryMaxAge(conf *cache.Config) error {
+	if conf.MaxAge <= 0 || conf.MaxAge > memoryMaxAge {
+		return fmt.Errorf("max age of memory cache should be between 0 and %s", memoryMaxAge)
+	}
+	return nil
+}
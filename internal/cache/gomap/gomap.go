@@ -0,0 +1,202 @@
+// Package gomap provides a [cache.Cache] which keeps objects in the memory of the local process.
+// Objects are evicted in least recently used order once the configured amount of entries is reached.
+package gomap
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+type Config struct {
+	// MaxEntries is the maximum amount of objects kept by each cache.
+	// The least recently used object is evicted when the limit is exceeded.
+	// 0 means unlimited.
+	MaxEntries int
+}
+
+type mapCache[I, K comparable, V cache.Entry[I, K]] struct {
+	name       string
+	indices    []I
+	config     *cache.Config
+	maxEntries int
+	now        func() time.Time
+
+	mu sync.Mutex
+	// lru holds the objects, the most recently used object is in front.
+	lru     *list.List
+	objects map[K]*list.Element
+	index   map[I]map[K][]*list.Element
+}
+
+type object[K comparable, V any] struct {
+	id      K
+	value   V
+	created time.Time
+	lastUse time.Time
+	removed bool
+}
+
+// NewCache returns an in-memory Cache implementation based on the builtin go map type
+// and a doubly linked list for least recently used eviction.
+// Object values are stored as-is and there is no encoding or decoding involved,
+// so values returned from the cache must not be modified.
+func NewCache[I, K comparable, V cache.Entry[I, K]](name string, indices []I, config *cache.Config, connector Config) cache.Cache[I, K, V] {
+	c := &mapCache[I, K, V]{
+		name:       name,
+		indices:    indices,
+		config:     config,
+		maxEntries: connector.MaxEntries,
+		now:        time.Now,
+	}
+	c.init()
+	return c
+}
+
+func (c *mapCache[I, K, V]) init() {
+	c.lru = list.New()
+	c.objects = make(map[K]*list.Element)
+	c.index = make(map[I]map[K][]*list.Element, len(c.indices))
+	for _, index := range c.indices {
+		c.index[index] = make(map[K][]*list.Element)
+	}
+}
+
+func (c *mapCache[I, K, V]) Get(_ context.Context, index I, key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys, ok := c.index[index]
+	if !ok {
+		logging.WithFields("cache", c.name, "index", index).WithError(cache.NewIndexUnknownErr(index)).Error("cache get")
+		return value, false
+	}
+	now := c.now()
+	for _, elem := range slices.Clone(keys[key]) {
+		obj := elem.Value.(*object[K, V])
+		if obj.removed {
+			continue
+		}
+		if !c.config.IsValid(now, obj.created, obj.lastUse) {
+			c.remove(elem)
+			continue
+		}
+		obj.lastUse = now
+		c.lru.MoveToFront(elem)
+		return obj.value, true
+	}
+	return value, false
+}
+
+func (c *mapCache[I, K, V]) Set(_ context.Context, value V) {
+	id, ok := c.identity(value)
+	if !ok {
+		logging.WithFields("cache", c.name).Debug("cache set: object without identity skipped")
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.objects[id]; ok {
+		c.remove(elem)
+	}
+	now := c.now()
+	elem := c.lru.PushFront(&object[K, V]{
+		id:      id,
+		value:   value,
+		created: now,
+		lastUse: now,
+	})
+	c.objects[id] = elem
+	for _, index := range c.indices {
+		for _, key := range value.Keys(index) {
+			c.index[index][key] = append(c.index[index][key], elem)
+		}
+	}
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *mapCache[I, K, V]) Invalidate(_ context.Context, index I, keys ...K) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	indexKeys, ok := c.index[index]
+	if !ok {
+		return cache.NewIndexUnknownErr(index)
+	}
+	for _, key := range keys {
+		for _, elem := range slices.Clone(indexKeys[key]) {
+			c.remove(elem)
+		}
+	}
+	return nil
+}
+
+func (c *mapCache[I, K, V]) Delete(_ context.Context, index I, keys ...K) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	indexKeys, ok := c.index[index]
+	if !ok {
+		return cache.NewIndexUnknownErr(index)
+	}
+	for _, key := range keys {
+		delete(indexKeys, key)
+	}
+	return nil
+}
+
+func (c *mapCache[I, K, V]) Truncate(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.init()
+	return nil
+}
+
+// identity returns the first key of the first index.
+func (c *mapCache[I, K, V]) identity(value V) (id K, ok bool) {
+	if len(c.indices) == 0 {
+		return id, false
+	}
+	keys := value.Keys(c.indices[0])
+	if len(keys) == 0 {
+		return id, false
+	}
+	return keys[0], true
+}
+
+// remove the element from the list and all its keys from the indices.
+// The caller must hold the lock.
+func (c *mapCache[I, K, V]) remove(elem *list.Element) {
+	obj := c.lru.Remove(elem).(*object[K, V])
+	if obj.removed {
+		return
+	}
+	obj.removed = true
+	if c.objects[obj.id] == elem {
+		delete(c.objects, obj.id)
+	}
+	for _, index := range c.indices {
+		indexKeys := c.index[index]
+		for _, key := range obj.value.Keys(index) {
+			elems := slices.DeleteFunc(indexKeys[key], func(e *list.Element) bool {
+				return e == elem
+			})
+			if len(elems) == 0 {
+				delete(indexKeys, key)
+				continue
+			}
+			indexKeys[key] = elems
+		}
+	}
+}
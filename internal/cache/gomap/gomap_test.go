@@ -0,0 +1,330 @@
+package gomap
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+type testIndex int
+
+const (
+	testIndexID testIndex = iota
+	testIndexName
+	testIndexInstance
+)
+
+var testIndices = []testIndex{
+	testIndexID,
+	testIndexName,
+	testIndexInstance,
+}
+
+type testObject struct {
+	id       string
+	names    []string
+	instance string
+}
+
+func (o *testObject) Keys(index testIndex) []string {
+	switch index {
+	case testIndexID:
+		return []string{o.id}
+	case testIndexName:
+		return o.names
+	case testIndexInstance:
+		return []string{o.instance}
+	default:
+		return nil
+	}
+}
+
+func newTestCache(config *cache.Config, connector Config, now func() time.Time) *mapCache[testIndex, string, *testObject] {
+	c := NewCache[testIndex, string, *testObject]("test", testIndices, config, connector).(*mapCache[testIndex, string, *testObject])
+	if now != nil {
+		c.now = now
+	}
+	return c
+}
+
+func Test_mapCache_Get(t *testing.T) {
+	obj := &testObject{
+		id:       "id",
+		names:    []string{"foo", "bar"},
+		instance: "instance",
+	}
+	type args struct {
+		index testIndex
+		key   string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   *testObject
+		wantOk bool
+	}{
+		{
+			name: "by id",
+			args: args{
+				index: testIndexID,
+				key:   "id",
+			},
+			want:   obj,
+			wantOk: true,
+		},
+		{
+			name: "by second name",
+			args: args{
+				index: testIndexName,
+				key:   "bar",
+			},
+			want:   obj,
+			wantOk: true,
+		},
+		{
+			name: "by shared key",
+			args: args{
+				index: testIndexInstance,
+				key:   "instance",
+			},
+			want:   obj,
+			wantOk: true,
+		},
+		{
+			name: "unknown key",
+			args: args{
+				index: testIndexName,
+				key:   "baz",
+			},
+			want:   nil,
+			wantOk: false,
+		},
+		{
+			name: "unknown index",
+			args: args{
+				index: 99,
+				key:   "id",
+			},
+			want:   nil,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCache(&cache.Config{}, Config{}, nil)
+			c.Set(context.Background(), obj)
+
+			got, ok := c.Get(context.Background(), tt.args.index, tt.args.key)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_mapCache_Get_expired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		config *cache.Config
+		setAt  time.Time
+		usedAt time.Time
+		getAt  time.Time
+		wantOk bool
+	}{
+		{
+			name:   "no ages",
+			config: &cache.Config{},
+			setAt:  now,
+			usedAt: now,
+			getAt:  now.Add(time.Hour),
+			wantOk: true,
+		},
+		{
+			name: "max age not reached",
+			config: &cache.Config{
+				MaxAge: time.Minute,
+			},
+			setAt:  now,
+			usedAt: now,
+			getAt:  now.Add(time.Second),
+			wantOk: true,
+		},
+		{
+			name: "max age exceeded",
+			config: &cache.Config{
+				MaxAge: time.Minute,
+			},
+			setAt:  now,
+			usedAt: now.Add(30 * time.Second),
+			getAt:  now.Add(61 * time.Second),
+			wantOk: false,
+		},
+		{
+			name: "last use age refreshed",
+			config: &cache.Config{
+				LastUseAge: time.Minute,
+			},
+			setAt:  now,
+			usedAt: now.Add(50 * time.Second),
+			getAt:  now.Add(100 * time.Second),
+			wantOk: true,
+		},
+		{
+			name: "last use age exceeded",
+			config: &cache.Config{
+				LastUseAge: time.Minute,
+			},
+			setAt:  now,
+			usedAt: now,
+			getAt:  now.Add(61 * time.Second),
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var current time.Time
+			c := newTestCache(tt.config, Config{}, func() time.Time { return current })
+
+			current = tt.setAt
+			c.Set(context.Background(), &testObject{id: "id"})
+			current = tt.usedAt
+			_, ok := c.Get(context.Background(), testIndexID, "id")
+			require.True(t, ok)
+
+			current = tt.getAt
+			_, ok = c.Get(context.Background(), testIndexID, "id")
+			assert.Equal(t, tt.wantOk, ok)
+			if !tt.wantOk {
+				assert.Zero(t, c.lru.Len())
+				assert.Empty(t, c.index[testIndexInstance])
+			}
+		})
+	}
+}
+
+func Test_mapCache_Set_replace(t *testing.T) {
+	c := newTestCache(&cache.Config{}, Config{}, nil)
+	c.Set(context.Background(), &testObject{id: "id", names: []string{"foo", "bar"}})
+	c.Set(context.Background(), &testObject{id: "id", names: []string{"foo"}})
+
+	_, ok := c.Get(context.Background(), testIndexName, "bar")
+	assert.False(t, ok, "ghost key")
+	got, ok := c.Get(context.Background(), testIndexName, "foo")
+	require.True(t, ok)
+	assert.Equal(t, []string{"foo"}, got.names)
+	assert.Equal(t, 1, c.lru.Len())
+}
+
+func Test_mapCache_Set_evict(t *testing.T) {
+	c := newTestCache(&cache.Config{}, Config{MaxEntries: 2}, nil)
+	c.Set(context.Background(), &testObject{id: "1"})
+	c.Set(context.Background(), &testObject{id: "2"})
+	// use 1, so 2 becomes the least recently used
+	_, ok := c.Get(context.Background(), testIndexID, "1")
+	require.True(t, ok)
+	c.Set(context.Background(), &testObject{id: "3"})
+
+	_, ok = c.Get(context.Background(), testIndexID, "2")
+	assert.False(t, ok)
+	_, ok = c.Get(context.Background(), testIndexID, "1")
+	assert.True(t, ok)
+	_, ok = c.Get(context.Background(), testIndexID, "3")
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.lru.Len())
+}
+
+func Test_mapCache_Invalidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   testIndex
+		keys    []string
+		wantIDs []string
+		wantErr error
+	}{
+		{
+			name:    "by name",
+			index:   testIndexName,
+			keys:    []string{"bar"},
+			wantIDs: []string{"2", "3"},
+		},
+		{
+			name:    "by shared key",
+			index:   testIndexInstance,
+			keys:    []string{"instance1"},
+			wantIDs: []string{"3"},
+		},
+		{
+			name:    "multiple keys",
+			index:   testIndexID,
+			keys:    []string{"1", "3"},
+			wantIDs: []string{"2"},
+		},
+		{
+			name:    "not existing",
+			index:   testIndexID,
+			keys:    []string{"4"},
+			wantIDs: []string{"1", "2", "3"},
+		},
+		{
+			name:    "unknown index",
+			index:   99,
+			keys:    []string{"1"},
+			wantIDs: []string{"1", "2", "3"},
+			wantErr: cache.NewIndexUnknownErr(testIndex(99)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCache(&cache.Config{}, Config{}, nil)
+			c.Set(context.Background(), &testObject{id: "1", names: []string{"foo", "bar"}, instance: "instance1"})
+			c.Set(context.Background(), &testObject{id: "2", names: []string{"baz"}, instance: "instance1"})
+			c.Set(context.Background(), &testObject{id: "3", names: []string{"qux"}, instance: "instance2"})
+
+			err := c.Invalidate(context.Background(), tt.index, tt.keys...)
+			require.ErrorIs(t, err, tt.wantErr)
+			for _, id := range []string{"1", "2", "3"} {
+				_, ok := c.Get(context.Background(), testIndexID, id)
+				assert.Equal(t, slices.Contains(tt.wantIDs, id), ok, id)
+			}
+		})
+	}
+}
+
+func Test_mapCache_Delete(t *testing.T) {
+	c := newTestCache(&cache.Config{}, Config{}, nil)
+	c.Set(context.Background(), &testObject{id: "1", names: []string{"foo", "bar"}})
+
+	err := c.Delete(context.Background(), testIndexName, "foo")
+	require.NoError(t, err)
+
+	_, ok := c.Get(context.Background(), testIndexName, "foo")
+	assert.False(t, ok)
+	_, ok = c.Get(context.Background(), testIndexName, "bar")
+	assert.True(t, ok)
+	_, ok = c.Get(context.Background(), testIndexID, "1")
+	assert.True(t, ok)
+
+	err = c.Delete(context.Background(), 99, "foo")
+	assert.ErrorIs(t, err, cache.NewIndexUnknownErr(testIndex(99)))
+}
+
+func Test_mapCache_Truncate(t *testing.T) {
+	c := newTestCache(&cache.Config{}, Config{}, nil)
+	c.Set(context.Background(), &testObject{id: "1"})
+	c.Set(context.Background(), &testObject{id: "2"})
+
+	err := c.Truncate(context.Background())
+	require.NoError(t, err)
+
+	_, ok := c.Get(context.Background(), testIndexID, "1")
+	assert.False(t, ok)
+	_, ok = c.Get(context.Background(), testIndexID, "2")
+	assert.False(t, ok)
+	assert.Zero(t, c.lru.Len())
+}
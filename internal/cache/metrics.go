@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zitadel/logging"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+)
+
+const (
+	HitsCounter                     = "zitadel.cache.hits"
+	HitsCounterDescription          = "Cache lookups which returned an object"
+	MissesCounter                   = "zitadel.cache.misses"
+	MissesCounterDescription        = "Cache lookups which did not return an object"
+	InvalidationsCounter            = "zitadel.cache.invalidations"
+	InvalidationsCounterDescription = "Cache invalidations"
+)
+
+type metricsCache[I, K comparable, V Entry[I, K]] struct {
+	Cache[I, K, V]
+	name      string
+	connector Connector
+}
+
+// WithMetrics counts the hits, misses and invalidations of the cache.
+func WithMetrics[I, K comparable, V Entry[I, K]](name string, connector Connector, c Cache[I, K, V]) Cache[I, K, V] {
+	registerCounter(HitsCounter, HitsCounterDescription)
+	registerCounter(MissesCounter, MissesCounterDescription)
+	registerCounter(InvalidationsCounter, InvalidationsCounterDescription)
+	return &metricsCache[I, K, V]{
+		Cache:     c,
+		name:      name,
+		connector: connector,
+	}
+}
+
+func (c *metricsCache[I, K, V]) Get(ctx context.Context, index I, key K) (V, bool) {
+	value, ok := c.Cache.Get(ctx, index, key)
+	counter := MissesCounter
+	if ok {
+		counter = HitsCounter
+	}
+	c.addCount(ctx, counter, index, 1)
+	return value, ok
+}
+
+func (c *metricsCache[I, K, V]) Invalidate(ctx context.Context, index I, keys ...K) error {
+	c.addCount(ctx, InvalidationsCounter, index, int64(len(keys)))
+	return c.Cache.Invalidate(ctx, index, keys...)
+}
+
+func (c *metricsCache[I, K, V]) addCount(ctx context.Context, counter string, index I, value int64) {
+	labels := map[string]attribute.Value{
+		"cache":     attribute.StringValue(c.name),
+		"connector": attribute.StringValue(string(c.connector)),
+		"index":     attribute.StringValue(fmt.Sprint(index)),
+	}
+	err := metrics.AddCount(ctx, counter, value, labels)
+	logging.WithFields("name", counter, "labels", labels).OnError(err).Debug("incrementing counter metric failed")
+}
+
+func registerCounter(counter, description string) {
+	err := metrics.RegisterCounter(counter, description)
+	logging.WithFields("metric", counter).OnError(err).Panic("unable to register counter")
+}
@@ -0,0 +1,33 @@
+// Package noop provides a [cache.Cache] which never stores any object.
+package noop
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+type noop[I, K comparable, V cache.Entry[I, K]] struct{}
+
+// NewCache returns a cache that does nothing
+func NewCache[I, K comparable, V cache.Entry[I, K]]() cache.Cache[I, K, V] {
+	return noop[I, K, V]{}
+}
+
+func (noop[I, K, V]) Set(context.Context, V) {}
+
+func (noop[I, K, V]) Get(context.Context, I, K) (value V, ok bool) {
+	return value, false
+}
+
+func (noop[I, K, V]) Invalidate(context.Context, I, ...K) error {
+	return nil
+}
+
+func (noop[I, K, V]) Delete(context.Context, I, ...K) error {
+	return nil
+}
+
+func (noop[I, K, V]) Truncate(context.Context) error {
+	return nil
+}
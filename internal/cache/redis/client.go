@@ -0,0 +1,311 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+type Config struct {
+	// Addr of the Redis compatible server in the form host:port.
+	Addr string
+	// Username is used for ACL authentication, leave empty for password only authentication.
+	Username string
+	// Password is used for authentication, authentication is skipped when empty.
+	Password string
+	// DB is the number of the logical database to select after connecting.
+	DB int
+	// EnableTLS connects to the server using TLS.
+	EnableTLS bool
+	// PoolSize is the maximum amount of idle connections kept open.
+	PoolSize int
+
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// Error is an error reply of the server.
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+var errProtocol = errors.New("redis: protocol error")
+
+// client is a minimal RESP2 client with a pool of idle connections.
+// Only the commands needed by the cache are used,
+// so all command arguments and replies are treated as strings.
+type client struct {
+	config *Config
+	pool   chan *conn
+}
+
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+func newClient(config *Config) *client {
+	poolSize := config.PoolSize
+	if poolSize <= 0 {
+		poolSize = 10
+	}
+	return &client{
+		config: config,
+		pool:   make(chan *conn, poolSize),
+	}
+}
+
+// Do executes a single command and returns its reply.
+func (c *client) Do(ctx context.Context, args ...string) (any, error) {
+	replies, err := c.Pipeline(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return replies[0], nil
+}
+
+// Pipeline sends all commands at once and reads their replies afterwards.
+// The first error reply of the server is returned.
+func (c *client) Pipeline(ctx context.Context, cmds ...[]string) (_ []any, err error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		var replyErr Error
+		// connections are only reused if the protocol state is known.
+		if err != nil && !errors.As(err, &replyErr) {
+			cn.Close()
+			return
+		}
+		c.put(cn)
+	}()
+
+	if err = cn.setDeadline(ctx, c.config.WriteTimeout); err != nil {
+		return nil, err
+	}
+	for _, args := range cmds {
+		if err = cn.writeCommand(args); err != nil {
+			return nil, err
+		}
+	}
+	if err = cn.w.Flush(); err != nil {
+		return nil, err
+	}
+
+	if err = cn.setDeadline(ctx, c.config.ReadTimeout); err != nil {
+		return nil, err
+	}
+	replies := make([]any, len(cmds))
+	var firstErr error
+	for i := range cmds {
+		replies[i], err = cn.readReply()
+		var replyErr Error
+		if errors.As(err, &replyErr) {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return replies, firstErr
+}
+
+// Close closes all idle connections.
+func (c *client) Close() {
+	for {
+		select {
+		case cn := <-c.pool:
+			cn.Close()
+		default:
+			return
+		}
+	}
+}
+
+func (c *client) get(ctx context.Context) (*conn, error) {
+	select {
+	case cn := <-c.pool:
+		return cn, nil
+	default:
+		return c.dial(ctx)
+	}
+}
+
+func (c *client) put(cn *conn) {
+	select {
+	case c.pool <- cn:
+	default:
+		cn.Close()
+	}
+}
+
+func (c *client) dial(ctx context.Context) (_ *conn, err error) {
+	dialer := &net.Dialer{Timeout: c.config.DialTimeout}
+	var netConn net.Conn
+	if c.config.EnableTLS {
+		netConn, err = (&tls.Dialer{NetDialer: dialer}).DialContext(ctx, "tcp", c.config.Addr)
+	} else {
+		netConn, err = dialer.DialContext(ctx, "tcp", c.config.Addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cn := &conn{
+		Conn: netConn,
+		r:    bufio.NewReader(netConn),
+		w:    bufio.NewWriter(netConn),
+	}
+
+	var setup [][]string
+	if c.config.Password != "" {
+		auth := []string{"AUTH", c.config.Password}
+		if c.config.Username != "" {
+			auth = []string{"AUTH", c.config.Username, c.config.Password}
+		}
+		setup = append(setup, auth)
+	}
+	if c.config.DB != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.config.DB)})
+	}
+	for _, args := range setup {
+		if err = cn.setDeadline(ctx, c.config.WriteTimeout+c.config.ReadTimeout); err == nil {
+			err = cn.writeCommand(args)
+		}
+		if err == nil {
+			err = cn.w.Flush()
+		}
+		if err == nil {
+			_, err = cn.readReply()
+		}
+		if err != nil {
+			cn.Close()
+			return nil, fmt.Errorf("redis: connection setup %s: %w", args[0], err)
+		}
+	}
+	return cn, nil
+}
+
+func (cn *conn) setDeadline(ctx context.Context, timeout time.Duration) error {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	return cn.SetDeadline(deadline)
+}
+
+func (cn *conn) writeCommand(args []string) error {
+	cn.w.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		cn.w.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n")
+		cn.w.WriteString(arg)
+		if _, err := cn.w.WriteString("\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readReply reads a RESP2 reply.
+// Simple strings are returned as string, integers as int64,
+// bulk strings as []byte and arrays as []any.
+// Null bulk strings and null arrays are returned as nil.
+func (cn *conn) readReply() (any, error) {
+	return readReply(cn.r)
+}
+
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errProtocol
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errProtocol
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err = io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errProtocol
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		values := make([]any, size)
+		for i := range values {
+			values[i], err = readReply(r)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, errProtocol
+	}
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", errProtocol
+	}
+	return line[:len(line)-2], nil
+}
+
+// stringsReply converts an array reply of bulk strings.
+func stringsReply(reply any) ([]string, error) {
+	if reply == nil {
+		return nil, nil
+	}
+	values, ok := reply.([]any)
+	if !ok {
+		return nil, errProtocol
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		data, ok := value.([]byte)
+		if !ok {
+			return nil, errProtocol
+		}
+		result = append(result, string(data))
+	}
+	return result, nil
+}
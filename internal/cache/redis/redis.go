@@ -0,0 +1,279 @@
+// Package redis provides a [cache.Cache] which stores objects in a Redis compatible server,
+// so that the cache can be shared between multiple processes.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+// Connector holds the connection pool shared by all caches using the Redis connector.
+type Connector struct {
+	client *client
+}
+
+func NewConnector(config *Config) *Connector {
+	return &Connector{
+		client: newClient(config),
+	}
+}
+
+// Ping checks if the server is reachable.
+func (c *Connector) Ping(ctx context.Context) error {
+	_, err := c.client.Do(ctx, "PING")
+	return err
+}
+
+// Close closes all idle connections to the server.
+func (c *Connector) Close() {
+	c.client.Close()
+}
+
+// redisCache stores the JSON encoded objects under `zitadel:cache:<name>:o:<id>`,
+// where the id is the first key of the first index.
+// Each index key is a set of object ids stored under `zitadel:cache:<name>:i:<index>:<key>`.
+// Sets are not updated when objects expire,
+// stale ids are removed when they are encountered on Get.
+type redisCache[I, K comparable, V cache.Entry[I, K]] struct {
+	name    string
+	indices []I
+	config  *cache.Config
+	client  *client
+	now     func() time.Time
+}
+
+// envelope is the stored representation of an object.
+type envelope[V any] struct {
+	Created time.Time `json:"created"`
+	Value   V         `json:"value"`
+}
+
+// NewCache returns a cache which stores the objects JSON encoded in a Redis compatible server.
+// Non-exported fields of the objects are lost.
+func NewCache[I, K comparable, V cache.Entry[I, K]](name string, indices []I, config *cache.Config, connector *Connector) cache.Cache[I, K, V] {
+	return &redisCache[I, K, V]{
+		name:    name,
+		indices: indices,
+		config:  config,
+		client:  connector.client,
+		now:     time.Now,
+	}
+}
+
+func (c *redisCache[I, K, V]) Get(ctx context.Context, index I, key K) (value V, ok bool) {
+	if !slices.Contains(c.indices, index) {
+		c.logger(index).WithError(cache.NewIndexUnknownErr(index)).Error("cache get")
+		return value, false
+	}
+	indexKey := c.indexKey(index, key)
+	reply, err := c.client.Do(ctx, "SMEMBERS", indexKey)
+	if err != nil {
+		c.logger(index).WithError(err).Warn("cache get")
+		return value, false
+	}
+	ids, err := stringsReply(reply)
+	if err != nil {
+		c.logger(index).WithError(err).Warn("cache get")
+		return value, false
+	}
+	for _, id := range ids {
+		value, ok, err = c.getObject(ctx, index, key, indexKey, id)
+		if err != nil {
+			c.logger(index).WithError(err).Warn("cache get")
+			return value, false
+		}
+		if ok {
+			return value, true
+		}
+		// the object is gone or does not belong to the key anymore
+		_, err = c.client.Do(ctx, "SREM", indexKey, id)
+		c.logger(index).OnError(err).Warn("cache get: remove stale id")
+	}
+	return value, false
+}
+
+func (c *redisCache[I, K, V]) getObject(ctx context.Context, index I, key K, indexKey, id string) (value V, ok bool, err error) {
+	objectKey := c.objectKey(id)
+	reply, err := c.client.Do(ctx, "GET", objectKey)
+	if err != nil || reply == nil {
+		return value, false, err
+	}
+	data, isBytes := reply.([]byte)
+	if !isBytes {
+		return value, false, errProtocol
+	}
+	object := new(envelope[V])
+	if err = json.Unmarshal(data, object); err != nil {
+		return value, false, err
+	}
+	if !slices.Contains(object.Value.Keys(index), key) {
+		return value, false, nil
+	}
+	now := c.now()
+	if !c.config.IsValid(now, object.Created, now) {
+		_, err = c.client.Do(ctx, "DEL", objectKey)
+		return value, false, err
+	}
+	if ttl := c.ttl(); ttl > 0 && ttl == c.config.LastUseAge {
+		_, err = c.client.Pipeline(ctx,
+			[]string{"PEXPIRE", objectKey, milliseconds(ttl)},
+			[]string{"PEXPIRE", indexKey, milliseconds(ttl)},
+		)
+		c.logger(index).OnError(err).Warn("cache get: refresh last use")
+	}
+	return object.Value, true, nil
+}
+
+func (c *redisCache[I, K, V]) Set(ctx context.Context, value V) {
+	if len(c.indices) == 0 {
+		return
+	}
+	ids := value.Keys(c.indices[0])
+	if len(ids) == 0 {
+		logging.WithFields("cache", c.name).Debug("cache set: object without identity skipped")
+		return
+	}
+	id := fmt.Sprint(ids[0])
+	data, err := json.Marshal(&envelope[V]{
+		Created: c.now(),
+		Value:   value,
+	})
+	if err != nil {
+		logging.WithFields("cache", c.name).WithError(err).Error("cache set: encode object")
+		return
+	}
+
+	ttl := c.ttl()
+	set := []string{"SET", c.objectKey(id), string(data)}
+	if ttl > 0 {
+		set = append(set, "PX", milliseconds(ttl))
+	}
+	cmds := [][]string{set}
+	for _, index := range c.indices {
+		for _, key := range value.Keys(index) {
+			indexKey := c.indexKey(index, key)
+			cmds = append(cmds, []string{"SADD", indexKey, id})
+			if ttl > 0 {
+				cmds = append(cmds, []string{"PEXPIRE", indexKey, milliseconds(ttl)})
+			}
+		}
+	}
+	_, err = c.client.Pipeline(ctx, cmds...)
+	logging.WithFields("cache", c.name).OnError(err).Warn("cache set")
+}
+
+func (c *redisCache[I, K, V]) Invalidate(ctx context.Context, index I, keys ...K) error {
+	if !slices.Contains(c.indices, index) {
+		return cache.NewIndexUnknownErr(index)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	indexKeys := make([]string, len(keys))
+	cmds := make([][]string, len(keys))
+	for i, key := range keys {
+		indexKeys[i] = c.indexKey(index, key)
+		cmds[i] = []string{"SMEMBERS", indexKeys[i]}
+	}
+	replies, err := c.client.Pipeline(ctx, cmds...)
+	if err != nil {
+		return err
+	}
+	del := append([]string{"DEL"}, indexKeys...)
+	for _, reply := range replies {
+		ids, err := stringsReply(reply)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			del = append(del, c.objectKey(id))
+		}
+	}
+	_, err = c.client.Do(ctx, del...)
+	return err
+}
+
+func (c *redisCache[I, K, V]) Delete(ctx context.Context, index I, keys ...K) error {
+	if !slices.Contains(c.indices, index) {
+		return cache.NewIndexUnknownErr(index)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	del := make([]string, 0, len(keys)+1)
+	del = append(del, "DEL")
+	for _, key := range keys {
+		del = append(del, c.indexKey(index, key))
+	}
+	_, err := c.client.Do(ctx, del...)
+	return err
+}
+
+func (c *redisCache[I, K, V]) Truncate(ctx context.Context) error {
+	cursor := "0"
+	for {
+		reply, err := c.client.Do(ctx, "SCAN", cursor, "MATCH", c.prefix()+"*", "COUNT", "1000")
+		if err != nil {
+			return err
+		}
+		values, ok := reply.([]any)
+		if !ok || len(values) != 2 {
+			return errProtocol
+		}
+		next, ok := values[0].([]byte)
+		if !ok {
+			return errProtocol
+		}
+		keys, err := stringsReply(values[1])
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if _, err = c.client.Do(ctx, append([]string{"DEL"}, keys...)...); err != nil {
+				return err
+			}
+		}
+		cursor = string(next)
+		if cursor == "0" {
+			return nil
+		}
+	}
+}
+
+// ttl returns the time after which the server can drop an object and its index keys.
+// If the last use age is the shorter age, the expiry of the object and the used index key
+// is refreshed on each Get and the max age is verified using the creation date of the object.
+func (c *redisCache[I, K, V]) ttl() time.Duration {
+	if c.config.LastUseAge > 0 && (c.config.MaxAge == 0 || c.config.LastUseAge < c.config.MaxAge) {
+		return c.config.LastUseAge
+	}
+	return c.config.MaxAge
+}
+
+func (c *redisCache[I, K, V]) prefix() string {
+	return "zitadel:cache:" + c.name + ":"
+}
+
+func (c *redisCache[I, K, V]) objectKey(id string) string {
+	return c.prefix() + "o:" + id
+}
+
+func (c *redisCache[I, K, V]) indexKey(index I, key K) string {
+	return c.prefix() + "i:" + fmt.Sprint(index) + ":" + fmt.Sprint(key)
+}
+
+func (c *redisCache[I, K, V]) logger(index I) *logging.Entry {
+	return logging.WithFields("cache", c.name, "index", index)
+}
+
+func milliseconds(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
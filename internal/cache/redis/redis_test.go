@@ -0,0 +1,361 @@
+package redis
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+type testIndex int
+
+const (
+	testIndexID testIndex = iota
+	testIndexName
+	testIndexInstance
+)
+
+var testIndices = []testIndex{
+	testIndexID,
+	testIndexName,
+	testIndexInstance,
+}
+
+type testObject struct {
+	ID       string
+	Names    []string
+	Instance string
+}
+
+func (o *testObject) Keys(index testIndex) []string {
+	switch index {
+	case testIndexID:
+		return []string{o.ID}
+	case testIndexName:
+		return o.Names
+	case testIndexInstance:
+		return []string{o.Instance}
+	default:
+		return nil
+	}
+}
+
+func newTestCache(t *testing.T, config *cache.Config) (*redisCache[testIndex, string, *testObject], *testServer) {
+	server := newTestServer(t, "secret")
+	connector := NewConnector(&Config{
+		Addr:        server.addr(),
+		Password:    "secret",
+		DB:          1,
+		DialTimeout: time.Second,
+		ReadTimeout: time.Second,
+	})
+	t.Cleanup(connector.Close)
+	c := NewCache[testIndex, string, *testObject]("test", testIndices, config, connector).(*redisCache[testIndex, string, *testObject])
+	return c, server
+}
+
+func TestConnector_Ping(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{
+			name:     "authenticated",
+			password: "secret",
+		},
+		{
+			name:     "wrong password",
+			password: "wrong",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, "secret")
+			connector := NewConnector(&Config{
+				Addr:     server.addr(),
+				Password: tt.password,
+			})
+			defer connector.Close()
+
+			err := connector.Ping(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_redisCache_Get(t *testing.T) {
+	obj := &testObject{
+		ID:       "id",
+		Names:    []string{"foo", "bar"},
+		Instance: "instance",
+	}
+	type args struct {
+		index testIndex
+		key   string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   *testObject
+		wantOk bool
+	}{
+		{
+			name: "by id",
+			args: args{
+				index: testIndexID,
+				key:   "id",
+			},
+			want:   obj,
+			wantOk: true,
+		},
+		{
+			name: "by second name",
+			args: args{
+				index: testIndexName,
+				key:   "bar",
+			},
+			want:   obj,
+			wantOk: true,
+		},
+		{
+			name: "by shared key",
+			args: args{
+				index: testIndexInstance,
+				key:   "instance",
+			},
+			want:   obj,
+			wantOk: true,
+		},
+		{
+			name: "unknown key",
+			args: args{
+				index: testIndexName,
+				key:   "baz",
+			},
+			want:   nil,
+			wantOk: false,
+		},
+		{
+			name: "unknown index",
+			args: args{
+				index: 99,
+				key:   "id",
+			},
+			want:   nil,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestCache(t, &cache.Config{})
+			c.Set(context.Background(), obj)
+
+			got, ok := c.Get(context.Background(), tt.args.index, tt.args.key)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_redisCache_Get_expired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		config *cache.Config
+		setAt  time.Time
+		usedAt time.Time
+		getAt  time.Time
+		wantOk bool
+	}{
+		{
+			name:   "no ages",
+			config: &cache.Config{},
+			setAt:  now,
+			usedAt: now,
+			getAt:  now.Add(time.Hour),
+			wantOk: true,
+		},
+		{
+			name: "max age not reached",
+			config: &cache.Config{
+				MaxAge: time.Minute,
+			},
+			setAt:  now,
+			usedAt: now,
+			getAt:  now.Add(time.Second),
+			wantOk: true,
+		},
+		{
+			name: "max age exceeded",
+			config: &cache.Config{
+				MaxAge:     time.Minute,
+				LastUseAge: 40 * time.Second,
+			},
+			setAt:  now,
+			usedAt: now.Add(30 * time.Second),
+			getAt:  now.Add(61 * time.Second),
+			wantOk: false,
+		},
+		{
+			name: "last use age refreshed",
+			config: &cache.Config{
+				LastUseAge: time.Minute,
+			},
+			setAt:  now,
+			usedAt: now.Add(50 * time.Second),
+			getAt:  now.Add(100 * time.Second),
+			wantOk: true,
+		},
+		{
+			name: "last use age exceeded",
+			config: &cache.Config{
+				LastUseAge: time.Minute,
+			},
+			setAt:  now,
+			usedAt: now,
+			getAt:  now.Add(61 * time.Second),
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var current time.Time
+			c, server := newTestCache(t, tt.config)
+			c.now = func() time.Time { return current }
+			server.setNow(func() time.Time { return current })
+
+			current = tt.setAt
+			c.Set(context.Background(), &testObject{ID: "id", Instance: "instance"})
+			current = tt.usedAt
+			_, ok := c.Get(context.Background(), testIndexID, "id")
+			require.True(t, ok)
+
+			current = tt.getAt
+			_, ok = c.Get(context.Background(), testIndexID, "id")
+			assert.Equal(t, tt.wantOk, ok)
+			if !tt.wantOk {
+				assert.NotContains(t, server.allKeys(), c.objectKey("id"))
+			}
+		})
+	}
+}
+
+func Test_redisCache_Set_replace(t *testing.T) {
+	c, server := newTestCache(t, &cache.Config{})
+	c.Set(context.Background(), &testObject{ID: "id", Names: []string{"foo", "bar"}})
+	c.Set(context.Background(), &testObject{ID: "id", Names: []string{"foo"}})
+
+	_, ok := c.Get(context.Background(), testIndexName, "bar")
+	assert.False(t, ok, "ghost key")
+	assert.NotContains(t, server.allKeys(), c.indexKey(testIndexName, "bar"), "stale id not removed")
+	got, ok := c.Get(context.Background(), testIndexName, "foo")
+	require.True(t, ok)
+	assert.Equal(t, []string{"foo"}, got.Names)
+}
+
+func Test_redisCache_Invalidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   testIndex
+		keys    []string
+		wantIDs []string
+		wantErr error
+	}{
+		{
+			name:    "by name",
+			index:   testIndexName,
+			keys:    []string{"bar"},
+			wantIDs: []string{"2", "3"},
+		},
+		{
+			name:    "by shared key",
+			index:   testIndexInstance,
+			keys:    []string{"instance1"},
+			wantIDs: []string{"3"},
+		},
+		{
+			name:    "multiple keys",
+			index:   testIndexID,
+			keys:    []string{"1", "3"},
+			wantIDs: []string{"2"},
+		},
+		{
+			name:    "not existing",
+			index:   testIndexID,
+			keys:    []string{"4"},
+			wantIDs: []string{"1", "2", "3"},
+		},
+		{
+			name:    "unknown index",
+			index:   99,
+			keys:    []string{"1"},
+			wantIDs: []string{"1", "2", "3"},
+			wantErr: cache.NewIndexUnknownErr(testIndex(99)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestCache(t, &cache.Config{})
+			c.Set(context.Background(), &testObject{ID: "1", Names: []string{"foo", "bar"}, Instance: "instance1"})
+			c.Set(context.Background(), &testObject{ID: "2", Names: []string{"baz"}, Instance: "instance1"})
+			c.Set(context.Background(), &testObject{ID: "3", Names: []string{"qux"}, Instance: "instance2"})
+
+			err := c.Invalidate(context.Background(), tt.index, tt.keys...)
+			require.ErrorIs(t, err, tt.wantErr)
+			for _, id := range []string{"1", "2", "3"} {
+				_, ok := c.Get(context.Background(), testIndexID, id)
+				assert.Equal(t, slices.Contains(tt.wantIDs, id), ok, id)
+			}
+		})
+	}
+}
+
+func Test_redisCache_Delete(t *testing.T) {
+	c, _ := newTestCache(t, &cache.Config{})
+	c.Set(context.Background(), &testObject{ID: "1", Names: []string{"foo", "bar"}})
+
+	err := c.Delete(context.Background(), testIndexName, "foo")
+	require.NoError(t, err)
+
+	_, ok := c.Get(context.Background(), testIndexName, "foo")
+	assert.False(t, ok)
+	_, ok = c.Get(context.Background(), testIndexName, "bar")
+	assert.True(t, ok)
+	_, ok = c.Get(context.Background(), testIndexID, "1")
+	assert.True(t, ok)
+
+	err = c.Delete(context.Background(), 99, "foo")
+	assert.ErrorIs(t, err, cache.NewIndexUnknownErr(testIndex(99)))
+}
+
+func Test_redisCache_Truncate(t *testing.T) {
+	c, server := newTestCache(t, &cache.Config{})
+	other := NewCache[testIndex, string, *testObject]("other", testIndices, &cache.Config{}, &Connector{client: c.client})
+	c.Set(context.Background(), &testObject{ID: "1"})
+	c.Set(context.Background(), &testObject{ID: "2"})
+	other.Set(context.Background(), &testObject{ID: "1"})
+
+	err := c.Truncate(context.Background())
+	require.NoError(t, err)
+
+	_, ok := c.Get(context.Background(), testIndexID, "1")
+	assert.False(t, ok)
+	_, ok = c.Get(context.Background(), testIndexID, "2")
+	assert.False(t, ok)
+	_, ok = other.Get(context.Background(), testIndexID, "1")
+	assert.True(t, ok, "other cache must not be truncated")
+	for _, key := range server.allKeys() {
+		assert.NotContains(t, key, c.prefix())
+	}
+}
@@ -0,0 +1,269 @@
+package redis
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testServer is a local stand-in for a Redis server.
+// It implements the subset of RESP2 commands used by the cache.
+type testServer struct {
+	listener net.Listener
+	password string
+
+	mu      sync.Mutex
+	now     func() time.Time
+	strings map[string][]byte
+	sets    map[string]map[string]struct{}
+	expiry  map[string]time.Time
+	// commands records the names of all received commands.
+	commands []string
+}
+
+func newTestServer(t *testing.T, password string) *testServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testServer{
+		listener: listener,
+		password: password,
+		now:      time.Now,
+		strings:  make(map[string][]byte),
+		sets:     make(map[string]map[string]struct{}),
+		expiry:   make(map[string]time.Time),
+	}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *testServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	authenticated := s.password == ""
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+		values, ok := reply.([]any)
+		if !ok || len(values) == 0 {
+			return
+		}
+		args := make([]string, len(values))
+		for i, value := range values {
+			args[i] = string(value.([]byte))
+		}
+		cmd := strings.ToUpper(args[0])
+		if cmd == "AUTH" {
+			authenticated = args[len(args)-1] == s.password
+			if !authenticated {
+				writeValue(w, errors.New("WRONGPASS invalid password"))
+			} else {
+				writeValue(w, "OK")
+			}
+		} else if !authenticated {
+			writeValue(w, errors.New("NOAUTH Authentication required."))
+		} else {
+			writeValue(w, s.execute(cmd, args[1:]))
+		}
+		if err = w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func (s *testServer) execute(cmd string, args []string) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands = append(s.commands, cmd)
+	s.expire()
+
+	switch cmd {
+	case "PING":
+		return "PONG"
+	case "SELECT":
+		return "OK"
+	case "GET":
+		data, ok := s.strings[args[0]]
+		if !ok {
+			return nil
+		}
+		return data
+	case "SET":
+		s.del(args[0])
+		s.strings[args[0]] = []byte(args[1])
+		if len(args) == 4 && strings.ToUpper(args[2]) == "PX" {
+			s.setExpiry(args[0], args[3])
+		}
+		return "OK"
+	case "DEL":
+		var count int64
+		for _, key := range args {
+			if s.del(key) {
+				count++
+			}
+		}
+		return count
+	case "SADD":
+		set, ok := s.sets[args[0]]
+		if !ok {
+			set = make(map[string]struct{})
+			s.sets[args[0]] = set
+		}
+		var count int64
+		for _, member := range args[1:] {
+			if _, ok := set[member]; !ok {
+				count++
+			}
+			set[member] = struct{}{}
+		}
+		return count
+	case "SREM":
+		var count int64
+		for _, member := range args[1:] {
+			if _, ok := s.sets[args[0]][member]; ok {
+				count++
+			}
+			delete(s.sets[args[0]], member)
+		}
+		if len(s.sets[args[0]]) == 0 {
+			s.del(args[0])
+		}
+		return count
+	case "SMEMBERS":
+		members := make([]any, 0, len(s.sets[args[0]]))
+		for _, member := range sortedKeys(s.sets[args[0]]) {
+			members = append(members, []byte(member))
+		}
+		return members
+	case "PEXPIRE":
+		if !s.exists(args[0]) {
+			return int64(0)
+		}
+		s.setExpiry(args[0], args[1])
+		return int64(1)
+	case "SCAN":
+		// all keys are returned in a single iteration
+		pattern := args[2]
+		keys := make([]any, 0)
+		for _, key := range s.keys() {
+			if ok, _ := path.Match(pattern, key); ok {
+				keys = append(keys, []byte(key))
+			}
+		}
+		return []any{[]byte("0"), keys}
+	default:
+		return errors.New("ERR unknown command '" + cmd + "'")
+	}
+}
+
+func (s *testServer) setExpiry(key, ms string) {
+	millis, _ := strconv.ParseInt(ms, 10, 64)
+	s.expiry[key] = s.now().Add(time.Duration(millis) * time.Millisecond)
+}
+
+// expire removes all expired keys.
+func (s *testServer) expire() {
+	now := s.now()
+	for key, expiry := range s.expiry {
+		if !now.Before(expiry) {
+			s.del(key)
+		}
+	}
+}
+
+func (s *testServer) exists(key string) bool {
+	_, isString := s.strings[key]
+	_, isSet := s.sets[key]
+	return isString || isSet
+}
+
+func (s *testServer) del(key string) bool {
+	exists := s.exists(key)
+	delete(s.strings, key)
+	delete(s.sets, key)
+	delete(s.expiry, key)
+	return exists
+}
+
+func (s *testServer) keys() []string {
+	keys := make([]string, 0, len(s.strings)+len(s.sets))
+	for key := range s.strings {
+		keys = append(keys, key)
+	}
+	for key := range s.sets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *testServer) setNow(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+func (s *testServer) allKeys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	return s.keys()
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeValue(w *bufio.Writer, value any) {
+	switch v := value.(type) {
+	case nil:
+		w.WriteString("$-1\r\n")
+	case string:
+		w.WriteString("+" + v + "\r\n")
+	case error:
+		w.WriteString("-" + v.Error() + "\r\n")
+	case int64:
+		w.WriteString(":" + strconv.FormatInt(v, 10) + "\r\n")
+	case []byte:
+		w.WriteString("$" + strconv.Itoa(len(v)) + "\r\n")
+		w.Write(v)
+		w.WriteString("\r\n")
+	case []any:
+		w.WriteString("*" + strconv.Itoa(len(v)) + "\r\n")
+		for _, elem := range v {
+			writeValue(w, elem)
+		}
+	}
+}
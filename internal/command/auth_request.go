@@ -27,6 +27,8 @@ type AuthRequest struct {
 	MaxAge        *time.Duration
 	LoginHint     *string
 	HintUserID    *string
+	// AuthorizationDetails are the requested rich authorization details (RFC 9396).
+	AuthorizationDetails domain.AuthorizationDetails
 }
 
 type CurrentAuthRequest struct {
@@ -69,6 +71,7 @@ func (c *Commands) AddAuthRequest(ctx context.Context, authRequest *AuthRequest)
 		authRequest.MaxAge,
 		authRequest.LoginHint,
 		authRequest.HintUserID,
+		authRequest.AuthorizationDetails,
 	))
 	if err != nil {
 		return nil, err
@@ -185,6 +188,8 @@ func authRequestWriteModelToCurrentAuthRequest(writeModel *AuthRequestWriteModel
 			MaxAge:        writeModel.MaxAge,
 			LoginHint:     writeModel.LoginHint,
 			HintUserID:    writeModel.HintUserID,
+
+			AuthorizationDetails: writeModel.AuthorizationDetails,
 		},
 		SessionID:   writeModel.SessionID,
 		UserID:      writeModel.UserID,
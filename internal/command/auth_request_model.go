@@ -15,25 +15,26 @@ type AuthRequestWriteModel struct {
 	eventstore.WriteModel
 	aggregate *eventstore.Aggregate
 
-	LoginClient      string
-	ClientID         string
-	RedirectURI      string
-	State            string
-	Nonce            string
-	Scope            []string
-	Audience         []string
-	ResponseType     domain.OIDCResponseType
-	CodeChallenge    *domain.OIDCCodeChallenge
-	Prompt           []domain.Prompt
-	UILocales        []string
-	MaxAge           *time.Duration
-	LoginHint        *string
-	HintUserID       *string
-	SessionID        string
-	UserID           string
-	AuthTime         time.Time
-	AuthMethods      []domain.UserAuthMethodType
-	AuthRequestState domain.AuthRequestState
+	LoginClient          string
+	ClientID             string
+	RedirectURI          string
+	State                string
+	Nonce                string
+	Scope                []string
+	Audience             []string
+	ResponseType         domain.OIDCResponseType
+	CodeChallenge        *domain.OIDCCodeChallenge
+	Prompt               []domain.Prompt
+	UILocales            []string
+	MaxAge               *time.Duration
+	LoginHint            *string
+	HintUserID           *string
+	AuthorizationDetails domain.AuthorizationDetails
+	SessionID            string
+	UserID               string
+	AuthTime             time.Time
+	AuthMethods          []domain.UserAuthMethodType
+	AuthRequestState     domain.AuthRequestState
 }
 
 func NewAuthRequestWriteModel(ctx context.Context, id string) *AuthRequestWriteModel {
@@ -63,6 +64,7 @@ func (m *AuthRequestWriteModel) Reduce() error {
 			m.MaxAge = e.MaxAge
 			m.LoginHint = e.LoginHint
 			m.HintUserID = e.HintUserID
+			m.AuthorizationDetails = e.AuthorizationDetails
 			m.AuthRequestState = domain.AuthRequestStateAdded
 		case *authrequest.SessionLinkedEvent:
 			m.SessionID = e.SessionID
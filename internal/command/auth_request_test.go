@@ -59,6 +59,7 @@ func TestCommands_AddAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								nil,
 							),
 						),
 					),
@@ -96,6 +97,7 @@ func TestCommands_AddAuthRequest(t *testing.T) {
 							gu.Ptr(time.Duration(0)),
 							gu.Ptr("loginHint"),
 							gu.Ptr("hintUserID"),
+							nil,
 						),
 					),
 				),
@@ -223,6 +225,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -263,6 +266,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								nil,
 							),
 						),
 					),
@@ -301,6 +305,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								nil,
 							),
 						),
 					),
@@ -338,6 +343,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								nil,
 							),
 						),
 					),
@@ -398,6 +404,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								nil,
 							),
 						),
 					),
@@ -447,6 +454,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								nil,
 							),
 						),
 					),
@@ -532,6 +540,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								nil,
 							),
 						),
 					),
@@ -674,6 +683,7 @@ func TestCommands_FailAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								nil,
 							),
 						),
 					),
@@ -771,6 +781,7 @@ func TestCommands_AddAuthRequestCode(t *testing.T) {
 								gu.Ptr(time.Duration(0)),
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
+								nil,
 							),
 						),
 					),
@@ -807,6 +818,7 @@ func TestCommands_AddAuthRequestCode(t *testing.T) {
 								gu.Ptr(time.Duration(0)),
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -898,6 +910,7 @@ func TestCommands_ExchangeAuthCode(t *testing.T) {
 								gu.Ptr(time.Duration(0)),
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
+								nil,
 							),
 						),
 					),
@@ -935,6 +948,7 @@ func TestCommands_ExchangeAuthCode(t *testing.T) {
 								gu.Ptr(time.Duration(0)),
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -58,6 +58,7 @@ type Commands struct {
 	domainVerificationValidator     func(domain, token, verifier string, checkType api_http.CheckType) error
 	sessionTokenCreator             func(sessionID string) (id string, token string, err error)
 	sessionTokenVerifier            func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
+	orgDomainIDPRoutings            orgDomainIDPRoutingsFunc
 	defaultAccessTokenLifetime      time.Duration
 	defaultRefreshTokenLifetime     time.Duration
 	defaultRefreshTokenIdleLifetime time.Duration
@@ -68,12 +69,13 @@ type Commands struct {
 	keyAlgorithm            crypto.EncryptionAlgorithm
 	certificateAlgorithm    crypto.EncryptionAlgorithm
 	certKeySize             int
-	privateKeyLifetime      time.Duration
-	publicKeyLifetime       time.Duration
-	certificateLifetime     time.Duration
+	signingKeyRotation      sd.KeyRotation
+	samlCertificateRotation sd.SAMLCertificateRotation
 	defaultSecretGenerators *SecretGenerators
 
 	samlCertificateAndKeyGenerator func(id string) ([]byte, []byte, error)
+	pushRegistrationChallenge      func() (string, error)
+	pushChallengeNumber            func() (uint32, error)
 }
 
 func StartCommands(
@@ -89,6 +91,7 @@ func StartCommands(
 	httpClient *http.Client,
 	permissionCheck domain.PermissionCheck,
 	sessionTokenVerifier func(ctx context.Context, sessionToken string, sessionID string, tokenID string) (err error),
+	orgDomainIDPRoutings func(ctx context.Context, orgID string) (map[string]*domain.OrgDomainIDPRouting, error),
 	defaultAccessTokenLifetime,
 	defaultRefreshTokenLifetime,
 	defaultRefreshTokenIdleLifetime time.Duration,
@@ -110,9 +113,8 @@ func StartCommands(
 		externalPort:                    externalPort,
 		keySize:                         defaults.KeyConfig.Size,
 		certKeySize:                     defaults.KeyConfig.CertificateSize,
-		privateKeyLifetime:              defaults.KeyConfig.PrivateKeyLifetime,
-		publicKeyLifetime:               defaults.KeyConfig.PublicKeyLifetime,
-		certificateLifetime:             defaults.KeyConfig.CertificateLifetime,
+		signingKeyRotation:              defaults.KeyConfig.SigningKeys(),
+		samlCertificateRotation:         defaults.KeyConfig.SAMLCertificates(),
 		idpConfigEncryption:             idpConfigEncryption,
 		smtpEncryption:                  smtpEncryption,
 		smsEncryption:                   smsEncryption,
@@ -127,11 +129,14 @@ func StartCommands(
 		newCodeWithDefault:              newCryptoCodeWithDefaultConfig,
 		sessionTokenCreator:             sessionTokenCreator(idGenerator, sessionAlg),
 		sessionTokenVerifier:            sessionTokenVerifier,
+		orgDomainIDPRoutings:            orgDomainIDPRoutings,
 		defaultAccessTokenLifetime:      defaultAccessTokenLifetime,
 		defaultRefreshTokenLifetime:     defaultRefreshTokenLifetime,
 		defaultRefreshTokenIdleLifetime: defaultRefreshTokenIdleLifetime,
 		defaultSecretGenerators:         defaultSecretGenerators,
 		samlCertificateAndKeyGenerator:  samlCertificateAndKeyGenerator(defaults.KeyConfig.Size),
+		pushRegistrationChallenge:       newPushRegistrationChallenge,
+		pushChallengeNumber:             newPushChallengeNumber,
 	}
 
 	repo.codeAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
@@ -0,0 +1,197 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceIDPAutoLinking replaces the automatic linking of external users to existing users of a provider of the instance.
+func (c *Commands) SetInstanceIDPAutoLinking(ctx context.Context, id string, linking *domain.IDPAutoLinking) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if err = c.validateIDPAutoLinking(ctx, instanceID, id, linking); err != nil {
+		return nil, err
+	}
+	writeModel := NewInstanceIDPAutoLinkingWriteModel(instanceID, id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return c.pushIDPAutoLinking(ctx, &writeModel.IDPAutoLinkingWriteModel, writeModel, linking,
+		instance.NewIDPAutoLinkingSetEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, id, *linking),
+	)
+}
+
+// SetOrgIDPAutoLinking replaces the automatic linking of external users to existing users of a provider of the organization.
+func (c *Commands) SetOrgIDPAutoLinking(ctx context.Context, resourceOwner, id string, linking *domain.IDPAutoLinking) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ahB7e", "Errors.ResourceOwnerMissing")
+	}
+	if err = c.validateIDPAutoLinking(ctx, resourceOwner, id, linking); err != nil {
+		return nil, err
+	}
+	writeModel := NewOrgIDPAutoLinkingWriteModel(resourceOwner, id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return c.pushIDPAutoLinking(ctx, &writeModel.IDPAutoLinkingWriteModel, writeModel, linking,
+		org.NewIDPAutoLinkingSetEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id, *linking),
+	)
+}
+
+func (c *Commands) validateIDPAutoLinking(ctx context.Context, resourceOwner, id string, linking *domain.IDPAutoLinking) error {
+	if id == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Iek4o", "Errors.IDMissing")
+	}
+	if linking == nil {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Xai3d", "Errors.IDP.AutoLinking.Invalid")
+	}
+	if err := linking.Validate(); err != nil {
+		return err
+	}
+	typeWriteModel := NewIDPTypeWriteModel(id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, typeWriteModel); err != nil {
+		return err
+	}
+	if !typeWriteModel.State.Exists() || typeWriteModel.ResourceOwner != resourceOwner {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Vo0ee", "Errors.IDPConfig.NotExisting")
+	}
+	return nil
+}
+
+func (c *Commands) pushIDPAutoLinking(
+	ctx context.Context,
+	existing *IDPAutoLinkingWriteModel,
+	writeModel eventstore.QueryReducer,
+	linking *domain.IDPAutoLinking,
+	event eventstore.Command,
+) (*domain.ObjectDetails, error) {
+	if existing.Linking == *linking {
+		return writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// AutoLinkUserIDP links the external user to the existing user without the interaction of the user.
+// The caller is responsible to match the user by the asserted (and trusted) username or email address,
+// the command ensures that the provider allows linking and has the automatic linking enabled.
+// Users matched by their email address must have verified it, regardless of the trust level of the provider.
+func (c *Commands) AutoLinkUserIDP(ctx context.Context, userID, resourceOwner string, link *AddLink) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohph3", "Errors.IDMissing")
+	}
+	if link == nil || link.IDPID == "" || link.IDPExternalID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-aeD2i", "Errors.User.ExternalIDP.Invalid")
+	}
+	linking, err := c.idpAutoLinking(ctx, link.IDPID)
+	if err != nil {
+		return nil, err
+	}
+	if !linking.IsEnabled() {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieH4a", "Errors.IDP.AutoLinking.Disabled")
+	}
+	if err = c.checkAutoLinkingUser(ctx, userID, resourceOwner, linking); err != nil {
+		return nil, err
+	}
+	userAgg := user.NewAggregate(userID, resourceOwner)
+	event, err := addLink(ctx, c.eventstore.Filter, userAgg, link)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx,
+		event,
+		user.NewUserIDPLinkAutoLinkedEvent(ctx, &userAgg.Aggregate, link.IDPID, link.IDPExternalID, linking.Option),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// checkAutoLinkingUser ensures the user exists and, if linked by email, has verified the email address.
+func (c *Commands) checkAutoLinkingUser(ctx context.Context, userID, resourceOwner string, linking *domain.IDPAutoLinking) error {
+	if linking.Option != domain.AutoLinkingOptionEmail {
+		return c.checkUserExists(ctx, userID, resourceOwner)
+	}
+	emailWriteModel := NewHumanEmailWriteModel(userID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, emailWriteModel); err != nil {
+		return err
+	}
+	if !isUserStateExists(emailWriteModel.UserState) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Aeth0", "Errors.User.NotFound")
+	}
+	if !emailWriteModel.IsEmailVerified {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ooc1i", "Errors.IDP.AutoLinking.EmailNotVerified")
+	}
+	return nil
+}
+
+func (c *Commands) idpAutoLinking(ctx context.Context, idpID string) (*domain.IDPAutoLinking, error) {
+	idpWriteModel, err := c.linkingAllowedIDP(ctx, idpID)
+	if err != nil {
+		return nil, err
+	}
+	if idpWriteModel.Instance {
+		writeModel := NewInstanceIDPAutoLinkingWriteModel(idpWriteModel.ResourceOwner, idpID)
+		if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return nil, err
+		}
+		return &writeModel.Linking, nil
+	}
+	writeModel := NewOrgIDPAutoLinkingWriteModel(idpWriteModel.ResourceOwner, idpID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return &writeModel.Linking, nil
+}
+
+// linkingAllowedIDP returns the provider, if it allows linking external users to existing users.
+func (c *Commands) linkingAllowedIDP(ctx context.Context, idpID string) (*AllIDPWriteModel, error) {
+	idpWriteModel, err := IDPProviderWriteModel(ctx, c.eventstore.Filter, idpID)
+	if err != nil {
+		return nil, err
+	}
+	if !idpWriteModel.GetProviderOptions().IsLinkingAllowed {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Eeth6", "Errors.ExternalIDP.LinkingNotAllowed")
+	}
+	return idpWriteModel, nil
+}
+
+// IDPAutoLinkedSent records that the user was notified about the automatically linked external user.
+func (c *Commands) IDPAutoLinkedSent(ctx context.Context, orgID, userID, idpConfigID, externalUserID string) (err error) {
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-ooS0u", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohr8u", "Errors.User.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingUser.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewUserIDPLinkAutoLinkedSentEvent(ctx, userAgg, idpConfigID, externalUserID))
+	return err
+}
@@ -0,0 +1,110 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type IDPAutoLinkingWriteModel struct {
+	eventstore.WriteModel
+
+	ID      string
+	Linking domain.IDPAutoLinking
+}
+
+func (wm *IDPAutoLinkingWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.AutoLinkingSetEvent:
+			wm.Linking = e.IDPAutoLinking
+		case *idp.RemovedEvent:
+			wm.Linking = domain.IDPAutoLinking{}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+type InstanceIDPAutoLinkingWriteModel struct {
+	IDPAutoLinkingWriteModel
+}
+
+func NewInstanceIDPAutoLinkingWriteModel(instanceID, id string) *InstanceIDPAutoLinkingWriteModel {
+	return &InstanceIDPAutoLinkingWriteModel{
+		IDPAutoLinkingWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceIDPAutoLinkingWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.IDPAutoLinkingSetEvent:
+			wm.IDPAutoLinkingWriteModel.AppendEvents(&e.AutoLinkingSetEvent)
+		case *instance.IDPRemovedEvent:
+			wm.IDPAutoLinkingWriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *InstanceIDPAutoLinkingWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.IDPAutoLinkingSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+type OrgIDPAutoLinkingWriteModel struct {
+	IDPAutoLinkingWriteModel
+}
+
+func NewOrgIDPAutoLinkingWriteModel(orgID, id string) *OrgIDPAutoLinkingWriteModel {
+	return &OrgIDPAutoLinkingWriteModel{
+		IDPAutoLinkingWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgIDPAutoLinkingWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.IDPAutoLinkingSetEvent:
+			wm.IDPAutoLinkingWriteModel.AppendEvents(&e.AutoLinkingSetEvent)
+		case *org.IDPRemovedEvent:
+			wm.IDPAutoLinkingWriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgIDPAutoLinkingWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.IDPAutoLinkingSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
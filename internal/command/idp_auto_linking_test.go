@@ -0,0 +1,505 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetInstanceIDPAutoLinking(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		id      string
+		linking *domain.IDPAutoLinking
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	linking := &domain.IDPAutoLinking{
+		Option:     domain.AutoLinkingOptionEmail,
+		TrustLevel: domain.IDPTrustLevelVerifiedEmail,
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				linking: linking,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Iek4o", ""))
+				},
+			},
+		},
+		{
+			name: "username without full trust",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				linking: &domain.IDPAutoLinking{
+					Option:     domain.AutoLinkingOptionUsername,
+					TrustLevel: domain.IDPTrustLevelVerifiedEmail,
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "DOMAIN-oot6E", ""))
+				},
+			},
+		},
+		{
+			name: "idp of organization, not found",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(orgGitHubIDPAddedEvent()),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				linking: linking,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Vo0ee", ""))
+				},
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPAutoLinkingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								*linking,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				linking: linking,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent()),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewIDPAutoLinkingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							*linking,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				linking: linking,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetInstanceIDPAutoLinking(tt.args.ctx, tt.args.id, tt.args.linking)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetOrgIDPAutoLinking(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		linking       *domain.IDPAutoLinking
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resourceowner",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     context.Background(),
+				id:      "id1",
+				linking: &domain.IDPAutoLinking{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-ahB7e", ""))
+				},
+			},
+		},
+		{
+			name: "disable ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(orgGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPAutoLinkingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								domain.IDPAutoLinking{
+									Option:     domain.AutoLinkingOptionUsername,
+									TrustLevel: domain.IDPTrustLevelFull,
+								},
+							),
+						),
+					),
+					expectPush(
+						org.NewIDPAutoLinkingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							domain.IDPAutoLinking{},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				linking:       &domain.IDPAutoLinking{},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetOrgIDPAutoLinking(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.linking)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_AutoLinkUserIDP(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		link          *AddLink
+	}
+	type res struct {
+		err func(error) bool
+	}
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	link := &AddLink{
+		IDPID:         "id1",
+		DisplayName:   "user@example.com",
+		IDPExternalID: "externalUser1",
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing user id",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           ctx,
+				resourceOwner: "org1",
+				link:          link,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohph3", ""))
+				},
+			},
+		},
+		{
+			name: "linking not allowed",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", instanceGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", instanceGitHubIDPAddedEvent()),
+					),
+				),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				resourceOwner: "org1",
+				link:          link,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Eeth6", ""))
+				},
+			},
+		},
+		{
+			name: "auto linking disabled",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", instanceLinkingGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", instanceLinkingGitHubIDPAddedEvent()),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				resourceOwner: "org1",
+				link:          link,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieH4a", ""))
+				},
+			},
+		},
+		{
+			name: "email not verified",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", instanceLinkingGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", instanceLinkingGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1",
+							instance.NewIDPAutoLinkingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								domain.IDPAutoLinking{
+									Option:     domain.AutoLinkingOptionEmail,
+									TrustLevel: domain.IDPTrustLevelFull,
+								},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(
+								context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"user@example.com",
+								true,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				resourceOwner: "org1",
+				link:          link,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ooc1i", ""))
+				},
+			},
+		},
+		{
+			name: "link ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", instanceLinkingGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", instanceLinkingGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1",
+							instance.NewIDPAutoLinkingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								domain.IDPAutoLinking{
+									Option:     domain.AutoLinkingOptionEmail,
+									TrustLevel: domain.IDPTrustLevelVerifiedEmail,
+								},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(
+								context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"user@example.com",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", instanceLinkingGitHubIDPAddedEvent()),
+					),
+					expectPush(
+						user.NewUserIDPLinkAddedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"id1",
+							"user@example.com",
+							"externalUser1",
+						),
+						user.NewUserIDPLinkAutoLinkedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"id1",
+							"externalUser1",
+							domain.AutoLinkingOptionEmail,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				resourceOwner: "org1",
+				link:          link,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			_, err := c.AutoLinkUserIDP(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.link)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func instanceLinkingGitHubIDPAddedEvent() *instance.GitHubIDPAddedEvent {
+	return instance.NewGitHubIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		"id1",
+		"name",
+		"clientID",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("clientSecret"),
+		},
+		nil,
+		idp.Options{IsLinkingAllowed: true},
+	)
+}
@@ -18,7 +18,10 @@ import (
 	"github.com/zitadel/zitadel/internal/idp"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/bitbucket"
+	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
@@ -85,7 +88,11 @@ func (c *Commands) GetProvider(ctx context.Context, idpID string, idpCallback st
 		return nil, err
 	}
 	if writeModel.IDPType != domain.IDPTypeSAML {
-		return writeModel.ToProvider(idpCallback, c.idpConfigEncryption)
+		provider, err := writeModel.ToProvider(idpCallback, c.idpConfigEncryption)
+		if err != nil {
+			return nil, err
+		}
+		return c.requestRoleMappingAttribute(ctx, idpID, provider)
 	}
 	return writeModel.ToSAMLProvider(
 		samlRootURL,
@@ -111,6 +118,23 @@ func (c *Commands) GetProvider(ctx context.Context, idpID string, idpCallback st
 	)
 }
 
+// requestRoleMappingAttribute lets LDAP providers additionally request the attribute of the role mapping of the provider,
+// because only the configured attributes of the user are returned
+func (c *Commands) requestRoleMappingAttribute(ctx context.Context, idpID string, provider idp.Provider) (idp.Provider, error) {
+	ldapProvider, ok := provider.(*ldap.Provider)
+	if !ok {
+		return provider, nil
+	}
+	mapping, err := c.idpRoleMapping(ctx, idpID)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.IsEnabled() {
+		ldap.WithAdditionalAttributes(mapping.Claim)(ldapProvider)
+	}
+	return ldapProvider, nil
+}
+
 func (c *Commands) GetActiveIntent(ctx context.Context, intentID string) (*IDPIntentWriteModel, error) {
 	intent, err := c.GetIntentWriteModel(ctx, intentID, "")
 	if err != nil {
@@ -286,6 +310,10 @@ func tokensForSucceededIDPIntent(session idp.Session, encryptionAlg crypto.Encry
 		tokens = s.Tokens
 	case *azuread.Session:
 		tokens = s.Tokens()
+	case *github.Session:
+		tokens = s.Tokens()
+	case *bitbucket.Session:
+		tokens = s.Tokens()
 	case *apple.Session:
 		tokens = s.Tokens
 	default:
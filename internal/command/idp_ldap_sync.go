@@ -0,0 +1,122 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceLDAPSync replaces the directory synchronization settings of an LDAP provider of the instance.
+func (c *Commands) SetInstanceLDAPSync(ctx context.Context, id string, sync *domain.LDAPSync) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if err = c.validateLDAPSync(ctx, id, sync); err != nil {
+		return nil, err
+	}
+	if sync.OrgID != "" {
+		if err = c.checkOrgExists(ctx, sync.OrgID); err != nil {
+			return nil, err
+		}
+	}
+	writeModel := NewInstanceLDAPSyncWriteModel(instanceID, id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return c.pushLDAPSync(ctx, &writeModel.LDAPSyncWriteModel, writeModel, sync,
+		instance.NewLDAPSyncSetEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, id, *sync),
+	)
+}
+
+// SetOrgLDAPSync replaces the directory synchronization settings of an LDAP provider of the organization.
+// Users are always created in the organization of the provider.
+func (c *Commands) SetOrgLDAPSync(ctx context.Context, resourceOwner, id string, sync *domain.LDAPSync) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Oox8e", "Errors.ResourceOwnerMissing")
+	}
+	if err = c.validateLDAPSync(ctx, id, sync); err != nil {
+		return nil, err
+	}
+	sync.OrgID = ""
+	writeModel := NewOrgLDAPSyncWriteModel(resourceOwner, id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return c.pushLDAPSync(ctx, &writeModel.LDAPSyncWriteModel, writeModel, sync,
+		org.NewLDAPSyncSetEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id, *sync),
+	)
+}
+
+func (c *Commands) validateLDAPSync(ctx context.Context, id string, sync *domain.LDAPSync) error {
+	if id == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Ui7ee", "Errors.IDMissing")
+	}
+	if sync == nil {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Chee8", "Errors.IDP.LDAPSync.Invalid")
+	}
+	if err := sync.Validate(); err != nil {
+		return err
+	}
+	for _, mapping := range sync.GroupMappings {
+		if err := c.checkProjectExists(ctx, mapping.ProjectID, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Commands) pushLDAPSync(
+	ctx context.Context,
+	existing *LDAPSyncWriteModel,
+	writeModel eventstore.QueryReducer,
+	sync *domain.LDAPSync,
+	event eventstore.Command,
+) (*domain.ObjectDetails, error) {
+	if !existing.exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-eiK3a", "Errors.IDPConfig.NotExisting")
+	}
+	if reflect.DeepEqual(existing.Sync, *sync) {
+		return writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// AddLDAPSyncReport records the result of a run of the directory synchronization of an LDAP provider.
+// The resourceOwner is the instance for instance providers and the organization otherwise.
+func (c *Commands) AddLDAPSyncReport(ctx context.Context, resourceOwner, id string, report *domain.LDAPSyncReport) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if id == "" || resourceOwner == "" || report == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Thi0o", "Errors.IDMissing")
+	}
+	var event eventstore.Command
+	if resourceOwner == authz.GetInstance(ctx).InstanceID() {
+		event = instance.NewLDAPSyncFinishedEvent(ctx, &instance.NewAggregate(resourceOwner).Aggregate, id, *report)
+	} else {
+		event = org.NewLDAPSyncFinishedEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id, *report)
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
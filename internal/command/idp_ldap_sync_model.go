@@ -0,0 +1,124 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type LDAPSyncWriteModel struct {
+	eventstore.WriteModel
+
+	ID    string
+	State domain.IDPState
+	Sync  domain.LDAPSync
+}
+
+func (wm *LDAPSyncWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.LDAPIDPAddedEvent:
+			wm.State = domain.IDPStateActive
+		case *idp.LDAPSyncSetEvent:
+			wm.Sync = e.LDAPSync
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+			wm.Sync = domain.LDAPSync{}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *LDAPSyncWriteModel) exists() bool {
+	return wm.State.Exists()
+}
+
+type InstanceLDAPSyncWriteModel struct {
+	LDAPSyncWriteModel
+}
+
+func NewInstanceLDAPSyncWriteModel(instanceID, id string) *InstanceLDAPSyncWriteModel {
+	return &InstanceLDAPSyncWriteModel{
+		LDAPSyncWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceLDAPSyncWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.LDAPIDPAddedEvent:
+			wm.LDAPSyncWriteModel.AppendEvents(&e.LDAPIDPAddedEvent)
+		case *instance.LDAPSyncSetEvent:
+			wm.LDAPSyncWriteModel.AppendEvents(&e.LDAPSyncSetEvent)
+		case *instance.IDPRemovedEvent:
+			wm.LDAPSyncWriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *InstanceLDAPSyncWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.LDAPIDPAddedEventType,
+			instance.LDAPSyncSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+type OrgLDAPSyncWriteModel struct {
+	LDAPSyncWriteModel
+}
+
+func NewOrgLDAPSyncWriteModel(orgID, id string) *OrgLDAPSyncWriteModel {
+	return &OrgLDAPSyncWriteModel{
+		LDAPSyncWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgLDAPSyncWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.LDAPIDPAddedEvent:
+			wm.LDAPSyncWriteModel.AppendEvents(&e.LDAPIDPAddedEvent)
+		case *org.LDAPSyncSetEvent:
+			wm.LDAPSyncWriteModel.AppendEvents(&e.LDAPSyncSetEvent)
+		case *org.IDPRemovedEvent:
+			wm.LDAPSyncWriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgLDAPSyncWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.LDAPIDPAddedEventType,
+			org.LDAPSyncSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
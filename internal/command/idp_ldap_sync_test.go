@@ -0,0 +1,451 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func instanceLDAPIDPAddedEvent() *instance.LDAPIDPAddedEvent {
+	return instance.NewLDAPIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		"id1",
+		"name",
+		[]string{"server"},
+		false,
+		"basedn",
+		"binddn",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("password"),
+		},
+		"user",
+		[]string{"object"},
+		[]string{"filter"},
+		time.Second*30,
+		idp.LDAPAttributes{},
+		idp.Options{},
+	)
+}
+
+func TestCommandSide_SetInstanceLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx  context.Context
+		id   string
+		sync *domain.LDAPSync
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	sync := &domain.LDAPSync{
+		Enabled:           true,
+		Interval:          time.Hour,
+		Filter:            "(department=IT)",
+		DeactivateMissing: true,
+		GroupMappings: []*domain.LDAPGroupMapping{
+			{GroupDN: "cn=admins,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"admin"}},
+		},
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:  authz.WithInstanceID(context.Background(), "instance1"),
+				sync: sync,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ui7ee", ""))
+				},
+			},
+		},
+		{
+			name: "invalid interval",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				sync: &domain.LDAPSync{
+					Enabled:  true,
+					Interval: time.Second,
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "DOMAIN-ieV3a", ""))
+				},
+			},
+		},
+		{
+			name: "project not existing",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:  authz.WithInstanceID(context.Background(), "instance1"),
+				id:   "id1",
+				sync: sync,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "COMMAND-EbFMN", ""))
+				},
+			},
+		},
+		{
+			name: "idp not existing",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:  authz.WithInstanceID(context.Background(), "instance1"),
+				id:   "id1",
+				sync: sync,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-eiK3a", ""))
+				},
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(instanceLDAPIDPAddedEvent()),
+						eventFromEventPusher(
+							instance.NewLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								*sync,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:  authz.WithInstanceID(context.Background(), "instance1"),
+				id:   "id1",
+				sync: sync,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(instanceLDAPIDPAddedEvent()),
+					),
+					expectPush(
+						instance.NewLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							*sync,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:  authz.WithInstanceID(context.Background(), "instance1"),
+				id:   "id1",
+				sync: sync,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetInstanceLDAPSync(tt.args.ctx, tt.args.id, tt.args.sync)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetOrgLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		sync          *domain.LDAPSync
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resourceowner",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:  context.Background(),
+				id:   "id1",
+				sync: &domain.LDAPSync{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Oox8e", ""))
+				},
+			},
+		},
+		{
+			name: "set ok, org ignored",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLDAPIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								[]string{"server"},
+								false,
+								"basedn",
+								"binddn",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("password"),
+								},
+								"user",
+								[]string{"object"},
+								[]string{"filter"},
+								time.Second*30,
+								idp.LDAPAttributes{},
+								idp.Options{},
+							),
+						),
+					),
+					expectPush(
+						org.NewLDAPSyncSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							domain.LDAPSync{
+								Enabled:  true,
+								Interval: time.Hour,
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				sync: &domain.LDAPSync{
+					Enabled:  true,
+					Interval: time.Hour,
+					OrgID:    "org2",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetOrgLDAPSync(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.sync)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_AddLDAPSyncReport(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		report        *domain.LDAPSyncReport
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	report := &domain.LDAPSyncReport{
+		StartedAt:   time.Unix(1700000000, 0),
+		UsersFound:  3,
+		Created:     1,
+		Deactivated: 1,
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				report:        report,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Thi0o", ""))
+				},
+			},
+		},
+		{
+			name: "instance provider",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						instance.NewLDAPSyncFinishedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							*report,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+				report:        report,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "org provider",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						org.NewLDAPSyncFinishedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							*report,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				id:            "id1",
+				report:        report,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.AddLDAPSyncReport(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.report)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
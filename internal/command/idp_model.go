@@ -16,15 +16,19 @@ import (
 	providers "github.com/zitadel/zitadel/internal/idp"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/bitbucket"
+	"github.com/zitadel/zitadel/internal/idp/providers/discord"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
 	"github.com/zitadel/zitadel/internal/idp/providers/google"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/idp/providers/linkedin"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
 	saml2 "github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml/requesttracker"
+	"github.com/zitadel/zitadel/internal/idp/providers/slack"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/repository/idpconfig"
 	"github.com/zitadel/zitadel/internal/repository/instance"
@@ -833,6 +837,478 @@ func (wm *GitHubIDPWriteModel) GetProviderOptions() idp.Options {
 	return wm.Options
 }
 
+type BitbucketIDPWriteModel struct {
+	eventstore.WriteModel
+
+	ID           string
+	Name         string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       []string
+	idp.Options
+
+	State domain.IDPState
+}
+
+func (wm *BitbucketIDPWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.BitbucketIDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.BitbucketIDPChangedEvent:
+			wm.reduceChangedEvent(e)
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *BitbucketIDPWriteModel) reduceAddedEvent(e *idp.BitbucketIDPAddedEvent) {
+	wm.Name = e.Name
+	wm.ClientID = e.ClientID
+	wm.ClientSecret = e.ClientSecret
+	wm.Scopes = e.Scopes
+	wm.Options = e.Options
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *BitbucketIDPWriteModel) reduceChangedEvent(e *idp.BitbucketIDPChangedEvent) {
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.ClientID != nil {
+		wm.ClientID = *e.ClientID
+	}
+	if e.ClientSecret != nil {
+		wm.ClientSecret = e.ClientSecret
+	}
+	if e.Scopes != nil {
+		wm.Scopes = e.Scopes
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
+}
+
+func (wm *BitbucketIDPWriteModel) NewChanges(
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) ([]idp.BitbucketIDPChanges, error) {
+	changes := make([]idp.BitbucketIDPChanges, 0)
+	var clientSecret *crypto.CryptoValue
+	var err error
+	if clientSecretString != "" {
+		clientSecret, err = crypto.Crypt([]byte(clientSecretString), secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, idp.ChangeBitbucketClientSecret(clientSecret))
+	}
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeBitbucketName(name))
+	}
+	if wm.ClientID != clientID {
+		changes = append(changes, idp.ChangeBitbucketClientID(clientID))
+	}
+	if !reflect.DeepEqual(wm.Scopes, scopes) {
+		changes = append(changes, idp.ChangeBitbucketScopes(scopes))
+	}
+
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeBitbucketOptions(opts))
+	}
+	return changes, nil
+}
+
+func (wm *BitbucketIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	secret, err := crypto.DecryptString(wm.ClientSecret, idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	oauthOpts := make([]oauth.ProviderOpts, 0, 4)
+	if wm.IsCreationAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithCreationAllowed())
+	}
+	if wm.IsLinkingAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithLinkingAllowed())
+	}
+	if wm.IsAutoCreation {
+		oauthOpts = append(oauthOpts, oauth.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		oauthOpts = append(oauthOpts, oauth.WithAutoUpdate())
+	}
+	return bitbucket.New(
+		wm.ClientID,
+		secret,
+		callbackURL,
+		wm.Scopes,
+		oauthOpts...,
+	)
+}
+
+func (wm *BitbucketIDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
+type SlackIDPWriteModel struct {
+	eventstore.WriteModel
+
+	ID           string
+	Name         string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       []string
+	idp.Options
+
+	State domain.IDPState
+}
+
+func (wm *SlackIDPWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.SlackIDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.SlackIDPChangedEvent:
+			wm.reduceChangedEvent(e)
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SlackIDPWriteModel) reduceAddedEvent(e *idp.SlackIDPAddedEvent) {
+	wm.Name = e.Name
+	wm.ClientID = e.ClientID
+	wm.ClientSecret = e.ClientSecret
+	wm.Scopes = e.Scopes
+	wm.Options = e.Options
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *SlackIDPWriteModel) reduceChangedEvent(e *idp.SlackIDPChangedEvent) {
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.ClientID != nil {
+		wm.ClientID = *e.ClientID
+	}
+	if e.ClientSecret != nil {
+		wm.ClientSecret = e.ClientSecret
+	}
+	if e.Scopes != nil {
+		wm.Scopes = e.Scopes
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
+}
+
+func (wm *SlackIDPWriteModel) NewChanges(
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) ([]idp.SlackIDPChanges, error) {
+	changes := make([]idp.SlackIDPChanges, 0)
+	var clientSecret *crypto.CryptoValue
+	var err error
+	if clientSecretString != "" {
+		clientSecret, err = crypto.Crypt([]byte(clientSecretString), secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, idp.ChangeSlackClientSecret(clientSecret))
+	}
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeSlackName(name))
+	}
+	if wm.ClientID != clientID {
+		changes = append(changes, idp.ChangeSlackClientID(clientID))
+	}
+	if !reflect.DeepEqual(wm.Scopes, scopes) {
+		changes = append(changes, idp.ChangeSlackScopes(scopes))
+	}
+
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeSlackOptions(opts))
+	}
+	return changes, nil
+}
+
+func (wm *SlackIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	secret, err := crypto.DecryptString(wm.ClientSecret, idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	oauthOpts := make([]oauth.ProviderOpts, 0, 4)
+	if wm.IsCreationAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithCreationAllowed())
+	}
+	if wm.IsLinkingAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithLinkingAllowed())
+	}
+	if wm.IsAutoCreation {
+		oauthOpts = append(oauthOpts, oauth.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		oauthOpts = append(oauthOpts, oauth.WithAutoUpdate())
+	}
+	return slack.New(
+		wm.ClientID,
+		secret,
+		callbackURL,
+		wm.Scopes,
+		oauthOpts...,
+	)
+}
+
+func (wm *SlackIDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
+type DiscordIDPWriteModel struct {
+	eventstore.WriteModel
+
+	ID           string
+	Name         string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       []string
+	idp.Options
+
+	State domain.IDPState
+}
+
+func (wm *DiscordIDPWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.DiscordIDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.DiscordIDPChangedEvent:
+			wm.reduceChangedEvent(e)
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *DiscordIDPWriteModel) reduceAddedEvent(e *idp.DiscordIDPAddedEvent) {
+	wm.Name = e.Name
+	wm.ClientID = e.ClientID
+	wm.ClientSecret = e.ClientSecret
+	wm.Scopes = e.Scopes
+	wm.Options = e.Options
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *DiscordIDPWriteModel) reduceChangedEvent(e *idp.DiscordIDPChangedEvent) {
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.ClientID != nil {
+		wm.ClientID = *e.ClientID
+	}
+	if e.ClientSecret != nil {
+		wm.ClientSecret = e.ClientSecret
+	}
+	if e.Scopes != nil {
+		wm.Scopes = e.Scopes
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
+}
+
+func (wm *DiscordIDPWriteModel) NewChanges(
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) ([]idp.DiscordIDPChanges, error) {
+	changes := make([]idp.DiscordIDPChanges, 0)
+	var clientSecret *crypto.CryptoValue
+	var err error
+	if clientSecretString != "" {
+		clientSecret, err = crypto.Crypt([]byte(clientSecretString), secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, idp.ChangeDiscordClientSecret(clientSecret))
+	}
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeDiscordName(name))
+	}
+	if wm.ClientID != clientID {
+		changes = append(changes, idp.ChangeDiscordClientID(clientID))
+	}
+	if !reflect.DeepEqual(wm.Scopes, scopes) {
+		changes = append(changes, idp.ChangeDiscordScopes(scopes))
+	}
+
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeDiscordOptions(opts))
+	}
+	return changes, nil
+}
+
+func (wm *DiscordIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	secret, err := crypto.DecryptString(wm.ClientSecret, idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	oauthOpts := make([]oauth.ProviderOpts, 0, 4)
+	if wm.IsCreationAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithCreationAllowed())
+	}
+	if wm.IsLinkingAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithLinkingAllowed())
+	}
+	if wm.IsAutoCreation {
+		oauthOpts = append(oauthOpts, oauth.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		oauthOpts = append(oauthOpts, oauth.WithAutoUpdate())
+	}
+	return discord.New(
+		wm.ClientID,
+		secret,
+		callbackURL,
+		wm.Scopes,
+		oauthOpts...,
+	)
+}
+
+func (wm *DiscordIDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
+type LinkedInIDPWriteModel struct {
+	eventstore.WriteModel
+
+	ID           string
+	Name         string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       []string
+	idp.Options
+
+	State domain.IDPState
+}
+
+func (wm *LinkedInIDPWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.LinkedInIDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.LinkedInIDPChangedEvent:
+			wm.reduceChangedEvent(e)
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *LinkedInIDPWriteModel) reduceAddedEvent(e *idp.LinkedInIDPAddedEvent) {
+	wm.Name = e.Name
+	wm.ClientID = e.ClientID
+	wm.ClientSecret = e.ClientSecret
+	wm.Scopes = e.Scopes
+	wm.Options = e.Options
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *LinkedInIDPWriteModel) reduceChangedEvent(e *idp.LinkedInIDPChangedEvent) {
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.ClientID != nil {
+		wm.ClientID = *e.ClientID
+	}
+	if e.ClientSecret != nil {
+		wm.ClientSecret = e.ClientSecret
+	}
+	if e.Scopes != nil {
+		wm.Scopes = e.Scopes
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
+}
+
+func (wm *LinkedInIDPWriteModel) NewChanges(
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) ([]idp.LinkedInIDPChanges, error) {
+	changes := make([]idp.LinkedInIDPChanges, 0)
+	var clientSecret *crypto.CryptoValue
+	var err error
+	if clientSecretString != "" {
+		clientSecret, err = crypto.Crypt([]byte(clientSecretString), secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, idp.ChangeLinkedInClientSecret(clientSecret))
+	}
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeLinkedInName(name))
+	}
+	if wm.ClientID != clientID {
+		changes = append(changes, idp.ChangeLinkedInClientID(clientID))
+	}
+	if !reflect.DeepEqual(wm.Scopes, scopes) {
+		changes = append(changes, idp.ChangeLinkedInScopes(scopes))
+	}
+
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeLinkedInOptions(opts))
+	}
+	return changes, nil
+}
+
+func (wm *LinkedInIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	secret, err := crypto.DecryptString(wm.ClientSecret, idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	oauthOpts := make([]oauth.ProviderOpts, 0, 4)
+	if wm.IsCreationAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithCreationAllowed())
+	}
+	if wm.IsLinkingAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithLinkingAllowed())
+	}
+	if wm.IsAutoCreation {
+		oauthOpts = append(oauthOpts, oauth.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		oauthOpts = append(oauthOpts, oauth.WithAutoUpdate())
+	}
+	return linkedin.New(
+		wm.ClientID,
+		secret,
+		callbackURL,
+		wm.Scopes,
+		oauthOpts...,
+	)
+}
+
+func (wm *LinkedInIDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
 type GitHubEnterpriseIDPWriteModel struct {
 	eventstore.WriteModel
 
@@ -1890,6 +2366,14 @@ func (wm *IDPRemoveWriteModel) Reduce() error {
 			wm.reduceAdded(e.ID)
 		case *idp.GitHubIDPAddedEvent:
 			wm.reduceAdded(e.ID)
+		case *idp.BitbucketIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.SlackIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.DiscordIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.LinkedInIDPAddedEvent:
+			wm.reduceAdded(e.ID)
 		case *idp.GitHubEnterpriseIDPAddedEvent:
 			wm.reduceAdded(e.ID)
 		case *idp.GitLabIDPAddedEvent:
@@ -1964,8 +2448,24 @@ func (wm *IDPTypeWriteModel) Reduce() error {
 			wm.reduceAdded(e.ID, domain.IDPTypeAzureAD, e.Aggregate())
 		case *instance.GitHubIDPAddedEvent:
 			wm.reduceAdded(e.ID, domain.IDPTypeGitHub, e.Aggregate())
+		case *instance.BitbucketIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeBitbucket, e.Aggregate())
+		case *instance.SlackIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeSlack, e.Aggregate())
+		case *instance.DiscordIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeDiscord, e.Aggregate())
+		case *instance.LinkedInIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeLinkedIn, e.Aggregate())
 		case *org.GitHubIDPAddedEvent:
 			wm.reduceAdded(e.ID, domain.IDPTypeGitHub, e.Aggregate())
+		case *org.BitbucketIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeBitbucket, e.Aggregate())
+		case *org.SlackIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeSlack, e.Aggregate())
+		case *org.DiscordIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeDiscord, e.Aggregate())
+		case *org.LinkedInIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeLinkedIn, e.Aggregate())
 		case *instance.GitHubEnterpriseIDPAddedEvent:
 			wm.reduceAdded(e.ID, domain.IDPTypeGitHubEnterprise, e.Aggregate())
 		case *org.GitHubEnterpriseIDPAddedEvent:
@@ -2064,6 +2564,10 @@ func (wm *IDPTypeWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.JWTIDPAddedEventType,
 			instance.AzureADIDPAddedEventType,
 			instance.GitHubIDPAddedEventType,
+			instance.BitbucketIDPAddedEventType,
+			instance.SlackIDPAddedEventType,
+			instance.DiscordIDPAddedEventType,
+			instance.LinkedInIDPAddedEventType,
 			instance.GitHubEnterpriseIDPAddedEventType,
 			instance.GitLabIDPAddedEventType,
 			instance.GitLabSelfHostedIDPAddedEventType,
@@ -2084,6 +2588,10 @@ func (wm *IDPTypeWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.JWTIDPAddedEventType,
 			org.AzureADIDPAddedEventType,
 			org.GitHubIDPAddedEventType,
+			org.BitbucketIDPAddedEventType,
+			org.SlackIDPAddedEventType,
+			org.DiscordIDPAddedEventType,
+			org.LinkedInIDPAddedEventType,
 			org.GitHubEnterpriseIDPAddedEventType,
 			org.GitLabIDPAddedEventType,
 			org.GitLabSelfHostedIDPAddedEventType,
@@ -2157,6 +2665,14 @@ func NewAllIDPWriteModel(resourceOwner string, instanceBool bool, id string, idp
 			writeModel.model = NewAzureADInstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeGitHub:
 			writeModel.model = NewGitHubInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeBitbucket:
+			writeModel.model = NewBitbucketInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeSlack:
+			writeModel.model = NewSlackInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeDiscord:
+			writeModel.model = NewDiscordInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeLinkedIn:
+			writeModel.model = NewLinkedInInstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeGitHubEnterprise:
 			writeModel.model = NewGitHubEnterpriseInstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeGitLab:
@@ -2188,6 +2704,14 @@ func NewAllIDPWriteModel(resourceOwner string, instanceBool bool, id string, idp
 			writeModel.model = NewAzureADOrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeGitHub:
 			writeModel.model = NewGitHubOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeBitbucket:
+			writeModel.model = NewBitbucketOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeSlack:
+			writeModel.model = NewSlackOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeDiscord:
+			writeModel.model = NewDiscordOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeLinkedIn:
+			writeModel.model = NewLinkedInOrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeGitHubEnterprise:
 			writeModel.model = NewGitHubEnterpriseOrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeGitLab:
@@ -0,0 +1,135 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	oktaDefaultName     = "Okta"
+	keycloakDefaultName = "Keycloak"
+)
+
+// OktaProvider is a preset of the [GenericOIDCProvider] for an Okta organization.
+// If no AuthorizationServerID is provided, the Okta org authorization server is used.
+type OktaProvider struct {
+	Name                  string
+	Domain                string
+	AuthorizationServerID string
+	ClientID              string
+	ClientSecret          string
+	Scopes                []string
+	IDPOptions            idp.Options
+}
+
+func (p OktaProvider) genericOIDCProvider() (GenericOIDCProvider, error) {
+	oktaDomain := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(p.Domain), "https://"), "/")
+	if oktaDomain == "" || strings.ContainsAny(oktaDomain, "/?#") {
+		return GenericOIDCProvider{}, zerrors.ThrowInvalidArgument(nil, "COMMA-Hoh4e", "Errors.Invalid.Argument")
+	}
+	issuer := "https://" + oktaDomain
+	if authServer := strings.TrimSpace(p.AuthorizationServerID); authServer != "" {
+		issuer += "/oauth2/" + authServer
+	}
+	return GenericOIDCProvider{
+		Name:             defaultPresetName(p.Name, oktaDefaultName),
+		Issuer:           issuer,
+		ClientID:         p.ClientID,
+		ClientSecret:     p.ClientSecret,
+		Scopes:           defaultPresetScopes(p.Scopes),
+		IsIDTokenMapping: false,
+		IDPOptions:       p.IDPOptions,
+	}, nil
+}
+
+// KeycloakProvider is a preset of the [GenericOIDCProvider] for a realm of a Keycloak server.
+// The BaseURL must use https, since the issuer is used to retrieve the keys for the token verification.
+type KeycloakProvider struct {
+	Name         string
+	BaseURL      string
+	Realm        string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	IDPOptions   idp.Options
+}
+
+func (p KeycloakProvider) genericOIDCProvider() (GenericOIDCProvider, error) {
+	baseURL := strings.TrimSuffix(strings.TrimSpace(p.BaseURL), "/")
+	if !strings.HasPrefix(baseURL, "https://") || len(baseURL) == len("https://") {
+		return GenericOIDCProvider{}, zerrors.ThrowInvalidArgument(nil, "COMMA-ooy3Z", "Errors.Invalid.Argument")
+	}
+	realm := strings.TrimSpace(p.Realm)
+	if realm == "" || strings.ContainsAny(realm, "/?#") {
+		return GenericOIDCProvider{}, zerrors.ThrowInvalidArgument(nil, "COMMA-Eeth6", "Errors.Invalid.Argument")
+	}
+	return GenericOIDCProvider{
+		Name:             defaultPresetName(p.Name, keycloakDefaultName),
+		Issuer:           baseURL + "/realms/" + realm,
+		ClientID:         p.ClientID,
+		ClientSecret:     p.ClientSecret,
+		Scopes:           defaultPresetScopes(p.Scopes),
+		IsIDTokenMapping: false,
+		IDPOptions:       p.IDPOptions,
+	}, nil
+}
+
+func defaultPresetName(name, defaultName string) string {
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+	return defaultName
+}
+
+func defaultPresetScopes(scopes []string) []string {
+	if len(scopes) > 0 {
+		return scopes
+	}
+	return []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail}
+}
+
+// AddInstanceOktaProvider adds a generic OIDC provider based on the [OktaProvider] preset to the instance.
+// The provider can be changed afterwards like any other generic OIDC provider.
+func (c *Commands) AddInstanceOktaProvider(ctx context.Context, provider OktaProvider) (string, *domain.ObjectDetails, error) {
+	oidcProvider, err := provider.genericOIDCProvider()
+	if err != nil {
+		return "", nil, err
+	}
+	return c.AddInstanceGenericOIDCProvider(ctx, oidcProvider)
+}
+
+// AddOrgOktaProvider adds a generic OIDC provider based on the [OktaProvider] preset to the organization.
+// The provider can be changed afterwards like any other generic OIDC provider.
+func (c *Commands) AddOrgOktaProvider(ctx context.Context, resourceOwner string, provider OktaProvider) (string, *domain.ObjectDetails, error) {
+	oidcProvider, err := provider.genericOIDCProvider()
+	if err != nil {
+		return "", nil, err
+	}
+	return c.AddOrgGenericOIDCProvider(ctx, resourceOwner, oidcProvider)
+}
+
+// AddInstanceKeycloakProvider adds a generic OIDC provider based on the [KeycloakProvider] preset to the instance.
+// The provider can be changed afterwards like any other generic OIDC provider.
+func (c *Commands) AddInstanceKeycloakProvider(ctx context.Context, provider KeycloakProvider) (string, *domain.ObjectDetails, error) {
+	oidcProvider, err := provider.genericOIDCProvider()
+	if err != nil {
+		return "", nil, err
+	}
+	return c.AddInstanceGenericOIDCProvider(ctx, oidcProvider)
+}
+
+// AddOrgKeycloakProvider adds a generic OIDC provider based on the [KeycloakProvider] preset to the organization.
+// The provider can be changed afterwards like any other generic OIDC provider.
+func (c *Commands) AddOrgKeycloakProvider(ctx context.Context, resourceOwner string, provider KeycloakProvider) (string, *domain.ObjectDetails, error) {
+	oidcProvider, err := provider.genericOIDCProvider()
+	if err != nil {
+		return "", nil, err
+	}
+	return c.AddOrgGenericOIDCProvider(ctx, resourceOwner, oidcProvider)
+}
@@ -0,0 +1,215 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	openid "github.com/zitadel/oidc/v3/pkg/oidc"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestOktaProvider_genericOIDCProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider OktaProvider
+		want     GenericOIDCProvider
+		wantErr  bool
+	}{
+		{
+			name:     "missing domain",
+			provider: OktaProvider{},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid domain",
+			provider: OktaProvider{Domain: "example.okta.com/path"},
+			wantErr:  true,
+		},
+		{
+			name: "org authorization server",
+			provider: OktaProvider{
+				Domain:       "https://example.okta.com/",
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+			},
+			want: GenericOIDCProvider{
+				Name:         "Okta",
+				Issuer:       "https://example.okta.com",
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				Scopes:       []string{openid.ScopeOpenID, openid.ScopeProfile, openid.ScopeEmail},
+			},
+		},
+		{
+			name: "custom authorization server",
+			provider: OktaProvider{
+				Name:                  "name",
+				Domain:                "example.okta.com",
+				AuthorizationServerID: "default",
+				ClientID:              "clientID",
+				ClientSecret:          "clientSecret",
+				Scopes:                []string{openid.ScopeOpenID},
+				IDPOptions:            idp.Options{IsLinkingAllowed: true},
+			},
+			want: GenericOIDCProvider{
+				Name:         "name",
+				Issuer:       "https://example.okta.com/oauth2/default",
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				Scopes:       []string{openid.ScopeOpenID},
+				IDPOptions:   idp.Options{IsLinkingAllowed: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.genericOIDCProvider()
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKeycloakProvider_genericOIDCProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider KeycloakProvider
+		want     GenericOIDCProvider
+		wantErr  bool
+	}{
+		{
+			name:     "missing scheme",
+			provider: KeycloakProvider{BaseURL: "keycloak.example.com", Realm: "realm"},
+			wantErr:  true,
+		},
+		{
+			name:     "http scheme",
+			provider: KeycloakProvider{BaseURL: "http://keycloak.example.com", Realm: "realm"},
+			wantErr:  true,
+		},
+		{
+			name:     "missing host",
+			provider: KeycloakProvider{BaseURL: "https://", Realm: "realm"},
+			wantErr:  true,
+		},
+		{
+			name:     "missing realm",
+			provider: KeycloakProvider{BaseURL: "https://keycloak.example.com"},
+			wantErr:  true,
+		},
+		{
+			name: "ok",
+			provider: KeycloakProvider{
+				BaseURL:      "https://keycloak.example.com/",
+				Realm:        "realm",
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+			},
+			want: GenericOIDCProvider{
+				Name:         "Keycloak",
+				Issuer:       "https://keycloak.example.com/realms/realm",
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				Scopes:       []string{openid.ScopeOpenID, openid.ScopeProfile, openid.ScopeEmail},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.genericOIDCProvider()
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommandSide_AddInstanceOktaProvider(t *testing.T) {
+	c := &Commands{
+		eventstore: expectEventstore(
+			expectFilter(),
+			expectPush(
+				instance.NewOIDCIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+					"id1",
+					"Okta",
+					"https://example.okta.com/oauth2/default",
+					"clientID",
+					&crypto.CryptoValue{
+						CryptoType: crypto.TypeEncryption,
+						Algorithm:  "enc",
+						KeyID:      "id",
+						Crypted:    []byte("clientSecret"),
+					},
+					[]string{openid.ScopeOpenID, openid.ScopeProfile, openid.ScopeEmail},
+					false,
+					idp.Options{},
+				),
+			),
+		)(t),
+		idGenerator:         id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+		idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+	}
+	id, got, err := c.AddInstanceOktaProvider(authz.WithInstanceID(context.Background(), "instance1"), OktaProvider{
+		Domain:                "example.okta.com",
+		AuthorizationServerID: "default",
+		ClientID:              "clientID",
+		ClientSecret:          "clientSecret",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "id1", id)
+	assert.Equal(t, &domain.ObjectDetails{ResourceOwner: "instance1"}, got)
+}
+
+func TestCommandSide_AddOrgKeycloakProvider(t *testing.T) {
+	c := &Commands{
+		eventstore: expectEventstore(
+			expectFilter(),
+			expectPush(
+				org.NewOIDCIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+					"id1",
+					"Keycloak",
+					"https://keycloak.example.com/realms/realm",
+					"clientID",
+					&crypto.CryptoValue{
+						CryptoType: crypto.TypeEncryption,
+						Algorithm:  "enc",
+						KeyID:      "id",
+						Crypted:    []byte("clientSecret"),
+					},
+					[]string{openid.ScopeOpenID, openid.ScopeProfile, openid.ScopeEmail},
+					false,
+					idp.Options{},
+				),
+			),
+		)(t),
+		idGenerator:         id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+		idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+	}
+	id, got, err := c.AddOrgKeycloakProvider(context.Background(), "org1", KeycloakProvider{
+		BaseURL:      "https://keycloak.example.com",
+		Realm:        "realm",
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "id1", id)
+	assert.Equal(t, &domain.ObjectDetails{ResourceOwner: "org1"}, got)
+}
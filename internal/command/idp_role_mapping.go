@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceIDPRoleMapping replaces the mapping of claim values to project roles of a provider of the instance.
+func (c *Commands) SetInstanceIDPRoleMapping(ctx context.Context, id string, mapping *domain.IDPRoleMapping) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if err = c.validateIDPRoleMapping(ctx, instanceID, id, mapping); err != nil {
+		return nil, err
+	}
+	writeModel := NewInstanceIDPRoleMappingWriteModel(instanceID, id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return c.pushIDPRoleMapping(ctx, &writeModel.IDPRoleMappingWriteModel, writeModel, mapping,
+		instance.NewIDPRoleMappingSetEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, id, *mapping),
+	)
+}
+
+// SetOrgIDPRoleMapping replaces the mapping of claim values to project roles of a provider of the organization.
+func (c *Commands) SetOrgIDPRoleMapping(ctx context.Context, resourceOwner, id string, mapping *domain.IDPRoleMapping) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ooz3e", "Errors.ResourceOwnerMissing")
+	}
+	if err = c.validateIDPRoleMapping(ctx, resourceOwner, id, mapping); err != nil {
+		return nil, err
+	}
+	writeModel := NewOrgIDPRoleMappingWriteModel(resourceOwner, id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return c.pushIDPRoleMapping(ctx, &writeModel.IDPRoleMappingWriteModel, writeModel, mapping,
+		org.NewIDPRoleMappingSetEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id, *mapping),
+	)
+}
+
+func (c *Commands) validateIDPRoleMapping(ctx context.Context, resourceOwner, id string, mapping *domain.IDPRoleMapping) error {
+	if id == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-aiW4u", "Errors.IDMissing")
+	}
+	if mapping == nil {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Gie8k", "Errors.IDP.RoleMapping.Invalid")
+	}
+	if err := mapping.Validate(); err != nil {
+		return err
+	}
+	// the mapping is available for all types of providers, so only their existence is checked
+	typeWriteModel := NewIDPTypeWriteModel(id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, typeWriteModel); err != nil {
+		return err
+	}
+	if !typeWriteModel.State.Exists() || typeWriteModel.ResourceOwner != resourceOwner {
+		return zerrors.ThrowNotFound(nil, "COMMAND-eeP6o", "Errors.IDPConfig.NotExisting")
+	}
+	for _, rule := range mapping.Rules {
+		if err := c.checkProjectExists(ctx, rule.ProjectID, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// idpRoleMapping returns the mapping of claim values to project roles of the provider.
+// An empty mapping is returned if the provider doesn't exist or has no mapping.
+func (c *Commands) idpRoleMapping(ctx context.Context, id string) (_ *domain.IDPRoleMapping, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	typeWriteModel := NewIDPTypeWriteModel(id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, typeWriteModel); err != nil {
+		return nil, err
+	}
+	if !typeWriteModel.State.Exists() {
+		return new(domain.IDPRoleMapping), nil
+	}
+	if typeWriteModel.ResourceOwner == authz.GetInstance(ctx).InstanceID() {
+		writeModel := NewInstanceIDPRoleMappingWriteModel(typeWriteModel.ResourceOwner, id)
+		if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return nil, err
+		}
+		return &writeModel.Mapping, nil
+	}
+	writeModel := NewOrgIDPRoleMappingWriteModel(typeWriteModel.ResourceOwner, id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return &writeModel.Mapping, nil
+}
+
+func (c *Commands) pushIDPRoleMapping(
+	ctx context.Context,
+	existing *IDPRoleMappingWriteModel,
+	writeModel eventstore.QueryReducer,
+	mapping *domain.IDPRoleMapping,
+	event eventstore.Command,
+) (*domain.ObjectDetails, error) {
+	if reflect.DeepEqual(existing.Mapping, *mapping) {
+		return writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
@@ -0,0 +1,350 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetInstanceIDPRoleMapping(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		id      string
+		mapping *domain.IDPRoleMapping
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	mapping := &domain.IDPRoleMapping{
+		Claim: "groups",
+		Mode:  domain.IDPRoleMappingModeReconcile,
+		Rules: []*domain.IDPRoleRule{
+			{Value: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}},
+		},
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				mapping: mapping,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-aiW4u", ""))
+				},
+			},
+		},
+		{
+			name: "missing claim",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				mapping: &domain.IDPRoleMapping{
+					Mode:  domain.IDPRoleMappingModeAddOnly,
+					Rules: mapping.Rules,
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "DOMAIN-Aeh2u", ""))
+				},
+			},
+		},
+		{
+			name: "idp not existing",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				mapping: mapping,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-eeP6o", ""))
+				},
+			},
+		},
+		{
+			name: "idp of organization, not found",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(orgGitHubIDPAddedEvent()),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				mapping: mapping,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-eeP6o", ""))
+				},
+			},
+		},
+		{
+			name: "project not existing",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent()),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				mapping: mapping,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "COMMAND-EbFMN", ""))
+				},
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(projectAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPRoleMappingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								*mapping,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				mapping: mapping,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(projectAddedEvent()),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewIDPRoleMappingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							*mapping,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				mapping: mapping,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetInstanceIDPRoleMapping(tt.args.ctx, tt.args.id, tt.args.mapping)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetOrgIDPRoleMapping(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		mapping       *domain.IDPRoleMapping
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resourceowner",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     context.Background(),
+				id:      "id1",
+				mapping: &domain.IDPRoleMapping{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ooz3e", ""))
+				},
+			},
+		},
+		{
+			name: "disable ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(orgGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPRoleMappingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								domain.IDPRoleMapping{
+									Claim: "groups",
+									Mode:  domain.IDPRoleMappingModeAddOnly,
+									Rules: []*domain.IDPRoleRule{
+										{Value: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}},
+									},
+								},
+							),
+						),
+					),
+					expectPush(
+						org.NewIDPRoleMappingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							domain.IDPRoleMapping{},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				mapping:       &domain.IDPRoleMapping{},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetOrgIDPRoleMapping(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.mapping)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func projectAddedEvent() *project.ProjectAddedEvent {
+	return project.NewProjectAddedEvent(context.Background(),
+		&project.NewAggregate("project1", "org1").Aggregate,
+		"projectname1", true, true, true,
+		domain.PrivateLabelingSettingUnspecified,
+	)
+}
+
+func instanceGitHubIDPAddedEvent() *instance.GitHubIDPAddedEvent {
+	return instance.NewGitHubIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		"id1",
+		"name",
+		"clientID",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("clientSecret"),
+		},
+		nil,
+		idp.Options{},
+	)
+}
+
+func orgGitHubIDPAddedEvent() *org.GitHubIDPAddedEvent {
+	return org.NewGitHubIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+		"id1",
+		"name",
+		"clientID",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("clientSecret"),
+		},
+		nil,
+		idp.Options{},
+	)
+}
@@ -0,0 +1,343 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider/xml"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceSAMLMetadataRefresh replaces the metadata refresh settings of a SAML provider of the instance.
+func (c *Commands) SetInstanceSAMLMetadataRefresh(ctx context.Context, id string, refresh *domain.SAMLMetadataRefresh) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if err = validateSAMLMetadataRefresh(id, refresh); err != nil {
+		return nil, err
+	}
+	writeModel := NewInstanceSAMLMetadataRefreshWriteModel(instanceID, id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return c.pushSAMLMetadataRefresh(ctx, &writeModel.SAMLMetadataRefreshWriteModel, writeModel, refresh,
+		instance.NewSAMLMetadataRefreshSetEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, id, *refresh),
+	)
+}
+
+// SetOrgSAMLMetadataRefresh replaces the metadata refresh settings of a SAML provider of the organization.
+func (c *Commands) SetOrgSAMLMetadataRefresh(ctx context.Context, resourceOwner, id string, refresh *domain.SAMLMetadataRefresh) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ugh3a", "Errors.ResourceOwnerMissing")
+	}
+	if err = validateSAMLMetadataRefresh(id, refresh); err != nil {
+		return nil, err
+	}
+	writeModel := NewOrgSAMLMetadataRefreshWriteModel(resourceOwner, id)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return c.pushSAMLMetadataRefresh(ctx, &writeModel.SAMLMetadataRefreshWriteModel, writeModel, refresh,
+		org.NewSAMLMetadataRefreshSetEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id, *refresh),
+	)
+}
+
+func validateSAMLMetadataRefresh(id string, refresh *domain.SAMLMetadataRefresh) error {
+	if id == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-ahV4u", "Errors.IDMissing")
+	}
+	if refresh == nil {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Oo5ee", "Errors.IDP.SAMLMetadataRefresh.Invalid")
+	}
+	return refresh.Validate()
+}
+
+func (c *Commands) pushSAMLMetadataRefresh(
+	ctx context.Context,
+	existing *SAMLMetadataRefreshWriteModel,
+	writeModel eventstore.QueryReducer,
+	refresh *domain.SAMLMetadataRefresh,
+	event eventstore.Command,
+) (*domain.ObjectDetails, error) {
+	if !existing.exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Tho8i", "Errors.IDPConfig.NotExisting")
+	}
+	if existing.Refresh == *refresh {
+		return writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// RefreshSAMLProviderMetadata fetches the metadata of a SAML provider from its metadata URL
+// and replaces the stored metadata if it changed.
+// The metadata is only accepted if it is signed by a trusted certificate, see [verifySAMLMetadataSignature].
+// Signing certificates, which are no longer published by the provider, are kept in the metadata until the rollover period elapsed,
+// so responses signed with the previous certificate are still accepted during a rollover.
+// An alert is raised once for every published certificate expiring within the warning period.
+// Failures to fetch or validate the metadata are not returned but recorded in the report and the stored metadata is kept.
+// The resourceOwner is the instance for instance providers and the organization otherwise,
+// now is the time the refresh is started at.
+func (c *Commands) RefreshSAMLProviderMetadata(ctx context.Context, resourceOwner, id string, now time.Time) (_ *domain.SAMLMetadataRefreshReport, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if id == "" || resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Eiph8", "Errors.IDMissing")
+	}
+	events := newSAMLMetadataRefreshEvents(ctx, resourceOwner, id)
+	writeModel, existing := events.writeModel()
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !existing.exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-ief0E", "Errors.IDPConfig.NotExisting")
+	}
+	if existing.Refresh.URL == "" {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Chai4", "Errors.IDP.SAMLMetadataRefresh.URLMissing")
+	}
+
+	report := &domain.SAMLMetadataRefreshReport{StartedAt: now}
+	cmds := make([]eventstore.Command, 0, 3)
+	metadata, certificates, err := c.fetchSAMLMetadata(existing, now)
+	if err != nil {
+		logging.WithFields("idp", id).WithError(err).Warn("saml metadata refresh failed")
+		report.Error = err.Error()
+	} else {
+		report.Certificates = certificates
+		if !bytes.Equal(metadata, existing.Metadata) {
+			report.MetadataChanged = true
+			changed, err := events.metadataChanged(metadata)
+			if err != nil {
+				return nil, err
+			}
+			cmds = append(cmds, changed)
+		}
+		for _, certificate := range certificates {
+			if !certificate.IsExpiring(now, existing.Refresh.ExpiryWarning) || existing.isAlerted(certificate.Certificate) {
+				continue
+			}
+			logging.WithFields("idp", id, "resourceOwner", resourceOwner, "notAfter", certificate.NotAfter).Warn("signing certificate of saml provider expires soon")
+			cmds = append(cmds, events.certificateExpiring(certificate))
+		}
+	}
+	cmds = append(cmds, events.refreshed(report))
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// SAMLCertificateExpiringSent records that the administrators were notified about the expiring signing certificate of the SAML provider.
+// The resourceOwner is the instance for instance providers and the organization otherwise.
+func (c *Commands) SAMLCertificateExpiringSent(ctx context.Context, resourceOwner, id string, certificate []byte) (err error) {
+	if id == "" || resourceOwner == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Shoo8", "Errors.IDMissing")
+	}
+	_, err = c.eventstore.Push(ctx, newSAMLMetadataRefreshEvents(ctx, resourceOwner, id).certificateExpiringSent(certificate))
+	return err
+}
+
+// fetchSAMLMetadata fetches and validates the metadata of the provider.
+// It returns the metadata to store, including the signing certificates still in their rollover period,
+// and all accepted signing certificates.
+func (c *Commands) fetchSAMLMetadata(existing *SAMLMetadataRefreshWriteModel, now time.Time) ([]byte, []*domain.SAMLCertificate, error) {
+	// settings stored before https was required must not be used to replace the trusted certificates
+	if !strings.HasPrefix(existing.Refresh.URL, "https://") {
+		return nil, nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Vu4ie", "Errors.IDP.SAMLMetadataRefresh.URLInvalid")
+	}
+	data, err := xml.ReadMetadataFromURL(c.httpClient, existing.Refresh.URL)
+	if err != nil {
+		return nil, nil, zerrors.ThrowPreconditionFailed(err, "COMMAND-Ahm4i", "Errors.IDP.SAMLMetadataRefresh.FetchFailed")
+	}
+	// the stored metadata might not be valid for the refresh (e.g. missing signing certificate),
+	// so the entityID is only compared if it could be parsed
+	current, _ := saml.ParseMetadata(existing.Metadata)
+	var stored []*x509.Certificate
+	if current != nil {
+		stored, _ = saml.SigningCertificates(current)
+	}
+	if err = verifySAMLMetadataSignature(data, existing.Refresh, stored, now); err != nil {
+		return nil, nil, err
+	}
+	entity, err := saml.ParseMetadata(data)
+	if err != nil {
+		return nil, nil, zerrors.ThrowPreconditionFailed(err, "COMMAND-ooY9e", "Errors.IDP.SAMLMetadataRefresh.MetadataInvalid")
+	}
+	if current != nil && current.EntityID != entity.EntityID {
+		return nil, nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohp0a", "Errors.IDP.SAMLMetadataRefresh.EntityIDMismatch")
+	}
+	published, err := saml.SigningCertificates(entity)
+	if err != nil {
+		return nil, nil, zerrors.ThrowPreconditionFailed(err, "COMMAND-xoo3U", "Errors.IDP.SAMLMetadataRefresh.MetadataInvalid")
+	}
+	certificates := make([]*domain.SAMLCertificate, 0, len(published))
+	valid := false
+	for _, certificate := range published {
+		valid = valid || certificate.NotAfter.After(now)
+		certificates = append(certificates, &domain.SAMLCertificate{
+			Certificate: certificate.Raw,
+			NotAfter:    certificate.NotAfter,
+		})
+	}
+	if !valid {
+		return nil, nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Iej3u", "Errors.IDP.SAMLMetadataRefresh.CertificatesExpired")
+	}
+
+	retired := make([]*x509.Certificate, 0)
+	for _, previous := range previousSAMLCertificates(existing, stored) {
+		if containsSAMLCertificate(certificates, previous.Certificate) {
+			continue
+		}
+		retiredAt := previous.RetiredAt
+		if retiredAt.IsZero() {
+			retiredAt = now
+		}
+		if !now.Before(retiredAt.Add(existing.Refresh.RolloverPeriod)) || !now.Before(previous.NotAfter) {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(previous.Certificate)
+		if err != nil {
+			continue
+		}
+		retired = append(retired, certificate)
+		certificates = append(certificates, &domain.SAMLCertificate{
+			Certificate: previous.Certificate,
+			NotAfter:    previous.NotAfter,
+			RetiredAt:   retiredAt,
+		})
+	}
+	if len(retired) == 0 {
+		return data, certificates, nil
+	}
+	metadata, err := saml.AppendSigningCertificates(entity, retired)
+	if err != nil {
+		return nil, nil, zerrors.ThrowInternal(err, "COMMAND-Quae5", "Errors.Internal")
+	}
+	return metadata, certificates, nil
+}
+
+// verifySAMLMetadataSignature ensures the fetched metadata is signed with the configured metadata certificate
+// or, if none is configured, with one of the signing certificates currently trusted for the provider.
+// Otherwise, anyone able to tamper with the response of the metadata URL could replace the trusted certificates.
+func verifySAMLMetadataSignature(data []byte, refresh domain.SAMLMetadataRefresh, trusted []*x509.Certificate, now time.Time) error {
+	certificate, err := refresh.Certificate()
+	if err != nil {
+		return err
+	}
+	if certificate != nil {
+		trusted = []*x509.Certificate{certificate}
+	}
+	if err = saml.VerifyMetadataSignature(data, trusted, now); err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "COMMAND-Si3gn", "Errors.IDP.SAMLMetadataRefresh.SignatureInvalid")
+	}
+	return nil
+}
+
+// previousSAMLCertificates returns the signing certificates of the last successful refresh.
+// Before the first refresh, the certificates of the stored metadata are used.
+func previousSAMLCertificates(existing *SAMLMetadataRefreshWriteModel, stored []*x509.Certificate) []*domain.SAMLCertificate {
+	if existing.Certificates != nil {
+		return existing.Certificates
+	}
+	certificates := make([]*domain.SAMLCertificate, len(stored))
+	for i, certificate := range stored {
+		certificates[i] = &domain.SAMLCertificate{
+			Certificate: certificate.Raw,
+			NotAfter:    certificate.NotAfter,
+		}
+	}
+	return certificates
+}
+
+func containsSAMLCertificate(certificates []*domain.SAMLCertificate, certificate []byte) bool {
+	for _, c := range certificates {
+		if bytes.Equal(c.Certificate, certificate) {
+			return true
+		}
+	}
+	return false
+}
+
+// samlMetadataRefreshEvents creates the events of the metadata refresh on the aggregate of the provider,
+// which is the instance for instance providers and the organization otherwise.
+type samlMetadataRefreshEvents struct {
+	ctx           context.Context
+	resourceOwner string
+	id            string
+	isInstance    bool
+}
+
+func newSAMLMetadataRefreshEvents(ctx context.Context, resourceOwner, id string) *samlMetadataRefreshEvents {
+	return &samlMetadataRefreshEvents{
+		ctx:           ctx,
+		resourceOwner: resourceOwner,
+		id:            id,
+		isInstance:    resourceOwner == authz.GetInstance(ctx).InstanceID(),
+	}
+}
+
+func (e *samlMetadataRefreshEvents) writeModel() (eventstore.QueryReducer, *SAMLMetadataRefreshWriteModel) {
+	if e.isInstance {
+		writeModel := NewInstanceSAMLMetadataRefreshWriteModel(e.resourceOwner, e.id)
+		return writeModel, &writeModel.SAMLMetadataRefreshWriteModel
+	}
+	writeModel := NewOrgSAMLMetadataRefreshWriteModel(e.resourceOwner, e.id)
+	return writeModel, &writeModel.SAMLMetadataRefreshWriteModel
+}
+
+func (e *samlMetadataRefreshEvents) metadataChanged(metadata []byte) (eventstore.Command, error) {
+	changes := []idp.SAMLIDPChanges{idp.ChangeSAMLMetadata(metadata)}
+	if e.isInstance {
+		return instance.NewSAMLIDPChangedEvent(e.ctx, &instance.NewAggregate(e.resourceOwner).Aggregate, e.id, changes)
+	}
+	return org.NewSAMLIDPChangedEvent(e.ctx, &org.NewAggregate(e.resourceOwner).Aggregate, e.id, changes)
+}
+
+func (e *samlMetadataRefreshEvents) certificateExpiring(certificate *domain.SAMLCertificate) eventstore.Command {
+	if e.isInstance {
+		return instance.NewSAMLCertificateExpiringEvent(e.ctx, &instance.NewAggregate(e.resourceOwner).Aggregate, e.id, certificate.Certificate, certificate.NotAfter)
+	}
+	return org.NewSAMLCertificateExpiringEvent(e.ctx, &org.NewAggregate(e.resourceOwner).Aggregate, e.id, certificate.Certificate, certificate.NotAfter)
+}
+
+func (e *samlMetadataRefreshEvents) certificateExpiringSent(certificate []byte) eventstore.Command {
+	if e.isInstance {
+		return instance.NewSAMLCertificateExpiringSentEvent(e.ctx, &instance.NewAggregate(e.resourceOwner).Aggregate, e.id, certificate)
+	}
+	return org.NewSAMLCertificateExpiringSentEvent(e.ctx, &org.NewAggregate(e.resourceOwner).Aggregate, e.id, certificate)
+}
+
+func (e *samlMetadataRefreshEvents) refreshed(report *domain.SAMLMetadataRefreshReport) eventstore.Command {
+	if e.isInstance {
+		return instance.NewSAMLMetadataRefreshedEvent(e.ctx, &instance.NewAggregate(e.resourceOwner).Aggregate, e.id, *report)
+	}
+	return org.NewSAMLMetadataRefreshedEvent(e.ctx, &org.NewAggregate(e.resourceOwner).Aggregate, e.id, *report)
+}
@@ -0,0 +1,168 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type SAMLMetadataRefreshWriteModel struct {
+	eventstore.WriteModel
+
+	ID       string
+	State    domain.IDPState
+	Metadata []byte
+	Refresh  domain.SAMLMetadataRefresh
+	// Certificates are the signing certificates of the last successful refresh.
+	Certificates []*domain.SAMLCertificate
+	// alerted contains the certificates an expiry alert was already raised for.
+	alerted map[string]bool
+}
+
+func (wm *SAMLMetadataRefreshWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.SAMLIDPAddedEvent:
+			wm.State = domain.IDPStateActive
+			wm.Metadata = e.Metadata
+		case *idp.SAMLIDPChangedEvent:
+			if e.Metadata != nil {
+				wm.Metadata = e.Metadata
+			}
+		case *idp.SAMLMetadataRefreshSetEvent:
+			wm.Refresh = e.SAMLMetadataRefresh
+		case *idp.SAMLMetadataRefreshedEvent:
+			if e.Error == "" {
+				wm.Certificates = e.Certificates
+			}
+		case *idp.SAMLCertificateExpiringEvent:
+			if wm.alerted == nil {
+				wm.alerted = make(map[string]bool)
+			}
+			wm.alerted[string(e.Certificate)] = true
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+			wm.Metadata = nil
+			wm.Refresh = domain.SAMLMetadataRefresh{}
+			wm.Certificates = nil
+			wm.alerted = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SAMLMetadataRefreshWriteModel) exists() bool {
+	return wm.State.Exists()
+}
+
+func (wm *SAMLMetadataRefreshWriteModel) isAlerted(certificate []byte) bool {
+	return wm.alerted[string(certificate)]
+}
+
+type InstanceSAMLMetadataRefreshWriteModel struct {
+	SAMLMetadataRefreshWriteModel
+}
+
+func NewInstanceSAMLMetadataRefreshWriteModel(instanceID, id string) *InstanceSAMLMetadataRefreshWriteModel {
+	return &InstanceSAMLMetadataRefreshWriteModel{
+		SAMLMetadataRefreshWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceSAMLMetadataRefreshWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.SAMLIDPAddedEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.SAMLIDPAddedEvent)
+		case *instance.SAMLIDPChangedEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.SAMLIDPChangedEvent)
+		case *instance.SAMLMetadataRefreshSetEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.SAMLMetadataRefreshSetEvent)
+		case *instance.SAMLMetadataRefreshedEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.SAMLMetadataRefreshedEvent)
+		case *instance.SAMLCertificateExpiringEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.SAMLCertificateExpiringEvent)
+		case *instance.IDPRemovedEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *InstanceSAMLMetadataRefreshWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.SAMLIDPAddedEventType,
+			instance.SAMLIDPChangedEventType,
+			instance.SAMLMetadataRefreshSetEventType,
+			instance.SAMLMetadataRefreshedEventType,
+			instance.SAMLCertificateExpiringEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+type OrgSAMLMetadataRefreshWriteModel struct {
+	SAMLMetadataRefreshWriteModel
+}
+
+func NewOrgSAMLMetadataRefreshWriteModel(orgID, id string) *OrgSAMLMetadataRefreshWriteModel {
+	return &OrgSAMLMetadataRefreshWriteModel{
+		SAMLMetadataRefreshWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgSAMLMetadataRefreshWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.SAMLIDPAddedEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.SAMLIDPAddedEvent)
+		case *org.SAMLIDPChangedEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.SAMLIDPChangedEvent)
+		case *org.SAMLMetadataRefreshSetEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.SAMLMetadataRefreshSetEvent)
+		case *org.SAMLMetadataRefreshedEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.SAMLMetadataRefreshedEvent)
+		case *org.SAMLCertificateExpiringEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.SAMLCertificateExpiringEvent)
+		case *org.IDPRemovedEvent:
+			wm.SAMLMetadataRefreshWriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgSAMLMetadataRefreshWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.SAMLIDPAddedEventType,
+			org.SAMLIDPChangedEventType,
+			org.SAMLMetadataRefreshSetEventType,
+			org.SAMLMetadataRefreshedEventType,
+			org.SAMLCertificateExpiringEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
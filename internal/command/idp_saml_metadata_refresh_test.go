@@ -0,0 +1,1039 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func instanceSAMLIDPAddedEvent(metadata []byte) *instance.SAMLIDPAddedEvent {
+	return instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		"id1",
+		"name",
+		metadata,
+		nil,
+		nil,
+		"",
+		false,
+		idp.Options{},
+	)
+}
+
+func testSAMLIDPMetadata(entityID string, certificates ...*x509.Certificate) []byte {
+	keyDescriptors := make([]string, len(certificates))
+	for i, certificate := range certificates {
+		keyDescriptors[i] = fmt.Sprintf(`<KeyDescriptor use="signing"><KeyInfo xmlns="http://www.w3.org/2000/09/xmldsig#"><X509Data><X509Certificate>%s</X509Certificate></X509Data></KeyInfo></KeyDescriptor>`, base64.StdEncoding.EncodeToString(certificate.Raw))
+	}
+	return []byte(fmt.Sprintf(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="%s"><IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">%s<SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"></SingleSignOnService></IDPSSODescriptor></EntityDescriptor>`, entityID, strings.Join(keyDescriptors, "")))
+}
+
+func testSAMLIDPCertificate(t *testing.T, notAfter time.Time) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certificate, key
+}
+
+func testSignedSAMLIDPMetadata(t *testing.T, metadata []byte, certificate *x509.Certificate, key *rsa.PrivateKey) []byte {
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromBytes(metadata))
+	signing := dsig.NewDefaultSigningContext(dsig.TLSCertKeyStore(tls.Certificate{
+		Certificate: [][]byte{certificate.Raw},
+		PrivateKey:  key,
+	}))
+	signed, err := signing.SignEnveloped(doc.Root())
+	require.NoError(t, err)
+	doc.SetRoot(signed)
+	data, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	return data
+}
+
+func TestCommandSide_SetInstanceSAMLMetadataRefresh(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		id      string
+		refresh *domain.SAMLMetadataRefresh
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	refresh := &domain.SAMLMetadataRefresh{
+		Enabled:        true,
+		URL:            "https://idp.example.com/metadata",
+		Interval:       time.Hour,
+		RolloverPeriod: 24 * time.Hour,
+		ExpiryWarning:  30 * 24 * time.Hour,
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				refresh: refresh,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-ahV4u", ""))
+				},
+			},
+		},
+		{
+			name: "invalid url",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				refresh: &domain.SAMLMetadataRefresh{
+					Enabled:  true,
+					Interval: time.Hour,
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "DOMAIN-Eeb4o", ""))
+				},
+			},
+		},
+		{
+			name: "idp not existing",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				refresh: refresh,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Tho8i", ""))
+				},
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent([]byte("metadata"))),
+						eventFromEventPusher(
+							instance.NewSAMLMetadataRefreshSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								*refresh,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				refresh: refresh,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent([]byte("metadata"))),
+					),
+					expectPush(
+						instance.NewSAMLMetadataRefreshSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							*refresh,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				refresh: refresh,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetInstanceSAMLMetadataRefresh(tt.args.ctx, tt.args.id, tt.args.refresh)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetOrgSAMLMetadataRefresh(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		refresh       *domain.SAMLMetadataRefresh
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	refresh := &domain.SAMLMetadataRefresh{
+		Enabled:  true,
+		URL:      "https://idp.example.com/metadata",
+		Interval: time.Hour,
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resourceowner",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     context.Background(),
+				id:      "id1",
+				refresh: refresh,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ugh3a", ""))
+				},
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewSAMLIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								[]byte("metadata"),
+								nil,
+								nil,
+								"",
+								false,
+								idp.Options{},
+							),
+						),
+					),
+					expectPush(
+						org.NewSAMLMetadataRefreshSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							*refresh,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				refresh:       refresh,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetOrgSAMLMetadataRefresh(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.refresh)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SAMLCertificateExpiringSent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    func(error) bool
+	}{
+		{
+			name: "missing id",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+			},
+			err: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "instance provider",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						instance.NewSAMLCertificateExpiringSentEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							[]byte("certificate"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+		},
+		{
+			name: "org provider",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						org.NewSAMLCertificateExpiringSentEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							[]byte("certificate"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				id:            "id1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := c.SAMLCertificateExpiringSent(tt.args.ctx, tt.args.resourceOwner, tt.args.id, []byte("certificate"))
+			if tt.err != nil {
+				assert.True(t, tt.err(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCommandSide_RefreshSAMLProviderMetadata(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		httpClient *http.Client
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+	}
+	type res struct {
+		want *domain.SAMLMetadataRefreshReport
+		err  func(error) bool
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	current, currentKey := testSAMLIDPCertificate(t, now.Add(30*24*time.Hour))
+	next, nextKey := testSAMLIDPCertificate(t, now.Add(365*24*time.Hour))
+	expiring, expiringKey := testSAMLIDPCertificate(t, now.Add(24*time.Hour))
+	expired, _ := testSAMLIDPCertificate(t, now.Add(-time.Hour))
+	pinned, pinnedKey := testSAMLIDPCertificate(t, now.Add(365*24*time.Hour))
+	metadata := testSignedSAMLIDPMetadata(t, testSAMLIDPMetadata("https://idp.example.com", current), current, currentKey)
+	rolledOverMetadata := testSignedSAMLIDPMetadata(t, testSAMLIDPMetadata("https://idp.example.com", next), current, currentKey)
+	expiringMetadata := testSignedSAMLIDPMetadata(t, testSAMLIDPMetadata("https://idp.example.com", expiring), expiring, expiringKey)
+	pinnedMetadata := testSignedSAMLIDPMetadata(t, testSAMLIDPMetadata("https://idp.example.com", current), pinned, pinnedKey)
+	rolloverEntity, err := saml.ParseMetadata(rolledOverMetadata)
+	require.NoError(t, err)
+	rolloverMetadata, err := saml.AppendSigningCertificates(rolloverEntity, []*x509.Certificate{current})
+	require.NoError(t, err)
+	refresh := domain.SAMLMetadataRefresh{
+		Enabled:        true,
+		URL:            "https://idp.example.com/metadata",
+		Interval:       time.Hour,
+		RolloverPeriod: 24 * time.Hour,
+		ExpiryWarning:  7 * 24 * time.Hour,
+	}
+	refreshSetEvent := func() *instance.SAMLMetadataRefreshSetEvent {
+		return instance.NewSAMLMetadataRefreshSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "id1", refresh)
+	}
+	samlMetadataChangedEvent := func(metadata []byte) *instance.SAMLIDPChangedEvent {
+		event, _ := instance.NewSAMLIDPChangedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+			"id1",
+			[]idp.SAMLIDPChanges{idp.ChangeSAMLMetadata(metadata)},
+		)
+		return event
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Eiph8", ""))
+				},
+			},
+		},
+		{
+			name: "idp not existing",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-ief0E", ""))
+				},
+			},
+		},
+		{
+			name: "url missing",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Chai4", ""))
+				},
+			},
+		},
+		{
+			name: "fetch failed, reported",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+						eventFromEventPusher(refreshSetEvent()),
+					),
+					expectPush(
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt: now,
+								Error:     zerrors.ThrowPreconditionFailed(errors.New("error while reading metadata with statusCode: 404"), "COMMAND-Ahm4i", "Errors.IDP.SAMLMetadataRefresh.FetchFailed").Error(),
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusNotFound, nil),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt: now,
+					Error:     zerrors.ThrowPreconditionFailed(errors.New("error while reading metadata with statusCode: 404"), "COMMAND-Ahm4i", "Errors.IDP.SAMLMetadataRefresh.FetchFailed").Error(),
+				},
+			},
+		},
+		{
+			name: "entityID changed, reported",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+						eventFromEventPusher(refreshSetEvent()),
+					),
+					expectPush(
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt: now,
+								Error:     zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohp0a", "Errors.IDP.SAMLMetadataRefresh.EntityIDMismatch").Error(),
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, testSignedSAMLIDPMetadata(t, testSAMLIDPMetadata("https://other.example.com", current), current, currentKey)),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt: now,
+					Error:     zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohp0a", "Errors.IDP.SAMLMetadataRefresh.EntityIDMismatch").Error(),
+				},
+			},
+		},
+		{
+			name: "all certificates expired, reported",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+						eventFromEventPusher(refreshSetEvent()),
+					),
+					expectPush(
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt: now,
+								Error:     zerrors.ThrowPreconditionFailed(nil, "COMMAND-Iej3u", "Errors.IDP.SAMLMetadataRefresh.CertificatesExpired").Error(),
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, testSignedSAMLIDPMetadata(t, testSAMLIDPMetadata("https://idp.example.com", expired), current, currentKey)),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt: now,
+					Error:     zerrors.ThrowPreconditionFailed(nil, "COMMAND-Iej3u", "Errors.IDP.SAMLMetadataRefresh.CertificatesExpired").Error(),
+				},
+			},
+		},
+		{
+			name: "http url, reported",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+						eventFromEventPusher(
+							instance.NewSAMLMetadataRefreshSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "id1", domain.SAMLMetadataRefresh{
+								Enabled:  true,
+								URL:      "http://idp.example.com/metadata",
+								Interval: time.Hour,
+							}),
+						),
+					),
+					expectPush(
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt: now,
+								Error:     zerrors.ThrowPreconditionFailed(nil, "COMMAND-Vu4ie", "Errors.IDP.SAMLMetadataRefresh.URLInvalid").Error(),
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt: now,
+					Error:     zerrors.ThrowPreconditionFailed(nil, "COMMAND-Vu4ie", "Errors.IDP.SAMLMetadataRefresh.URLInvalid").Error(),
+				},
+			},
+		},
+		{
+			name: "metadata not signed, reported",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+						eventFromEventPusher(refreshSetEvent()),
+					),
+					expectPush(
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt: now,
+								Error:     zerrors.ThrowPreconditionFailed(saml.ErrMissingMetadataSignature, "COMMAND-Si3gn", "Errors.IDP.SAMLMetadataRefresh.SignatureInvalid").Error(),
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, testSAMLIDPMetadata("https://idp.example.com", next)),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt: now,
+					Error:     zerrors.ThrowPreconditionFailed(saml.ErrMissingMetadataSignature, "COMMAND-Si3gn", "Errors.IDP.SAMLMetadataRefresh.SignatureInvalid").Error(),
+				},
+			},
+		},
+		{
+			name: "metadata signed by untrusted certificate, reported",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+						eventFromEventPusher(refreshSetEvent()),
+					),
+					expectPush(
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt: now,
+								Error:     zerrors.ThrowPreconditionFailed(saml.ErrInvalidMetadataSignature, "COMMAND-Si3gn", "Errors.IDP.SAMLMetadataRefresh.SignatureInvalid").Error(),
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, testSignedSAMLIDPMetadata(t, testSAMLIDPMetadata("https://idp.example.com", next), next, nextKey)),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt: now,
+					Error:     zerrors.ThrowPreconditionFailed(saml.ErrInvalidMetadataSignature, "COMMAND-Si3gn", "Errors.IDP.SAMLMetadataRefresh.SignatureInvalid").Error(),
+				},
+			},
+		},
+		{
+			name: "metadata signed by configured metadata certificate",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+						eventFromEventPusher(
+							instance.NewSAMLMetadataRefreshSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "id1", domain.SAMLMetadataRefresh{
+								Enabled:             true,
+								URL:                 "https://idp.example.com/metadata",
+								MetadataCertificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: pinned.Raw})),
+								Interval:            time.Hour,
+							}),
+						),
+					),
+					expectPush(
+						samlMetadataChangedEvent(pinnedMetadata),
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt:       now,
+								MetadataChanged: true,
+								Certificates: []*domain.SAMLCertificate{
+									{Certificate: current.Raw, NotAfter: current.NotAfter},
+								},
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, pinnedMetadata),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt:       now,
+					MetadataChanged: true,
+					Certificates: []*domain.SAMLCertificate{
+						{Certificate: current.Raw, NotAfter: current.NotAfter},
+					},
+				},
+			},
+		},
+		{
+			name: "unchanged",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+						eventFromEventPusher(refreshSetEvent()),
+					),
+					expectPush(
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt: now,
+								Certificates: []*domain.SAMLCertificate{
+									{Certificate: current.Raw, NotAfter: current.NotAfter},
+								},
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, metadata),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt: now,
+					Certificates: []*domain.SAMLCertificate{
+						{Certificate: current.Raw, NotAfter: current.NotAfter},
+					},
+				},
+			},
+		},
+		{
+			name: "certificate rollover, previous certificate kept",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+						eventFromEventPusher(refreshSetEvent()),
+					),
+					expectPush(
+						samlMetadataChangedEvent(rolloverMetadata),
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt:       now,
+								MetadataChanged: true,
+								Certificates: []*domain.SAMLCertificate{
+									{Certificate: next.Raw, NotAfter: next.NotAfter},
+									{Certificate: current.Raw, NotAfter: current.NotAfter, RetiredAt: now},
+								},
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, rolledOverMetadata),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt:       now,
+					MetadataChanged: true,
+					Certificates: []*domain.SAMLCertificate{
+						{Certificate: next.Raw, NotAfter: next.NotAfter},
+						{Certificate: current.Raw, NotAfter: current.NotAfter, RetiredAt: now},
+					},
+				},
+			},
+		},
+		{
+			name: "certificate rollover, rollover period elapsed",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(metadata)),
+						eventFromEventPusher(refreshSetEvent()),
+						eventFromEventPusher(samlMetadataChangedEvent(rolloverMetadata)),
+						eventFromEventPusher(
+							instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								domain.SAMLMetadataRefreshReport{
+									StartedAt:       now.Add(-48 * time.Hour),
+									MetadataChanged: true,
+									Certificates: []*domain.SAMLCertificate{
+										{Certificate: next.Raw, NotAfter: next.NotAfter},
+										{Certificate: current.Raw, NotAfter: current.NotAfter, RetiredAt: now.Add(-48 * time.Hour)},
+									},
+								},
+							),
+						),
+					),
+					expectPush(
+						samlMetadataChangedEvent(rolledOverMetadata),
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt:       now,
+								MetadataChanged: true,
+								Certificates: []*domain.SAMLCertificate{
+									{Certificate: next.Raw, NotAfter: next.NotAfter},
+								},
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, rolledOverMetadata),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt:       now,
+					MetadataChanged: true,
+					Certificates: []*domain.SAMLCertificate{
+						{Certificate: next.Raw, NotAfter: next.NotAfter},
+					},
+				},
+			},
+		},
+		{
+			name: "certificate expiring, alerted",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(expiringMetadata)),
+						eventFromEventPusher(refreshSetEvent()),
+					),
+					expectPush(
+						instance.NewSAMLCertificateExpiringEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							expiring.Raw,
+							expiring.NotAfter,
+						),
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt: now,
+								Certificates: []*domain.SAMLCertificate{
+									{Certificate: expiring.Raw, NotAfter: expiring.NotAfter},
+								},
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, expiringMetadata),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt: now,
+					Certificates: []*domain.SAMLCertificate{
+						{Certificate: expiring.Raw, NotAfter: expiring.NotAfter},
+					},
+				},
+			},
+		},
+		{
+			name: "certificate expiring, already alerted",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(expiringMetadata)),
+						eventFromEventPusher(refreshSetEvent()),
+						eventFromEventPusher(
+							instance.NewSAMLCertificateExpiringEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								expiring.Raw,
+								expiring.NotAfter,
+							),
+						),
+					),
+					expectPush(
+						instance.NewSAMLMetadataRefreshedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt: now,
+								Certificates: []*domain.SAMLCertificate{
+									{Certificate: expiring.Raw, NotAfter: expiring.NotAfter},
+								},
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, expiringMetadata),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt: now,
+					Certificates: []*domain.SAMLCertificate{
+						{Certificate: expiring.Raw, NotAfter: expiring.NotAfter},
+					},
+				},
+			},
+		},
+		{
+			name: "org provider",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewSAMLIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								metadata,
+								nil,
+								nil,
+								"",
+								false,
+								idp.Options{},
+							),
+						),
+						eventFromEventPusher(
+							org.NewSAMLMetadataRefreshSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "id1", refresh),
+						),
+					),
+					expectPush(
+						org.NewSAMLMetadataRefreshedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							domain.SAMLMetadataRefreshReport{
+								StartedAt: now,
+								Certificates: []*domain.SAMLCertificate{
+									{Certificate: current.Raw, NotAfter: current.NotAfter},
+								},
+							},
+						),
+					),
+				),
+				httpClient: newTestClient(http.StatusOK, metadata),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.SAMLMetadataRefreshReport{
+					StartedAt: now,
+					Certificates: []*domain.SAMLCertificate{
+						{Certificate: current.Raw, NotAfter: current.NotAfter},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+				httpClient: tt.fields.httpClient,
+			}
+			got, err := c.RefreshSAMLProviderMetadata(tt.args.ctx, tt.args.resourceOwner, tt.args.id, now)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
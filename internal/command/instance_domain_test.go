@@ -161,6 +161,9 @@ func TestCommandSide_AddInstanceDomain(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								false,
+								"",
+								0,
 							),
 						),
 					),
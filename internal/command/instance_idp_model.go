@@ -432,6 +432,266 @@ func (wm *InstanceGitHubIDPWriteModel) NewChangedEvent(
 	return instance.NewGitHubIDPChangedEvent(ctx, aggregate, id, changes)
 }
 
+type InstanceBitbucketIDPWriteModel struct {
+	BitbucketIDPWriteModel
+}
+
+func NewBitbucketInstanceIDPWriteModel(instanceID, id string) *InstanceBitbucketIDPWriteModel {
+	return &InstanceBitbucketIDPWriteModel{
+		BitbucketIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceBitbucketIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.BitbucketIDPAddedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.BitbucketIDPAddedEvent)
+		case *instance.BitbucketIDPChangedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.BitbucketIDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.BitbucketIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceBitbucketIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.BitbucketIDPAddedEventType,
+			instance.BitbucketIDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceBitbucketIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) (*instance.BitbucketIDPChangedEvent, error) {
+
+	changes, err := wm.BitbucketIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return instance.NewBitbucketIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type InstanceSlackIDPWriteModel struct {
+	SlackIDPWriteModel
+}
+
+func NewSlackInstanceIDPWriteModel(instanceID, id string) *InstanceSlackIDPWriteModel {
+	return &InstanceSlackIDPWriteModel{
+		SlackIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceSlackIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.SlackIDPAddedEvent:
+			wm.SlackIDPWriteModel.AppendEvents(&e.SlackIDPAddedEvent)
+		case *instance.SlackIDPChangedEvent:
+			wm.SlackIDPWriteModel.AppendEvents(&e.SlackIDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.SlackIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.SlackIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceSlackIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.SlackIDPAddedEventType,
+			instance.SlackIDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceSlackIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) (*instance.SlackIDPChangedEvent, error) {
+
+	changes, err := wm.SlackIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return instance.NewSlackIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type InstanceDiscordIDPWriteModel struct {
+	DiscordIDPWriteModel
+}
+
+func NewDiscordInstanceIDPWriteModel(instanceID, id string) *InstanceDiscordIDPWriteModel {
+	return &InstanceDiscordIDPWriteModel{
+		DiscordIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceDiscordIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.DiscordIDPAddedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.DiscordIDPAddedEvent)
+		case *instance.DiscordIDPChangedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.DiscordIDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.DiscordIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceDiscordIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.DiscordIDPAddedEventType,
+			instance.DiscordIDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceDiscordIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) (*instance.DiscordIDPChangedEvent, error) {
+
+	changes, err := wm.DiscordIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return instance.NewDiscordIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type InstanceLinkedInIDPWriteModel struct {
+	LinkedInIDPWriteModel
+}
+
+func NewLinkedInInstanceIDPWriteModel(instanceID, id string) *InstanceLinkedInIDPWriteModel {
+	return &InstanceLinkedInIDPWriteModel{
+		LinkedInIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceLinkedInIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.LinkedInIDPAddedEvent:
+			wm.LinkedInIDPWriteModel.AppendEvents(&e.LinkedInIDPAddedEvent)
+		case *instance.LinkedInIDPChangedEvent:
+			wm.LinkedInIDPWriteModel.AppendEvents(&e.LinkedInIDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.LinkedInIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.LinkedInIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceLinkedInIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.LinkedInIDPAddedEventType,
+			instance.LinkedInIDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceLinkedInIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) (*instance.LinkedInIDPChangedEvent, error) {
+
+	changes, err := wm.LinkedInIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return instance.NewLinkedInIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
 type InstanceGitHubEnterpriseIDPWriteModel struct {
 	GitHubEnterpriseIDPWriteModel
 }
@@ -0,0 +1,54 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetDefaultWebAuthNAttestationPolicy replaces the restrictions of authenticators users of the instance are able to register.
+// The policy applies to all organizations without a custom policy.
+func (c *Commands) SetDefaultWebAuthNAttestationPolicy(ctx context.Context, policy *domain.WebAuthNAttestationPolicy) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if policy == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-ooX4a", "Errors.Policy.WebAuthNAttestation.Invalid")
+	}
+	if err = policy.Normalize(); err != nil {
+		return nil, err
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	writeModel := NewInstanceWebAuthNAttestationPolicyWriteModel(instanceID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if writeModel.State.Exists() && reflect.DeepEqual(writeModel.Policy, *policy) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx,
+		instance.NewWebAuthNAttestationPolicySetEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, *policy),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// getDefaultWebAuthNAttestationPolicy returns the policy of the instance.
+// An empty policy (accepting any authenticator) is returned, if none was set.
+func (c *Commands) getDefaultWebAuthNAttestationPolicy(ctx context.Context) (*domain.WebAuthNAttestationPolicy, error) {
+	writeModel := NewInstanceWebAuthNAttestationPolicyWriteModel(authz.GetInstance(ctx).InstanceID())
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return &writeModel.Policy, nil
+}
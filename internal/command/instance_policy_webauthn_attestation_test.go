@@ -0,0 +1,139 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetDefaultWebAuthNAttestationPolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		policy *domain.WebAuthNAttestationPolicy
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing policy",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INSTANCE-ooX4a", ""))
+				},
+			},
+		},
+		{
+			name: "invalid aaguid",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				policy: &domain.WebAuthNAttestationPolicy{
+					AllowedAAGUIDs: []string{"invalid"},
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohng4", ""))
+				},
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewWebAuthNAttestationPolicySetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								domain.WebAuthNAttestationPolicy{
+									RequireAttestation: true,
+									AllowedAAGUIDs:     []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				policy: &domain.WebAuthNAttestationPolicy{
+					RequireAttestation: true,
+					AllowedAAGUIDs:     []string{"CB69481E-8FF7-4039-93EC-0A2729A154A8"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectPush(
+						instance.NewWebAuthNAttestationPolicySetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							domain.WebAuthNAttestationPolicy{
+								RequireAttestation: true,
+								RequireMetadata:    true,
+								DeniedAAGUIDs:      []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				policy: &domain.WebAuthNAttestationPolicy{
+					RequireAttestation: true,
+					RequireMetadata:    true,
+					DeniedAAGUIDs:      []string{"cb69481e8ff7403993ec0a2729a154a8"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetDefaultWebAuthNAttestationPolicy(tt.args.ctx, tt.args.policy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -6,42 +6,120 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"math/big"
+	"slices"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
 )
 
 func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string) error {
-	privateCrypto, publicCrypto, err := crypto.GenerateEncryptedKeyPair(c.keySize, c.keyAlgorithm)
+	event, err := c.newSigningKeyPairAddedEvent(ctx, algorithm)
 	if err != nil {
 		return err
 	}
+	_, err = c.eventstore.Push(ctx, event)
+	return err
+}
+
+func (c *Commands) newSigningKeyPairAddedEvent(ctx context.Context, algorithm string) (*keypair.AddedEvent, error) {
+	privateCrypto, publicCrypto, err := crypto.GenerateEncryptedKeyPair(c.keySize, c.keyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
 	keyID, err := c.idGenerator.Next()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	privateKeyExp := time.Now().UTC().Add(c.privateKeyLifetime)
-	publicKeyExp := time.Now().UTC().Add(c.publicKeyLifetime)
+	// the key is used as soon as its predecessors expired, which is after the pre-publication if the key was rotated in time
+	privateKeyExp := time.Now().UTC().Add(c.signingKeyRotation.PrePublication + c.signingKeyRotation.Lifetime)
+	publicKeyExp := privateKeyExp.Add(c.signingKeyRotation.RetireAfter)
 
 	keyPairWriteModel := NewKeyPairWriteModel(keyID, authz.GetInstance(ctx).InstanceID())
 	keyAgg := KeyPairAggregateFromWriteModel(&keyPairWriteModel.WriteModel)
-	_, err = c.eventstore.Push(ctx, keypair.NewAddedEvent(
+	return keypair.NewAddedEvent(
 		ctx,
 		keyAgg,
 		domain.KeyUsageSigning,
 		algorithm,
 		privateCrypto, publicCrypto,
-		privateKeyExp, publicKeyExp))
-	return err
+		privateKeyExp, publicKeyExp,
+	), nil
+}
+
+// RotateSigningKey creates the successor of the signing keys of the instance
+// as soon as it has to be published according to the pre-publication of the rotation policy.
+// It returns true if a key was created.
+func (c *Commands) RotateSigningKey(ctx context.Context, algorithm string, now time.Time) (bool, error) {
+	keyPairs, err := c.instanceKeyPairsWriteModel(ctx)
+	if err != nil {
+		return false, err
+	}
+	if keyPairs.PrivateKeyExpiry(domain.KeyUsageSigning).After(now.Add(c.signingKeyRotation.PrePublication)) {
+		return false, nil
+	}
+	return true, c.GenerateSigningKeyPair(ctx, algorithm)
+}
+
+// RevokeKeyPairs revokes all key pairs of the instance which are used or published, e.g. after a suspected compromise.
+// If prePublishSuccessor is set, the key pairs are revoked after the pre-publication of the signing key rotation policy
+// and the successor of the signing key is published until then.
+// Otherwise, the key pairs are revoked immediately and the successors are created as soon as they are required.
+func (c *Commands) RevokeKeyPairs(ctx context.Context, reason string, prePublishSuccessor bool, algorithm string) (*domain.ObjectDetails, error) {
+	keyPairs, err := c.instanceKeyPairsWriteModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var revokeAt time.Time
+	if prePublishSuccessor && c.signingKeyRotation.PrePublication > 0 {
+		revokeAt = now.Add(c.signingKeyRotation.PrePublication)
+	}
+	ids := make([]string, 0, len(keyPairs.KeyPairs))
+	for id := range keyPairs.KeyPairs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	events := make([]eventstore.Command, 0, len(ids)+1)
+	for _, id := range ids {
+		keyPair := keyPairs.KeyPairs[id]
+		if !keyPair.Active(now) {
+			continue
+		}
+		events = append(events, keypair.NewRevokedEvent(ctx, keyPair.Aggregate, reason, revokeAt))
+	}
+	if len(events) == 0 {
+		return writeModelToObjectDetails(&keyPairs.WriteModel), nil
+	}
+	if !revokeAt.IsZero() {
+		successor, err := c.newSigningKeyPairAddedEvent(ctx, algorithm)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, successor)
+	}
+	if err = c.pushAppendAndReduce(ctx, keyPairs, events...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&keyPairs.WriteModel), nil
+}
+
+func (c *Commands) instanceKeyPairsWriteModel(ctx context.Context) (*InstanceKeyPairsWriteModel, error) {
+	writeModel := NewInstanceKeyPairsWriteModel(authz.GetInstance(ctx).InstanceID())
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
 }
 
 func (c *Commands) GenerateSAMLCACertificate(ctx context.Context, algorithm string) error {
 	now := time.Now().UTC()
-	after := now.Add(c.certificateLifetime)
+	privateKeyExp, after := certificateExpiries(c.samlCertificateRotation.CA, now)
 	randInt, err := rand.Int(rand.Reader, big.NewInt(1000))
 	if err != nil {
 		return err
@@ -72,7 +150,7 @@ func (c *Commands) GenerateSAMLCACertificate(ctx context.Context, algorithm stri
 			domain.KeyUsageSAMLCA,
 			algorithm,
 			privateCrypto, publicCrypto,
-			after, after,
+			privateKeyExp, after,
 		),
 		keypair.NewAddedCertificateEvent(
 			ctx,
@@ -86,7 +164,7 @@ func (c *Commands) GenerateSAMLCACertificate(ctx context.Context, algorithm stri
 
 func (c *Commands) GenerateSAMLResponseCertificate(ctx context.Context, algorithm string, caPrivateKey *rsa.PrivateKey, caCertificate []byte) error {
 	now := time.Now().UTC()
-	after := now.Add(c.certificateLifetime)
+	privateKeyExp, after := certificateExpiries(c.samlCertificateRotation.Response, now)
 	randInt, err := rand.Int(rand.Reader, big.NewInt(1000))
 	if err != nil {
 		return err
@@ -118,7 +196,7 @@ func (c *Commands) GenerateSAMLResponseCertificate(ctx context.Context, algorith
 			domain.KeyUsageSAMLResponseSinging,
 			algorithm,
 			privateCrypto, publicCrypto,
-			after, after,
+			privateKeyExp, after,
 		),
 		keypair.NewAddedCertificateEvent(
 			ctx,
@@ -132,7 +210,7 @@ func (c *Commands) GenerateSAMLResponseCertificate(ctx context.Context, algorith
 
 func (c *Commands) GenerateSAMLMetadataCertificate(ctx context.Context, algorithm string, caPrivateKey *rsa.PrivateKey, caCertificate []byte) error {
 	now := time.Now().UTC()
-	after := now.Add(c.certificateLifetime)
+	privateKeyExp, after := certificateExpiries(c.samlCertificateRotation.Metadata, now)
 	randInt, err := rand.Int(rand.Reader, big.NewInt(1000))
 	if err != nil {
 		return err
@@ -163,7 +241,7 @@ func (c *Commands) GenerateSAMLMetadataCertificate(ctx context.Context, algorith
 			domain.KeyUsageSAMLMetadataSigning,
 			algorithm,
 			privateCrypto, publicCrypto,
-			after, after),
+			privateKeyExp, after),
 		keypair.NewAddedCertificateEvent(
 			ctx,
 			keyAgg,
@@ -173,3 +251,10 @@ func (c *Commands) GenerateSAMLMetadataCertificate(ctx context.Context, algorith
 	)
 	return err
 }
+
+// certificateExpiries returns the end of the use of the private key
+// and the expiry of the certificate, which is valid until the key is retired.
+func certificateExpiries(rotation sd.KeyRotation, now time.Time) (privateKeyExp, certificateExp time.Time) {
+	privateKeyExp = now.Add(rotation.Lifetime)
+	return privateKeyExp, privateKeyExp.Add(rotation.RetireAfter)
+}
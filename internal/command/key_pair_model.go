@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
@@ -66,3 +68,100 @@ func (wm *KeyPairWriteModel) Query() *eventstore.SearchQueryBuilder {
 func KeyPairAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return eventstore.AggregateFromWriteModel(wm, keypair.AggregateType, keypair.AggregateVersion)
 }
+
+// InstanceKeyPairsWriteModel are the key pairs of an instance which are not revoked
+type InstanceKeyPairsWriteModel struct {
+	eventstore.WriteModel
+
+	KeyPairs map[string]*InstanceKeyPair
+}
+
+type InstanceKeyPair struct {
+	Aggregate         *eventstore.Aggregate
+	Usage             domain.KeyUsage
+	PrivateKeyExpiry  time.Time
+	PublicKeyExpiry   time.Time
+	CertificateExpiry time.Time
+}
+
+// Active returns true if the key pair is used or published at t
+func (k *InstanceKeyPair) Active(t time.Time) bool {
+	return k.PrivateKeyExpiry.After(t) || k.PublicKeyExpiry.After(t) || k.CertificateExpiry.After(t)
+}
+
+// revokeAt ends the use and publication of the key pair at t, if it didn't end earlier
+func (k *InstanceKeyPair) revokeAt(t time.Time) {
+	if k.PrivateKeyExpiry.After(t) {
+		k.PrivateKeyExpiry = t
+	}
+	if k.PublicKeyExpiry.After(t) {
+		k.PublicKeyExpiry = t
+	}
+	if k.CertificateExpiry.After(t) {
+		k.CertificateExpiry = t
+	}
+}
+
+func NewInstanceKeyPairsWriteModel(instanceID string) *InstanceKeyPairsWriteModel {
+	return &InstanceKeyPairsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   instanceID,
+			ResourceOwner: instanceID,
+			InstanceID:    instanceID,
+		},
+		KeyPairs: make(map[string]*InstanceKeyPair),
+	}
+}
+
+func (wm *InstanceKeyPairsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *keypair.AddedEvent:
+			wm.KeyPairs[e.Aggregate().ID] = &InstanceKeyPair{
+				Aggregate:        e.Aggregate(),
+				Usage:            e.Usage,
+				PrivateKeyExpiry: e.PrivateKey.Expiry,
+				PublicKeyExpiry:  e.PublicKey.Expiry,
+			}
+		case *keypair.AddedCertificateEvent:
+			if keyPair, ok := wm.KeyPairs[e.Aggregate().ID]; ok {
+				keyPair.CertificateExpiry = e.Certificate.Expiry
+			}
+		case *keypair.RevokedEvent:
+			keyPair, ok := wm.KeyPairs[e.Aggregate().ID]
+			if !ok {
+				continue
+			}
+			if e.RevokeAt.IsZero() {
+				delete(wm.KeyPairs, e.Aggregate().ID)
+				continue
+			}
+			keyPair.revokeAt(e.RevokeAt)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceKeyPairsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		InstanceID(wm.InstanceID).
+		AddQuery().
+		AggregateTypes(keypair.AggregateType).
+		EventTypes(
+			keypair.AddedEventType,
+			keypair.AddedCertificateEventType,
+			keypair.RevokedEventType,
+		).
+		Builder()
+}
+
+// PrivateKeyExpiry returns the latest expiry of the private keys of the usage
+func (wm *InstanceKeyPairsWriteModel) PrivateKeyExpiry(usage domain.KeyUsage) (expiry time.Time) {
+	for _, keyPair := range wm.KeyPairs {
+		if keyPair.Usage == usage && keyPair.PrivateKeyExpiry.After(expiry) {
+			expiry = keyPair.PrivateKeyExpiry
+		}
+	}
+	return expiry
+}
@@ -0,0 +1,235 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/keypair"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func keyPairAggregate(id string) *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            id,
+		Type:          keypair.AggregateType,
+		ResourceOwner: "INSTANCE",
+		InstanceID:    "INSTANCE",
+		Version:       keypair.AggregateVersion,
+	}
+}
+
+func keyPairAddedEvent(id string, usage domain.KeyUsage, privateKeyExpiry, publicKeyExpiry time.Time) eventstore.Event {
+	return eventFromEventPusherWithInstanceID("INSTANCE",
+		keypair.NewAddedEvent(context.Background(),
+			keyPairAggregate(id),
+			usage,
+			"RS256",
+			&crypto.CryptoValue{},
+			&crypto.CryptoValue{},
+			privateKeyExpiry,
+			publicKeyExpiry,
+		),
+	)
+}
+
+func TestCommands_RotateSigningKey(t *testing.T) {
+	now := time.Now()
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		rotated bool
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "filter error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilterError(zerrors.ThrowInternal(nil, "id", "filter failed")),
+				),
+			},
+			res: res{
+				err: zerrors.IsInternal,
+			},
+		},
+		{
+			name: "successor not due",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						keyPairAddedEvent("key1", domain.KeyUsageSigning, now.Add(2*time.Hour), now.Add(26*time.Hour)),
+						// certificates have their own rotation
+						keyPairAddedEvent("key2", domain.KeyUsageSAMLCA, now.Add(-time.Hour), now.Add(-time.Hour)),
+					),
+				),
+			},
+			res: res{
+				rotated: false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:         tt.fields.eventstore(t),
+				signingKeyRotation: systemdefaults.KeyRotation{Lifetime: 6 * time.Hour, PrePublication: time.Hour, RetireAfter: 24 * time.Hour},
+			}
+			rotated, err := c.RotateSigningKey(authz.WithInstanceID(context.Background(), "INSTANCE"), "RS256", now)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			} else if !tt.res.err(err) {
+				t.Errorf("got wrong err: %v", err)
+			}
+			assert.Equal(t, tt.res.rotated, rotated)
+		})
+	}
+}
+
+func TestCommands_RevokeKeyPairs(t *testing.T) {
+	now := time.Now()
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		prePublishSuccessor bool
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "filter error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilterError(zerrors.ThrowInternal(nil, "id", "filter failed")),
+				),
+			},
+			res: res{
+				err: zerrors.IsInternal,
+			},
+		},
+		{
+			name: "no active keys",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						keyPairAddedEvent("key1", domain.KeyUsageSigning, now.Add(-2*time.Hour), now.Add(-time.Hour)),
+						keyPairAddedEvent("key2", domain.KeyUsageSigning, now.Add(time.Hour), now.Add(time.Hour)),
+						eventFromEventPusherWithInstanceID("INSTANCE",
+							keypair.NewRevokedEvent(context.Background(), keyPairAggregate("key2"), "compromised", time.Time{}),
+						),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "revoke used and published keys",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						keyPairAddedEvent("key1", domain.KeyUsageSigning, now.Add(-2*time.Hour), now.Add(-time.Hour)),
+						keyPairAddedEvent("key2", domain.KeyUsageSigning, now.Add(-time.Hour), now.Add(time.Hour)),
+						keyPairAddedEvent("key3", domain.KeyUsageSAMLCA, now.Add(time.Hour), now.Add(time.Hour)),
+					),
+					expectPush(
+						keypair.NewRevokedEvent(context.Background(), keyPairAggregate("key2"), "compromised", time.Time{}),
+						keypair.NewRevokedEvent(context.Background(), keyPairAggregate("key3"), "compromised", time.Time{}),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "revoke keys after pre-publication already revoked",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						keyPairAddedEvent("key1", domain.KeyUsageSigning, now.Add(time.Hour), now.Add(2*time.Hour)),
+						eventFromEventPusherWithInstanceID("INSTANCE",
+							keypair.NewRevokedEvent(context.Background(), keyPairAggregate("key1"), "compromised", now.Add(-time.Minute)),
+						),
+					),
+				),
+			},
+			args: args{
+				prePublishSuccessor: true,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:         tt.fields.eventstore(t),
+				signingKeyRotation: systemdefaults.KeyRotation{Lifetime: 6 * time.Hour, PrePublication: time.Hour, RetireAfter: 24 * time.Hour},
+			}
+			got, err := c.RevokeKeyPairs(authz.WithInstanceID(context.Background(), "INSTANCE"), "compromised", tt.args.prePublishSuccessor, "RS256")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			} else if !tt.res.err(err) {
+				t.Errorf("got wrong err: %v", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want.ResourceOwner, got.ResourceOwner)
+			}
+		})
+	}
+}
+
+func TestInstanceKeyPairsWriteModel_Reduce(t *testing.T) {
+	now := time.Now()
+	added := func(id string, privateKeyExpiry, publicKeyExpiry time.Time) eventstore.Event {
+		return keypair.NewAddedEvent(context.Background(), keyPairAggregate(id), domain.KeyUsageSigning, "RS256", &crypto.CryptoValue{}, &crypto.CryptoValue{}, privateKeyExpiry, publicKeyExpiry)
+	}
+	wm := NewInstanceKeyPairsWriteModel("INSTANCE")
+	wm.AppendEvents(
+		added("key1", now.Add(time.Hour), now.Add(2*time.Hour)),
+		added("key2", now.Add(3*time.Hour), now.Add(4*time.Hour)),
+		added("key3", now.Add(5*time.Hour), now.Add(6*time.Hour)),
+		keypair.NewRevokedEvent(context.Background(), keyPairAggregate("key1"), "compromised", now.Add(90*time.Minute)),
+		keypair.NewRevokedEvent(context.Background(), keyPairAggregate("key2"), "compromised", now.Add(90*time.Minute)),
+		keypair.NewRevokedEvent(context.Background(), keyPairAggregate("key3"), "compromised", time.Time{}),
+	)
+	assert.NoError(t, wm.Reduce())
+
+	if assert.Len(t, wm.KeyPairs, 2) {
+		// the earlier end of the use isn't extended by the revocation
+		assert.Equal(t, now.Add(time.Hour), wm.KeyPairs["key1"].PrivateKeyExpiry)
+		assert.Equal(t, now.Add(90*time.Minute), wm.KeyPairs["key1"].PublicKeyExpiry)
+		assert.Equal(t, now.Add(90*time.Minute), wm.KeyPairs["key2"].PrivateKeyExpiry)
+		assert.Equal(t, now.Add(90*time.Minute), wm.KeyPairs["key2"].PublicKeyExpiry)
+	}
+	assert.Equal(t, now.Add(90*time.Minute), wm.PrivateKeyExpiry(domain.KeyUsageSigning))
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/repository/authrequest"
@@ -29,13 +30,19 @@ const (
 
 // AddOIDCSessionAccessToken creates a new OIDC Session, creates an access token and returns its id and expiration.
 // If the underlying [AuthRequest] is a OIDC Auth Code Flow, it will set the code as exchanged.
-func (c *Commands) AddOIDCSessionAccessToken(ctx context.Context, authRequestID string) (string, time.Time, error) {
+// The access token gets the requested authorizationDetails, which must be part of the ones granted in the [AuthRequest].
+// If none are requested, it gets all granted details.
+func (c *Commands) AddOIDCSessionAccessToken(ctx context.Context, authRequestID string, authorizationDetails domain.AuthorizationDetails, certificateThumbprint string) (string, time.Time, error) {
 	cmd, err := c.newOIDCSessionAddEvents(ctx, authRequestID)
 	if err != nil {
 		return "", time.Time{}, err
 	}
+	authorizationDetails, err = accessTokenAuthorizationDetails(cmd.authRequestWriteModel.AuthorizationDetails, authorizationDetails)
+	if err != nil {
+		return "", time.Time{}, err
+	}
 	cmd.AddSession(ctx)
-	if err = cmd.AddAccessToken(ctx, cmd.authRequestWriteModel.Scope); err != nil {
+	if err = cmd.AddAccessToken(ctx, cmd.authRequestWriteModel.Scope, authorizationDetails, certificateThumbprint); err != nil {
 		return "", time.Time{}, err
 	}
 	cmd.SetAuthRequestSuccessful(ctx)
@@ -46,13 +53,18 @@ func (c *Commands) AddOIDCSessionAccessToken(ctx context.Context, authRequestID
 // AddOIDCSessionRefreshAndAccessToken creates a new OIDC Session, creates an access token and refresh token.
 // It returns the access token id, expiration and the refresh token.
 // If the underlying [AuthRequest] is a OIDC Auth Code Flow, it will set the code as exchanged.
-func (c *Commands) AddOIDCSessionRefreshAndAccessToken(ctx context.Context, authRequestID string) (tokenID, refreshToken string, tokenExpiration time.Time, err error) {
+// The authorizationDetails are handled the same way as in [Commands.AddOIDCSessionAccessToken].
+func (c *Commands) AddOIDCSessionRefreshAndAccessToken(ctx context.Context, authRequestID string, authorizationDetails domain.AuthorizationDetails, certificateThumbprint string) (tokenID, refreshToken string, tokenExpiration time.Time, err error) {
 	cmd, err := c.newOIDCSessionAddEvents(ctx, authRequestID)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
+	authorizationDetails, err = accessTokenAuthorizationDetails(cmd.authRequestWriteModel.AuthorizationDetails, authorizationDetails)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
 	cmd.AddSession(ctx)
-	if err = cmd.AddAccessToken(ctx, cmd.authRequestWriteModel.Scope); err != nil {
+	if err = cmd.AddAccessToken(ctx, cmd.authRequestWriteModel.Scope, authorizationDetails, certificateThumbprint); err != nil {
 		return "", "", time.Time{}, err
 	}
 	if err = cmd.AddRefreshToken(ctx); err != nil {
@@ -64,12 +76,30 @@ func (c *Commands) AddOIDCSessionRefreshAndAccessToken(ctx context.Context, auth
 
 // ExchangeOIDCSessionRefreshAndAccessToken updates an existing OIDC Session, creates a new access and refresh token.
 // It returns the access token id and expiration and the new refresh token.
-func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, oidcSessionID, refreshToken string, scope []string) (tokenID, newRefreshToken string, tokenExpiration time.Time, err error) {
-	cmd, err := c.newOIDCSessionUpdateEvents(ctx, oidcSessionID, refreshToken)
+// The new access token gets the requested authorizationDetails, which must be part of the ones granted to the session.
+// If none are requested, it gets all granted details.
+// The refresh token is rotated on every exchange. If an already rotated refresh token is presented again,
+// all tokens of the session are revoked, unless the directly preceding token is presented within the reuseGracePeriod.
+// In that case the current refresh token is returned without another rotation.
+// If the same token is exchanged concurrently, only one rotation succeeds and the other request is evaluated again.
+func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, oidcSessionID, refreshToken string, scope []string, authorizationDetails domain.AuthorizationDetails, certificateThumbprint string, reuseGracePeriod time.Duration) (tokenID, newRefreshToken string, tokenExpiration time.Time, err error) {
+	tokenID, newRefreshToken, tokenExpiration, err = c.exchangeOIDCSessionRefreshAndAccessToken(ctx, oidcSessionID, refreshToken, scope, authorizationDetails, certificateThumbprint, reuseGracePeriod)
+	if !zerrors.IsErrorAlreadyExists(err) {
+		return tokenID, newRefreshToken, tokenExpiration, err
+	}
+	return c.exchangeOIDCSessionRefreshAndAccessToken(ctx, oidcSessionID, refreshToken, scope, authorizationDetails, certificateThumbprint, reuseGracePeriod)
+}
+
+func (c *Commands) exchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, oidcSessionID, refreshToken string, scope []string, authorizationDetails domain.AuthorizationDetails, certificateThumbprint string, reuseGracePeriod time.Duration) (tokenID, newRefreshToken string, tokenExpiration time.Time, err error) {
+	cmd, err := c.newOIDCSessionUpdateEvents(ctx, oidcSessionID, refreshToken, reuseGracePeriod)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	authorizationDetails, err = accessTokenAuthorizationDetails(cmd.oidcSessionWriteModel.AuthorizationDetails, authorizationDetails)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
-	if err = cmd.AddAccessToken(ctx, scope); err != nil {
+	if err = cmd.AddAccessToken(ctx, scope, authorizationDetails, certificateThumbprint); err != nil {
 		return "", "", time.Time{}, err
 	}
 	if err = cmd.RenewRefreshToken(ctx); err != nil {
@@ -96,6 +126,35 @@ func (c *Commands) OIDCSessionByRefreshToken(ctx context.Context, refreshToken s
 	return writeModel, nil
 }
 
+// OIDCSessionForRefreshTokenGrant computes the current state of an existing OIDCSession by a refresh_token to start a Refresh Token Grant.
+// Other than [Commands.OIDCSessionByRefreshToken] it only checks that the session and its current refresh token are active.
+// The presented token itself is checked on the exchange to be able to detect the reuse of an already rotated token.
+func (c *Commands) OIDCSessionForRefreshTokenGrant(ctx context.Context, refreshToken string) (*OIDCSessionWriteModel, error) {
+	oidcSessionID, _, err := parseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	writeModel := NewOIDCSessionWriteModel(oidcSessionID, "")
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, zerrors.ThrowPreconditionFailed(err, "OIDCS-ahT4o", "Errors.OIDCSession.RefreshTokenInvalid")
+	}
+	if err = writeModel.CheckRefreshTokenActive(); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func accessTokenAuthorizationDetails(granted, requested domain.AuthorizationDetails) (domain.AuthorizationDetails, error) {
+	if len(requested) == 0 {
+		return granted, nil
+	}
+	if !granted.Contains(requested) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "OIDCS-ieB3a", "Errors.OIDCSession.AuthorizationDetailsNotGranted")
+	}
+	return requested, nil
+}
+
 func oidcSessionTokenIDsFromToken(token string) (oidcSessionID, refreshTokenID, accessTokenID string, err error) {
 	split := strings.Split(token, TokenDelimiter)
 	if len(split) != 2 {
@@ -226,7 +285,7 @@ func parseRefreshToken(refreshToken string) (oidcSessionID, refreshTokenID strin
 	return split[0], strings.Split(split[1], oidcTokenSubjectDelimiter)[0], nil
 }
 
-func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, oidcSessionID, refreshToken string) (*OIDCSessionEvents, error) {
+func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, oidcSessionID, refreshToken string, reuseGracePeriod time.Duration) (*OIDCSessionEvents, error) {
 	refreshTokenID, err := c.decryptRefreshToken(refreshToken)
 	if err != nil {
 		return nil, err
@@ -235,9 +294,22 @@ func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, oidcSessionID
 	if err = c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel); err != nil {
 		return nil, err
 	}
-	if err = sessionWriteModel.CheckRefreshToken(refreshTokenID); err != nil {
+	if err = sessionWriteModel.CheckRefreshTokenActive(); err != nil {
 		return nil, err
 	}
+	var keepRefreshToken bool
+	if sessionWriteModel.RefreshTokenID != refreshTokenID {
+		if !sessionWriteModel.isRefreshTokenWithinGracePeriod(refreshTokenID, reuseGracePeriod) {
+			if sessionWriteModel.isRefreshTokenRotated(refreshTokenID) {
+				if err = c.revokeOIDCSessionRefreshTokenFamily(ctx, sessionWriteModel); err != nil {
+					return nil, err
+				}
+				return nil, zerrors.ThrowPreconditionFailed(nil, "OIDCS-Aeph5", "Errors.User.RefreshToken.Reused")
+			}
+			return nil, zerrors.ThrowPreconditionFailed(nil, "OIDCS-Ooh9u", "Errors.OIDCSession.RefreshTokenInvalid")
+		}
+		keepRefreshToken = true
+	}
 	accessTokenLifetime, refreshTokenLifeTime, refreshTokenIdleLifetime, err := c.tokenTokenLifetimes(ctx)
 	if err != nil {
 		return nil, err
@@ -250,9 +322,22 @@ func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, oidcSessionID
 		accessTokenLifetime:      accessTokenLifetime,
 		refreshTokenLifeTime:     refreshTokenLifeTime,
 		refreshTokenIdleLifetime: refreshTokenIdleLifetime,
+		keepRefreshToken:         keepRefreshToken,
 	}, nil
 }
 
+// revokeOIDCSessionRefreshTokenFamily revokes the refresh and access token of the session
+// after an already rotated refresh token was presented again.
+// The reuse is recorded on the user, so the user is notified the same way as for other refresh tokens.
+func (c *Commands) revokeOIDCSessionRefreshTokenFamily(ctx context.Context, writeModel *OIDCSessionWriteModel) error {
+	userAgg := &user.NewAggregate(writeModel.UserID, writeModel.aggregate.ResourceOwner).Aggregate
+	_, err := c.eventstore.Push(ctx,
+		user.NewHumanRefreshTokenReuseDetectedEvent(ctx, userAgg, writeModel.AggregateID, writeModel.ClientID, ""),
+		oidcsession.NewRefreshTokenRevokedEvent(ctx, writeModel.aggregate),
+	)
+	return err
+}
+
 type OIDCSessionEvents struct {
 	eventstore               *eventstore.Eventstore
 	idGenerator              id.Generator
@@ -264,6 +349,8 @@ type OIDCSessionEvents struct {
 	accessTokenLifetime      time.Duration
 	refreshTokenLifeTime     time.Duration
 	refreshTokenIdleLifetime time.Duration
+	// keepRefreshToken is set if the preceding refresh token was presented within the reuse grace period
+	keepRefreshToken bool
 
 	// accessTokenID is set by the command
 	accessTokenID string
@@ -283,6 +370,7 @@ func (c *OIDCSessionEvents) AddSession(ctx context.Context) {
 		c.authRequestWriteModel.Scope,
 		c.sessionWriteModel.AuthMethodTypes(),
 		c.sessionWriteModel.AuthenticationTime(),
+		c.authRequestWriteModel.AuthorizationDetails,
 	))
 }
 
@@ -290,13 +378,13 @@ func (c *OIDCSessionEvents) SetAuthRequestSuccessful(ctx context.Context) {
 	c.events = append(c.events, authrequest.NewSucceededEvent(ctx, c.authRequestWriteModel.aggregate))
 }
 
-func (c *OIDCSessionEvents) AddAccessToken(ctx context.Context, scope []string) error {
+func (c *OIDCSessionEvents) AddAccessToken(ctx context.Context, scope []string, authorizationDetails domain.AuthorizationDetails, certificateThumbprint string) error {
 	accessTokenID, err := c.idGenerator.Next()
 	if err != nil {
 		return err
 	}
 	c.accessTokenID = AccessTokenPrefix + accessTokenID
-	c.events = append(c.events, oidcsession.NewAccessTokenAddedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.accessTokenID, scope, c.accessTokenLifetime))
+	c.events = append(c.events, oidcsession.NewAccessTokenAddedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.accessTokenID, scope, c.accessTokenLifetime, authorizationDetails, certificateThumbprint))
 	return nil
 }
 
@@ -311,12 +399,18 @@ func (c *OIDCSessionEvents) AddRefreshToken(ctx context.Context) (err error) {
 }
 
 func (c *OIDCSessionEvents) RenewRefreshToken(ctx context.Context) (err error) {
+	// the refresh token is not rotated again if it was requested concurrently within the grace period
+	if c.keepRefreshToken {
+		c.refreshToken, err = c.encryptRefreshToken(c.oidcSessionWriteModel.RefreshTokenID, c.oidcSessionWriteModel.UserID)
+		return err
+	}
 	var refreshTokenID string
 	refreshTokenID, c.refreshToken, err = c.generateRefreshToken(c.oidcSessionWriteModel.UserID)
 	if err != nil {
 		return err
 	}
-	c.events = append(c.events, oidcsession.NewRefreshTokenRenewedEvent(ctx, c.oidcSessionWriteModel.aggregate, refreshTokenID, c.refreshTokenIdleLifetime))
+	c.events = append(c.events, oidcsession.NewRefreshTokenRenewedEvent(ctx, c.oidcSessionWriteModel.aggregate, refreshTokenID, c.refreshTokenIdleLifetime,
+		c.oidcSessionWriteModel.RefreshTokenID, c.oidcSessionWriteModel.PreviousRefreshTokenID))
 	return nil
 }
 
@@ -326,11 +420,19 @@ func (c *OIDCSessionEvents) generateRefreshToken(userID string) (refreshTokenID,
 		return "", "", err
 	}
 	refreshTokenID = RefreshTokenPrefix + refreshTokenID
-	token, err := c.encryptionAlg.Encrypt([]byte(fmt.Sprintf(oidcTokenFormat, c.oidcSessionWriteModel.OIDCRefreshTokenID(refreshTokenID), userID)))
+	refreshToken, err = c.encryptRefreshToken(refreshTokenID, userID)
 	if err != nil {
 		return "", "", err
 	}
-	return refreshTokenID, base64.RawURLEncoding.EncodeToString(token), nil
+	return refreshTokenID, refreshToken, nil
+}
+
+func (c *OIDCSessionEvents) encryptRefreshToken(refreshTokenID, userID string) (string, error) {
+	token, err := c.encryptionAlg.Encrypt([]byte(fmt.Sprintf(oidcTokenFormat, c.oidcSessionWriteModel.OIDCRefreshTokenID(refreshTokenID), userID)))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
 }
 
 func (c *OIDCSessionEvents) PushEvents(ctx context.Context) (accessTokenID string, refreshToken string, accessTokenExpiration time.Time, err error) {
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"slices"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -19,6 +20,7 @@ type OIDCSessionWriteModel struct {
 	Scope                      []string
 	AuthMethods                []domain.UserAuthMethodType
 	AuthTime                   time.Time
+	AuthorizationDetails       domain.AuthorizationDetails
 	State                      domain.OIDCSessionState
 	AccessTokenID              string
 	AccessTokenCreation        time.Time
@@ -28,6 +30,13 @@ type OIDCSessionWriteModel struct {
 	RefreshTokenExpiration     time.Time
 	RefreshTokenIdleExpiration time.Time
 
+	// PreviousRefreshTokenID and RefreshTokenRenewedAt describe the last rotation
+	// and are used to tolerate concurrent refresh requests within the grace period.
+	PreviousRefreshTokenID string
+	RefreshTokenRenewedAt  time.Time
+	// RotatedRefreshTokenIDs contains all refresh tokens which were replaced by a rotation.
+	RotatedRefreshTokenIDs []string
+
 	aggregate *eventstore.Aggregate
 }
 
@@ -89,6 +98,7 @@ func (wm *OIDCSessionWriteModel) reduceAdded(e *oidcsession.AddedEvent) {
 	wm.Scope = e.Scope
 	wm.AuthMethods = e.AuthMethods
 	wm.AuthTime = e.AuthTime
+	wm.AuthorizationDetails = e.AuthorizationDetails
 	wm.State = domain.OIDCSessionStateActive
 	// the write model might be initialized without resource owner,
 	// so update the aggregate
@@ -114,6 +124,9 @@ func (wm *OIDCSessionWriteModel) reduceRefreshTokenAdded(e *oidcsession.RefreshT
 }
 
 func (wm *OIDCSessionWriteModel) reduceRefreshTokenRenewed(e *oidcsession.RefreshTokenRenewedEvent) {
+	wm.PreviousRefreshTokenID = wm.RefreshTokenID
+	wm.RotatedRefreshTokenIDs = append(wm.RotatedRefreshTokenIDs, wm.RefreshTokenID)
+	wm.RefreshTokenRenewedAt = e.CreationDate()
 	wm.RefreshTokenID = e.ID
 	wm.RefreshTokenIdleExpiration = e.CreationDate().Add(e.IdleLifetime)
 }
@@ -140,6 +153,35 @@ func (wm *OIDCSessionWriteModel) CheckRefreshToken(refreshTokenID string) error
 	return nil
 }
 
+// CheckRefreshTokenActive checks if the session and its current refresh token are active,
+// without checking the presented token itself.
+// This allows the renewal to detect the reuse of an already rotated refresh token.
+func (wm *OIDCSessionWriteModel) CheckRefreshTokenActive() error {
+	if wm.State != domain.OIDCSessionStateActive || wm.RefreshTokenID == "" {
+		return zerrors.ThrowPreconditionFailed(nil, "OIDCS-Iek1a", "Errors.OIDCSession.RefreshTokenInvalid")
+	}
+	now := time.Now()
+	if wm.RefreshTokenExpiration.Before(now) || wm.RefreshTokenIdleExpiration.Before(now) {
+		return zerrors.ThrowPreconditionFailed(nil, "OIDCS-ohW5e", "Errors.OIDCSession.RefreshTokenInvalid")
+	}
+	return nil
+}
+
+// isRefreshTokenRotated checks if the refresh token was already replaced by a rotation.
+func (wm *OIDCSessionWriteModel) isRefreshTokenRotated(refreshTokenID string) bool {
+	return slices.Contains(wm.RotatedRefreshTokenIDs, refreshTokenID)
+}
+
+// isRefreshTokenWithinGracePeriod checks if the refresh token is the one replaced by the last rotation
+// and the rotation happened at most gracePeriod ago.
+// The session is bound to a single client, which is checked on the token request.
+func (wm *OIDCSessionWriteModel) isRefreshTokenWithinGracePeriod(refreshTokenID string, gracePeriod time.Duration) bool {
+	return gracePeriod > 0 &&
+		wm.PreviousRefreshTokenID != "" &&
+		wm.PreviousRefreshTokenID == refreshTokenID &&
+		time.Since(wm.RefreshTokenRenewedAt) <= gracePeriod
+}
+
 func (wm *OIDCSessionWriteModel) CheckAccessToken(accessTokenID string) error {
 	if wm.State != domain.OIDCSessionStateActive {
 		return zerrors.ThrowPreconditionFailed(nil, "OIDCS-KL2pk", "Errors.OIDCSession.Token.Invalid")
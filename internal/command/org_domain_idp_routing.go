@@ -0,0 +1,129 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// orgDomainIDPRoutingsFunc returns the routings of the organization by their lower-cased domain.
+type orgDomainIDPRoutingsFunc func(ctx context.Context, orgID string) (map[string]*domain.OrgDomainIDPRouting, error)
+
+// SetOrgDomainIDPRouting routes the users with a login name of the verified domain to the identity providers on login.
+// The providers must either belong to the organization or the instance.
+func (c *Commands) SetOrgDomainIDPRouting(ctx context.Context, orgID, orgDomain string, routing *domain.OrgDomainIDPRouting) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohch9", "Errors.ResourceOwnerMissing")
+	}
+	if orgDomain == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ieV3a", "Errors.Org.DomainMissing")
+	}
+	if routing == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Bai7e", "Errors.Org.Domain.IDPRouting.Invalid")
+	}
+	if err = routing.Validate(); err != nil {
+		return nil, err
+	}
+	domainWriteModel, err := c.getOrgDomainWriteModel(ctx, orgID, orgDomain)
+	if err != nil {
+		return nil, err
+	}
+	if domainWriteModel.State != domain.OrgDomainStateActive {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ro3ee", "Errors.Org.DomainNotOnOrg")
+	}
+	if !domainWriteModel.Verified {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-aeW7u", "Errors.Org.DomainNotVerified")
+	}
+	resourceOwners := []string{orgID, authz.GetInstance(ctx).InstanceID()}
+	for _, id := range routing.IDPIDs {
+		typeWriteModel := NewIDPTypeWriteModel(id)
+		if err = c.eventstore.FilterToQueryReducer(ctx, typeWriteModel); err != nil {
+			return nil, err
+		}
+		if !typeWriteModel.State.Exists() || !slices.Contains(resourceOwners, typeWriteModel.ResourceOwner) {
+			return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Eet4i", "Errors.IDPConfig.NotExisting")
+		}
+	}
+
+	writeModel := NewOrgDomainIDPRoutingWriteModel(orgID, orgDomain)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if writeModel.Routing != nil && writeModel.Routing.Force == routing.Force && slices.Equal(writeModel.Routing.IDPIDs, routing.IDPIDs) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewDomainIDPRoutingSetEvent(ctx, &org.NewAggregate(orgID).Aggregate, orgDomain, *routing))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveOrgDomainIDPRouting removes the routing of the domain, so its users are asked for their login method again.
+func (c *Commands) RemoveOrgDomainIDPRouting(ctx context.Context, orgID, orgDomain string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ooL4i", "Errors.ResourceOwnerMissing")
+	}
+	if orgDomain == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Oog5a", "Errors.Org.DomainMissing")
+	}
+	writeModel := NewOrgDomainIDPRoutingWriteModel(orgID, orgDomain)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if writeModel.Routing == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Aiw0u", "Errors.Org.Domain.IDPRouting.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewDomainIDPRoutingRemovedEvent(ctx, &org.NewAggregate(orgID).Aggregate, orgDomain))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// checkPasswordAllowedByIDPRouting returns an error if the user logs in with a domain of its organization,
+// which is routed to identity providers with the force option, so the user must not authenticate with a password.
+// The domain of the user name is used, if the organization does not require the login names to be suffixed with its domains.
+func checkPasswordAllowedByIDPRouting(ctx context.Context, filter preparation.FilterToQueryReducer, orgDomainIDPRoutings orgDomainIDPRoutingsFunc, orgID, userName string) error {
+	if orgDomainIDPRoutings == nil {
+		return nil
+	}
+	routings, err := orgDomainIDPRoutings(ctx, orgID)
+	if err != nil || len(routings) == 0 {
+		return err
+	}
+	if routing := routings[domain.LoginNameDomain(userName)]; routing != nil && routing.Force {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Shoo3", "Errors.Org.Domain.IDPRouting.PasswordNotAllowed")
+	}
+	domainPolicy, err := domainPolicyWriteModel(ctx, filter, orgID)
+	if err != nil {
+		return err
+	}
+	if !domainPolicy.UserLoginMustBeDomain {
+		return nil
+	}
+	for _, routing := range routings {
+		if routing.Force {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-ees8O", "Errors.Org.Domain.IDPRouting.PasswordNotAllowed")
+		}
+	}
+	return nil
+}
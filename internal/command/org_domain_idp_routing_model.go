@@ -0,0 +1,75 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgDomainIDPRoutingWriteModel struct {
+	eventstore.WriteModel
+
+	Domain  string
+	Routing *domain.OrgDomainIDPRouting
+}
+
+func NewOrgDomainIDPRoutingWriteModel(orgID, orgDomain string) *OrgDomainIDPRoutingWriteModel {
+	return &OrgDomainIDPRoutingWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+		Domain: orgDomain,
+	}
+}
+
+func (wm *OrgDomainIDPRoutingWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.DomainIDPRoutingSetEvent:
+			if e.Domain != wm.Domain {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.DomainIDPRoutingRemovedEvent:
+			if e.Domain != wm.Domain {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.DomainRemovedEvent:
+			if e.Domain != wm.Domain {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgDomainIDPRoutingWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.DomainIDPRoutingSetEvent:
+			routing := e.OrgDomainIDPRouting
+			wm.Routing = &routing
+		case *org.DomainIDPRoutingRemovedEvent,
+			*org.DomainRemovedEvent:
+			wm.Routing = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgDomainIDPRoutingWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.OrgDomainIDPRoutingSetEventType,
+			org.OrgDomainIDPRoutingRemovedEventType,
+			org.OrgDomainRemovedEventType,
+		).
+		EventData(map[string]interface{}{"domain": wm.Domain}).
+		Builder()
+}
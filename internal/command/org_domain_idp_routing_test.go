@@ -0,0 +1,456 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetOrgDomainIDPRouting(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx       context.Context
+		orgID     string
+		orgDomain string
+		routing   *domain.OrgDomainIDPRouting
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	routing := &domain.OrgDomainIDPRouting{
+		IDPIDs: []string{"id1"},
+		Force:  true,
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing domain",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:   "org1",
+				routing: routing,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-ieV3a", ""))
+				},
+			},
+		},
+		{
+			name: "missing providers",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:     "org1",
+				orgDomain: "partner.com",
+				routing:   &domain.OrgDomainIDPRouting{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "DOMAIN-ohX3u", ""))
+				},
+			},
+		},
+		{
+			name: "domain not existing",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:     "org1",
+				orgDomain: "partner.com",
+				routing:   routing,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Ro3ee", ""))
+				},
+			},
+		},
+		{
+			name: "domain not verified",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:     "org1",
+				orgDomain: "partner.com",
+				routing:   routing,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "COMMAND-aeW7u", ""))
+				},
+			},
+		},
+		{
+			name: "idp not existing",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com"),
+						),
+						eventFromEventPusher(
+							org.NewDomainVerifiedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com"),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:     "org1",
+				orgDomain: "partner.com",
+				routing:   routing,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Eet4i", ""))
+				},
+			},
+		},
+		{
+			name: "idp of other organization",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainAddedEvent(context.Background(), &org.NewAggregate("org2").Aggregate, "partner.com"),
+						),
+						eventFromEventPusher(
+							org.NewDomainVerifiedEvent(context.Background(), &org.NewAggregate("org2").Aggregate, "partner.com"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(orgGitHubIDPAddedEvent()),
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:     "org2",
+				orgDomain: "partner.com",
+				routing:   routing,
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Eet4i", ""))
+				},
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com"),
+						),
+						eventFromEventPusher(
+							org.NewDomainVerifiedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainIDPRoutingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com", *routing),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:     "org1",
+				orgDomain: "partner.com",
+				routing:   routing,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "set ok, idp of organization",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com"),
+						),
+						eventFromEventPusher(
+							org.NewDomainVerifiedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(orgGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainIDPRoutingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com",
+								domain.OrgDomainIDPRouting{IDPIDs: []string{"id1"}},
+							),
+						),
+					),
+					expectPush(
+						org.NewDomainIDPRoutingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com", *routing),
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:     "org1",
+				orgDomain: "partner.com",
+				routing:   routing,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetOrgDomainIDPRouting(tt.args.ctx, tt.args.orgID, tt.args.orgDomain, tt.args.routing)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveOrgDomainIDPRouting(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx       context.Context
+		orgID     string
+		orgDomain string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:     "org1",
+				orgDomain: "partner.com",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Aiw0u", ""))
+				},
+			},
+		},
+		{
+			name: "domain removed, not found",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainIDPRoutingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com",
+								domain.OrgDomainIDPRouting{IDPIDs: []string{"id1"}},
+							),
+						),
+						eventFromEventPusher(
+							org.NewDomainRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com", true),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:     "org1",
+				orgDomain: "partner.com",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Aiw0u", ""))
+				},
+			},
+		},
+		{
+			name: "remove ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainIDPRoutingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com",
+								domain.OrgDomainIDPRouting{IDPIDs: []string{"id1"}},
+							),
+						),
+					),
+					expectPush(
+						org.NewDomainIDPRoutingRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "partner.com"),
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:     "org1",
+				orgDomain: "partner.com",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.RemoveOrgDomainIDPRouting(tt.args.ctx, tt.args.orgID, tt.args.orgDomain)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func Test_checkPasswordAllowedByIDPRouting(t *testing.T) {
+	routings := func(routings map[string]*domain.OrgDomainIDPRouting) orgDomainIDPRoutingsFunc {
+		return func(_ context.Context, orgID string) (map[string]*domain.OrgDomainIDPRouting, error) {
+			if orgID != "org1" {
+				return nil, nil
+			}
+			return routings, nil
+		}
+	}
+	domainPolicy := func(userLoginMustBeDomain bool) expect {
+		return expectFilter(
+			eventFromEventPusher(
+				org.NewDomainPolicyAddedEvent(context.Background(),
+					&org.NewAggregate("org1").Aggregate,
+					userLoginMustBeDomain,
+					false,
+					false,
+				),
+			),
+		)
+	}
+	tests := []struct {
+		name                 string
+		eventstore           func(*testing.T) *eventstore.Eventstore
+		orgDomainIDPRoutings orgDomainIDPRoutingsFunc
+		userName             string
+		wantErr              error
+	}{
+		{
+			name:       "no routings",
+			eventstore: expectEventstore(),
+			userName:   "alice@partner.com",
+		},
+		{
+			name:                 "no routing of organization",
+			eventstore:           expectEventstore(),
+			orgDomainIDPRoutings: routings(nil),
+			userName:             "alice@partner.com",
+		},
+		{
+			name: "routing not forced",
+			eventstore: expectEventstore(
+				domainPolicy(true),
+			),
+			orgDomainIDPRoutings: routings(map[string]*domain.OrgDomainIDPRouting{
+				"partner.com": {IDPIDs: []string{"id1"}},
+			}),
+			userName: "alice@partner.com",
+		},
+		{
+			name: "forced routing of other domain",
+			eventstore: expectEventstore(
+				domainPolicy(false),
+			),
+			orgDomainIDPRoutings: routings(map[string]*domain.OrgDomainIDPRouting{
+				"partner.com": {IDPIDs: []string{"id1"}, Force: true},
+			}),
+			userName: "alice@example.com",
+		},
+		{
+			name:       "forced routing of user name domain",
+			eventstore: expectEventstore(),
+			orgDomainIDPRoutings: routings(map[string]*domain.OrgDomainIDPRouting{
+				"partner.com": {IDPIDs: []string{"id1"}, Force: true},
+			}),
+			userName: "Alice@Partner.com",
+			wantErr:  zerrors.ThrowPreconditionFailed(nil, "COMMAND-Shoo3", "Errors.Org.Domain.IDPRouting.PasswordNotAllowed"),
+		},
+		{
+			name: "forced routing of organization domain suffixing the login name",
+			eventstore: expectEventstore(
+				domainPolicy(true),
+			),
+			orgDomainIDPRoutings: routings(map[string]*domain.OrgDomainIDPRouting{
+				"partner.com": {IDPIDs: []string{"id1"}, Force: true},
+			}),
+			userName: "alice",
+			wantErr:  zerrors.ThrowPreconditionFailed(nil, "COMMAND-ees8O", "Errors.Org.Domain.IDPRouting.PasswordNotAllowed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPasswordAllowedByIDPRouting(context.Background(), tt.eventstore(t).Filter, tt.orgDomainIDPRoutings, "org1", tt.userName)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -439,6 +439,270 @@ func (wm *OrgGitHubIDPWriteModel) NewChangedEvent(
 	return org.NewGitHubIDPChangedEvent(ctx, aggregate, id, changes)
 }
 
+type OrgBitbucketIDPWriteModel struct {
+	BitbucketIDPWriteModel
+}
+
+func NewBitbucketOrgIDPWriteModel(orgID, id string) *OrgBitbucketIDPWriteModel {
+	return &OrgBitbucketIDPWriteModel{
+		BitbucketIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgBitbucketIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.BitbucketIDPAddedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.BitbucketIDPAddedEvent)
+		case *org.BitbucketIDPChangedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.BitbucketIDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.BitbucketIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgBitbucketIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.BitbucketIDPAddedEventType,
+			org.BitbucketIDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgBitbucketIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) (*org.BitbucketIDPChangedEvent, error) {
+
+	changes, err := wm.BitbucketIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return org.NewBitbucketIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type OrgSlackIDPWriteModel struct {
+	SlackIDPWriteModel
+}
+
+func NewSlackOrgIDPWriteModel(orgID, id string) *OrgSlackIDPWriteModel {
+	return &OrgSlackIDPWriteModel{
+		SlackIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgSlackIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.SlackIDPAddedEvent:
+			wm.SlackIDPWriteModel.AppendEvents(&e.SlackIDPAddedEvent)
+		case *org.SlackIDPChangedEvent:
+			wm.SlackIDPWriteModel.AppendEvents(&e.SlackIDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.SlackIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.SlackIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgSlackIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.SlackIDPAddedEventType,
+			org.SlackIDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgSlackIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) (*org.SlackIDPChangedEvent, error) {
+
+	changes, err := wm.SlackIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return org.NewSlackIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type OrgDiscordIDPWriteModel struct {
+	DiscordIDPWriteModel
+}
+
+func NewDiscordOrgIDPWriteModel(orgID, id string) *OrgDiscordIDPWriteModel {
+	return &OrgDiscordIDPWriteModel{
+		DiscordIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgDiscordIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.DiscordIDPAddedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.DiscordIDPAddedEvent)
+		case *org.DiscordIDPChangedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.DiscordIDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.DiscordIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgDiscordIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.DiscordIDPAddedEventType,
+			org.DiscordIDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgDiscordIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) (*org.DiscordIDPChangedEvent, error) {
+
+	changes, err := wm.DiscordIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return org.NewDiscordIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type OrgLinkedInIDPWriteModel struct {
+	LinkedInIDPWriteModel
+}
+
+func NewLinkedInOrgIDPWriteModel(orgID, id string) *OrgLinkedInIDPWriteModel {
+	return &OrgLinkedInIDPWriteModel{
+		LinkedInIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgLinkedInIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.LinkedInIDPAddedEvent:
+			wm.LinkedInIDPWriteModel.AppendEvents(&e.LinkedInIDPAddedEvent)
+		case *org.LinkedInIDPChangedEvent:
+			wm.LinkedInIDPWriteModel.AppendEvents(&e.LinkedInIDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.LinkedInIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.LinkedInIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgLinkedInIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.LinkedInIDPAddedEventType,
+			org.LinkedInIDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgLinkedInIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.Crypto,
+	scopes []string,
+	options idp.Options,
+) (*org.LinkedInIDPChangedEvent, error) {
+
+	changes, err := wm.LinkedInIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return org.NewLinkedInIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
 type OrgGitHubEnterpriseIDPWriteModel struct {
 	GitHubEnterpriseIDPWriteModel
 }
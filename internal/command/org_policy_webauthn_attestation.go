@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetOrgWebAuthNAttestationPolicy replaces the restrictions of authenticators users of the organization are able to register.
+// The policy overrides the default policy of the instance.
+func (c *Commands) SetOrgWebAuthNAttestationPolicy(ctx context.Context, resourceOwner string, policy *domain.WebAuthNAttestationPolicy) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "Org-Ueth4", "Errors.ResourceOwnerMissing")
+	}
+	if policy == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "Org-ahC3o", "Errors.Policy.WebAuthNAttestation.Invalid")
+	}
+	if err = policy.Normalize(); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.orgWebAuthNAttestationPolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State == domain.PolicyStateActive && reflect.DeepEqual(writeModel.Policy, *policy) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx,
+		org.NewWebAuthNAttestationPolicySetEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, *policy),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveOrgWebAuthNAttestationPolicy removes the custom policy of the organization,
+// so the default policy of the instance applies again.
+func (c *Commands) RemoveOrgWebAuthNAttestationPolicy(ctx context.Context, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "Org-ieP9u", "Errors.ResourceOwnerMissing")
+	}
+	writeModel, err := c.orgWebAuthNAttestationPolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.PolicyStateActive {
+		return nil, zerrors.ThrowNotFound(nil, "Org-Jei6o", "Errors.Org.WebAuthNAttestationPolicy.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx,
+		org.NewWebAuthNAttestationPolicyRemovedEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// getOrgWebAuthNAttestationPolicy returns the custom policy of the organization or the default policy of the instance.
+func (c *Commands) getOrgWebAuthNAttestationPolicy(ctx context.Context, orgID string) (*domain.WebAuthNAttestationPolicy, error) {
+	writeModel, err := c.orgWebAuthNAttestationPolicyWriteModelByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State == domain.PolicyStateActive {
+		return &writeModel.Policy, nil
+	}
+	return c.getDefaultWebAuthNAttestationPolicy(ctx)
+}
+
+func (c *Commands) orgWebAuthNAttestationPolicyWriteModelByID(ctx context.Context, orgID string) (*OrgWebAuthNAttestationPolicyWriteModel, error) {
+	writeModel := NewOrgWebAuthNAttestationPolicyWriteModel(orgID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
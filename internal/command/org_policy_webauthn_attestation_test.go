@@ -0,0 +1,312 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetOrgWebAuthNAttestationPolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		policy        *domain.WebAuthNAttestationPolicy
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resourceowner",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:    context.Background(),
+				policy: &domain.WebAuthNAttestationPolicy{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "Org-Ueth4", ""))
+				},
+			},
+		},
+		{
+			name: "missing policy",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "Org-ahC3o", ""))
+				},
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewWebAuthNAttestationPolicySetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								domain.WebAuthNAttestationPolicy{RequireMetadata: true},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				policy:        &domain.WebAuthNAttestationPolicy{RequireMetadata: true},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "set after removal",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewWebAuthNAttestationPolicySetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								domain.WebAuthNAttestationPolicy{},
+							),
+						),
+						eventFromEventPusher(
+							org.NewWebAuthNAttestationPolicyRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate),
+						),
+					),
+					expectPush(
+						org.NewWebAuthNAttestationPolicySetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							domain.WebAuthNAttestationPolicy{},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				policy:        &domain.WebAuthNAttestationPolicy{},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectPush(
+						org.NewWebAuthNAttestationPolicySetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							domain.WebAuthNAttestationPolicy{
+								RequireAttestation: true,
+								AllowedAAGUIDs:     []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				policy: &domain.WebAuthNAttestationPolicy{
+					RequireAttestation: true,
+					AllowedAAGUIDs:     []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.SetOrgWebAuthNAttestationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.policy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveOrgWebAuthNAttestationPolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resourceowner",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "Org-ieP9u", ""))
+				},
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "Org-Jei6o", ""))
+				},
+			},
+		},
+		{
+			name: "remove ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewWebAuthNAttestationPolicySetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								domain.WebAuthNAttestationPolicy{RequireAttestation: true},
+							),
+						),
+					),
+					expectPush(
+						org.NewWebAuthNAttestationPolicyRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.RemoveOrgWebAuthNAttestationPolicy(tt.args.ctx, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_getOrgWebAuthNAttestationPolicy(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		want       *domain.WebAuthNAttestationPolicy
+	}{
+		{
+			name: "custom policy",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						org.NewWebAuthNAttestationPolicySetEvent(ctx, &org.NewAggregate("org1").Aggregate,
+							domain.WebAuthNAttestationPolicy{RequireMetadata: true},
+						),
+					),
+				),
+			),
+			want: &domain.WebAuthNAttestationPolicy{RequireMetadata: true},
+		},
+		{
+			name: "default policy",
+			eventstore: expectEventstore(
+				expectFilter(),
+				expectFilter(
+					eventFromEventPusher(
+						instance.NewWebAuthNAttestationPolicySetEvent(ctx, &instance.NewAggregate("instance1").Aggregate,
+							domain.WebAuthNAttestationPolicy{RequireAttestation: true},
+						),
+					),
+				),
+			),
+			want: &domain.WebAuthNAttestationPolicy{RequireAttestation: true},
+		},
+		{
+			name: "no policy",
+			eventstore: expectEventstore(
+				expectFilter(),
+				expectFilter(),
+			),
+			want: &domain.WebAuthNAttestationPolicy{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.getOrgWebAuthNAttestationPolicy(ctx, "org1")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type WebAuthNAttestationPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	Policy domain.WebAuthNAttestationPolicy
+	State  domain.PolicyState
+}
+
+func (wm *WebAuthNAttestationPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.WebAuthNAttestationPolicySetEvent:
+			wm.Policy = e.WebAuthNAttestationPolicy
+			wm.State = domain.PolicyStateActive
+		case *policy.WebAuthNAttestationPolicyRemovedEvent:
+			wm.Policy = domain.WebAuthNAttestationPolicy{}
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+type InstanceWebAuthNAttestationPolicyWriteModel struct {
+	WebAuthNAttestationPolicyWriteModel
+}
+
+func NewInstanceWebAuthNAttestationPolicyWriteModel(instanceID string) *InstanceWebAuthNAttestationPolicyWriteModel {
+	return &InstanceWebAuthNAttestationPolicyWriteModel{
+		WebAuthNAttestationPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+		},
+	}
+}
+
+func (wm *InstanceWebAuthNAttestationPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.WebAuthNAttestationPolicySetEvent:
+			wm.WebAuthNAttestationPolicyWriteModel.AppendEvents(&e.WebAuthNAttestationPolicySetEvent)
+		}
+	}
+}
+
+func (wm *InstanceWebAuthNAttestationPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.WebAuthNAttestationPolicySetEventType,
+		).
+		Builder()
+}
+
+type OrgWebAuthNAttestationPolicyWriteModel struct {
+	WebAuthNAttestationPolicyWriteModel
+}
+
+func NewOrgWebAuthNAttestationPolicyWriteModel(orgID string) *OrgWebAuthNAttestationPolicyWriteModel {
+	return &OrgWebAuthNAttestationPolicyWriteModel{
+		WebAuthNAttestationPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+		},
+	}
+}
+
+func (wm *OrgWebAuthNAttestationPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.WebAuthNAttestationPolicySetEvent:
+			wm.WebAuthNAttestationPolicyWriteModel.AppendEvents(&e.WebAuthNAttestationPolicySetEvent)
+		case *org.WebAuthNAttestationPolicyRemovedEvent:
+			wm.WebAuthNAttestationPolicyWriteModel.AppendEvents(&e.WebAuthNAttestationPolicyRemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgWebAuthNAttestationPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.WebAuthNAttestationPolicySetEventType,
+			org.WebAuthNAttestationPolicyRemovedEventType,
+		).
+		Builder()
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetApplicationClaimMappings replaces the claim mappings of an OIDC or SAML application.
+// Passing no mappings removes all existing ones.
+func (c *Commands) SetApplicationClaimMappings(ctx context.Context, projectID, appID string, claimMappings []*domain.ClaimMapping, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" || appID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahz3o", "Errors.IDMissing")
+	}
+	if err = domain.ValidateClaimMappings(claimMappings); err != nil {
+		return nil, err
+	}
+	existing, err := c.getApplicationClaimMappingsWriteModel(ctx, projectID, appID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State == domain.AppStateUnspecified || existing.State == domain.AppStateRemoved {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Xei8u", "Errors.Project.App.NotExisting")
+	}
+	if !existing.supportsClaimMappings {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ieh9o", "Errors.Project.App.ClaimMapping.NotSupported")
+	}
+	if len(existing.ClaimMappings) == len(claimMappings) && (len(claimMappings) == 0 || reflect.DeepEqual(existing.ClaimMappings, claimMappings)) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-ooL4e", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewApplicationClaimMappingsSetEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&existing.WriteModel),
+		appID,
+		claimMappings,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(existing, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) getApplicationClaimMappingsWriteModel(ctx context.Context, projectID, appID, resourceOwner string) (*ApplicationClaimMappingsWriteModel, error) {
+	writeModel := NewApplicationClaimMappingsWriteModel(projectID, appID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
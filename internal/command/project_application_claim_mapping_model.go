@@ -0,0 +1,100 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type ApplicationClaimMappingsWriteModel struct {
+	eventstore.WriteModel
+
+	AppID         string
+	State         domain.AppState
+	ClaimMappings []*domain.ClaimMapping
+
+	// claim mappings are only supported by OIDC and SAML applications
+	supportsClaimMappings bool
+}
+
+func NewApplicationClaimMappingsWriteModel(projectID, appID, resourceOwner string) *ApplicationClaimMappingsWriteModel {
+	return &ApplicationClaimMappingsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		AppID: appID,
+	}
+}
+
+func (wm *ApplicationClaimMappingsWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.OIDCConfigAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.SAMLConfigAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationClaimMappingsSetEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *ApplicationClaimMappingsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			wm.State = domain.AppStateActive
+		case *project.ApplicationRemovedEvent:
+			wm.State = domain.AppStateRemoved
+			wm.ClaimMappings = nil
+		case *project.OIDCConfigAddedEvent:
+			wm.supportsClaimMappings = true
+		case *project.SAMLConfigAddedEvent:
+			wm.supportsClaimMappings = true
+		case *project.ApplicationClaimMappingsSetEvent:
+			wm.ClaimMappings = e.ClaimMappings
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.AppStateRemoved
+			wm.ClaimMappings = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ApplicationClaimMappingsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ApplicationAddedType,
+			project.ApplicationRemovedType,
+			project.OIDCConfigAddedType,
+			project.SAMLConfigAddedType,
+			project.ApplicationClaimMappingsSetType,
+			project.ProjectRemovedType).
+		Builder()
+}
@@ -106,12 +106,12 @@ func (wm *ApplicationKeyWriteModel) Reduce() error {
 
 func (wm *ApplicationKeyWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAddedEvent) {
 	wm.ClientID = e.ClientID
-	wm.KeysAllowed = e.AuthMethodType == domain.OIDCAuthMethodTypePrivateKeyJWT
+	wm.KeysAllowed = e.AuthMethodType.KeysAllowed()
 }
 
 func (wm *ApplicationKeyWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
 	if e.AuthMethodType != nil {
-		wm.KeysAllowed = *e.AuthMethodType == domain.OIDCAuthMethodTypePrivateKeyJWT
+		wm.KeysAllowed = e.AuthMethodType.KeysAllowed()
 	}
 }
 
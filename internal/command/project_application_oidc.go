@@ -34,6 +34,10 @@ type addOIDCApp struct {
 	ClockSkew                   time.Duration
 	AdditionalOrigins           []string
 	SkipSuccessPageForNativeApp bool
+	ConsentRequired             bool
+	TLSClientAuthSubjectDN      string
+	// RefreshTokenReuseGracePeriod overrides the default grace period if set
+	RefreshTokenReuseGracePeriod time.Duration
 
 	ClientID          string
 	ClientSecret      *crypto.CryptoValue
@@ -55,6 +59,10 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 			return nil, zerrors.ThrowInvalidArgument(nil, "V2-PnCMS", "Errors.Invalid.Argument")
 		}
 
+		if !domain.RefreshTokenReuseGracePeriodValid(app.RefreshTokenReuseGracePeriod) {
+			return nil, zerrors.ThrowInvalidArgument(nil, "V2-eiG4a", "Errors.Invalid.Argument")
+		}
+
 		for _, origin := range app.AdditionalOrigins {
 			if !http_util.IsOrigin(strings.TrimSpace(origin)) {
 				return nil, zerrors.ThrowInvalidArgument(nil, "V2-DqWPX", "Errors.Invalid.Argument")
@@ -65,6 +73,10 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 			return nil, zerrors.ThrowInvalidArgument(nil, "V2-sLpW1", "Errors.Invalid.Argument")
 		}
 
+		if app.AuthMethodType == domain.OIDCAuthMethodTypeTLSClientAuth && strings.TrimSpace(app.TLSClientAuthSubjectDN) == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "V2-uo7Ai", "Errors.Invalid.Argument")
+		}
+
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) (_ []eventstore.Command, err error) {
 			project, err := projectWriteModel(ctx, filter, app.Aggregate.ID, app.Aggregate.ResourceOwner)
 			if err != nil || !project.State.Valid() {
@@ -112,6 +124,9 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 					app.ClockSkew,
 					trimStringSliceWhiteSpaces(app.AdditionalOrigins),
 					app.SkipSuccessPageForNativeApp,
+					app.ConsentRequired,
+					strings.TrimSpace(app.TLSClientAuthSubjectDN),
+					app.RefreshTokenReuseGracePeriod,
 				),
 			}, nil
 		}, nil
@@ -196,6 +211,9 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		oidcApp.ClockSkew,
 		trimStringSliceWhiteSpaces(oidcApp.AdditionalOrigins),
 		oidcApp.SkipNativeAppSuccessPage,
+		oidcApp.ConsentRequired,
+		strings.TrimSpace(oidcApp.TLSClientAuthSubjectDN),
+		oidcApp.RefreshTokenReuseGracePeriod,
 	))
 
 	addedApplication.AppID = oidcApp.AppID
@@ -248,6 +266,9 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		oidc.ClockSkew,
 		trimStringSliceWhiteSpaces(oidc.AdditionalOrigins),
 		oidc.SkipNativeAppSuccessPage,
+		oidc.ConsentRequired,
+		strings.TrimSpace(oidc.TLSClientAuthSubjectDN),
+		oidc.RefreshTokenReuseGracePeriod,
 	)
 	if err != nil {
 		return nil, err
@@ -36,7 +36,11 @@ type OIDCApplicationWriteModel struct {
 	State                    domain.AppState
 	AdditionalOrigins        []string
 	SkipNativeAppSuccessPage bool
+	ConsentRequired          bool
+	TLSClientAuthSubjectDN   string
 	oidc                     bool
+
+	RefreshTokenReuseGracePeriod time.Duration
 }
 
 func NewOIDCApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *OIDCApplicationWriteModel {
@@ -158,6 +162,9 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.ClockSkew = e.ClockSkew
 	wm.AdditionalOrigins = e.AdditionalOrigins
 	wm.SkipNativeAppSuccessPage = e.SkipNativeAppSuccessPage
+	wm.ConsentRequired = e.ConsentRequired
+	wm.TLSClientAuthSubjectDN = e.TLSClientAuthSubjectDN
+	wm.RefreshTokenReuseGracePeriod = e.RefreshTokenReuseGracePeriod
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -206,6 +213,15 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.SkipNativeAppSuccessPage != nil {
 		wm.SkipNativeAppSuccessPage = *e.SkipNativeAppSuccessPage
 	}
+	if e.ConsentRequired != nil {
+		wm.ConsentRequired = *e.ConsentRequired
+	}
+	if e.TLSClientAuthSubjectDN != nil {
+		wm.TLSClientAuthSubjectDN = *e.TLSClientAuthSubjectDN
+	}
+	if e.RefreshTokenReuseGracePeriod != nil {
+		wm.RefreshTokenReuseGracePeriod = *e.RefreshTokenReuseGracePeriod
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -245,7 +261,10 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	idTokenUserinfoAssertion bool,
 	clockSkew time.Duration,
 	additionalOrigins []string,
-	skipNativeAppSuccessPage bool,
+	skipNativeAppSuccessPage,
+	consentRequired bool,
+	tlsClientAuthSubjectDN string,
+	refreshTokenReuseGracePeriod time.Duration,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -295,6 +314,15 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if wm.SkipNativeAppSuccessPage != skipNativeAppSuccessPage {
 		changes = append(changes, project.ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage))
 	}
+	if wm.ConsentRequired != consentRequired {
+		changes = append(changes, project.ChangeConsentRequired(consentRequired))
+	}
+	if wm.TLSClientAuthSubjectDN != tlsClientAuthSubjectDN {
+		changes = append(changes, project.ChangeTLSClientAuthSubjectDN(tlsClientAuthSubjectDN))
+	}
+	if wm.RefreshTokenReuseGracePeriod != refreshTokenReuseGracePeriod {
+		changes = append(changes, project.ChangeRefreshTokenReuseGracePeriod(refreshTokenReuseGracePeriod))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
@@ -171,6 +171,9 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						[]string{"https://sub.test.ch"},
 						false,
+						false,
+						"",
+						0,
 					),
 				},
 			},
@@ -236,6 +239,9 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						false,
+						"",
+						0,
 					),
 				},
 			},
@@ -390,6 +396,9 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							time.Second*1,
 							[]string{"https://sub.test.ch"},
 							true,
+							false,
+							"",
+							0,
 						),
 					),
 				),
@@ -495,6 +504,9 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							time.Second*1,
 							[]string{"https://sub.test.ch"},
 							true,
+							false,
+							"",
+							0,
 						),
 					),
 				),
@@ -729,6 +741,9 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
+								"",
+								0,
 							),
 						),
 					),
@@ -803,6 +818,9 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
+								"",
+								0,
 							),
 						),
 					),
@@ -877,6 +895,9 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
+								"",
+								0,
 							),
 						),
 					),
@@ -911,6 +932,7 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 					ClockSkew:                time.Second * 2,
 					AdditionalOrigins:        []string{"https://sub.test.ch"},
 					SkipNativeAppSuccessPage: true,
+					ConsentRequired:          true,
 				},
 				resourceOwner: "org1",
 			},
@@ -938,6 +960,7 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 					ClockSkew:                time.Second * 2,
 					AdditionalOrigins:        []string{"https://sub.test.ch"},
 					SkipNativeAppSuccessPage: true,
+					ConsentRequired:          true,
 					Compliance:               &domain.Compliance{},
 					State:                    domain.AppStateActive,
 				},
@@ -1074,6 +1097,9 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								false,
+								"",
+								0,
 							),
 						),
 					),
@@ -1157,6 +1183,7 @@ func newOIDCAppChangedEvent(ctx context.Context, appID, projectID, resourceOwner
 		project.ChangeIDTokenRoleAssertion(false),
 		project.ChangeIDTokenUserinfoAssertion(false),
 		project.ChangeClockSkew(time.Second * 2),
+		project.ChangeConsentRequired(true),
 	}
 	event, _ := project.NewOIDCConfigChangedEvent(ctx,
 		&project.NewAggregate(projectID, resourceOwner).Aggregate,
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetSAMLSSOSettings replaces the IdP-initiated and WS-Federation settings of a SAML application.
+func (c *Commands) SetSAMLSSOSettings(ctx context.Context, projectID, appID string, settings *domain.SAMLSSOSettings, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" || appID == "" || settings == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Iec5u", "Errors.IDMissing")
+	}
+	if err = settings.Validate(); err != nil {
+		return nil, err
+	}
+	existing, err := c.getSAMLSSOSettingsWriteModel(ctx, projectID, appID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State == domain.AppStateUnspecified || existing.State == domain.AppStateRemoved {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Aeng4", "Errors.Project.App.NotExisting")
+	}
+	if !existing.saml {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohb3e", "Errors.Project.App.IsNotSAML")
+	}
+	if !existing.hasChanged(settings) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieX7o", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewSAMLSSOSettingsSetEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&existing.WriteModel),
+		appID,
+		settings.IDPInitiatedEnabled,
+		settings.RelayState,
+		settings.WSFedEnabled,
+		settings.WSFedReplyURLs,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(existing, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) getSAMLSSOSettingsWriteModel(ctx context.Context, projectID, appID, resourceOwner string) (*SAMLSSOSettingsWriteModel, error) {
+	writeModel := NewSAMLSSOSettingsWriteModel(projectID, appID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,104 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type SAMLSSOSettingsWriteModel struct {
+	eventstore.WriteModel
+
+	AppID               string
+	State               domain.AppState
+	IDPInitiatedEnabled bool
+	RelayState          string
+	WSFedEnabled        bool
+	WSFedReplyURLs      []string
+
+	saml bool
+}
+
+func NewSAMLSSOSettingsWriteModel(projectID, appID, resourceOwner string) *SAMLSSOSettingsWriteModel {
+	return &SAMLSSOSettingsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		AppID: appID,
+	}
+}
+
+func (wm *SAMLSSOSettingsWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.SAMLConfigAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.SAMLSSOSettingsSetEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *SAMLSSOSettingsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			wm.State = domain.AppStateActive
+		case *project.ApplicationRemovedEvent:
+			wm.State = domain.AppStateRemoved
+		case *project.SAMLConfigAddedEvent:
+			wm.saml = true
+		case *project.SAMLSSOSettingsSetEvent:
+			wm.IDPInitiatedEnabled = e.IDPInitiatedEnabled
+			wm.RelayState = e.RelayState
+			wm.WSFedEnabled = e.WSFedEnabled
+			wm.WSFedReplyURLs = e.WSFedReplyURLs
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.AppStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SAMLSSOSettingsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ApplicationAddedType,
+			project.ApplicationRemovedType,
+			project.SAMLConfigAddedType,
+			project.SAMLSSOSettingsSetType,
+			project.ProjectRemovedType).
+		Builder()
+}
+
+func (wm *SAMLSSOSettingsWriteModel) hasChanged(settings *domain.SAMLSSOSettings) bool {
+	return wm.IDPInitiatedEnabled != settings.IDPInitiatedEnabled ||
+		wm.RelayState != settings.RelayState ||
+		wm.WSFedEnabled != settings.WSFedEnabled ||
+		!slices.Equal(wm.WSFedReplyURLs, settings.WSFedReplyURLs)
+}
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AddProjectAuthorizationDetailType registers a new type of authorization details (RFC 9396) on the project.
+func (c *Commands) AddProjectAuthorizationDetailType(ctx context.Context, detailType *domain.AuthorizationDetailType, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if !detailType.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-iePh5", "Errors.Project.AuthorizationDetailType.Invalid")
+	}
+	if err = c.checkProjectExists(ctx, detailType.AggregateID, resourceOwner); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.getProjectAuthorizationDetailTypeWriteModel(ctx, detailType.Type, detailType.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State.Exists() {
+		return nil, zerrors.ThrowAlreadyExists(nil, "COMMAND-Ohg7e", "Errors.Project.AuthorizationDetailType.AlreadyExists")
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, project.NewAuthorizationDetailTypeAddedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		detailType.Type,
+		detailType.DisplayName,
+		detailType.Actions,
+		detailType.Locations,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ChangeProjectAuthorizationDetailType changes the display name and the allowed actions and locations of the registered type.
+// The type itself cannot be changed.
+func (c *Commands) ChangeProjectAuthorizationDetailType(ctx context.Context, detailType *domain.AuthorizationDetailType, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if !detailType.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Oe7ai", "Errors.Project.AuthorizationDetailType.Invalid")
+	}
+	writeModel, err := c.getProjectAuthorizationDetailTypeWriteModel(ctx, detailType.Type, detailType.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ahkie", "Errors.Project.AuthorizationDetailType.NotFound")
+	}
+	changedEvent, err := writeModel.NewChangedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		detailType.DisplayName,
+		detailType.Actions,
+		detailType.Locations,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if changedEvent == nil {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	if err = c.pushAppendAndReduce(ctx, writeModel, changedEvent); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveProjectAuthorizationDetailType removes the registered type from the project.
+// Already issued tokens are not affected, but new requests containing the type will be rejected.
+func (c *Commands) RemoveProjectAuthorizationDetailType(ctx context.Context, projectID, detailType, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if projectID == "" || detailType == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Vah6u", "Errors.Project.AuthorizationDetailType.Invalid")
+	}
+	writeModel, err := c.getProjectAuthorizationDetailTypeWriteModel(ctx, detailType, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-uG4ee", "Errors.Project.AuthorizationDetailType.NotFound")
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, project.NewAuthorizationDetailTypeRemovedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		detailType,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) getProjectAuthorizationDetailTypeWriteModel(ctx context.Context, detailType, projectID, resourceOwner string) (*ProjectAuthorizationDetailTypeWriteModel, error) {
+	writeModel := NewProjectAuthorizationDetailTypeWriteModel(detailType, projectID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,116 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type ProjectAuthorizationDetailTypeWriteModel struct {
+	eventstore.WriteModel
+
+	Type        string
+	DisplayName string
+	Actions     []string
+	Locations   []string
+	State       domain.AuthorizationDetailTypeState
+}
+
+func NewProjectAuthorizationDetailTypeWriteModel(detailType, projectID, resourceOwner string) *ProjectAuthorizationDetailTypeWriteModel {
+	return &ProjectAuthorizationDetailTypeWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		Type: detailType,
+	}
+}
+
+func (wm *ProjectAuthorizationDetailTypeWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.AuthorizationDetailTypeAddedEvent:
+			if e.DetailType == wm.Type {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *project.AuthorizationDetailTypeChangedEvent:
+			if e.DetailType == wm.Type {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *project.AuthorizationDetailTypeRemovedEvent:
+			if e.DetailType == wm.Type {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *ProjectAuthorizationDetailTypeWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.AuthorizationDetailTypeAddedEvent:
+			wm.Type = e.DetailType
+			wm.DisplayName = e.DisplayName
+			wm.Actions = e.Actions
+			wm.Locations = e.Locations
+			wm.State = domain.AuthorizationDetailTypeStateActive
+		case *project.AuthorizationDetailTypeChangedEvent:
+			if e.DisplayName != nil {
+				wm.DisplayName = *e.DisplayName
+			}
+			if e.Actions != nil {
+				wm.Actions = *e.Actions
+			}
+			if e.Locations != nil {
+				wm.Locations = *e.Locations
+			}
+		case *project.AuthorizationDetailTypeRemovedEvent:
+			wm.State = domain.AuthorizationDetailTypeStateRemoved
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.AuthorizationDetailTypeStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ProjectAuthorizationDetailTypeWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.AuthorizationDetailTypeAddedType,
+			project.AuthorizationDetailTypeChangedType,
+			project.AuthorizationDetailTypeRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+}
+
+func (wm *ProjectAuthorizationDetailTypeWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	displayName string,
+	actions,
+	locations []string,
+) (*project.AuthorizationDetailTypeChangedEvent, error) {
+	changes := make([]project.AuthorizationDetailTypeChanges, 0, 3)
+	if wm.DisplayName != displayName {
+		changes = append(changes, project.ChangeAuthorizationDetailTypeDisplayName(displayName))
+	}
+	if !slices.Equal(wm.Actions, actions) {
+		changes = append(changes, project.ChangeAuthorizationDetailTypeActions(actions))
+	}
+	if !slices.Equal(wm.Locations, locations) {
+		changes = append(changes, project.ChangeAuthorizationDetailTypeLocations(locations))
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return project.NewAuthorizationDetailTypeChangedEvent(ctx, aggregate, wm.Type, changes)
+}
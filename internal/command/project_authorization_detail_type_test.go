@@ -0,0 +1,462 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_AddProjectAuthorizationDetailType(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		detailType    *domain.AuthorizationDetailType
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid type, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				detailType: &domain.AuthorizationDetailType{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				detailType: &domain.AuthorizationDetailType{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Type: "payment_initiation",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "type already registered, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewAuthorizationDetailTypeAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"payment_initiation",
+								"Payment",
+								nil,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				detailType: &domain.AuthorizationDetailType{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Type: "payment_initiation",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add type, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						project.NewAuthorizationDetailTypeAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"payment_initiation",
+							"Payment",
+							[]string{"initiate", "status"},
+							[]string{"https://bank.example.com"},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				detailType: &domain.AuthorizationDetailType{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Type:        "payment_initiation",
+					DisplayName: "Payment",
+					Actions:     []string{"initiate", "status"},
+					Locations:   []string{"https://bank.example.com"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.AddProjectAuthorizationDetailType(tt.args.ctx, tt.args.detailType, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeProjectAuthorizationDetailType(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		detailType    *domain.AuthorizationDetailType
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "type not registered, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				detailType: &domain.AuthorizationDetailType{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Type: "payment_initiation",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "type removed, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewAuthorizationDetailTypeAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"payment_initiation",
+								"Payment",
+								nil,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							project.NewAuthorizationDetailTypeRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"payment_initiation",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				detailType: &domain.AuthorizationDetailType{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Type: "payment_initiation",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewAuthorizationDetailTypeAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"payment_initiation",
+								"Payment",
+								[]string{"initiate"},
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				detailType: &domain.AuthorizationDetailType{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Type:        "payment_initiation",
+					DisplayName: "Payment",
+					Actions:     []string{"initiate"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "change type, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewAuthorizationDetailTypeAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"payment_initiation",
+								"Payment",
+								[]string{"initiate"},
+								nil,
+							),
+						),
+					),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := project.NewAuthorizationDetailTypeChangedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"payment_initiation",
+								[]project.AuthorizationDetailTypeChanges{
+									project.ChangeAuthorizationDetailTypeDisplayName("Payment Initiation"),
+									project.ChangeAuthorizationDetailTypeActions([]string{"initiate", "status"}),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				detailType: &domain.AuthorizationDetailType{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Type:        "payment_initiation",
+					DisplayName: "Payment Initiation",
+					Actions:     []string{"initiate", "status"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ChangeProjectAuthorizationDetailType(tt.args.ctx, tt.args.detailType, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveProjectAuthorizationDetailType(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		projectID     string
+		detailType    string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing type, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "type not registered, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				detailType:    "payment_initiation",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove type, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewAuthorizationDetailTypeAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"payment_initiation",
+								"Payment",
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						project.NewAuthorizationDetailTypeRemovedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"payment_initiation",
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				detailType:    "payment_initiation",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveProjectAuthorizationDetailType(tt.args.ctx, tt.args.projectID, tt.args.detailType, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -47,6 +47,10 @@ func oidcWriteModelToOIDCConfig(writeModel *OIDCApplicationWriteModel) *domain.O
 		ClockSkew:                writeModel.ClockSkew,
 		AdditionalOrigins:        writeModel.AdditionalOrigins,
 		SkipNativeAppSuccessPage: writeModel.SkipNativeAppSuccessPage,
+		ConsentRequired:          writeModel.ConsentRequired,
+		TLSClientAuthSubjectDN:   writeModel.TLSClientAuthSubjectDN,
+
+		RefreshTokenReuseGracePeriod: writeModel.RefreshTokenReuseGracePeriod,
 	}
 }
 
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
@@ -49,6 +50,63 @@ func (wm *quotaWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return query.Builder()
 }
 
+var _ eventstore.Snapshotter = (*quotaWriteModel)(nil)
+
+// quotaWriteModelSnapshotVersion must be increased if the query, the reducer or [quotaSnapshot] change
+const quotaWriteModelSnapshotVersion = 1
+
+// quotaSnapshot is the reduced state of the [quotaWriteModel]
+type quotaSnapshot struct {
+	RollingAggregateID string                        `json:"rollingAggregateId,omitempty"`
+	From               time.Time                     `json:"from,omitempty"`
+	ResetInterval      time.Duration                 `json:"resetInterval,omitempty"`
+	Amount             uint64                        `json:"amount,omitempty"`
+	Limit              bool                          `json:"limit,omitempty"`
+	Notifications      []*quota.SetEventNotification `json:"notifications,omitempty"`
+}
+
+// SnapshotType implements [eventstore.Snapshotter]
+func (wm *quotaWriteModel) SnapshotType() string {
+	return "quota"
+}
+
+// SnapshotKey implements [eventstore.Snapshotter]
+func (wm *quotaWriteModel) SnapshotKey() string {
+	return fmt.Sprintf("%s:%d", wm.ResourceOwner, wm.unit)
+}
+
+// SnapshotVersion implements [eventstore.Snapshotter]
+func (wm *quotaWriteModel) SnapshotVersion() uint16 {
+	return quotaWriteModelSnapshotVersion
+}
+
+// MarshalSnapshot implements [eventstore.Snapshotter]
+func (wm *quotaWriteModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(&quotaSnapshot{
+		RollingAggregateID: wm.rollingAggregateID,
+		From:               wm.from,
+		ResetInterval:      wm.resetInterval,
+		Amount:             wm.amount,
+		Limit:              wm.limit,
+		Notifications:      wm.notifications,
+	})
+}
+
+// UnmarshalSnapshot implements [eventstore.Snapshotter]
+func (wm *quotaWriteModel) UnmarshalSnapshot(data []byte) error {
+	state := new(quotaSnapshot)
+	if err := json.Unmarshal(data, state); err != nil {
+		return err
+	}
+	wm.rollingAggregateID = state.RollingAggregateID
+	wm.from = state.From
+	wm.resetInterval = state.ResetInterval
+	wm.amount = state.Amount
+	wm.limit = state.Limit
+	wm.notifications = state.Notifications
+	return nil
+}
+
 func (wm *quotaWriteModel) Reduce() error {
 	for _, event := range wm.Events {
 		wm.ChangeDate = event.CreatedAt()
@@ -401,3 +401,28 @@ func uint64Ptr(i uint64) *uint64                 { return &i }
 func boolPtr(b bool) *bool                       { return &b }
 func durationPtr(d time.Duration) *time.Duration { return &d }
 func timePtr(t time.Time) *time.Time             { return &t }
+
+func TestQuotaWriteModel_Snapshot(t *testing.T) {
+	want := newQuotaWriteModel("instance1", "ro1", quota.RequestsAllAuthenticated)
+	want.rollingAggregateID = "quota1"
+	want.from = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	want.resetInterval = time.Hour
+	want.amount = 10
+	want.limit = true
+	want.notifications = []*quota.SetEventNotification{{
+		ID:      "notification1",
+		Percent: 50,
+		Repeat:  true,
+		CallURL: "https://url.com",
+	}}
+	data, err := want.MarshalSnapshot()
+	assert.NoError(t, err)
+
+	got := newQuotaWriteModel("instance1", "ro1", quota.RequestsAllAuthenticated)
+	assert.Equal(t, want.SnapshotKey(), got.SnapshotKey())
+	assert.NoError(t, got.UnmarshalSnapshot(data))
+	assert.Equal(t, want, got)
+
+	assert.Error(t, got.UnmarshalSnapshot([]byte("invalid")))
+	assert.Equal(t, want, got, "write model must not change on error")
+}
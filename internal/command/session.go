@@ -38,6 +38,8 @@ type SessionCommands struct {
 	createCode  cryptoCodeWithDefaultFunc
 	createToken func(sessionID string) (id string, token string, err error)
 	now         func() time.Time
+
+	orgDomainIDPRoutings orgDomainIDPRoutingsFunc
 }
 
 func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWriteModel) *SessionCommands {
@@ -52,6 +54,8 @@ func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWri
 		createCode:        c.newCodeWithDefault,
 		createToken:       c.sessionTokenCreator,
 		now:               time.Now,
+
+		orgDomainIDPRoutings: c.orgDomainIDPRoutings,
 	}
 }
 
@@ -83,6 +87,9 @@ func CheckPassword(password string) SessionCommand {
 		if cmd.passwordWriteModel.EncodedHash == "" {
 			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-WEf3t", "Errors.User.Password.NotSet")
 		}
+		if err = checkPasswordAllowedByIDPRouting(ctx, cmd.eventstore.Filter, cmd.orgDomainIDPRoutings, cmd.passwordWriteModel.ResourceOwner, cmd.passwordWriteModel.UserName); err != nil {
+			return err
+		}
 		ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "passwap.Verify")
 		updated, err := cmd.hasher.Verify(cmd.passwordWriteModel.EncodedHash, password)
 		spanPasswordComparison.EndWithError(err)
@@ -226,6 +233,14 @@ func (s *SessionCommands) OTPEmailChecked(ctx context.Context, checkedAt time.Ti
 	s.eventCommands = append(s.eventCommands, session.NewOTPEmailCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
 }
 
+func (s *SessionCommands) PushChallenged(ctx context.Context, challengeID string, expiry time.Duration, number uint32, devices []session.PushChallengeDevice) {
+	s.eventCommands = append(s.eventCommands, session.NewPushChallengedEvent(ctx, s.sessionWriteModel.aggregate, challengeID, expiry, number, devices))
+}
+
+func (s *SessionCommands) PushChecked(ctx context.Context, checkedAt time.Time, deviceID string) {
+	s.eventCommands = append(s.eventCommands, session.NewPushCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt, deviceID))
+}
+
 func (s *SessionCommands) SetToken(ctx context.Context, tokenID string) {
 	// trigger activity log for session for user
 	activity.Trigger(ctx, s.sessionWriteModel.UserResourceOwner, s.sessionWriteModel.UserID, activity.SessionAPI)
@@ -17,6 +17,38 @@ type WebAuthNChallengeModel struct {
 	RPID               string
 }
 
+// PushChallengeModel is the state of the push challenge sent to the devices of the user.
+type PushChallengeModel struct {
+	ChallengeID  string
+	Number       uint32
+	Expiry       time.Duration
+	CreationDate time.Time
+	DeviceIDs    []string
+	// ApprovedBy is the id of the device, which approved the challenge
+	ApprovedBy string
+	Denied     bool
+}
+
+// HasDevice returns whether the challenge was sent to the device
+func (p *PushChallengeModel) HasDevice(deviceID string) bool {
+	for _, id := range p.DeviceIDs {
+		if id == deviceID {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolved returns whether the challenge was already approved or denied
+func (p *PushChallengeModel) Resolved() bool {
+	return p.ApprovedBy != "" || p.Denied
+}
+
+// Expired returns whether the challenge expired
+func (p *PushChallengeModel) Expired() bool {
+	return p.CreationDate.Add(p.Expiry).Before(time.Now())
+}
+
 type OTPCode struct {
 	Code         *crypto.CryptoValue
 	Expiry       time.Duration
@@ -47,6 +79,7 @@ type SessionWriteModel struct {
 	TOTPCheckedAt        time.Time
 	OTPSMSCheckedAt      time.Time
 	OTPEmailCheckedAt    time.Time
+	PushCheckedAt        time.Time
 	WebAuthNUserVerified bool
 	Metadata             map[string][]byte
 	State                domain.SessionState
@@ -55,6 +88,7 @@ type SessionWriteModel struct {
 	WebAuthNChallenge     *WebAuthNChallengeModel
 	OTPSMSCodeChallenge   *OTPCode
 	OTPEmailCodeChallenge *OTPCode
+	PushChallenge         *PushChallengeModel
 
 	aggregate *eventstore.Aggregate
 }
@@ -94,6 +128,14 @@ func (wm *SessionWriteModel) Reduce() error {
 			wm.reduceOTPEmailChallenged(e)
 		case *session.OTPEmailCheckedEvent:
 			wm.reduceOTPEmailChecked(e)
+		case *session.PushChallengedEvent:
+			wm.reducePushChallenged(e)
+		case *session.PushApprovedEvent:
+			wm.reducePushApproved(e)
+		case *session.PushDeniedEvent:
+			wm.reducePushDenied(e)
+		case *session.PushCheckedEvent:
+			wm.reducePushChecked(e)
 		case *session.TokenSetEvent:
 			wm.reduceTokenSet(e)
 		case *session.LifetimeSetEvent:
@@ -122,6 +164,10 @@ func (wm *SessionWriteModel) Query() *eventstore.SearchQueryBuilder {
 			session.OTPSMSCheckedType,
 			session.OTPEmailChallengedType,
 			session.OTPEmailCheckedType,
+			session.PushChallengedType,
+			session.PushApprovedType,
+			session.PushDeniedType,
+			session.PushCheckedType,
 			session.TokenSetType,
 			session.MetadataSetType,
 			session.LifetimeSetType,
@@ -198,6 +244,39 @@ func (wm *SessionWriteModel) reduceOTPEmailChecked(e *session.OTPEmailCheckedEve
 	wm.OTPEmailCheckedAt = e.CheckedAt
 }
 
+func (wm *SessionWriteModel) reducePushChallenged(e *session.PushChallengedEvent) {
+	deviceIDs := make([]string, len(e.Devices))
+	for i, device := range e.Devices {
+		deviceIDs[i] = device.DeviceID
+	}
+	wm.PushChallenge = &PushChallengeModel{
+		ChallengeID:  e.ChallengeID,
+		Number:       e.Number,
+		Expiry:       e.Expiry,
+		CreationDate: e.CreationDate(),
+		DeviceIDs:    deviceIDs,
+	}
+}
+
+func (wm *SessionWriteModel) reducePushApproved(e *session.PushApprovedEvent) {
+	if wm.PushChallenge == nil || wm.PushChallenge.ChallengeID != e.ChallengeID {
+		return
+	}
+	wm.PushChallenge.ApprovedBy = e.DeviceID
+}
+
+func (wm *SessionWriteModel) reducePushDenied(e *session.PushDeniedEvent) {
+	if wm.PushChallenge == nil || wm.PushChallenge.ChallengeID != e.ChallengeID {
+		return
+	}
+	wm.PushChallenge.Denied = true
+}
+
+func (wm *SessionWriteModel) reducePushChecked(e *session.PushCheckedEvent) {
+	wm.PushChallenge = nil
+	wm.PushCheckedAt = e.CheckedAt
+}
+
 func (wm *SessionWriteModel) reduceTokenSet(e *session.TokenSetEvent) {
 	wm.TokenID = e.TokenID
 }
@@ -220,6 +299,7 @@ func (wm *SessionWriteModel) AuthenticationTime() time.Time {
 		wm.IntentCheckedAt,
 		wm.OTPSMSCheckedAt,
 		wm.OTPEmailCheckedAt,
+		wm.PushCheckedAt,
 	} {
 		if check.After(authTime) {
 			authTime = check
@@ -253,6 +333,9 @@ func (wm *SessionWriteModel) AuthMethodTypes() []domain.UserAuthMethodType {
 	if !wm.OTPEmailCheckedAt.IsZero() {
 		types = append(types, domain.UserAuthMethodTypeOTPEmail)
 	}
+	if !wm.PushCheckedAt.IsZero() {
+		types = append(types, domain.UserAuthMethodTypePush)
+	}
 	return types
 }
 
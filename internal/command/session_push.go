@@ -0,0 +1,144 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// CreatePushChallenge sends a push challenge to all ready devices of the user.
+// The id of the challenge is returned in challengeID,
+// the number the user has to enter on the device is returned in number and has to be displayed to the user.
+func (c *Commands) CreatePushChallenge(challengeID *string, number *uint32) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Oow8e", "Errors.User.UserIDMissing")
+		}
+		writeModel := NewHumanPushDevicesWriteModel(cmd.sessionWriteModel.UserID, "")
+		if err := cmd.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return err
+		}
+		readyDevices := writeModel.ReadyDevices()
+		if len(readyDevices) == 0 {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ui4ah", "Errors.User.MFA.Push.NotReady")
+		}
+		id, err := c.idGenerator.Next()
+		if err != nil {
+			return err
+		}
+		challengeNumber, err := c.pushChallengeNumber()
+		if err != nil {
+			return err
+		}
+		devices := make([]session.PushChallengeDevice, len(readyDevices))
+		for i, device := range readyDevices {
+			devices[i] = session.PushChallengeDevice{
+				DeviceID:  device.DeviceID,
+				PushToken: device.PushToken,
+			}
+		}
+		*challengeID = id
+		*number = challengeNumber
+		cmd.PushChallenged(ctx, id, domain.PushChallengeLifetime, challengeNumber, devices)
+		return nil
+	}
+}
+
+// CheckPush checks that the current push challenge was approved on one of the devices of the user.
+func CheckPush() SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-ooM9a", "Errors.User.UserIDMissing")
+		}
+		challenge := cmd.sessionWriteModel.PushChallenge
+		if challenge == nil {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gei4o", "Errors.User.MFA.Push.ChallengeNotFound")
+		}
+		if challenge.Denied {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Aej9i", "Errors.User.MFA.Push.Denied")
+		}
+		if challenge.ApprovedBy == "" {
+			if challenge.Expired() {
+				return zerrors.ThrowPreconditionFailed(nil, "COMMAND-eiQu7", "Errors.User.MFA.Push.Expired")
+			}
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Xoh2k", "Errors.User.MFA.Push.Pending")
+		}
+		cmd.PushChecked(ctx, cmd.now(), challenge.ApprovedBy)
+		return nil
+	}
+}
+
+// PushChallengeSent marks the push challenge of the session as sent to the devices of the user.
+func (c *Commands) PushChallengeSent(ctx context.Context, sessionID, resourceOwner string) error {
+	sessionWriteModel := NewSessionWriteModel(sessionID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel)
+	if err != nil {
+		return err
+	}
+	if sessionWriteModel.PushChallenge == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-aiS1u", "Errors.User.MFA.Push.ChallengeNotFound")
+	}
+	return c.pushAppendAndReduce(ctx, sessionWriteModel,
+		session.NewPushSentEvent(ctx, &session.NewAggregate(sessionID, sessionWriteModel.ResourceOwner).Aggregate),
+	)
+}
+
+// RespondPushChallenge approves or denies the push challenge of the session on behalf of a device of the user.
+// The response is signed by the device ([domain.PushChallengeResponseData]), so no further authentication is required.
+// If the user approved the challenge, but entered a wrong number, the challenge is denied.
+func (c *Commands) RespondPushChallenge(ctx context.Context, sessionID, challengeID, deviceID string, approve bool, number uint32, signature []byte) (*domain.ObjectDetails, error) {
+	if sessionID == "" || challengeID == "" || deviceID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohph4", "Errors.User.MFA.Push.ChallengeNotFound")
+	}
+	sessionWriteModel := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	if err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel); err != nil {
+		return nil, err
+	}
+	if err := sessionWriteModel.CheckIsActive(); err != nil {
+		return nil, err
+	}
+	challenge := sessionWriteModel.PushChallenge
+	if challenge == nil || challenge.ChallengeID != challengeID || !challenge.HasDevice(deviceID) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Kah3e", "Errors.User.MFA.Push.ChallengeNotFound")
+	}
+	if challenge.Resolved() {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieY5a", "Errors.User.MFA.Push.AlreadyResponded")
+	}
+	if challenge.Expired() {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-eeN4o", "Errors.User.MFA.Push.Expired")
+	}
+	devicesWriteModel := NewHumanPushDevicesWriteModel(sessionWriteModel.UserID, "")
+	if err := c.eventstore.FilterToQueryReducer(ctx, devicesWriteModel); err != nil {
+		return nil, err
+	}
+	device := devicesWriteModel.Device(deviceID)
+	if device == nil || device.State != domain.MFAStateReady {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Vai7e", "Errors.User.MFA.Push.DeviceNotFound")
+	}
+	if err := domain.VerifyPushSignature(device.PublicKey, domain.PushChallengeResponseData(sessionID, challengeID, approve, number), signature); err != nil {
+		return nil, err
+	}
+	agg := &session.NewAggregate(sessionID, sessionWriteModel.ResourceOwner).Aggregate
+	switch {
+	case !approve:
+		err := c.pushAppendAndReduce(ctx, sessionWriteModel, session.NewPushDeniedEvent(ctx, agg, challengeID, deviceID, false))
+		if err != nil {
+			return nil, err
+		}
+	case number != challenge.Number:
+		err := c.pushAppendAndReduce(ctx, sessionWriteModel, session.NewPushDeniedEvent(ctx, agg, challengeID, deviceID, true))
+		if err != nil {
+			return nil, err
+		}
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahng1", "Errors.User.MFA.Push.NumberMismatch")
+	default:
+		err := c.pushAppendAndReduce(ctx, sessionWriteModel, session.NewPushApprovedEvent(ctx, agg, challengeID, deviceID))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return writeModelToObjectDetails(&sessionWriteModel.WriteModel), nil
+}
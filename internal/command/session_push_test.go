@@ -0,0 +1,456 @@
+package command
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_CreatePushChallenge(t *testing.T) {
+	publicKey, _ := newTestPushDeviceKey(t)
+	type fields struct {
+		userID      string
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type res struct {
+		err         error
+		challengeID string
+		number      uint32
+		commands    []eventstore.Command
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "userID missing, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Oow8e", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "no ready device, precondition error",
+			fields: fields{
+				userID: "user1",
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(pushTestHumanAddedEvent()),
+						eventFromEventPusher(
+							user.NewHumanPushDeviceAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"device1", "phone", publicKey, "token", "challenge",
+							),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ui4ah", "Errors.User.MFA.Push.NotReady"),
+			},
+		},
+		{
+			name: "challenged",
+			fields: fields{
+				userID: "user1",
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(pushTestHumanAddedEvent()),
+						eventFromEventPusher(
+							user.NewHumanPushDeviceAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"device1", "phone", publicKey, "token1", "challenge",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanPushDeviceVerifiedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "device1", "phone"),
+						),
+						eventFromEventPusher(
+							user.NewHumanPushDeviceAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"device2", "tablet", publicKey, "token2", "challenge",
+							),
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "challengeID"),
+			},
+			res: res{
+				challengeID: "challengeID",
+				number:      42,
+				commands: []eventstore.Command{
+					session.NewPushChallengedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+						"challengeID",
+						domain.PushChallengeLifetime,
+						42,
+						[]session.PushChallengeDevice{{DeviceID: "device1", PushToken: "token1"}},
+					),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				idGenerator: tt.fields.idGenerator,
+				pushChallengeNumber: func() (uint32, error) {
+					return 42, nil
+				},
+			}
+			var (
+				challengeID string
+				number      uint32
+			)
+			cmd := c.CreatePushChallenge(&challengeID, &number)
+
+			sessionModel := &SessionWriteModel{
+				UserID:        tt.fields.userID,
+				UserCheckedAt: testNow,
+				State:         domain.SessionStateActive,
+				aggregate:     &session.NewAggregate("sessionID", "instanceID").Aggregate,
+			}
+			cmds := &SessionCommands{
+				sessionCommands:   []SessionCommand{cmd},
+				sessionWriteModel: sessionModel,
+				eventstore:        tt.fields.eventstore(t),
+				now:               time.Now,
+			}
+
+			err := cmd(context.Background(), cmds)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.challengeID, challengeID)
+			assert.Equal(t, tt.res.number, number)
+			assert.Equal(t, tt.res.commands, cmds.eventCommands)
+		})
+	}
+}
+
+func TestCheckPush(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    string
+		challenge *PushChallengeModel
+		err       error
+		commands  []eventstore.Command
+	}{
+		{
+			name: "missing userID",
+			err:  zerrors.ThrowPreconditionFailed(nil, "COMMAND-ooM9a", "Errors.User.UserIDMissing"),
+		},
+		{
+			name:   "missing challenge",
+			userID: "user1",
+			err:    zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gei4o", "Errors.User.MFA.Push.ChallengeNotFound"),
+		},
+		{
+			name:   "denied",
+			userID: "user1",
+			challenge: &PushChallengeModel{
+				ChallengeID:  "challengeID",
+				Expiry:       domain.PushChallengeLifetime,
+				CreationDate: time.Now(),
+				Denied:       true,
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Aej9i", "Errors.User.MFA.Push.Denied"),
+		},
+		{
+			name:   "expired",
+			userID: "user1",
+			challenge: &PushChallengeModel{
+				ChallengeID:  "challengeID",
+				Expiry:       domain.PushChallengeLifetime,
+				CreationDate: time.Now().Add(-time.Hour),
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-eiQu7", "Errors.User.MFA.Push.Expired"),
+		},
+		{
+			name:   "pending",
+			userID: "user1",
+			challenge: &PushChallengeModel{
+				ChallengeID:  "challengeID",
+				Expiry:       domain.PushChallengeLifetime,
+				CreationDate: time.Now(),
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Xoh2k", "Errors.User.MFA.Push.Pending"),
+		},
+		{
+			name:   "approved",
+			userID: "user1",
+			challenge: &PushChallengeModel{
+				ChallengeID:  "challengeID",
+				Expiry:       domain.PushChallengeLifetime,
+				CreationDate: time.Now(),
+				ApprovedBy:   "device1",
+			},
+			commands: []eventstore.Command{
+				session.NewPushCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+					testNow,
+					"device1",
+				),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := CheckPush()
+
+			sessionModel := &SessionWriteModel{
+				UserID:        tt.userID,
+				UserCheckedAt: testNow,
+				State:         domain.SessionStateActive,
+				PushChallenge: tt.challenge,
+				aggregate:     &session.NewAggregate("sessionID", "instanceID").Aggregate,
+			}
+			cmds := &SessionCommands{
+				sessionCommands:   []SessionCommand{cmd},
+				sessionWriteModel: sessionModel,
+				eventstore:        expectEventstore()(t),
+				now: func() time.Time {
+					return testNow
+				},
+			}
+
+			err := cmd(context.Background(), cmds)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.commands, cmds.eventCommands)
+		})
+	}
+}
+
+func TestCommands_PushChallengeSent(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		wantErr    error
+	}{
+		{
+			name: "not challenged, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-aiS1u", "Errors.User.MFA.Push.ChallengeNotFound"),
+		},
+		{
+			name: "challenged and sent",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						session.NewPushChallengedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+							"challengeID", domain.PushChallengeLifetime, 42,
+							[]session.PushChallengeDevice{{DeviceID: "device1", PushToken: "token1"}},
+						),
+					),
+				),
+				expectPush(
+					session.NewPushSentEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate),
+				),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			err := c.PushChallengeSent(context.Background(), "sessionID", "instanceID")
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCommands_RespondPushChallenge(t *testing.T) {
+	publicKey, privateKey := newTestPushDeviceKey(t)
+	ctx := authz.WithInstanceID(context.Background(), "instanceID")
+	sessionAgg := &session.NewAggregate("sessionID", "instanceID").Aggregate
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+
+	sessionEvents := func(challengeCreation func(eventstore.Command) *repository.Event, events ...eventstore.Command) expect {
+		filtered := []eventstore.Event{
+			eventFromEventPusher(session.NewAddedEvent(ctx, sessionAgg, &domain.UserAgent{})),
+			eventFromEventPusher(session.NewUserCheckedEvent(ctx, sessionAgg, "user1", "org1", testNow)),
+			challengeCreation(session.NewPushChallengedEvent(ctx, sessionAgg,
+				"challengeID", domain.PushChallengeLifetime, 42,
+				[]session.PushChallengeDevice{{DeviceID: "device1", PushToken: "token1"}},
+			)),
+		}
+		for _, event := range events {
+			filtered = append(filtered, eventFromEventPusher(event))
+		}
+		return expectFilter(filtered...)
+	}
+	deviceEvents := expectFilter(
+		eventFromEventPusher(pushTestHumanAddedEvent()),
+		eventFromEventPusher(
+			user.NewHumanPushDeviceAddedEvent(ctx, userAgg, "device1", "phone", publicKey, "token1", "challenge"),
+		),
+		eventFromEventPusher(
+			user.NewHumanPushDeviceVerifiedEvent(ctx, userAgg, "device1", "phone"),
+		),
+	)
+	sign := func(approve bool, number uint32) []byte {
+		return ed25519.Sign(privateKey, domain.PushChallengeResponseData("sessionID", "challengeID", approve, number))
+	}
+
+	type args struct {
+		challengeID string
+		deviceID    string
+		approve     bool
+		number      uint32
+		signature   []byte
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		wantErr    error
+	}{
+		{
+			name: "challenge not found",
+			eventstore: expectEventstore(
+				sessionEvents(eventFromEventPusherWithCreationDateNow),
+			),
+			args: args{
+				challengeID: "otherChallengeID",
+				deviceID:    "device1",
+				approve:     true,
+				number:      42,
+				signature:   sign(true, 42),
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-Kah3e", "Errors.User.MFA.Push.ChallengeNotFound"),
+		},
+		{
+			name: "device not challenged",
+			eventstore: expectEventstore(
+				sessionEvents(eventFromEventPusherWithCreationDateNow),
+			),
+			args: args{
+				challengeID: "challengeID",
+				deviceID:    "device2",
+				approve:     true,
+				number:      42,
+				signature:   sign(true, 42),
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-Kah3e", "Errors.User.MFA.Push.ChallengeNotFound"),
+		},
+		{
+			name: "already responded",
+			eventstore: expectEventstore(
+				sessionEvents(eventFromEventPusherWithCreationDateNow,
+					session.NewPushApprovedEvent(ctx, sessionAgg, "challengeID", "device1"),
+				),
+			),
+			args: args{
+				challengeID: "challengeID",
+				deviceID:    "device1",
+				approve:     true,
+				number:      42,
+				signature:   sign(true, 42),
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieY5a", "Errors.User.MFA.Push.AlreadyResponded"),
+		},
+		{
+			name: "expired",
+			eventstore: expectEventstore(
+				sessionEvents(eventFromEventPusher),
+			),
+			args: args{
+				challengeID: "challengeID",
+				deviceID:    "device1",
+				approve:     true,
+				number:      42,
+				signature:   sign(true, 42),
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-eeN4o", "Errors.User.MFA.Push.Expired"),
+		},
+		{
+			name: "invalid signature",
+			eventstore: expectEventstore(
+				sessionEvents(eventFromEventPusherWithCreationDateNow),
+				deviceEvents,
+			),
+			args: args{
+				challengeID: "challengeID",
+				deviceID:    "device1",
+				approve:     true,
+				number:      42,
+				signature:   sign(false, 42),
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-ieL0u", "Errors.User.MFA.Push.SignatureInvalid"),
+		},
+		{
+			name: "denied",
+			eventstore: expectEventstore(
+				sessionEvents(eventFromEventPusherWithCreationDateNow),
+				deviceEvents,
+				expectPush(
+					session.NewPushDeniedEvent(ctx, sessionAgg, "challengeID", "device1", false),
+				),
+			),
+			args: args{
+				challengeID: "challengeID",
+				deviceID:    "device1",
+				approve:     false,
+				signature:   sign(false, 0),
+			},
+		},
+		{
+			name: "number mismatch, denied",
+			eventstore: expectEventstore(
+				sessionEvents(eventFromEventPusherWithCreationDateNow),
+				deviceEvents,
+				expectPush(
+					session.NewPushDeniedEvent(ctx, sessionAgg, "challengeID", "device1", true),
+				),
+			),
+			args: args{
+				challengeID: "challengeID",
+				deviceID:    "device1",
+				approve:     true,
+				number:      24,
+				signature:   sign(true, 24),
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahng1", "Errors.User.MFA.Push.NumberMismatch"),
+		},
+		{
+			name: "approved",
+			eventstore: expectEventstore(
+				sessionEvents(eventFromEventPusherWithCreationDateNow),
+				deviceEvents,
+				expectPush(
+					session.NewPushApprovedEvent(ctx, sessionAgg, "challengeID", "device1"),
+				),
+			),
+			args: args{
+				challengeID: "challengeID",
+				deviceID:    "device1",
+				approve:     true,
+				number:      42,
+				signature:   sign(true, 42),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			_, err := c.RespondPushChallenge(ctx, "sessionID", tt.args.challengeID, tt.args.deviceID, tt.args.approve, tt.args.number, tt.args.signature)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
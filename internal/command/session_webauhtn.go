@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/zitadel/zitadel/internal/domain"
+	webauthn_helper "github.com/zitadel/zitadel/internal/webauthn"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -42,6 +43,9 @@ func (s *SessionCommands) getHumanWebAuthNTokenReadModel(ctx context.Context, us
 
 func (c *Commands) CreateWebAuthNChallenge(userVerification domain.UserVerificationRequirement, rpid string, dst json.Unmarshaler) SessionCommand {
 	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" && userVerification == domain.UserVerificationRequirementRequired {
+			return c.createDiscoverableWebAuthNChallenge(ctx, cmd, rpid, dst)
+		}
 		humanPasskeys, err := cmd.getHumanWebAuthNTokens(ctx, userVerification)
 		if err != nil {
 			return err
@@ -59,6 +63,38 @@ func (c *Commands) CreateWebAuthNChallenge(userVerification domain.UserVerificat
 	}
 }
 
+// createDiscoverableWebAuthNChallenge creates a challenge for a passkey without a user being checked beforehand.
+// The user will be identified by the user handle of the assertion, see [Commands.CheckWebAuthN].
+func (c *Commands) createDiscoverableWebAuthNChallenge(ctx context.Context, cmd *SessionCommands, rpid string, dst json.Unmarshaler) error {
+	webAuthNLogin, err := c.webauthnConfig.BeginDiscoverableLogin(ctx, domain.UserVerificationRequirementRequired, rpid)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(webAuthNLogin.CredentialAssertionData, dst); err != nil {
+		return zerrors.ThrowInternal(err, "COMMAND-ahX4i", "Errors.Internal")
+	}
+	cmd.WebAuthNChallenged(ctx, webAuthNLogin.Challenge, nil, webAuthNLogin.UserVerification, rpid)
+	return nil
+}
+
+// checkDiscoverableWebAuthNUser checks the user identified by the user handle of the passkey assertion,
+// if the session was not bound to a user when the challenge was created.
+func (s *SessionCommands) checkDiscoverableWebAuthNUser(ctx context.Context, credentialAssertionData []byte) error {
+	if s.sessionWriteModel.WebAuthNChallenge.UserVerification != domain.UserVerificationRequirementRequired {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Thie0", "Errors.User.UserIDMissing")
+	}
+	userID, err := webauthn_helper.UserIDFromAssertion(credentialAssertionData)
+	if err != nil {
+		return err
+	}
+	s.sessionWriteModel.UserID = userID
+	humanWriteModel, err := s.gethumanWriteModel(ctx)
+	if err != nil {
+		return err
+	}
+	return s.UserChecked(ctx, userID, humanWriteModel.ResourceOwner, s.now())
+}
+
 func (c *Commands) CheckWebAuthN(credentialAssertionData json.Marshaler) SessionCommand {
 	return func(ctx context.Context, cmd *SessionCommands) error {
 		credentialAssertionData, err := json.Marshal(credentialAssertionData)
@@ -69,6 +105,11 @@ func (c *Commands) CheckWebAuthN(credentialAssertionData json.Marshaler) Session
 		if challenge == nil {
 			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ioqu5", "Errors.Session.WebAuthN.NoChallenge")
 		}
+		if cmd.sessionWriteModel.UserID == "" {
+			if err = cmd.checkDiscoverableWebAuthNUser(ctx, credentialAssertionData); err != nil {
+				return err
+			}
+		}
 		webAuthNTokens, err := cmd.getHumanWebAuthNTokens(ctx, challenge.UserVerification)
 		if err != nil {
 			return err
@@ -2,18 +2,24 @@ package command
 
 import (
 	"context"
+	"encoding/base64"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/text/language"
+	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/session"
 	"github.com/zitadel/zitadel/internal/repository/user"
+	webauthn_helper "github.com/zitadel/zitadel/internal/webauthn"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -248,3 +254,152 @@ func TestSessionCommands_getHumanWebAuthNTokens(t *testing.T) {
 		assert.Equal(t, tt.res.want, got)
 	}
 }
+
+func TestCommands_CreateWebAuthNChallenge_discoverable(t *testing.T) {
+	ctx := authz.WithRequestedDomain(context.Background(), "example.com")
+	c := &Commands{
+		webauthnConfig: &webauthn_helper.Config{
+			DisplayName:    "test",
+			ExternalSecure: true,
+		},
+	}
+	dst := new(structpb.Struct)
+	cmd := c.CreateWebAuthNChallenge(domain.UserVerificationRequirementRequired, "", dst)
+	sessionModel := &SessionWriteModel{
+		State:     domain.SessionStateActive,
+		aggregate: &session.NewAggregate("sessionID", "instanceID").Aggregate,
+	}
+	cmds := &SessionCommands{
+		sessionCommands:   []SessionCommand{cmd},
+		sessionWriteModel: sessionModel,
+		eventstore:        expectEventstore()(t),
+		now:               time.Now,
+	}
+
+	err := cmd(ctx, cmds)
+	require.NoError(t, err)
+	require.Len(t, cmds.eventCommands, 1)
+	challenged, ok := cmds.eventCommands[0].(*session.WebAuthNChallengedEvent)
+	require.True(t, ok)
+	assert.NotEmpty(t, challenged.Challenge)
+	assert.Empty(t, challenged.AllowedCrentialIDs)
+	assert.Equal(t, domain.UserVerificationRequirementRequired, challenged.UserVerification)
+	// discoverable credentials must not be restricted
+	assert.NotContains(t, dst.GetFields()["publicKey"].GetStructValue().GetFields(), "allowCredentials")
+}
+
+func TestSessionCommands_checkDiscoverableWebAuthNUser(t *testing.T) {
+	passkeyAssertion := func(userHandle string) []byte {
+		enc := base64.RawURLEncoding.EncodeToString
+		return []byte(`{
+			"id": "` + enc([]byte("credential")) + `",
+			"rawId": "` + enc([]byte("credential")) + `",
+			"type": "public-key",
+			"response": {
+				"authenticatorData": "` + enc(make([]byte, 37)) + `",
+				"clientDataJSON": "` + enc([]byte(`{"type":"webauthn.get","challenge":"challenge","origin":"https://example.com"}`)) + `",
+				"signature": "` + enc([]byte("signature")) + `",
+				"userHandle": "` + enc([]byte(userHandle)) + `"
+			}
+		}`)
+	}
+	sessionAgg := &session.NewAggregate("sessionID", "instanceID").Aggregate
+	type fields struct {
+		eventstore       func(*testing.T) *eventstore.Eventstore
+		userVerification domain.UserVerificationRequirement
+	}
+	type res struct {
+		err           error
+		userID        string
+		resourceOwner string
+		commands      []eventstore.Command
+	}
+	tests := []struct {
+		name                    string
+		fields                  fields
+		credentialAssertionData []byte
+		res                     res
+	}{
+		{
+			name: "u2f challenge",
+			fields: fields{
+				eventstore:       expectEventstore(),
+				userVerification: domain.UserVerificationRequirementDiscouraged,
+			},
+			credentialAssertionData: passkeyAssertion("userID"),
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Thie0", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "missing user handle",
+			fields: fields{
+				eventstore:       expectEventstore(),
+				userVerification: domain.UserVerificationRequirementRequired,
+			},
+			credentialAssertionData: passkeyAssertion(""),
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "WEBAU-Wai6d", "Errors.User.WebAuthN.ValidateLoginFailed"),
+			},
+		},
+		{
+			name: "user not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				userVerification: domain.UserVerificationRequirementRequired,
+			},
+			credentialAssertionData: passkeyAssertion("userID"),
+			res: res{
+				err:    zerrors.ThrowPreconditionFailed(nil, "COMMAND-Df4b3", "Errors.User.NotFound"),
+				userID: "userID",
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("userID", "org1").Aggregate,
+								"username", "", "", "", "", language.English,
+								domain.GenderUnspecified, "email@test.ch", true,
+							),
+						),
+					),
+				),
+				userVerification: domain.UserVerificationRequirementRequired,
+			},
+			credentialAssertionData: passkeyAssertion("userID"),
+			res: res{
+				userID:        "userID",
+				resourceOwner: "org1",
+				commands: []eventstore.Command{
+					session.NewUserCheckedEvent(context.Background(), sessionAgg, "userID", "org1", testNow),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmds := &SessionCommands{
+				sessionWriteModel: &SessionWriteModel{
+					State:             domain.SessionStateActive,
+					WebAuthNChallenge: &WebAuthNChallengeModel{UserVerification: tt.fields.userVerification},
+					aggregate:         sessionAgg,
+				},
+				eventstore: tt.fields.eventstore(t),
+				now: func() time.Time {
+					return testNow
+				},
+			}
+			err := cmds.checkDiscoverableWebAuthNUser(context.Background(), tt.credentialAssertionData)
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.userID, cmds.sessionWriteModel.UserID)
+			assert.Equal(t, tt.res.resourceOwner, cmds.sessionWriteModel.UserResourceOwner)
+			assert.Equal(t, tt.res.commands, cmds.eventCommands)
+		})
+	}
+}
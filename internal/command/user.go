@@ -127,6 +127,29 @@ func (c *Commands) ReactivateUser(ctx context.Context, userID, resourceOwner str
 	return writeModelToObjectDetails(&existingUser.WriteModel), nil
 }
 
+// ReactivateUserDeactivatedBy reactivates an inactive user only if it was deactivated by the editor,
+// so deactivations by anyone else are kept.
+func (c *Commands) ReactivateUserDeactivatedBy(ctx context.Context, userID, resourceOwner, editorID string) (reactivated bool, err error) {
+	if userID == "" {
+		return false, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohth4", "Errors.User.UserIDMissing")
+	}
+
+	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return false, err
+	}
+	if !isUserStateInactive(existingUser.UserState) || existingUser.DeactivatedBy != editorID {
+		return false, nil
+	}
+
+	_, err = c.eventstore.Push(ctx,
+		user.NewUserReactivatedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (c *Commands) LockUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
 	if userID == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-2M0sd", "Errors.User.UserIDMissing")
@@ -232,12 +255,12 @@ func (c *Commands) RemoveUser(ctx context.Context, userID, resourceOwner string,
 	return writeModelToObjectDetails(&existingUser.WriteModel), nil
 }
 
-func (c *Commands) AddUserToken(ctx context.Context, orgID, agentID, clientID, userID string, audience, scopes []string, lifetime time.Duration) (*domain.Token, error) {
+func (c *Commands) AddUserToken(ctx context.Context, orgID, agentID, clientID, userID string, audience, scopes []string, lifetime time.Duration, certificateThumbprint string, authorizationDetails domain.AuthorizationDetails) (*domain.Token, error) {
 	if userID == "" { //do not check for empty orgID (JWT Profile requests won't provide it, so service user requests fail)
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Dbge4", "Errors.IDMissing")
 	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
-	event, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, "", audience, scopes, lifetime)
+	event, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, "", certificateThumbprint, audience, scopes, lifetime, authorizationDetails)
 	if err != nil {
 		return nil, err
 	}
@@ -264,7 +287,7 @@ func (c *Commands) RevokeAccessToken(ctx context.Context, userID, orgID, tokenID
 	return writeModelToObjectDetails(&accessTokenWriteModel.WriteModel), nil
 }
 
-func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteModel, agentID, clientID, refreshTokenID string, audience, scopes []string, lifetime time.Duration) (*user.UserTokenAddedEvent, *domain.Token, error) {
+func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteModel, agentID, clientID, refreshTokenID, certificateThumbprint string, audience, scopes []string, lifetime time.Duration, authorizationDetails domain.AuthorizationDetails) (*user.UserTokenAddedEvent, *domain.Token, error) {
 	err := c.eventstore.FilterToQueryReducer(ctx, userWriteModel)
 	if err != nil {
 		return nil, nil, err
@@ -287,19 +310,21 @@ func (c *Commands) addUserToken(ctx context.Context, userWriteModel *UserWriteMo
 	}
 
 	userAgg := UserAggregateFromWriteModel(&userWriteModel.WriteModel)
-	return user.NewUserTokenAddedEvent(ctx, userAgg, tokenID, clientID, agentID, preferredLanguage, refreshTokenID, audience, scopes, expiration),
+	return user.NewUserTokenAddedEvent(ctx, userAgg, tokenID, clientID, agentID, preferredLanguage, refreshTokenID, audience, scopes, expiration, certificateThumbprint, authorizationDetails),
 		&domain.Token{
 			ObjectRoot: models.ObjectRoot{
 				AggregateID: userWriteModel.AggregateID,
 			},
-			TokenID:           tokenID,
-			UserAgentID:       agentID,
-			ApplicationID:     clientID,
-			RefreshTokenID:    refreshTokenID,
-			Audience:          audience,
-			Scopes:            scopes,
-			Expiration:        expiration,
-			PreferredLanguage: preferredLanguage,
+			TokenID:               tokenID,
+			UserAgentID:           agentID,
+			ApplicationID:         clientID,
+			RefreshTokenID:        refreshTokenID,
+			Audience:              audience,
+			Scopes:                scopes,
+			Expiration:            expiration,
+			PreferredLanguage:     preferredLanguage,
+			CertificateThumbprint: certificateThumbprint,
+			AuthorizationDetails:  authorizationDetails,
 		}, nil
 }
 
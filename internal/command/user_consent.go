@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// GrantUserConsent stores the scopes the user consented to for the client.
+// Previously granted scopes of the client are replaced.
+func (c *Commands) GrantUserConsent(ctx context.Context, userID, resourceOwner, clientID, projectID string, scopes []string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ve1ei", "Errors.User.UserIDMissing")
+	}
+	if clientID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Joh8a", "Errors.User.Consent.ClientIDMissing")
+	}
+	if err := c.checkUserExists(ctx, userID, resourceOwner); err != nil {
+		return nil, err
+	}
+	existingConsent, err := c.userConsentWriteModelByID(ctx, userID, clientID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	scopes = normalizeConsentScopes(scopes)
+	if existingConsent.Exists() && existingConsent.ProjectID == projectID && slices.Equal(existingConsent.Scopes, scopes) {
+		return writeModelToObjectDetails(&existingConsent.WriteModel), nil
+	}
+	userAgg := UserAggregateFromWriteModel(&existingConsent.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, existingConsent, user.NewConsentGrantedEvent(ctx, userAgg, clientID, projectID, scopes)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingConsent.WriteModel), nil
+}
+
+// RevokeUserConsent removes the consent of the user for the client,
+// so the user has to consent again on the next authorization.
+func (c *Commands) RevokeUserConsent(ctx context.Context, userID, clientID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ooch4", "Errors.User.UserIDMissing")
+	}
+	if clientID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-eiZ7u", "Errors.User.Consent.ClientIDMissing")
+	}
+	existingConsent, err := c.userConsentWriteModelByID(ctx, userID, clientID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if userID != authz.GetCtxData(ctx).UserID {
+		if err := c.checkPermission(ctx, domain.PermissionUserWrite, existingConsent.ResourceOwner, userID); err != nil {
+			return nil, err
+		}
+	}
+	if !existingConsent.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Aip6u", "Errors.User.Consent.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingConsent.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, existingConsent, user.NewConsentRevokedEvent(ctx, userAgg, clientID)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingConsent.WriteModel), nil
+}
+
+func (c *Commands) userConsentWriteModelByID(ctx context.Context, userID, clientID, resourceOwner string) (writeModel *UserConsentWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewUserConsentWriteModel(userID, clientID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func normalizeConsentScopes(scopes []string) []string {
+	normalized := slices.Clone(scopes)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
@@ -0,0 +1,83 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type UserConsentWriteModel struct {
+	eventstore.WriteModel
+
+	ClientID  string
+	ProjectID string
+	Scopes    []string
+	granted   bool
+}
+
+func NewUserConsentWriteModel(userID, clientID, resourceOwner string) *UserConsentWriteModel {
+	return &UserConsentWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		ClientID: clientID,
+	}
+}
+
+func (wm *UserConsentWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.ConsentGrantedEvent:
+			if wm.ClientID != e.ClientID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.ConsentRevokedEvent:
+			if wm.ClientID != e.ClientID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *UserConsentWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.ConsentGrantedEvent:
+			wm.ProjectID = e.ProjectID
+			wm.Scopes = e.Scopes
+			wm.granted = true
+		case *user.ConsentRevokedEvent,
+			*user.UserRemovedEvent:
+			wm.ProjectID = ""
+			wm.Scopes = nil
+			wm.granted = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserConsentWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.ConsentGrantedType,
+			user.ConsentRevokedType,
+			user.UserRemovedType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+func (wm *UserConsentWriteModel) Exists() bool {
+	return wm.granted
+}
@@ -0,0 +1,389 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_GrantUserConsent(t *testing.T) {
+	ctx := authz.NewMockContext("inst1", "org1", "user1")
+	userAdded := eventFromEventPusher(
+		user.NewHumanAddedEvent(context.Background(),
+			&user.NewAggregate("user1", "org1").Aggregate,
+			"username",
+			"firstname",
+			"lastname",
+			"nickname",
+			"displayname",
+			language.German,
+			domain.GenderUnspecified,
+			"email@test.ch",
+			true,
+		),
+	)
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID        string
+		resourceOwner string
+		clientID      string
+		projectID     string
+		scopes        []string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				resourceOwner: "org1",
+				clientID:      "client1",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ve1ei", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "client id missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Joh8a", "Errors.User.Consent.ClientIDMissing"),
+			},
+		},
+		{
+			name: "user not existing, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				clientID:      "client1",
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-uXHNj", "Errors.User.NotFound"),
+			},
+		},
+		{
+			name: "same scopes already granted, no event",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(userAdded),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewConsentGrantedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+								"project1",
+								[]string{"email", "openid"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				clientID:      "client1",
+				projectID:     "project1",
+				scopes:        []string{"openid", "email", "openid"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "consent of other client, granted",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(userAdded),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewConsentGrantedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client2",
+								"project1",
+								[]string{"email", "openid"},
+							),
+						),
+					),
+					expectPush(
+						user.NewConsentGrantedEvent(ctx,
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"client1",
+							"project1",
+							[]string{"email", "openid"},
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				clientID:      "client1",
+				projectID:     "project1",
+				scopes:        []string{"openid", "email"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "additional scopes, granted",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(userAdded),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewConsentGrantedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+								"project1",
+								[]string{"openid"},
+							),
+						),
+					),
+					expectPush(
+						user.NewConsentGrantedEvent(ctx,
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"client1",
+							"project1",
+							[]string{"openid", "profile"},
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				clientID:      "client1",
+				projectID:     "project1",
+				scopes:        []string{"profile", "openid"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.GrantUserConsent(ctx, tt.args.userID, tt.args.resourceOwner, tt.args.clientID, tt.args.projectID, tt.args.scopes)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestCommandSide_RevokeUserConsent(t *testing.T) {
+	ctx := authz.NewMockContext("inst1", "org1", "user1")
+	type fields struct {
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		userID        string
+		clientID      string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				clientID:      "client1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ooch4", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "client id missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-eiZ7u", "Errors.User.Consent.ClientIDMissing"),
+			},
+		},
+		{
+			name: "other user not permission, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				userID:        "other",
+				clientID:      "client1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+			},
+		},
+		{
+			name: "consent revoked, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewConsentGrantedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+								"project1",
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusher(
+							user.NewConsentRevokedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+							),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				userID:        "user1",
+				clientID:      "client1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Aip6u", "Errors.User.Consent.NotFound"),
+			},
+		},
+		{
+			name: "successful revoke",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewConsentGrantedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"client1",
+								"project1",
+								[]string{"openid"},
+							),
+						),
+					),
+					expectPush(
+						user.NewConsentRevokedEvent(ctx,
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"client1",
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				userID:        "user1",
+				clientID:      "client1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "resource owner of other org resolved, successful revoke",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewConsentGrantedEvent(ctx,
+								&user.NewAggregate("other", "org2").Aggregate,
+								"client1",
+								"project1",
+								[]string{"openid"},
+							),
+						),
+					),
+					expectPush(
+						user.NewConsentRevokedEvent(ctx,
+							&user.NewAggregate("other", "org2").Aggregate,
+							"client1",
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				userID:   "other",
+				clientID: "client1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org2",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := r.RevokeUserConsent(ctx, tt.args.userID, tt.args.clientID, tt.args.resourceOwner)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
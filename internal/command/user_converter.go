@@ -133,6 +133,8 @@ func writeModelToWebAuthN(wm *HumanWebAuthNWriteModel) *domain.WebAuthNToken {
 		WebAuthNTokenName: wm.WebAuthNTokenName,
 		State:             wm.State,
 		RPID:              wm.RPID,
+		AuthenticatorName: wm.AuthenticatorName,
+		AuthenticatorIcon: wm.AuthenticatorIcon,
 	}
 }
 
@@ -0,0 +1,193 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type UserGrantsSyncResult struct {
+	Added   uint32
+	Changed uint32
+	Removed uint32
+}
+
+// SyncUserGrants adds, changes or removes the user grants of a user based on roles provided by an external system (e.g. a directory).
+// rolesByProject maps the project id to the role keys the user must be granted, a nil value removes the grant.
+// existingGrantIDs maps the project id to the id of the current user grant of the user on that project.
+// If addOnly is set, existing roles are kept and grants are never removed.
+// As the roles are managed by the external system, no explicit project permission is checked.
+func (c *Commands) SyncUserGrants(ctx context.Context, userID, resourceOwner string, rolesByProject map[string][]string, existingGrantIDs map[string]string, addOnly bool) (_ *UserGrantsSyncResult, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ahM4o", "Errors.IDMissing")
+	}
+	result := new(UserGrantsSyncResult)
+	cmds := make([]eventstore.Command, 0, len(rolesByProject))
+	for projectID, roleKeys := range rolesByProject {
+		cmd, err := c.syncUserGrant(ctx, userID, resourceOwner, projectID, existingGrantIDs[projectID], roleKeys, addOnly, result)
+		if err != nil {
+			return nil, err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if len(cmds) == 0 {
+		return result, nil
+	}
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SyncIDPUserGrants keeps the user grants of a user in sync with the roles mapped from the claim values of the federated user
+// by the role mapping of the identity provider. The groups of the user are fetched by the session if required.
+// The grants are created in the organization of the user, nothing is changed if the provider has no role mapping.
+func (c *Commands) SyncIDPUserGrants(ctx context.Context, idpID, userID string, idpUser idp.User, idpSession idp.Session) (_ *UserGrantsSyncResult, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	mapping, err := c.idpRoleMapping(ctx, idpID)
+	if err != nil {
+		return nil, err
+	}
+	if !mapping.IsEnabled() {
+		return new(UserGrantsSyncResult), nil
+	}
+	existingUser, err := c.userExistsWriteModel(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ohm4i", "Errors.User.NotFound")
+	}
+	values, err := idp.SessionClaimValues(ctx, idpSession, idpUser, mapping.Claim)
+	if err != nil {
+		return nil, err
+	}
+	return c.SyncIDPRoleMappingUserGrants(ctx, mapping, userID, existingUser.ResourceOwner, values)
+}
+
+// SyncIDPRoleMappingUserGrants keeps the user grants of a user in sync with the roles mapped from the claim values (groups) by the mapping.
+// Depending on the mode of the mapping, roles and grants of unmatched values are kept or removed.
+func (c *Commands) SyncIDPRoleMappingUserGrants(ctx context.Context, mapping *domain.IDPRoleMapping, userID, resourceOwner string, values []string) (_ *UserGrantsSyncResult, err error) {
+	if !mapping.IsEnabled() {
+		return new(UserGrantsSyncResult), nil
+	}
+	rolesByProject := mapping.RolesByProject(values)
+	if len(rolesByProject) == 0 {
+		return new(UserGrantsSyncResult), nil
+	}
+	projectIDs := make([]string, 0, len(rolesByProject))
+	for projectID := range rolesByProject {
+		projectIDs = append(projectIDs, projectID)
+	}
+	existingGrantIDs, err := c.userGrantIDsByProject(ctx, userID, resourceOwner, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	return c.SyncUserGrants(ctx, userID, resourceOwner, rolesByProject, existingGrantIDs, mapping.Mode == domain.IDPRoleMappingModeAddOnly)
+}
+
+// userGrantIDsByProject returns the ids of the user grants of the user on the projects which are not removed.
+func (c *Commands) userGrantIDsByProject(ctx context.Context, userID, resourceOwner string, projectIDs []string) (map[string]string, error) {
+	events, err := c.eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(resourceOwner).
+		AddQuery().
+		AggregateTypes(usergrant.AggregateType).
+		EventTypes(usergrant.UserGrantAddedType).
+		EventData(map[string]interface{}{"userId": userID}).
+		Builder())
+	if err != nil {
+		return nil, err
+	}
+	grantIDs := make(map[string]string, len(projectIDs))
+	for _, event := range events {
+		added, ok := event.(*usergrant.UserGrantAddedEvent)
+		if !ok || !slices.Contains(projectIDs, added.ProjectID) {
+			continue
+		}
+		existing, err := c.userGrantWriteModelByID(ctx, added.Aggregate().ID, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if existing.State == domain.UserGrantStateUnspecified || existing.State == domain.UserGrantStateRemoved {
+			continue
+		}
+		grantIDs[added.ProjectID] = existing.AggregateID
+	}
+	return grantIDs, nil
+}
+
+func (c *Commands) syncUserGrant(ctx context.Context, userID, resourceOwner, projectID, grantID string, roleKeys []string, addOnly bool, result *UserGrantsSyncResult) (eventstore.Command, error) {
+	if grantID == "" {
+		if roleKeys == nil {
+			return nil, nil
+		}
+		cmd, _, err := c.addUserGrant(ctx, &domain.UserGrant{UserID: userID, ProjectID: projectID, RoleKeys: roleKeys}, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		result.Added++
+		return cmd, nil
+	}
+	existing, err := c.userGrantWriteModelByID(ctx, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State == domain.UserGrantStateUnspecified || existing.State == domain.UserGrantStateRemoved {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-ooT6e", "Errors.UserGrant.NotFound")
+	}
+	userGrantAgg := UserGrantAggregateFromWriteModel(&existing.WriteModel)
+	if addOnly {
+		if roleKeys == nil {
+			return nil, nil
+		}
+		roleKeys = appendMissingRoleKeys(existing.RoleKeys, roleKeys)
+	}
+	if roleKeys == nil {
+		result.Removed++
+		return usergrant.NewUserGrantRemovedEvent(ctx, userGrantAgg, existing.UserID, existing.ProjectID, existing.ProjectGrantID), nil
+	}
+	if sameRoleKeys(existing.RoleKeys, roleKeys) {
+		return nil, nil
+	}
+	err = c.checkUserGrantPreCondition(ctx, &domain.UserGrant{UserID: userID, ProjectID: existing.ProjectID, ProjectGrantID: existing.ProjectGrantID, RoleKeys: roleKeys}, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	result.Changed++
+	return usergrant.NewUserGrantChangedEvent(ctx, userGrantAgg, roleKeys), nil
+}
+
+func sameRoleKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, key := range a {
+		if !slices.Contains(b, key) {
+			return false
+		}
+	}
+	return true
+}
+
+func appendMissingRoleKeys(existing, keys []string) []string {
+	merged := slices.Clone(existing)
+	for _, key := range keys {
+		if !slices.Contains(merged, key) {
+			merged = append(merged, key)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,558 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SyncUserGrants(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx              context.Context
+		userID           string
+		resourceOwner    string
+		rolesByProject   map[string][]string
+		existingGrantIDs map[string]string
+		addOnly          bool
+	}
+	type res struct {
+		want *UserGrantsSyncResult
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing user id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no grant and no roles, nothing to do",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "user1",
+				resourceOwner:  "org1",
+				rolesByProject: map[string][]string{"project1": nil},
+			},
+			res: res{
+				want: &UserGrantsSyncResult{},
+			},
+		},
+		{
+			name: "same roles, unchanged",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"", []string{"rolekey1", "rolekey2"}),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:              context.Background(),
+				userID:           "user1",
+				resourceOwner:    "org1",
+				rolesByProject:   map[string][]string{"project1": {"rolekey2", "rolekey1"}},
+				existingGrantIDs: map[string]string{"project1": "usergrant1"},
+			},
+			res: res{
+				want: &UserGrantsSyncResult{},
+			},
+		},
+		{
+			name: "grant removed, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"", []string{"rolekey1"}),
+						),
+						eventFromEventPusher(
+							usergrant.NewUserGrantRemovedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								""),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:              context.Background(),
+				userID:           "user1",
+				resourceOwner:    "org1",
+				rolesByProject:   map[string][]string{"project1": nil},
+				existingGrantIDs: map[string]string{"project1": "usergrant1"},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "roles missing, grant removed without permission check",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"", []string{"rolekey1"}),
+						),
+					),
+					expectPush(
+						usergrant.NewUserGrantRemovedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:              context.Background(),
+				userID:           "user1",
+				resourceOwner:    "org1",
+				rolesByProject:   map[string][]string{"project1": nil},
+				existingGrantIDs: map[string]string{"project1": "usergrant1"},
+			},
+			res: res{
+				want: &UserGrantsSyncResult{Removed: 1},
+			},
+		},
+		{
+			name: "add only, roles merged",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"", []string{"rolekey1"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username1",
+								"firstname1",
+								"lastname1",
+								"nickname1",
+								"displayname1",
+								language.German,
+								domain.GenderMale,
+								"email1",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"rolekey1",
+								"rolekey",
+								"",
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"rolekey2",
+								"rolekey",
+								"",
+							),
+						),
+					),
+					expectPush(
+						usergrant.NewUserGrantChangedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							[]string{"rolekey1", "rolekey2"},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:              context.Background(),
+				userID:           "user1",
+				resourceOwner:    "org1",
+				rolesByProject:   map[string][]string{"project1": {"rolekey2"}},
+				existingGrantIDs: map[string]string{"project1": "usergrant1"},
+				addOnly:          true,
+			},
+			res: res{
+				want: &UserGrantsSyncResult{Changed: 1},
+			},
+		},
+		{
+			name: "add only, grant not removed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"", []string{"rolekey1"}),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:              context.Background(),
+				userID:           "user1",
+				resourceOwner:    "org1",
+				rolesByProject:   map[string][]string{"project1": nil},
+				existingGrantIDs: map[string]string{"project1": "usergrant1"},
+				addOnly:          true,
+			},
+			res: res{
+				want: &UserGrantsSyncResult{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SyncUserGrants(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.rolesByProject, tt.args.existingGrantIDs, tt.args.addOnly)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SyncIDPRoleMappingUserGrants(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		mapping *domain.IDPRoleMapping
+		values  []string
+	}
+	type res struct {
+		want *UserGrantsSyncResult
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "mapping disabled, unchanged",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				mapping: &domain.IDPRoleMapping{},
+				values:  []string{"admins"},
+			},
+			res: res{
+				want: &UserGrantsSyncResult{},
+			},
+		},
+		{
+			name: "add only without matching value, unchanged",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				mapping: &domain.IDPRoleMapping{
+					Claim: "groups",
+					Mode:  domain.IDPRoleMappingModeAddOnly,
+					Rules: []*domain.IDPRoleRule{{Value: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+				},
+				values: []string{"users"},
+			},
+			res: res{
+				want: &UserGrantsSyncResult{},
+			},
+		},
+		{
+			name: "reconcile without matching value, removed grants ignored and active grant removed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"}),
+						),
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant2", "org1").Aggregate,
+								"user1", "project2", "", []string{"admin"}),
+						),
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant3", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"}),
+						),
+						eventFromEventPusher(
+							usergrant.NewUserGrantRemovedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1", "project1", ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant3", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant3", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"}),
+						),
+					),
+					expectPush(
+						usergrant.NewUserGrantRemovedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant3", "org1").Aggregate,
+							"user1", "project1", ""),
+					),
+				),
+			},
+			args: args{
+				mapping: &domain.IDPRoleMapping{
+					Claim: "groups",
+					Mode:  domain.IDPRoleMappingModeReconcile,
+					Rules: []*domain.IDPRoleRule{{Value: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+				},
+				values: []string{"users"},
+			},
+			res: res{
+				want: &UserGrantsSyncResult{Removed: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.SyncIDPRoleMappingUserGrants(context.Background(), tt.args.mapping, "user1", "org1", tt.args.values)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+// testGroupsSession is an [idp.Session] providing the groups of the user by [idp.SessionSupportsGroups]
+type testGroupsSession struct {
+	idp.Session
+	groups []string
+}
+
+func (s *testGroupsSession) FetchGroups(context.Context) ([]string, error) {
+	return s.groups, nil
+}
+
+func TestCommandSide_SyncIDPUserGrants(t *testing.T) {
+	mapping := domain.IDPRoleMapping{
+		Claim: idp.GroupsClaim,
+		Mode:  domain.IDPRoleMappingModeReconcile,
+		Rules: []*domain.IDPRoleRule{{Value: "zitadel/engineering", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+	}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		want *UserGrantsSyncResult
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "no role mapping, unchanged",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent()),
+					),
+					expectFilter(),
+				),
+			},
+			res: res{
+				want: &UserGrantsSyncResult{},
+			},
+		},
+		{
+			name: "user not found, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPRoleMappingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "id1", mapping),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "groups fetched by the session, grant unchanged",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPRoleMappingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "id1", mapping),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username1",
+								"firstname1",
+								"lastname1",
+								"nickname1",
+								"displayname1",
+								language.German,
+								domain.GenderMale,
+								"email1",
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"}),
+						),
+					),
+				),
+			},
+			res: res{
+				want: &UserGrantsSyncResult{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			session := &testGroupsSession{groups: []string{"zitadel", "zitadel/engineering"}}
+			got, err := r.SyncIDPUserGrants(authz.WithInstanceID(context.Background(), "instance1"), "id1", "user1", &oauth.UserMapper{}, session)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
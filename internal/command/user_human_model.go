@@ -1,6 +1,8 @@
 package command
 
 import (
+	"encoding/json"
+
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -45,6 +47,41 @@ func NewHumanWriteModel(userID, resourceOwner string) *HumanWriteModel {
 	}
 }
 
+var _ eventstore.Snapshotter = (*HumanWriteModel)(nil)
+
+// humanWriteModelSnapshotVersion must be increased if the query, the reducer or the fields of [HumanWriteModel] change
+const humanWriteModelSnapshotVersion = 1
+
+// SnapshotType implements [eventstore.Snapshotter]
+func (wm *HumanWriteModel) SnapshotType() string {
+	return "user.human"
+}
+
+// SnapshotKey implements [eventstore.Snapshotter]
+func (wm *HumanWriteModel) SnapshotKey() string {
+	return wm.ResourceOwner + ":" + wm.AggregateID
+}
+
+// SnapshotVersion implements [eventstore.Snapshotter]
+func (wm *HumanWriteModel) SnapshotVersion() uint16 {
+	return humanWriteModelSnapshotVersion
+}
+
+// MarshalSnapshot implements [eventstore.Snapshotter]
+func (wm *HumanWriteModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(wm)
+}
+
+// UnmarshalSnapshot implements [eventstore.Snapshotter]
+func (wm *HumanWriteModel) UnmarshalSnapshot(data []byte) error {
+	state := *wm
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	*wm = state
+	return nil
+}
+
 func (wm *HumanWriteModel) Reduce() error {
 	for _, event := range wm.Events {
 		switch e := event.(type) {
@@ -266,6 +266,9 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	if wm.EncodedHash == "" {
 		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-3nJ4t", "Errors.User.Password.NotSet")
 	}
+	if err = checkPasswordAllowedByIDPRouting(ctx, c.eventstore.Filter, c.orgDomainIDPRoutings, wm.ResourceOwner, wm.UserName); err != nil {
+		return err
+	}
 
 	userAgg := UserAggregateFromWriteModel(&wm.WriteModel)
 	ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "passwap.Verify")
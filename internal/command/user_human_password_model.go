@@ -21,6 +21,10 @@ type HumanPasswordWriteModel struct {
 	CodeExpiry               time.Duration
 	PasswordCheckFailedCount uint64
 
+	// UserName is used to check if the domain of the user
+	// allows authentication with a password, see [checkPasswordAllowedByIDPRouting]
+	UserName string
+
 	UserState domain.UserState
 }
 
@@ -39,11 +43,15 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 		case *user.HumanAddedEvent:
 			wm.EncodedHash = user.SecretOrEncodedHash(e.Secret, e.EncodedHash)
 			wm.SecretChangeRequired = e.ChangeRequired
+			wm.UserName = e.UserName
 			wm.UserState = domain.UserStateActive
 		case *user.HumanRegisteredEvent:
 			wm.EncodedHash = user.SecretOrEncodedHash(e.Secret, e.EncodedHash)
 			wm.SecretChangeRequired = e.ChangeRequired
+			wm.UserName = e.UserName
 			wm.UserState = domain.UserStateActive
+		case *user.UsernameChangedEvent:
+			wm.UserName = e.UserName
 		case *user.HumanInitialCodeAddedEvent:
 			wm.UserState = domain.UserStateInitial
 		case *user.HumanInitializedCheckSucceededEvent:
@@ -93,6 +101,7 @@ func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.HumanPasswordChangedType,
 			user.HumanPasswordCodeAddedType,
 			user.HumanEmailVerifiedType,
+			user.UserUserNameChangedType,
 			user.HumanPasswordCheckFailedType,
 			user.HumanPasswordCheckSucceededType,
 			user.HumanPasswordHashUpdatedType,
@@ -1201,8 +1201,9 @@ func TestCommandSide_PasswordCodeSent(t *testing.T) {
 
 func TestCommandSide_CheckPassword(t *testing.T) {
 	type fields struct {
-		eventstore         *eventstore.Eventstore
-		userPasswordHasher *crypto.PasswordHasher
+		eventstore           *eventstore.Eventstore
+		userPasswordHasher   *crypto.PasswordHasher
+		orgDomainIDPRoutings orgDomainIDPRoutingsFunc
 	}
 	type args struct {
 		ctx           context.Context
@@ -1730,6 +1731,84 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 			},
 			res: res{},
 		},
+		{
+			name: "check password, password not allowed by idp routing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLoginPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								domain.PasswordlessTypeNotAllowed,
+								"",
+								time.Hour*1,
+								time.Hour*2,
+								time.Hour*3,
+								time.Hour*4,
+								time.Hour*5,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username@test.ch",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanPasswordChangedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"$plain$x$password",
+								false,
+								"")),
+					),
+				),
+				userPasswordHasher: mockPasswordHasher("x"),
+				orgDomainIDPRoutings: func(context.Context, string) (map[string]*domain.OrgDomainIDPRouting, error) {
+					return map[string]*domain.OrgDomainIDPRouting{
+						"test.ch": {IDPIDs: []string{"idp1"}, Force: true},
+					}, nil
+				},
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				password:      "password",
+				authReq: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
 		{
 			name: "check password, ok, updated hash",
 			fields: fields{
@@ -1995,8 +2074,9 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
-				eventstore:         tt.fields.eventstore,
-				userPasswordHasher: tt.fields.userPasswordHasher,
+				eventstore:           tt.fields.eventstore,
+				userPasswordHasher:   tt.fields.userPasswordHasher,
+				orgDomainIDPRoutings: tt.fields.orgDomainIDPRoutings,
 			}
 			err := r.HumanCheckPassword(tt.args.ctx, tt.args.resourceOwner, tt.args.userID, tt.args.password, tt.args.authReq, tt.args.lockoutPolicy)
 			if tt.res.err == nil {
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// newPushRegistrationChallenge returns a random challenge the device has to sign to verify its registration.
+func newPushRegistrationChallenge() (string, error) {
+	challenge := make([]byte, 32)
+	if _, err := rand.Read(challenge); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(challenge), nil
+}
+
+// newPushChallengeNumber returns a random two digit number the user has to enter on the device to approve a push challenge.
+func newPushChallengeNumber() (uint32, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(90))
+	if err != nil {
+		return 0, err
+	}
+	return uint32(n.Int64()) + 10, nil
+}
+
+// AddHumanPushDevice registers a device of the user, which approves push challenges.
+// The device has to prove the possession of the private key by signing the returned challenge ([Commands.VerifyHumanPushDevice]).
+func (c *Commands) AddHumanPushDevice(ctx context.Context, userID, resourceOwner, name, pushToken string, publicKey []byte) (*domain.PushDeviceRegistration, error) {
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahm3u", "Errors.User.UserIDMissing")
+	}
+	if pushToken == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ahQu5", "Errors.User.MFA.Push.PushTokenMissing")
+	}
+	if err := domain.ValidatePushDevicePublicKey(publicKey); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.humanPushDevicesWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	deviceID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := c.pushRegistrationChallenge()
+	if err != nil {
+		return nil, err
+	}
+	if err = c.pushAppendAndReduce(ctx, writeModel,
+		user.NewHumanPushDeviceAddedEvent(ctx, UserAggregateFromWriteModel(&writeModel.WriteModel), deviceID, name, publicKey, pushToken, challenge),
+	); err != nil {
+		return nil, err
+	}
+	return &domain.PushDeviceRegistration{
+		ObjectDetails: writeModelToObjectDetails(&writeModel.WriteModel),
+		DeviceID:      deviceID,
+		Challenge:     challenge,
+	}, nil
+}
+
+// VerifyHumanPushDevice verifies the registration of the device by the signature of the [domain.PushDeviceRegistrationData].
+func (c *Commands) VerifyHumanPushDevice(ctx context.Context, userID, resourceOwner, deviceID string, signature []byte) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ohV0e", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.humanPushDevicesWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	device := writeModel.Device(deviceID)
+	if device == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Jae4o", "Errors.User.MFA.Push.DeviceNotFound")
+	}
+	if device.State == domain.MFAStateReady {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Iej1a", "Errors.User.MFA.Push.AlreadyReady")
+	}
+	if err = domain.VerifyPushSignature(device.PublicKey, domain.PushDeviceRegistrationData(userID, deviceID, device.Challenge), signature); err != nil {
+		return nil, err
+	}
+	if err = c.pushAppendAndReduce(ctx, writeModel,
+		user.NewHumanPushDeviceVerifiedEvent(ctx, UserAggregateFromWriteModel(&writeModel.WriteModel), deviceID, device.Name),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanPushDevice(ctx context.Context, userID, resourceOwner, deviceID string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-eeQu2", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.humanPushDevicesWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.Device(deviceID) == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-ahG2o", "Errors.User.MFA.Push.DeviceNotFound")
+	}
+	if err = c.pushAppendAndReduce(ctx, writeModel,
+		user.NewHumanPushDeviceRemovedEvent(ctx, UserAggregateFromWriteModel(&writeModel.WriteModel), deviceID),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// humanPushDevicesWriteModel returns the devices of an existing user,
+// which can only be managed by the user itself or with the user.write permission.
+func (c *Commands) humanPushDevicesWriteModel(ctx context.Context, userID, resourceOwner string) (*HumanPushDevicesWriteModel, error) {
+	writeModel := NewHumanPushDevicesWriteModel(userID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if writeModel.UserState == domain.UserStateUnspecified || writeModel.UserState == domain.UserStateDeleted {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-ieD4u", "Errors.User.NotFound")
+	}
+	if userID != authz.GetCtxData(ctx).UserID {
+		if err := c.checkPermission(ctx, domain.PermissionUserWrite, writeModel.ResourceOwner, userID); err != nil {
+			return nil, err
+		}
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,110 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanPushDevice struct {
+	DeviceID  string
+	Name      string
+	PublicKey []byte
+	PushToken string
+	Challenge string
+	State     domain.MFAState
+}
+
+// HumanPushDevicesWriteModel contains all devices of a user registered for push notifications
+type HumanPushDevicesWriteModel struct {
+	eventstore.WriteModel
+
+	UserState domain.UserState
+	Devices   []*HumanPushDevice
+}
+
+func NewHumanPushDevicesWriteModel(userID, resourceOwner string) *HumanPushDevicesWriteModel {
+	return &HumanPushDevicesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanPushDevicesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent, *user.HumanRegisteredEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.HumanPushDeviceAddedEvent:
+			wm.Devices = append(wm.Devices, &HumanPushDevice{
+				DeviceID:  e.DeviceID,
+				Name:      e.Name,
+				PublicKey: e.PublicKey,
+				PushToken: e.PushToken,
+				Challenge: e.Challenge,
+				State:     domain.MFAStateNotReady,
+			})
+		case *user.HumanPushDeviceVerifiedEvent:
+			if device := wm.Device(e.DeviceID); device != nil {
+				device.State = domain.MFAStateReady
+				device.Challenge = ""
+			}
+		case *user.HumanPushDeviceRemovedEvent:
+			wm.Devices = slices.DeleteFunc(wm.Devices, func(device *HumanPushDevice) bool {
+				return device.DeviceID == e.DeviceID
+			})
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+			wm.Devices = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanPushDevicesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserV1AddedType,
+			user.UserV1RegisteredType,
+			user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.HumanPushDeviceAddedType,
+			user.HumanPushDeviceVerifiedType,
+			user.HumanPushDeviceRemovedType,
+			user.UserRemovedType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// Device returns the device with the ID or nil if it does not exist.
+func (wm *HumanPushDevicesWriteModel) Device(deviceID string) *HumanPushDevice {
+	for _, device := range wm.Devices {
+		if device.DeviceID == deviceID {
+			return device
+		}
+	}
+	return nil
+}
+
+// ReadyDevices returns the verified devices, which are able to approve push challenges.
+func (wm *HumanPushDevicesWriteModel) ReadyDevices() []*HumanPushDevice {
+	devices := make([]*HumanPushDevice, 0, len(wm.Devices))
+	for _, device := range wm.Devices {
+		if device.State == domain.MFAStateReady {
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
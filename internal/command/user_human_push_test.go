@@ -0,0 +1,349 @@
+package command
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func newTestPushDeviceKey(t *testing.T) ([]byte, ed25519.PrivateKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	return publicKeyDER, privateKey
+}
+
+func pushTestHumanAddedEvent() eventstore.Command {
+	return user.NewHumanAddedEvent(context.Background(),
+		&user.NewAggregate("user1", "org1").Aggregate,
+		"username",
+		"firstname",
+		"lastname",
+		"nickname",
+		"displayname",
+		language.German,
+		domain.GenderUnspecified,
+		"email@test.ch",
+		true,
+	)
+}
+
+func TestCommands_AddHumanPushDevice(t *testing.T) {
+	publicKey, _ := newTestPushDeviceKey(t)
+	ctx := authz.NewMockContext("instanceID", "org1", "user1")
+
+	type fields struct {
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		idGenerator     id.Generator
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx       context.Context
+		userID    string
+		pushToken string
+		publicKey []byte
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *domain.PushDeviceRegistration
+		wantErr error
+	}{
+		{
+			name: "missing userID",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:       ctx,
+				pushToken: "token",
+				publicKey: publicKey,
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahm3u", "Errors.User.UserIDMissing"),
+		},
+		{
+			name: "missing push token",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:       ctx,
+				userID:    "user1",
+				publicKey: publicKey,
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "COMMAND-ahQu5", "Errors.User.MFA.Push.PushTokenMissing"),
+		},
+		{
+			name: "invalid public key",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:       ctx,
+				userID:    "user1",
+				pushToken: "token",
+				publicKey: []byte("key"),
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ooy3a", "Errors.User.MFA.Push.PublicKeyInvalid"),
+		},
+		{
+			name: "user not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:       ctx,
+				userID:    "user1",
+				pushToken: "token",
+				publicKey: publicKey,
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-ieD4u", "Errors.User.NotFound"),
+		},
+		{
+			name: "other user, no permission",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(pushTestHumanAddedEvent()),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:       authz.NewMockContext("instanceID", "org1", "user2"),
+				userID:    "user1",
+				pushToken: "token",
+				publicKey: publicKey,
+			},
+			wantErr: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+		},
+		{
+			name: "device added",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(pushTestHumanAddedEvent()),
+					),
+					expectPush(
+						user.NewHumanPushDeviceAddedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate,
+							"device1", "phone", publicKey, "token", "challenge",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "device1"),
+			},
+			args: args{
+				ctx:       ctx,
+				userID:    "user1",
+				pushToken: "token",
+				publicKey: publicKey,
+			},
+			want: &domain.PushDeviceRegistration{
+				ObjectDetails: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+				DeviceID:  "device1",
+				Challenge: "challenge",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				idGenerator:     tt.fields.idGenerator,
+				checkPermission: tt.fields.checkPermission,
+				pushRegistrationChallenge: func() (string, error) {
+					return "challenge", nil
+				},
+			}
+			got, err := c.AddHumanPushDevice(tt.args.ctx, tt.args.userID, "org1", "phone", tt.args.pushToken, tt.args.publicKey)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_VerifyHumanPushDevice(t *testing.T) {
+	publicKey, privateKey := newTestPushDeviceKey(t)
+	ctx := authz.NewMockContext("instanceID", "org1", "user1")
+	signature := ed25519.Sign(privateKey, domain.PushDeviceRegistrationData("user1", "device1", "challenge"))
+
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		deviceID  string
+		signature []byte
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *domain.ObjectDetails
+		wantErr error
+	}{
+		{
+			name: "device not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(pushTestHumanAddedEvent()),
+					),
+				),
+			},
+			args: args{
+				deviceID:  "device1",
+				signature: signature,
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-Jae4o", "Errors.User.MFA.Push.DeviceNotFound"),
+		},
+		{
+			name: "already ready",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(pushTestHumanAddedEvent()),
+						eventFromEventPusher(
+							user.NewHumanPushDeviceAddedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate,
+								"device1", "phone", publicKey, "token", "challenge",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanPushDeviceVerifiedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate, "device1", "phone"),
+						),
+					),
+				),
+			},
+			args: args{
+				deviceID:  "device1",
+				signature: signature,
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Iej1a", "Errors.User.MFA.Push.AlreadyReady"),
+		},
+		{
+			name: "invalid signature",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(pushTestHumanAddedEvent()),
+						eventFromEventPusher(
+							user.NewHumanPushDeviceAddedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate,
+								"device1", "phone", publicKey, "token", "challenge",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				deviceID:  "device1",
+				signature: []byte("signature"),
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-ieL0u", "Errors.User.MFA.Push.SignatureInvalid"),
+		},
+		{
+			name: "verified",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(pushTestHumanAddedEvent()),
+						eventFromEventPusher(
+							user.NewHumanPushDeviceAddedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate,
+								"device1", "phone", publicKey, "token", "challenge",
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanPushDeviceVerifiedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate, "device1", "phone"),
+					),
+				),
+			},
+			args: args{
+				deviceID:  "device1",
+				signature: signature,
+			},
+			want: &domain.ObjectDetails{
+				ResourceOwner: "org1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.VerifyHumanPushDevice(ctx, "user1", "org1", tt.args.deviceID, tt.args.signature)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_RemoveHumanPushDevice(t *testing.T) {
+	publicKey, _ := newTestPushDeviceKey(t)
+	ctx := authz.NewMockContext("instanceID", "org1", "user1")
+
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		want       *domain.ObjectDetails
+		wantErr    error
+	}{
+		{
+			name: "device not found",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(pushTestHumanAddedEvent()),
+				),
+			),
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-ahG2o", "Errors.User.MFA.Push.DeviceNotFound"),
+		},
+		{
+			name: "removed",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(pushTestHumanAddedEvent()),
+					eventFromEventPusher(
+						user.NewHumanPushDeviceAddedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate,
+							"device1", "phone", publicKey, "token", "challenge",
+						),
+					),
+				),
+				expectPush(
+					user.NewHumanPushDeviceRemovedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate, "device1"),
+				),
+			),
+			want: &domain.ObjectDetails{
+				ResourceOwner: "org1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.RemoveHumanPushDevice(ctx, "user1", "org1", "device1")
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
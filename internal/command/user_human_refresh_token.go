@@ -10,6 +10,10 @@ import (
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
+// AddAccessAndRefreshToken creates a new refresh token if none is passed, otherwise the refresh token is renewed.
+// A new refresh token is granted the grantedAuthorizationDetails of the auth request.
+// The access token gets the requested authorizationDetails, which must be part of the ones granted to the refresh token.
+// If none are requested, it gets all granted details.
 func (c *Commands) AddAccessAndRefreshToken(
 	ctx context.Context,
 	orgID,
@@ -22,13 +26,17 @@ func (c *Commands) AddAccessAndRefreshToken(
 	authMethodsReferences []string,
 	accessLifetime,
 	refreshIdleExpiration,
-	refreshExpiration time.Duration,
+	refreshExpiration,
+	refreshReuseGracePeriod time.Duration,
 	authTime time.Time,
+	certificateThumbprint string,
+	grantedAuthorizationDetails,
+	authorizationDetails domain.AuthorizationDetails,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if refreshToken == "" {
-		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime)
+		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime, certificateThumbprint, grantedAuthorizationDetails, authorizationDetails)
 	}
-	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime)
+	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime, refreshReuseGracePeriod, certificateThumbprint, authorizationDetails)
 }
 
 func (c *Commands) AddNewRefreshTokenAndAccessToken(
@@ -44,20 +52,27 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	accessLifetime,
 	refreshIdleExpiration time.Duration,
 	authTime time.Time,
+	certificateThumbprint string,
+	grantedAuthorizationDetails,
+	authorizationDetails domain.AuthorizationDetails,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if userID == "" || clientID == "" {
 		return nil, "", zerrors.ThrowInvalidArgument(nil, "COMMAND-adg4r", "Errors.IDMissing")
 	}
+	authorizationDetails, err = accessTokenAuthorizationDetails(grantedAuthorizationDetails, authorizationDetails)
+	if err != nil {
+		return nil, "", err
+	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
 	refreshTokenID, err := c.idGenerator.Next()
 	if err != nil {
 		return nil, "", err
 	}
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, certificateThumbprint, audience, scopes, accessLifetime, authorizationDetails)
 	if err != nil {
 		return nil, "", err
 	}
-	refreshTokenEvent, newRefreshToken, err := c.addRefreshToken(ctx, accessToken, authMethodsReferences, authTime, refreshIdleExpiration, refreshExpiration)
+	refreshTokenEvent, newRefreshToken, err := c.addRefreshToken(ctx, accessToken, authMethodsReferences, authTime, refreshIdleExpiration, refreshExpiration, grantedAuthorizationDetails)
 	if err != nil {
 		return nil, "", err
 	}
@@ -68,6 +83,10 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	return accessToken, newRefreshToken, nil
 }
 
+// RenewRefreshTokenAndAccessToken rotates the refresh token and creates a new access token.
+// If the same token is renewed concurrently, only one rotation succeeds.
+// The other request is evaluated again against the rotated token,
+// so it either gets the current token within the reuse grace period or the token family is revoked.
 func (c *Commands) RenewRefreshTokenAndAccessToken(
 	ctx context.Context,
 	userID,
@@ -78,18 +97,52 @@ func (c *Commands) RenewRefreshTokenAndAccessToken(
 	audience,
 	scopes []string,
 	idleExpiration,
-	accessLifetime time.Duration,
+	accessLifetime,
+	reuseGracePeriod time.Duration,
+	certificateThumbprint string,
+	authorizationDetails domain.AuthorizationDetails,
+) (accessToken *domain.Token, newRefreshToken string, err error) {
+	accessToken, newRefreshToken, err = c.renewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, idleExpiration, accessLifetime, reuseGracePeriod, certificateThumbprint, authorizationDetails)
+	if !zerrors.IsErrorAlreadyExists(err) {
+		return accessToken, newRefreshToken, err
+	}
+	return c.renewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, idleExpiration, accessLifetime, reuseGracePeriod, certificateThumbprint, authorizationDetails)
+}
+
+func (c *Commands) renewRefreshTokenAndAccessToken(
+	ctx context.Context,
+	userID,
+	orgID,
+	refreshToken,
+	agentID,
+	clientID string,
+	audience,
+	scopes []string,
+	idleExpiration,
+	accessLifetime,
+	reuseGracePeriod time.Duration,
+	certificateThumbprint string,
+	authorizationDetails domain.AuthorizationDetails,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
-	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration)
+	refreshTokenEvent, refreshTokenWriteModel, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, clientID, idleExpiration, reuseGracePeriod)
+	if err != nil {
+		return nil, "", err
+	}
+	authorizationDetails, err = accessTokenAuthorizationDetails(refreshTokenWriteModel.AuthorizationDetails, authorizationDetails)
 	if err != nil {
 		return nil, "", err
 	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenWriteModel.TokenID, certificateThumbprint, audience, scopes, accessLifetime, authorizationDetails)
 	if err != nil {
 		return nil, "", err
 	}
-	_, err = c.eventstore.Push(ctx, accessTokenEvent, refreshTokenEvent)
+	cmds := []eventstore.Command{accessTokenEvent}
+	// the refresh token is not rotated again if it was requested concurrently within the grace period
+	if refreshTokenEvent != nil {
+		cmds = append(cmds, refreshTokenEvent)
+	}
+	_, err = c.eventstore.Push(ctx, cmds...)
 	if err != nil {
 		return nil, "", err
 	}
@@ -128,7 +181,7 @@ func (c *Commands) RevokeRefreshTokens(ctx context.Context, userID, orgID string
 	return err
 }
 
-func (c *Commands) addRefreshToken(ctx context.Context, accessToken *domain.Token, authMethodsReferences []string, authTime time.Time, idleExpiration, expiration time.Duration) (*user.HumanRefreshTokenAddedEvent, string, error) {
+func (c *Commands) addRefreshToken(ctx context.Context, accessToken *domain.Token, authMethodsReferences []string, authTime time.Time, idleExpiration, expiration time.Duration, authorizationDetails domain.AuthorizationDetails) (*user.HumanRefreshTokenAddedEvent, string, error) {
 	refreshToken, err := domain.NewRefreshToken(accessToken.AggregateID, accessToken.RefreshTokenID, c.keyAlgorithm)
 	if err != nil {
 		return nil, "", err
@@ -136,46 +189,94 @@ func (c *Commands) addRefreshToken(ctx context.Context, accessToken *domain.Toke
 	refreshTokenWriteModel := NewHumanRefreshTokenWriteModel(accessToken.AggregateID, accessToken.ResourceOwner, accessToken.RefreshTokenID)
 	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
 	return user.NewHumanRefreshTokenAddedEvent(ctx, userAgg, accessToken.RefreshTokenID, accessToken.ApplicationID, accessToken.UserAgentID,
-			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration),
+			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration, authorizationDetails),
 		refreshToken, nil
 }
 
-func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken string, idleExpiration time.Duration) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
+// renewRefreshToken rotates the refresh token, so every token can only be used once.
+// If an already rotated token is presented, the whole token family is revoked,
+// unless the same client presents the directly preceding token within the reuseGracePeriod.
+// In that case the current token is returned without another rotation.
+func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken, clientID string, idleExpiration, reuseGracePeriod time.Duration) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenWriteModel *HumanRefreshTokenWriteModel, newRefreshToken string, err error) {
 	if refreshToken == "" {
-		return nil, "", "", zerrors.ThrowInvalidArgument(nil, "COMMAND-DHrr3", "Errors.IDMissing")
+		return nil, nil, "", zerrors.ThrowInvalidArgument(nil, "COMMAND-DHrr3", "Errors.IDMissing")
 	}
 
 	tokenUserID, tokenID, token, err := domain.FromRefreshToken(refreshToken, c.keyAlgorithm)
 	if err != nil {
-		return nil, "", "", zerrors.ThrowInvalidArgument(err, "COMMAND-Dbfe4", "Errors.User.RefreshToken.Invalid")
+		return nil, nil, "", zerrors.ThrowInvalidArgument(err, "COMMAND-Dbfe4", "Errors.User.RefreshToken.Invalid")
 	}
 	if tokenUserID != userID {
-		return nil, "", "", zerrors.ThrowInvalidArgument(nil, "COMMAND-Ht2g2", "Errors.User.RefreshToken.Invalid")
+		return nil, nil, "", zerrors.ThrowInvalidArgument(nil, "COMMAND-Ht2g2", "Errors.User.RefreshToken.Invalid")
 	}
-	refreshTokenWriteModel := NewHumanRefreshTokenWriteModel(userID, orgID, tokenID)
+	refreshTokenWriteModel = NewHumanRefreshTokenWriteModel(userID, orgID, tokenID)
 	err = c.eventstore.FilterToQueryReducer(ctx, refreshTokenWriteModel)
 	if err != nil {
-		return nil, "", "", err
+		return nil, nil, "", err
 	}
 	if refreshTokenWriteModel.UserState != domain.UserStateActive {
-		return nil, "", "", zerrors.ThrowInvalidArgument(nil, "COMMAND-BHnhs", "Errors.User.RefreshToken.Invalid")
+		return nil, nil, "", zerrors.ThrowInvalidArgument(nil, "COMMAND-BHnhs", "Errors.User.RefreshToken.Invalid")
 	}
-	if refreshTokenWriteModel.RefreshToken != token ||
-		refreshTokenWriteModel.IdleExpiration.Before(time.Now()) ||
+	if refreshTokenWriteModel.IdleExpiration.Before(time.Now()) ||
 		refreshTokenWriteModel.Expiration.Before(time.Now()) {
-		return nil, "", "", zerrors.ThrowInvalidArgument(nil, "COMMAND-Vr43e", "Errors.User.RefreshToken.Invalid")
+		return nil, nil, "", zerrors.ThrowInvalidArgument(nil, "COMMAND-Vr43e", "Errors.User.RefreshToken.Invalid")
+	}
+	if refreshTokenWriteModel.RefreshToken != token {
+		if refreshTokenWriteModel.isWithinGracePeriod(token, clientID, reuseGracePeriod) {
+			currentRefreshToken, err := domain.RefreshToken(userID, tokenID, refreshTokenWriteModel.RefreshToken, c.keyAlgorithm)
+			if err != nil {
+				return nil, nil, "", err
+			}
+			return nil, refreshTokenWriteModel, currentRefreshToken, nil
+		}
+		if refreshTokenWriteModel.isRotated(token) {
+			if err = c.revokeRefreshTokenFamily(ctx, refreshTokenWriteModel); err != nil {
+				return nil, nil, "", err
+			}
+			return nil, nil, "", zerrors.ThrowInvalidArgument(nil, "COMMAND-eeR7a", "Errors.User.RefreshToken.Reused")
+		}
+		return nil, nil, "", zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohn3k", "Errors.User.RefreshToken.Invalid")
 	}
 
 	newToken, err := c.idGenerator.Next()
 	if err != nil {
-		return nil, "", "", err
+		return nil, nil, "", err
 	}
 	newRefreshToken, err = domain.RefreshToken(userID, tokenID, newToken, c.keyAlgorithm)
 	if err != nil {
-		return nil, "", "", err
+		return nil, nil, "", err
 	}
 	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
-	return user.NewHumanRefreshTokenRenewedEvent(ctx, userAgg, tokenID, newToken, idleExpiration), tokenID, newRefreshToken, nil
+	return user.NewHumanRefreshTokenRenewedEvent(ctx, userAgg, tokenID, newToken, token, refreshTokenWriteModel.PreviousRefreshToken, idleExpiration),
+		refreshTokenWriteModel, newRefreshToken, nil
+}
+
+// revokeRefreshTokenFamily revokes the token (and with it all its rotations)
+// after an already rotated token was presented again.
+func (c *Commands) revokeRefreshTokenFamily(ctx context.Context, refreshTokenWriteModel *HumanRefreshTokenWriteModel) error {
+	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
+	_, err := c.eventstore.Push(ctx,
+		user.NewHumanRefreshTokenReuseDetectedEvent(ctx, userAgg, refreshTokenWriteModel.TokenID, refreshTokenWriteModel.ClientID, refreshTokenWriteModel.UserAgentID),
+		user.NewHumanRefreshTokenRemovedEvent(ctx, userAgg, refreshTokenWriteModel.TokenID),
+	)
+	return err
+}
+
+// RefreshTokenReuseDetectedSent records that the user was notified about the reused refresh token.
+func (c *Commands) RefreshTokenReuseDetectedSent(ctx context.Context, orgID, userID, tokenID string) (err error) {
+	if userID == "" || tokenID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Iet8o", "Errors.IDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ahw4e", "Errors.User.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingUser.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanRefreshTokenReuseDetectedSentEvent(ctx, userAgg, tokenID))
+	return err
 }
 
 func (c *Commands) removeRefreshToken(ctx context.Context, userID, orgID, tokenID string) (*user.HumanRefreshTokenRemovedEvent, *HumanRefreshTokenWriteModel, error) {
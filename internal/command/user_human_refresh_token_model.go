@@ -1,6 +1,7 @@
 package command
 
 import (
+	"slices"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -19,6 +20,16 @@ type HumanRefreshTokenWriteModel struct {
 	IdleExpiration time.Time
 	Expiration     time.Time
 	UserAgentID    string
+	ClientID       string
+	// AuthorizationDetails are granted to the refresh token, its access tokens can only narrow them down
+	AuthorizationDetails domain.AuthorizationDetails
+
+	// PreviousRefreshToken and RenewedAt describe the last rotation
+	// and are used to tolerate concurrent refresh requests within the grace period.
+	PreviousRefreshToken string
+	RenewedAt            time.Time
+	// RotatedRefreshTokens contains all token values which were replaced by a rotation.
+	RotatedRefreshTokens []string
 }
 
 func NewHumanRefreshTokenWriteModel(userID, resourceOwner, tokenID string) *HumanRefreshTokenWriteModel {
@@ -65,10 +76,15 @@ func (wm *HumanRefreshTokenWriteModel) Reduce() error {
 			wm.Expiration = e.CreationDate().Add(e.Expiration)
 			wm.UserState = domain.UserStateActive
 			wm.UserAgentID = e.UserAgentID
+			wm.ClientID = e.ClientID
+			wm.AuthorizationDetails = e.AuthorizationDetails
 		case *user.HumanRefreshTokenRenewedEvent:
-			if wm.UserState == domain.UserStateActive {
-				wm.RefreshToken = e.RefreshToken
+			if wm.UserState != domain.UserStateActive {
+				continue
 			}
+			wm.PreviousRefreshToken = wm.RefreshToken
+			wm.RotatedRefreshTokens = append(wm.RotatedRefreshTokens, wm.RefreshToken)
+			wm.RenewedAt = e.CreationDate()
 			wm.RefreshToken = e.RefreshToken
 			wm.IdleExpiration = e.CreationDate().Add(e.IdleExpiration)
 		case *user.HumanSignedOutEvent:
@@ -105,3 +121,18 @@ func (wm *HumanRefreshTokenWriteModel) Query() *eventstore.SearchQueryBuilder {
 	}
 	return query
 }
+
+// isRotated checks if the token was already replaced by a rotation.
+func (wm *HumanRefreshTokenWriteModel) isRotated(token string) bool {
+	return slices.Contains(wm.RotatedRefreshTokens, token)
+}
+
+// isWithinGracePeriod checks if the token is the one replaced by the last rotation,
+// the rotation happened at most gracePeriod ago and the same client presents it.
+func (wm *HumanRefreshTokenWriteModel) isWithinGracePeriod(token, clientID string, gracePeriod time.Duration) bool {
+	return gracePeriod > 0 &&
+		wm.PreviousRefreshToken != "" &&
+		wm.PreviousRefreshToken == token &&
+		wm.ClientID == clientID &&
+		time.Since(wm.RenewedAt) <= gracePeriod
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -40,6 +41,8 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		authTime              time.Time
 		refreshIdleExpiration time.Duration
 		refreshExpiration     time.Duration
+		grantedDetails        domain.AuthorizationDetails
+		authorizationDetails  domain.AuthorizationDetails
 	}
 	type res struct {
 		token        *domain.Token
@@ -87,6 +90,65 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 				err: zerrors.IsNotFound,
 			},
 		},
+		{
+			name: "add refresh token, authorization details not granted, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:                  context.Background(),
+				orgID:                "orgID",
+				agentID:              "agentID",
+				userID:               "userID",
+				clientID:             "clientID",
+				grantedDetails:       domain.AuthorizationDetails{{Type: "payment_initiation"}},
+				authorizationDetails: domain.AuthorizationDetails{{Type: "account_information"}},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "renew refresh token, authorization details not granted to refresh token, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							domain.AuthorizationDetails{{Type: "payment_initiation"}},
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "refreshToken1"),
+			},
+			args: args{
+				ctx:                   context.Background(),
+				orgID:                 "orgID",
+				agentID:               "agentID",
+				userID:                "userID",
+				clientID:              "applicationID",
+				refreshToken:          base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				refreshIdleExpiration: 1 * time.Hour,
+				// the details granted by the auth request are ignored on renewal
+				grantedDetails:       domain.AuthorizationDetails{{Type: "account_information"}},
+				authorizationDetails: domain.AuthorizationDetails{{Type: "account_information"}},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "renew refresh token, invalid token, error",
 			fields: fields{
@@ -135,6 +197,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
 							context.Background(),
@@ -173,6 +236,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							time.Now(),
 							-1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 					),
 				),
@@ -237,7 +301,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		//					time.Now(),
 		//					1*time.Hour,
 		//					24*time.Hour,
-		//				)),
+		//				, nil)),
 		//			),
 		//			expectPushFailed(
 		//				zerrors.ThrowInternal(nil, "ERROR", "internal"),
@@ -252,12 +316,14 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		//						[]string{"clientID1"},
 		//						[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 		//						time.Now().Add(5*time.Minute),
-		//					)),
+		//					, nil)),
 		//					eventFromEventPusher(user.NewHumanRefreshTokenRenewedEvent(
 		//						context.Background(),
 		//						&user.NewAggregate("userID", "orgID").Aggregate,
 		//						"tokenID",
 		//						"refreshToken1",
+		//						"tokenID",
+		//						"",
 		//						1*time.Hour,
 		//					)),
 		//				},
@@ -292,7 +358,8 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
 			got, gotRefresh, err := c.AddAccessAndRefreshToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.refreshToken,
-				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, tt.args.authTime)
+				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, 0, tt.args.authTime, "",
+				tt.args.grantedDetails, tt.args.authorizationDetails)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -307,6 +374,77 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 	}
 }
 
+func TestCommands_RenewRefreshTokenAndAccessToken_concurrentRenewal(t *testing.T) {
+	refreshTokenAdded := func() eventstore.Event {
+		return eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+			context.Background(),
+			&user.NewAggregate("userID", "orgID").Aggregate,
+			"tokenID",
+			"applicationID",
+			"userAgentID",
+			"de",
+			[]string{"clientID1"},
+			[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+			[]string{"password"},
+			time.Now(),
+			1*time.Hour,
+			24*time.Hour,
+			nil,
+		))
+	}
+	humanAdded := func() eventstore.Event {
+		return eventFromEventPusher(user.NewHumanAddedEvent(
+			context.Background(),
+			&user.NewAggregate("userID", "orgID").Aggregate,
+			"username",
+			"firstname",
+			"lastname",
+			"nickname",
+			"displayname",
+			language.German,
+			domain.GenderUnspecified,
+			"email@test.ch",
+			true,
+		))
+	}
+	c := &Commands{
+		eventstore: eventstoreExpect(t,
+			expectFilter(refreshTokenAdded()),
+			expectFilter(humanAdded()),
+			expectFilter(humanAdded()),
+			// the token was renewed by a concurrent request
+			expectRandomPushFailed(
+				zerrors.ThrowAlreadyExists(nil, "V3-DKcYh", "Errors.User.RefreshToken.AlreadyRotated"),
+				[]eventstore.Command{&user.UserTokenAddedEvent{}, &user.HumanRefreshTokenRenewedEvent{}},
+			),
+			expectFilter(
+				refreshTokenAdded(),
+				eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+					context.Background(),
+					&user.NewAggregate("userID", "orgID").Aggregate,
+					"tokenID",
+					"refreshToken2",
+					"tokenID",
+					"",
+					1*time.Hour,
+				)),
+			),
+			expectFilter(humanAdded()),
+			expectFilter(humanAdded()),
+			expectRandomPush([]eventstore.Command{&user.UserTokenAddedEvent{}}),
+		),
+		idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "refreshToken1", "accessTokenID1", "accessTokenID2"),
+		keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+	}
+	got, gotRefresh, err := c.RenewRefreshTokenAndAccessToken(context.Background(), "userID", "orgID",
+		base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")), "agentID", "applicationID",
+		[]string{"clientID1"}, []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess}, 1*time.Hour, 5*time.Minute, 10*time.Second, "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "accessTokenID2", got.TokenID)
+	// the token of the concurrent renewal is returned within the grace period
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:refreshToken2")), gotRefresh)
+}
+
 func TestCommands_RevokeRefreshToken(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
@@ -372,6 +510,7 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectPushFailed(zerrors.ThrowInternal(nil, "ERROR", "internal"),
@@ -411,6 +550,7 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectPush(
@@ -506,6 +646,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectFilter(),
@@ -539,6 +680,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectFilter(
@@ -555,6 +697,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectPushFailed(zerrors.ThrowInternal(nil, "ERROR", "internal"),
@@ -599,6 +742,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectFilter(
@@ -615,6 +759,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectPush(
@@ -691,6 +836,7 @@ func TestCommands_addRefreshToken(t *testing.T) {
 		authTime              time.Time
 		idleExpiration        time.Duration
 		expiration            time.Duration
+		authorizationDetails  domain.AuthorizationDetails
 	}
 	type res struct {
 		event        *user.HumanRefreshTokenAddedEvent
@@ -730,6 +876,7 @@ func TestCommands_addRefreshToken(t *testing.T) {
 				authTime:              authTime,
 				idleExpiration:        1 * time.Hour,
 				expiration:            10 * time.Hour,
+				authorizationDetails:  domain.AuthorizationDetails{{Type: "payment_initiation"}},
 			},
 			res: res{
 				event: user.NewHumanRefreshTokenAddedEvent(
@@ -745,6 +892,7 @@ func TestCommands_addRefreshToken(t *testing.T) {
 					authTime,
 					1*time.Hour,
 					10*time.Hour,
+					domain.AuthorizationDetails{{Type: "payment_initiation"}},
 				),
 				refreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:refreshTokenID:refreshTokenID")),
 			},
@@ -756,7 +904,7 @@ func TestCommands_addRefreshToken(t *testing.T) {
 				eventstore:   tt.fields.eventstore,
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
-			gotEvent, gotRefreshToken, err := c.addRefreshToken(tt.args.ctx, tt.args.accessToken, tt.args.authMethodsReferences, tt.args.authTime, tt.args.idleExpiration, tt.args.expiration)
+			gotEvent, gotRefreshToken, err := c.addRefreshToken(tt.args.ctx, tt.args.accessToken, tt.args.authMethodsReferences, tt.args.authTime, tt.args.idleExpiration, tt.args.expiration, tt.args.authorizationDetails)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -778,11 +926,13 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 		keyAlgorithm crypto.EncryptionAlgorithm
 	}
 	type args struct {
-		ctx            context.Context
-		userID         string
-		orgID          string
-		refreshToken   string
-		idleExpiration time.Duration
+		ctx              context.Context
+		userID           string
+		orgID            string
+		refreshToken     string
+		clientID         string
+		idleExpiration   time.Duration
+		reuseGracePeriod time.Duration
 	}
 	type res struct {
 		event           *user.HumanRefreshTokenRenewedEvent
@@ -856,6 +1006,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
 							context.Background(),
@@ -894,6 +1045,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 					),
 				),
@@ -927,6 +1079,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 						eventFromEventPusher(
 							user.NewUserDeactivatedEvent(
@@ -967,6 +1120,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 						eventFromEventPusher(
 							user.NewHumanSignedOutEvent(
@@ -1008,6 +1162,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 					),
 				),
@@ -1027,12 +1182,282 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 					&user.NewAggregate("userID", "orgID").Aggregate,
 					"tokenID",
 					"refreshToken1",
+					"tokenID",
+					"",
 					1*time.Hour,
 				),
 				refreshTokenID:  "tokenID",
 				newRefreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:refreshToken1")),
 			},
 		},
+		{
+			name: "renewed token renewed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							"tokenID",
+							"",
+							1*time.Hour,
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "refreshToken2"),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:refreshToken1")),
+				idleExpiration: 1 * time.Hour,
+			},
+			res: res{
+				event: user.NewHumanRefreshTokenRenewedEvent(
+					context.Background(),
+					&user.NewAggregate("userID", "orgID").Aggregate,
+					"tokenID",
+					"refreshToken2",
+					"refreshToken1",
+					"tokenID",
+					1*time.Hour,
+				),
+				refreshTokenID:  "tokenID",
+				newRefreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:refreshToken2")),
+			},
+		},
+		{
+			name: "unknown token, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							"tokenID",
+							"",
+							1*time.Hour,
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:              context.Background(),
+				userID:           "userID",
+				orgID:            "orgID",
+				refreshToken:     base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:unknown")),
+				clientID:         "applicationID",
+				idleExpiration:   1 * time.Hour,
+				reuseGracePeriod: 10 * time.Second,
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "rotated token reused, token revoked",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							"tokenID",
+							"",
+							1*time.Hour,
+						)),
+					),
+					expectPush(
+						user.NewHumanRefreshTokenReuseDetectedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+						),
+						user.NewHumanRefreshTokenRemovedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+						),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:              context.Background(),
+				userID:           "userID",
+				orgID:            "orgID",
+				refreshToken:     base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				clientID:         "applicationID",
+				idleExpiration:   1 * time.Hour,
+				reuseGracePeriod: 0,
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "previous token within grace period from other client, token revoked",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							"tokenID",
+							"",
+							1*time.Hour,
+						)),
+					),
+					expectPush(
+						user.NewHumanRefreshTokenReuseDetectedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+						),
+						user.NewHumanRefreshTokenRemovedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+						),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:              context.Background(),
+				userID:           "userID",
+				orgID:            "orgID",
+				refreshToken:     base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				clientID:         "otherClientID",
+				idleExpiration:   1 * time.Hour,
+				reuseGracePeriod: 10 * time.Second,
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "previous token within grace period, current token returned",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							"tokenID",
+							"",
+							1*time.Hour,
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:              context.Background(),
+				userID:           "userID",
+				orgID:            "orgID",
+				refreshToken:     base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				clientID:         "applicationID",
+				idleExpiration:   1 * time.Hour,
+				reuseGracePeriod: 10 * time.Second,
+			},
+			res: res{
+				refreshTokenID:  "tokenID",
+				newRefreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:refreshToken1")),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1041,7 +1466,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				idGenerator:  tt.fields.idGenerator,
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
-			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.idleExpiration)
+			gotEvent, gotWriteModel, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.clientID, tt.args.idleExpiration, tt.args.reuseGracePeriod)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -1050,7 +1475,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 			}
 			if tt.res.err == nil {
 				assert.Equal(t, tt.res.event, gotEvent)
-				assert.Equal(t, tt.res.refreshTokenID, gotRefreshTokenID)
+				assert.Equal(t, tt.res.refreshTokenID, gotWriteModel.TokenID)
 				assert.Equal(t, tt.res.newRefreshToken, gotNewRefreshToken)
 			}
 		})
@@ -157,7 +157,11 @@ func (c *Commands) addHumanWebAuthN(ctx context.Context, userID, resourceowner,
 	if accountName == "" {
 		accountName = string(user.EmailAddress)
 	}
-	webAuthN, err := c.webauthnConfig.BeginRegistration(ctx, user, accountName, authenticatorPlatform, userVerification, rpID, tokens...)
+	attestationPolicy, err := c.getOrgWebAuthNAttestationPolicy(ctx, org.AggregateID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	webAuthN, err := c.webauthnConfig.BeginRegistration(ctx, user, accountName, authenticatorPlatform, userVerification, attestationPolicy, rpID, tokens...)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -196,6 +200,8 @@ func (c *Commands) HumanVerifyU2FSetup(ctx context.Context, userID, resourceowne
 			webAuthN.AAGUID,
 			webAuthN.SignCount,
 			userAgentID,
+			webAuthN.AuthenticatorName,
+			webAuthN.AuthenticatorIcon,
 		),
 	)
 	if err != nil {
@@ -247,6 +253,8 @@ func (c *Commands) humanHumanPasswordlessSetup(ctx context.Context, userID, reso
 			webAuthN.AAGUID,
 			webAuthN.SignCount,
 			userAgentID,
+			webAuthN.AuthenticatorName,
+			webAuthN.AuthenticatorIcon,
 		),
 	}
 	if codeCheckEvent != nil {
@@ -271,8 +279,12 @@ func (c *Commands) verifyHumanWebAuthN(ctx context.Context, userID, resourceowne
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	attestationPolicy, err := c.getOrgWebAuthNAttestationPolicy(ctx, user.ResourceOwner)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	_, token := domain.GetTokenToVerify(tokens)
-	webAuthN, err := c.webauthnConfig.FinishRegistration(ctx, user, token, tokenName, credentialData, userAgentID != "")
+	webAuthN, err := c.webauthnConfig.FinishRegistration(ctx, user, token, tokenName, credentialData, userAgentID != "", attestationPolicy)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -406,7 +418,29 @@ func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, res
 	if err != nil {
 		return err
 	}
+	return c.finishPasswordlessLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, authRequest)
+}
+
+// BeginPasskeyLogin starts a passwordless login with a discoverable credential (passkey),
+// where the user is identified by the assertion instead of being selected beforehand.
+// As there's no user yet, the returned challenge has to be kept by the caller (e.g. on the auth request).
+func (c *Commands) BeginPasskeyLogin(ctx context.Context) (*domain.WebAuthNLogin, error) {
+	return c.webauthnConfig.BeginDiscoverableLogin(ctx, domain.UserVerificationRequirementRequired, "")
+}
+
+// HumanFinishPasskeyLogin verifies the assertion of the discoverable credential against the challenge
+// created by [Commands.BeginPasskeyLogin] for the user identified by its user handle.
+func (c *Commands) HumanFinishPasskeyLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, webAuthNLogin *domain.WebAuthNLogin, authRequest *domain.AuthRequest) error {
+	if webAuthNLogin == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Aiv7o", "Errors.User.WebAuthN.NotFound")
+	}
+	// the challenge was not bound to a user, but the assertion must be verified for the identified one
+	passkeyLogin := *webAuthNLogin
+	passkeyLogin.AggregateID = userID
+	return c.finishPasswordlessLogin(ctx, userID, resourceOwner, credentialData, &passkeyLogin, authRequest)
+}
 
+func (c *Commands) finishPasswordlessLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, webAuthNLogin *domain.WebAuthNLogin, authRequest *domain.AuthRequest) error {
 	passwordlessTokens, err := c.getHumanPasswordlessTokens(ctx, userID, resourceOwner)
 	if err != nil {
 		return err
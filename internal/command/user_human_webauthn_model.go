@@ -22,6 +22,8 @@ type HumanWebAuthNWriteModel struct {
 	SignCount         uint32
 	WebAuthNTokenName string
 	RPID              string
+	AuthenticatorName string
+	AuthenticatorIcon string
 
 	State domain.MFAState
 }
@@ -125,6 +127,8 @@ func (wm *HumanWebAuthNWriteModel) appendVerifiedEvent(e *user.HumanWebAuthNVeri
 	wm.AAGUID = e.AAGUID
 	wm.SignCount = e.SignCount
 	wm.WebAuthNTokenName = e.WebAuthNTokenName
+	wm.AuthenticatorName = e.AuthenticatorName
+	wm.AuthenticatorIcon = e.AuthenticatorIcon
 	wm.State = domain.MFAStateReady
 }
 
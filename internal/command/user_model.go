@@ -14,6 +14,9 @@ type UserWriteModel struct {
 	IDPLinks  []*domain.UserIDPLink
 	UserState domain.UserState
 	UserType  domain.UserType
+
+	// DeactivatedBy is the editor of the deactivation of an inactive user.
+	DeactivatedBy string
 }
 
 func NewUserWriteModel(userID, resourceOwner string) *UserWriteModel {
@@ -76,10 +79,12 @@ func (wm *UserWriteModel) Reduce() error {
 		case *user.UserDeactivatedEvent:
 			if wm.UserState != domain.UserStateDeleted {
 				wm.UserState = domain.UserStateInactive
+				wm.DeactivatedBy = e.Creator()
 			}
 		case *user.UserReactivatedEvent:
 			if wm.UserState != domain.UserStateDeleted {
 				wm.UserState = domain.UserStateActive
+				wm.DeactivatedBy = ""
 			}
 		case *user.UserRemovedEvent:
 			wm.UserState = domain.UserStateDeleted
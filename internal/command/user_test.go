@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -804,6 +805,218 @@ func TestCommandSide_ReactivateUser(t *testing.T) {
 	}
 }
 
+func TestCommandSide_ReactivateUserDeactivatedBy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		orgID    string
+		userID   string
+		editorID string
+	}
+	type res struct {
+		want bool
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "",
+				editorID: "editor1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user active, not reactivated",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "user1",
+				editorID: "editor1",
+			},
+			res: res{
+				want: false,
+			},
+		},
+		{
+			name: "user deactivated by other editor, not reactivated",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewUserDeactivatedEvent(authz.SetCtxData(context.Background(), authz.CtxData{UserID: "admin1"}),
+								&user.NewAggregate("user1", "org1").Aggregate),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "user1",
+				editorID: "editor1",
+			},
+			res: res{
+				want: false,
+			},
+		},
+		{
+			name: "user reactivated and deactivated by other editor, not reactivated",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewUserDeactivatedEvent(authz.SetCtxData(context.Background(), authz.CtxData{UserID: "editor1"}),
+								&user.NewAggregate("user1", "org1").Aggregate),
+						),
+						eventFromEventPusher(
+							user.NewUserReactivatedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate),
+						),
+						eventFromEventPusher(
+							user.NewUserDeactivatedEvent(authz.SetCtxData(context.Background(), authz.CtxData{UserID: "admin1"}),
+								&user.NewAggregate("user1", "org1").Aggregate),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "user1",
+				editorID: "editor1",
+			},
+			res: res{
+				want: false,
+			},
+		},
+		{
+			name: "user deactivated by editor, reactivated",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewUserDeactivatedEvent(authz.SetCtxData(context.Background(), authz.CtxData{UserID: "editor1"}),
+								&user.NewAggregate("user1", "org1").Aggregate),
+						),
+					),
+					expectPush(
+						user.NewUserReactivatedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "org1",
+				userID:   "user1",
+				editorID: "editor1",
+			},
+			res: res{
+				want: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ReactivateUserDeactivatedBy(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.editorID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
 func TestCommandSide_LockUser(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
@@ -1500,7 +1713,7 @@ func TestCommandSide_AddUserToken(t *testing.T) {
 				eventstore:  tt.fields.eventstore,
 				idGenerator: tt.fields.idGenerator,
 			}
-			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, tt.args.lifetime)
+			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, tt.args.lifetime, "", nil)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -1566,6 +1779,8 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								[]string{"clientID"},
 								[]string{"openid"},
 								time.Now(),
+								"",
+								nil,
 							),
 						),
 					),
@@ -1598,6 +1813,8 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								[]string{"clientID"},
 								[]string{"openid"},
 								time.Now().Add(5*time.Hour),
+								"",
+								nil,
 							),
 						),
 					),
@@ -159,3 +159,21 @@ func (c *Commands) addUserPasskeyCode(ctx context.Context, userID, resourceOwner
 func (c *Commands) newPasskeyCode(ctx context.Context, filter preparation.FilterToQueryReducer, alg crypto.EncryptionAlgorithm) (*CryptoCode, error) {
 	return c.newCode(ctx, filter, domain.SecretGeneratorTypePasswordlessInitCode, alg)
 }
+
+// RemoveUserPasskey removes the passkey of the user.
+// Users can always remove their own passkeys, for other users the user.write permission is required.
+func (c *Commands) RemoveUserPasskey(ctx context.Context, userID, resourceOwner, passkeyID string) (*domain.ObjectDetails, error) {
+	if userID == "" || passkeyID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Phoo2", "Errors.IDMissing")
+	}
+	if userID != authz.GetCtxData(ctx).UserID {
+		existing, err := c.webauthNWriteModelByID(ctx, userID, passkeyID, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if err = c.checkPermission(ctx, domain.PermissionUserWrite, existing.ResourceOwner, userID); err != nil {
+			return nil, err
+		}
+	}
+	return c.HumanRemovePasswordless(ctx, userID, passkeyID, resourceOwner)
+}
@@ -103,6 +103,8 @@ func TestCommands_RegisterUserPasskey(t *testing.T) {
 							false, false, false,
 						),
 					)),
+					expectFilter(), // org webauthn attestation policy
+					expectFilter(), // instance webauthn attestation policy
 				),
 				idGenerator: id_mock.NewIDGeneratorExpectError(t, io.ErrClosedPipe),
 			},
@@ -374,6 +376,8 @@ func TestCommands_pushUserPasskey(t *testing.T) {
 				false, false, false,
 			),
 		)),
+		expectFilter(), // org webauthn attestation policy
+		expectFilter(), // instance webauthn attestation policy
 		expectFilter(eventFromEventPusher(
 			user.NewHumanWebAuthNAddedEvent(eventstore.NewBaseEventForPush(
 				ctx, &org.NewAggregate("org1").Aggregate, user.HumanPasswordlessTokenAddedType,
@@ -900,3 +904,133 @@ func TestCommands_addUserPasskeyCode(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands_RemoveUserPasskey(t *testing.T) {
+	ctx := authz.NewMockContextWithPermissions("instance1", "org1", "user1", nil)
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	otherUserAgg := &user.NewAggregate("user2", "org1").Aggregate
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		userID        string
+		resourceOwner string
+		passkeyID     string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *domain.ObjectDetails
+		wantErr error
+	}{
+		{
+			name: "missing passkey id",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "COMMAND-Phoo2", "Errors.IDMissing"),
+		},
+		{
+			name: "other user, permission denied",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanPasswordlessAddedEvent(ctx, otherUserAgg, "passkey1", "challenge", "rpID"),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				userID:        "user2",
+				resourceOwner: "org1",
+				passkeyID:     "passkey1",
+			},
+			wantErr: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+		},
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				passkeyID:     "passkey1",
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-DAfb2", "Errors.User.WebAuthN.NotFound"),
+		},
+		{
+			name: "own passkey, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanPasswordlessAddedEvent(ctx, userAgg, "passkey1", "challenge", "rpID"),
+						),
+					),
+					expectPush(
+						user.NewHumanPasswordlessRemovedEvent(ctx, userAgg, "passkey1"),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				passkeyID:     "passkey1",
+			},
+			want: &domain.ObjectDetails{
+				ResourceOwner: "org1",
+			},
+		},
+		{
+			name: "other user, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanPasswordlessAddedEvent(ctx, otherUserAgg, "passkey1", "challenge", "rpID"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanPasswordlessAddedEvent(ctx, otherUserAgg, "passkey1", "challenge", "rpID"),
+						),
+					),
+					expectPush(
+						user.NewHumanPasswordlessRemovedEvent(ctx, otherUserAgg, "passkey1"),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				userID:        "user2",
+				resourceOwner: "org1",
+				passkeyID:     "passkey1",
+			},
+			want: &domain.ObjectDetails{
+				ResourceOwner: "org1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := c.RemoveUserPasskey(ctx, tt.args.userID, tt.args.resourceOwner, tt.args.passkeyID)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -97,6 +97,8 @@ func TestCommands_RegisterUserU2F(t *testing.T) {
 							false, false, false,
 						),
 					)),
+					expectFilter(), // org webauthn attestation policy
+					expectFilter(), // instance webauthn attestation policy
 				),
 				idGenerator: id_mock.NewIDGeneratorExpectError(t, io.ErrClosedPipe),
 			},
@@ -157,6 +159,8 @@ func TestCommands_pushUserU2F(t *testing.T) {
 				false, false, false,
 			),
 		)),
+		expectFilter(), // org webauthn attestation policy
+		expectFilter(), // instance webauthn attestation policy
 		expectFilter(eventFromEventPusher(
 			user.NewHumanWebAuthNAddedEvent(eventstore.NewBaseEventForPush(
 				ctx, &org.NewAggregate("org1").Aggregate, user.HumanPasswordlessTokenAddedType,
@@ -43,4 +43,54 @@ type KeyConfig struct {
 	PublicKeyLifetime   time.Duration
 	CertificateSize     int
 	CertificateLifetime time.Duration
+	// SigningKeyRotation replaces PrivateKeyLifetime and PublicKeyLifetime if its Lifetime is set.
+	// It only applies to the OIDC signing keys.
+	SigningKeyRotation KeyRotation
+	// SAMLCertificateRotation replaces CertificateLifetime for the certificates of a purpose if its Lifetime is set.
+	// Encryption keys aren't covered by a rotation policy, they are rotated by configuring a new key
+	// and re-encrypting the secrets with `zitadel key reencrypt`.
+	SAMLCertificateRotation SAMLCertificateRotation
+}
+
+// SAMLCertificateRotation are the rotation policies of the SAML certificates by purpose.
+// The PrePublication isn't applied, as the SAML metadata only contains the certificate in use,
+// so a successor is used as soon as it's created shortly before its predecessor is no longer used.
+type SAMLCertificateRotation struct {
+	CA       KeyRotation
+	Response KeyRotation
+	Metadata KeyRotation
+}
+
+// KeyRotation is the rotation policy of keys which are published,
+// the timeline of a key is: published, used (and published), retired (only published)
+type KeyRotation struct {
+	// Lifetime is the duration a key is used
+	Lifetime time.Duration
+	// PrePublication is the duration a key is published before it is used
+	PrePublication time.Duration
+	// RetireAfter is the duration a key stays published after it is no longer used
+	RetireAfter time.Duration
+}
+
+// SigningKeys returns the rotation policy of the OIDC signing keys
+func (c *KeyConfig) SigningKeys() KeyRotation {
+	if c.SigningKeyRotation.Lifetime > 0 {
+		return c.SigningKeyRotation
+	}
+	return KeyRotation{
+		Lifetime:    c.PrivateKeyLifetime,
+		RetireAfter: max(c.PublicKeyLifetime-c.PrivateKeyLifetime, 0),
+	}
+}
+
+// SAMLCertificates returns the rotation policies of the SAML certificates,
+// the CertificateLifetime is used for policies without Lifetime.
+func (c *KeyConfig) SAMLCertificates() SAMLCertificateRotation {
+	policies := c.SAMLCertificateRotation
+	for _, policy := range []*KeyRotation{&policies.CA, &policies.Response, &policies.Metadata} {
+		if policy.Lifetime == 0 {
+			policy.Lifetime = c.CertificateLifetime
+		}
+	}
+	return policies
 }
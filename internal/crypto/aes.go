@@ -30,6 +30,16 @@ func NewAESCrypto(config *KeyConfig, keyStorage KeyStorage) (*AESCrypto, error)
 	}, nil
 }
 
+// NewStaticAESCrypto encrypts and decrypts by a single key which isn't stored in the key storage,
+// e.g. the key of an instance export
+func NewStaticAESCrypto(keyID, key string) *AESCrypto {
+	return &AESCrypto{
+		keys:            map[string]string{keyID: key},
+		encryptionKeyID: keyID,
+		keyIDs:          []string{keyID},
+	}
+}
+
 func (a *AESCrypto) Algorithm() string {
 	return "aes"
 }
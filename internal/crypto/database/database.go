@@ -3,10 +3,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	z_db "github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -16,23 +19,33 @@ type Database struct {
 	masterKey string
 	encrypt   func(key, masterKey string) (encryptedKey string, err error)
 	decrypt   func(encryptedKey, masterKey string) (key string, err error)
+	// provider wraps the keys instead of the masterkey if set
+	provider kms.Provider
 }
 
 const (
 	EncryptionKeysTable  = "system.encryption_keys"
 	encryptionKeysIDCol  = "id"
 	encryptionKeysKeyCol = "key"
+
+	// kmsPrefix marks keys wrapped by a [kms.Provider], they are stored as kms:<provider>:<base64 wrapped key>
+	kmsPrefix = "kms:"
 )
 
-func NewKeyStorage(client *z_db.DB, masterKey string) (*Database, error) {
-	if err := checkMasterKeyLength(masterKey); err != nil {
-		return nil, err
+// NewKeyStorage returns the storage of the encryption keys.
+// The keys are wrapped by the provider if set, the masterkey is only required to read keys created before.
+func NewKeyStorage(client *z_db.DB, masterKey string, provider kms.Provider) (*Database, error) {
+	if provider == nil || masterKey != "" {
+		if err := checkMasterKeyLength(masterKey); err != nil {
+			return nil, err
+		}
 	}
 	return &Database{
 		client:    client,
 		masterKey: masterKey,
 		encrypt:   crypto.EncryptAESString,
 		decrypt:   crypto.DecryptAESString,
+		provider:  provider,
 	}, nil
 }
 
@@ -51,7 +64,7 @@ func (d *Database) ReadKeys() (crypto.Keys, error) {
 			if err != nil {
 				return zerrors.ThrowInternal(err, "", "unable to read keys")
 			}
-			key, err := d.decrypt(encryptionKey, d.masterKey)
+			key, err := d.decryptKey(context.Background(), id, encryptionKey)
 			if err != nil {
 				return zerrors.ThrowInternal(err, "", "unable to decrypt key")
 			}
@@ -83,7 +96,7 @@ func (d *Database) ReadKey(id string) (_ *crypto.Key, err error) {
 		if err != nil {
 			return zerrors.ThrowInternal(err, "", "unable to read key")
 		}
-		key, err = d.decrypt(encryptionKey, d.masterKey)
+		key, err = d.decryptKey(context.Background(), id, encryptionKey)
 		if err != nil {
 			return zerrors.ThrowInternal(err, "", "unable to decrypt key")
 		}
@@ -103,7 +116,7 @@ func (d *Database) CreateKeys(ctx context.Context, keys ...*crypto.Key) error {
 	insert := sq.Insert(EncryptionKeysTable).
 		Columns(encryptionKeysIDCol, encryptionKeysKeyCol).PlaceholderFormat(sq.Dollar)
 	for _, key := range keys {
-		encryptionKey, err := d.encrypt(key.Value, d.masterKey)
+		encryptionKey, err := d.encryptKey(ctx, key)
 		if err != nil {
 			return zerrors.ThrowInternal(err, "", "unable to encrypt key")
 		}
@@ -129,6 +142,111 @@ func (d *Database) CreateKeys(ctx context.Context, keys ...*crypto.Key) error {
 	return nil
 }
 
+// WrapKeys wraps the keys encrypted by the masterkey with the provider
+// and returns the count of the wrapped keys
+func (d *Database) WrapKeys(ctx context.Context) (int, error) {
+	if d.provider == nil {
+		return 0, zerrors.ThrowPreconditionFailed(nil, "CRYPT-Kms1W", "no kms provider configured")
+	}
+	stmt, args, err := sq.Select(encryptionKeysIDCol, encryptionKeysKeyCol).
+		From(EncryptionKeysTable).
+		ToSql()
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "CRYPT-Kms2S", "unable to read keys")
+	}
+	tx, err := d.client.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "CRYPT-Kms3T", "unable to wrap keys")
+	}
+	keys := make([]*crypto.Key, 0)
+	rows, err := tx.QueryContext(ctx, stmt+" FOR UPDATE", args...)
+	if err != nil {
+		tx.Rollback()
+		return 0, zerrors.ThrowInternal(err, "CRYPT-Kms4Q", "unable to read keys")
+	}
+	for rows.Next() {
+		var id, encryptionKey string
+		if err = rows.Scan(&id, &encryptionKey); err != nil {
+			break
+		}
+		if strings.HasPrefix(encryptionKey, kmsPrefix) {
+			continue
+		}
+		var key string
+		if key, err = d.decryptKey(ctx, id, encryptionKey); err != nil {
+			break
+		}
+		keys = append(keys, &crypto.Key{ID: id, Value: key})
+	}
+	if closeErr := rows.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	if err != nil {
+		tx.Rollback()
+		return 0, zerrors.ThrowInternal(err, "CRYPT-Kms5R", "unable to read keys")
+	}
+	for _, key := range keys {
+		encryptionKey, err := d.encryptKey(ctx, key)
+		if err != nil {
+			tx.Rollback()
+			return 0, zerrors.ThrowInternal(err, "CRYPT-Kms6E", "unable to wrap key")
+		}
+		stmt, args, err := sq.Update(EncryptionKeysTable).
+			Set(encryptionKeysKeyCol, encryptionKey).
+			Where(sq.Eq{encryptionKeysIDCol: key.ID}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+		if err != nil {
+			tx.Rollback()
+			return 0, zerrors.ThrowInternal(err, "CRYPT-Kms7U", "unable to wrap key")
+		}
+		if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+			tx.Rollback()
+			return 0, zerrors.ThrowInternal(err, "CRYPT-Kms8U", "unable to wrap key")
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, zerrors.ThrowInternal(err, "CRYPT-Kms9C", "unable to wrap keys")
+	}
+	return len(keys), nil
+}
+
+func (d *Database) encryptKey(ctx context.Context, key *crypto.Key) (string, error) {
+	if d.provider == nil {
+		return d.encrypt(key.Value, d.masterKey)
+	}
+	wrapped, err := d.provider.Wrap(ctx, key.ID, []byte(key.Value))
+	if err != nil {
+		return "", err
+	}
+	return kmsPrefix + d.provider.Name() + ":" + base64.StdEncoding.EncodeToString(wrapped), nil
+}
+
+func (d *Database) decryptKey(ctx context.Context, id, encryptionKey string) (string, error) {
+	if !strings.HasPrefix(encryptionKey, kmsPrefix) {
+		if d.masterKey == "" {
+			return "", zerrors.ThrowPreconditionFailedf(nil, "CRYPT-KmsAM", "key %s is encrypted by the masterkey", id)
+		}
+		return d.decrypt(encryptionKey, d.masterKey)
+	}
+	provider, wrapped, _ := strings.Cut(strings.TrimPrefix(encryptionKey, kmsPrefix), ":")
+	if d.provider == nil || d.provider.Name() != provider {
+		return "", zerrors.ThrowPreconditionFailedf(nil, "CRYPT-KmsBP", "key %s is wrapped by kms provider %s", id, provider)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return "", err
+	}
+	key, err := d.provider.Unwrap(ctx, id, decoded)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
 func checkMasterKeyLength(masterKey string) error {
 	if length := len([]byte(masterKey)); length != 32 {
 		return zerrors.ThrowInternalf(nil, "", "masterkey must be 32 bytes, but is %d", length)
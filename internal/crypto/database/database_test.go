@@ -445,6 +445,49 @@ func Test_checkMasterKeyLength(t *testing.T) {
 	}
 }
 
+// reversingProvider wraps the key material by reversing it
+type reversingProvider struct{}
+
+func (reversingProvider) Name() string { return "test" }
+
+func (reversingProvider) Wrap(_ context.Context, _ string, plaintext []byte) ([]byte, error) {
+	return reverse(plaintext), nil
+}
+
+func (reversingProvider) Unwrap(_ context.Context, _ string, wrapped []byte) ([]byte, error) {
+	return reverse(wrapped), nil
+}
+
+func reverse(value []byte) []byte {
+	reversed := make([]byte, len(value))
+	for i, b := range value {
+		reversed[len(value)-1-i] = b
+	}
+	return reversed
+}
+
+func Test_database_kms(t *testing.T) {
+	d, err := NewKeyStorage(nil, "", reversingProvider{})
+	assert.NoError(t, err)
+
+	encryptionKey, err := d.encryptKey(context.Background(), &crypto.Key{ID: "id1", Value: "key1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "kms:test:MXllaw==", encryptionKey)
+
+	key, err := d.decryptKey(context.Background(), "id1", encryptionKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "key1", key)
+
+	_, err = d.decryptKey(context.Background(), "id2", "legacy")
+	assert.True(t, zerrors.IsPreconditionFailed(err))
+
+	_, err = d.decryptKey(context.Background(), "id3", "kms:other:MXllaw==")
+	assert.True(t, zerrors.IsPreconditionFailed(err))
+
+	_, err = NewKeyStorage(nil, "", nil)
+	assert.Error(t, err)
+}
+
 type db struct {
 	mock sqlmock.Sqlmock
 	db   *z_db.DB
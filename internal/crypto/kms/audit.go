@@ -0,0 +1,42 @@
+package kms
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+)
+
+// Audited logs every use of the key encryption key of the provider
+func Audited(provider Provider) Provider {
+	if _, ok := provider.(*audited); ok {
+		return provider
+	}
+	return &audited{Provider: provider}
+}
+
+type audited struct {
+	Provider
+}
+
+// Wrap implements [Provider]
+func (a *audited) Wrap(ctx context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	wrapped, err := a.Provider.Wrap(ctx, keyID, plaintext)
+	a.log("wrap", keyID, err)
+	return wrapped, err
+}
+
+// Unwrap implements [Provider]
+func (a *audited) Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	plaintext, err := a.Provider.Unwrap(ctx, keyID, wrapped)
+	a.log("unwrap", keyID, err)
+	return plaintext, err
+}
+
+func (a *audited) log(operation, keyID string, err error) {
+	entry := logging.WithFields("provider", a.Name(), "operation", operation, "key", keyID)
+	if err != nil {
+		entry.WithError(err).Warn("kms key use failed")
+		return
+	}
+	entry.Info("kms key used")
+}
@@ -0,0 +1,61 @@
+// Package kms wraps the encryption keys of ZITADEL with a key encryption key held by an external key management system.
+// The key encryption key never leaves the KMS, so the database only contains wrapped key material.
+package kms
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Provider encrypts and decrypts key material with the key encryption key of an external KMS
+type Provider interface {
+	// Name identifies the provider of wrapped key material
+	Name() string
+	// Wrap encrypts the key material identified by keyID
+	Wrap(ctx context.Context, keyID string, plaintext []byte) ([]byte, error)
+	// Unwrap decrypts the key material identified by keyID which was wrapped by [Provider.Wrap]
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+const (
+	ProviderVault  = "vault"
+	ProviderPKCS11 = "pkcs11"
+)
+
+type Config struct {
+	// Provider is either "vault" or "pkcs11"
+	// The encryption keys are encrypted by the masterkey if empty
+	Provider string
+	Vault    *VaultConfig
+	PKCS11   *PKCS11Config
+}
+
+// Enabled returns true if the encryption keys are wrapped by a KMS
+func (c *Config) Enabled() bool {
+	return c != nil && c.Provider != ""
+}
+
+// NewProvider returns the configured provider, every use of the key encryption key is logged.
+// nil is returned if no provider is configured.
+func (c *Config) NewProvider() (Provider, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+	var (
+		provider Provider
+		err      error
+	)
+	switch c.Provider {
+	case ProviderVault:
+		provider, err = NewVault(c.Vault)
+	case ProviderPKCS11:
+		provider, err = NewPKCS11(c.PKCS11)
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "KMS-Pr0vd", "unknown kms provider %q", c.Provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Audited(provider), nil
+}
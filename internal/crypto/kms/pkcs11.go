@@ -0,0 +1,419 @@
+//go:build pkcs11 && cgo
+
+package kms
+
+/*
+#cgo linux LDFLAGS: -ldl
+
+#include <dlfcn.h>
+#include <stdlib.h>
+#include <string.h>
+
+// the subset of the PKCS#11 v2.40 types used by ZITADEL,
+// defined here so no vendor header is required to build
+typedef unsigned long CK_ULONG;
+typedef CK_ULONG CK_RV;
+typedef CK_ULONG CK_SLOT_ID;
+typedef CK_ULONG CK_SESSION_HANDLE;
+typedef CK_ULONG CK_OBJECT_HANDLE;
+typedef CK_ULONG CK_FLAGS;
+typedef unsigned char CK_BYTE;
+typedef unsigned char CK_BBOOL;
+
+typedef struct { CK_BYTE major; CK_BYTE minor; } CK_VERSION;
+
+typedef struct {
+	CK_BYTE label[32];
+	CK_BYTE manufacturerID[32];
+	CK_BYTE model[16];
+	CK_BYTE serialNumber[16];
+	CK_FLAGS flags;
+	CK_ULONG ulMaxSessionCount;
+	CK_ULONG ulSessionCount;
+	CK_ULONG ulMaxRwSessionCount;
+	CK_ULONG ulRwSessionCount;
+	CK_ULONG ulMaxPinLen;
+	CK_ULONG ulMinPinLen;
+	CK_ULONG ulTotalPublicMemory;
+	CK_ULONG ulFreePublicMemory;
+	CK_ULONG ulTotalPrivateMemory;
+	CK_ULONG ulFreePrivateMemory;
+	CK_VERSION hardwareVersion;
+	CK_VERSION firmwareVersion;
+	CK_BYTE utcTime[16];
+} CK_TOKEN_INFO;
+
+typedef struct {
+	CK_ULONG type;
+	void *pValue;
+	CK_ULONG ulValueLen;
+} CK_ATTRIBUTE;
+
+typedef struct {
+	CK_ULONG mechanism;
+	void *pParameter;
+	CK_ULONG ulParameterLen;
+} CK_MECHANISM;
+
+typedef struct {
+	CK_BYTE *pIv;
+	CK_ULONG ulIvLen;
+	CK_ULONG ulIvBits;
+	CK_BYTE *pAAD;
+	CK_ULONG ulAADLen;
+	CK_ULONG ulTagBits;
+} CK_GCM_PARAMS;
+
+typedef struct {
+	void *CreateMutex;
+	void *DestroyMutex;
+	void *LockMutex;
+	void *UnlockMutex;
+	CK_FLAGS flags;
+	void *pReserved;
+} CK_C_INITIALIZE_ARGS;
+
+#define ZCKR_OK 0x0UL
+#define ZCKR_FUNCTION_FAILED 0x6UL
+#define ZCKR_USER_ALREADY_LOGGED_IN 0x100UL
+#define ZCKR_CRYPTOKI_ALREADY_INITIALIZED 0x191UL
+#define ZCKR_TOKEN_NOT_FOUND 0xFFFFFFF0UL
+#define ZCKR_KEY_NOT_FOUND 0xFFFFFFF1UL
+#define ZCKR_KEY_NOT_UNIQUE 0xFFFFFFF2UL
+#define ZCKF_OS_LOCKING_OK 0x2UL
+#define ZCKF_RW_SESSION 0x2UL
+#define ZCKF_SERIAL_SESSION 0x4UL
+#define ZCKU_USER 1UL
+#define ZCKA_CLASS 0x0UL
+#define ZCKA_LABEL 0x3UL
+#define ZCKO_SECRET_KEY 0x4UL
+#define ZCKM_AES_GCM 0x1087UL
+
+typedef struct {
+	void *handle;
+	CK_RV (*Initialize)(void *);
+	CK_RV (*Finalize)(void *);
+	CK_RV (*GetSlotList)(CK_BBOOL, CK_SLOT_ID *, CK_ULONG *);
+	CK_RV (*GetTokenInfo)(CK_SLOT_ID, CK_TOKEN_INFO *);
+	CK_RV (*OpenSession)(CK_SLOT_ID, CK_FLAGS, void *, void *, CK_SESSION_HANDLE *);
+	CK_RV (*CloseSession)(CK_SESSION_HANDLE);
+	CK_RV (*Login)(CK_SESSION_HANDLE, CK_ULONG, CK_BYTE *, CK_ULONG);
+	CK_RV (*FindObjectsInit)(CK_SESSION_HANDLE, CK_ATTRIBUTE *, CK_ULONG);
+	CK_RV (*FindObjects)(CK_SESSION_HANDLE, CK_OBJECT_HANDLE *, CK_ULONG, CK_ULONG *);
+	CK_RV (*FindObjectsFinal)(CK_SESSION_HANDLE);
+	CK_RV (*EncryptInit)(CK_SESSION_HANDLE, CK_MECHANISM *, CK_OBJECT_HANDLE);
+	CK_RV (*Encrypt)(CK_SESSION_HANDLE, CK_BYTE *, CK_ULONG, CK_BYTE *, CK_ULONG *);
+	CK_RV (*DecryptInit)(CK_SESSION_HANDLE, CK_MECHANISM *, CK_OBJECT_HANDLE);
+	CK_RV (*Decrypt)(CK_SESSION_HANDLE, CK_BYTE *, CK_ULONG, CK_BYTE *, CK_ULONG *);
+} zpkcs11;
+
+static zpkcs11 *zpkcs11_load(const char *path) {
+	void *handle = dlopen(path, RTLD_NOW | RTLD_LOCAL);
+	if (handle == NULL) {
+		return NULL;
+	}
+	zpkcs11 *p = calloc(1, sizeof(zpkcs11));
+	if (p == NULL) {
+		dlclose(handle);
+		return NULL;
+	}
+	p->handle = handle;
+	p->Initialize = dlsym(handle, "C_Initialize");
+	p->Finalize = dlsym(handle, "C_Finalize");
+	p->GetSlotList = dlsym(handle, "C_GetSlotList");
+	p->GetTokenInfo = dlsym(handle, "C_GetTokenInfo");
+	p->OpenSession = dlsym(handle, "C_OpenSession");
+	p->CloseSession = dlsym(handle, "C_CloseSession");
+	p->Login = dlsym(handle, "C_Login");
+	p->FindObjectsInit = dlsym(handle, "C_FindObjectsInit");
+	p->FindObjects = dlsym(handle, "C_FindObjects");
+	p->FindObjectsFinal = dlsym(handle, "C_FindObjectsFinal");
+	p->EncryptInit = dlsym(handle, "C_EncryptInit");
+	p->Encrypt = dlsym(handle, "C_Encrypt");
+	p->DecryptInit = dlsym(handle, "C_DecryptInit");
+	p->Decrypt = dlsym(handle, "C_Decrypt");
+	if (!p->Initialize || !p->Finalize || !p->GetSlotList || !p->GetTokenInfo ||
+		!p->OpenSession || !p->CloseSession || !p->Login ||
+		!p->FindObjectsInit || !p->FindObjects || !p->FindObjectsFinal ||
+		!p->EncryptInit || !p->Encrypt || !p->DecryptInit || !p->Decrypt) {
+		dlclose(handle);
+		free(p);
+		return NULL;
+	}
+	return p;
+}
+
+static CK_RV zpkcs11_initialize(zpkcs11 *p) {
+	CK_C_INITIALIZE_ARGS args;
+	memset(&args, 0, sizeof(args));
+	args.flags = ZCKF_OS_LOCKING_OK;
+	CK_RV rv = p->Initialize(&args);
+	if (rv == ZCKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		return ZCKR_OK;
+	}
+	return rv;
+}
+
+// zpkcs11_find_slot returns the slot of the token with the label, labels are padded with spaces
+static CK_RV zpkcs11_find_slot(zpkcs11 *p, const char *label, CK_SLOT_ID *slot) {
+	CK_ULONG count = 0;
+	CK_RV rv = p->GetSlotList(1, NULL, &count);
+	if (rv != ZCKR_OK) {
+		return rv;
+	}
+	if (count == 0) {
+		return ZCKR_TOKEN_NOT_FOUND;
+	}
+	CK_SLOT_ID *slots = calloc(count, sizeof(CK_SLOT_ID));
+	if (slots == NULL) {
+		return ZCKR_FUNCTION_FAILED;
+	}
+	rv = p->GetSlotList(1, slots, &count);
+	if (rv != ZCKR_OK) {
+		free(slots);
+		return rv;
+	}
+	CK_BYTE padded[32];
+	memset(padded, ' ', sizeof(padded));
+	size_t length = strlen(label);
+	memcpy(padded, label, length > sizeof(padded) ? sizeof(padded) : length);
+	rv = ZCKR_TOKEN_NOT_FOUND;
+	for (CK_ULONG i = 0; i < count; i++) {
+		CK_TOKEN_INFO info;
+		if (p->GetTokenInfo(slots[i], &info) != ZCKR_OK) {
+			continue;
+		}
+		if (memcmp(info.label, padded, sizeof(padded)) == 0) {
+			*slot = slots[i];
+			rv = ZCKR_OK;
+			break;
+		}
+	}
+	free(slots);
+	return rv;
+}
+
+static CK_RV zpkcs11_open(zpkcs11 *p, CK_SLOT_ID slot, CK_BYTE *pin, CK_ULONG pinLen, CK_SESSION_HANDLE *session) {
+	CK_RV rv = p->OpenSession(slot, ZCKF_SERIAL_SESSION | ZCKF_RW_SESSION, NULL, NULL, session);
+	if (rv != ZCKR_OK) {
+		return rv;
+	}
+	rv = p->Login(*session, ZCKU_USER, pin, pinLen);
+	if (rv == ZCKR_USER_ALREADY_LOGGED_IN) {
+		return ZCKR_OK;
+	}
+	if (rv != ZCKR_OK) {
+		p->CloseSession(*session);
+	}
+	return rv;
+}
+
+// zpkcs11_find_key returns the secret key with the label, which must be unique
+static CK_RV zpkcs11_find_key(zpkcs11 *p, CK_SESSION_HANDLE session, CK_BYTE *label, CK_ULONG labelLen, CK_OBJECT_HANDLE *key) {
+	CK_ULONG class = ZCKO_SECRET_KEY;
+	CK_ATTRIBUTE template[2] = {
+		{ZCKA_CLASS, &class, sizeof(class)},
+		{ZCKA_LABEL, label, labelLen},
+	};
+	CK_RV rv = p->FindObjectsInit(session, template, 2);
+	if (rv != ZCKR_OK) {
+		return rv;
+	}
+	CK_OBJECT_HANDLE found[2];
+	CK_ULONG count = 0;
+	rv = p->FindObjects(session, found, 2, &count);
+	p->FindObjectsFinal(session);
+	if (rv != ZCKR_OK) {
+		return rv;
+	}
+	if (count == 0) {
+		return ZCKR_KEY_NOT_FOUND;
+	}
+	if (count > 1) {
+		return ZCKR_KEY_NOT_UNIQUE;
+	}
+	*key = found[0];
+	return ZCKR_OK;
+}
+
+static CK_RV zpkcs11_gcm(zpkcs11 *p, CK_SESSION_HANDLE session, CK_OBJECT_HANDLE key, int encrypt,
+		CK_BYTE *iv, CK_ULONG ivLen, CK_BYTE *in, CK_ULONG inLen, CK_BYTE *out, CK_ULONG *outLen) {
+	CK_GCM_PARAMS params;
+	memset(&params, 0, sizeof(params));
+	params.pIv = iv;
+	params.ulIvLen = ivLen;
+	params.ulIvBits = ivLen * 8;
+	params.ulTagBits = 128;
+	CK_MECHANISM mechanism = {ZCKM_AES_GCM, &params, sizeof(params)};
+	CK_RV rv;
+	if (encrypt) {
+		rv = p->EncryptInit(session, &mechanism, key);
+		if (rv != ZCKR_OK) {
+			return rv;
+		}
+		return p->Encrypt(session, in, inLen, out, outLen);
+	}
+	rv = p->DecryptInit(session, &mechanism, key);
+	if (rv != ZCKR_OK) {
+		return rv;
+	}
+	return p->Decrypt(session, in, inLen, out, outLen);
+}
+
+static void zpkcs11_close(zpkcs11 *p, CK_SESSION_HANDLE session) {
+	p->CloseSession(session);
+	p->Finalize(NULL);
+	dlclose(p->handle);
+	free(p);
+}
+*/
+import "C"
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	pkcs11IVLength  = 12
+	pkcs11TagLength = 16
+)
+
+// PKCS11 wraps the key material with an AES key of a PKCS#11 token (e.g. an HSM or SoftHSM) using AES-GCM.
+// The wrapped key material is the random IV followed by the ciphertext.
+type PKCS11 struct {
+	mu      sync.Mutex
+	module  *C.zpkcs11
+	session C.CK_SESSION_HANDLE
+	key     C.CK_OBJECT_HANDLE
+}
+
+func NewPKCS11(config *PKCS11Config) (_ *PKCS11, err error) {
+	if config == nil || config.Module == "" || config.TokenLabel == "" || config.KeyLabel == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "KMS-Pk1Cf", "pkcs11 module, token label and key label must be set")
+	}
+	path := C.CString(config.Module)
+	defer C.free(unsafe.Pointer(path))
+	module := C.zpkcs11_load(path)
+	if module == nil {
+		return nil, zerrors.ThrowInternalf(nil, "KMS-Pk2Ld", "unable to load pkcs11 module %s", config.Module)
+	}
+	if rv := C.zpkcs11_initialize(module); rv != C.ZCKR_OK {
+		C.zpkcs11_close(module, 0)
+		return nil, pkcs11Error(rv, "KMS-Pk3In", "initialize")
+	}
+	token := C.CString(config.TokenLabel)
+	defer C.free(unsafe.Pointer(token))
+	var slot C.CK_SLOT_ID
+	if rv := C.zpkcs11_find_slot(module, token, &slot); rv != C.ZCKR_OK {
+		C.zpkcs11_close(module, 0)
+		return nil, pkcs11Error(rv, "KMS-Pk4Sl", "find token")
+	}
+	pin := C.CBytes([]byte(config.PIN))
+	defer C.free(pin)
+	var session C.CK_SESSION_HANDLE
+	if rv := C.zpkcs11_open(module, slot, (*C.CK_BYTE)(pin), C.CK_ULONG(len(config.PIN)), &session); rv != C.ZCKR_OK {
+		C.zpkcs11_close(module, 0)
+		return nil, pkcs11Error(rv, "KMS-Pk5Se", "open session")
+	}
+	label := C.CBytes([]byte(config.KeyLabel))
+	defer C.free(label)
+	var key C.CK_OBJECT_HANDLE
+	if rv := C.zpkcs11_find_key(module, session, (*C.CK_BYTE)(label), C.CK_ULONG(len(config.KeyLabel)), &key); rv != C.ZCKR_OK {
+		C.zpkcs11_close(module, session)
+		return nil, pkcs11Error(rv, "KMS-Pk6Ky", "find key")
+	}
+	return &PKCS11{
+		module:  module,
+		session: session,
+		key:     key,
+	}, nil
+}
+
+// Name implements [Provider]
+func (*PKCS11) Name() string {
+	return ProviderPKCS11
+}
+
+// Wrap implements [Provider]
+func (p *PKCS11) Wrap(_ context.Context, _ string, plaintext []byte) ([]byte, error) {
+	if len(plaintext) == 0 {
+		return nil, zerrors.ThrowInvalidArgument(nil, "KMS-Pk7Em", "nothing to wrap")
+	}
+	wrapped := make([]byte, pkcs11IVLength+len(plaintext)+pkcs11TagLength)
+	iv := wrapped[:pkcs11IVLength]
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Pk8Iv", "Errors.Internal")
+	}
+	n, err := p.gcm(true, iv, plaintext, wrapped[pkcs11IVLength:])
+	if err != nil {
+		return nil, err
+	}
+	return wrapped[:pkcs11IVLength+n], nil
+}
+
+// Unwrap implements [Provider]
+func (p *PKCS11) Unwrap(_ context.Context, _ string, wrapped []byte) ([]byte, error) {
+	if len(wrapped) <= pkcs11IVLength+pkcs11TagLength {
+		return nil, zerrors.ThrowInvalidArgument(nil, "KMS-Pk9Ln", "wrapped key too short")
+	}
+	plaintext := make([]byte, len(wrapped)-pkcs11IVLength)
+	n, err := p.gcm(false, wrapped[:pkcs11IVLength], wrapped[pkcs11IVLength:], plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return plaintext[:n], nil
+}
+
+func (p *PKCS11) gcm(encrypt bool, iv, in, out []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.module == nil {
+		return 0, zerrors.ThrowInternal(nil, "KMS-PkACl", "pkcs11 module closed")
+	}
+	var enc C.int
+	if encrypt {
+		enc = 1
+	}
+	outLen := C.CK_ULONG(len(out))
+	rv := C.zpkcs11_gcm(p.module, p.session, p.key, enc,
+		(*C.CK_BYTE)(unsafe.Pointer(&iv[0])), C.CK_ULONG(len(iv)),
+		(*C.CK_BYTE)(unsafe.Pointer(&in[0])), C.CK_ULONG(len(in)),
+		(*C.CK_BYTE)(unsafe.Pointer(&out[0])), &outLen,
+	)
+	if rv != C.ZCKR_OK {
+		if encrypt {
+			return 0, pkcs11Error(rv, "KMS-PkBEn", "encrypt")
+		}
+		return 0, pkcs11Error(rv, "KMS-PkCDe", "decrypt")
+	}
+	return int(outLen), nil
+}
+
+// Close logs out of the token and unloads the module
+func (p *PKCS11) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.module != nil {
+		C.zpkcs11_close(p.module, p.session)
+		p.module = nil
+	}
+	return nil
+}
+
+func pkcs11Error(rv C.CK_RV, id, operation string) error {
+	switch rv {
+	case C.ZCKR_TOKEN_NOT_FOUND:
+		return zerrors.ThrowNotFound(nil, id, "pkcs11 token not found")
+	case C.ZCKR_KEY_NOT_FOUND:
+		return zerrors.ThrowNotFound(nil, id, "pkcs11 key not found")
+	case C.ZCKR_KEY_NOT_UNIQUE:
+		return zerrors.ThrowPreconditionFailed(nil, id, "pkcs11 key label is not unique")
+	}
+	return zerrors.ThrowInternalf(nil, id, "pkcs11 %s failed: CKR 0x%x", operation, uint64(rv))
+}
@@ -0,0 +1,14 @@
+package kms
+
+// PKCS11Config configures a PKCS#11 token holding the AES key which wraps the encryption keys.
+// ZITADEL must be built with cgo and the build tag pkcs11 to use it.
+type PKCS11Config struct {
+	// Module is the path to the PKCS#11 library, e.g. /usr/lib/softhsm/libsofthsm2.so
+	Module string
+	// TokenLabel is the label of the token containing the key
+	TokenLabel string
+	// PIN of the user of the token
+	PIN string
+	// KeyLabel is the label of the AES key
+	KeyLabel string
+}
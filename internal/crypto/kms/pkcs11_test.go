@@ -0,0 +1,44 @@
+//go:build pkcs11 && cgo
+
+package kms
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPKCS11 requires a token with an AES key, e.g. created by SoftHSM:
+//
+//	softhsm2-util --init-token --free --label zitadel --pin 1234 --so-pin 1234
+//	pkcs11-tool --module /usr/lib/softhsm/libsofthsm2.so --login --pin 1234 --token-label zitadel --keygen --key-type AES:32 --label masterkey
+func TestPKCS11(t *testing.T) {
+	module := os.Getenv("ZITADEL_TEST_PKCS11_MODULE")
+	if module == "" {
+		t.Skip("ZITADEL_TEST_PKCS11_MODULE not set")
+	}
+	provider, err := NewPKCS11(&PKCS11Config{
+		Module:     module,
+		TokenLabel: os.Getenv("ZITADEL_TEST_PKCS11_TOKEN"),
+		PIN:        os.Getenv("ZITADEL_TEST_PKCS11_PIN"),
+		KeyLabel:   os.Getenv("ZITADEL_TEST_PKCS11_KEY"),
+	})
+	require.NoError(t, err)
+	defer provider.Close()
+
+	ctx := context.Background()
+	wrapped, err := provider.Wrap(ctx, "keyID", []byte("passphrasewhichneedstobe32bytes!"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(wrapped), "passphrase")
+
+	plaintext, err := provider.Unwrap(ctx, "keyID", wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "passphrasewhichneedstobe32bytes!", string(plaintext))
+
+	wrapped[len(wrapped)-1] ^= 0xff
+	_, err = provider.Unwrap(ctx, "keyID", wrapped)
+	assert.Error(t, err)
+}
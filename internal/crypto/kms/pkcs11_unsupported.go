@@ -0,0 +1,21 @@
+//go:build !pkcs11 || !cgo
+
+package kms
+
+import (
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// PKCS11 is only available if ZITADEL is built with cgo and the build tag pkcs11
+type PKCS11 struct {
+	Provider
+}
+
+func NewPKCS11(*PKCS11Config) (*PKCS11, error) {
+	return nil, zerrors.ThrowUnimplemented(nil, "KMS-Pk0Tg", "pkcs11 requires a build with cgo and the build tag pkcs11")
+}
+
+// Close implements [io.Closer]
+func (*PKCS11) Close() error {
+	return nil
+}
@@ -0,0 +1,144 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// VaultConfig configures a HashiCorp Vault Transit compatible secrets engine
+type VaultConfig struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200
+	Address string
+	// Token used to authenticate against Vault
+	Token string
+	// Namespace of Vault Enterprise, optional
+	Namespace string
+	// Mount path of the transit secrets engine, defaults to "transit"
+	Mount string
+	// Key is the name of the transit key wrapping the encryption keys
+	Key string
+	// Timeout of the requests to Vault, defaults to 10s
+	Timeout time.Duration
+}
+
+// Vault wraps the key material with the Transit secrets engine of HashiCorp Vault
+type Vault struct {
+	config *VaultConfig
+	client *http.Client
+}
+
+func NewVault(config *VaultConfig) (*Vault, error) {
+	if config == nil || config.Address == "" || config.Key == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "KMS-Va1Cf", "vault address and key must be set")
+	}
+	cfg := *config
+	cfg.Address = strings.TrimSuffix(cfg.Address, "/")
+	if cfg.Mount == "" {
+		cfg.Mount = "transit"
+	}
+	cfg.Mount = strings.Trim(cfg.Mount, "/")
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &Vault{
+		config: &cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Name implements [Provider]
+func (*Vault) Name() string {
+	return ProviderVault
+}
+
+type vaultEncryptRequest struct {
+	Plaintext string `json:"plaintext"`
+	Context   string `json:"context,omitempty"`
+}
+
+type vaultDecryptRequest struct {
+	Ciphertext string `json:"ciphertext"`
+	Context    string `json:"context,omitempty"`
+}
+
+type vaultResponse struct {
+	Data struct {
+		Ciphertext string `json:"ciphertext"`
+		Plaintext  string `json:"plaintext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// Wrap implements [Provider]
+// The id of the key is not passed as context, because derived keys are optional in Vault.
+func (v *Vault) Wrap(ctx context.Context, _ string, plaintext []byte) ([]byte, error) {
+	resp, err := v.do(ctx, "encrypt", &vaultEncryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(plaintext),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Data.Ciphertext == "" {
+		return nil, zerrors.ThrowInternal(nil, "KMS-Va2Ct", "vault returned no ciphertext")
+	}
+	return []byte(resp.Data.Ciphertext), nil
+}
+
+// Unwrap implements [Provider]
+func (v *Vault) Unwrap(ctx context.Context, _ string, wrapped []byte) ([]byte, error) {
+	resp, err := v.do(ctx, "decrypt", &vaultDecryptRequest{
+		Ciphertext: string(wrapped),
+	})
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Va3Pt", "vault returned invalid plaintext")
+	}
+	return plaintext, nil
+}
+
+func (v *Vault) do(ctx context.Context, operation string, body any) (*vaultResponse, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Va4Ma", "Errors.Internal")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		v.config.Address+"/v1/"+v.config.Mount+"/"+operation+"/"+v.config.Key,
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Va5Rq", "Errors.Internal")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", v.config.Token)
+	if v.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.config.Namespace)
+	}
+	res, err := v.client.Do(req)
+	if err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "KMS-Va6Do", "vault unavailable")
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "KMS-Va7Rd", "vault unavailable")
+	}
+	resp := new(vaultResponse)
+	if err = json.Unmarshal(data, resp); err != nil && res.StatusCode == http.StatusOK {
+		return nil, zerrors.ThrowInternal(err, "KMS-Va8Um", "vault returned invalid response")
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, zerrors.ThrowInternalf(nil, "KMS-Va9St", "vault %s failed with status %d: %s", operation, res.StatusCode, strings.Join(resp.Errors, ", "))
+	}
+	return resp, nil
+}
@@ -0,0 +1,87 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transitServer mimics the transit secrets engine by prefixing the plaintext
+func transitServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		body := make(map[string]string)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		var data map[string]string
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/zitadel":
+			data = map[string]string{"ciphertext": "vault:v1:" + body["plaintext"]}
+		case "/v1/transit/decrypt/zitadel":
+			data = map[string]string{"plaintext": strings.TrimPrefix(body["ciphertext"], "vault:v1:")}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": data}))
+	}))
+}
+
+func TestVault(t *testing.T) {
+	server := transitServer(t)
+	defer server.Close()
+	ctx := context.Background()
+
+	t.Run("wrap and unwrap", func(t *testing.T) {
+		vault, err := NewVault(&VaultConfig{Address: server.URL + "/", Token: "token", Key: "zitadel"})
+		require.NoError(t, err)
+		wrapped, err := vault.Wrap(ctx, "keyID", []byte("key"))
+		require.NoError(t, err)
+		assert.Equal(t, "vault:v1:"+base64.StdEncoding.EncodeToString([]byte("key")), string(wrapped))
+		plaintext, err := vault.Unwrap(ctx, "keyID", wrapped)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("key"), plaintext)
+	})
+	t.Run("denied", func(t *testing.T) {
+		vault, err := NewVault(&VaultConfig{Address: server.URL, Token: "wrong", Key: "zitadel"})
+		require.NoError(t, err)
+		_, err = vault.Wrap(ctx, "keyID", []byte("key"))
+		assert.ErrorContains(t, err, "permission denied")
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		vault, err := NewVault(&VaultConfig{Address: server.URL, Token: "token", Key: "other"})
+		require.NoError(t, err)
+		_, err = vault.Unwrap(ctx, "keyID", []byte("vault:v1:a2V5"))
+		assert.Error(t, err)
+	})
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewVault(&VaultConfig{Address: server.URL})
+		assert.Error(t, err)
+	})
+}
+
+func TestConfig_NewProvider(t *testing.T) {
+	provider, err := (*Config)(nil).NewProvider()
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = (&Config{Provider: ProviderVault, Vault: &VaultConfig{Address: "http://localhost:8200", Key: "zitadel"}}).NewProvider()
+	require.NoError(t, err)
+	assert.Equal(t, ProviderVault, provider.Name())
+	_, ok := provider.(*audited)
+	assert.True(t, ok, "provider must be audited")
+
+	_, err = (&Config{Provider: "unknown"}).NewProvider()
+	assert.Error(t, err)
+}
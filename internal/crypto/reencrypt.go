@@ -0,0 +1,176 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Reencrypter encrypts values which are encrypted by a retired key with the current encryption key of the algorithm.
+// A key is retired as soon as it's no longer the encryption key but still listed in the decryption keys.
+type Reencrypter struct {
+	algorithms []EncryptionAlgorithm
+}
+
+func NewReencrypter(algorithms ...EncryptionAlgorithm) *Reencrypter {
+	return &Reencrypter{algorithms: algorithms}
+}
+
+// Reencrypt updates the value in place and returns true if it was encrypted by a retired key.
+// Hashes and values of unknown keys remain unchanged.
+func (r *Reencrypter) Reencrypt(value *CryptoValue) (bool, error) {
+	if value == nil || value.CryptoType != TypeEncryption {
+		return false, nil
+	}
+	alg := r.retiredBy(value)
+	if alg == nil {
+		return false, nil
+	}
+	decrypted, err := alg.Decrypt(value.Crypted, value.KeyID)
+	if err != nil {
+		return false, err
+	}
+	crypted, err := alg.Encrypt(decrypted)
+	if err != nil {
+		return false, err
+	}
+	value.KeyID = alg.EncryptionKeyID()
+	value.Crypted = crypted
+	return true, nil
+}
+
+// retiredBy returns the algorithm which retired the key of the value
+// nil is returned if the key is still used for encryption
+func (r *Reencrypter) retiredBy(value *CryptoValue) EncryptionAlgorithm {
+	var retiredBy EncryptionAlgorithm
+	for _, alg := range r.algorithms {
+		if alg.Algorithm() != value.Algorithm {
+			continue
+		}
+		// keys can be shared by multiple configurations
+		if alg.EncryptionKeyID() == value.KeyID {
+			return nil
+		}
+		if retiredBy == nil && slices.Contains(alg.DecryptionKeyIDs(), value.KeyID) {
+			retiredBy = alg
+		}
+	}
+	return retiredBy
+}
+
+// ReencryptJSON re-encrypts all encrypted values in the JSON document, e.g. an event payload.
+// The document is only returned if at least one value changed.
+func (r *Reencrypter) ReencryptJSON(document []byte) ([]byte, bool, error) {
+	return reencryptJSON(document, r.Reencrypt)
+}
+
+// reencryptJSON replaces all values of the JSON document which are changed by reencrypt.
+// The document is only returned if at least one value changed.
+func reencryptJSON(document []byte, reencrypt func(*CryptoValue) (bool, error)) ([]byte, bool, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	// numbers must not lose precision
+	decoder.UseNumber()
+	var parsed any
+	if err := decoder.Decode(&parsed); err != nil {
+		return nil, false, zerrors.ThrowInternal(err, "CRYPT-Re1Js", "unable to parse document")
+	}
+	changed, err := reencryptJSONNode(parsed, reencrypt)
+	if err != nil || !changed {
+		return nil, false, err
+	}
+	reencrypted, err := json.Marshal(parsed)
+	if err != nil {
+		return nil, false, zerrors.ThrowInternal(err, "CRYPT-Re2Js", "unable to marshal document")
+	}
+	return reencrypted, true, nil
+}
+
+func reencryptJSONNode(node any, reencrypt func(*CryptoValue) (bool, error)) (changed bool, err error) {
+	switch n := node.(type) {
+	case map[string]any:
+		if value, ok := cryptoValueFromJSON(n); ok {
+			changed, err = reencrypt(value)
+			if err != nil || !changed {
+				return false, err
+			}
+			setJSONField(n, "algorithm", value.Algorithm)
+			setJSONField(n, "keyID", value.KeyID)
+			setJSONField(n, "crypted", base64.StdEncoding.EncodeToString(value.Crypted))
+			return true, nil
+		}
+		for _, child := range n {
+			childChanged, err := reencryptJSONNode(child, reencrypt)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || childChanged
+		}
+	case []any:
+		for _, child := range n {
+			childChanged, err := reencryptJSONNode(child, reencrypt)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || childChanged
+		}
+	}
+	return changed, nil
+}
+
+// cryptoValueFromJSON maps the object to a [CryptoValue] if it contains all its fields.
+// Field names are matched case-insensitively like [json.Unmarshal] does.
+func cryptoValueFromJSON(object map[string]any) (*CryptoValue, bool) {
+	cryptoType, ok := jsonField(object, "cryptoType").(json.Number)
+	if !ok {
+		return nil, false
+	}
+	algorithm, ok := jsonField(object, "algorithm").(string)
+	if !ok {
+		return nil, false
+	}
+	keyID, ok := jsonField(object, "keyID").(string)
+	if !ok {
+		return nil, false
+	}
+	crypted, ok := jsonField(object, "crypted").(string)
+	if !ok {
+		return nil, false
+	}
+	typ, err := cryptoType.Int64()
+	if err != nil {
+		return nil, false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(crypted)
+	if err != nil {
+		return nil, false
+	}
+	return &CryptoValue{
+		CryptoType: CryptoType(typ),
+		Algorithm:  algorithm,
+		KeyID:      keyID,
+		Crypted:    decoded,
+	}, true
+}
+
+func jsonField(object map[string]any, name string) any {
+	for key, value := range object {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return nil
+}
+
+// setJSONField overwrites the field with the existing name
+func setJSONField(object map[string]any, name string, value any) {
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			object[key] = value
+			return
+		}
+	}
+}
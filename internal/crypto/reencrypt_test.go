@@ -0,0 +1,167 @@
+package crypto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReencrypter() (*Reencrypter, *AESCrypto) {
+	alg := &AESCrypto{
+		keys: map[string]string{
+			"old": "oldpassphrasewhichneedstobe32by!",
+			"new": "newpassphrasewhichneedstobe32by!",
+		},
+		encryptionKeyID: "new",
+		keyIDs:          []string{"old", "new"},
+	}
+	return NewReencrypter(alg), alg
+}
+
+func encryptWithKey(t *testing.T, alg *AESCrypto, keyID, value string) *CryptoValue {
+	crypted, err := EncryptAES([]byte(value), alg.keys[keyID])
+	require.NoError(t, err)
+	return &CryptoValue{
+		CryptoType: TypeEncryption,
+		Algorithm:  alg.Algorithm(),
+		KeyID:      keyID,
+		Crypted:    crypted,
+	}
+}
+
+func TestReencrypter_Reencrypt(t *testing.T) {
+	reencrypter, alg := testReencrypter()
+	tests := []struct {
+		name        string
+		value       *CryptoValue
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			name:  "nil",
+			value: nil,
+		},
+		{
+			name: "hash",
+			value: &CryptoValue{
+				CryptoType: TypeHash,
+				Algorithm:  alg.Algorithm(),
+				KeyID:      "old",
+				Crypted:    []byte("hash"),
+			},
+		},
+		{
+			name:  "current key",
+			value: encryptWithKey(t, alg, "new", "secret"),
+		},
+		{
+			name: "unknown key",
+			value: &CryptoValue{
+				CryptoType: TypeEncryption,
+				Algorithm:  alg.Algorithm(),
+				KeyID:      "unknown",
+				Crypted:    []byte("crypted"),
+			},
+		},
+		{
+			name: "other algorithm",
+			value: &CryptoValue{
+				CryptoType: TypeEncryption,
+				Algorithm:  "rsa",
+				KeyID:      "old",
+				Crypted:    []byte("crypted"),
+			},
+		},
+		{
+			name: "invalid value",
+			value: &CryptoValue{
+				CryptoType: TypeEncryption,
+				Algorithm:  alg.Algorithm(),
+				KeyID:      "old",
+				Crypted:    []byte("crypted"),
+			},
+			wantErr: true,
+		},
+		{
+			name:        "retired key",
+			value:       encryptWithKey(t, alg, "old", "secret"),
+			wantChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := reencrypter.Reencrypt(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+			if !tt.wantChanged {
+				return
+			}
+			assert.Equal(t, "new", tt.value.KeyID)
+			decrypted, err := DecryptString(tt.value, alg)
+			require.NoError(t, err)
+			assert.Equal(t, "secret", decrypted)
+		})
+	}
+}
+
+func TestReencrypter_ReencryptJSON(t *testing.T) {
+	reencrypter, alg := testReencrypter()
+	retired, err := json.Marshal(encryptWithKey(t, alg, "old", "secret"))
+	require.NoError(t, err)
+	current, err := json.Marshal(encryptWithKey(t, alg, "new", "secret"))
+	require.NoError(t, err)
+
+	t.Run("unchanged", func(t *testing.T) {
+		document := `{"clientSecret": ` + string(current) + `, "sequence": 12345678901234567890}`
+		got, changed, err := reencrypter.ReencryptJSON([]byte(document))
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Nil(t, got)
+	})
+	t.Run("invalid document", func(t *testing.T) {
+		_, _, err := reencrypter.ReencryptJSON([]byte(`{`))
+		assert.Error(t, err)
+	})
+	t.Run("nested values", func(t *testing.T) {
+		lowerCase := `{"cryptoType": 0, "algorithm": "aes", "keyID": "old", "crypted": "` + string(retiredCrypted(t, retired)) + `"}`
+		document := `{"privateKey": {"key": ` + string(retired) + `, "expiry": "2024-01-01T00:00:00Z"}, "secrets": [` + lowerCase + `], "sequence": 12345678901234567890}`
+		got, changed, err := reencrypter.ReencryptJSON([]byte(document))
+		require.NoError(t, err)
+		assert.True(t, changed)
+
+		var payload struct {
+			PrivateKey struct {
+				Key    *CryptoValue `json:"key"`
+				Expiry string       `json:"expiry"`
+			} `json:"privateKey"`
+			Secrets  []*CryptoValue `json:"secrets"`
+			Sequence json.Number    `json:"sequence"`
+		}
+		require.NoError(t, json.Unmarshal(got, &payload))
+		assert.Equal(t, "2024-01-01T00:00:00Z", payload.PrivateKey.Expiry)
+		assert.Equal(t, json.Number("12345678901234567890"), payload.Sequence)
+		for _, value := range append(payload.Secrets, payload.PrivateKey.Key) {
+			assert.Equal(t, "new", value.KeyID)
+			decrypted, err := DecryptString(value, alg)
+			require.NoError(t, err)
+			assert.Equal(t, "secret", decrypted)
+		}
+		// the field names of the document are kept
+		assert.Contains(t, string(got), `"keyID":"new"`)
+		assert.Contains(t, string(got), `"KeyID":"new"`)
+	})
+}
+
+func retiredCrypted(t *testing.T, value []byte) []byte {
+	var parsed struct {
+		Crypted json.RawMessage
+	}
+	require.NoError(t, json.Unmarshal(value, &parsed))
+	return parsed.Crypted[1 : len(parsed.Crypted)-1]
+}
@@ -0,0 +1,60 @@
+package crypto
+
+import (
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Transcrypter encrypts the values of mapped keys by another algorithm,
+// e.g. to transfer secrets between deployments which don't share their encryption keys.
+// Values of keys which aren't mapped are rejected, hashes remain unchanged.
+type Transcrypter struct {
+	mappings map[string]*keyMapping
+}
+
+type keyMapping struct {
+	from, to EncryptionAlgorithm
+}
+
+func NewTranscrypter() *Transcrypter {
+	return &Transcrypter{mappings: make(map[string]*keyMapping)}
+}
+
+// Map encrypts the values of all keys of from by the encryption key of to.
+// Keys shared by multiple configurations keep their first mapping.
+func (t *Transcrypter) Map(from, to EncryptionAlgorithm) *Transcrypter {
+	for _, keyID := range from.DecryptionKeyIDs() {
+		if _, ok := t.mappings[keyID]; !ok {
+			t.mappings[keyID] = &keyMapping{from: from, to: to}
+		}
+	}
+	return t
+}
+
+// Transcrypt updates the value in place and returns true if it was encrypted
+func (t *Transcrypter) Transcrypt(value *CryptoValue) (bool, error) {
+	if value == nil || value.CryptoType != TypeEncryption {
+		return false, nil
+	}
+	mapping, ok := t.mappings[value.KeyID]
+	if !ok || mapping.from.Algorithm() != value.Algorithm {
+		return false, zerrors.ThrowPreconditionFailedf(nil, "CRYPT-Tc1Km", "no mapping for key %s of algorithm %s", value.KeyID, value.Algorithm)
+	}
+	decrypted, err := mapping.from.Decrypt(value.Crypted, value.KeyID)
+	if err != nil {
+		return false, err
+	}
+	crypted, err := mapping.to.Encrypt(decrypted)
+	if err != nil {
+		return false, err
+	}
+	value.Algorithm = mapping.to.Algorithm()
+	value.KeyID = mapping.to.EncryptionKeyID()
+	value.Crypted = crypted
+	return true, nil
+}
+
+// TranscryptJSON encrypts all encrypted values in the JSON document, e.g. an event payload.
+// The document is only returned if at least one value changed.
+func (t *Transcrypter) TranscryptJSON(document []byte) ([]byte, bool, error) {
+	return reencryptJSON(document, t.Transcrypt)
+}
@@ -0,0 +1,110 @@
+package crypto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscrypter_Transcrypt(t *testing.T) {
+	_, source := testReencrypter()
+	target := NewStaticAESCrypto("exported.User", "exportpassphrasewhichneedstobe32")
+	transcrypter := NewTranscrypter().Map(source, target)
+	tests := []struct {
+		name        string
+		value       *CryptoValue
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			name:  "nil",
+			value: nil,
+		},
+		{
+			name: "hash",
+			value: &CryptoValue{
+				CryptoType: TypeHash,
+				Algorithm:  "bcrypt",
+				KeyID:      "unknown",
+				Crypted:    []byte("hash"),
+			},
+		},
+		{
+			name: "unmapped key",
+			value: &CryptoValue{
+				CryptoType: TypeEncryption,
+				Algorithm:  source.Algorithm(),
+				KeyID:      "unknown",
+				Crypted:    []byte("crypted"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "other algorithm",
+			value: &CryptoValue{
+				CryptoType: TypeEncryption,
+				Algorithm:  "rsa",
+				KeyID:      "new",
+				Crypted:    []byte("crypted"),
+			},
+			wantErr: true,
+		},
+		{
+			name:        "encryption key",
+			value:       encryptWithKey(t, source, "new", "secret"),
+			wantChanged: true,
+		},
+		{
+			name:        "decryption key",
+			value:       encryptWithKey(t, source, "old", "secret"),
+			wantChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := transcrypter.Transcrypt(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+			if !tt.wantChanged {
+				return
+			}
+			assert.Equal(t, "exported.User", tt.value.KeyID)
+			decrypted, err := DecryptString(tt.value, target)
+			require.NoError(t, err)
+			assert.Equal(t, "secret", decrypted)
+		})
+	}
+}
+
+func TestTranscrypter_TranscryptJSON(t *testing.T) {
+	_, source := testReencrypter()
+	exported := NewStaticAESCrypto("exported.User", "exportpassphrasewhichneedstobe32")
+	value, err := json.Marshal(encryptWithKey(t, source, "old", "secret"))
+	require.NoError(t, err)
+	document := []byte(`{"secret": ` + string(value) + `, "sequence": 12345678901234567890}`)
+
+	// export and import back into the source keys
+	got, changed, err := NewTranscrypter().Map(source, exported).TranscryptJSON(document)
+	require.NoError(t, err)
+	require.True(t, changed)
+	got, changed, err = NewTranscrypter().Map(exported, source).TranscryptJSON(got)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	var payload struct {
+		Secret   *CryptoValue `json:"secret"`
+		Sequence json.Number  `json:"sequence"`
+	}
+	require.NoError(t, json.Unmarshal(got, &payload))
+	assert.Equal(t, json.Number("12345678901234567890"), payload.Sequence)
+	assert.Equal(t, "new", payload.Secret.KeyID)
+	decrypted, err := DecryptString(payload.Secret, source)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+}
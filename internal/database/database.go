@@ -21,7 +21,9 @@ type Config struct {
 	Dialects                   map[string]interface{} `mapstructure:",remain"`
 	EventPushConnRatio         float64
 	ProjectionSpoolerConnRatio float64
-	connector                  dialect.Connector
+	// Replicas serve reads of queries, only supported by postgres
+	Replicas  ReplicasConfig
+	connector dialect.Connector
 }
 
 func (c *Config) SetConnector(connector dialect.Connector) {
@@ -31,6 +33,21 @@ func (c *Config) SetConnector(connector dialect.Connector) {
 type DB struct {
 	*sql.DB
 	dialect.Database
+	replicas *replicas
+	shards   *shards
+}
+
+// reader returns the pool serving the reads of the context on the shard of the context,
+// reads are served by a replica if the context is marked by [WithCallType] and a replica is available
+func (db *DB) reader(ctx context.Context) (*sql.DB, error) {
+	pool, err := db.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if replica := pool.replicas.reader(ctx); replica != nil {
+		return replica, nil
+	}
+	return pool.DB, nil
 }
 
 func (db *DB) Query(scan func(*sql.Rows) error, query string, args ...any) error {
@@ -38,7 +55,11 @@ func (db *DB) Query(scan func(*sql.Rows) error, query string, args ...any) error
 }
 
 func (db *DB) QueryContext(ctx context.Context, scan func(rows *sql.Rows) error, query string, args ...any) (err error) {
-	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	reader, err := db.reader(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := reader.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return err
 	}
@@ -71,7 +92,11 @@ func (db *DB) QueryRow(scan func(*sql.Row) error, query string, args ...any) (er
 }
 
 func (db *DB) QueryRowContext(ctx context.Context, scan func(row *sql.Row) error, query string, args ...any) (err error) {
-	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	reader, err := db.reader(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := reader.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return err
 	}
@@ -122,9 +147,15 @@ func Connect(config Config, useAdmin bool, purpose dialect.DBPurpose) (*DB, erro
 		return nil, zerrors.ThrowPreconditionFailed(err, "DATAB-0pIWD", "Errors.Database.Connection.Failed")
 	}
 
+	replicas, err := connectReplicas(config.Replicas, config.connector, purpose)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DB{
 		DB:       client,
 		Database: config.connector,
+		replicas: replicas,
 	}, nil
 }
 
@@ -134,7 +165,15 @@ func DecodeHook(from, to reflect.Value) (_ interface{}, err error) {
 	}
 
 	config := new(Config)
-	if err = mapstructure.Decode(from.Interface(), config); err != nil {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.ComposeDecodeHookFunc(mapstructure.StringToTimeDurationHookFunc(), mapstructure.StringToSliceHookFunc(",")),
+		WeaklyTypedInput: true,
+		Result:           config,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = decoder.Decode(from.Interface()); err != nil {
 		return nil, err
 	}
 
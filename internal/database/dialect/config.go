@@ -58,6 +58,15 @@ type Connector interface {
 	Database
 }
 
+// ReplicaConnector is implemented by dialects supporting read replicas
+type ReplicaConnector interface {
+	// ConnectReplica connects to the replica with the configuration of the primary
+	ConnectReplica(host string, port int32, purpose DBPurpose) (*sql.DB, error)
+	// ReplicationLagQuery returns the statement querying the replication lag of a replica in seconds,
+	// the lag must be NULL if the replica does not replicate from the primary
+	ReplicationLagQuery() string
+}
+
 type Database interface {
 	DatabaseName() string
 	Username() string
@@ -88,6 +88,30 @@ func (c *Config) Connect(useAdmin bool, pusherRatio, spoolerRatio float64, purpo
 	return client, nil
 }
 
+// ConnectReplica connects to the replica with the users and pool sizes of the primary
+func (c *Config) ConnectReplica(host string, port int32, purpose dialect.DBPurpose) (*sql.DB, error) {
+	replica := *c
+	replica.Host = host
+	if port != 0 {
+		replica.Port = port
+	}
+	return replica.Connect(false, 0, 0, purpose)
+}
+
+// ReplicationLagQuery returns the time since the last replayed transaction,
+// the lag is 0 if all received changes are replayed.
+// The lag is NULL if the replica does not stream from the primary,
+// because a replica which lost its connection has replayed everything it received.
+// The status of the WAL receiver is only visible to roles with the privileges of pg_read_all_stats,
+// otherwise only a missing WAL receiver is detected.
+func (c *Config) ReplicationLagQuery() string {
+	return `SELECT CASE
+	WHEN NOT EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE COALESCE(status, 'streaming') = 'streaming') THEN NULL
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+}
+
 func (c *Config) DatabaseName() string {
 	return c.Database
 }
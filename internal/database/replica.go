@@ -0,0 +1,197 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// CallType defines which kind of read is executed.
+// Replicas only serve reads of the call types they are configured for.
+type CallType string
+
+const (
+	// CallTypeGet reads a single object, e.g. a user by its id.
+	// Reads of single objects are served by the primary unless they opt in by [WithCallType].
+	CallTypeGet CallType = "get"
+	// CallTypeList lists or searches objects
+	CallTypeList CallType = "list"
+)
+
+const (
+	defaultReplicaMaxLag        = 5 * time.Second
+	defaultReplicaCheckInterval = 5 * time.Second
+)
+
+type ReplicasConfig struct {
+	// Hosts are the read replicas of the database
+	// Reads fall back to the primary if no replica is available for the call type
+	Hosts []ReplicaConfig
+	// MaxLag defines how far a replica can lag behind the primary to still serve reads
+	MaxLag time.Duration
+	// CheckInterval defines how often the health and the replication lag of the replicas are checked
+	CheckInterval time.Duration
+}
+
+type ReplicaConfig struct {
+	Host string
+	Port int32
+	// CallTypes served by the replica, either "get" or "list"
+	// All call types are served if empty
+	CallTypes []CallType
+}
+
+type replicaCtxKey struct{}
+
+type replicaCtx struct {
+	callType CallType
+	primary  bool
+}
+
+// WithCallType marks the reads of the context as call type so they can be served by a replica
+func WithCallType(ctx context.Context, callType CallType) context.Context {
+	current, _ := ctx.Value(replicaCtxKey{}).(*replicaCtx)
+	if current != nil && current.primary {
+		return ctx
+	}
+	return context.WithValue(ctx, replicaCtxKey{}, &replicaCtx{callType: callType})
+}
+
+// WithPrimary ensures that the reads of the context are served by the primary,
+// e.g. if the reads must contain data written just before
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaCtxKey{}, &replicaCtx{primary: true})
+}
+
+type replica struct {
+	client    *sql.DB
+	host      string
+	callTypes []CallType
+	// available is false if the replica is unhealthy or lags behind the primary
+	available atomic.Bool
+}
+
+func (r *replica) serves(callType CallType) bool {
+	if len(r.callTypes) == 0 {
+		return true
+	}
+	for _, served := range r.callTypes {
+		if served == callType {
+			return true
+		}
+	}
+	return false
+}
+
+type replicas struct {
+	replicas []*replica
+	lagQuery string
+	maxLag   time.Duration
+	next     atomic.Uint32
+}
+
+func connectReplicas(config ReplicasConfig, connector dialect.Connector, purpose dialect.DBPurpose) (*replicas, error) {
+	if len(config.Hosts) == 0 || purpose != dialect.DBPurposeQuery {
+		return nil, nil
+	}
+	replicaConnector, ok := connector.(dialect.ReplicaConnector)
+	if !ok {
+		return nil, zerrors.ThrowPreconditionFailedf(nil, "DATAB-Rep1c", "read replicas are not supported by %s", connector.Type())
+	}
+	set := &replicas{
+		replicas: make([]*replica, len(config.Hosts)),
+		lagQuery: replicaConnector.ReplicationLagQuery(),
+		maxLag:   config.MaxLag,
+	}
+	if set.maxLag == 0 {
+		set.maxLag = defaultReplicaMaxLag
+	}
+	for i, host := range config.Hosts {
+		client, err := replicaConnector.ConnectReplica(host.Host, host.Port, purpose)
+		if err != nil {
+			return nil, err
+		}
+		set.replicas[i] = &replica{
+			client:    client,
+			host:      host.Host,
+			callTypes: host.CallTypes,
+		}
+	}
+	// replicas only serve reads after their first successful check
+	set.check(context.Background(), true)
+	interval := config.CheckInterval
+	if interval == 0 {
+		interval = defaultReplicaCheckInterval
+	}
+	go set.checkEvery(interval)
+	return set, nil
+}
+
+// reader returns the replica serving the reads of the context,
+// nil is returned if the reads are served by the primary.
+// Reads are only served by a replica if the context is marked by [WithCallType],
+// all other reads must read their own writes and are served by the primary.
+func (set *replicas) reader(ctx context.Context) *sql.DB {
+	if set == nil {
+		return nil
+	}
+	current, _ := ctx.Value(replicaCtxKey{}).(*replicaCtx)
+	if current == nil || current.primary || current.callType == "" {
+		return nil
+	}
+	// round robin over the available replicas
+	start := set.next.Add(1)
+	for i := range set.replicas {
+		r := set.replicas[(int(start)+i)%len(set.replicas)]
+		if r.available.Load() && r.serves(current.callType) {
+			return r.client
+		}
+	}
+	return nil
+}
+
+func (set *replicas) checkEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		set.check(context.Background(), false)
+	}
+}
+
+func (set *replicas) check(ctx context.Context, initial bool) {
+	for _, r := range set.replicas {
+		err := set.checkReplica(ctx, r)
+		available := err == nil
+		if r.available.Swap(available) == available && !initial {
+			continue
+		}
+		if available {
+			logging.WithFields("host", r.host).Info("replica serves reads")
+			continue
+		}
+		logging.WithFields("host", r.host).WithError(err).Warn("replica unavailable, reads are served by the primary")
+	}
+}
+
+func (set *replicas) checkReplica(ctx context.Context, r *replica) error {
+	ctx, cancel := context.WithTimeout(ctx, set.maxLag)
+	defer cancel()
+	var lag sql.NullFloat64
+	if err := r.client.QueryRowContext(ctx, set.lagQuery).Scan(&lag); err != nil {
+		return err
+	}
+	// the lag of a replica which stopped streaming from the primary is unknown
+	if !lag.Valid {
+		return zerrors.ThrowUnavailable(nil, "DATAB-Rep3S", "replica does not stream from the primary")
+	}
+	if lagDuration := time.Duration(lag.Float64 * float64(time.Second)); lagDuration > set.maxLag {
+		return zerrors.ThrowUnavailablef(nil, "DATAB-Rep2L", "replication lag of %s exceeds the max lag", lagDuration)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/database/mock"
+)
+
+func testReplica(available bool, callTypes ...CallType) *replica {
+	r := &replica{
+		client:    new(sql.DB),
+		callTypes: callTypes,
+	}
+	r.available.Store(available)
+	return r
+}
+
+func Test_replicas_reader(t *testing.T) {
+	available := testReplica(true)
+	listOnly := testReplica(true, CallTypeList)
+	unavailable := testReplica(false)
+	tests := []struct {
+		name     string
+		replicas *replicas
+		ctx      context.Context
+		want     *sql.DB
+	}{
+		{
+			name:     "no replicas",
+			replicas: nil,
+			ctx:      WithCallType(context.Background(), CallTypeList),
+			want:     nil,
+		},
+		{
+			name:     "no call type",
+			replicas: &replicas{replicas: []*replica{available}},
+			ctx:      context.Background(),
+			want:     nil,
+		},
+		{
+			name:     "get call type",
+			replicas: &replicas{replicas: []*replica{available}},
+			ctx:      WithCallType(context.Background(), CallTypeGet),
+			want:     available.client,
+		},
+		{
+			name:     "call type of context",
+			replicas: &replicas{replicas: []*replica{listOnly}},
+			ctx:      WithCallType(context.Background(), CallTypeList),
+			want:     listOnly.client,
+		},
+		{
+			name:     "call type not served",
+			replicas: &replicas{replicas: []*replica{listOnly}},
+			ctx:      WithCallType(context.Background(), CallTypeGet),
+			want:     nil,
+		},
+		{
+			name:     "primary",
+			replicas: &replicas{replicas: []*replica{available}},
+			ctx:      WithPrimary(context.Background()),
+			want:     nil,
+		},
+		{
+			name:     "primary not overwritten",
+			replicas: &replicas{replicas: []*replica{available}},
+			ctx:      WithCallType(WithPrimary(context.Background()), CallTypeList),
+			want:     nil,
+		},
+		{
+			name:     "failover",
+			replicas: &replicas{replicas: []*replica{unavailable, available}},
+			ctx:      WithCallType(context.Background(), CallTypeList),
+			want:     available.client,
+		},
+		{
+			name:     "no replica available",
+			replicas: &replicas{replicas: []*replica{unavailable}},
+			ctx:      WithCallType(context.Background(), CallTypeList),
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.replicas.reader(tt.ctx)
+			assert.Same(t, tt.want, got)
+		})
+	}
+}
+
+func Test_replicas_reader_roundRobin(t *testing.T) {
+	first, second := testReplica(true), testReplica(true)
+	set := &replicas{replicas: []*replica{first, second}}
+	ctx := WithCallType(context.Background(), CallTypeList)
+
+	got := set.reader(ctx)
+	assert.NotSame(t, got, set.reader(ctx))
+	assert.Same(t, got, set.reader(ctx))
+}
+
+func Test_replicas_check(t *testing.T) {
+	const lagQuery = "SELECT lag"
+	tests := []struct {
+		name          string
+		mock          func(*testing.T) *mock.SQLMock
+		wantAvailable bool
+	}{
+		{
+			name: "query error",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(lagQuery, mock.WithQueryErr(sql.ErrConnDone)),
+				)
+			},
+			wantAvailable: false,
+		},
+		{
+			name: "lag exceeded",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(lagQuery, mock.WithQueryResult([]string{"lag"}, [][]driver.Value{{6.5}})),
+				)
+			},
+			wantAvailable: false,
+		},
+		{
+			name: "not streaming",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(lagQuery, mock.WithQueryResult([]string{"lag"}, [][]driver.Value{{nil}})),
+				)
+			},
+			wantAvailable: false,
+		},
+		{
+			name: "available",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(lagQuery, mock.WithQueryResult([]string{"lag"}, [][]driver.Value{{0.5}})),
+				)
+			},
+			wantAvailable: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := tt.mock(t)
+			r := &replica{client: client.DB, host: "replica"}
+			// the state is inverted to ensure the check updates it
+			r.available.Store(!tt.wantAvailable)
+			set := &replicas{
+				replicas: []*replica{r},
+				lagQuery: lagQuery,
+				maxLag:   5 * time.Second,
+			}
+			set.check(context.Background(), false)
+			assert.Equal(t, tt.wantAvailable, r.available.Load())
+			client.Assert(t)
+		})
+	}
+}
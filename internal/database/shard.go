@@ -0,0 +1,344 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// DefaultShard is the cluster configured in the Database section.
+// Instances without placement are located on the default shard.
+const DefaultShard = "default"
+
+const defaultPlacementCacheTTL = 15 * time.Second
+
+type ShardingConfig struct {
+	// Shards are the additional database clusters instances can be placed on
+	Shards map[string]Config
+	// PlacementCacheTTL defines how long the placement of an instance is cached
+	// Moving an instance blocks its writes at least twice as long
+	PlacementCacheTTL time.Duration
+}
+
+// Placement locates an instance on a shard
+type Placement struct {
+	InstanceID string
+	Shard      string
+	// Locked placements reject pushed events, e.g. while the instance is moved to another shard
+	Locked bool
+
+	// LockOwner identifies the move holding the lock, the move renews LockExpiry while it's running
+	LockOwner string
+	// LockTarget is the shard the locked instance is moved to
+	LockTarget string
+	LockExpiry time.Time
+}
+
+// StaleLock reports if the lock wasn't renewed in time, e.g. because the process moving the instance crashed
+func (p *Placement) StaleLock(now time.Time) bool {
+	return p.Locked && !now.Before(p.LockExpiry)
+}
+
+type shardCtxKey struct{}
+
+// WithShard routes the queries of the context to the shard instead of the shard of the instance,
+// e.g. to query all instances of a shard
+func WithShard(ctx context.Context, shard string) context.Context {
+	return context.WithValue(ctx, shardCtxKey{}, shard)
+}
+
+func shardFromContext(ctx context.Context) (string, bool) {
+	shard, ok := ctx.Value(shardCtxKey{}).(string)
+	return shard, ok
+}
+
+type shards struct {
+	pools      map[string]*DB
+	instanceID func(ctx context.Context) string
+	placements *placements
+}
+
+// ConnectSharded connects to the default shard and all shards of the config.
+// The queries of an instance are routed to the shard the instance is placed on,
+// the instance is resolved by instanceID.
+func ConnectSharded(config Config, sharding *ShardingConfig, purpose dialect.DBPurpose, instanceID func(ctx context.Context) string) (*DB, error) {
+	db, err := Connect(config, false, purpose)
+	if err != nil {
+		return nil, err
+	}
+	if sharding == nil || len(sharding.Shards) == 0 {
+		return db, nil
+	}
+	db.shards = &shards{
+		pools:      make(map[string]*DB, len(sharding.Shards)),
+		instanceID: instanceID,
+		placements: newPlacements(db.DB, sharding.PlacementCacheTTL),
+	}
+	for name, shardConfig := range sharding.Shards {
+		if name == DefaultShard {
+			return nil, zerrors.ThrowInvalidArgumentf(nil, "DATAB-Shr1N", "shard name %q is reserved for the database", DefaultShard)
+		}
+		db.shards.pools[name], err = Connect(shardConfig, false, purpose)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// Shards returns the names of all shards, starting with [DefaultShard]
+func (db *DB) Shards() []string {
+	names := []string{DefaultShard}
+	if db.shards == nil {
+		return names
+	}
+	shardNames := make([]string, 0, len(db.shards.pools))
+	for name := range db.shards.pools {
+		shardNames = append(shardNames, name)
+	}
+	slices.Sort(shardNames)
+	return append(names, shardNames...)
+}
+
+// PlacementCacheTTL returns how long placements are cached,
+// changed placements are observed by all processes after this duration
+func (db *DB) PlacementCacheTTL() time.Duration {
+	if db.shards == nil {
+		return 0
+	}
+	return db.shards.placements.ttl
+}
+
+// pool returns the client of the shard the context is routed to
+func (db *DB) pool(ctx context.Context) (*DB, error) {
+	if db.shards == nil {
+		return db, nil
+	}
+	shard, ok := shardFromContext(ctx)
+	if !ok {
+		instanceID := db.shards.instanceID(ctx)
+		if instanceID == "" {
+			return db, nil
+		}
+		placement, err := db.shards.placements.get(ctx, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		shard = placement.Shard
+	}
+	return db.shardPool(shard)
+}
+
+func (db *DB) shardPool(shard string) (*DB, error) {
+	if shard == DefaultShard {
+		return db, nil
+	}
+	pool, ok := db.shards.pools[shard]
+	if !ok {
+		return nil, zerrors.ThrowNotFoundf(nil, "DATAB-Shr2P", "shard %q not configured", shard)
+	}
+	return pool, nil
+}
+
+// ShardPool returns the connection pool of the shard the context is routed to,
+// e.g. for statements executed on [sql.DB] directly
+func (db *DB) ShardPool(ctx context.Context) (*sql.DB, error) {
+	pool, err := db.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pool.DB, nil
+}
+
+// BeginTx starts the transaction on the shard of the context
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	pool, err := db.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pool.DB.BeginTx(ctx, opts)
+}
+
+// ExecContext executes the statement on the shard of the context
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	pool, err := db.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pool.DB.ExecContext(ctx, query, args...)
+}
+
+// CheckWritable returns an error if the instance of the context must not be written,
+// because the instance is moved or the context is routed to a shard the instance isn't placed on.
+// It's checked before events are pushed and before rows of the instance are written directly,
+// e.g. auth requests, assets and quota usage.
+func (db *DB) CheckWritable(ctx context.Context) error {
+	if db.shards == nil {
+		return nil
+	}
+	instanceID := db.shards.instanceID(ctx)
+	if instanceID == "" {
+		return nil
+	}
+	placement, err := db.shards.placements.get(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	if placement.Locked {
+		return zerrors.ThrowUnavailable(nil, "DATAB-Shr3L", "Errors.Instance.Moving")
+	}
+	if shard, ok := shardFromContext(ctx); ok && shard != placement.Shard {
+		return zerrors.ThrowPreconditionFailedf(nil, "DATAB-Shr4W", "instance is not placed on shard %q", shard)
+	}
+	return nil
+}
+
+// Placement returns the current placement of the instance, the cache is bypassed
+func (db *DB) Placement(ctx context.Context, instanceID string) (*Placement, error) {
+	if db.shards == nil {
+		return &Placement{InstanceID: instanceID, Shard: DefaultShard}, nil
+	}
+	return db.shards.placements.load(ctx, instanceID)
+}
+
+// SetPlacement places the instance on the shard
+func (db *DB) SetPlacement(ctx context.Context, placement *Placement) error {
+	if db.shards == nil {
+		if placement.Shard == DefaultShard {
+			return nil
+		}
+		return zerrors.ThrowPreconditionFailed(nil, "DATAB-Shr5S", "sharding is not configured")
+	}
+	if _, err := db.shardPool(placement.Shard); err != nil {
+		return err
+	}
+	return db.shards.placements.set(ctx, placement)
+}
+
+// RenewPlacementLock extends the lock of the instance until expiry and hands it over from previousOwner to owner.
+// It fails if the lock isn't held by previousOwner anymore, e.g. because it was taken over after it became stale.
+func (db *DB) RenewPlacementLock(ctx context.Context, instanceID, previousOwner, owner string, expiry time.Time) error {
+	if db.shards == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "DATAB-Shr8R", "sharding is not configured")
+	}
+	return db.shards.placements.update(ctx, renewPlacementLockStmt, instanceID, previousOwner, owner, expiry)
+}
+
+// ReleasePlacementLock places the instance on the shard and unlocks it if the lock is still held by owner
+func (db *DB) ReleasePlacementLock(ctx context.Context, instanceID, owner, shard string) error {
+	if db.shards == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "DATAB-Shr9U", "sharding is not configured")
+	}
+	if _, err := db.shardPool(shard); err != nil {
+		return err
+	}
+	return db.shards.placements.update(ctx, releasePlacementLockStmt, instanceID, owner, shard)
+}
+
+const (
+	placementQuery   = `SELECT shard, locked, lock_owner, lock_target, lock_expiry FROM system.instance_placements WHERE instance_id = $1`
+	setPlacementStmt = `INSERT INTO system.instance_placements (instance_id, shard, locked, lock_owner, lock_target, lock_expiry, change_date)` +
+		` VALUES ($1, $2, $3, $4, $5, $6, NOW())` +
+		` ON CONFLICT (instance_id) DO UPDATE SET shard = EXCLUDED.shard, locked = EXCLUDED.locked,` +
+		` lock_owner = EXCLUDED.lock_owner, lock_target = EXCLUDED.lock_target, lock_expiry = EXCLUDED.lock_expiry, change_date = EXCLUDED.change_date`
+	// locks created before the owner was stored have no owner
+	renewPlacementLockStmt = `UPDATE system.instance_placements SET lock_owner = $3, lock_expiry = $4, change_date = NOW()` +
+		` WHERE instance_id = $1 AND locked AND COALESCE(lock_owner, '') = $2`
+	releasePlacementLockStmt = `UPDATE system.instance_placements SET shard = $3, locked = FALSE, lock_owner = NULL, lock_target = NULL, lock_expiry = NULL, change_date = NOW()` +
+		` WHERE instance_id = $1 AND locked AND lock_owner = $2`
+)
+
+type cachedPlacement struct {
+	placement *Placement
+	expiry    time.Time
+}
+
+// placements are stored on the default shard and cached for ttl
+type placements struct {
+	client *sql.DB
+	ttl    time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]*cachedPlacement
+}
+
+func newPlacements(client *sql.DB, ttl time.Duration) *placements {
+	if ttl == 0 {
+		ttl = defaultPlacementCacheTTL
+	}
+	return &placements{
+		client: client,
+		ttl:    ttl,
+		cache:  make(map[string]*cachedPlacement),
+	}
+}
+
+func (p *placements) get(ctx context.Context, instanceID string) (*Placement, error) {
+	p.mu.RLock()
+	cached, ok := p.cache[instanceID]
+	p.mu.RUnlock()
+	if ok && time.Now().Before(cached.expiry) {
+		return cached.placement, nil
+	}
+	return p.load(ctx, instanceID)
+}
+
+func (p *placements) load(ctx context.Context, instanceID string) (*Placement, error) {
+	var (
+		placement             = &Placement{InstanceID: instanceID}
+		lockOwner, lockTarget sql.NullString
+		lockExpiry            sql.NullTime
+	)
+	err := p.client.QueryRowContext(ctx, placementQuery, instanceID).Scan(&placement.Shard, &placement.Locked, &lockOwner, &lockTarget, &lockExpiry)
+	placement.LockOwner, placement.LockTarget, placement.LockExpiry = lockOwner.String, lockTarget.String, lockExpiry.Time
+	if errors.Is(err, sql.ErrNoRows) {
+		placement.Shard = DefaultShard
+		err = nil
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "DATAB-Shr6Q", "Errors.Internal")
+	}
+	p.mu.Lock()
+	p.cache[instanceID] = &cachedPlacement{placement: placement, expiry: time.Now().Add(p.ttl)}
+	p.mu.Unlock()
+	return placement, nil
+}
+
+func (p *placements) set(ctx context.Context, placement *Placement) error {
+	_, err := p.client.ExecContext(ctx, setPlacementStmt,
+		placement.InstanceID,
+		placement.Shard,
+		placement.Locked,
+		sql.NullString{String: placement.LockOwner, Valid: placement.LockOwner != ""},
+		sql.NullString{String: placement.LockTarget, Valid: placement.LockTarget != ""},
+		sql.NullTime{Time: placement.LockExpiry, Valid: !placement.LockExpiry.IsZero()},
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "DATAB-Shr7S", "Errors.Internal")
+	}
+	p.mu.Lock()
+	p.cache[placement.InstanceID] = &cachedPlacement{placement: placement, expiry: time.Now().Add(p.ttl)}
+	p.mu.Unlock()
+	return nil
+}
+
+// update executes a statement changing the lock of the placement, the cached placement is reloaded on its next use
+func (p *placements) update(ctx context.Context, stmt, instanceID string, args ...any) error {
+	res, err := p.client.ExecContext(ctx, stmt, append([]any{instanceID}, args...)...)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "DATAB-Shr0U", "Errors.Internal")
+	}
+	p.mu.Lock()
+	delete(p.cache, instanceID)
+	p.mu.Unlock()
+	if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+		return zerrors.ThrowPreconditionFailed(err, "DATAB-ShrAL", "lock of the instance is not held")
+	}
+	return nil
+}
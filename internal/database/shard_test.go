@@ -0,0 +1,339 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type testInstanceKey struct{}
+
+func testInstanceID(ctx context.Context) string {
+	instanceID, _ := ctx.Value(testInstanceKey{}).(string)
+	return instanceID
+}
+
+func withTestInstance(ctx context.Context, instanceID string) context.Context {
+	return context.WithValue(ctx, testInstanceKey{}, instanceID)
+}
+
+func testShardedDB(client *sql.DB, cached ...*Placement) *DB {
+	placements := newPlacements(client, time.Hour)
+	for _, placement := range cached {
+		placements.cache[placement.InstanceID] = &cachedPlacement{placement: placement, expiry: time.Now().Add(time.Hour)}
+	}
+	return &DB{
+		DB: client,
+		shards: &shards{
+			pools: map[string]*DB{
+				"shard-b": {DB: new(sql.DB)},
+				"shard-a": {DB: new(sql.DB)},
+			},
+			instanceID: testInstanceID,
+			placements: placements,
+		},
+	}
+}
+
+func TestDB_Shards(t *testing.T) {
+	assert.Equal(t, []string{DefaultShard}, new(DB).Shards())
+	assert.Equal(t, []string{DefaultShard, "shard-a", "shard-b"}, testShardedDB(nil).Shards())
+}
+
+func TestDB_pool(t *testing.T) {
+	db := testShardedDB(new(sql.DB),
+		&Placement{InstanceID: "placed", Shard: "shard-a"},
+		&Placement{InstanceID: "unknown-shard", Shard: "removed"},
+	)
+	tests := []struct {
+		name    string
+		db      *DB
+		ctx     context.Context
+		want    *DB
+		wantErr func(error) bool
+	}{
+		{
+			name: "not sharded",
+			db:   &DB{},
+			ctx:  withTestInstance(context.Background(), "placed"),
+		},
+		{
+			name: "no instance",
+			db:   db,
+			ctx:  context.Background(),
+			want: db,
+		},
+		{
+			name: "placement of instance",
+			db:   db,
+			ctx:  withTestInstance(context.Background(), "placed"),
+			want: db.shards.pools["shard-a"],
+		},
+		{
+			name: "shard of context",
+			db:   db,
+			ctx:  WithShard(withTestInstance(context.Background(), "placed"), "shard-b"),
+			want: db.shards.pools["shard-b"],
+		},
+		{
+			name: "default shard of context",
+			db:   db,
+			ctx:  WithShard(withTestInstance(context.Background(), "placed"), DefaultShard),
+			want: db,
+		},
+		{
+			name:    "unknown shard",
+			db:      db,
+			ctx:     withTestInstance(context.Background(), "unknown-shard"),
+			wantErr: zerrors.IsNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.db.pool(tt.ctx)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == nil {
+				tt.want = tt.db
+			}
+			assert.Same(t, tt.want, got)
+		})
+	}
+}
+
+func TestDB_pool_loadPlacement(t *testing.T) {
+	tests := []struct {
+		name      string
+		mock      func(*testing.T) *mock.SQLMock
+		wantShard string
+		wantErr   bool
+	}{
+		{
+			name: "not placed",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(placementQuery, mock.WithQueryArgs("instance"), mock.WithQueryErr(sql.ErrNoRows)),
+				)
+			},
+			wantShard: DefaultShard,
+		},
+		{
+			name: "placed",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(placementQuery,
+						mock.WithQueryArgs("instance"),
+						mock.WithQueryResult([]string{"shard", "locked", "lock_owner", "lock_target", "lock_expiry"}, [][]driver.Value{{"shard-a", false, nil, nil, nil}}),
+					),
+				)
+			},
+			wantShard: "shard-a",
+		},
+		{
+			name: "query error",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(placementQuery, mock.WithQueryArgs("instance"), mock.WithQueryErr(sql.ErrConnDone)),
+				)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := tt.mock(t)
+			db := testShardedDB(client.DB)
+			ctx := withTestInstance(context.Background(), "instance")
+
+			got, err := db.pool(ctx)
+			client.Assert(t)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			want, err := db.shardPool(tt.wantShard)
+			require.NoError(t, err)
+			assert.Same(t, want, got)
+			// the placement is cached
+			_, err = db.pool(ctx)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDB_CheckWritable(t *testing.T) {
+	db := testShardedDB(new(sql.DB),
+		&Placement{InstanceID: "placed", Shard: "shard-a"},
+		&Placement{InstanceID: "locked", Shard: "shard-a", Locked: true},
+	)
+	tests := []struct {
+		name    string
+		db      *DB
+		ctx     context.Context
+		wantErr func(error) bool
+	}{
+		{
+			name: "not sharded",
+			db:   new(DB),
+			ctx:  withTestInstance(context.Background(), "locked"),
+		},
+		{
+			name: "no instance",
+			db:   db,
+			ctx:  context.Background(),
+		},
+		{
+			name: "writable",
+			db:   db,
+			ctx:  withTestInstance(context.Background(), "placed"),
+		},
+		{
+			name: "shard of placement",
+			db:   db,
+			ctx:  WithShard(withTestInstance(context.Background(), "placed"), "shard-a"),
+		},
+		{
+			name:    "locked",
+			db:      db,
+			ctx:     withTestInstance(context.Background(), "locked"),
+			wantErr: zerrors.IsUnavailable,
+		},
+		{
+			name:    "other shard",
+			db:      db,
+			ctx:     WithShard(withTestInstance(context.Background(), "placed"), "shard-b"),
+			wantErr: zerrors.IsPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.db.CheckWritable(tt.ctx)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDB_SetPlacement(t *testing.T) {
+	t.Run("not sharded", func(t *testing.T) {
+		db := new(DB)
+		assert.NoError(t, db.SetPlacement(context.Background(), &Placement{InstanceID: "instance", Shard: DefaultShard}))
+		assert.True(t, zerrors.IsPreconditionFailed(db.SetPlacement(context.Background(), &Placement{InstanceID: "instance", Shard: "shard-a"})))
+	})
+	t.Run("unknown shard", func(t *testing.T) {
+		db := testShardedDB(new(sql.DB))
+		assert.True(t, zerrors.IsNotFound(db.SetPlacement(context.Background(), &Placement{InstanceID: "instance", Shard: "removed"})))
+	})
+	t.Run("placed", func(t *testing.T) {
+		expiry := time.Now().Add(time.Minute)
+		client := mock.NewSQLMock(t,
+			mock.ExcpectExec(setPlacementStmt, mock.WithExecArgs("instance", "shard-a", true, "owner", DefaultShard, expiry), mock.WithExecRowsAffected(1)),
+		)
+		db := testShardedDB(client.DB)
+		placement := &Placement{InstanceID: "instance", Shard: "shard-a", Locked: true, LockOwner: "owner", LockTarget: DefaultShard, LockExpiry: expiry}
+		require.NoError(t, db.SetPlacement(context.Background(), placement))
+		client.Assert(t)
+
+		err := db.CheckWritable(withTestInstance(context.Background(), "instance"))
+		assert.True(t, zerrors.IsUnavailable(err))
+	})
+}
+
+func TestPlacement_StaleLock(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		placement *Placement
+		want      bool
+	}{
+		{
+			name:      "unlocked",
+			placement: &Placement{Shard: DefaultShard},
+		},
+		{
+			name:      "renewed",
+			placement: &Placement{Shard: DefaultShard, Locked: true, LockOwner: "owner", LockExpiry: now.Add(time.Minute)},
+		},
+		{
+			name:      "expired",
+			placement: &Placement{Shard: DefaultShard, Locked: true, LockOwner: "owner", LockExpiry: now.Add(-time.Minute)},
+			want:      true,
+		},
+		{
+			name:      "without expiry",
+			placement: &Placement{Shard: DefaultShard, Locked: true},
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.placement.StaleLock(now))
+		})
+	}
+}
+
+func TestDB_RenewPlacementLock(t *testing.T) {
+	expiry := time.Now().Add(time.Minute)
+	t.Run("not sharded", func(t *testing.T) {
+		err := new(DB).RenewPlacementLock(context.Background(), "instance", "stale", "owner", expiry)
+		assert.True(t, zerrors.IsPreconditionFailed(err))
+	})
+	t.Run("renewed", func(t *testing.T) {
+		client := mock.NewSQLMock(t,
+			mock.ExcpectExec(renewPlacementLockStmt, mock.WithExecArgs("locked", "stale", "owner", expiry), mock.WithExecRowsAffected(1)),
+		)
+		db := testShardedDB(client.DB, &Placement{InstanceID: "locked", Shard: "shard-a", Locked: true, LockOwner: "stale"})
+		require.NoError(t, db.RenewPlacementLock(context.Background(), "locked", "stale", "owner", expiry))
+		client.Assert(t)
+		// the placement is reloaded
+		assert.NotContains(t, db.shards.placements.cache, "locked")
+	})
+	t.Run("lock not held", func(t *testing.T) {
+		client := mock.NewSQLMock(t,
+			mock.ExcpectExec(renewPlacementLockStmt, mock.WithExecArgs("locked", "stale", "owner", expiry), mock.WithExecRowsAffected(0)),
+		)
+		db := testShardedDB(client.DB)
+		err := db.RenewPlacementLock(context.Background(), "locked", "stale", "owner", expiry)
+		assert.True(t, zerrors.IsPreconditionFailed(err))
+		client.Assert(t)
+	})
+}
+
+func TestDB_ReleasePlacementLock(t *testing.T) {
+	t.Run("unknown shard", func(t *testing.T) {
+		db := testShardedDB(new(sql.DB))
+		assert.True(t, zerrors.IsNotFound(db.ReleasePlacementLock(context.Background(), "locked", "owner", "removed")))
+	})
+	t.Run("released", func(t *testing.T) {
+		client := mock.NewSQLMock(t,
+			mock.ExcpectExec(releasePlacementLockStmt, mock.WithExecArgs("locked", "owner", "shard-a"), mock.WithExecRowsAffected(1)),
+		)
+		db := testShardedDB(client.DB)
+		require.NoError(t, db.ReleasePlacementLock(context.Background(), "locked", "owner", "shard-a"))
+		client.Assert(t)
+	})
+	t.Run("lock taken over", func(t *testing.T) {
+		client := mock.NewSQLMock(t,
+			mock.ExcpectExec(releasePlacementLockStmt, mock.WithExecArgs("locked", "owner", "shard-a"), mock.WithExecRowsAffected(0)),
+		)
+		db := testShardedDB(client.DB)
+		err := db.ReleasePlacementLock(context.Background(), "locked", "owner", "shard-a")
+		assert.True(t, zerrors.IsPreconditionFailed(err))
+		client.Assert(t)
+	})
+}
@@ -46,6 +46,11 @@ type OIDCApp struct {
 	ClockSkew                time.Duration
 	AdditionalOrigins        []string
 	SkipNativeAppSuccessPage bool
+	ConsentRequired          bool
+	TLSClientAuthSubjectDN   string
+	// RefreshTokenReuseGracePeriod overrides the default grace period
+	// in which the preceding refresh token can be presented again, if set.
+	RefreshTokenReuseGracePeriod time.Duration
 
 	State AppState
 }
@@ -108,8 +113,21 @@ const (
 	OIDCAuthMethodTypePost
 	OIDCAuthMethodTypeNone
 	OIDCAuthMethodTypePrivateKeyJWT
+	OIDCAuthMethodTypeTLSClientAuth
+	OIDCAuthMethodTypeSelfSignedTLSClientAuth
 )
 
+// KeysAllowed returns true if the method authenticates the client with registered application keys.
+// The certificate of a self signed TLS client authentication must match one of the keys.
+func (t OIDCAuthMethodType) KeysAllowed() bool {
+	return t == OIDCAuthMethodTypePrivateKeyJWT || t == OIDCAuthMethodTypeSelfSignedTLSClientAuth
+}
+
+// IsTLSClientAuth returns true for the mutual TLS client authentication methods of RFC 8705.
+func (t OIDCAuthMethodType) IsTLSClientAuth() bool {
+	return t == OIDCAuthMethodTypeTLSClientAuth || t == OIDCAuthMethodTypeSelfSignedTLSClientAuth
+}
+
 type Compliance struct {
 	NoneCompliant bool
 	Problems      []string
@@ -126,6 +144,12 @@ func (a *OIDCApp) IsValid() bool {
 	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() {
 		return false
 	}
+	if a.AuthMethodType == OIDCAuthMethodTypeTLSClientAuth && strings.TrimSpace(a.TLSClientAuthSubjectDN) == "" {
+		return false
+	}
+	if !RefreshTokenReuseGracePeriodValid(a.RefreshTokenReuseGracePeriod) {
+		return false
+	}
 	grantTypes := a.getRequiredGrantTypes()
 	if len(grantTypes) == 0 {
 		return false
@@ -148,6 +172,15 @@ func (a *OIDCApp) OriginsValid() bool {
 	return true
 }
 
+// MaxRefreshTokenReuseGracePeriod limits the grace period of an application,
+// so rotated refresh tokens can't be used for long.
+const MaxRefreshTokenReuseGracePeriod = time.Minute
+
+// RefreshTokenReuseGracePeriodValid checks the grace period of an application, 0 uses the default.
+func RefreshTokenReuseGracePeriodValid(gracePeriod time.Duration) bool {
+	return gracePeriod >= 0 && gracePeriod <= MaxRefreshTokenReuseGracePeriod
+}
+
 func ContainsRequiredGrantTypes(responseTypes []OIDCResponseType, grantTypes []OIDCGrantType) bool {
 	required := RequiredOIDCGrantTypes(responseTypes)
 	return ContainsOIDCGrantTypes(required, grantTypes)
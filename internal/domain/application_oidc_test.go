@@ -46,6 +46,34 @@ func TestApplicationValid(t *testing.T) {
 			},
 			result: false,
 		},
+		{
+			name: "invalid refresh token reuse grace period",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:                   models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                        "AppID",
+					AppName:                      "AppName",
+					RefreshTokenReuseGracePeriod: time.Minute + time.Second,
+					ResponseTypes:                []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:                   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				},
+			},
+			result: false,
+		},
+		{
+			name: "valid refresh token reuse grace period",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:                   models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                        "AppID",
+					AppName:                      "AppName",
+					RefreshTokenReuseGracePeriod: time.Minute,
+					ResponseTypes:                []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:                   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				},
+			},
+			result: true,
+		},
 		{
 			name: "valid oidc application: responsetype code",
 			args: args{
@@ -176,6 +204,34 @@ func TestApplicationValid(t *testing.T) {
 				},
 			},
 			result: false,
+		},		{
+			name: "invalid oidc application: tls client auth without subject dn",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:          "AppID",
+					AppName:        "Name",
+					ResponseTypes:  []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:     []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					AuthMethodType: OIDCAuthMethodTypeTLSClientAuth,
+				},
+			},
+			result: false,
+		},
+		{
+			name: "valid oidc application: tls client auth with subject dn",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:             models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                  "AppID",
+					AppName:                "Name",
+					ResponseTypes:          []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:             []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					AuthMethodType:         OIDCAuthMethodTypeTLSClientAuth,
+					TLSClientAuthSubjectDN: "CN=client,O=ZITADEL",
+				},
+			},
+			result: true,
 		},
 	}
 	for _, tt := range tests {
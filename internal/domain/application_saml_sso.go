@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"net/url"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SAMLRelayStateMaxLength is the maximum length of the RelayState as defined in the SAML 2.0 bindings (3.4.3).
+const SAMLRelayStateMaxLength = 80
+
+// SAMLSSOSettings configure the sign-on flows of a SAML application
+// in addition to the SP-initiated SAML 2.0 flow.
+type SAMLSSOSettings struct {
+	// IDPInitiatedEnabled allows launching the application from ZITADEL (IdP-initiated SSO).
+	IDPInitiatedEnabled bool
+	// RelayState is sent to the service provider on IdP-initiated logins.
+	RelayState string
+	// WSFedEnabled allows the application to sign in using the WS-Federation passive requestor profile.
+	// The entity ID of the application is used as the realm (wtrealm).
+	WSFedEnabled bool
+	// WSFedReplyURLs are the allowed reply URLs (wreply). The first one is used if none is requested.
+	WSFedReplyURLs []string
+}
+
+func (s *SAMLSSOSettings) Validate() error {
+	if len(s.RelayState) > SAMLRelayStateMaxLength {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-ahX4c", "Errors.Project.App.SAMLSSO.RelayStateInvalid")
+	}
+	if s.WSFedEnabled && len(s.WSFedReplyURLs) == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohz8e", "Errors.Project.App.SAMLSSO.ReplyURLMissing")
+	}
+	for _, replyURL := range s.WSFedReplyURLs {
+		if !isValidReplyURL(replyURL) {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ree1x", "Errors.Project.App.SAMLSSO.ReplyURLInvalid")
+		}
+	}
+	return nil
+}
+
+// WSFedReplyURL returns the requested reply URL if it is allowed.
+// If none is requested, the first configured reply URL is returned.
+func (s *SAMLSSOSettings) WSFedReplyURL(requested string) (string, bool) {
+	if requested == "" {
+		if len(s.WSFedReplyURLs) == 0 {
+			return "", false
+		}
+		return s.WSFedReplyURLs[0], true
+	}
+	return requested, slices.Contains(s.WSFedReplyURLs, requested)
+}
+
+func isValidReplyURL(replyURL string) bool {
+	u, err := url.Parse(replyURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "https" || u.Scheme == "http") && u.Host != "" && u.Fragment == ""
+}
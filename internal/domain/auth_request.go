@@ -56,6 +56,10 @@ type AuthRequest struct {
 	DefaultTranslations      []*CustomText
 	OrgTranslations          []*CustomText
 	SAMLRequestID            string
+	ConsentGiven             bool
+	// PasskeyChallenge is the challenge for a login with a discoverable credential,
+	// which is not bound to a user and therefore kept on the request
+	PasskeyChallenge *WebAuthNLogin
 }
 
 type ExternalUser struct {
@@ -72,6 +76,8 @@ type ExternalUser struct {
 	Phone             PhoneNumber
 	IsPhoneVerified   bool
 	Metadatas         []*Metadata
+	// Groups are the values of the claim configured in the role mapping of the IDP
+	Groups []string
 }
 
 type Prompt int32
@@ -155,6 +161,8 @@ func NewAuthRequestFromType(requestType AuthRequestType) (*AuthRequest, error) {
 		return &AuthRequest{Request: &AuthRequestSAML{}}, nil
 	case AuthRequestTypeDevice:
 		return &AuthRequest{Request: &AuthRequestDevice{}}, nil
+	case AuthRequestTypeWSFed:
+		return &AuthRequest{Request: &AuthRequestWSFed{}}, nil
 	}
 	return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-ds2kl", "invalid request type")
 }
@@ -218,6 +226,14 @@ func (a *AuthRequest) GetScopeOrgID() string {
 	return ""
 }
 
+// GetAuthorizationDetails returns the authorization details requested by an OIDC client.
+func (a *AuthRequest) GetAuthorizationDetails() AuthorizationDetails {
+	if request, ok := a.Request.(*AuthRequestOIDC); ok {
+		return request.AuthorizationDetails
+	}
+	return nil
+}
+
 func (a *AuthRequest) Done() bool {
 	for _, step := range a.PossibleSteps {
 		if step.Type() == NextStepRedirectToCallback {
@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	AuthorizationDetailsParam = "authorization_details"
+	AuthorizationDetailsClaim = "authorization_details"
+)
+
+// AuthorizationDetail is a single entry of the `authorization_details` parameter
+// of a Rich Authorization Request as defined in RFC 9396.
+// The common data fields are parsed into the typed fields,
+// any other (API specific) field is kept in Fields.
+type AuthorizationDetail struct {
+	Type       string
+	Locations  []string
+	Actions    []string
+	Datatypes  []string
+	Identifier string
+	Privileges []string
+	Fields     map[string]any
+}
+
+var authorizationDetailCommonFields = []string{"type", "locations", "actions", "datatypes", "identifier", "privileges"}
+
+type authorizationDetailCommon struct {
+	Type       string   `json:"type"`
+	Locations  []string `json:"locations,omitempty"`
+	Actions    []string `json:"actions,omitempty"`
+	Datatypes  []string `json:"datatypes,omitempty"`
+	Identifier string   `json:"identifier,omitempty"`
+	Privileges []string `json:"privileges,omitempty"`
+}
+
+func (d *AuthorizationDetail) MarshalJSON() ([]byte, error) {
+	common, err := json.Marshal(authorizationDetailCommon{
+		Type:       d.Type,
+		Locations:  d.Locations,
+		Actions:    d.Actions,
+		Datatypes:  d.Datatypes,
+		Identifier: d.Identifier,
+		Privileges: d.Privileges,
+	})
+	if err != nil || len(d.Fields) == 0 {
+		return common, err
+	}
+	merged := make(map[string]any, len(d.Fields)+len(authorizationDetailCommonFields))
+	for key, value := range d.Fields {
+		merged[key] = value
+	}
+	if err = json.Unmarshal(common, &merged); err != nil {
+		return nil, err
+	}
+	return json.Marshal(merged)
+}
+
+func (d *AuthorizationDetail) UnmarshalJSON(data []byte) error {
+	common := new(authorizationDetailCommon)
+	if err := json.Unmarshal(data, common); err != nil {
+		return err
+	}
+	fields := make(map[string]any)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, field := range authorizationDetailCommonFields {
+		delete(fields, field)
+	}
+	*d = AuthorizationDetail{
+		Type:       common.Type,
+		Locations:  common.Locations,
+		Actions:    common.Actions,
+		Datatypes:  common.Datatypes,
+		Identifier: common.Identifier,
+		Privileges: common.Privileges,
+	}
+	if len(fields) > 0 {
+		d.Fields = fields
+	}
+	return nil
+}
+
+// Equal compares the complete detail, including the type specific fields.
+func (d *AuthorizationDetail) Equal(other *AuthorizationDetail) bool {
+	if d == nil || other == nil {
+		return d == other
+	}
+	a, errA := json.Marshal(d)
+	b, errB := json.Marshal(other)
+	if errA != nil || errB != nil {
+		return false
+	}
+	var mapA, mapB map[string]any
+	if json.Unmarshal(a, &mapA) != nil || json.Unmarshal(b, &mapB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(mapA, mapB)
+}
+
+type AuthorizationDetails []*AuthorizationDetail
+
+// ParseAuthorizationDetails parses the JSON encoded `authorization_details` request parameter.
+// An empty parameter results in no details.
+func ParseAuthorizationDetails(param string) (AuthorizationDetails, error) {
+	if param == "" {
+		return nil, nil
+	}
+	var details AuthorizationDetails
+	if err := json.Unmarshal([]byte(param), &details); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-Ahx3o", "Errors.AuthorizationDetails.Invalid")
+	}
+	for _, detail := range details {
+		if detail == nil || detail.Type == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-eeL2u", "Errors.AuthorizationDetails.TypeMissing")
+		}
+	}
+	return details, nil
+}
+
+// Types returns the distinct types of all details.
+func (d AuthorizationDetails) Types() []string {
+	types := make([]string, 0, len(d))
+	for _, detail := range d {
+		if !slices.Contains(types, detail.Type) {
+			types = append(types, detail.Type)
+		}
+	}
+	return types
+}
+
+// Contains checks if every detail of the subset is part of the details.
+// It is used to check that a token request only narrows down the granted details.
+func (d AuthorizationDetails) Contains(subset AuthorizationDetails) bool {
+	for _, requested := range subset {
+		if !slices.ContainsFunc(d, requested.Equal) {
+			return false
+		}
+	}
+	return true
+}
+
+// AuthorizationDetailType is a type of authorization details registered on a project.
+// Only registered types are accepted in authorization and token requests of the project's applications.
+// If Actions or Locations are set, the requested details must only contain values from these lists.
+type AuthorizationDetailType struct {
+	models.ObjectRoot
+
+	Type        string
+	DisplayName string
+	Actions     []string
+	Locations   []string
+}
+
+type AuthorizationDetailTypeState int32
+
+const (
+	AuthorizationDetailTypeStateUnspecified AuthorizationDetailTypeState = iota
+	AuthorizationDetailTypeStateActive
+	AuthorizationDetailTypeStateRemoved
+)
+
+func (s AuthorizationDetailTypeState) Exists() bool {
+	return s == AuthorizationDetailTypeStateActive
+}
+
+func (t *AuthorizationDetailType) IsValid() bool {
+	return t.AggregateID != "" && t.Type != ""
+}
+
+// Validate checks the detail against the constraints of the registered type.
+func (t *AuthorizationDetailType) Validate(detail *AuthorizationDetail) error {
+	if detail.Type != t.Type {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Tai8a", "Errors.AuthorizationDetails.TypeNotRegistered")
+	}
+	if len(t.Actions) > 0 && !isSubset(detail.Actions, t.Actions) {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-ooX4e", "Errors.AuthorizationDetails.ActionNotAllowed")
+	}
+	if len(t.Locations) > 0 && !isSubset(detail.Locations, t.Locations) {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-iuN1a", "Errors.AuthorizationDetails.LocationNotAllowed")
+	}
+	return nil
+}
+
+// ValidateAuthorizationDetails checks that every requested detail is of a registered type
+// and satisfies its constraints.
+func ValidateAuthorizationDetails(details AuthorizationDetails, types []*AuthorizationDetailType) error {
+	for _, detail := range details {
+		i := slices.IndexFunc(types, func(t *AuthorizationDetailType) bool {
+			return t.Type == detail.Type
+		})
+		if i < 0 {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Oongi", "Errors.AuthorizationDetails.TypeNotRegistered")
+		}
+		if err := types[i].Validate(detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isSubset(values, allowed []string) bool {
+	for _, value := range values {
+		if !slices.Contains(allowed, value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestParseAuthorizationDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		want    AuthorizationDetails
+		wantErr func(error) bool
+	}{
+		{
+			name:  "empty",
+			param: "",
+			want:  nil,
+		},
+		{
+			name:    "invalid json",
+			param:   `{"type":`,
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "missing type",
+			param:   `[{"actions":["initiate"]}]`,
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:  "common and type specific fields",
+			param: `[{"type":"payment_initiation","actions":["initiate"],"locations":["https://bank.example.com"],"instructedAmount":{"currency":"EUR","amount":"100.00"}}]`,
+			want: AuthorizationDetails{
+				{
+					Type:      "payment_initiation",
+					Actions:   []string{"initiate"},
+					Locations: []string{"https://bank.example.com"},
+					Fields: map[string]any{
+						"instructedAmount": map[string]any{
+							"currency": "EUR",
+							"amount":   "100.00",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAuthorizationDetails(tt.param)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAuthorizationDetail_MarshalJSON(t *testing.T) {
+	detail := &AuthorizationDetail{
+		Type:    "payment_initiation",
+		Actions: []string{"initiate"},
+		Fields: map[string]any{
+			"creditorAccount": map[string]any{"iban": "DE02100100109307118603"},
+		},
+	}
+	data, err := json.Marshal(detail)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"payment_initiation","actions":["initiate"],"creditorAccount":{"iban":"DE02100100109307118603"}}`, string(data))
+
+	got := new(AuthorizationDetail)
+	require.NoError(t, json.Unmarshal(data, got))
+	assert.True(t, detail.Equal(got))
+}
+
+func TestAuthorizationDetails_Contains(t *testing.T) {
+	granted := AuthorizationDetails{
+		{Type: "account_information", Actions: []string{"list_accounts"}},
+		{Type: "payment_initiation", Actions: []string{"initiate"}, Fields: map[string]any{"amount": "100"}},
+	}
+	tests := []struct {
+		name   string
+		subset AuthorizationDetails
+		want   bool
+	}{
+		{
+			name:   "empty subset",
+			subset: nil,
+			want:   true,
+		},
+		{
+			name:   "single granted detail",
+			subset: AuthorizationDetails{{Type: "payment_initiation", Actions: []string{"initiate"}, Fields: map[string]any{"amount": "100"}}},
+			want:   true,
+		},
+		{
+			name:   "changed type specific field",
+			subset: AuthorizationDetails{{Type: "payment_initiation", Actions: []string{"initiate"}, Fields: map[string]any{"amount": "1000"}}},
+			want:   false,
+		},
+		{
+			name:   "not granted type",
+			subset: AuthorizationDetails{{Type: "other"}},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, granted.Contains(tt.subset))
+		})
+	}
+}
+
+func TestValidateAuthorizationDetails(t *testing.T) {
+	types := []*AuthorizationDetailType{
+		{
+			ObjectRoot: models.ObjectRoot{AggregateID: "project1"},
+			Type:       "payment_initiation",
+			Actions:    []string{"initiate", "status"},
+			Locations:  []string{"https://bank.example.com"},
+		},
+		{
+			ObjectRoot: models.ObjectRoot{AggregateID: "project1"},
+			Type:       "account_information",
+		},
+	}
+	tests := []struct {
+		name    string
+		details AuthorizationDetails
+		wantErr func(error) bool
+	}{
+		{
+			name:    "not registered type",
+			details: AuthorizationDetails{{Type: "unknown"}},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "action not allowed",
+			details: AuthorizationDetails{{Type: "payment_initiation", Actions: []string{"cancel"}}},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "location not allowed",
+			details: AuthorizationDetails{{Type: "payment_initiation", Locations: []string{"https://evil.example.com"}}},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "valid",
+			details: AuthorizationDetails{
+				{Type: "payment_initiation", Actions: []string{"initiate"}, Locations: []string{"https://bank.example.com"}},
+				{Type: "account_information", Actions: []string{"anything"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAuthorizationDetails(tt.details, types)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
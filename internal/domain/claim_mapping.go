@@ -0,0 +1,312 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type ClaimMappingSource int32
+
+const (
+	ClaimMappingSourceUnspecified ClaimMappingSource = iota
+	ClaimMappingSourceUserField
+	ClaimMappingSourceUserMetadata
+	ClaimMappingSourceOrgField
+	ClaimMappingSourceRoles
+	ClaimMappingSourceStatic
+)
+
+type ClaimMappingTransformation int32
+
+const (
+	ClaimMappingTransformationNone ClaimMappingTransformation = iota
+	ClaimMappingTransformationLowercase
+	ClaimMappingTransformationJoin
+	ClaimMappingTransformationRegex
+)
+
+type ClaimMappingTarget int32
+
+const (
+	ClaimMappingTargetUnspecified ClaimMappingTarget = iota
+	ClaimMappingTargetIDToken
+	ClaimMappingTargetAccessToken
+	ClaimMappingTargetUserinfo
+	ClaimMappingTargetIntrospection
+	ClaimMappingTargetSAMLResponse
+)
+
+const (
+	ClaimMappingUserFieldID                 = "id"
+	ClaimMappingUserFieldUsername           = "username"
+	ClaimMappingUserFieldPreferredLoginName = "preferred_login_name"
+	ClaimMappingUserFieldFirstName          = "first_name"
+	ClaimMappingUserFieldLastName           = "last_name"
+	ClaimMappingUserFieldNickName           = "nick_name"
+	ClaimMappingUserFieldDisplayName        = "display_name"
+	ClaimMappingUserFieldEmail              = "email"
+	ClaimMappingUserFieldPhone              = "phone"
+	ClaimMappingUserFieldPreferredLanguage  = "preferred_language"
+
+	ClaimMappingOrgFieldID            = "id"
+	ClaimMappingOrgFieldName          = "name"
+	ClaimMappingOrgFieldPrimaryDomain = "primary_domain"
+)
+
+var (
+	claimMappingUserFields = []string{
+		ClaimMappingUserFieldID,
+		ClaimMappingUserFieldUsername,
+		ClaimMappingUserFieldPreferredLoginName,
+		ClaimMappingUserFieldFirstName,
+		ClaimMappingUserFieldLastName,
+		ClaimMappingUserFieldNickName,
+		ClaimMappingUserFieldDisplayName,
+		ClaimMappingUserFieldEmail,
+		ClaimMappingUserFieldPhone,
+		ClaimMappingUserFieldPreferredLanguage,
+	}
+	claimMappingOrgFields = []string{
+		ClaimMappingOrgFieldID,
+		ClaimMappingOrgFieldName,
+		ClaimMappingOrgFieldPrimaryDomain,
+	}
+	// reservedClaims are set by the protocol and must not be overwritten by a mapping
+	reservedClaims = []string{
+		"iss", "sub", "aud", "exp", "nbf", "iat", "jti", "azp", "nonce", "auth_time", "amr", "acr",
+		"at_hash", "c_hash", "sid", "client_id", "scope", "active", "token_type", "cnf", "act", "may_act",
+	}
+	reservedClaimPrefix = "urn:zitadel:iam"
+)
+
+// ClaimMapping declares a claim which is added to the tokens of an application.
+// The value is taken from the Source, identified by the Key (user field, metadata key or org field)
+// or the static Value, and can be transformed before it is set on the Targets.
+type ClaimMapping struct {
+	Claim          string                     `json:"claim,omitempty"`
+	Source         ClaimMappingSource         `json:"source,omitempty"`
+	Key            string                     `json:"key,omitempty"`
+	Value          string                     `json:"value,omitempty"`
+	Transformation ClaimMappingTransformation `json:"transformation,omitempty"`
+	// Separator is used by [ClaimMappingTransformationJoin]
+	Separator string `json:"separator,omitempty"`
+	// Pattern and Replacement are used by [ClaimMappingTransformationRegex]
+	Pattern     string               `json:"pattern,omitempty"`
+	Replacement string               `json:"replacement,omitempty"`
+	Targets     []ClaimMappingTarget `json:"targets,omitempty"`
+
+	// pattern is the compiled Pattern, it's compiled once the mapping is loaded or validated
+	pattern *regexp.Regexp
+}
+
+// UnmarshalJSON compiles the Pattern of a loaded mapping,
+// so it's not compiled on every evaluation.
+func (m *ClaimMapping) UnmarshalJSON(data []byte) error {
+	type mapping ClaimMapping
+	if err := json.Unmarshal(data, (*mapping)(m)); err != nil {
+		return err
+	}
+	m.pattern = nil
+	if m.Transformation == ClaimMappingTransformationRegex {
+		// an invalid pattern is returned by the evaluation
+		m.pattern, _ = regexp.Compile(m.Pattern)
+	}
+	return nil
+}
+
+// ClaimMappingValues provides the values of a user a [ClaimMapping] is evaluated against.
+// Empty values are not mapped.
+type ClaimMappingValues interface {
+	UserField(ctx context.Context, field string) (string, error)
+	UserMetadata(ctx context.Context, key string) ([]byte, error)
+	OrgField(ctx context.Context, field string) (string, error)
+	Roles(ctx context.Context) ([]string, error)
+}
+
+func (m *ClaimMapping) Validate() error {
+	if m.Claim == "" || slices.Contains(reservedClaims, m.Claim) || strings.HasPrefix(m.Claim, reservedClaimPrefix) {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ahf3u", "Errors.Project.App.ClaimMapping.InvalidClaim")
+	}
+	if len(m.Targets) == 0 || slices.Contains(m.Targets, ClaimMappingTargetUnspecified) {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Oov5e", "Errors.Project.App.ClaimMapping.TargetMissing")
+	}
+	if err := m.validateSource(); err != nil {
+		return err
+	}
+	return m.validateTransformation()
+}
+
+func (m *ClaimMapping) validateSource() error {
+	switch m.Source {
+	case ClaimMappingSourceUserField:
+		if slices.Contains(claimMappingUserFields, m.Key) {
+			return nil
+		}
+	case ClaimMappingSourceOrgField:
+		if slices.Contains(claimMappingOrgFields, m.Key) {
+			return nil
+		}
+	case ClaimMappingSourceUserMetadata:
+		if m.Key != "" {
+			return nil
+		}
+	case ClaimMappingSourceStatic:
+		if m.Value != "" {
+			return nil
+		}
+	case ClaimMappingSourceRoles:
+		return nil
+	case ClaimMappingSourceUnspecified:
+	}
+	return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Quu3a", "Errors.Project.App.ClaimMapping.InvalidSource")
+}
+
+func (m *ClaimMapping) validateTransformation() error {
+	switch m.Transformation {
+	case ClaimMappingTransformationNone,
+		ClaimMappingTransformationLowercase,
+		ClaimMappingTransformationJoin:
+		return nil
+	case ClaimMappingTransformationRegex:
+		if m.Pattern == "" {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-eeT4a", "Errors.Project.App.ClaimMapping.InvalidPattern")
+		}
+		pattern, err := m.regexp()
+		if err != nil {
+			return err
+		}
+		m.pattern = pattern
+		return nil
+	}
+	return zerrors.ThrowInvalidArgument(nil, "DOMAIN-aiX7o", "Errors.Project.App.ClaimMapping.InvalidTransformation")
+}
+
+// regexp returns the compiled Pattern of the mapping.
+// The Pattern is only compiled if it wasn't compiled when the mapping was loaded or validated.
+func (m *ClaimMapping) regexp() (*regexp.Regexp, error) {
+	if m.pattern != nil {
+		return m.pattern, nil
+	}
+	pattern, err := regexp.Compile(m.Pattern)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-eeT4a", "Errors.Project.App.ClaimMapping.InvalidPattern")
+	}
+	return pattern, nil
+}
+
+func ValidateClaimMappings(mappings []*ClaimMapping) error {
+	claims := make(map[ClaimMappingTarget][]string)
+	for _, mapping := range mappings {
+		if err := mapping.Validate(); err != nil {
+			return err
+		}
+		for _, target := range mapping.Targets {
+			if slices.Contains(claims[target], mapping.Claim) {
+				return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohbi4", "Errors.Project.App.ClaimMapping.DuplicateClaim")
+			}
+			claims[target] = append(claims[target], mapping.Claim)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the (transformed) value of the mapping,
+// which is either a string or a list of strings (roles).
+// If the source does not provide a value, ok is false.
+func (m *ClaimMapping) Evaluate(ctx context.Context, values ClaimMappingValues) (value any, ok bool, err error) {
+	switch m.Source {
+	case ClaimMappingSourceUserField:
+		field, err := values.UserField(ctx, m.Key)
+		if err != nil || field == "" {
+			return nil, false, err
+		}
+		return evaluated(m.transformString(field))
+	case ClaimMappingSourceUserMetadata:
+		metadata, err := values.UserMetadata(ctx, m.Key)
+		if err != nil || len(metadata) == 0 {
+			return nil, false, err
+		}
+		return evaluated(m.transformString(string(metadata)))
+	case ClaimMappingSourceOrgField:
+		field, err := values.OrgField(ctx, m.Key)
+		if err != nil || field == "" {
+			return nil, false, err
+		}
+		return evaluated(m.transformString(field))
+	case ClaimMappingSourceRoles:
+		roles, err := values.Roles(ctx)
+		if err != nil || len(roles) == 0 {
+			return nil, false, err
+		}
+		return evaluated(m.transformList(roles))
+	case ClaimMappingSourceStatic:
+		return evaluated(m.transformString(m.Value))
+	case ClaimMappingSourceUnspecified:
+	}
+	return nil, false, nil
+}
+
+// evaluated returns the transformed value of [ClaimMapping.Evaluate] or the error of the transformation.
+func evaluated(value any, err error) (any, bool, error) {
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (m *ClaimMapping) transformString(value string) (string, error) {
+	switch m.Transformation {
+	case ClaimMappingTransformationLowercase:
+		return strings.ToLower(value), nil
+	case ClaimMappingTransformationRegex:
+		pattern, err := m.regexp()
+		if err != nil {
+			return "", err
+		}
+		return pattern.ReplaceAllString(value, m.Replacement), nil
+	case ClaimMappingTransformationNone,
+		ClaimMappingTransformationJoin:
+	}
+	return value, nil
+}
+
+func (m *ClaimMapping) transformList(values []string) (any, error) {
+	if m.Transformation == ClaimMappingTransformationJoin {
+		return strings.Join(values, m.Separator), nil
+	}
+	transformed := make([]string, len(values))
+	for i, value := range values {
+		var err error
+		if transformed[i], err = m.transformString(value); err != nil {
+			return nil, err
+		}
+	}
+	return transformed, nil
+}
+
+// EvaluateClaimMappings returns the claims of all mappings for the specified target.
+func EvaluateClaimMappings(ctx context.Context, mappings []*ClaimMapping, target ClaimMappingTarget, values ClaimMappingValues) (map[string]any, error) {
+	var claims map[string]any
+	for _, mapping := range mappings {
+		if !slices.Contains(mapping.Targets, target) {
+			continue
+		}
+		value, ok, err := mapping.Evaluate(ctx, values)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if claims == nil {
+			claims = make(map[string]any)
+		}
+		claims[mapping.Claim] = value
+	}
+	return claims, nil
+}
@@ -0,0 +1,277 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type testClaimMappingValues struct {
+	userFields map[string]string
+	metadata   map[string][]byte
+	orgFields  map[string]string
+	roles      []string
+	err        error
+}
+
+func (v *testClaimMappingValues) UserField(_ context.Context, field string) (string, error) {
+	return v.userFields[field], v.err
+}
+
+func (v *testClaimMappingValues) UserMetadata(_ context.Context, key string) ([]byte, error) {
+	return v.metadata[key], v.err
+}
+
+func (v *testClaimMappingValues) OrgField(_ context.Context, field string) (string, error) {
+	return v.orgFields[field], v.err
+}
+
+func (v *testClaimMappingValues) Roles(context.Context) ([]string, error) {
+	return v.roles, v.err
+}
+
+func TestClaimMapping_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *ClaimMapping
+		wantErr error
+	}{
+		{
+			name: "claim missing",
+			mapping: &ClaimMapping{
+				Source:  ClaimMappingSourceRoles,
+				Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken},
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ahf3u", "Errors.Project.App.ClaimMapping.InvalidClaim"),
+		},
+		{
+			name: "reserved claim",
+			mapping: &ClaimMapping{
+				Claim:   "sub",
+				Source:  ClaimMappingSourceRoles,
+				Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken},
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ahf3u", "Errors.Project.App.ClaimMapping.InvalidClaim"),
+		},
+		{
+			name: "zitadel claim",
+			mapping: &ClaimMapping{
+				Claim:   "urn:zitadel:iam:org:project:roles",
+				Source:  ClaimMappingSourceRoles,
+				Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken},
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ahf3u", "Errors.Project.App.ClaimMapping.InvalidClaim"),
+		},
+		{
+			name: "target missing",
+			mapping: &ClaimMapping{
+				Claim:  "groups",
+				Source: ClaimMappingSourceRoles,
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Oov5e", "Errors.Project.App.ClaimMapping.TargetMissing"),
+		},
+		{
+			name: "unknown user field",
+			mapping: &ClaimMapping{
+				Claim:   "department",
+				Source:  ClaimMappingSourceUserField,
+				Key:     "department",
+				Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken},
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Quu3a", "Errors.Project.App.ClaimMapping.InvalidSource"),
+		},
+		{
+			name: "static value missing",
+			mapping: &ClaimMapping{
+				Claim:   "tenant",
+				Source:  ClaimMappingSourceStatic,
+				Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken},
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Quu3a", "Errors.Project.App.ClaimMapping.InvalidSource"),
+		},
+		{
+			name: "invalid pattern",
+			mapping: &ClaimMapping{
+				Claim:          "department",
+				Source:         ClaimMappingSourceUserMetadata,
+				Key:            "department",
+				Transformation: ClaimMappingTransformationRegex,
+				Pattern:        "(",
+				Targets:        []ClaimMappingTarget{ClaimMappingTargetIDToken},
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-eeT4a", "Errors.Project.App.ClaimMapping.InvalidPattern"),
+		},
+		{
+			name: "ok",
+			mapping: &ClaimMapping{
+				Claim:          "department",
+				Source:         ClaimMappingSourceUserMetadata,
+				Key:            "department",
+				Transformation: ClaimMappingTransformationRegex,
+				Pattern:        "^dep-(.*)$",
+				Replacement:    "$1",
+				Targets:        []ClaimMappingTarget{ClaimMappingTargetIDToken, ClaimMappingTargetSAMLResponse},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mapping.Validate()
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateClaimMappings(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []*ClaimMapping
+		wantErr  error
+	}{
+		{
+			name: "duplicate claim on same target",
+			mappings: []*ClaimMapping{
+				{Claim: "tenant", Source: ClaimMappingSourceStatic, Value: "a", Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+				{Claim: "tenant", Source: ClaimMappingSourceStatic, Value: "b", Targets: []ClaimMappingTarget{ClaimMappingTargetAccessToken, ClaimMappingTargetIDToken}},
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohbi4", "Errors.Project.App.ClaimMapping.DuplicateClaim"),
+		},
+		{
+			name: "same claim on different targets",
+			mappings: []*ClaimMapping{
+				{Claim: "tenant", Source: ClaimMappingSourceStatic, Value: "a", Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+				{Claim: "tenant", Source: ClaimMappingSourceStatic, Value: "b", Targets: []ClaimMappingTarget{ClaimMappingTargetAccessToken}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateClaimMappings(tt.mappings)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestEvaluateClaimMappings(t *testing.T) {
+	values := &testClaimMappingValues{
+		userFields: map[string]string{
+			ClaimMappingUserFieldEmail: "Gigi@Example.com",
+		},
+		metadata: map[string][]byte{
+			"department": []byte("dep-finance"),
+		},
+		orgFields: map[string]string{
+			ClaimMappingOrgFieldName: "ACME",
+		},
+		roles: []string{"Admin", "Viewer"},
+	}
+	tests := []struct {
+		name     string
+		mappings []*ClaimMapping
+		target   ClaimMappingTarget
+		values   ClaimMappingValues
+		want     map[string]any
+		wantErr  error
+	}{
+		{
+			name: "values error",
+			mappings: []*ClaimMapping{
+				{Claim: "mail", Source: ClaimMappingSourceUserField, Key: ClaimMappingUserFieldEmail, Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+			},
+			target:  ClaimMappingTargetIDToken,
+			values:  &testClaimMappingValues{err: zerrors.ThrowInternal(nil, "ID", "error")},
+			wantErr: zerrors.ThrowInternal(nil, "ID", "error"),
+		},
+		{
+			name: "invalid pattern error",
+			mappings: []*ClaimMapping{
+				{Claim: "department", Source: ClaimMappingSourceUserMetadata, Key: "department", Transformation: ClaimMappingTransformationRegex, Pattern: "(", Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+			},
+			target:  ClaimMappingTargetIDToken,
+			values:  values,
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-eeT4a", "Errors.Project.App.ClaimMapping.InvalidPattern"),
+		},
+		{
+			name: "other target",
+			mappings: []*ClaimMapping{
+				{Claim: "mail", Source: ClaimMappingSourceUserField, Key: ClaimMappingUserFieldEmail, Targets: []ClaimMappingTarget{ClaimMappingTargetAccessToken}},
+			},
+			target: ClaimMappingTargetIDToken,
+			values: values,
+			want:   nil,
+		},
+		{
+			name: "missing values are not mapped",
+			mappings: []*ClaimMapping{
+				{Claim: "nick", Source: ClaimMappingSourceUserField, Key: ClaimMappingUserFieldNickName, Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+				{Claim: "cost_center", Source: ClaimMappingSourceUserMetadata, Key: "cost_center", Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+			},
+			target: ClaimMappingTargetIDToken,
+			values: values,
+			want:   nil,
+		},
+		{
+			name: "all sources and transformations",
+			mappings: []*ClaimMapping{
+				{Claim: "mail", Source: ClaimMappingSourceUserField, Key: ClaimMappingUserFieldEmail, Transformation: ClaimMappingTransformationLowercase, Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+				{Claim: "department", Source: ClaimMappingSourceUserMetadata, Key: "department", Transformation: ClaimMappingTransformationRegex, Pattern: "^dep-(.*)$", Replacement: "$1", Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+				{Claim: "company", Source: ClaimMappingSourceOrgField, Key: ClaimMappingOrgFieldName, Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+				{Claim: "groups", Source: ClaimMappingSourceRoles, Transformation: ClaimMappingTransformationLowercase, Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+				{Claim: "group_list", Source: ClaimMappingSourceRoles, Transformation: ClaimMappingTransformationJoin, Separator: ",", Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+				{Claim: "tenant", Source: ClaimMappingSourceStatic, Value: "acme", Targets: []ClaimMappingTarget{ClaimMappingTargetIDToken}},
+			},
+			target: ClaimMappingTargetIDToken,
+			values: values,
+			want: map[string]any{
+				"mail":       "gigi@example.com",
+				"department": "finance",
+				"company":    "ACME",
+				"groups":     []string{"admin", "viewer"},
+				"group_list": "Admin,Viewer",
+				"tenant":     "acme",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateClaimMappings(context.Background(), tt.mappings, tt.target, tt.values)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClaimMapping_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantPattern bool
+	}{
+		{
+			name:        "regex compiled",
+			data:        `{"claim":"department","transformation":3,"pattern":"^dep-(.*)$","replacement":"$1"}`,
+			wantPattern: true,
+		},
+		{
+			name: "invalid regex",
+			data: `{"claim":"department","transformation":3,"pattern":"("}`,
+		},
+		{
+			name: "no regex",
+			data: `{"claim":"department","transformation":1,"pattern":"^dep-(.*)$"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := new(ClaimMapping)
+			require.NoError(t, json.Unmarshal([]byte(tt.data), mapping))
+			assert.Equal(t, "department", mapping.Claim)
+			assert.Equal(t, tt.wantPattern, mapping.pattern != nil)
+		})
+	}
+}
@@ -17,6 +17,9 @@ const (
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
 	PasswordChangeMessageType           = "PasswordChange"
+	IDPAutoLinkedMessageType            = "IDPAutoLinked"
+	RefreshTokenReusedMessageType       = "RefreshTokenReused"
+	SAMLCertificateExpiringMessageType  = "SAMLCertificateExpiring"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -71,5 +74,8 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyEmailOTPMessageType ||
 		textType == DomainClaimedMessageType ||
 		textType == PasswordlessRegistrationMessageType ||
-		textType == PasswordChangeMessageType
+		textType == PasswordChangeMessageType ||
+		textType == IDPAutoLinkedMessageType ||
+		textType == RefreshTokenReusedMessageType ||
+		textType == SAMLCertificateExpiringMessageType
 }
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// PushChallengeLifetime is the time the user has to approve a push challenge on the device.
+	PushChallengeLifetime = 2 * time.Minute
+
+	pushRegistrationPurpose = "zitadel-push-registration"
+	pushResponsePurpose     = "zitadel-push-response"
+	pushDecisionApprove     = "approve"
+	pushDecisionDeny        = "deny"
+)
+
+// PushDeviceRegistration is returned when a device is added to a user.
+// The device has to sign the [PushDeviceRegistrationData] containing the Challenge to prove the possession of the private key.
+type PushDeviceRegistration struct {
+	*ObjectDetails
+	DeviceID  string
+	Challenge string
+}
+
+// PushDeviceRegistrationData returns the data the device signs to verify its registration.
+func PushDeviceRegistrationData(userID, deviceID, challenge string) []byte {
+	return []byte(strings.Join([]string{pushRegistrationPurpose, userID, deviceID, challenge}, "."))
+}
+
+// PushChallengeResponseData returns the data the device signs to approve or deny a push challenge.
+// The number is the one displayed on the login the user entered on the device (number matching),
+// it is only relevant for approvals.
+func PushChallengeResponseData(sessionID, challengeID string, approve bool, number uint32) []byte {
+	decision := pushDecisionDeny
+	if approve {
+		decision = pushDecisionApprove
+	}
+	return []byte(strings.Join([]string{pushResponsePurpose, sessionID, challengeID, decision, strconv.FormatUint(uint64(number), 10)}, "."))
+}
+
+// ValidatePushDevicePublicKey checks that the public key is a PKIX (DER) encoded ECDSA P-256 or Ed25519 key.
+func ValidatePushDevicePublicKey(publicKey []byte) error {
+	_, err := parsePushDevicePublicKey(publicKey)
+	return err
+}
+
+func parsePushDevicePublicKey(publicKey []byte) (any, error) {
+	key, err := x509.ParsePKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-Ooy3a", "Errors.User.MFA.Push.PublicKeyInvalid")
+	}
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-wei0A", "Errors.User.MFA.Push.PublicKeyInvalid")
+		}
+		return k, nil
+	case ed25519.PublicKey:
+		return k, nil
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-Eizu4", "Errors.User.MFA.Push.PublicKeyInvalid")
+	}
+}
+
+// VerifyPushSignature verifies the signature of the data by the private key of the device.
+// ECDSA signatures are expected ASN.1 encoded over the SHA-256 hash of the data.
+func VerifyPushSignature(publicKey, data, signature []byte) error {
+	key, err := parsePushDevicePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	var valid bool
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		hash := sha256.Sum256(data)
+		valid = ecdsa.VerifyASN1(k, hash[:], signature)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(k, data, signature)
+	}
+	if !valid {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-ieL0u", "Errors.User.MFA.Push.SignatureInvalid")
+	}
+	return nil
+}
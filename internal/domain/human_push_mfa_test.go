@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPushSignature(t *testing.T) {
+	data := PushChallengeResponseData("sessionID", "challengeID", true, 42)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecPublic, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+	hash := sha256.Sum256(data)
+	ecSignature, err := ecdsa.SignASN1(rand.Reader, ecKey, hash[:])
+	require.NoError(t, err)
+
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edPublic, err := x509.MarshalPKIXPublicKey(edPublicKey)
+	require.NoError(t, err)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p384Public, err := x509.MarshalPKIXPublicKey(&p384Key.PublicKey)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPublic, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		publicKey []byte
+		data      []byte
+		signature []byte
+		wantErr   bool
+	}{
+		{
+			name:      "invalid key",
+			publicKey: []byte("key"),
+			data:      data,
+			signature: ecSignature,
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported curve",
+			publicKey: p384Public,
+			data:      data,
+			signature: ecSignature,
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported key type",
+			publicKey: rsaPublic,
+			data:      data,
+			signature: ecSignature,
+			wantErr:   true,
+		},
+		{
+			name:      "ecdsa valid",
+			publicKey: ecPublic,
+			data:      data,
+			signature: ecSignature,
+		},
+		{
+			name:      "ecdsa other data",
+			publicKey: ecPublic,
+			data:      PushChallengeResponseData("sessionID", "challengeID", true, 43),
+			signature: ecSignature,
+			wantErr:   true,
+		},
+		{
+			name:      "ed25519 valid",
+			publicKey: edPublic,
+			data:      data,
+			signature: ed25519.Sign(edKey, data),
+		},
+		{
+			name:      "ed25519 other data",
+			publicKey: edPublic,
+			data:      PushChallengeResponseData("sessionID", "challengeID", false, 42),
+			signature: ed25519.Sign(edKey, data),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyPushSignature(tt.publicKey, tt.data, tt.signature)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushChallengeResponseData(t *testing.T) {
+	assert.Equal(t, "zitadel-push-response.session.challenge.approve.42", string(PushChallengeResponseData("session", "challenge", true, 42)))
+	assert.Equal(t, "zitadel-push-response.session.challenge.deny.0", string(PushChallengeResponseData("session", "challenge", false, 0)))
+}
@@ -24,6 +24,8 @@ type WebAuthNToken struct {
 	SignCount              uint32
 	WebAuthNTokenName      string
 	RPID                   string
+	AuthenticatorName      string
+	AuthenticatorIcon      string
 }
 
 type WebAuthNLogin struct {
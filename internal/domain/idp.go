@@ -38,6 +38,10 @@ const (
 	IDPTypeGoogle
 	IDPTypeApple
 	IDPTypeSAML
+	IDPTypeBitbucket
+	IDPTypeSlack
+	IDPTypeDiscord
+	IDPTypeLinkedIn
 )
 
 func (t IDPType) GetCSSClass() string {
@@ -59,7 +63,11 @@ func (t IDPType) GetCSSClass() string {
 		IDPTypeJWT,
 		IDPTypeOAuth,
 		IDPTypeLDAP,
-		IDPTypeSAML:
+		IDPTypeSAML,
+		IDPTypeBitbucket,
+		IDPTypeSlack,
+		IDPTypeDiscord,
+		IDPTypeLinkedIn:
 		fallthrough
 	default:
 		return ""
@@ -85,6 +93,14 @@ func (t IDPType) DisplayName() string {
 		return "Google"
 	case IDPTypeApple:
 		return "Apple"
+	case IDPTypeBitbucket:
+		return "Bitbucket"
+	case IDPTypeSlack:
+		return "Slack"
+	case IDPTypeDiscord:
+		return "Discord"
+	case IDPTypeLinkedIn:
+		return "LinkedIn"
 	case IDPTypeUnspecified,
 		IDPTypeOIDC,
 		IDPTypeJWT,
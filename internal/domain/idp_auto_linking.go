@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AutoLinkingOption defines by which asserted attribute an external user is linked to an existing user.
+type AutoLinkingOption int32
+
+const (
+	AutoLinkingOptionUnspecified AutoLinkingOption = iota
+	// AutoLinkingOptionUsername links the external user to the user with exactly the same username.
+	AutoLinkingOptionUsername
+	// AutoLinkingOptionEmail links the external user to the user with exactly the same verified email address.
+	AutoLinkingOptionEmail
+	autoLinkingOptionCount
+)
+
+func (o AutoLinkingOption) Valid() bool {
+	return o > AutoLinkingOptionUnspecified && o < autoLinkingOptionCount
+}
+
+// IDPTrustLevel defines how far the assertions of a provider are trusted for the automatic linking.
+type IDPTrustLevel int32
+
+const (
+	// IDPTrustLevelUnspecified never trusts the provider, users are never linked automatically.
+	IDPTrustLevelUnspecified IDPTrustLevel = iota
+	// IDPTrustLevelVerifiedEmail trusts email addresses the provider asserts as verified.
+	IDPTrustLevelVerifiedEmail
+	// IDPTrustLevelFull trusts all email addresses and usernames asserted by the provider,
+	// e.g. for the corporate directory of the organization.
+	// Users linked by email must still have verified their email address.
+	IDPTrustLevelFull
+	idpTrustLevelCount
+)
+
+func (l IDPTrustLevel) Valid() bool {
+	return l > IDPTrustLevelUnspecified && l < idpTrustLevelCount
+}
+
+// IDPAutoLinking links external users to existing users without user interaction,
+// if the provider asserts an email address or username matching exactly one existing user.
+// The zero value disables the automatic linking.
+type IDPAutoLinking struct {
+	Option     AutoLinkingOption `json:"option,omitempty"`
+	TrustLevel IDPTrustLevel     `json:"trustLevel,omitempty"`
+}
+
+func (a *IDPAutoLinking) IsEnabled() bool {
+	return a != nil && a.Option.Valid() && a.TrustLevel.Valid()
+}
+
+func (a *IDPAutoLinking) Validate() error {
+	if a.Option == AutoLinkingOptionUnspecified {
+		return nil
+	}
+	if !a.Option.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-eiN4a", "Errors.IDP.AutoLinking.OptionInvalid")
+	}
+	if !a.TrustLevel.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ahm1u", "Errors.IDP.AutoLinking.TrustLevelInvalid")
+	}
+	// usernames can't be verified, so they must only be trusted if the provider is
+	if a.Option == AutoLinkingOptionUsername && a.TrustLevel != IDPTrustLevelFull {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-oot6E", "Errors.IDP.AutoLinking.UsernameRequiresFullTrust")
+	}
+	return nil
+}
+
+// LinkingValue returns the asserted username or email address the existing user is searched by.
+// It returns false if the linking is disabled or the assertion is not trusted.
+func (a *IDPAutoLinking) LinkingValue(username, email string, emailVerified bool) (string, bool) {
+	if !a.IsEnabled() {
+		return "", false
+	}
+	switch a.Option {
+	case AutoLinkingOptionUsername:
+		return username, username != "" && a.TrustLevel == IDPTrustLevelFull
+	case AutoLinkingOptionEmail:
+		trusted := a.TrustLevel == IDPTrustLevelFull || (a.TrustLevel == IDPTrustLevelVerifiedEmail && emailVerified)
+		return email, email != "" && trusted
+	case AutoLinkingOptionUnspecified, autoLinkingOptionCount:
+		fallthrough
+	default:
+		return "", false
+	}
+}
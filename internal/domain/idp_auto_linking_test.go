@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDPAutoLinking_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		linking *IDPAutoLinking
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			linking: &IDPAutoLinking{},
+		},
+		{
+			name:    "invalid option",
+			linking: &IDPAutoLinking{Option: autoLinkingOptionCount, TrustLevel: IDPTrustLevelFull},
+			wantErr: true,
+		},
+		{
+			name:    "missing trust level",
+			linking: &IDPAutoLinking{Option: AutoLinkingOptionEmail},
+			wantErr: true,
+		},
+		{
+			name:    "username without full trust",
+			linking: &IDPAutoLinking{Option: AutoLinkingOptionUsername, TrustLevel: IDPTrustLevelVerifiedEmail},
+			wantErr: true,
+		},
+		{
+			name:    "username with full trust",
+			linking: &IDPAutoLinking{Option: AutoLinkingOptionUsername, TrustLevel: IDPTrustLevelFull},
+		},
+		{
+			name:    "email with verified email trust",
+			linking: &IDPAutoLinking{Option: AutoLinkingOptionEmail, TrustLevel: IDPTrustLevelVerifiedEmail},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.linking.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestIDPAutoLinking_LinkingValue(t *testing.T) {
+	type args struct {
+		username      string
+		email         string
+		emailVerified bool
+	}
+	tests := []struct {
+		name      string
+		linking   *IDPAutoLinking
+		args      args
+		wantValue string
+		wantOK    bool
+	}{
+		{
+			name:    "nil",
+			linking: nil,
+			args:    args{username: "user", email: "user@example.com", emailVerified: true},
+		},
+		{
+			name:    "untrusted",
+			linking: &IDPAutoLinking{Option: AutoLinkingOptionEmail},
+			args:    args{email: "user@example.com", emailVerified: true},
+		},
+		{
+			name:    "email not verified",
+			linking: &IDPAutoLinking{Option: AutoLinkingOptionEmail, TrustLevel: IDPTrustLevelVerifiedEmail},
+			args:    args{email: "user@example.com"},
+		},
+		{
+			name:      "email verified",
+			linking:   &IDPAutoLinking{Option: AutoLinkingOptionEmail, TrustLevel: IDPTrustLevelVerifiedEmail},
+			args:      args{email: "user@example.com", emailVerified: true},
+			wantValue: "user@example.com",
+			wantOK:    true,
+		},
+		{
+			name:      "email fully trusted",
+			linking:   &IDPAutoLinking{Option: AutoLinkingOptionEmail, TrustLevel: IDPTrustLevelFull},
+			args:      args{email: "user@example.com"},
+			wantValue: "user@example.com",
+			wantOK:    true,
+		},
+		{
+			name:    "email missing",
+			linking: &IDPAutoLinking{Option: AutoLinkingOptionEmail, TrustLevel: IDPTrustLevelFull},
+			args:    args{username: "user"},
+		},
+		{
+			name:    "username not fully trusted",
+			linking: &IDPAutoLinking{Option: AutoLinkingOptionUsername, TrustLevel: IDPTrustLevelVerifiedEmail},
+			args:    args{username: "user", email: "user@example.com", emailVerified: true},
+		},
+		{
+			name:      "username fully trusted",
+			linking:   &IDPAutoLinking{Option: AutoLinkingOptionUsername, TrustLevel: IDPTrustLevelFull},
+			args:      args{username: "user"},
+			wantValue: "user",
+			wantOK:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := tt.linking.LinkingValue(tt.args.username, tt.args.email, tt.args.emailVerified)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.wantValue, value)
+			}
+		})
+	}
+}
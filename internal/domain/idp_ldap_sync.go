@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const LDAPSyncMinInterval = 5 * time.Minute
+
+// LDAPSync configures the scheduled synchronization of the users of an LDAP directory.
+type LDAPSync struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval is the minimal duration between two runs of the synchronization.
+	Interval time.Duration `json:"interval,omitempty"`
+	// Filter is an optional LDAP filter, which is combined with the user object classes of the provider.
+	Filter string `json:"filter,omitempty"`
+	// OrgID is the organization new users are created in.
+	// It's only used for instance providers, users of organization providers are always created in the organization of the provider.
+	// If empty, the default organization of the instance is used.
+	OrgID string `json:"orgId,omitempty"`
+	// DeactivateMissing deactivates linked users, which are no longer returned by the directory.
+	DeactivateMissing bool `json:"deactivateMissing,omitempty"`
+	// NestedGroups resolves the group membership transitively (Active Directory only).
+	NestedGroups  bool                `json:"nestedGroups,omitempty"`
+	GroupMappings []*LDAPGroupMapping `json:"groupMappings,omitempty"`
+}
+
+// LDAPGroupMapping grants the roles of a project to all members of an LDAP group.
+type LDAPGroupMapping struct {
+	GroupDN   string   `json:"groupDN,omitempty"`
+	ProjectID string   `json:"projectId,omitempty"`
+	RoleKeys  []string `json:"roleKeys,omitempty"`
+}
+
+func (s *LDAPSync) Validate() error {
+	if s.Enabled && s.Interval < LDAPSyncMinInterval {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-ieV3a", "Errors.IDP.LDAPSync.IntervalInvalid")
+	}
+	if s.Filter != "" {
+		if _, err := ldap.CompileFilter(s.Filter); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "DOMAIN-Ohs2e", "Errors.IDP.LDAPSync.FilterInvalid")
+		}
+	}
+	for _, mapping := range s.GroupMappings {
+		if mapping == nil || mapping.GroupDN == "" || mapping.ProjectID == "" {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ku8ah", "Errors.IDP.LDAPSync.GroupMappingInvalid")
+		}
+	}
+	return nil
+}
+
+// RolesByProject returns the role keys granted by the groups for every project of the group mappings.
+// Projects without any matching group are returned with nil roles, meaning the user must not have a grant.
+// Grants to projects which are not part of the mappings are never touched.
+// Group DNs are compared case-insensitively.
+func (s *LDAPSync) RolesByProject(groups []string) map[string][]string {
+	roles := make(map[string][]string, len(s.GroupMappings))
+	for _, mapping := range s.GroupMappings {
+		keys := roles[mapping.ProjectID]
+		if !containsDN(groups, mapping.GroupDN) {
+			roles[mapping.ProjectID] = keys
+			continue
+		}
+		for _, key := range mapping.RoleKeys {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+		if keys == nil {
+			keys = []string{}
+		}
+		roles[mapping.ProjectID] = keys
+	}
+	return roles
+}
+
+func containsDN(dns []string, dn string) bool {
+	for _, d := range dns {
+		if strings.EqualFold(d, dn) {
+			return true
+		}
+	}
+	return false
+}
+
+// LDAPSyncReport summarizes a run of the LDAP synchronization.
+type LDAPSyncReport struct {
+	StartedAt     time.Time `json:"startedAt,omitempty"`
+	UsersFound    uint32    `json:"usersFound,omitempty"`
+	Created       uint32    `json:"created,omitempty"`
+	Updated       uint32    `json:"updated,omitempty"`
+	Deactivated   uint32    `json:"deactivated,omitempty"`
+	Reactivated   uint32    `json:"reactivated,omitempty"`
+	GrantsAdded   uint32    `json:"grantsAdded,omitempty"`
+	GrantsChanged uint32    `json:"grantsChanged,omitempty"`
+	GrantsRemoved uint32    `json:"grantsRemoved,omitempty"`
+	Failed        uint32    `json:"failed,omitempty"`
+	// Error is set if the run was aborted, e.g. because the directory was not reachable.
+	Error string `json:"error,omitempty"`
+}
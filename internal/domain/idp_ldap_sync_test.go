@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestLDAPSync_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sync    *LDAPSync
+		wantErr error
+	}{
+		{
+			name: "disabled",
+			sync: &LDAPSync{},
+		},
+		{
+			name: "interval too short",
+			sync: &LDAPSync{
+				Enabled:  true,
+				Interval: time.Minute,
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-ieV3a", "Errors.IDP.LDAPSync.IntervalInvalid"),
+		},
+		{
+			name: "invalid filter",
+			sync: &LDAPSync{
+				Enabled:  true,
+				Interval: time.Hour,
+				Filter:   "department=IT",
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohs2e", "Errors.IDP.LDAPSync.FilterInvalid"),
+		},
+		{
+			name: "group mapping without project",
+			sync: &LDAPSync{
+				Enabled:  true,
+				Interval: time.Hour,
+				GroupMappings: []*LDAPGroupMapping{
+					{GroupDN: "cn=admins,dc=example,dc=com"},
+				},
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ku8ah", "Errors.IDP.LDAPSync.GroupMappingInvalid"),
+		},
+		{
+			name: "valid",
+			sync: &LDAPSync{
+				Enabled:  true,
+				Interval: time.Hour,
+				Filter:   "(department=IT)",
+				GroupMappings: []*LDAPGroupMapping{
+					{GroupDN: "cn=admins,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"admin"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sync.Validate()
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLDAPSync_RolesByProject(t *testing.T) {
+	sync := &LDAPSync{
+		GroupMappings: []*LDAPGroupMapping{
+			{GroupDN: "cn=admins,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"admin", "user"}},
+			{GroupDN: "cn=users,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"user"}},
+			{GroupDN: "cn=users,dc=example,dc=com", ProjectID: "project2"},
+			{GroupDN: "cn=finance,dc=example,dc=com", ProjectID: "project3", RoleKeys: []string{"viewer"}},
+		},
+	}
+	tests := []struct {
+		name   string
+		groups []string
+		want   map[string][]string
+	}{
+		{
+			name: "no groups",
+			want: map[string][]string{
+				"project1": nil,
+				"project2": nil,
+				"project3": nil,
+			},
+		},
+		{
+			name:   "merged roles",
+			groups: []string{"CN=Admins,DC=example,DC=com", "cn=users,dc=example,dc=com"},
+			want: map[string][]string{
+				"project1": {"admin", "user"},
+				"project2": {},
+				"project3": nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sync.RolesByProject(tt.groups))
+		})
+	}
+}
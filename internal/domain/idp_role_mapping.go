@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type IDPRoleMappingMode int32
+
+const (
+	IDPRoleMappingModeUnspecified IDPRoleMappingMode = iota
+	// IDPRoleMappingModeAddOnly only adds the mapped roles to the user grants, roles are never removed.
+	IDPRoleMappingModeAddOnly
+	// IDPRoleMappingModeReconcile sets the roles of the user grants on the mapped projects to exactly the mapped roles.
+	// Grants are removed if no value of the claim matches a rule of the project.
+	IDPRoleMappingModeReconcile
+	idpRoleMappingModeCount
+)
+
+func (m IDPRoleMappingMode) Valid() bool {
+	return m > IDPRoleMappingModeUnspecified && m < idpRoleMappingModeCount
+}
+
+// IDPRoleMapping maps the values of a claim (or attribute) of the external user to roles of projects,
+// which are granted to the user on every login.
+// An empty mapping (no rules) disables the mapping.
+type IDPRoleMapping struct {
+	// Claim is the name of the claim or attribute providing the values, e.g. `groups` or `memberOf`.
+	// Nested claims can be addressed with a dot separated path, e.g. `realm_access.roles`.
+	Claim string             `json:"claim,omitempty"`
+	Mode  IDPRoleMappingMode `json:"mode,omitempty"`
+	Rules []*IDPRoleRule     `json:"rules,omitempty"`
+}
+
+// IDPRoleRule grants the roles of a project to the user if the claim contains the value.
+type IDPRoleRule struct {
+	Value     string   `json:"value,omitempty"`
+	ProjectID string   `json:"projectId,omitempty"`
+	RoleKeys  []string `json:"roleKeys,omitempty"`
+}
+
+func (m *IDPRoleMapping) IsEnabled() bool {
+	return m != nil && len(m.Rules) > 0
+}
+
+func (m *IDPRoleMapping) Validate() error {
+	if len(m.Rules) == 0 {
+		return nil
+	}
+	if strings.TrimSpace(m.Claim) == "" {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Aeh2u", "Errors.IDP.RoleMapping.ClaimMissing")
+	}
+	if !m.Mode.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-wai3O", "Errors.IDP.RoleMapping.ModeInvalid")
+	}
+	for _, rule := range m.Rules {
+		if rule == nil || rule.Value == "" || rule.ProjectID == "" {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Quoo9", "Errors.IDP.RoleMapping.RuleInvalid")
+		}
+	}
+	return nil
+}
+
+// RolesByProject returns the role keys granted by the claim values for the projects of the rules.
+// In reconcile mode, projects without any matching rule are returned with nil roles, meaning the user must not have a grant.
+// In add only mode, only projects with a matching rule are returned.
+// Values are compared case-insensitively.
+func (m *IDPRoleMapping) RolesByProject(values []string) map[string][]string {
+	roles := make(map[string][]string, len(m.Rules))
+	for _, rule := range m.Rules {
+		keys, ok := roles[rule.ProjectID]
+		if !slices.ContainsFunc(values, func(value string) bool { return strings.EqualFold(value, rule.Value) }) {
+			if !ok && m.Mode == IDPRoleMappingModeReconcile {
+				roles[rule.ProjectID] = nil
+			}
+			continue
+		}
+		for _, key := range rule.RoleKeys {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+		if keys == nil {
+			keys = []string{}
+		}
+		roles[rule.ProjectID] = keys
+	}
+	return roles
+}
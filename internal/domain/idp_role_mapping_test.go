@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDPRoleMapping_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *IDPRoleMapping
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			mapping: &IDPRoleMapping{},
+		},
+		{
+			name: "missing claim",
+			mapping: &IDPRoleMapping{
+				Mode:  IDPRoleMappingModeAddOnly,
+				Rules: []*IDPRoleRule{{Value: "admins", ProjectID: "project1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing mode",
+			mapping: &IDPRoleMapping{
+				Claim: "groups",
+				Rules: []*IDPRoleRule{{Value: "admins", ProjectID: "project1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rule without project",
+			mapping: &IDPRoleMapping{
+				Claim: "groups",
+				Mode:  IDPRoleMappingModeAddOnly,
+				Rules: []*IDPRoleRule{{Value: "admins"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			mapping: &IDPRoleMapping{
+				Claim: "groups",
+				Mode:  IDPRoleMappingModeReconcile,
+				Rules: []*IDPRoleRule{{Value: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mapping.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestIDPRoleMapping_RolesByProject(t *testing.T) {
+	rules := []*IDPRoleRule{
+		{Value: "admins", ProjectID: "project1", RoleKeys: []string{"admin", "user"}},
+		{Value: "users", ProjectID: "project1", RoleKeys: []string{"user"}},
+		{Value: "readers", ProjectID: "project2", RoleKeys: []string{"reader"}},
+		{Value: "members", ProjectID: "project3"},
+	}
+	tests := []struct {
+		name   string
+		mode   IDPRoleMappingMode
+		values []string
+		want   map[string][]string
+	}{
+		{
+			name:   "reconcile, unmatched projects removed",
+			mode:   IDPRoleMappingModeReconcile,
+			values: []string{"Admins", "users"},
+			want: map[string][]string{
+				"project1": {"admin", "user"},
+				"project2": nil,
+				"project3": nil,
+			},
+		},
+		{
+			name:   "add only, unmatched projects ignored",
+			mode:   IDPRoleMappingModeAddOnly,
+			values: []string{"readers", "members"},
+			want: map[string][]string{
+				"project2": {"reader"},
+				"project3": {},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &IDPRoleMapping{Claim: "groups", Mode: tt.mode, Rules: rules}
+			assert.Equal(t, tt.want, m.RolesByProject(tt.values))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const SAMLMetadataRefreshMinInterval = 5 * time.Minute
+
+// SAMLMetadataRefresh configures the scheduled refresh of the metadata of a SAML provider from its metadata URL.
+type SAMLMetadataRefresh struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// URL must use https, since the fetched metadata replaces the trusted signing certificates of the provider.
+	URL string `json:"url,omitempty"`
+	// MetadataCertificate is the PEM encoded certificate the signature of the fetched metadata is verified with.
+	// If empty, the metadata must be signed with one of the signing certificates currently trusted for the provider.
+	MetadataCertificate string `json:"metadataCertificate,omitempty"`
+	// Interval is the minimal duration between two refreshes of the metadata.
+	Interval time.Duration `json:"interval,omitempty"`
+	// RolloverPeriod is the duration signing certificates, which are no longer published by the provider,
+	// are still accepted for the validation of responses.
+	RolloverPeriod time.Duration `json:"rolloverPeriod,omitempty"`
+	// ExpiryWarning is the duration before the expiry of a signing certificate an alert is raised.
+	// If zero, no alerts are raised.
+	ExpiryWarning time.Duration `json:"expiryWarning,omitempty"`
+}
+
+func (r *SAMLMetadataRefresh) Validate() error {
+	if r.URL != "" || r.Enabled {
+		u, err := url.Parse(r.URL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return zerrors.ThrowInvalidArgument(err, "DOMAIN-Eeb4o", "Errors.IDP.SAMLMetadataRefresh.URLInvalid")
+		}
+	}
+	if _, err := r.Certificate(); err != nil {
+		return err
+	}
+	if r.Enabled && r.Interval < SAMLMetadataRefreshMinInterval {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohf3i", "Errors.IDP.SAMLMetadataRefresh.IntervalInvalid")
+	}
+	if r.RolloverPeriod < 0 || r.ExpiryWarning < 0 {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Iex7a", "Errors.IDP.SAMLMetadataRefresh.DurationInvalid")
+	}
+	return nil
+}
+
+// Certificate returns the parsed MetadataCertificate, which is nil if none is configured.
+func (r *SAMLMetadataRefresh) Certificate() (*x509.Certificate, error) {
+	if r.MetadataCertificate == "" {
+		return nil, nil
+	}
+	block, _ := pem.Decode([]byte(r.MetadataCertificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-Pe3mc", "Errors.IDP.SAMLMetadataRefresh.CertificateInvalid")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-Pe3mc", "Errors.IDP.SAMLMetadataRefresh.CertificateInvalid")
+	}
+	return certificate, nil
+}
+
+// SAMLCertificate is a signing certificate of a SAML provider.
+type SAMLCertificate struct {
+	// Certificate is the DER encoded certificate.
+	Certificate []byte    `json:"certificate,omitempty"`
+	NotAfter    time.Time `json:"notAfter,omitempty"`
+	// RetiredAt is set as soon as the certificate is no longer published in the metadata of the provider.
+	// Retired certificates are accepted until the rollover period elapsed.
+	RetiredAt time.Time `json:"retiredAt,omitempty"`
+}
+
+func (c *SAMLCertificate) IsRetired() bool {
+	return !c.RetiredAt.IsZero()
+}
+
+// IsExpiring returns true if the certificate is still published by the provider and expires within the warning period.
+func (c *SAMLCertificate) IsExpiring(now time.Time, warning time.Duration) bool {
+	return warning > 0 && !c.IsRetired() && c.NotAfter.Before(now.Add(warning))
+}
+
+// Fingerprint identifies the certificate, see [SAMLCertificateFingerprint].
+func (c *SAMLCertificate) Fingerprint() string {
+	return SAMLCertificateFingerprint(c.Certificate)
+}
+
+// SAMLCertificateFingerprint returns the hex encoded SHA-256 hash of the DER encoded certificate.
+func SAMLCertificateFingerprint(certificate []byte) string {
+	hash := sha256.Sum256(certificate)
+	return hex.EncodeToString(hash[:])
+}
+
+// SAMLMetadataRefreshReport summarizes a refresh of the metadata of a SAML provider.
+type SAMLMetadataRefreshReport struct {
+	StartedAt time.Time `json:"startedAt,omitempty"`
+	// MetadataChanged is true if the stored metadata was replaced.
+	MetadataChanged bool `json:"metadataChanged,omitempty"`
+	// Certificates are the signing certificates accepted after the refresh, including retired ones.
+	Certificates []*SAMLCertificate `json:"certificates,omitempty"`
+	// Error is set if the metadata could not be fetched or was invalid, the stored metadata is kept in that case.
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestSAMLMetadataRefresh_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		refresh *SAMLMetadataRefresh
+		wantErr error
+	}{
+		{
+			name:    "disabled",
+			refresh: &SAMLMetadataRefresh{},
+		},
+		{
+			name: "missing url",
+			refresh: &SAMLMetadataRefresh{
+				Enabled:  true,
+				Interval: time.Hour,
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Eeb4o", "Errors.IDP.SAMLMetadataRefresh.URLInvalid"),
+		},
+		{
+			name: "invalid scheme",
+			refresh: &SAMLMetadataRefresh{
+				URL: "ftp://idp.example.com/metadata",
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Eeb4o", "Errors.IDP.SAMLMetadataRefresh.URLInvalid"),
+		},
+		{
+			name: "http scheme",
+			refresh: &SAMLMetadataRefresh{
+				URL: "http://idp.example.com/metadata",
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Eeb4o", "Errors.IDP.SAMLMetadataRefresh.URLInvalid"),
+		},
+		{
+			name: "invalid metadata certificate",
+			refresh: &SAMLMetadataRefresh{
+				URL:                 "https://idp.example.com/metadata",
+				MetadataCertificate: "-----BEGIN CERTIFICATE-----\ninvalid\n-----END CERTIFICATE-----",
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Pe3mc", "Errors.IDP.SAMLMetadataRefresh.CertificateInvalid"),
+		},
+		{
+			name: "interval too short",
+			refresh: &SAMLMetadataRefresh{
+				Enabled:  true,
+				URL:      "https://idp.example.com/metadata",
+				Interval: time.Minute,
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohf3i", "Errors.IDP.SAMLMetadataRefresh.IntervalInvalid"),
+		},
+		{
+			name: "negative rollover period",
+			refresh: &SAMLMetadataRefresh{
+				Enabled:        true,
+				URL:            "https://idp.example.com/metadata",
+				Interval:       time.Hour,
+				RolloverPeriod: -time.Hour,
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Iex7a", "Errors.IDP.SAMLMetadataRefresh.DurationInvalid"),
+		},
+		{
+			name: "valid",
+			refresh: &SAMLMetadataRefresh{
+				Enabled:        true,
+				URL:            "https://idp.example.com/metadata",
+				Interval:       time.Hour,
+				RolloverPeriod: 24 * time.Hour,
+				ExpiryWarning:  30 * 24 * time.Hour,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.refresh.Validate()
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSAMLCertificate_IsExpiring(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		certificate *SAMLCertificate
+		warning     time.Duration
+		want        bool
+	}{
+		{
+			name:        "no warning",
+			certificate: &SAMLCertificate{NotAfter: now.Add(time.Hour)},
+			want:        false,
+		},
+		{
+			name:        "not expiring",
+			certificate: &SAMLCertificate{NotAfter: now.Add(48 * time.Hour)},
+			warning:     24 * time.Hour,
+			want:        false,
+		},
+		{
+			name:        "expiring",
+			certificate: &SAMLCertificate{NotAfter: now.Add(time.Hour)},
+			warning:     24 * time.Hour,
+			want:        true,
+		},
+		{
+			name:        "retired",
+			certificate: &SAMLCertificate{NotAfter: now.Add(time.Hour), RetiredAt: now},
+			warning:     24 * time.Hour,
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.certificate.IsExpiring(now, tt.warning))
+		})
+	}
+}
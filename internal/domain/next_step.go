@@ -29,6 +29,7 @@ const (
 	NextStepProjectRequired
 	NextStepRedirectToExternalIDP
 	NextStepLoginSucceeded
+	NextStepConsent
 )
 
 type LoginStep struct{}
@@ -164,6 +165,17 @@ func (s *LinkUsersStep) Type() NextStepType {
 	return NextStepLinkUsers
 }
 
+// ConsentStep asks the user to consent to the requested authorization details
+// and the requested scopes of the client.
+type ConsentStep struct {
+	AuthorizationDetails AuthorizationDetails
+	Scopes               []string
+}
+
+func (s *ConsentStep) Type() NextStepType {
+	return NextStepConsent
+}
+
 type GrantRequiredStep struct{}
 
 func (s *GrantRequiredStep) Type() NextStepType {
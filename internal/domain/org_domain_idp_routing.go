@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// OrgDomainIDPRouting routes users with a login name of a verified organization domain
+// directly to identity providers instead of asking for their password (home realm discovery).
+type OrgDomainIDPRouting struct {
+	// IDPIDs are the identity providers the users of the domain are routed to.
+	// Users linked to one of them are routed to the linked one, all others to the first.
+	IDPIDs []string `json:"idpIds,omitempty"`
+	// Force prevents users of the domain from authenticating with a local password.
+	// Without it, users which are not linked to any of the providers can still use their password.
+	Force bool `json:"force,omitempty"`
+}
+
+func (r *OrgDomainIDPRouting) Validate() error {
+	if len(r.IDPIDs) == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-ohX3u", "Errors.Org.Domain.IDPRouting.IDPMissing")
+	}
+	for i, id := range r.IDPIDs {
+		if id == "" || slices.Contains(r.IDPIDs[:i], id) {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Eing8", "Errors.Org.Domain.IDPRouting.Invalid")
+		}
+	}
+	return nil
+}
+
+// RoutedIDP returns the provider the user is routed to.
+// If the user is linked to one of the providers, the first linked one is returned, otherwise the first provider.
+func (r *OrgDomainIDPRouting) RoutedIDP(linkedIDPIDs []string) string {
+	for _, id := range r.IDPIDs {
+		if slices.Contains(linkedIDPIDs, id) {
+			return id
+		}
+	}
+	return r.IDPIDs[0]
+}
+
+// IsLinked returns true if one of the linked providers is part of the routing.
+func (r *OrgDomainIDPRouting) IsLinked(linkedIDPIDs []string) bool {
+	return slices.ContainsFunc(r.IDPIDs, func(id string) bool {
+		return slices.Contains(linkedIDPIDs, id)
+	})
+}
+
+// LoginNameDomain returns the lower-cased domain part of a login name or email, e.g. `partner.com` for `alice@partner.com`.
+// It returns an empty string if the login name has no domain part.
+func LoginNameDomain(loginName string) string {
+	loginName = strings.TrimSpace(loginName)
+	index := strings.LastIndex(loginName, "@")
+	if index < 0 {
+		return ""
+	}
+	return strings.ToLower(loginName[index+1:])
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrgDomainIDPRouting_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		routing *OrgDomainIDPRouting
+		wantErr bool
+	}{
+		{
+			name:    "no providers",
+			routing: &OrgDomainIDPRouting{},
+			wantErr: true,
+		},
+		{
+			name:    "empty provider",
+			routing: &OrgDomainIDPRouting{IDPIDs: []string{"idp1", ""}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate provider",
+			routing: &OrgDomainIDPRouting{IDPIDs: []string{"idp1", "idp2", "idp1"}},
+			wantErr: true,
+		},
+		{
+			name:    "valid",
+			routing: &OrgDomainIDPRouting{IDPIDs: []string{"idp1", "idp2"}, Force: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.routing.Validate()
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestOrgDomainIDPRouting_RoutedIDP(t *testing.T) {
+	routing := &OrgDomainIDPRouting{IDPIDs: []string{"idp1", "idp2", "idp3"}}
+	tests := []struct {
+		name       string
+		linked     []string
+		want       string
+		wantLinked bool
+	}{
+		{
+			name: "not linked",
+			want: "idp1",
+		},
+		{
+			name:   "linked to other provider",
+			linked: []string{"other"},
+			want:   "idp1",
+		},
+		{
+			name:       "linked",
+			linked:     []string{"other", "idp3"},
+			want:       "idp3",
+			wantLinked: true,
+		},
+		{
+			name:       "linked to multiple, order of routing",
+			linked:     []string{"idp3", "idp2"},
+			want:       "idp2",
+			wantLinked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, routing.RoutedIDP(tt.linked))
+			assert.Equal(t, tt.wantLinked, routing.IsLinked(tt.linked))
+		})
+	}
+}
+
+func TestLoginNameDomain(t *testing.T) {
+	tests := []struct {
+		loginName string
+		want      string
+	}{
+		{loginName: "alice", want: ""},
+		{loginName: "alice@partner.com", want: "partner.com"},
+		{loginName: " Alice@Partner.COM ", want: "partner.com"},
+		{loginName: "alice@example.com@partner.com", want: "partner.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.loginName, func(t *testing.T) {
+			assert.Equal(t, tt.want, LoginNameDomain(tt.loginName))
+		})
+	}
+}
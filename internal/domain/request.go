@@ -23,6 +23,7 @@ const (
 	AuthRequestTypeOIDC AuthRequestType = iota
 	AuthRequestTypeSAML
 	AuthRequestTypeDevice
+	AuthRequestTypeWSFed
 )
 
 type AuthRequestOIDC struct {
@@ -30,6 +31,8 @@ type AuthRequestOIDC struct {
 	ResponseType  OIDCResponseType
 	Nonce         string
 	CodeChallenge *OIDCCodeChallenge
+	// AuthorizationDetails are the requested rich authorization details (RFC 9396)
+	AuthorizationDetails AuthorizationDetails
 }
 
 func (a *AuthRequestOIDC) Type() AuthRequestType {
@@ -72,3 +75,19 @@ func (*AuthRequestDevice) Type() AuthRequestType {
 func (a *AuthRequestDevice) IsValid() bool {
 	return a.DeviceCode != "" && a.UserCode != ""
 }
+
+// AuthRequestWSFed is a sign-in request of the WS-Federation passive requestor profile (wa=wsignin1.0)
+// for a SAML application, identified by its entity ID as realm.
+type AuthRequestWSFed struct {
+	Realm   string
+	Reply   string
+	Context string
+}
+
+func (*AuthRequestWSFed) Type() AuthRequestType {
+	return AuthRequestTypeWSFed
+}
+
+func (a *AuthRequestWSFed) IsValid() bool {
+	return a.Realm != "" && a.Reply != ""
+}
@@ -2,6 +2,9 @@ package domain
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"strings"
 	"time"
 
@@ -20,6 +23,19 @@ type Token struct {
 	Expiration        time.Time
 	Scopes            []string
 	PreferredLanguage string
+	// CertificateThumbprint binds the token to the client certificate (RFC 8705)
+	CertificateThumbprint string
+	AuthorizationDetails  AuthorizationDetails
+}
+
+// CertificateThumbprint returns the base64url encoded SHA-256 thumbprint
+// of the DER encoded certificate, as used in the `x5t#S256` confirmation claim.
+func CertificateThumbprint(cert *x509.Certificate) string {
+	if cert == nil {
+		return ""
+	}
+	sum := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
 }
 
 func AddAudScopeToAudience(ctx context.Context, audience, scopes []string) []string {
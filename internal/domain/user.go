@@ -42,6 +42,7 @@ const (
 	UserAuthMethodTypeIDP
 	UserAuthMethodTypeOTPSMS
 	UserAuthMethodTypeOTPEmail
+	UserAuthMethodTypePush
 	userAuthMethodTypeCount
 )
 
@@ -59,6 +60,7 @@ func HasMFA(methods []UserAuthMethodType) bool {
 			UserAuthMethodTypeTOTP,
 			UserAuthMethodTypeOTPSMS,
 			UserAuthMethodTypeOTPEmail,
+			UserAuthMethodTypePush,
 			UserAuthMethodTypeIDP:
 			factors++
 		case UserAuthMethodTypeUnspecified,
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// WebAuthNAttestationPolicy restricts the authenticators (security keys and passkeys) users are able to register.
+// The default (empty) policy accepts any authenticator.
+type WebAuthNAttestationPolicy struct {
+	// RequireAttestation only accepts authenticators providing an attestation statement signed by an attestation certificate,
+	// self attestation and authenticators without attestation are rejected.
+	RequireAttestation bool `json:"requireAttestation,omitempty"`
+	// RequireMetadata only accepts authenticators listed in the FIDO Metadata Service without any undesired status.
+	// The attestation certificate must chain to a root certificate of the metadata.
+	RequireMetadata bool `json:"requireMetadata,omitempty"`
+	// AllowedAAGUIDs only accepts authenticators with one of the listed AAGUIDs, if not empty.
+	// As the AAGUID is asserted by the authenticator itself, it must be proven like for RequireMetadata.
+	AllowedAAGUIDs []string `json:"allowedAaguids,omitempty"`
+	// DeniedAAGUIDs rejects authenticators with any of the listed AAGUIDs.
+	DeniedAAGUIDs []string `json:"deniedAaguids,omitempty"`
+}
+
+func (p *WebAuthNAttestationPolicy) IsEnabled() bool {
+	return p != nil && (p.RequireAttestation || p.RequireMetadata || len(p.AllowedAAGUIDs) > 0 || len(p.DeniedAAGUIDs) > 0)
+}
+
+// Normalize validates the AAGUIDs of the policy and converts them to their canonical (lower case) form.
+func (p *WebAuthNAttestationPolicy) Normalize() (err error) {
+	if p.AllowedAAGUIDs, err = normalizeAAGUIDs(p.AllowedAAGUIDs); err != nil {
+		return err
+	}
+	p.DeniedAAGUIDs, err = normalizeAAGUIDs(p.DeniedAAGUIDs)
+	return err
+}
+
+func normalizeAAGUIDs(aaguids []string) ([]string, error) {
+	if len(aaguids) == 0 {
+		return nil, nil
+	}
+	normalized := make([]string, 0, len(aaguids))
+	for _, aaguid := range aaguids {
+		id, err := uuid.Parse(aaguid)
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-Ohng4", "Errors.Policy.WebAuthNAttestation.AAGUIDInvalid")
+		}
+		if !slices.Contains(normalized, id.String()) {
+			normalized = append(normalized, id.String())
+		}
+	}
+	return normalized, nil
+}
+
+// CheckAAGUID returns an error if the authenticator with the AAGUID is denied or not allowed by the policy.
+func (p *WebAuthNAttestationPolicy) CheckAAGUID(aaguid []byte) error {
+	id, err := uuid.FromBytes(aaguid)
+	if err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "DOMAIN-ieR7a", "Errors.User.WebAuthN.AuthenticatorNotAllowed")
+	}
+	if slices.Contains(p.DeniedAAGUIDs, id.String()) {
+		return zerrors.ThrowPreconditionFailed(nil, "DOMAIN-Vee8u", "Errors.User.WebAuthN.AuthenticatorNotAllowed")
+	}
+	if len(p.AllowedAAGUIDs) > 0 && !slices.Contains(p.AllowedAAGUIDs, id.String()) {
+		return zerrors.ThrowPreconditionFailed(nil, "DOMAIN-aeN3i", "Errors.User.WebAuthN.AuthenticatorNotAllowed")
+	}
+	return nil
+}
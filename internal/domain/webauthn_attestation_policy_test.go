@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebAuthNAttestationPolicy_Normalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *WebAuthNAttestationPolicy
+		want    *WebAuthNAttestationPolicy
+		wantErr bool
+	}{
+		{
+			name:   "empty",
+			policy: &WebAuthNAttestationPolicy{},
+			want:   &WebAuthNAttestationPolicy{},
+		},
+		{
+			name: "invalid aaguid",
+			policy: &WebAuthNAttestationPolicy{
+				AllowedAAGUIDs: []string{"yubikey"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "canonical form without duplicates",
+			policy: &WebAuthNAttestationPolicy{
+				AllowedAAGUIDs: []string{"CB69481E-8FF7-4039-93EC-0A2729A154A8", "cb69481e8ff7403993ec0a2729a154a8"},
+				DeniedAAGUIDs:  []string{"urn:uuid:ee882879-721c-4913-9775-3dfcce97072a"},
+			},
+			want: &WebAuthNAttestationPolicy{
+				AllowedAAGUIDs: []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+				DeniedAAGUIDs:  []string{"ee882879-721c-4913-9775-3dfcce97072a"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Normalize()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, tt.policy)
+		})
+	}
+}
+
+func TestWebAuthNAttestationPolicy_CheckAAGUID(t *testing.T) {
+	allowed := uuid.MustParse("cb69481e-8ff7-4039-93ec-0a2729a154a8")
+	denied := uuid.MustParse("ee882879-721c-4913-9775-3dfcce97072a")
+	other := uuid.MustParse("fa2b99dc-9e39-4257-8f92-4a30d23c4118")
+	tests := []struct {
+		name    string
+		policy  *WebAuthNAttestationPolicy
+		aaguid  []byte
+		wantErr bool
+	}{
+		{
+			name:   "no restrictions",
+			policy: &WebAuthNAttestationPolicy{},
+			aaguid: other[:],
+		},
+		{
+			name:    "invalid aaguid",
+			policy:  &WebAuthNAttestationPolicy{},
+			aaguid:  []byte{1},
+			wantErr: true,
+		},
+		{
+			name:    "denied",
+			policy:  &WebAuthNAttestationPolicy{DeniedAAGUIDs: []string{denied.String()}},
+			aaguid:  denied[:],
+			wantErr: true,
+		},
+		{
+			name:   "not denied",
+			policy: &WebAuthNAttestationPolicy{DeniedAAGUIDs: []string{denied.String()}},
+			aaguid: other[:],
+		},
+		{
+			name:   "allowed",
+			policy: &WebAuthNAttestationPolicy{AllowedAAGUIDs: []string{allowed.String()}},
+			aaguid: allowed[:],
+		},
+		{
+			name:    "not allowed",
+			policy:  &WebAuthNAttestationPolicy{AllowedAAGUIDs: []string{allowed.String()}},
+			aaguid:  other[:],
+			wantErr: true,
+		},
+		{
+			name:    "zero aaguid not allowed",
+			policy:  &WebAuthNAttestationPolicy{AllowedAAGUIDs: []string{allowed.String()}},
+			aaguid:  make([]byte, 16),
+			wantErr: true,
+		},
+		{
+			name:    "denied takes precedence",
+			policy:  &WebAuthNAttestationPolicy{AllowedAAGUIDs: []string{denied.String()}, DeniedAAGUIDs: []string{denied.String()}},
+			aaguid:  denied[:],
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.CheckAAGUID(tt.aaguid)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -2,11 +2,23 @@ package eventstore
 
 import (
 	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
 )
 
 type Config struct {
 	PushTimeout time.Duration
+	Snapshots   SnapshotConfig
+	Rewrite     RewriteConfig
 
 	Pusher  Pusher
 	Querier Querier
+	// SnapshotStorage is optional, snapshots are disabled if not set
+	SnapshotStorage SnapshotStorage
+	// Rewriter is optional, stored payloads are upcasted on read if not set
+	Rewriter EventRewriter
+	// PersonalDataStorage and PersonalDataKeyAlgorithm are optional,
+	// personal data is pushed unencrypted if not set
+	PersonalDataStorage      PersonalDataStorage
+	PersonalDataKeyAlgorithm crypto.EncryptionAlgorithm
 }
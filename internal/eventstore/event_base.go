@@ -84,8 +84,9 @@ func (e *BaseEvent) DataAsBytes() []byte {
 }
 
 // Revision implements action
-func (*BaseEvent) Revision() uint16 {
-	return 0
+// Events are always pushed and mapped with the latest revision of their type, see [RegisterUpcaster]
+func (e *BaseEvent) Revision() uint16 {
+	return EventRevision(e.EventType)
 }
 
 // Unmarshal implements Event
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
 )
 
 // Eventstore abstracts all functions needed to store valid events
@@ -17,6 +18,15 @@ type Eventstore struct {
 	pusher  Pusher
 	querier Querier
 
+	snapshots       SnapshotConfig
+	snapshotStorage SnapshotStorage
+
+	rewrite  RewriteConfig
+	rewriter EventRewriter
+
+	personalDataStorage      PersonalDataStorage
+	personalDataKeyAlgorithm crypto.EncryptionAlgorithm
+
 	instances         []string
 	lastInstanceQuery time.Time
 	instancesMu       sync.Mutex
@@ -56,6 +66,15 @@ func NewEventstore(config *Config) *Eventstore {
 		pusher:  config.Pusher,
 		querier: config.Querier,
 
+		snapshots:       config.Snapshots,
+		snapshotStorage: config.SnapshotStorage,
+
+		rewrite:  config.Rewrite,
+		rewriter: config.Rewriter,
+
+		personalDataStorage:      config.PersonalDataStorage,
+		personalDataKeyAlgorithm: config.PersonalDataKeyAlgorithm,
+
 		instancesMu: sync.Mutex{},
 	}
 }
@@ -77,12 +96,17 @@ func (es *Eventstore) Push(ctx context.Context, cmds ...Command) ([]Event, error
 		ctx, cancel = context.WithTimeout(ctx, es.PushTimeout)
 		defer cancel()
 	}
+	keys := make(personalDataKeys)
+	cmds, err := es.encryptPersonalData(ctx, keys, cmds)
+	if err != nil {
+		return nil, err
+	}
 	events, err := es.pusher.Push(ctx, cmds...)
 	if err != nil {
 		return nil, err
 	}
 
-	mappedEvents, err := es.mapEvents(events)
+	mappedEvents, err := es.mapEvents(ctx, keys, events)
 	if err != nil {
 		return mappedEvents, err
 	}
@@ -105,8 +129,9 @@ func (es *Eventstore) AggregateTypes() []string {
 func (es *Eventstore) Filter(ctx context.Context, searchQuery *SearchQueryBuilder) ([]Event, error) {
 	events := make([]Event, 0, searchQuery.GetLimit())
 	searchQuery.ensureInstanceID(ctx)
+	keys := make(personalDataKeys)
 	err := es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
-		event, err := es.mapEvent(event)
+		event, err := es.mapEvent(ctx, keys, event)
 		if err != nil {
 			return err
 		}
@@ -119,10 +144,10 @@ func (es *Eventstore) Filter(ctx context.Context, searchQuery *SearchQueryBuilde
 	return events, nil
 }
 
-func (es *Eventstore) mapEvents(events []Event) (mappedEvents []Event, err error) {
+func (es *Eventstore) mapEvents(ctx context.Context, keys personalDataKeys, events []Event) (mappedEvents []Event, err error) {
 	mappedEvents = make([]Event, len(events))
 	for i, event := range events {
-		mappedEvents[i], err = es.mapEventLocked(event)
+		mappedEvents[i], err = es.mapEventLocked(ctx, keys, event)
 		if err != nil {
 			return nil, err
 		}
@@ -130,11 +155,19 @@ func (es *Eventstore) mapEvents(events []Event) (mappedEvents []Event, err error
 	return mappedEvents, nil
 }
 
-func (es *Eventstore) mapEvent(event Event) (Event, error) {
-	return es.mapEventLocked(event)
+func (es *Eventstore) mapEvent(ctx context.Context, keys personalDataKeys, event Event) (Event, error) {
+	return es.mapEventLocked(ctx, keys, event)
 }
 
-func (es *Eventstore) mapEventLocked(event Event) (Event, error) {
+func (es *Eventstore) mapEventLocked(ctx context.Context, keys personalDataKeys, event Event) (Event, error) {
+	event, err := upcast(event)
+	if err != nil {
+		return nil, err
+	}
+	event, err = es.decryptPersonalData(ctx, keys, event)
+	if err != nil {
+		return nil, err
+	}
 	interceptors, ok := eventInterceptors[event.Type()]
 	if !ok || interceptors.eventMapper == nil {
 		return BaseEventFromRepo(event), nil
@@ -161,8 +194,9 @@ type reducer interface {
 // FilterToReducer filters the events based on the search query, appends all events to the reducer and calls it's reduce function
 func (es *Eventstore) FilterToReducer(ctx context.Context, searchQuery *SearchQueryBuilder, r reducer) error {
 	searchQuery.ensureInstanceID(ctx)
+	keys := make(personalDataKeys)
 	return es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
-		event, err := es.mapEvent(event)
+		event, err := es.mapEvent(ctx, keys, event)
 		if err != nil {
 			return err
 		}
@@ -209,7 +243,11 @@ type QueryReducer interface {
 
 // FilterToQueryReducer filters the events based on the search query of the query function,
 // appends all events to the reducer and calls it's reduce function
+// If r implements [Snapshotter] the filter resumes from the latest snapshot.
 func (es *Eventstore) FilterToQueryReducer(ctx context.Context, r QueryReducer) error {
+	if snapshotter, ok := r.(Snapshotter); ok && es.snapshotsEnabled() {
+		return es.filterToSnapshotter(ctx, snapshotter)
+	}
 	return es.FilterToReducer(ctx, r.Query(), r)
 }
 
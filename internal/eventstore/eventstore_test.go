@@ -1253,7 +1253,7 @@ func TestEventstore_mapEvents(t *testing.T) {
 				t.FailNow()
 			}
 
-			gotMappedEvents, err := es.mapEvents(tt.args.events)
+			gotMappedEvents, err := es.mapEvents(context.Background(), make(personalDataKeys), tt.args.events)
 			if (err != nil) != tt.res.wantErr {
 				t.Errorf("Eventstore.mapEvents() error = %v, wantErr %v", err, tt.res.wantErr)
 				return
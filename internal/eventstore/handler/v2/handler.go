@@ -28,6 +28,7 @@ type EventStore interface {
 	FilterToQueryReducer(ctx context.Context, reducer eventstore.QueryReducer) error
 	Filter(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error)
 	Push(ctx context.Context, cmds ...eventstore.Command) ([]eventstore.Event, error)
+	LatestSequence(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) (float64, error)
 }
 
 type Config struct {
@@ -62,6 +63,8 @@ type Handler struct {
 	triggeredInstancesSync sync.Map
 
 	triggerWithoutEvents Reduce
+
+	cacheInvalidations []func(ctx context.Context, aggregates []*eventstore.Aggregate)
 }
 
 var _ migration.Migration = (*Handler)(nil)
@@ -371,13 +374,23 @@ func (h *Handler) queryInstances(ctx context.Context) ([]string, error) {
 	return h.es.InstanceIDs(ctx, h.requeueEvery, false, query)
 }
 
+// existingInstances returns the instances of all shards
 func (h *Handler) existingInstances(ctx context.Context) ([]string, error) {
-	ai := existingInstances{}
-	if err := h.es.FilterToQueryReducer(ctx, &ai); err != nil {
-		return nil, err
+	var instances []string
+	for _, shard := range h.client.Shards() {
+		ai := existingInstances{}
+		if err := h.es.FilterToQueryReducer(database.WithShard(ctx, shard), &ai); err != nil {
+			return nil, err
+		}
+		// instances are found on multiple shards while they are moved
+		for _, instance := range ai {
+			if !slices.Contains(instances, instance) {
+				instances = append(instances, instance)
+			}
+		}
 	}
 
-	return ai, nil
+	return instances, nil
 }
 
 type triggerConfig struct {
@@ -405,9 +418,10 @@ func (h *Handler) Trigger(ctx context.Context, opts ...TriggerOpt) (_ context.Co
 		opt(config)
 	}
 
+	// the projection is up to date on the primary only, replicas might lag behind
 	cancel := h.lockInstance(ctx, config)
 	if cancel == nil {
-		return call.ResetTimestamp(ctx), nil
+		return database.WithPrimary(call.ResetTimestamp(ctx)), nil
 	}
 	defer cancel()
 
@@ -416,7 +430,7 @@ func (h *Handler) Trigger(ctx context.Context, opts ...TriggerOpt) (_ context.Co
 		h.log().OnError(err).Info("process events failed")
 		h.log().WithField("iteration", i).Debug("trigger iteration")
 		if !additionalIteration || err != nil {
-			return call.ResetTimestamp(ctx), err
+			return database.WithPrimary(call.ResetTimestamp(ctx)), err
 		}
 	}
 }
@@ -483,6 +497,7 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 	if err != nil {
 		return false, err
 	}
+	var executedStatements []*Statement
 	defer func() {
 		if err != nil && !errors.Is(err, &executionError{}) {
 			rollbackErr := tx.Rollback()
@@ -493,6 +508,9 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 		if err == nil {
 			err = commitErr
 		}
+		if commitErr == nil {
+			h.invalidateCaches(ctx, executedStatements)
+		}
 	}()
 
 	currentState, err := h.currentState(ctx, tx, config)
@@ -522,6 +540,7 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 	if lastProcessedIndex < 0 {
 		return false, err
 	}
+	executedStatements = statements[:lastProcessedIndex+1]
 
 	currentState.position = statements[lastProcessedIndex].Position
 	currentState.offset = statements[lastProcessedIndex].offset
@@ -534,6 +553,40 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 	return additionalIteration, err
 }
 
+// RegisterCacheInvalidation registers a function which is called with the aggregates
+// of all statements committed by the projection.
+// It must be called before the handler is started.
+func (h *Handler) RegisterCacheInvalidation(invalidate func(ctx context.Context, aggregates []*eventstore.Aggregate)) {
+	h.cacheInvalidations = append(h.cacheInvalidations, invalidate)
+}
+
+func (h *Handler) invalidateCaches(ctx context.Context, statements []*Statement) {
+	if len(h.cacheInvalidations) == 0 || len(statements) == 0 {
+		return
+	}
+	// the transaction context might already be timed out
+	ctx = context.WithoutCancel(ctx)
+
+	aggregates := make([]*eventstore.Aggregate, 0, len(statements))
+	for _, statement := range statements {
+		if slices.ContainsFunc(aggregates, func(aggregate *eventstore.Aggregate) bool {
+			return aggregate.InstanceID == statement.InstanceID &&
+				aggregate.Type == statement.AggregateType &&
+				aggregate.ID == statement.AggregateID
+		}) {
+			continue
+		}
+		aggregates = append(aggregates, &eventstore.Aggregate{
+			ID:         statement.AggregateID,
+			Type:       statement.AggregateType,
+			InstanceID: statement.InstanceID,
+		})
+	}
+	for _, invalidate := range h.cacheInvalidations {
+		invalidate(ctx, aggregates)
+	}
+}
+
 func (h *Handler) generateStatements(ctx context.Context, tx *sql.Tx, currentState *state) (_ []*Statement, additionalIteration bool, err error) {
 	if h.triggerWithoutEvents != nil {
 		stmt, err := h.triggerWithoutEvents(pseudo.NewScheduledEvent(ctx, time.Now(), currentState.instanceID))
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestHandler_invalidateCaches(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements []*Statement
+		want       []*eventstore.Aggregate
+	}{
+		{
+			name:       "no statements",
+			statements: nil,
+			want:       nil,
+		},
+		{
+			name: "aggregates deduplicated",
+			statements: []*Statement{
+				{
+					AggregateType: "org",
+					AggregateID:   "org1",
+					InstanceID:    "instance1",
+				},
+				{
+					AggregateType: "org",
+					AggregateID:   "org1",
+					InstanceID:    "instance1",
+				},
+				{
+					AggregateType: "org",
+					AggregateID:   "org1",
+					InstanceID:    "instance2",
+				},
+				{
+					AggregateType: "instance",
+					AggregateID:   "instance1",
+					InstanceID:    "instance1",
+				},
+			},
+			want: []*eventstore.Aggregate{
+				{
+					Type:       "org",
+					ID:         "org1",
+					InstanceID: "instance1",
+				},
+				{
+					Type:       "org",
+					ID:         "org1",
+					InstanceID: "instance2",
+				},
+				{
+					Type:       "instance",
+					ID:         "instance1",
+					InstanceID: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls [][]*eventstore.Aggregate
+			h := &Handler{}
+			for i := 0; i < 2; i++ {
+				h.RegisterCacheInvalidation(func(ctx context.Context, aggregates []*eventstore.Aggregate) {
+					if ctx.Err() != nil {
+						t.Errorf("context must not be canceled: %v", ctx.Err())
+					}
+					calls = append(calls, aggregates)
+				})
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			h.invalidateCaches(ctx, tt.statements)
+
+			if tt.want == nil {
+				if len(calls) != 0 {
+					t.Errorf("unexpected invalidation: %v", calls)
+				}
+				return
+			}
+			if len(calls) != 2 {
+				t.Fatalf("expected 2 invalidations, got %d", len(calls))
+			}
+			for _, got := range calls {
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("unexpected aggregates: want %v got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
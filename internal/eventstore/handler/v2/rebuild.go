@@ -0,0 +1,659 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// shadowSuffix is appended to the projection name to build the name of the shadow projection
+	shadowSuffix = "_shadow"
+	// retiredSuffix is appended to the tables replaced by the shadow tables until they are dropped
+	retiredSuffix = "_retired"
+
+	// rebuildHeartbeat is the interval the process running a rebuild refreshes its claim
+	rebuildHeartbeat = 10 * time.Second
+	// rebuildStaleAfter is the duration after which the claim of a rebuild without heartbeat can be taken over,
+	// e.g. if the process running it stopped
+	rebuildStaleAfter = time.Minute
+)
+
+var (
+	//go:embed rebuild_tables.sql
+	rebuildTablesStmt string
+	//go:embed rebuild_columns.sql
+	rebuildColumnsStmt string
+	//go:embed rebuild_indices.sql
+	rebuildIndicesStmt string
+	//go:embed rebuild_progress.sql
+	rebuildProgressStmt string
+	//go:embed rebuild_lock.sql
+	rebuildLockStmt string
+	//go:embed rebuild_claim.sql
+	rebuildClaimStmt string
+	//go:embed rebuild_heartbeat.sql
+	rebuildHeartbeatStmt string
+	//go:embed rebuild_finish.sql
+	rebuildFinishStmt string
+	//go:embed rebuild_state.sql
+	rebuildStateStmt string
+
+	// rebuildStateTables contain the state of the projections by projection name
+	rebuildStateTables = []string{"projections.current_states", "projections.failed_events2"}
+)
+
+// RebuildState is the state of the last rebuild of a projection, it's shared by all processes
+type RebuildState struct {
+	Running     bool
+	Err         error
+	InstanceIDs []string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+}
+
+// RebuildProgress is the progress of the shadow projection per instance
+type RebuildProgress struct {
+	Projection string
+	Instances  []*InstanceProgress
+}
+
+// CaughtUp returns true if the shadow projection of all instances reduced the latest event
+func (p *RebuildProgress) CaughtUp() bool {
+	for _, instance := range p.Instances {
+		if !instance.CaughtUp() {
+			return false
+		}
+	}
+	return true
+}
+
+type InstanceProgress struct {
+	InstanceID string
+	// Position of the last event reduced by the shadow projection
+	Position float64
+	// Head is the position of the latest event handled by the projection
+	Head float64
+}
+
+func (p *InstanceProgress) CaughtUp() bool {
+	return p.Position >= p.Head
+}
+
+// shadowProjection reduces the events of the projection into shadow tables
+type shadowProjection struct {
+	Projection
+}
+
+func (p *shadowProjection) Name() string {
+	return p.Projection.Name() + shadowSuffix
+}
+
+// Init implements [initializer] and creates the tables of the projection with the shadow name
+func (p *shadowProjection) Init() *handler.Check {
+	if check, ok := p.Projection.(initializer); ok {
+		return check.Init()
+	}
+	return new(handler.Check)
+}
+
+// shadow returns a handler with the same configuration which reduces into the shadow tables.
+// Caches are not invalidated by the shadow handler as it doesn't serve queries.
+func (h *Handler) shadow() *Handler {
+	return &Handler{
+		client:                h.client,
+		projection:            &shadowProjection{Projection: h.projection},
+		es:                    h.es,
+		bulkLimit:             h.bulkLimit,
+		eventTypes:            h.eventTypes,
+		maxFailureCount:       h.maxFailureCount,
+		retryFailedAfter:      h.retryFailedAfter,
+		requeueEvery:          h.requeueEvery,
+		handleActiveInstances: h.handleActiveInstances,
+		txDuration:            h.txDuration,
+		now:                   h.now,
+	}
+}
+
+// Rebuild reduces all events into shadow tables while the projection keeps serving queries from its tables.
+// As soon as the shadow tables caught up with the events, they replace the tables of the projection.
+// If instanceIDs are passed, only the rows of these instances are replaced, otherwise the tables are swapped.
+// progress is called after each iteration over the instances.
+//
+// The rebuild is claimed in the database, so only one rebuild of the projection runs across all processes.
+func (h *Handler) Rebuild(ctx context.Context, instanceIDs []string, progress func(*RebuildProgress)) error {
+	claim, err := h.claimRebuild(ctx, instanceIDs)
+	if err != nil {
+		return err
+	}
+	return h.rebuild(ctx, claim, instanceIDs, progress)
+}
+
+// StartRebuild claims the rebuild of the projection and runs it in the background,
+// the rebuild continues if ctx is canceled. Its state is returned by [Handler.RebuildState].
+func (h *Handler) StartRebuild(ctx context.Context, instanceIDs []string) error {
+	claim, err := h.claimRebuild(ctx, instanceIDs)
+	if err != nil {
+		return err
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		err := h.rebuild(ctx, claim, instanceIDs, nil)
+		h.log().OnError(err).Error("projection rebuild failed")
+	}()
+	return nil
+}
+
+// RebuildState returns the state of the last rebuild of the projection
+func (h *Handler) RebuildState(ctx context.Context) (*RebuildState, error) {
+	state := new(RebuildState)
+	err := h.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		var (
+			instanceIDs database.TextArray[string]
+			finishedAt  sql.NullTime
+			rebuildErr  sql.NullString
+		)
+		if err := row.Scan(&instanceIDs, &state.StartedAt, &finishedAt, &rebuildErr, &state.Running); err != nil {
+			return err
+		}
+		state.InstanceIDs = instanceIDs
+		state.FinishedAt = finishedAt.Time
+		if rebuildErr.Valid {
+			state.Err = errors.New(rebuildErr.String)
+		}
+		return nil
+	}, rebuildStateStmt, h.ProjectionName(), int(rebuildStaleAfter.Seconds()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, zerrors.ThrowNotFound(err, "V2-Rb5Nf", "Errors.ProjectionName.RebuildNotFound")
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Rb5St", "Errors.Internal")
+	}
+	return state, nil
+}
+
+// claimRebuild claims the rebuild of the projection and returns the start of the claimed rebuild,
+// an error is returned if another process runs a rebuild of the projection
+func (h *Handler) claimRebuild(ctx context.Context, instanceIDs []string) (claim time.Time, err error) {
+	if h.triggerWithoutEvents != nil {
+		return claim, zerrors.ThrowPreconditionFailed(nil, "V2-Rb9Xo", "Errors.ProjectionName.RebuildNotSupported")
+	}
+	err = h.inTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, rebuildClaimStmt, h.ProjectionName(), database.TextArray[string](instanceIDs), int(rebuildStaleAfter.Seconds())).
+			Scan(&claim)
+		if errors.Is(err, sql.ErrNoRows) {
+			return zerrors.ThrowPreconditionFailed(nil, "V2-Rb4Cl", "Errors.ProjectionName.RebuildRunning")
+		}
+		if err != nil {
+			return zerrors.ThrowInternal(err, "V2-Rb4Cn", "Errors.Internal")
+		}
+		return nil
+	})
+	return claim, err
+}
+
+// keepRebuildClaimed refreshes the heartbeat of the claimed rebuild until ctx is done.
+// The rebuild is canceled if the claim was taken over by another process.
+func (h *Handler) keepRebuildClaimed(ctx context.Context, claim time.Time, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(rebuildHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		res, err := h.client.ExecContext(ctx, rebuildHeartbeatStmt, h.ProjectionName(), claim)
+		if err != nil {
+			h.log().WithError(err).Warn("unable to refresh rebuild claim")
+			continue
+		}
+		if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+			cancel(zerrors.ThrowPreconditionFailed(nil, "V2-Rb4Hb", "Errors.ProjectionName.RebuildRunning"))
+			return
+		}
+	}
+}
+
+// finishRebuild releases the claim of the rebuild and stores its error
+func (h *Handler) finishRebuild(ctx context.Context, claim time.Time, rebuildErr error) {
+	var errText sql.NullString
+	if rebuildErr != nil {
+		errText = sql.NullString{String: rebuildErr.Error(), Valid: true}
+	}
+	_, err := h.client.ExecContext(ctx, rebuildFinishStmt, h.ProjectionName(), claim, errText)
+	h.log().OnError(err).Warn("unable to finish rebuild")
+}
+
+func (h *Handler) rebuild(ctx context.Context, claim time.Time, instanceIDs []string, progress func(*RebuildProgress)) (err error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	go h.keepRebuildClaimed(ctx, claim, cancel)
+	defer func() {
+		h.finishRebuild(context.WithoutCancel(ctx), claim, err)
+	}()
+
+	logging.WithFields("projection", h.ProjectionName(), "instances", instanceIDs).Info("projection rebuild started")
+	// remove the leftovers of a previous rebuild
+	if err = h.dropShadow(ctx); err != nil {
+		return err
+	}
+	shadow := h.shadow()
+	if err = shadow.Init(ctx); err != nil {
+		return err
+	}
+
+	instances := instanceIDs
+	if len(instances) == 0 {
+		instances, err = h.existingInstances(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	for {
+		for _, instance := range instances {
+			if _, err = shadow.Trigger(authz.WithInstanceID(ctx, instance)); err != nil {
+				return err
+			}
+		}
+		current, err := h.RebuildProgress(ctx, instances)
+		if err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(current)
+		}
+		if current.CaughtUp() {
+			break
+		}
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+	}
+
+	if len(instanceIDs) > 0 {
+		err = h.swapInstances(ctx, instanceIDs)
+	} else {
+		err = h.swapTables(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	h.invalidateInstances(ctx, instances)
+	logging.WithFields("projection", h.ProjectionName(), "instances", instanceIDs).Info("projection rebuild done")
+	return h.dropShadow(ctx)
+}
+
+// RebuildProgress returns the progress of the shadow projection of the instances.
+// The progress of all existing instances is returned if no instance ids are passed.
+func (h *Handler) RebuildProgress(ctx context.Context, instanceIDs []string) (_ *RebuildProgress, err error) {
+	if len(instanceIDs) == 0 {
+		instanceIDs, err = h.existingInstances(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	positions := make(map[string]float64, len(instanceIDs))
+	err = h.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var (
+				instanceID string
+				position   sql.NullFloat64
+			)
+			if err := rows.Scan(&instanceID, &position); err != nil {
+				return err
+			}
+			positions[instanceID] = position.Float64
+		}
+		return rows.Err()
+	}, rebuildProgressStmt, h.ProjectionName()+shadowSuffix, database.TextArray[string](instanceIDs))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Rb2Pq", "Errors.Internal")
+	}
+
+	progress := &RebuildProgress{
+		Projection: h.ProjectionName(),
+		Instances:  make([]*InstanceProgress, len(instanceIDs)),
+	}
+	for i, instanceID := range instanceIDs {
+		head, err := h.es.LatestSequence(authz.WithInstanceID(ctx, instanceID), h.headQuery())
+		if err != nil {
+			return nil, err
+		}
+		progress.Instances[i] = &InstanceProgress{
+			InstanceID: instanceID,
+			Position:   positions[instanceID],
+			Head:       head,
+		}
+	}
+	return progress, nil
+}
+
+// headQuery returns the query for the position of the latest event handled by the projection
+func (h *Handler) headQuery() *eventstore.SearchQueryBuilder {
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsMaxSequence).
+		AwaitOpenTransactions()
+	for aggregateType, eventTypes := range h.eventTypes {
+		builder = builder.
+			AddQuery().
+			AggregateTypes(aggregateType).
+			EventTypes(eventTypes...).
+			Builder()
+	}
+	return builder
+}
+
+// swapTables replaces the tables of the projection by the shadow tables.
+// The replaced tables are renamed in the transaction and dropped afterwards,
+// so that queries waiting for the lock of the tables are still able to finish.
+func (h *Handler) swapTables(ctx context.Context) error {
+	schema, table := splitProjectionName(h.ProjectionName())
+	shadowTable := table + shadowSuffix
+
+	var swapped []*projectionTable
+	err := h.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, rebuildLockStmt, h.ProjectionName(), database.TextArray[string](nil)); err != nil {
+			return zerrors.ThrowInternal(err, "V2-Rb4Lk", "Errors.Internal")
+		}
+		shadowTables, err := projectionTables(ctx, tx, schema, shadowTable)
+		if err != nil {
+			return err
+		}
+		for _, shadow := range shadowTables {
+			live := table + strings.TrimPrefix(shadow.name, shadowTable)
+			stmt := fmt.Sprintf("ALTER %[1]s IF EXISTS %[2]s.%[3]s RENAME TO %[4]s; ALTER %[1]s %[2]s.%[5]s RENAME TO %[3]s",
+				shadow.kind(), schema, live, live+retiredSuffix, shadow.name,
+			)
+			if _, err = tx.ExecContext(ctx, stmt); err != nil {
+				return zerrors.ThrowInternal(err, "V2-Rb5Sw", "Errors.Internal")
+			}
+			swapped = append(swapped, &projectionTable{name: live, isView: shadow.isView})
+		}
+		return renameStates(ctx, tx, h.ProjectionName(), nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = h.dropRetired(ctx); err != nil {
+		return err
+	}
+	// the names of the indices are derived from the table name
+	for _, swap := range swapped {
+		if swap.isView {
+			continue
+		}
+		err = h.renameIndices(ctx, schema, swap.name, shadowTable, table)
+		h.log().WithField("table", swap.name).OnError(err).Warn("unable to rename indices of rebuilt table")
+	}
+	return nil
+}
+
+// swapInstances replaces the rows of the instances in the tables of the projection by the rows of the shadow tables.
+func (h *Handler) swapInstances(ctx context.Context, instanceIDs []string) error {
+	schema, table := splitProjectionName(h.ProjectionName())
+	shadowTable := table + shadowSuffix
+	instances := database.TextArray[string](instanceIDs)
+
+	return h.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, rebuildLockStmt, h.ProjectionName(), instances); err != nil {
+			return zerrors.ThrowInternal(err, "V2-Rb6Lk", "Errors.Internal")
+		}
+		shadowTables, err := projectionTables(ctx, tx, schema, shadowTable)
+		if err != nil {
+			return err
+		}
+		// views are based on the tables
+		shadowTables = withoutViews(shadowTables)
+
+		// secondary tables reference the primary table, which is ordered first
+		for i := len(shadowTables) - 1; i >= 0; i-- {
+			live := table + strings.TrimPrefix(shadowTables[i].name, shadowTable)
+			if _, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s.%s WHERE instance_id = ANY($1)", schema, live), instances); err != nil {
+				return zerrors.ThrowInternal(err, "V2-Rb7Dl", "Errors.Internal")
+			}
+		}
+		for _, shadow := range shadowTables {
+			columns, err := tableColumns(ctx, tx, schema, shadow.name)
+			if err != nil {
+				return err
+			}
+			live := table + strings.TrimPrefix(shadow.name, shadowTable)
+			stmt := fmt.Sprintf("INSERT INTO %[1]s.%[2]s (%[3]s) SELECT %[3]s FROM %[1]s.%[4]s WHERE instance_id = ANY($1)",
+				schema, live, strings.Join(columns, ", "), shadow.name,
+			)
+			if _, err = tx.ExecContext(ctx, stmt, instances); err != nil {
+				return zerrors.ThrowInternal(err, "V2-Rb8Cp", "Errors.Internal")
+			}
+		}
+		return renameStates(ctx, tx, h.ProjectionName(), instanceIDs)
+	})
+}
+
+// dropShadow removes the shadow tables and the state of the shadow projection
+func (h *Handler) dropShadow(ctx context.Context) error {
+	schema, table := splitProjectionName(h.ProjectionName())
+	err := h.inTx(ctx, func(tx *sql.Tx) error {
+		tables, err := projectionTables(ctx, tx, schema, table+shadowSuffix)
+		if err != nil {
+			return err
+		}
+		if err = dropTables(ctx, tx, schema, tables); err != nil {
+			return err
+		}
+		for _, stateTable := range rebuildStateTables {
+			if _, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE projection_name = $1", stateTable), h.ProjectionName()+shadowSuffix); err != nil {
+				return zerrors.ThrowInternal(err, "V2-Rb9St", "Errors.Internal")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return h.dropRetired(ctx)
+}
+
+// dropRetired drops the tables replaced by the shadow tables
+func (h *Handler) dropRetired(ctx context.Context) error {
+	schema, table := splitProjectionName(h.ProjectionName())
+	return h.inTx(ctx, func(tx *sql.Tx) error {
+		tables, err := projectionTables(ctx, tx, schema, table)
+		if err != nil {
+			return err
+		}
+		retired := make([]*projectionTable, 0, len(tables))
+		for _, t := range tables {
+			if strings.HasSuffix(t.name, retiredSuffix) {
+				retired = append(retired, t)
+			}
+		}
+		return dropTables(ctx, tx, schema, retired)
+	})
+}
+
+// renameIndices replaces the prefix of the indices of the table
+func (h *Handler) renameIndices(ctx context.Context, schema, table, oldPrefix, newPrefix string) error {
+	var indices []string
+	err := h.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var index string
+			if err := rows.Scan(&index); err != nil {
+				return err
+			}
+			indices = append(indices, index)
+		}
+		return rows.Err()
+	}, rebuildIndicesStmt, schema, table)
+	if err != nil {
+		return err
+	}
+	for _, index := range indices {
+		if !strings.HasPrefix(index, oldPrefix) {
+			continue
+		}
+		_, err = h.client.ExecContext(ctx, fmt.Sprintf("ALTER INDEX %s.%s RENAME TO %s", schema, index, newPrefix+strings.TrimPrefix(index, oldPrefix)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidateInstances invalidates the cached objects of the instances after the rows were replaced
+func (h *Handler) invalidateInstances(ctx context.Context, instanceIDs []string) {
+	statements := make([]*Statement, len(instanceIDs))
+	for i, instanceID := range instanceIDs {
+		statements[i] = &Statement{
+			AggregateType: instance.AggregateType,
+			AggregateID:   instanceID,
+			InstanceID:    instanceID,
+		}
+	}
+	h.invalidateCaches(ctx, statements)
+}
+
+func (h *Handler) inTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-Rb0Tx", "Errors.Internal")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			h.log().OnError(rollbackErr).Debug("unable to rollback tx")
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = zerrors.ThrowInternal(commitErr, "V2-Rb1Cm", "Errors.Internal")
+		}
+	}()
+	return fn(tx)
+}
+
+// renameStates moves the state of the shadow projection to the projection.
+// The states of all instances are moved if no instance ids are passed.
+func renameStates(ctx context.Context, tx *sql.Tx, projectionName string, instanceIDs []string) error {
+	instances := database.TextArray[string](instanceIDs)
+	for _, stateTable := range rebuildStateTables {
+		_, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE projection_name = $1 AND ($2::TEXT[] IS NULL OR instance_id = ANY($2))", stateTable), projectionName, instances)
+		if err != nil {
+			return zerrors.ThrowInternal(err, "V2-Rb3Dl", "Errors.Internal")
+		}
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET projection_name = $1 WHERE projection_name = $2 AND ($3::TEXT[] IS NULL OR instance_id = ANY($3))", stateTable), projectionName, projectionName+shadowSuffix, instances)
+		if err != nil {
+			return zerrors.ThrowInternal(err, "V2-Rb3Up", "Errors.Internal")
+		}
+	}
+	return nil
+}
+
+type projectionTable struct {
+	name   string
+	isView bool
+}
+
+func (t *projectionTable) kind() string {
+	if t.isView {
+		return "VIEW"
+	}
+	return "TABLE"
+}
+
+// projectionTables returns the primary table followed by the secondary tables of the projection
+func projectionTables(ctx context.Context, tx *sql.Tx, schema, table string) ([]*projectionTable, error) {
+	rows, err := tx.QueryContext(ctx, rebuildTablesStmt, schema, table, strings.ReplaceAll(table, "_", `\_`)+`\_%`)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Rb1Tb", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	var tables []*projectionTable
+	for rows.Next() {
+		t := new(projectionTable)
+		if err = rows.Scan(&t.name, &t.isView); err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-Rb2Tb", "Errors.Internal")
+		}
+		tables = append(tables, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Rb3Tb", "Errors.Internal")
+	}
+	return tables, nil
+}
+
+func tableColumns(ctx context.Context, tx *sql.Tx, schema, table string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, rebuildColumnsStmt, schema, table)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Rb1Cl", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err = rows.Scan(&column); err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-Rb2Cl", "Errors.Internal")
+		}
+		columns = append(columns, `"`+column+`"`)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Rb3Cl", "Errors.Internal")
+	}
+	return columns, nil
+}
+
+// dropTables drops the views before the tables they are based on
+func dropTables(ctx context.Context, tx *sql.Tx, schema string, tables []*projectionTable) error {
+	var views, baseTables []string
+	for _, t := range tables {
+		if t.isView {
+			views = append(views, schema+"."+t.name)
+			continue
+		}
+		baseTables = append(baseTables, schema+"."+t.name)
+	}
+	if len(views) > 0 {
+		if _, err := tx.ExecContext(ctx, "DROP VIEW IF EXISTS "+strings.Join(views, ", ")+" CASCADE"); err != nil {
+			return zerrors.ThrowInternal(err, "V2-Rb1Dr", "Errors.Internal")
+		}
+	}
+	if len(baseTables) > 0 {
+		if _, err := tx.ExecContext(ctx, "DROP TABLE IF EXISTS "+strings.Join(baseTables, ", ")+" CASCADE"); err != nil {
+			return zerrors.ThrowInternal(err, "V2-Rb2Dr", "Errors.Internal")
+		}
+	}
+	return nil
+}
+
+func withoutViews(tables []*projectionTable) []*projectionTable {
+	baseTables := make([]*projectionTable, 0, len(tables))
+	for _, t := range tables {
+		if !t.isView {
+			baseTables = append(baseTables, t)
+		}
+	}
+	return baseTables
+}
+
+// splitProjectionName splits the projection name into schema and table
+func splitProjectionName(name string) (schema, table string) {
+	schema, table, found := strings.Cut(name, ".")
+	if !found {
+		return "public", name
+	}
+	return schema, table
+}
@@ -0,0 +1,25 @@
+INSERT INTO projections.rebuilds (
+    projection_name
+    , instance_ids
+    , started_at
+    , heartbeat
+    , finished_at
+    , error
+) VALUES (
+    $1
+    , $2
+    , NOW()
+    , NOW()
+    , NULL
+    , NULL
+) ON CONFLICT (projection_name) DO UPDATE SET
+    instance_ids = EXCLUDED.instance_ids
+    , started_at = EXCLUDED.started_at
+    , heartbeat = EXCLUDED.heartbeat
+    , finished_at = NULL
+    , error = NULL
+-- the rebuild is only claimed if the previous rebuild finished or its process stopped refreshing the heartbeat
+WHERE
+    projections.rebuilds.finished_at IS NOT NULL
+    OR projections.rebuilds.heartbeat < NOW() - ($3::INT * INTERVAL '1 second')
+RETURNING started_at
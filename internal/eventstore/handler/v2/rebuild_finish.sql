@@ -0,0 +1,6 @@
+UPDATE projections.rebuilds SET
+    finished_at = NOW()
+    , error = $3
+WHERE
+    projection_name = $1
+    AND started_at = $2
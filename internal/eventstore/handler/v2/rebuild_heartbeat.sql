@@ -0,0 +1,6 @@
+UPDATE projections.rebuilds SET
+    heartbeat = NOW()
+WHERE
+    projection_name = $1
+    AND started_at = $2
+    AND finished_at IS NULL
@@ -0,0 +1,7 @@
+SELECT
+    indexname
+FROM
+    pg_catalog.pg_indexes
+WHERE
+    schemaname = $1
+    AND tablename = $2
@@ -0,0 +1,8 @@
+SELECT
+    instance_id
+FROM
+    projections.current_states
+WHERE
+    projection_name = $1
+    AND ($2::TEXT[] IS NULL OR instance_id = ANY($2))
+FOR UPDATE
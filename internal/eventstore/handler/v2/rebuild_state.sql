@@ -0,0 +1,10 @@
+SELECT
+    instance_ids
+    , started_at
+    , finished_at
+    , error
+    , finished_at IS NULL AND heartbeat >= NOW() - ($2::INT * INTERVAL '1 second') AS running
+FROM
+    projections.rebuilds
+WHERE
+    projection_name = $1
@@ -0,0 +1,13 @@
+SELECT
+    table_name
+    , table_type = 'VIEW'
+FROM
+    information_schema.tables
+WHERE
+    table_schema = $1
+    AND (
+        table_name = $2
+        OR table_name LIKE $3
+    )
+ORDER BY
+    table_name
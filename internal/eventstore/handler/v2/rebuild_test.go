@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type initProjection struct {
+	projection
+	check *handler.Check
+}
+
+func (p *initProjection) Init() *handler.Check {
+	return p.check
+}
+
+func TestShadowProjection(t *testing.T) {
+	check := &handler.Check{
+		Executes: []func(handler.Executer, string) (bool, error){
+			func(handler.Executer, string) (bool, error) { return true, nil },
+		},
+	}
+	tests := []struct {
+		name       string
+		projection Projection
+		wantNoop   bool
+	}{
+		{
+			name:       "without init",
+			projection: &projection{name: "projections.test"},
+			wantNoop:   true,
+		},
+		{
+			name:       "with init",
+			projection: &initProjection{projection: projection{name: "projections.test"}, check: check},
+			wantNoop:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shadow := &shadowProjection{Projection: tt.projection}
+			if got := shadow.Name(); got != "projections.test_shadow" {
+				t.Errorf("unexpected name: want projections.test_shadow, got %s", got)
+			}
+			if got := shadow.Init().IsNoop(); got != tt.wantNoop {
+				t.Errorf("unexpected noop: want %v, got %v", tt.wantNoop, got)
+			}
+		})
+	}
+}
+
+func TestRebuildProgress_CaughtUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		instances []*InstanceProgress
+		want      bool
+	}{
+		{
+			name: "no instances",
+			want: true,
+		},
+		{
+			name: "all caught up",
+			instances: []*InstanceProgress{
+				{InstanceID: "instance1", Position: 10, Head: 10},
+				{InstanceID: "instance2", Position: 0, Head: 0},
+			},
+			want: true,
+		},
+		{
+			name: "one behind",
+			instances: []*InstanceProgress{
+				{InstanceID: "instance1", Position: 10, Head: 10},
+				{InstanceID: "instance2", Position: 5, Head: 10},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := &RebuildProgress{Projection: "projections.test", Instances: tt.instances}
+			if got := progress.CaughtUp(); got != tt.want {
+				t.Errorf("CaughtUp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_Rebuild_triggerWithoutEvents(t *testing.T) {
+	h := &Handler{
+		projection: &projection{name: "projections.test"},
+		triggerWithoutEvents: func(eventstore.Event) (*Statement, error) {
+			return nil, nil
+		},
+	}
+	err := h.Rebuild(context.Background(), nil, nil)
+	if !zerrors.IsPreconditionFailed(err) {
+		t.Errorf("expected precondition failed, got: %v", err)
+	}
+}
+
+func TestHandler_claimRebuild(t *testing.T) {
+	instances, err := database.TextArray[string]{"instance"}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	startedAt := time.Now()
+	tests := []struct {
+		name  string
+		mock  *mock.SQLMock
+		want  time.Time
+		isErr func(t *testing.T, err error)
+	}{
+		{
+			name: "running",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(rebuildClaimStmt,
+					mock.WithQueryArgs("projections.test", instances, int64(60)),
+					mock.WithQueryResult([]string{"started_at"}, nil),
+				),
+				mock.ExpectRollback(nil),
+			),
+			isErr: func(t *testing.T, err error) {
+				if !zerrors.IsPreconditionFailed(err) {
+					t.Errorf("expected precondition failed, got: %v", err)
+				}
+			},
+		},
+		{
+			name: "query fails",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(rebuildClaimStmt,
+					mock.WithQueryArgs("projections.test", instances, int64(60)),
+					mock.WithQueryErr(errors.New("query failed")),
+				),
+				mock.ExpectRollback(nil),
+			),
+			isErr: func(t *testing.T, err error) {
+				if !zerrors.IsInternal(err) {
+					t.Errorf("expected internal error, got: %v", err)
+				}
+			},
+		},
+		{
+			name: "claimed",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(rebuildClaimStmt,
+					mock.WithQueryArgs("projections.test", instances, int64(60)),
+					mock.WithQueryResult([]string{"started_at"}, [][]driver.Value{{startedAt}}),
+				),
+				mock.ExpectCommit(nil),
+			),
+			want: startedAt,
+		},
+	}
+	for _, tt := range tests {
+		if tt.isErr == nil {
+			tt.isErr = func(t *testing.T, err error) {
+				if err != nil {
+					t.Error("expected no error got:", err)
+				}
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				projection: &projection{name: "projections.test"},
+				client:     &database.DB{DB: tt.mock.DB},
+			}
+			got, err := h.claimRebuild(context.Background(), []string{"instance"})
+			tt.isErr(t, err)
+			if !got.Equal(tt.want) {
+				t.Errorf("expected claim %v, got: %v", tt.want, got)
+			}
+
+			tt.mock.Assert(t)
+		})
+	}
+}
+
+func TestHandler_swapInstances(t *testing.T) {
+	instances, err := database.TextArray[string]{"instance"}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		mock  *mock.SQLMock
+		isErr func(t *testing.T, err error)
+	}{
+		{
+			name: "lock fails",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(rebuildLockStmt,
+					mock.WithExecArgs("projections.test", instances),
+					mock.WithExecErr(errors.New("lock failed")),
+				),
+				mock.ExpectRollback(nil),
+			),
+			isErr: func(t *testing.T, err error) {
+				if !zerrors.IsInternal(err) {
+					t.Errorf("expected internal error, got: %v", err)
+				}
+			},
+		},
+		{
+			name: "rows swapped",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(rebuildLockStmt,
+					mock.WithExecArgs("projections.test", instances),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExpectQuery(rebuildTablesStmt,
+					mock.WithQueryArgs("projections", "test_shadow", `test\_shadow\_%`),
+					mock.WithQueryResult([]string{"table_name", "is_view"}, [][]driver.Value{
+						{"test_shadow", false},
+						{"test_shadow_secondary", false},
+						{"test_shadow_view", true},
+					}),
+				),
+				mock.ExcpectExec("DELETE FROM projections.test_secondary WHERE instance_id = ANY($1)",
+					mock.WithExecArgs(instances),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec("DELETE FROM projections.test WHERE instance_id = ANY($1)",
+					mock.WithExecArgs(instances),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExpectQuery(rebuildColumnsStmt,
+					mock.WithQueryArgs("projections", "test_shadow"),
+					mock.WithQueryResult([]string{"column_name"}, [][]driver.Value{{"id"}, {"instance_id"}}),
+				),
+				mock.ExcpectExec(`INSERT INTO projections.test ("id", "instance_id") SELECT "id", "instance_id" FROM projections.test_shadow WHERE instance_id = ANY($1)`,
+					mock.WithExecArgs(instances),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExpectQuery(rebuildColumnsStmt,
+					mock.WithQueryArgs("projections", "test_shadow_secondary"),
+					mock.WithQueryResult([]string{"column_name"}, [][]driver.Value{{"id"}, {"instance_id"}}),
+				),
+				mock.ExcpectExec(`INSERT INTO projections.test_secondary ("id", "instance_id") SELECT "id", "instance_id" FROM projections.test_shadow_secondary WHERE instance_id = ANY($1)`,
+					mock.WithExecArgs(instances),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec("DELETE FROM projections.current_states WHERE projection_name = $1 AND ($2::TEXT[] IS NULL OR instance_id = ANY($2))",
+					mock.WithExecArgs("projections.test", instances),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec("UPDATE projections.current_states SET projection_name = $1 WHERE projection_name = $2 AND ($3::TEXT[] IS NULL OR instance_id = ANY($3))",
+					mock.WithExecArgs("projections.test", "projections.test_shadow", instances),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec("DELETE FROM projections.failed_events2 WHERE projection_name = $1 AND ($2::TEXT[] IS NULL OR instance_id = ANY($2))",
+					mock.WithExecArgs("projections.test", instances),
+					mock.WithExecNoRowsAffected(),
+				),
+				mock.ExcpectExec("UPDATE projections.failed_events2 SET projection_name = $1 WHERE projection_name = $2 AND ($3::TEXT[] IS NULL OR instance_id = ANY($3))",
+					mock.WithExecArgs("projections.test", "projections.test_shadow", instances),
+					mock.WithExecNoRowsAffected(),
+				),
+				mock.ExpectCommit(nil),
+			),
+		},
+	}
+	for _, tt := range tests {
+		if tt.isErr == nil {
+			tt.isErr = func(t *testing.T, err error) {
+				if err != nil {
+					t.Error("expected no error got:", err)
+				}
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				projection: &projection{name: "projections.test"},
+				client:     &database.DB{DB: tt.mock.DB},
+			}
+			err := h.swapInstances(context.Background(), []string{"instance"})
+			tt.isErr(t, err)
+
+			tt.mock.Assert(t)
+		})
+	}
+}
@@ -539,6 +539,13 @@ func NewTextArrayContainsCond(column string, value string) Condition {
 	}
 }
 
+// NewOneOfTextCond returns a Condition that checks if the text column equals one of the values
+func NewOneOfTextCond(column string, values []string) Condition {
+	return func(param string) (string, []any) {
+		return column + " = ANY(" + param + ")", []any{database.TextArray[string](values)}
+	}
+}
+
 // Not is a function and not a method, so that calling it is well readable
 // For example conditions := []Condition{ Not(NewTextArrayContainsCond())}
 func Not(condition Condition) Condition {
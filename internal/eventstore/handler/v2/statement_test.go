@@ -1557,6 +1557,19 @@ func Test_columnsToWhere(t *testing.T) {
 				values: []interface{}{database.TextArray[string]{"val1"}},
 			},
 		},
+		{
+			name: "one of text",
+			args: args{
+				conds: []Condition{
+					NewOneOfTextCond("col1", []string{"val1", "val2"}),
+				},
+				paramOffset: 1,
+			},
+			want: want{
+				wheres: []string{"(col1 = ANY($1))"},
+				values: []interface{}{database.TextArray[string]{"val1", "val2"}},
+			},
+		},
 		{
 			name: "not",
 			args: args{
@@ -0,0 +1,338 @@
+package eventstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const personalDataKeyLength = 32
+
+var personalDataFields map[EventType][]string
+
+// RegisterPersonalData registers the top level fields of the payload of the event type which contain personal data.
+// The values of the fields are encrypted with the data key of the aggregate before the event is pushed.
+// As soon as the key is deleted by a [PersonalDataEraser] the fields are mapped as null.
+//
+// Upcasters of the event type receive the encrypted values of the fields.
+func RegisterPersonalData(eventType EventType, fields ...string) {
+	if eventType == "" || len(fields) == 0 {
+		return
+	}
+	if personalDataFields == nil {
+		personalDataFields = make(map[EventType][]string)
+	}
+	registered := personalDataFields[eventType]
+	for _, field := range fields {
+		if !slices.Contains(registered, field) {
+			registered = append(registered, field)
+		}
+	}
+	personalDataFields[eventType] = registered
+}
+
+// PersonalDataFields returns the registered fields of the event type containing personal data
+func PersonalDataFields(eventType EventType) []string {
+	return personalDataFields[eventType]
+}
+
+// PersonalDataEventTypes returns the event types containing personal data
+func PersonalDataEventTypes() []EventType {
+	types := make([]EventType, 0, len(personalDataFields))
+	for eventType := range personalDataFields {
+		types = append(types, eventType)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// PersonalDataEraser is implemented by commands which erase the personal data of their aggregate.
+// The data key of the aggregate is deleted in the same transaction as the command is pushed.
+//
+// Snapshots of the aggregate are deleted as well,
+// so write models containing personal data must use the aggregate id as suffix of their [Snapshotter.SnapshotKey].
+type PersonalDataEraser interface {
+	Command
+	ErasesPersonalData() bool
+}
+
+// ErasesPersonalData returns true if the command implements [PersonalDataEraser] and erases the personal data
+func ErasesPersonalData(command Command) bool {
+	eraser, ok := command.(PersonalDataEraser)
+	return ok && eraser.ErasesPersonalData()
+}
+
+// PersonalDataErasure records which personal data of an aggregate was erased
+type PersonalDataErasure struct {
+	InstanceID    string
+	AggregateType AggregateType
+	AggregateID   string
+	ErasedAt      time.Time
+	ErasedBy      string
+	// Events is the count of the events per event type whose personal data was erased
+	Events map[EventType]uint64
+	// PlaintextEvents is the count of the events per event type which were pushed before the personal data was encrypted,
+	// their personal data is still readable
+	PlaintextEvents map[EventType]uint64
+}
+
+// PersonalDataStorage stores the data keys of the aggregates.
+// The data keys are encrypted by the [crypto.EncryptionAlgorithm] passed in the [Config].
+type PersonalDataStorage interface {
+	// PersonalDataKey returns the data key of the aggregate or nil if the aggregate has none
+	PersonalDataKey(ctx context.Context, instanceID, aggregateID string) (*crypto.CryptoValue, error)
+	// AddPersonalDataKey stores the data key if the aggregate has none and returns the stored data key
+	AddPersonalDataKey(ctx context.Context, instanceID, aggregateID string, key *crypto.CryptoValue) (*crypto.CryptoValue, error)
+	// PersonalDataErasures returns the erasures of the instance, newest first
+	PersonalDataErasures(ctx context.Context, instanceID string) ([]*PersonalDataErasure, error)
+}
+
+// PersonalDataErasures returns the erasures of the personal data of the instance
+func (es *Eventstore) PersonalDataErasures(ctx context.Context, instanceID string) ([]*PersonalDataErasure, error) {
+	if es.personalDataStorage == nil {
+		return nil, zerrors.ThrowUnimplemented(nil, "V2-Pd1Nx", "Errors.Eventstore.PersonalDataNotSupported")
+	}
+	return es.personalDataStorage.PersonalDataErasures(ctx, instanceID)
+}
+
+func (es *Eventstore) personalDataEnabled() bool {
+	return es.personalDataStorage != nil && es.personalDataKeyAlgorithm != nil
+}
+
+// personalDataKeys caches the decrypted data keys per aggregate during a single push or filter,
+// a nil key means the aggregate has no key (anymore).
+type personalDataKeys map[string][]byte
+
+func (es *Eventstore) personalDataKey(ctx context.Context, keys personalDataKeys, aggregate *Aggregate, create bool) ([]byte, error) {
+	cacheKey := aggregate.InstanceID + ":" + aggregate.ID
+	if key, ok := keys[cacheKey]; ok && (key != nil || !create) {
+		return key, nil
+	}
+	if !es.personalDataEnabled() {
+		return nil, nil
+	}
+	stored, err := es.personalDataStorage.PersonalDataKey(ctx, aggregate.InstanceID, aggregate.ID)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil && create {
+		stored, err = es.newPersonalDataKey(ctx, aggregate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if stored == nil {
+		keys[cacheKey] = nil
+		return nil, nil
+	}
+	key, err := crypto.Decrypt(stored, es.personalDataKeyAlgorithm)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Pd2Dk", "Errors.Internal")
+	}
+	keys[cacheKey] = key
+	return key, nil
+}
+
+func (es *Eventstore) newPersonalDataKey(ctx context.Context, aggregate *Aggregate) (*crypto.CryptoValue, error) {
+	key := make([]byte, personalDataKeyLength)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Pd3Gk", "Errors.Internal")
+	}
+	encrypted, err := crypto.Encrypt(key, es.personalDataKeyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	return es.personalDataStorage.AddPersonalDataKey(ctx, aggregate.InstanceID, aggregate.ID, encrypted)
+}
+
+// personalDataCommand replaces the payload of the command with the encrypted payload
+type personalDataCommand struct {
+	Command
+	payload json.RawMessage
+}
+
+// Payload implements [Command]
+func (c *personalDataCommand) Payload() any {
+	return c.payload
+}
+
+// encryptPersonalData encrypts the registered fields of the payloads of the commands
+func (es *Eventstore) encryptPersonalData(ctx context.Context, keys personalDataKeys, cmds []Command) ([]Command, error) {
+	if !es.personalDataEnabled() {
+		return cmds, nil
+	}
+	encrypted := make([]Command, len(cmds))
+	for i, cmd := range cmds {
+		encrypted[i] = cmd
+		fields := personalDataFields[cmd.Type()]
+		if len(fields) == 0 || cmd.Payload() == nil {
+			continue
+		}
+		payload, err := json.Marshal(cmd.Payload())
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-Pd4Ma", "Errors.Internal")
+		}
+		var key []byte
+		payload, err = mapPersonalData(payload, fields, func(value json.RawMessage) (_ json.RawMessage, err error) {
+			if _, ok := encryptedPersonalDataValue(value); ok || bytes.Equal(value, nullValue) {
+				return value, nil
+			}
+			if key == nil {
+				if key, err = es.personalDataKey(ctx, keys, cmd.Aggregate(), true); err != nil {
+					return nil, err
+				}
+			}
+			return encryptPersonalDataValue(key, value)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if payload == nil {
+			continue
+		}
+		encrypted[i] = &personalDataCommand{Command: cmd, payload: payload}
+	}
+	return encrypted, nil
+}
+
+// decryptPersonalData returns the event with the decrypted fields of the payload.
+// The fields are null if the data key of the aggregate was erased.
+func (es *Eventstore) decryptPersonalData(ctx context.Context, keys personalDataKeys, event Event) (Event, error) {
+	fields := personalDataFields[event.Type()]
+	if len(fields) == 0 || len(event.DataAsBytes()) == 0 {
+		return event, nil
+	}
+	var (
+		key    []byte
+		loaded bool
+	)
+	payload, err := mapPersonalData(event.DataAsBytes(), fields, func(value json.RawMessage) (_ json.RawMessage, err error) {
+		ciphertext, ok := encryptedPersonalDataValue(value)
+		if !ok {
+			// pushed before the personal data was encrypted
+			return value, nil
+		}
+		if !loaded {
+			if key, err = es.personalDataKey(ctx, keys, event.Aggregate(), false); err != nil {
+				return nil, err
+			}
+			loaded = true
+		}
+		if key == nil {
+			return nullValue, nil
+		}
+		value, err = decryptPersonalDataValue(key, ciphertext)
+		if err != nil {
+			logging.WithFields("type", event.Type(), "aggregate", event.Aggregate().ID).WithError(err).Warn("unable to decrypt personal data")
+			return nullValue, nil
+		}
+		return value, nil
+	})
+	if err != nil || payload == nil {
+		return event, err
+	}
+	return &payloadEvent{
+		Event:    event,
+		payload:  payload,
+		revision: event.Revision(),
+	}, nil
+}
+
+var nullValue = json.RawMessage("null")
+
+// mapPersonalData replaces the values of the fields in the payload
+// nil is returned if the payload contains none of the fields
+func mapPersonalData(payload []byte, fields []string, mapValue func(json.RawMessage) (json.RawMessage, error)) ([]byte, error) {
+	data := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Pd5Um", "Errors.Internal")
+	}
+	var mapped bool
+	for _, field := range fields {
+		value, ok := data[field]
+		if !ok {
+			continue
+		}
+		value, err := mapValue(value)
+		if err != nil {
+			return nil, err
+		}
+		data[field] = value
+		mapped = true
+	}
+	if !mapped {
+		return nil, nil
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Pd6Ma", "Errors.Internal")
+	}
+	return payload, nil
+}
+
+// encryptedPersonalData is the stored value of a field containing personal data
+type encryptedPersonalData struct {
+	Ciphertext []byte `json:"encryptedPersonalData"`
+}
+
+func encryptedPersonalDataValue(value json.RawMessage) ([]byte, bool) {
+	if len(value) == 0 || value[0] != '{' {
+		return nil, false
+	}
+	var encrypted encryptedPersonalData
+	if err := json.Unmarshal(value, &encrypted); err != nil || len(encrypted.Ciphertext) == 0 {
+		return nil, false
+	}
+	return encrypted.Ciphertext, true
+}
+
+func encryptPersonalDataValue(key []byte, value json.RawMessage) (json.RawMessage, error) {
+	gcm, err := personalDataCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Pd7Nc", "Errors.Internal")
+	}
+	encrypted, err := json.Marshal(&encryptedPersonalData{Ciphertext: gcm.Seal(nonce, nonce, value, nil)})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Pd8Ma", "Errors.Internal")
+	}
+	return encrypted, nil
+}
+
+func decryptPersonalDataValue(key, ciphertext []byte) (json.RawMessage, error) {
+	gcm, err := personalDataCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, zerrors.ThrowInternal(nil, "V2-Pd9Ln", "ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func personalDataCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-PdACi", "Errors.Internal")
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-PdBGc", "Errors.Internal")
+	}
+	return gcm, nil
+}
@@ -0,0 +1,215 @@
+package eventstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+// testKeyAlgorithm stores the data keys unencrypted
+type testKeyAlgorithm struct{}
+
+func (testKeyAlgorithm) Algorithm() string                              { return "test" }
+func (testKeyAlgorithm) EncryptionKeyID() string                        { return "testKey" }
+func (testKeyAlgorithm) DecryptionKeyIDs() []string                     { return []string{"testKey"} }
+func (testKeyAlgorithm) Encrypt(value []byte) ([]byte, error)           { return value, nil }
+func (testKeyAlgorithm) Decrypt(value []byte, _ string) ([]byte, error) { return value, nil }
+func (testKeyAlgorithm) DecryptString(value []byte, _ string) (string, error) {
+	return string(value), nil
+}
+
+type testPersonalDataStorage struct {
+	keys map[string]*crypto.CryptoValue
+}
+
+func (s *testPersonalDataStorage) PersonalDataKey(_ context.Context, instanceID, aggregateID string) (*crypto.CryptoValue, error) {
+	return s.keys[instanceID+":"+aggregateID], nil
+}
+
+func (s *testPersonalDataStorage) AddPersonalDataKey(_ context.Context, instanceID, aggregateID string, key *crypto.CryptoValue) (*crypto.CryptoValue, error) {
+	if stored, ok := s.keys[instanceID+":"+aggregateID]; ok {
+		return stored, nil
+	}
+	s.keys[instanceID+":"+aggregateID] = key
+	return key, nil
+}
+
+func (s *testPersonalDataStorage) PersonalDataErasures(context.Context, string) ([]*PersonalDataErasure, error) {
+	return nil, nil
+}
+
+// storingPusher stores the pushed events so they can be filtered
+type storingPusher struct {
+	testQuerier
+}
+
+func (repo *storingPusher) Push(_ context.Context, commands ...Command) ([]Event, error) {
+	events := make([]Event, len(commands))
+	for i, command := range commands {
+		payload, err := json.Marshal(command.Payload())
+		if err != nil {
+			return nil, err
+		}
+		events[i] = &BaseEvent{
+			Seq:       uint64(len(repo.events) + 1),
+			Creation:  time.Now(),
+			EventType: command.Type(),
+			Data:      payload,
+			User:      command.Creator(),
+			Agg:       command.Aggregate(),
+		}
+		repo.events = append(repo.events, events[i])
+	}
+	return events, nil
+}
+
+type personalDataPayload struct {
+	Name     string  `json:"name,omitempty"`
+	Email    string  `json:"email,omitempty"`
+	Country  *string `json:"country,omitempty"`
+	Language string  `json:"language,omitempty"`
+}
+
+func TestEventstore_personalData(t *testing.T) {
+	eventType := EventType("test.personal_data")
+	RegisterPersonalData(eventType, "name", "email", "country")
+	t.Cleanup(func() { delete(personalDataFields, eventType) })
+
+	country := "CH"
+	newCommand := func(aggregateID string) Command {
+		cmd := newTestEvent(aggregateID, "", func() interface{} {
+			return &personalDataPayload{Name: "gigi", Email: "gigi@zitadel.com", Country: &country, Language: "de"}
+		}, false)
+		cmd.BaseEvent.EventType = eventType
+		return cmd
+	}
+	unmarshal := func(t *testing.T, event Event) *personalDataPayload {
+		payload := new(personalDataPayload)
+		if err := event.Unmarshal(payload); err != nil {
+			t.Fatalf("unable to unmarshal payload: %v", err)
+		}
+		return payload
+	}
+
+	storage := &testPersonalDataStorage{keys: make(map[string]*crypto.CryptoValue)}
+	repo := &storingPusher{testQuerier: testQuerier{t: t}}
+	es := NewEventstore(&Config{
+		Pusher:                   repo,
+		Querier:                  repo,
+		PersonalDataStorage:      storage,
+		PersonalDataKeyAlgorithm: testKeyAlgorithm{},
+	})
+	ctx := context.Background()
+
+	pushed, err := es.Push(ctx, newCommand("user1"), newCommand("user2"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(storage.keys) != 2 {
+		t.Fatalf("expected a data key per aggregate, got %d", len(storage.keys))
+	}
+
+	t.Run("stored encrypted", func(t *testing.T) {
+		stored := repo.events[0].DataAsBytes()
+		for _, plain := range []string{"gigi", "CH"} {
+			if bytes.Contains(stored, []byte(plain)) {
+				t.Errorf("personal data %q stored unencrypted: %s", plain, stored)
+			}
+		}
+		if !bytes.Contains(stored, []byte(`"language":"de"`)) {
+			t.Errorf("unregistered field must not be encrypted: %s", stored)
+		}
+	})
+
+	t.Run("push returns decrypted", func(t *testing.T) {
+		payload := unmarshal(t, pushed[0])
+		if payload.Name != "gigi" || payload.Email != "gigi@zitadel.com" || payload.Country == nil || *payload.Country != "CH" {
+			t.Errorf("unexpected payload: %+v", payload)
+		}
+	})
+
+	t.Run("filter returns decrypted", func(t *testing.T) {
+		events, err := es.Filter(ctx, NewSearchQueryBuilder(ColumnsEvent).InstanceID("instanceID"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, event := range events {
+			if payload := unmarshal(t, event); payload.Name != "gigi" || payload.Language != "de" {
+				t.Errorf("unexpected payload: %+v", payload)
+			}
+		}
+	})
+
+	t.Run("erased", func(t *testing.T) {
+		delete(storage.keys, repo.events[0].Aggregate().InstanceID+":user1")
+		events, err := es.Filter(ctx, NewSearchQueryBuilder(ColumnsEvent).InstanceID("instanceID"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		erased := unmarshal(t, events[0])
+		if erased.Name != "" || erased.Email != "" || erased.Country != nil || erased.Language != "de" {
+			t.Errorf("personal data not erased: %+v", erased)
+		}
+		if !bytes.Contains(events[0].DataAsBytes(), []byte(`"name":null`)) {
+			t.Errorf("erased field must be null: %s", events[0].DataAsBytes())
+		}
+		if payload := unmarshal(t, events[1]); payload.Name != "gigi" {
+			t.Errorf("personal data of other aggregate erased: %+v", payload)
+		}
+	})
+
+	t.Run("unencrypted", func(t *testing.T) {
+		repo.events = []Event{&BaseEvent{
+			EventType: eventType,
+			Agg:       &Aggregate{ID: "user3", InstanceID: "instanceID"},
+			Data:      []byte(`{"name":"gigi"}`),
+		}}
+		events, err := es.Filter(ctx, NewSearchQueryBuilder(ColumnsEvent).InstanceID("instanceID"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if payload := unmarshal(t, events[0]); payload.Name != "gigi" {
+			t.Errorf("unexpected payload: %+v", payload)
+		}
+	})
+}
+
+func TestEventstore_personalData_disabled(t *testing.T) {
+	eventType := EventType("test.personal_data.disabled")
+	RegisterPersonalData(eventType, "name")
+	t.Cleanup(func() { delete(personalDataFields, eventType) })
+
+	cmd := newTestEvent("user1", "", func() interface{} { return &personalDataPayload{Name: "gigi"} }, false)
+	cmd.BaseEvent.EventType = eventType
+	repo := &storingPusher{testQuerier: testQuerier{t: t}}
+	es := NewEventstore(&Config{Pusher: repo, Querier: repo})
+
+	if _, err := es.Push(context.Background(), cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored := string(repo.events[0].DataAsBytes()); stored != `{"name":"gigi"}` {
+		t.Errorf("unexpected payload: %s", stored)
+	}
+	if _, err := es.PersonalDataErasures(context.Background(), "instanceID"); err == nil {
+		t.Error("expected error without storage")
+	}
+}
+
+func TestErasesPersonalData(t *testing.T) {
+	if ErasesPersonalData(newTestEvent("user1", "", nil, false)) {
+		t.Error("command must not erase personal data")
+	}
+	if !ErasesPersonalData(&erasingCommand{newTestEvent("user1", "", nil, false)}) {
+		t.Error("command must erase personal data")
+	}
+}
+
+type erasingCommand struct {
+	*testEvent
+}
+
+func (*erasingCommand) ErasesPersonalData() bool { return true }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -270,11 +271,21 @@ func (db *CRDB) LatestSequence(ctx context.Context, searchQuery *eventstore.Sear
 }
 
 // InstanceIDs returns the instance ids found by the search query
+// InstanceIDs returns the instance ids found on all shards,
+// instances are found on multiple shards while they are moved
 func (db *CRDB) InstanceIDs(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder) ([]string, error) {
 	var ids []string
-	err := query(ctx, db, searchQuery, &ids, false)
-	if err != nil {
-		return nil, err
+	for _, shard := range db.DB.Shards() {
+		var shardIDs []string
+		err := query(database.WithShard(ctx, shard), db, searchQuery, &shardIDs, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range shardIDs {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
 	}
 	return ids, nil
 }
@@ -324,6 +335,7 @@ func (db *CRDB) eventQuery(useV1 bool) string {
 		", aggregate_type" +
 		", aggregate_id" +
 		", revision" +
+		", payload_revision" +
 		" FROM eventstore.events2"
 }
 
@@ -207,6 +207,7 @@ func eventsScanner(useV1 bool) func(scanner scan, dest interface{}) (err error)
 				&event.AggregateType,
 				&event.AggregateID,
 				&revision,
+				&event.PayloadRevision,
 			)
 			event.Version = eventstore.Version("v" + strconv.Itoa(int(revision)))
 		}
@@ -0,0 +1,218 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/logging"
+)
+
+// Snapshotter is implemented by write models which opt in to snapshots.
+// The state of the write model is stored after it reduced enough events,
+// following filters restore the state and only query the events after the position of the snapshot.
+//
+// The query of the write model must always return the same events for the same [Snapshotter.SnapshotKey].
+// [Snapshotter.SnapshotVersion] must be increased as soon as the reducer or the state changes,
+// snapshots of other versions are ignored.
+type Snapshotter interface {
+	QueryReducer
+	// SnapshotType identifies the write model, e.g. "user.human"
+	SnapshotType() string
+	// SnapshotKey identifies the snapshot of the write model inside an instance
+	SnapshotKey() string
+	// SnapshotVersion is the version of the reducer
+	SnapshotVersion() uint16
+	// MarshalSnapshot returns the state of the write model
+	// The fields of the embedded [WriteModel] are stored by the eventstore.
+	MarshalSnapshot() ([]byte, error)
+	// UnmarshalSnapshot restores the state returned by [Snapshotter.MarshalSnapshot]
+	// The write model must not be changed if an error is returned.
+	UnmarshalSnapshot(data []byte) error
+	// snapshotWriteModel is implemented by embedding [WriteModel]
+	snapshotWriteModel() *WriteModel
+}
+
+// Snapshot is the stored state of a [Snapshotter] after reducing all events up to Position
+type Snapshot struct {
+	InstanceID string
+	Type       string
+	Key        string
+	Version    uint16
+	Position   float64
+	Payload    []byte
+}
+
+// SnapshotStorage stores the latest snapshot per write model
+type SnapshotStorage interface {
+	// Snapshot returns the latest snapshot or nil if none was found
+	Snapshot(ctx context.Context, instanceID, snapshotType, key string) (*Snapshot, error)
+	// SetSnapshot stores the snapshot if it's newer than the stored one or the version changed
+	SetSnapshot(ctx context.Context, snapshot *Snapshot) error
+}
+
+type SnapshotConfig struct {
+	// Every defines the minimum count of events reduced since the last snapshot before a new snapshot is stored
+	// Snapshots are disabled if zero
+	Every uint32
+	// MinEventAge is the minimum age of the events included in a snapshot.
+	// Younger events might still be committed with a lower position by concurrent transactions,
+	// so it must be greater than the PushTimeout.
+	MinEventAge time.Duration
+}
+
+// snapshotPayload is the stored payload of a [Snapshot]
+type snapshotPayload struct {
+	AggregateID       string          `json:"aggregateId,omitempty"`
+	ResourceOwner     string          `json:"resourceOwner,omitempty"`
+	InstanceID        string          `json:"instanceId,omitempty"`
+	ProcessedSequence uint64          `json:"processedSequence,omitempty"`
+	ChangeDate        time.Time       `json:"changeDate,omitempty"`
+	State             json.RawMessage `json:"state"`
+}
+
+func (wm *WriteModel) snapshotWriteModel() *WriteModel {
+	return wm
+}
+
+func (es *Eventstore) snapshotsEnabled() bool {
+	return es.snapshotStorage != nil && es.snapshots.Every > 0
+}
+
+// snapshotCompatible checks if the query returns all events of a single instance in ascending order,
+// otherwise the state after reducing the events can't be snapshotted.
+func snapshotCompatible(query *SearchQueryBuilder) bool {
+	return query.GetInstanceID() != nil &&
+		len(query.GetInstanceIDs()) == 0 &&
+		!query.GetDesc() &&
+		query.GetLimit() == 0 &&
+		query.GetOffset() == 0 &&
+		query.GetTx() == nil &&
+		query.GetPositionAfter() == 0 &&
+		query.GetEventSequenceGreater() == 0 &&
+		query.GetCreationDateAfter().IsZero() &&
+		query.GetCreationDateBefore().IsZero()
+}
+
+// filterToSnapshotter restores the latest compatible snapshot of r and reduces the events after it.
+// A new snapshot is stored if at least [SnapshotConfig.Every] events were reduced.
+// Failures of the snapshot storage are logged and the events are reduced without snapshot.
+func (es *Eventstore) filterToSnapshotter(ctx context.Context, r Snapshotter) error {
+	query := r.Query()
+	query.ensureInstanceID(ctx)
+	if !snapshotCompatible(query) {
+		return es.FilterToReducer(ctx, query, r)
+	}
+	instanceID := *query.GetInstanceID()
+	// the key is computed before reducing because the reducer might change the fields it's based on
+	key := r.SnapshotKey()
+
+	var position float64
+	snapshot, err := es.snapshotStorage.Snapshot(ctx, instanceID, r.SnapshotType(), key)
+	logging.WithFields("type", r.SnapshotType(), "key", key).OnError(err).Warn("unable to load snapshot")
+	if err == nil && snapshot != nil && snapshot.Version == r.SnapshotVersion() {
+		err = restoreSnapshot(r, snapshot)
+		logging.WithFields("type", r.SnapshotType(), "key", key).OnError(err).Warn("unable to restore snapshot")
+		if err == nil {
+			position = snapshot.Position
+			query.PositionAfter(position)
+		}
+	}
+
+	var (
+		reduced       uint32
+		lastPosition  float64
+		lastCreatedAt time.Time
+		captured      bool
+		pending       *Snapshot
+	)
+	settledBefore := time.Now().Add(-es.snapshots.MinEventAge)
+	keys := make(personalDataKeys)
+	err = es.querier.FilterToReducer(ctx, query, func(event Event) error {
+		// the position of the database might be more precise than the position of the snapshot
+		if event.Position() <= position {
+			return nil
+		}
+		// the state is captured before the first event which might not be settled yet.
+		// Events pushed by the same transaction share their position,
+		// so the state is only captured after the last event of a transaction,
+		// otherwise the remaining events of the transaction would be skipped after the restore.
+		if !captured && reduced >= es.snapshots.Every && event.CreatedAt().After(settledBefore) {
+			if event.Position() > lastPosition && !lastCreatedAt.After(settledBefore) {
+				pending = captureSnapshot(r, instanceID, key, lastPosition)
+			}
+			captured = true
+		}
+		lastPosition, lastCreatedAt = event.Position(), event.CreatedAt()
+		event, err := es.mapEvent(ctx, keys, event)
+		if err != nil {
+			return err
+		}
+		r.AppendEvents(event)
+		if err = r.Reduce(); err != nil {
+			return err
+		}
+		reduced++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !captured && reduced >= es.snapshots.Every && !lastCreatedAt.After(settledBefore) {
+		pending = captureSnapshot(r, instanceID, key, lastPosition)
+	}
+	if pending != nil {
+		err = es.snapshotStorage.SetSnapshot(ctx, pending)
+		logging.WithFields("type", pending.Type, "key", pending.Key).OnError(err).Warn("unable to store snapshot")
+	}
+	return nil
+}
+
+// captureSnapshot returns the current state of r or nil if it cannot be marshalled
+func captureSnapshot(r Snapshotter, instanceID, key string, position float64) *Snapshot {
+	state, err := r.MarshalSnapshot()
+	if err != nil {
+		logging.WithFields("type", r.SnapshotType(), "key", key).WithError(err).Warn("unable to marshal snapshot")
+		return nil
+	}
+	wm := r.snapshotWriteModel()
+	payload, err := json.Marshal(&snapshotPayload{
+		AggregateID:       wm.AggregateID,
+		ResourceOwner:     wm.ResourceOwner,
+		InstanceID:        wm.InstanceID,
+		ProcessedSequence: wm.ProcessedSequence,
+		ChangeDate:        wm.ChangeDate,
+		State:             state,
+	})
+	if err != nil {
+		logging.WithFields("type", r.SnapshotType(), "key", key).WithError(err).Warn("unable to marshal snapshot")
+		return nil
+	}
+	return &Snapshot{
+		InstanceID: instanceID,
+		Type:       r.SnapshotType(),
+		Key:        key,
+		Version:    r.SnapshotVersion(),
+		Position:   position,
+		Payload:    payload,
+	}
+}
+
+// restoreSnapshot sets the state of r to the state of the snapshot.
+// r is not changed if an error is returned.
+func restoreSnapshot(r Snapshotter, snapshot *Snapshot) error {
+	payload := new(snapshotPayload)
+	if err := json.Unmarshal(snapshot.Payload, payload); err != nil {
+		return err
+	}
+	if err := r.UnmarshalSnapshot(payload.State); err != nil {
+		return err
+	}
+	wm := r.snapshotWriteModel()
+	wm.AggregateID = payload.AggregateID
+	wm.ResourceOwner = payload.ResourceOwner
+	wm.InstanceID = payload.InstanceID
+	wm.ProcessedSequence = payload.ProcessedSequence
+	wm.ChangeDate = payload.ChangeDate
+	return nil
+}
@@ -0,0 +1,452 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type testSnapshotter struct {
+	WriteModel
+	key     string
+	version uint16
+
+	Count uint32 `json:"count"`
+}
+
+func (s *testSnapshotter) Query() *SearchQueryBuilder {
+	return NewSearchQueryBuilder(ColumnsEvent).
+		InstanceID("instance").
+		AddQuery().
+		AggregateTypes("test.aggregate").
+		AggregateIDs(s.key).
+		Builder()
+}
+
+func (s *testSnapshotter) Reduce() error {
+	s.Count += uint32(len(s.Events))
+	return s.WriteModel.Reduce()
+}
+
+func (s *testSnapshotter) SnapshotType() string {
+	return "test"
+}
+
+func (s *testSnapshotter) SnapshotKey() string {
+	return s.key
+}
+
+func (s *testSnapshotter) SnapshotVersion() uint16 {
+	return s.version
+}
+
+func (s *testSnapshotter) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+func (s *testSnapshotter) UnmarshalSnapshot(data []byte) error {
+	state := *s
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	*s = state
+	return nil
+}
+
+type testSnapshotStorage struct {
+	snapshot *Snapshot
+	getErr   error
+	set      *Snapshot
+}
+
+func (s *testSnapshotStorage) Snapshot(_ context.Context, instanceID, snapshotType, key string) (*Snapshot, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	if s.snapshot == nil || s.snapshot.InstanceID != instanceID || s.snapshot.Type != snapshotType || s.snapshot.Key != key {
+		return nil, nil
+	}
+	return s.snapshot, nil
+}
+
+func (s *testSnapshotStorage) SetSnapshot(_ context.Context, snapshot *Snapshot) error {
+	s.set = snapshot
+	return nil
+}
+
+func testSnapshotEvents(count int, createdAt func(i int) time.Time) []Event {
+	events := make([]Event, count)
+	for i := range events {
+		events[i] = &BaseEvent{
+			Agg: &Aggregate{
+				ID:            "key",
+				Type:          "test.aggregate",
+				ResourceOwner: "ro",
+				InstanceID:    "instance",
+			},
+			EventType: "test.snapshotted",
+			Seq:       uint64(i + 1),
+			Pos:       float64(i + 1),
+			Creation:  createdAt(i),
+		}
+	}
+	return events
+}
+
+// withPositions overwrites the positions of the events, e.g. for events of the same transaction
+func withPositions(events []Event, positions ...float64) []Event {
+	for i, position := range positions {
+		events[i].(*BaseEvent).Pos = position
+	}
+	return events
+}
+
+func testSnapshotPayload(t *testing.T, count uint32, sequence uint64, changeDate time.Time) []byte {
+	t.Helper()
+	state, err := json.Marshal(&testSnapshotter{Count: count})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(&snapshotPayload{
+		AggregateID:       "key",
+		ResourceOwner:     "ro",
+		InstanceID:        "instance",
+		ProcessedSequence: sequence,
+		ChangeDate:        changeDate,
+		State:             state,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+func TestEventstore_FilterToQueryReducer_snapshots(t *testing.T) {
+	settled := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	allSettled := func(int) time.Time { return settled }
+
+	type fields struct {
+		events  []Event
+		storage *testSnapshotStorage
+		every   uint32
+	}
+	type want struct {
+		count    uint32
+		sequence uint64
+		set      *Snapshot
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		version uint16
+		want    want
+	}{
+		{
+			name: "below threshold, no snapshot",
+			fields: fields{
+				events:  testSnapshotEvents(2, allSettled),
+				storage: &testSnapshotStorage{},
+				every:   3,
+			},
+			version: 1,
+			want: want{
+				count:    2,
+				sequence: 2,
+			},
+		},
+		{
+			name: "threshold reached, snapshot stored",
+			fields: fields{
+				events:  testSnapshotEvents(3, allSettled),
+				storage: &testSnapshotStorage{},
+				every:   3,
+			},
+			version: 1,
+			want: want{
+				count:    3,
+				sequence: 3,
+				set: &Snapshot{
+					InstanceID: "instance",
+					Type:       "test",
+					Key:        "key",
+					Version:    1,
+					Position:   3,
+					Payload:    testSnapshotPayload(t, 3, 3, settled),
+				},
+			},
+		},
+		{
+			name: "unsettled events not snapshotted",
+			fields: fields{
+				events: testSnapshotEvents(4, func(i int) time.Time {
+					if i < 2 {
+						return settled
+					}
+					return time.Now()
+				}),
+				storage: &testSnapshotStorage{},
+				every:   2,
+			},
+			version: 1,
+			want: want{
+				count:    4,
+				sequence: 4,
+				set: &Snapshot{
+					InstanceID: "instance",
+					Type:       "test",
+					Key:        "key",
+					Version:    1,
+					Position:   2,
+					Payload:    testSnapshotPayload(t, 2, 2, settled),
+				},
+			},
+		},
+		{
+			name: "transaction not split by snapshot",
+			fields: fields{
+				events: withPositions(testSnapshotEvents(4, func(i int) time.Time {
+					if i < 2 {
+						return settled
+					}
+					return time.Now()
+				}), 1, 2, 2, 3),
+				storage: &testSnapshotStorage{},
+				every:   2,
+			},
+			version: 1,
+			want: want{
+				count:    4,
+				sequence: 4,
+			},
+		},
+		{
+			name: "snapshot after transaction",
+			fields: fields{
+				events: withPositions(testSnapshotEvents(4, func(i int) time.Time {
+					if i < 3 {
+						return settled
+					}
+					return time.Now()
+				}), 1, 2, 2, 3),
+				storage: &testSnapshotStorage{},
+				every:   2,
+			},
+			version: 1,
+			want: want{
+				count:    4,
+				sequence: 4,
+				set: &Snapshot{
+					InstanceID: "instance",
+					Type:       "test",
+					Key:        "key",
+					Version:    1,
+					Position:   2,
+					Payload:    testSnapshotPayload(t, 3, 3, settled),
+				},
+			},
+		},
+		{
+			name: "resume from snapshot after transaction",
+			fields: fields{
+				events: withPositions(testSnapshotEvents(4, allSettled), 1, 2, 2, 3),
+				storage: &testSnapshotStorage{
+					snapshot: &Snapshot{
+						InstanceID: "instance",
+						Type:       "test",
+						Key:        "key",
+						Version:    1,
+						Position:   2,
+						Payload:    testSnapshotPayload(t, 3, 3, settled),
+					},
+				},
+				every: 3,
+			},
+			version: 1,
+			want: want{
+				count:    4,
+				sequence: 4,
+			},
+		},
+		{
+			name: "resume from snapshot",
+			fields: fields{
+				events: testSnapshotEvents(4, allSettled),
+				storage: &testSnapshotStorage{
+					snapshot: &Snapshot{
+						InstanceID: "instance",
+						Type:       "test",
+						Key:        "key",
+						Version:    1,
+						Position:   3,
+						Payload:    testSnapshotPayload(t, 3, 3, settled),
+					},
+				},
+				every: 3,
+			},
+			version: 1,
+			want: want{
+				count:    4,
+				sequence: 4,
+			},
+		},
+		{
+			name: "snapshot of other version ignored",
+			fields: fields{
+				events: testSnapshotEvents(4, allSettled),
+				storage: &testSnapshotStorage{
+					snapshot: &Snapshot{
+						InstanceID: "instance",
+						Type:       "test",
+						Key:        "key",
+						Version:    1,
+						Position:   3,
+						Payload:    testSnapshotPayload(t, 100, 3, settled),
+					},
+				},
+				every: 3,
+			},
+			version: 2,
+			want: want{
+				count:    4,
+				sequence: 4,
+				set: &Snapshot{
+					InstanceID: "instance",
+					Type:       "test",
+					Key:        "key",
+					Version:    2,
+					Position:   4,
+					Payload:    testSnapshotPayload(t, 4, 4, settled),
+				},
+			},
+		},
+		{
+			name: "invalid snapshot ignored",
+			fields: fields{
+				events: testSnapshotEvents(2, allSettled),
+				storage: &testSnapshotStorage{
+					snapshot: &Snapshot{
+						InstanceID: "instance",
+						Type:       "test",
+						Key:        "key",
+						Version:    1,
+						Position:   1,
+						Payload:    []byte(`{"state": "invalid"}`),
+					},
+				},
+				every: 3,
+			},
+			version: 1,
+			want: want{
+				count:    2,
+				sequence: 2,
+			},
+		},
+		{
+			name: "storage error ignored",
+			fields: fields{
+				events: testSnapshotEvents(2, allSettled),
+				storage: &testSnapshotStorage{
+					getErr: zerrors.ThrowInternal(nil, "V2-Sn4pT", "test err"),
+				},
+				every: 3,
+			},
+			version: 1,
+			want: want{
+				count:    2,
+				sequence: 2,
+			},
+		},
+		{
+			name: "snapshots disabled",
+			fields: fields{
+				events:  testSnapshotEvents(3, allSettled),
+				storage: &testSnapshotStorage{},
+				every:   0,
+			},
+			version: 1,
+			want: want{
+				count:    3,
+				sequence: 3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := NewEventstore(&Config{
+				Querier: &testQuerier{
+					events: tt.fields.events,
+					t:      t,
+				},
+				Snapshots: SnapshotConfig{
+					Every:       tt.fields.every,
+					MinEventAge: time.Minute,
+				},
+				SnapshotStorage: tt.fields.storage,
+			})
+			r := &testSnapshotter{
+				WriteModel: WriteModel{
+					AggregateID: "key",
+				},
+				key:     "key",
+				version: tt.version,
+			}
+			if err := es.FilterToQueryReducer(context.Background(), r); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if r.Count != tt.want.count {
+				t.Errorf("wrong count of reduced events: want %d, got %d", tt.want.count, r.Count)
+			}
+			if r.ProcessedSequence != tt.want.sequence {
+				t.Errorf("wrong processed sequence: want %d, got %d", tt.want.sequence, r.ProcessedSequence)
+			}
+			if !reflect.DeepEqual(tt.fields.storage.set, tt.want.set) {
+				t.Errorf("unexpected snapshot stored: want %+v, got %+v", tt.want.set, tt.fields.storage.set)
+			}
+		})
+	}
+}
+
+func Test_snapshotCompatible(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *SearchQueryBuilder
+		want  bool
+	}{
+		{
+			name:  "single instance",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance"),
+			want:  true,
+		},
+		{
+			name:  "no instance",
+			query: NewSearchQueryBuilder(ColumnsEvent),
+			want:  false,
+		},
+		{
+			name:  "descending",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").OrderDesc(),
+			want:  false,
+		},
+		{
+			name:  "limited",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").Limit(1),
+			want:  false,
+		},
+		{
+			name:  "position after",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").PositionAfter(1),
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snapshotCompatible(tt.query); got != tt.want {
+				t.Errorf("snapshotCompatible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Upcaster transforms the payload of an event into the payload of the next revision
+type Upcaster func(payload []byte) ([]byte, error)
+
+var upcasters map[EventType][]Upcaster
+
+// RegisterUpcaster registers the function which transforms payloads of the event type from revision from to from+1.
+// Upcasters must be registered in ascending order starting at revision 0.
+// The count of upcasters is the latest revision of the event type.
+// Events are pushed with the latest revision of their type and older payloads are upcasted before they are mapped.
+func RegisterUpcaster(eventType EventType, from uint16, upcast Upcaster) {
+	if upcast == nil || eventType == "" {
+		return
+	}
+	if upcasters == nil {
+		upcasters = make(map[EventType][]Upcaster)
+	}
+	registered := upcasters[eventType]
+	switch {
+	case int(from) < len(registered):
+		registered[from] = upcast
+	case int(from) == len(registered):
+		upcasters[eventType] = append(registered, upcast)
+	default:
+		panic(fmt.Sprintf("upcaster of %s from revision %d registered before revision %d", eventType, from, len(registered)))
+	}
+}
+
+// EventRevision returns the latest revision of the payload of the event type
+func EventRevision(eventType EventType) uint16 {
+	return uint16(len(upcasters[eventType]))
+}
+
+// UpcastedEventTypes returns the event types with at least one upcaster
+func UpcastedEventTypes() []EventType {
+	types := make([]EventType, 0, len(upcasters))
+	for eventType := range upcasters {
+		types = append(types, eventType)
+	}
+	return types
+}
+
+// UpcastPayload transforms the payload of the event type from the revision to the latest revision
+func UpcastPayload(eventType EventType, revision uint16, payload []byte) (_ []byte, latest uint16, err error) {
+	registered := upcasters[eventType]
+	for ; int(revision) < len(registered); revision++ {
+		payload, err = registered[revision](payload)
+		if err != nil {
+			return nil, revision, zerrors.ThrowInternalf(err, "V2-Up1cA", "unable to upcast %s from revision %d", eventType, revision)
+		}
+	}
+	return payload, revision, nil
+}
+
+// upcast returns the event with the payload of the latest revision of its type
+func upcast(event Event) (Event, error) {
+	if int(event.Revision()) >= len(upcasters[event.Type()]) {
+		return event, nil
+	}
+	payload, revision, err := UpcastPayload(event.Type(), event.Revision(), event.DataAsBytes())
+	if err != nil {
+		return nil, err
+	}
+	return &payloadEvent{
+		Event:    event,
+		payload:  payload,
+		revision: revision,
+	}, nil
+}
+
+// payloadEvent replaces the payload of the stored event
+type payloadEvent struct {
+	Event
+	payload  []byte
+	revision uint16
+}
+
+// Revision implements [Event]
+func (e *payloadEvent) Revision() uint16 {
+	return e.revision
+}
+
+// DataAsBytes implements [Event]
+func (e *payloadEvent) DataAsBytes() []byte {
+	return e.payload
+}
+
+// Unmarshal implements [Event]
+func (e *payloadEvent) Unmarshal(ptr any) error {
+	return (&BaseEvent{Data: e.payload}).Unmarshal(ptr)
+}
+
+// EventRevisionCount is the count of stored events of an event type with the same payload revision
+type EventRevisionCount struct {
+	EventType EventType
+	Revision  uint16
+	Count     uint64
+}
+
+// EventRewriter rewrites stored payloads to the latest revision of their event type
+type EventRewriter interface {
+	// EventRevisions returns the count of stored events per event type and payload revision
+	EventRevisions(ctx context.Context) ([]*EventRevisionCount, error)
+	// RewriteEvents upcasts the stored payloads of at most limit events of the event type
+	// and returns the count of rewritten events
+	RewriteEvents(ctx context.Context, eventType EventType, limit uint16) (int, error)
+}
+
+type RewriteConfig struct {
+	// Enabled starts the rewrite of outdated payloads in the background
+	Enabled bool
+	// BulkLimit is the maximum count of events rewritten per transaction
+	BulkLimit uint16
+	// Interval between the transactions, the rewrite must not slow down pushing events
+	Interval time.Duration
+}
+
+// EventRevisions returns the count of stored events per event type and payload revision
+func (es *Eventstore) EventRevisions(ctx context.Context) ([]*EventRevisionCount, error) {
+	if es.rewriter == nil {
+		return nil, zerrors.ThrowUnimplemented(nil, "V2-Up2cA", "Errors.Eventstore.RewriteNotSupported")
+	}
+	return es.rewriter.EventRevisions(ctx)
+}
+
+// RewriteEvents rewrites the stored payloads of all upcasted event types to their latest revision
+// and returns as soon as no outdated payloads are left.
+func (es *Eventstore) RewriteEvents(ctx context.Context) error {
+	if es.rewriter == nil {
+		return zerrors.ThrowUnimplemented(nil, "V2-Up3cA", "Errors.Eventstore.RewriteNotSupported")
+	}
+	limit := es.rewrite.BulkLimit
+	if limit == 0 {
+		limit = 100
+	}
+	for _, eventType := range UpcastedEventTypes() {
+		total := 0
+		for {
+			rewritten, err := es.rewriter.RewriteEvents(ctx, eventType, limit)
+			if err != nil {
+				return err
+			}
+			total += rewritten
+			if rewritten < int(limit) {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(es.rewrite.Interval):
+			}
+		}
+		logging.WithFields("type", eventType, "revision", EventRevision(eventType), "count", total).Info("events rewritten")
+	}
+	return nil
+}
+
+// StartRewrite rewrites the outdated payloads in the background if enabled
+func (es *Eventstore) StartRewrite(ctx context.Context) {
+	if !es.rewrite.Enabled || es.rewriter == nil {
+		return
+	}
+	go func() {
+		err := es.RewriteEvents(ctx)
+		logging.OnError(err).Error("rewrite of events failed")
+	}()
+}
@@ -0,0 +1,142 @@
+package eventstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+// storedEvent is an event read from the storage with the revision it was pushed with
+type storedEvent struct {
+	*BaseEvent
+	revision uint16
+}
+
+func (e *storedEvent) Revision() uint16 {
+	return e.revision
+}
+
+func renameField(from, to string) Upcaster {
+	return func(payload []byte) ([]byte, error) {
+		return bytes.Replace(payload, []byte(`"`+from+`"`), []byte(`"`+to+`"`), 1), nil
+	}
+}
+
+func TestRegisterUpcaster(t *testing.T) {
+	eventType := EventType("test.upcaster.registered")
+	t.Cleanup(func() { delete(upcasters, eventType) })
+
+	RegisterUpcaster(eventType, 0, renameField("a", "b"))
+	RegisterUpcaster(eventType, 1, renameField("b", "c"))
+	// registering a revision again replaces the upcaster
+	RegisterUpcaster(eventType, 1, renameField("b", "d"))
+
+	if revision := EventRevision(eventType); revision != 2 {
+		t.Errorf("unexpected revision: want 2, got %d", revision)
+	}
+	if revision := (&BaseEvent{EventType: eventType}).Revision(); revision != 2 {
+		t.Errorf("unexpected revision of base event: want 2, got %d", revision)
+	}
+	payload, revision, err := UpcastPayload(eventType, 0, []byte(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revision != 2 || string(payload) != `{"d":1}` {
+		t.Errorf("unexpected upcast: want 2 {\"d\":1}, got %d %s", revision, payload)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on gap in revisions")
+		}
+	}()
+	RegisterUpcaster(eventType, 3, renameField("d", "e"))
+}
+
+func TestEventstore_Filter_upcast(t *testing.T) {
+	eventType := EventType("test.upcaster.filtered")
+	failingType := EventType("test.upcaster.failing")
+	RegisterUpcaster(eventType, 0, renameField("firstname", "firstName"))
+	RegisterUpcaster(eventType, 1, renameField("firstName", "givenName"))
+	RegisterUpcaster(failingType, 0, func([]byte) ([]byte, error) { return nil, errors.New("invalid payload") })
+	t.Cleanup(func() {
+		delete(upcasters, eventType)
+		delete(upcasters, failingType)
+	})
+
+	tests := []struct {
+		name        string
+		event       Event
+		wantPayload string
+		wantErr     bool
+	}{
+		{
+			name: "revision 0",
+			event: &storedEvent{
+				BaseEvent: &BaseEvent{EventType: eventType, Agg: &Aggregate{}, Data: []byte(`{"firstname":"gigi"}`)},
+				revision:  0,
+			},
+			wantPayload: `{"givenName":"gigi"}`,
+		},
+		{
+			name: "revision 1",
+			event: &storedEvent{
+				BaseEvent: &BaseEvent{EventType: eventType, Agg: &Aggregate{}, Data: []byte(`{"firstName":"gigi"}`)},
+				revision:  1,
+			},
+			wantPayload: `{"givenName":"gigi"}`,
+		},
+		{
+			name: "latest revision",
+			event: &storedEvent{
+				BaseEvent: &BaseEvent{EventType: eventType, Agg: &Aggregate{}, Data: []byte(`{"givenName":"gigi"}`)},
+				revision:  2,
+			},
+			wantPayload: `{"givenName":"gigi"}`,
+		},
+		{
+			name: "upcast fails",
+			event: &storedEvent{
+				BaseEvent: &BaseEvent{EventType: failingType, Agg: &Aggregate{}, Data: []byte(`{}`)},
+				revision:  0,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := NewEventstore(&Config{
+				Querier: &testQuerier{
+					events: []Event{tt.event},
+					t:      t,
+				},
+			})
+			events, err := es.Filter(context.Background(), NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance"))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+			if payload := string(events[0].DataAsBytes()); payload != tt.wantPayload {
+				t.Errorf("unexpected payload: want %s, got %s", tt.wantPayload, payload)
+			}
+			if revision := events[0].Revision(); revision != 2 {
+				t.Errorf("unexpected revision: want 2, got %d", revision)
+			}
+			payload := struct {
+				GivenName string `json:"givenName"`
+			}{}
+			if err = events[0].Unmarshal(&payload); err != nil || payload.GivenName != "gigi" {
+				t.Errorf("unexpected unmarshal: %v %+v", err, payload)
+			}
+		})
+	}
+}
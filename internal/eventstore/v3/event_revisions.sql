@@ -0,0 +1,12 @@
+SELECT
+    event_type
+    , payload_revision
+    , COUNT(*)
+FROM
+    eventstore.events2
+GROUP BY
+    event_type
+    , payload_revision
+ORDER BY
+    event_type
+    , payload_revision;
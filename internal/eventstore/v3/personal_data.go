@@ -0,0 +1,152 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed personal_data_key_get.sql
+	getPersonalDataKeyStmt string
+	//go:embed personal_data_key_add.sql
+	addPersonalDataKeyStmt string
+	//go:embed personal_data_key_delete.sql
+	deletePersonalDataKeyStmt string
+	//go:embed personal_data_snapshots_delete.sql
+	deletePersonalDataSnapshotsStmt string
+	//go:embed personal_data_erasure_add.sql
+	addPersonalDataErasureStmt string
+	//go:embed personal_data_erasures.sql
+	personalDataErasuresStmt string
+)
+
+var _ eventstore.PersonalDataStorage = (*Eventstore)(nil)
+
+// PersonalDataKey implements [eventstore.PersonalDataStorage]
+func (es *Eventstore) PersonalDataKey(ctx context.Context, instanceID, aggregateID string) (*crypto.CryptoValue, error) {
+	key := new(crypto.CryptoValue)
+	err := es.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(key)
+	}, getPersonalDataKeyStmt, instanceID, aggregateID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Pd1Gt", "Errors.Internal")
+	}
+	return key, nil
+}
+
+// AddPersonalDataKey implements [eventstore.PersonalDataStorage]
+func (es *Eventstore) AddPersonalDataKey(ctx context.Context, instanceID, aggregateID string, key *crypto.CryptoValue) (*crypto.CryptoValue, error) {
+	stored := new(crypto.CryptoValue)
+	err := es.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(stored)
+	}, addPersonalDataKeyStmt, instanceID, aggregateID, key)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Pd2Ad", "Errors.Internal")
+	}
+	return stored, nil
+}
+
+// PersonalDataErasures implements [eventstore.PersonalDataStorage]
+func (es *Eventstore) PersonalDataErasures(ctx context.Context, instanceID string) (erasures []*eventstore.PersonalDataErasure, err error) {
+	err = es.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			erasure := new(eventstore.PersonalDataErasure)
+			var events, plaintextEvents database.Map[uint64]
+			err := rows.Scan(
+				&erasure.InstanceID,
+				&erasure.AggregateType,
+				&erasure.AggregateID,
+				&erasure.ErasedAt,
+				&erasure.ErasedBy,
+				&events,
+				&plaintextEvents,
+			)
+			if err != nil {
+				return err
+			}
+			erasure.Events = eventCounts(events)
+			erasure.PlaintextEvents = eventCounts(plaintextEvents)
+			erasures = append(erasures, erasure)
+		}
+		return rows.Err()
+	}, personalDataErasuresStmt, instanceID)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Pd3Er", "Errors.Internal")
+	}
+	return erasures, nil
+}
+
+// handlePersonalDataErasures deletes the data keys and the snapshots of the aggregates
+// of the commands implementing [eventstore.PersonalDataEraser] and records the erasures
+func handlePersonalDataErasures(ctx context.Context, tx *sql.Tx, commands []eventstore.Command) error {
+	var (
+		eventTypes database.TextArray[string]
+		fields     []byte
+	)
+	for _, command := range commands {
+		if !eventstore.ErasesPersonalData(command) {
+			continue
+		}
+		if eventTypes == nil {
+			var err error
+			if eventTypes, fields, err = personalDataFields(); err != nil {
+				return err
+			}
+		}
+		aggregate := command.Aggregate()
+		if _, err := tx.ExecContext(ctx, deletePersonalDataKeyStmt, aggregate.InstanceID, aggregate.ID); err != nil {
+			return zerrors.ThrowInternal(err, "V3-Pd4Dk", "Errors.Internal")
+		}
+		if _, err := tx.ExecContext(ctx, deletePersonalDataSnapshotsStmt, aggregate.InstanceID, aggregate.ID); err != nil {
+			return zerrors.ThrowInternal(err, "V3-Pd5Ds", "Errors.Internal")
+		}
+		_, err := tx.ExecContext(ctx, addPersonalDataErasureStmt,
+			aggregate.InstanceID,
+			aggregate.Type,
+			aggregate.ID,
+			command.Creator(),
+			fields,
+			eventTypes,
+		)
+		if err != nil {
+			return zerrors.ThrowInternal(err, "V3-Pd6Er", "Errors.Internal")
+		}
+	}
+	return nil
+}
+
+func eventCounts(counts database.Map[uint64]) map[eventstore.EventType]uint64 {
+	events := make(map[eventstore.EventType]uint64, len(counts))
+	for eventType, count := range counts {
+		events[eventstore.EventType(eventType)] = count
+	}
+	return events
+}
+
+// personalDataFields returns the event types containing personal data
+// and the JSON object of their fields which is used to detect events pushed before the personal data was encrypted
+func personalDataFields() (database.TextArray[string], []byte, error) {
+	types := eventstore.PersonalDataEventTypes()
+	eventTypes := make(database.TextArray[string], len(types))
+	fields := make(map[string][]string, len(types))
+	for i, eventType := range types {
+		eventTypes[i] = string(eventType)
+		fields[string(eventType)] = eventstore.PersonalDataFields(eventType)
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, zerrors.ThrowInternal(err, "V3-Pd7Fd", "Errors.Internal")
+	}
+	return eventTypes, data, nil
+}
@@ -0,0 +1,46 @@
+INSERT INTO eventstore.personal_data_erasures (
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , erased_at
+    , erased_by
+    , events
+    , plaintext_events
+) SELECT
+    $1
+    , $2
+    , $3
+    , NOW()
+    , $4
+    , COALESCE(jsonb_object_agg(e.event_type, e.erased) FILTER (WHERE e.erased > 0), '{}'::JSONB)
+    , COALESCE(jsonb_object_agg(e.event_type, e.plaintext) FILTER (WHERE e.plaintext > 0), '{}'::JSONB)
+FROM (
+    SELECT
+        event_type
+        , COUNT(*) FILTER (WHERE NOT plaintext) AS erased
+        , COUNT(*) FILTER (WHERE plaintext) AS plaintext
+    FROM (
+        SELECT
+            e.event_type
+            -- events pushed before the personal data was encrypted contain the values of the fields in plaintext
+            , EXISTS (
+                SELECT
+                    1
+                FROM
+                    jsonb_array_elements_text($5::JSONB -> e.event_type) AS f(field)
+                WHERE
+                    jsonb_typeof(e.payload -> f.field) <> 'null'
+                    AND e.payload -> f.field -> 'encryptedPersonalData' IS NULL
+            ) AS plaintext
+        FROM
+            eventstore.events2 e
+        WHERE
+            e.instance_id = $1
+            AND e.aggregate_type = $2
+            AND e.aggregate_id = $3
+            AND e.event_type = ANY($6)
+    ) p
+    GROUP BY
+        event_type
+) e
+ON CONFLICT (instance_id, aggregate_id) DO NOTHING
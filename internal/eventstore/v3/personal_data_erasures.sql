@@ -0,0 +1,14 @@
+SELECT
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , erased_at
+    , erased_by
+    , events
+    , plaintext_events
+FROM
+    eventstore.personal_data_erasures
+WHERE
+    instance_id = $1
+ORDER BY
+    erased_at DESC
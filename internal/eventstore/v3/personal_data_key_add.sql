@@ -0,0 +1,14 @@
+INSERT INTO eventstore.personal_data_keys (
+    instance_id
+    , aggregate_id
+    , "key"
+    , created_at
+) VALUES (
+    $1
+    , $2
+    , $3
+    , NOW()
+) ON CONFLICT (instance_id, aggregate_id) DO UPDATE SET
+    -- the key of a concurrent transaction wins
+    "key" = eventstore.personal_data_keys."key"
+RETURNING "key"
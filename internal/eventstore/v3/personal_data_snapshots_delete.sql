@@ -0,0 +1,5 @@
+DELETE FROM
+    eventstore.snapshots
+WHERE
+    instance_id = $1
+    AND (snapshot_key = $2 OR snapshot_key LIKE '%:' || $2)
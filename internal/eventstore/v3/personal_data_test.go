@@ -0,0 +1,19 @@
+package eventstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func Test_personalDataFields(t *testing.T) {
+	eventstore.RegisterPersonalData("test.v3.personal_data.added", "firstName", "email")
+
+	eventTypes, fields, err := personalDataFields()
+	require.NoError(t, err)
+	assert.Contains(t, eventTypes, "test.v3.personal_data.added")
+	assert.Contains(t, string(fields), `"test.v3.personal_data.added":["firstName","email"]`)
+}
@@ -19,6 +19,10 @@ import (
 )
 
 func (es *Eventstore) Push(ctx context.Context, commands ...eventstore.Command) (events []eventstore.Event, err error) {
+	// events of instances which are moved to another shard are rejected
+	if err = es.client.CheckWritable(ctx); err != nil {
+		return nil, err
+	}
 	tx, err := es.client.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -42,7 +46,10 @@ func (es *Eventstore) Push(ctx context.Context, commands ...eventstore.Command)
 			return err
 		}
 
-		return handleUniqueConstraints(ctx, tx, commands)
+		if err = handleUniqueConstraints(ctx, tx, commands); err != nil {
+			return err
+		}
+		return handlePersonalDataErasures(ctx, tx, commands)
 	})
 
 	if err != nil {
@@ -92,7 +99,7 @@ func insertEvents(ctx context.Context, tx *sql.Tx, sequences []*latestSequence,
 	return events, nil
 }
 
-const argsPerCommand = 10
+const argsPerCommand = 11
 
 func mapCommands(commands []eventstore.Command, sequences []*latestSequence) (events []eventstore.Event, placeholders []string, args []any, err error) {
 	events = make([]eventstore.Event, len(commands))
@@ -128,6 +135,7 @@ func mapCommands(commands []eventstore.Command, sequences []*latestSequence) (ev
 			i*argsPerCommand+8,
 			i*argsPerCommand+9,
 			i*argsPerCommand+10,
+			i*argsPerCommand+11,
 		)
 
 		revision, err := strconv.Atoi(strings.TrimPrefix(string(events[i].(*event).aggregate.Version), "v"))
@@ -145,6 +153,7 @@ func mapCommands(commands []eventstore.Command, sequences []*latestSequence) (ev
 			events[i].(*event).payload,
 			events[i].(*event).sequence,
 			i,
+			events[i].(*event).revision,
 		)
 	}
 
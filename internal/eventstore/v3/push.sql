@@ -13,6 +13,7 @@ INSERT INTO eventstore.events2 (
 
     , "position"
     , in_tx_order
+    , payload_revision
 ) VALUES
     %s
 RETURNING created_at, "position";
\ No newline at end of file
@@ -65,7 +65,7 @@ func Test_mapCommands(t *testing.T) {
 					),
 				},
 				placeHolders: []string{
-					"($1, $2, $3, $4, $5, $6, $7, $8, $9, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $10)",
+					"($1, $2, $3, $4, $5, $6, $7, $8, $9, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $10, $11)",
 				},
 				args: []any{
 					"instance",
@@ -78,6 +78,7 @@ func Test_mapCommands(t *testing.T) {
 					Payload(nil),
 					uint64(1),
 					0,
+					uint16(1),
 				},
 				err: func(t *testing.T, err error) {},
 			},
@@ -114,8 +115,8 @@ func Test_mapCommands(t *testing.T) {
 					),
 				},
 				placeHolders: []string{
-					"($1, $2, $3, $4, $5, $6, $7, $8, $9, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $10)",
-					"($11, $12, $13, $14, $15, $16, $17, $18, $19, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $20)",
+					"($1, $2, $3, $4, $5, $6, $7, $8, $9, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $10, $11)",
+					"($12, $13, $14, $15, $16, $17, $18, $19, $20, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $21, $22)",
 				},
 				args: []any{
 					// first event
@@ -129,6 +130,7 @@ func Test_mapCommands(t *testing.T) {
 					Payload(nil),
 					uint64(6),
 					0,
+					uint16(1),
 					// second event
 					"instance",
 					"ro",
@@ -140,6 +142,7 @@ func Test_mapCommands(t *testing.T) {
 					Payload(nil),
 					uint64(7),
 					1,
+					uint16(1),
 				},
 				err: func(t *testing.T, err error) {},
 			},
@@ -180,8 +183,8 @@ func Test_mapCommands(t *testing.T) {
 					),
 				},
 				placeHolders: []string{
-					"($1, $2, $3, $4, $5, $6, $7, $8, $9, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $10)",
-					"($11, $12, $13, $14, $15, $16, $17, $18, $19, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $20)",
+					"($1, $2, $3, $4, $5, $6, $7, $8, $9, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $10, $11)",
+					"($12, $13, $14, $15, $16, $17, $18, $19, $20, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $21, $22)",
 				},
 				args: []any{
 					// first event
@@ -195,6 +198,7 @@ func Test_mapCommands(t *testing.T) {
 					Payload(nil),
 					uint64(6),
 					0,
+					uint16(1),
 					// second event
 					"instance",
 					"ro",
@@ -206,6 +210,7 @@ func Test_mapCommands(t *testing.T) {
 					Payload(nil),
 					uint64(1),
 					1,
+					uint16(1),
 				},
 				err: func(t *testing.T, err error) {},
 			},
@@ -0,0 +1,125 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed event_revisions.sql
+	eventRevisionsStmt string
+	//go:embed rewrite_select.sql
+	rewriteSelectStmt string
+	//go:embed rewrite_update.sql
+	rewriteUpdateStmt string
+)
+
+var _ eventstore.EventRewriter = (*Eventstore)(nil)
+
+// EventRevisions implements [eventstore.EventRewriter]
+func (es *Eventstore) EventRevisions(ctx context.Context) (revisions []*eventstore.EventRevisionCount, err error) {
+	err = es.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			revision := new(eventstore.EventRevisionCount)
+			if err := rows.Scan(&revision.EventType, &revision.Revision, &revision.Count); err != nil {
+				return err
+			}
+			revisions = append(revisions, revision)
+		}
+		return rows.Err()
+	}, eventRevisionsStmt)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Rw1Rv", "Errors.Internal")
+	}
+	return revisions, nil
+}
+
+type storedEvent struct {
+	instanceID    string
+	aggregateType string
+	aggregateID   string
+	sequence      uint64
+	revision      uint16
+	payload       Payload
+}
+
+// RewriteEvents implements [eventstore.EventRewriter]
+// The payloads are upcasted by the registered [eventstore.Upcaster]s and updated in a single transaction.
+func (es *Eventstore) RewriteEvents(ctx context.Context, eventType eventstore.EventType, limit uint16) (rewritten int, err error) {
+	latest := eventstore.EventRevision(eventType)
+	if latest == 0 {
+		return 0, nil
+	}
+	tx, err := es.client.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "V3-Rw2Tx", "Errors.Internal")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			logging.OnError(rollbackErr).Debug("unable to rollback")
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			err = zerrors.ThrowInternal(err, "V3-Rw3Cm", "Errors.Internal")
+		}
+	}()
+
+	events, err := outdatedEvents(ctx, tx, eventType, latest, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, event := range events {
+		payload, revision, err := eventstore.UpcastPayload(eventType, event.revision, event.payload)
+		if err != nil {
+			return 0, err
+		}
+		_, err = tx.ExecContext(ctx, rewriteUpdateStmt,
+			Payload(payload),
+			revision,
+			event.instanceID,
+			event.aggregateType,
+			event.aggregateID,
+			event.sequence,
+		)
+		if err != nil {
+			return 0, zerrors.ThrowInternal(err, "V3-Rw4Up", "Errors.Internal")
+		}
+	}
+	return len(events), nil
+}
+
+func outdatedEvents(ctx context.Context, tx *sql.Tx, eventType eventstore.EventType, latest, limit uint16) ([]*storedEvent, error) {
+	rows, err := tx.QueryContext(ctx, rewriteSelectStmt, eventType, latest, limit)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Rw5Sl", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	events := make([]*storedEvent, 0, limit)
+	for rows.Next() {
+		event := new(storedEvent)
+		err = rows.Scan(
+			&event.instanceID,
+			&event.aggregateType,
+			&event.aggregateID,
+			&event.sequence,
+			&event.revision,
+			&event.payload,
+		)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "V3-Rw6Sc", "Errors.Internal")
+		}
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Rw7Rw", "Errors.Internal")
+	}
+	return events, nil
+}
@@ -0,0 +1,14 @@
+SELECT
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , "sequence"
+    , payload_revision
+    , payload
+FROM
+    eventstore.events2
+WHERE
+    event_type = $1
+    AND payload_revision < $2
+LIMIT $3
+FOR UPDATE;
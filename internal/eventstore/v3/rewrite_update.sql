@@ -0,0 +1,8 @@
+UPDATE eventstore.events2 SET
+    payload = $1
+    , payload_revision = $2
+WHERE
+    instance_id = $3
+    AND aggregate_type = $4
+    AND aggregate_id = $5
+    AND "sequence" = $6;
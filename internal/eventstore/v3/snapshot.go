@@ -0,0 +1,55 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed snapshot_get.sql
+	getSnapshotStmt string
+	//go:embed snapshot_set.sql
+	setSnapshotStmt string
+)
+
+var _ eventstore.SnapshotStorage = (*Eventstore)(nil)
+
+// Snapshot implements [eventstore.SnapshotStorage]
+func (es *Eventstore) Snapshot(ctx context.Context, instanceID, snapshotType, key string) (*eventstore.Snapshot, error) {
+	snapshot := &eventstore.Snapshot{
+		InstanceID: instanceID,
+		Type:       snapshotType,
+		Key:        key,
+	}
+	err := es.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&snapshot.Version, &snapshot.Position, &snapshot.Payload)
+	}, getSnapshotStmt, instanceID, snapshotType, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Sn4pG", "Errors.Internal")
+	}
+	return snapshot, nil
+}
+
+// SetSnapshot implements [eventstore.SnapshotStorage]
+func (es *Eventstore) SetSnapshot(ctx context.Context, snapshot *eventstore.Snapshot) error {
+	_, err := es.client.ExecContext(ctx, setSnapshotStmt,
+		snapshot.InstanceID,
+		snapshot.Type,
+		snapshot.Key,
+		snapshot.Version,
+		snapshot.Position,
+		snapshot.Payload,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V3-Sn4pS", "Errors.Internal")
+	}
+	return nil
+}
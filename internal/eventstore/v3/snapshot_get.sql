@@ -0,0 +1,10 @@
+SELECT
+    version
+    , "position"
+    , payload
+FROM
+    eventstore.snapshots
+WHERE
+    instance_id = $1
+    AND snapshot_type = $2
+    AND snapshot_key = $3
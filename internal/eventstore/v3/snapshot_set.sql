@@ -0,0 +1,24 @@
+INSERT INTO eventstore.snapshots (
+    instance_id
+    , snapshot_type
+    , snapshot_key
+    , version
+    , "position"
+    , payload
+    , created_at
+) VALUES (
+    $1
+    , $2
+    , $3
+    , $4
+    , $5
+    , $6
+    , NOW()
+) ON CONFLICT (instance_id, snapshot_type, snapshot_key) DO UPDATE SET
+    version = EXCLUDED.version
+    , "position" = EXCLUDED."position"
+    , payload = EXCLUDED.payload
+    , created_at = EXCLUDED.created_at
+WHERE
+    eventstore.snapshots."position" < EXCLUDED."position"
+    OR eventstore.snapshots.version <> EXCLUDED.version
@@ -0,0 +1,87 @@
+package idp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GroupsClaim is the claim the groups fetched by a [SessionSupportsGroups] are provided by
+const GroupsClaim = "groups"
+
+// ClaimValuesProvider can be implemented by a [User] to provide the values of its claims or attributes,
+// e.g. if they are not part of its JSON representation.
+type ClaimValuesProvider interface {
+	GetClaimValues(claim string) []string
+}
+
+// ClaimValues returns the values of the claim (or attribute) of the federated user.
+// Nested claims can be addressed with a dot separated path, e.g. `realm_access.roles`.
+// If the user does not implement [ClaimValuesProvider], the claim is looked up in the JSON representation of the user.
+func ClaimValues(user User, claim string) []string {
+	if user == nil || claim == "" {
+		return nil
+	}
+	if provider, ok := user.(ClaimValuesProvider); ok {
+		return provider.GetClaimValues(claim)
+	}
+	data, err := json.Marshal(user)
+	if err != nil {
+		return nil
+	}
+	var claims map[string]interface{}
+	if err = json.Unmarshal(data, &claims); err != nil {
+		return nil
+	}
+	return lookupClaimValues(claims, claim)
+}
+
+// SessionClaimValues returns the values of the claim of the federated user like [ClaimValues].
+// If the user does not provide the [GroupsClaim], the groups are fetched by the session if it implements [SessionSupportsGroups].
+func SessionClaimValues(ctx context.Context, session Session, user User, claim string) ([]string, error) {
+	values := ClaimValues(user, claim)
+	if len(values) > 0 || claim != GroupsClaim {
+		return values, nil
+	}
+	groups, ok := session.(SessionSupportsGroups)
+	if !ok {
+		return values, nil
+	}
+	return groups.FetchGroups(ctx)
+}
+
+func lookupClaimValues(claims map[string]interface{}, claim string) []string {
+	// claims like `https://example.com/groups` contain dots themselves, so the full name is checked first
+	if value, ok := claims[claim]; ok {
+		return claimValues(value)
+	}
+	name, path, found := strings.Cut(claim, ".")
+	if !found {
+		return nil
+	}
+	nested, ok := claims[name].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return lookupClaimValues(nested, path)
+}
+
+func claimValues(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, claimValues(item)...)
+		}
+		return values
+	case map[string]interface{}:
+		return nil
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,180 @@
+package idp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+type testUser struct {
+	ID     string                 `json:"sub"`
+	Claims map[string]interface{} `json:"claims"`
+}
+
+func (u *testUser) GetID() string                      { return u.ID }
+func (u *testUser) GetFirstName() string               { return "" }
+func (u *testUser) GetLastName() string                { return "" }
+func (u *testUser) GetDisplayName() string             { return "" }
+func (u *testUser) GetNickname() string                { return "" }
+func (u *testUser) GetPreferredUsername() string       { return "" }
+func (u *testUser) GetEmail() domain.EmailAddress      { return "" }
+func (u *testUser) IsEmailVerified() bool              { return false }
+func (u *testUser) GetPhone() domain.PhoneNumber       { return "" }
+func (u *testUser) IsPhoneVerified() bool              { return false }
+func (u *testUser) GetPreferredLanguage() language.Tag { return language.Und }
+func (u *testUser) GetAvatarURL() string               { return "" }
+func (u *testUser) GetProfile() string                 { return "" }
+
+type testAttributeUser struct {
+	testUser
+	attributes map[string][]string
+}
+
+func (u *testAttributeUser) GetClaimValues(claim string) []string {
+	return u.attributes[claim]
+}
+
+func TestClaimValues(t *testing.T) {
+	user := &testUser{
+		ID: "id",
+		Claims: map[string]interface{}{
+			"groups":                     []string{"admins", "users"},
+			"https://example.com/groups": []string{"admins"},
+			"level":                      3,
+			"realm_access":               map[string]interface{}{"roles": []string{"reader"}},
+		},
+	}
+	tests := []struct {
+		name  string
+		user  User
+		claim string
+		want  []string
+	}{
+		{
+			name:  "string",
+			user:  user,
+			claim: "sub",
+			want:  []string{"id"},
+		},
+		{
+			name:  "array",
+			user:  user,
+			claim: "claims.groups",
+			want:  []string{"admins", "users"},
+		},
+		{
+			name:  "name containing dots",
+			user:  user,
+			claim: "claims.https://example.com/groups",
+			want:  []string{"admins"},
+		},
+		{
+			name:  "number",
+			user:  user,
+			claim: "claims.level",
+			want:  []string{"3"},
+		},
+		{
+			name:  "nested",
+			user:  user,
+			claim: "claims.realm_access.roles",
+			want:  []string{"reader"},
+		},
+		{
+			name:  "object",
+			user:  user,
+			claim: "claims.realm_access",
+			want:  nil,
+		},
+		{
+			name:  "missing",
+			user:  user,
+			claim: "claims.roles",
+			want:  nil,
+		},
+		{
+			name: "claim values provider",
+			user: &testAttributeUser{
+				attributes: map[string][]string{"memberOf": {"cn=admins"}},
+			},
+			claim: "memberOf",
+			want:  []string{"cn=admins"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClaimValues(tt.user, tt.claim))
+		})
+	}
+}
+
+type testSession struct {
+	groups []string
+	err    error
+}
+
+func (s *testSession) GetAuth(context.Context) (string, bool)        { return "", false }
+func (s *testSession) FetchUser(context.Context) (User, error)       { return nil, nil }
+func (s *testSession) FetchGroups(context.Context) ([]string, error) { return s.groups, s.err }
+
+func TestSessionClaimValues(t *testing.T) {
+	user := &testUser{
+		ID:     "id",
+		Claims: map[string]interface{}{"groups": []string{"admins"}},
+	}
+	tests := []struct {
+		name    string
+		session Session
+		user    User
+		claim   string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "claim of the user",
+			session: &testSession{groups: []string{"org/team"}},
+			user:    &testAttributeUser{attributes: map[string][]string{"groups": {"admins"}}},
+			claim:   GroupsClaim,
+			want:    []string{"admins"},
+		},
+		{
+			name:    "groups fetched by the session",
+			session: &testSession{groups: []string{"org", "org/team"}},
+			user:    user,
+			claim:   GroupsClaim,
+			want:    []string{"org", "org/team"},
+		},
+		{
+			name:    "groups not fetched for other claims",
+			session: &testSession{groups: []string{"org"}},
+			user:    user,
+			claim:   "roles",
+			want:    nil,
+		},
+		{
+			name:    "session without groups",
+			session: nil,
+			user:    user,
+			claim:   GroupsClaim,
+			want:    nil,
+		},
+		{
+			name:    "fetching groups failed",
+			session: &testSession{err: assert.AnError},
+			user:    user,
+			claim:   GroupsClaim,
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SessionClaimValues(context.Background(), tt.session, tt.user, tt.claim)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Package ldapsync periodically synchronizes the users of LDAP identity providers
+// with an enabled directory synchronization.
+package ldapsync
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+type Config struct {
+	// Enabled defines if the scheduled synchronization runs on this instance of ZITADEL
+	Enabled bool
+	// PageSize is the amount of entries requested per page from the directory
+	PageSize uint32
+}
+
+var syncHandler *handler.Handler
+
+func Register(
+	ctx context.Context,
+	customConfig projection.CustomConfig,
+	config Config,
+	commands *command.Commands,
+	queries *query.Queries,
+	userCodeAlg crypto.EncryptionAlgorithm,
+) {
+	if !config.Enabled {
+		return
+	}
+	syncHandler = newSyncer(ctx, config, projection.ApplyCustomConfig(customConfig), commands, queries, userCodeAlg)
+}
+
+func Start(ctx context.Context) {
+	if syncHandler == nil {
+		return
+	}
+	syncHandler.Start(ctx)
+}
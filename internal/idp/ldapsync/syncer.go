@@ -0,0 +1,428 @@
+package ldapsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	SyncerProjectionTable = "projections.idp_ldap_syncer"
+	SyncUserID            = "LDAP_SYNC"
+)
+
+type syncer struct {
+	config      Config
+	commands    *command.Commands
+	queries     *query.Queries
+	userCodeAlg crypto.EncryptionAlgorithm
+}
+
+func newSyncer(
+	ctx context.Context,
+	config Config,
+	handlerCfg handler.Config,
+	commands *command.Commands,
+	queries *query.Queries,
+	userCodeAlg crypto.EncryptionAlgorithm,
+) *handler.Handler {
+	s := &syncer{
+		config:      config,
+		commands:    commands,
+		queries:     queries,
+		userCodeAlg: userCodeAlg,
+	}
+	handlerCfg.TriggerWithoutEvents = s.syncDirectories
+	return handler.NewHandler(
+		ctx,
+		&handlerCfg,
+		s,
+	)
+}
+
+func (s *syncer) Name() string {
+	return SyncerProjectionTable
+}
+
+func (s *syncer) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{{
+		Aggregate: pseudo.AggregateType,
+		EventReducers: []handler.EventReducer{{
+			Event:  pseudo.ScheduledEventType,
+			Reduce: s.syncDirectories,
+		}},
+	}}
+}
+
+func (s *syncer) syncDirectories(event eventstore.Event) (*handler.Statement, error) {
+	scheduledEvent, ok := event.(*pseudo.ScheduledEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "LDAPS-Mai5e", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		for _, instanceID := range scheduledEvent.InstanceIDs {
+			if err := s.syncInstance(instanceID, scheduledEvent.Timestamp); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
+
+func (s *syncer) syncInstance(instanceID string, now time.Time) error {
+	ctx := call.WithTimestamp(authz.WithInstanceID(context.Background(), instanceID))
+	instance, err := s.queries.InstanceByID(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = authz.WithInstance(ctx, instance)
+	syncs, err := s.queries.EnabledLDAPSyncs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, sync := range syncs {
+		if !sync.IsDue(now) {
+			continue
+		}
+		report := s.syncDirectory(ctx, sync)
+		_, err = s.commands.AddLDAPSyncReport(systemContext(ctx, sync.ResourceOwner), sync.ResourceOwner, sync.IDPID, report)
+		logging.WithFields("instance", instanceID, "idp", sync.IDPID).OnError(err).Error("unable to add ldap sync report")
+	}
+	return nil
+}
+
+// syncDirectory runs a single synchronization of the directory of the provided sync.
+// Failures are not returned but recorded in the report, so the next run will only be started after the interval.
+func (s *syncer) syncDirectory(ctx context.Context, sync *query.LDAPSync) *domain.LDAPSyncReport {
+	report := &domain.LDAPSyncReport{StartedAt: time.Now()}
+	if err := s.runSync(ctx, sync, report); err != nil {
+		logging.WithFields("idp", sync.IDPID).WithError(err).Warn("ldap sync failed")
+		report.Error = err.Error()
+	}
+	return report
+}
+
+func (s *syncer) runSync(ctx context.Context, sync *query.LDAPSync, report *domain.LDAPSyncReport) error {
+	provider, err := s.ldapProvider(ctx, sync.IDPID)
+	if err != nil {
+		return err
+	}
+	directoryUsers, err := provider.SearchUsers(ctx, sync.Filter, s.config.PageSize, sync.NestedGroups)
+	if err != nil {
+		return err
+	}
+	report.UsersFound = uint32(len(directoryUsers))
+	links, err := s.idpUserLinks(ctx, sync.IDPID)
+	if err != nil {
+		return err
+	}
+	orgID := sync.OrgID
+	if orgID == "" {
+		orgID = sync.ResourceOwner
+		if orgID == authz.GetInstance(ctx).InstanceID() {
+			orgID = authz.GetInstance(ctx).DefaultOrganisationID()
+		}
+	}
+
+	found := make(map[string]struct{}, len(directoryUsers))
+	for _, directoryUser := range directoryUsers {
+		found[directoryUser.GetID()] = struct{}{}
+		if err := s.syncUser(ctx, sync, provider, orgID, links[directoryUser.GetID()], directoryUser, report); err != nil {
+			report.Failed++
+			logging.WithFields("idp", sync.IDPID, "dn", directoryUser.DN).WithError(err).Warn("unable to sync ldap user")
+		}
+	}
+	// an empty result is most likely caused by a misconfiguration, so no user is deactivated
+	if !sync.DeactivateMissing || len(directoryUsers) == 0 {
+		return nil
+	}
+	for externalID, link := range links {
+		if _, ok := found[externalID]; ok {
+			continue
+		}
+		if err := s.deactivateUser(ctx, link, report); err != nil {
+			report.Failed++
+			logging.WithFields("idp", sync.IDPID, "user", link.UserID).WithError(err).Warn("unable to deactivate ldap user")
+		}
+	}
+	return nil
+}
+
+func (s *syncer) ldapProvider(ctx context.Context, idpID string) (*ldap.Provider, error) {
+	provider, err := s.commands.GetProvider(ctx, idpID, "", "")
+	if err != nil {
+		return nil, err
+	}
+	ldapProvider, ok := provider.(*ldap.Provider)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgument(nil, "LDAPS-aiS8o", "Errors.ExternalIDP.IDPTypeNotImplemented")
+	}
+	return ldapProvider, nil
+}
+
+// idpUserLinks returns the links of the identity provider mapped by the external user id
+func (s *syncer) idpUserLinks(ctx context.Context, idpID string) (map[string]*query.IDPUserLink, error) {
+	idpIDQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(idpID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.queries.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{idpIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	mapped := make(map[string]*query.IDPUserLink, len(links.Links))
+	for _, link := range links.Links {
+		mapped[link.ProvidedUserID] = link
+	}
+	return mapped, nil
+}
+
+func (s *syncer) syncUser(ctx context.Context, sync *query.LDAPSync, provider *ldap.Provider, orgID string, link *query.IDPUserLink, directoryUser *ldap.DirectoryUser, report *domain.LDAPSyncReport) (err error) {
+	var userID, resourceOwner string
+	if link == nil {
+		if !provider.IsAutoCreation() {
+			return nil
+		}
+		userID, err = s.createUser(systemContext(ctx, orgID), sync.IDPID, orgID, directoryUser)
+		if err != nil {
+			return err
+		}
+		report.Created++
+		resourceOwner = orgID
+	} else {
+		userID, resourceOwner = link.UserID, link.ResourceOwner
+		if err = s.updateUser(systemContext(ctx, resourceOwner), sync, provider, userID, directoryUser, report); err != nil {
+			return err
+		}
+	}
+	if len(sync.GroupMappings) == 0 {
+		return nil
+	}
+	return s.syncGrants(systemContext(ctx, resourceOwner), sync, userID, resourceOwner, directoryUser.Groups, report)
+}
+
+func (s *syncer) createUser(ctx context.Context, idpID, orgID string, directoryUser *ldap.DirectoryUser) (string, error) {
+	human := &command.AddHuman{
+		Username:          username(directoryUser.User),
+		FirstName:         directoryUser.GetFirstName(),
+		LastName:          directoryUser.GetLastName(),
+		NickName:          directoryUser.GetNickname(),
+		DisplayName:       directoryUser.GetDisplayName(),
+		PreferredLanguage: directoryUser.GetPreferredLanguage(),
+		Email: command.Email{
+			Address:  directoryUser.GetEmail(),
+			Verified: directoryUser.IsEmailVerified(),
+		},
+		Phone: command.Phone{
+			Number:   directoryUser.GetPhone(),
+			Verified: directoryUser.IsPhoneVerified(),
+		},
+		ExternalIDP: true,
+		Links: []*command.AddLink{
+			{
+				IDPID:         idpID,
+				DisplayName:   directoryUser.GetPreferredUsername(),
+				IDPExternalID: directoryUser.GetID(),
+			},
+		},
+	}
+	if err := s.commands.AddHuman(ctx, orgID, human, false); err != nil {
+		return "", err
+	}
+	return human.ID, nil
+}
+
+func (s *syncer) updateUser(ctx context.Context, sync *query.LDAPSync, provider *ldap.Provider, userID string, directoryUser *ldap.DirectoryUser, report *domain.LDAPSyncReport) error {
+	user, err := s.queries.GetUserByID(ctx, false, userID)
+	if err != nil {
+		return err
+	}
+	if user.Human == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "LDAPS-Ee3ph", "Errors.User.NotHuman")
+	}
+	// only users deactivated by the sync are reactivated, deactivations by an admin are kept
+	if sync.DeactivateMissing && user.State == domain.UserStateInactive {
+		reactivated, err := s.commands.ReactivateUserDeactivatedBy(ctx, user.ID, user.ResourceOwner, SyncUserID)
+		if err != nil {
+			return err
+		}
+		if reactivated {
+			report.Reactivated++
+		}
+	}
+	if !provider.IsAutoUpdate() {
+		return nil
+	}
+	updated, err := s.updateUserData(ctx, user, directoryUser)
+	if updated {
+		report.Updated++
+	}
+	return err
+}
+
+func (s *syncer) updateUserData(ctx context.Context, user *query.User, directoryUser *ldap.DirectoryUser) (updated bool, err error) {
+	if hasProfileChanged(user.Human, directoryUser.User) {
+		_, err = s.commands.ChangeHumanProfile(ctx, &domain.Profile{
+			ObjectRoot:        models.ObjectRoot{AggregateID: user.ID, ResourceOwner: user.ResourceOwner},
+			FirstName:         directoryUser.GetFirstName(),
+			LastName:          directoryUser.GetLastName(),
+			NickName:          directoryUser.GetNickname(),
+			DisplayName:       directoryUser.GetDisplayName(),
+			PreferredLanguage: directoryUser.GetPreferredLanguage(),
+			Gender:            user.Human.Gender,
+		})
+		if err != nil {
+			return updated, err
+		}
+		updated = true
+	}
+	if hasEmailChanged(user.Human, directoryUser.User) {
+		emailCodeGenerator, err := s.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyEmailCode, s.userCodeAlg)
+		if err != nil {
+			return updated, err
+		}
+		_, err = s.commands.ChangeHumanEmail(ctx, &domain.Email{
+			ObjectRoot:      models.ObjectRoot{AggregateID: user.ID, ResourceOwner: user.ResourceOwner},
+			EmailAddress:    directoryUser.GetEmail(),
+			IsEmailVerified: directoryUser.IsEmailVerified(),
+		}, emailCodeGenerator)
+		if err != nil {
+			return updated, err
+		}
+		updated = true
+	}
+	phoneChanged, err := hasPhoneChanged(user.Human, directoryUser.User)
+	if err != nil || !phoneChanged {
+		return updated, err
+	}
+	phoneCodeGenerator, err := s.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyPhoneCode, s.userCodeAlg)
+	if err != nil {
+		return updated, err
+	}
+	_, err = s.commands.ChangeHumanPhone(ctx, &domain.Phone{
+		ObjectRoot:      models.ObjectRoot{AggregateID: user.ID},
+		PhoneNumber:     directoryUser.GetPhone(),
+		IsPhoneVerified: directoryUser.IsPhoneVerified(),
+	}, user.ResourceOwner, phoneCodeGenerator)
+	if err != nil {
+		return updated, err
+	}
+	return true, nil
+}
+
+func (s *syncer) deactivateUser(ctx context.Context, link *query.IDPUserLink, report *domain.LDAPSyncReport) error {
+	ctx = systemContext(ctx, link.ResourceOwner)
+	user, err := s.queries.GetUserByID(ctx, false, link.UserID)
+	if err != nil {
+		return err
+	}
+	// initial users can't be deactivated, they are not able to login without the directory anyway
+	if user.State != domain.UserStateActive {
+		return nil
+	}
+	if _, err = s.commands.DeactivateUser(ctx, user.ID, user.ResourceOwner); err != nil {
+		return err
+	}
+	report.Deactivated++
+	return nil
+}
+
+func (s *syncer) syncGrants(ctx context.Context, sync *query.LDAPSync, userID, resourceOwner string, groups []string, report *domain.LDAPSyncReport) error {
+	rolesByProject := sync.RolesByProject(groups)
+	projectIDs := make([]string, 0, len(rolesByProject))
+	for projectID := range rolesByProject {
+		projectIDs = append(projectIDs, projectID)
+	}
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return err
+	}
+	projectIDsQuery, err := query.NewUserGrantProjectIDsSearchQuery(projectIDs)
+	if err != nil {
+		return err
+	}
+	resourceOwnerQuery, err := query.NewUserGrantResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return err
+	}
+	grants, err := s.queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery, projectIDsQuery, resourceOwnerQuery}}, false)
+	if err != nil {
+		return err
+	}
+	existingGrantIDs := make(map[string]string, len(grants.UserGrants))
+	for _, grant := range grants.UserGrants {
+		existingGrantIDs[grant.ProjectID] = grant.ID
+	}
+	result, err := s.commands.SyncUserGrants(ctx, userID, resourceOwner, rolesByProject, existingGrantIDs, false)
+	if err != nil {
+		return err
+	}
+	report.GrantsAdded += result.Added
+	report.GrantsChanged += result.Changed
+	report.GrantsRemoved += result.Removed
+	return nil
+}
+
+func systemContext(ctx context.Context, resourceOwner string) context.Context {
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: SyncUserID, OrgID: resourceOwner})
+}
+
+func username(user *ldap.User) string {
+	if username := user.GetPreferredUsername(); username != "" {
+		return username
+	}
+	if email := user.GetEmail(); email != "" {
+		return string(email)
+	}
+	return user.GetID()
+}
+
+func hasProfileChanged(human *query.Human, user *ldap.User) bool {
+	return user.GetFirstName() != human.FirstName ||
+		user.GetLastName() != human.LastName ||
+		user.GetNickname() != human.NickName ||
+		user.GetDisplayName() != human.DisplayName ||
+		user.GetPreferredLanguage() != human.PreferredLanguage
+}
+
+func hasEmailChanged(human *query.Human, user *ldap.User) bool {
+	email := user.GetEmail().Normalize()
+	if email == "" {
+		return false
+	}
+	// ignore if the same email is not set to verified anymore
+	if email == human.Email && human.IsEmailVerified {
+		return false
+	}
+	return email != human.Email || user.IsEmailVerified() != human.IsEmailVerified
+}
+
+func hasPhoneChanged(human *query.Human, user *ldap.User) (bool, error) {
+	if user.GetPhone() == "" {
+		return false, nil
+	}
+	phone, err := user.GetPhone().Normalize()
+	if err != nil {
+		return false, err
+	}
+	// ignore if the same phone is not set to verified anymore
+	if phone == human.Phone && human.IsPhoneVerified {
+		return false, nil
+	}
+	return phone != human.Phone || user.IsPhoneVerified() != human.IsPhoneVerified, nil
+}
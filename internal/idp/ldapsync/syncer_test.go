@@ -0,0 +1,110 @@
+package ldapsync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_username(t *testing.T) {
+	tests := []struct {
+		name string
+		user *ldap.User
+		want string
+	}{
+		{
+			name: "preferred username",
+			user: &ldap.User{ID: "id", PreferredUsername: "username", Email: "user@example.com"},
+			want: "username",
+		},
+		{
+			name: "email",
+			user: &ldap.User{ID: "id", Email: "user@example.com"},
+			want: "user@example.com",
+		},
+		{
+			name: "id",
+			user: &ldap.User{ID: "id"},
+			want: "id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, username(tt.user))
+		})
+	}
+}
+
+func Test_hasProfileChanged(t *testing.T) {
+	human := &query.Human{
+		FirstName:         "first",
+		LastName:          "last",
+		NickName:          "nick",
+		DisplayName:       "display",
+		PreferredLanguage: language.German,
+	}
+	tests := []struct {
+		name string
+		user *ldap.User
+		want bool
+	}{
+		{
+			name: "unchanged",
+			user: &ldap.User{FirstName: "first", LastName: "last", NickName: "nick", DisplayName: "display", PreferredLanguage: language.German},
+			want: false,
+		},
+		{
+			name: "last name changed",
+			user: &ldap.User{FirstName: "first", LastName: "changed", NickName: "nick", DisplayName: "display", PreferredLanguage: language.German},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasProfileChanged(human, tt.user))
+		})
+	}
+}
+
+func Test_hasEmailChanged(t *testing.T) {
+	tests := []struct {
+		name  string
+		human *query.Human
+		user  *ldap.User
+		want  bool
+	}{
+		{
+			name:  "no email in directory",
+			human: &query.Human{Email: "user@example.com"},
+			user:  &ldap.User{},
+			want:  false,
+		},
+		{
+			name:  "same verified email, not verified in directory",
+			human: &query.Human{Email: "user@example.com", IsEmailVerified: true},
+			user:  &ldap.User{Email: " user@example.com "},
+			want:  false,
+		},
+		{
+			name:  "verified in directory",
+			human: &query.Human{Email: "user@example.com"},
+			user:  &ldap.User{Email: "user@example.com", EmailVerified: true},
+			want:  true,
+		},
+		{
+			name:  "different email",
+			human: &query.Human{Email: "user@example.com", IsEmailVerified: true},
+			user:  &ldap.User{Email: "new@example.com", EmailVerified: true},
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasEmailChanged(tt.human, tt.user))
+		})
+	}
+}
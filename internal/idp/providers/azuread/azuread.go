@@ -19,8 +19,11 @@ const (
 	keysURLTemplate  string = "https://login.microsoftonline.com/%s/discovery/v2.0/keys"
 	userURL          string = "https://graph.microsoft.com/v1.0/me"
 	userinfoEndpoint string = "https://graph.microsoft.com/oidc/userinfo"
+	groupsURL        string = "https://graph.microsoft.com/v1.0/me/memberOf/microsoft.graph.group?$select=id"
 
 	ScopeUserRead string = "User.Read"
+	// ScopeGroupMemberRead is required to fetch the groups of the user
+	ScopeGroupMemberRead string = "GroupMember.Read.All"
 )
 
 // TenantType are the well known tenant types to scope the users that can authenticate. TenantType is not an
@@ -57,6 +60,13 @@ func (p *Provider) issuer() string {
 	return fmt.Sprintf(issuerTemplate, p.tenant)
 }
 
+// isMultiTenant returns true if users of any tenant are allowed to authenticate
+func (p *Provider) isMultiTenant() bool {
+	return p.tenant == CommonTenant ||
+		p.tenant == OrganizationsTenant ||
+		p.tenant == ConsumersTenant
+}
+
 // keysEndpoint returns the OIDC jwks_url based on the [TenantType]
 func (p *Provider) keysEndpoint() string {
 	return fmt.Sprintf(keysURLTemplate, p.tenant)
@@ -2,6 +2,8 @@ package azuread
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
@@ -12,6 +14,13 @@ import (
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 )
 
+var ErrTenantMissing = errors.New("id_token does not contain a tenant id")
+
+var (
+	_ idp.SessionSupportsMigration = (*Session)(nil)
+	_ idp.SessionSupportsGroups    = (*Session)(nil)
+)
+
 // Session extends the [oauth.Session] to be able to handle the id_token and to implement the [idp.SessionSupportsMigration] functionality
 type Session struct {
 	*Provider
@@ -39,6 +48,38 @@ func (s *Session) RetrievePreviousID() (string, error) {
 	return userinfo.Subject, nil
 }
 
+// groups is the paginated response of the group memberships of the user
+// https://learn.microsoft.com/en-us/graph/api/user-list-memberof
+type groups struct {
+	Value []struct {
+		ID string `json:"id"`
+	} `json:"value"`
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// FetchGroups implements the [idp.SessionSupportsGroups] interface.
+// It returns the object ids of the groups the user is a direct member of, like the `groups` claim of Azure AD.
+// The [ScopeGroupMemberRead] scope is required.
+func (s *Session) FetchGroups(ctx context.Context) ([]string, error) {
+	var ids []string
+	for next := groupsURL; next != ""; {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("authorization", s.oauth().Tokens.TokenType+" "+s.oauth().Tokens.AccessToken)
+		page := new(groups)
+		if err := httphelper.HttpRequest(s.Provider.HttpClient(), req, page); err != nil {
+			return nil, err
+		}
+		for _, group := range page.Value {
+			ids = append(ids, group.ID)
+		}
+		next = page.NextLink
+	}
+	return ids, nil
+}
+
 // FetchUser implements the [idp.Session] interface.
 // It will execute an OAuth 2.0 code exchange if needed to retrieve the access token,
 // call the specified userEndpoint and map the received information into an [idp.User].
@@ -48,20 +89,23 @@ func (s *Session) FetchUser(ctx context.Context) (user idp.User, err error) {
 	if err != nil {
 		return nil, err
 	}
+	idToken, ok := s.oauth().Tokens.Extra("id_token").(string)
+	if !ok {
+		return user, nil
+	}
+	issuer := s.Provider.issuer()
 	// since azure will sign the id_token always with the issuer of the application it might differ from
 	// the issuer the auth and token were based on, e.g. when allowing all account types to login,
 	// then the auth endpoint must be `https://login.microsoftonline.com/common/oauth2/v2.0/authorize`
 	// even though the issuer would be like `https://login.microsoftonline.com/d8cdd43f-fd94-4576-8deb-f3bfea72dc2e/v2.0`
-	if s.Provider.tenant == CommonTenant ||
-		s.Provider.tenant == OrganizationsTenant ||
-		s.Provider.tenant == ConsumersTenant {
-		return user, nil
-	}
-	idToken, ok := s.oauth().Tokens.Extra("id_token").(string)
-	if !ok {
-		return user, nil
+	// so any tenant is accepted, but the token must be issued by the tenant it claims to be from.
+	if s.Provider.isMultiTenant() {
+		issuer, err = tenantIssuer(idToken)
+		if err != nil {
+			return nil, err
+		}
 	}
-	idTokenVerifier := rp.NewIDTokenVerifier(s.Provider.issuer(), s.Provider.OAuthConfig().ClientID, rp.NewRemoteKeySet(s.Provider.HttpClient(), s.Provider.keysEndpoint()))
+	idTokenVerifier := rp.NewIDTokenVerifier(issuer, s.Provider.OAuthConfig().ClientID, rp.NewRemoteKeySet(s.Provider.HttpClient(), s.Provider.keysEndpoint()))
 	s.oauth().Tokens.IDTokenClaims, err = rp.VerifyTokens[*oidc.IDTokenClaims](ctx, s.oauth().Tokens.AccessToken, idToken, idTokenVerifier)
 	if err != nil {
 		return nil, err
@@ -70,6 +114,21 @@ func (s *Session) FetchUser(ctx context.Context) (user idp.User, err error) {
 	return user, nil
 }
 
+// tenantIssuer returns the issuer of the tenant (`tid` claim) the id_token was issued for.
+// The token is not verified yet, the returned issuer is used for the verification itself.
+func tenantIssuer(idToken string) (string, error) {
+	claims := new(struct {
+		TenantID string `json:"tid"`
+	})
+	if _, err := oidc.ParseToken(idToken, claims); err != nil {
+		return "", err
+	}
+	if claims.TenantID == "" {
+		return "", ErrTenantMissing
+	}
+	return fmt.Sprintf(issuerTemplate, claims.TenantID), nil
+}
+
 // Tokens returns the [oidc.Tokens] of the underlying [oauth.Session].
 func (s *Session) Tokens() *oidc.Tokens[*oidc.IDTokenClaims] {
 	return s.oauth().Tokens
@@ -2,6 +2,7 @@ package azuread
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"net/http"
 	"testing"
@@ -414,3 +415,102 @@ func TestSession_RetrievePreviousID(t *testing.T) {
 		})
 	}
 }
+
+func TestSession_FetchGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		httpMock func()
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name: "missing permission",
+			httpMock: func() {
+				gock.New("https://graph.microsoft.com").
+					Get("/v1.0/me/memberOf/microsoft.graph.group").
+					Reply(403)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			httpMock: func() {
+				gock.New("https://graph.microsoft.com").
+					Get("/v1.0/me/memberOf/microsoft.graph.group").
+					MatchParam("$select", "id").
+					Reply(200).
+					JSON(`{"value":[{"id":"group1"},{"id":"group2"}],"@odata.nextLink":"https://graph.microsoft.com/v1.0/me/memberOf/microsoft.graph.group?$skiptoken=next"}`)
+				gock.New("https://graph.microsoft.com").
+					Get("/v1.0/me/memberOf/microsoft.graph.group").
+					MatchParam("$skiptoken", "next").
+					Reply(200).
+					JSON(`{"value":[{"id":"group3"}]}`)
+			},
+			want: []string{"group1", "group2", "group3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			tt.httpMock()
+
+			provider, err := New("", "clientID", "clientSecret", "redirectURI", nil)
+			require.NoError(t, err)
+			session := &Session{
+				Provider: provider,
+				OAuthSession: &oauth.Session{
+					Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+						Token: &oauth2.Token{
+							AccessToken: "accessToken",
+							TokenType:   oidc.BearerToken,
+						},
+					},
+					Provider: provider.Provider,
+				}}
+
+			groups, err := session.FetchGroups(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, groups)
+		})
+	}
+}
+
+func Test_tenantIssuer(t *testing.T) {
+	tests := []struct {
+		name    string
+		idToken string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "invalid token",
+			idToken: "invalid",
+			wantErr: true,
+		},
+		{
+			name:    "missing tenant",
+			idToken: "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"sub"}`)) + ".signature",
+			wantErr: true,
+		},
+		{
+			name:    "tenant",
+			idToken: "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"sub","tid":"d8cdd43f-fd94-4576-8deb-f3bfea72dc2e"}`)) + ".signature",
+			want:    "https://login.microsoftonline.com/d8cdd43f-fd94-4576-8deb-f3bfea72dc2e/v2.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tenantIssuer(tt.idToken)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package bitbucket
+
+import (
+	"golang.org/x/oauth2"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+)
+
+const (
+	authURL   = "https://bitbucket.org/site/oauth2/authorize"
+	tokenURL  = "https://bitbucket.org/site/oauth2/access_token"
+	userURL   = "https://api.bitbucket.org/2.0/user"
+	emailsURL = "https://api.bitbucket.org/2.0/user/emails"
+	name      = "Bitbucket"
+
+	ScopeAccount = "account"
+	ScopeEmail   = "email"
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// New creates a Bitbucket provider using the [oauth.Provider] (OAuth 2.0 generic provider).
+// If no scopes are provided, `account email` will be requested.
+func New(clientID, secret, callbackURL string, scopes []string, options ...oauth.ProviderOpts) (*Provider, error) {
+	rp, err := oauth.New(
+		newConfig(clientID, secret, callbackURL, scopes),
+		name,
+		userURL,
+		func() idp.User {
+			return new(User)
+		},
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Provider: rp,
+	}, nil
+}
+
+// Provider is the [idp.Provider] implementation for Bitbucket
+type Provider struct {
+	*oauth.Provider
+}
+
+func newConfig(clientID, secret, callbackURL string, scopes []string) *oauth2.Config {
+	if len(scopes) == 0 {
+		scopes = []string{ScopeAccount, ScopeEmail}
+	}
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: secret,
+		RedirectURL:  callbackURL,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  authURL,
+			TokenURL: tokenURL,
+		},
+		Scopes: scopes,
+	}
+}
+
+// User is a representation of the authenticated Bitbucket user and implements the [idp.User] interface
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-get
+//
+// Bitbucket does not return the email on the user endpoint, so it's set by the [Session]
+// from the primary address of the user's email addresses.
+type User struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	Username    string `json:"username"`
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name"`
+	Links       struct {
+		HTML   Link `json:"html"`
+		Avatar Link `json:"avatar"`
+	} `json:"links"`
+
+	Email         domain.EmailAddress `json:"-"`
+	EmailVerified bool                `json:"-"`
+}
+
+type Link struct {
+	Href string `json:"href"`
+}
+
+// GetID is an implementation of the [idp.User] interface.
+func (u *User) GetID() string {
+	return u.UUID
+}
+
+// GetFirstName is an implementation of the [idp.User] interface.
+// It returns an empty string because Bitbucket does not provide the user's firstname.
+func (u *User) GetFirstName() string {
+	return ""
+}
+
+// GetLastName is an implementation of the [idp.User] interface.
+// It returns an empty string because Bitbucket does not provide the user's lastname.
+func (u *User) GetLastName() string {
+	return ""
+}
+
+// GetDisplayName is an implementation of the [idp.User] interface.
+func (u *User) GetDisplayName() string {
+	return u.DisplayName
+}
+
+// GetNickname is an implementation of the [idp.User] interface.
+func (u *User) GetNickname() string {
+	return u.Nickname
+}
+
+// GetPreferredUsername is an implementation of the [idp.User] interface
+// returning the username of the Bitbucket user.
+func (u *User) GetPreferredUsername() string {
+	return u.Username
+}
+
+// GetEmail is an implementation of the [idp.User] interface.
+func (u *User) GetEmail() domain.EmailAddress {
+	return u.Email
+}
+
+// IsEmailVerified is an implementation of the [idp.User] interface.
+// It returns if the primary email was confirmed on Bitbucket.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerified
+}
+
+// GetPhone is an implementation of the [idp.User] interface.
+// It returns an empty string because Bitbucket does not provide the user's phone.
+func (u *User) GetPhone() domain.PhoneNumber {
+	return ""
+}
+
+// IsPhoneVerified is an implementation of the [idp.User] interface
+// it returns false because Bitbucket does not provide the user's phone
+func (u *User) IsPhoneVerified() bool {
+	return false
+}
+
+// GetPreferredLanguage is an implementation of the [idp.User] interface.
+// It returns [language.Und] because Bitbucket does not provide the user's language.
+func (u *User) GetPreferredLanguage() language.Tag {
+	return language.Und
+}
+
+// GetProfile is an implementation of the [idp.User] interface.
+func (u *User) GetProfile() string {
+	return u.Links.HTML.Href
+}
+
+// GetAvatarURL is an implementation of the [idp.User] interface.
+func (u *User) GetAvatarURL() string {
+	return u.Links.Avatar.Href
+}
@@ -0,0 +1,85 @@
+package bitbucket
+
+import (
+	"context"
+	"net/http"
+
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+)
+
+var _ idp.Session = (*Session)(nil)
+
+// Session extends the [oauth.Session] to retrieve the email of the user, which is not part of the user endpoint.
+type Session struct {
+	*Provider
+	Code string
+
+	OAuthSession *oauth.Session
+}
+
+// emails is the paginated response of the email endpoint
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-emails-get
+type emails struct {
+	Values []struct {
+		Email       domain.EmailAddress `json:"email"`
+		IsPrimary   bool                `json:"is_primary"`
+		IsConfirmed bool                `json:"is_confirmed"`
+	} `json:"values"`
+}
+
+// GetAuth implements the [idp.Session] interface by calling the wrapped [oauth.Session].
+func (s *Session) GetAuth(ctx context.Context) (content string, redirect bool) {
+	return s.oauth().GetAuth(ctx)
+}
+
+// FetchUser implements the [idp.Session] interface.
+// It will execute an OAuth 2.0 code exchange if needed to retrieve the access token,
+// call the user endpoint and additionally the email endpoint to set the primary email of the user.
+func (s *Session) FetchUser(ctx context.Context) (_ idp.User, err error) {
+	user, err := s.oauth().FetchUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bitbucketUser, ok := user.(*User)
+	if !ok {
+		return user, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, emailsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("authorization", s.oauth().Tokens.TokenType+" "+s.oauth().Tokens.AccessToken)
+	addresses := new(emails)
+	if err := httphelper.HttpRequest(s.Provider.HttpClient(), req, addresses); err != nil {
+		return nil, err
+	}
+	for _, address := range addresses.Values {
+		if address.IsPrimary {
+			bitbucketUser.Email = address.Email
+			bitbucketUser.EmailVerified = address.IsConfirmed
+			break
+		}
+	}
+	return bitbucketUser, nil
+}
+
+// Tokens returns the [oidc.Tokens] of the underlying [oauth.Session].
+func (s *Session) Tokens() *oidc.Tokens[*oidc.IDTokenClaims] {
+	return s.oauth().Tokens
+}
+
+func (s *Session) oauth() *oauth.Session {
+	if s.OAuthSession != nil {
+		return s.OAuthSession
+	}
+	s.OAuthSession = &oauth.Session{
+		Code:     s.Code,
+		Provider: s.Provider.Provider,
+	}
+	return s.OAuthSession
+}
@@ -0,0 +1,138 @@
+package bitbucket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+)
+
+func TestSession_FetchUser(t *testing.T) {
+	type want struct {
+		err               bool
+		id                string
+		displayName       string
+		preferredUsername string
+		email             domain.EmailAddress
+		isEmailVerified   bool
+		avatarURL         string
+		profile           string
+	}
+	tests := []struct {
+		name     string
+		httpMock func()
+		want     want
+	}{
+		{
+			name: "user error",
+			httpMock: func() {
+				gock.New("https://api.bitbucket.org").
+					Get("/2.0/user").
+					Reply(http.StatusInternalServerError)
+			},
+			want: want{
+				err: true,
+			},
+		},
+		{
+			name: "emails error",
+			httpMock: func() {
+				gock.New("https://api.bitbucket.org").
+					Get("/2.0/user").
+					Reply(http.StatusOK).
+					JSON(user())
+				gock.New("https://api.bitbucket.org").
+					Get("/2.0/user/emails").
+					Reply(http.StatusInternalServerError)
+			},
+			want: want{
+				err: true,
+			},
+		},
+		{
+			name: "successful fetch",
+			httpMock: func() {
+				gock.New("https://api.bitbucket.org").
+					Get("/2.0/user").
+					Reply(http.StatusOK).
+					JSON(user())
+				gock.New("https://api.bitbucket.org").
+					Get("/2.0/user/emails").
+					Reply(http.StatusOK).
+					JSON(map[string]any{
+						"values": []map[string]any{
+							{"email": "other@example.com", "is_primary": false, "is_confirmed": false},
+							{"email": "primary@example.com", "is_primary": true, "is_confirmed": true},
+						},
+					})
+			},
+			want: want{
+				id:                "{uuid}",
+				displayName:       "display name",
+				preferredUsername: "username",
+				email:             "primary@example.com",
+				isEmailVerified:   true,
+				avatarURL:         "https://bitbucket.org/account/username/avatar/",
+				profile:           "https://bitbucket.org/username/",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			tt.httpMock()
+			a := assert.New(t)
+
+			provider, err := New("clientID", "clientSecret", "redirectURI", nil)
+			require.NoError(t, err)
+			session := &Session{
+				Provider: provider,
+				OAuthSession: &oauth.Session{
+					Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+						Token: &oauth2.Token{
+							AccessToken: "accessToken",
+							TokenType:   oidc.BearerToken,
+						},
+					},
+					Provider: provider.Provider,
+				},
+			}
+
+			user, err := session.FetchUser(context.Background())
+			if tt.want.err {
+				a.Error(err)
+				return
+			}
+			require.NoError(t, err)
+			a.Equal(tt.want.id, user.GetID())
+			a.Equal(tt.want.displayName, user.GetDisplayName())
+			a.Equal(tt.want.preferredUsername, user.GetPreferredUsername())
+			a.Equal(tt.want.email, user.GetEmail())
+			a.Equal(tt.want.isEmailVerified, user.IsEmailVerified())
+			a.Equal(tt.want.avatarURL, user.GetAvatarURL())
+			a.Equal(tt.want.profile, user.GetProfile())
+		})
+	}
+}
+
+func user() map[string]any {
+	return map[string]any{
+		"uuid":         "{uuid}",
+		"account_id":   "account-id",
+		"username":     "username",
+		"nickname":     "nickname",
+		"display_name": "display name",
+		"links": map[string]any{
+			"html":   map[string]any{"href": "https://bitbucket.org/username/"},
+			"avatar": map[string]any{"href": "https://bitbucket.org/account/username/avatar/"},
+		},
+	}
+}
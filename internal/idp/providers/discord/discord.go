@@ -0,0 +1,158 @@
+package discord
+
+import (
+	"fmt"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+)
+
+const (
+	authURL           = "https://discord.com/oauth2/authorize"
+	tokenURL          = "https://discord.com/api/oauth2/token"
+	userURL           = "https://discord.com/api/users/@me"
+	avatarURLTemplate = "https://cdn.discordapp.com/avatars/%s/%s.png"
+	name              = "Discord"
+
+	ScopeIdentify = "identify"
+	ScopeEmail    = "email"
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// New creates a Discord provider using the [oauth.Provider] (OAuth 2.0 generic provider).
+// If no scopes are provided, `identify email` will be requested.
+func New(clientID, secret, callbackURL string, scopes []string, options ...oauth.ProviderOpts) (*Provider, error) {
+	rp, err := oauth.New(
+		newConfig(clientID, secret, callbackURL, scopes),
+		name,
+		userURL,
+		func() idp.User {
+			return new(User)
+		},
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Provider: rp,
+	}, nil
+}
+
+// Provider is the [idp.Provider] implementation for Discord
+type Provider struct {
+	*oauth.Provider
+}
+
+func newConfig(clientID, secret, callbackURL string, scopes []string) *oauth2.Config {
+	if len(scopes) == 0 {
+		scopes = []string{ScopeIdentify, ScopeEmail}
+	}
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: secret,
+		RedirectURL:  callbackURL,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  authURL,
+			TokenURL: tokenURL,
+		},
+		Scopes: scopes,
+	}
+}
+
+// User is a representation of the authenticated Discord user and implements the [idp.User] interface
+// https://discord.com/developers/docs/resources/user#user-object
+type User struct {
+	ID         string              `json:"id"`
+	Username   string              `json:"username"`
+	GlobalName string              `json:"global_name"`
+	Avatar     string              `json:"avatar"`
+	Email      domain.EmailAddress `json:"email"`
+	Verified   bool                `json:"verified"`
+	Locale     string              `json:"locale"`
+}
+
+// GetID is an implementation of the [idp.User] interface.
+func (u *User) GetID() string {
+	return u.ID
+}
+
+// GetFirstName is an implementation of the [idp.User] interface.
+// It returns an empty string because Discord does not provide the user's firstname.
+func (u *User) GetFirstName() string {
+	return ""
+}
+
+// GetLastName is an implementation of the [idp.User] interface.
+// It returns an empty string because Discord does not provide the user's lastname.
+func (u *User) GetLastName() string {
+	return ""
+}
+
+// GetDisplayName is an implementation of the [idp.User] interface.
+// It returns the global name and falls back to the username if none is set.
+func (u *User) GetDisplayName() string {
+	if u.GlobalName != "" {
+		return u.GlobalName
+	}
+	return u.Username
+}
+
+// GetNickname is an implementation of the [idp.User] interface
+// returning the username of the Discord user.
+func (u *User) GetNickname() string {
+	return u.Username
+}
+
+// GetPreferredUsername is an implementation of the [idp.User] interface
+// returning the username of the Discord user.
+func (u *User) GetPreferredUsername() string {
+	return u.Username
+}
+
+// GetEmail is an implementation of the [idp.User] interface.
+func (u *User) GetEmail() domain.EmailAddress {
+	return u.Email
+}
+
+// IsEmailVerified is an implementation of the [idp.User] interface.
+func (u *User) IsEmailVerified() bool {
+	return u.Verified
+}
+
+// GetPhone is an implementation of the [idp.User] interface.
+// It returns an empty string because Discord does not provide the user's phone.
+func (u *User) GetPhone() domain.PhoneNumber {
+	return ""
+}
+
+// IsPhoneVerified is an implementation of the [idp.User] interface
+// it returns false because Discord does not provide the user's phone
+func (u *User) IsPhoneVerified() bool {
+	return false
+}
+
+// GetPreferredLanguage is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredLanguage() language.Tag {
+	return language.Make(u.Locale)
+}
+
+// GetProfile is an implementation of the [idp.User] interface.
+// It returns an empty string because Discord does not provide a profile page of the user.
+func (u *User) GetProfile() string {
+	return ""
+}
+
+// GetAvatarURL is an implementation of the [idp.User] interface.
+// It returns the URL of the user's avatar on the Discord CDN if the user has set one.
+func (u *User) GetAvatarURL() string {
+	if u.Avatar == "" {
+		return ""
+	}
+	return fmt.Sprintf(avatarURLTemplate, u.ID, u.Avatar)
+}
@@ -0,0 +1,81 @@
+package discord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+)
+
+func TestProvider_BeginAuth(t *testing.T) {
+	type fields struct {
+		clientID     string
+		clientSecret string
+		redirectURI  string
+		scopes       []string
+		options      []oauth.ProviderOpts
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   idp.Session
+	}{
+		{
+			name: "successful auth",
+			fields: fields{
+				clientID:     "clientID",
+				clientSecret: "clientSecret",
+				redirectURI:  "redirectURI",
+			},
+			want: &oauth.Session{
+				AuthURL: "https://discord.com/oauth2/authorize?client_id=clientID&prompt=select_account&redirect_uri=redirectURI&response_type=code&scope=identify+email&state=testState",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			r := require.New(t)
+
+			provider, err := New(tt.fields.clientID, tt.fields.clientSecret, tt.fields.redirectURI, tt.fields.scopes, tt.fields.options...)
+			r.NoError(err)
+
+			ctx := context.Background()
+			session, err := provider.BeginAuth(ctx, "testState")
+			r.NoError(err)
+
+			wantHeaders, wantContent := tt.want.GetAuth(ctx)
+			gotHeaders, gotContent := session.GetAuth(ctx)
+			a.Equal(wantHeaders, gotHeaders)
+			a.Equal(wantContent, gotContent)
+		})
+	}
+}
+
+func TestUser_GetAvatarURL(t *testing.T) {
+	tests := []struct {
+		name string
+		user *User
+		want string
+	}{
+		{
+			name: "no avatar",
+			user: &User{ID: "id"},
+			want: "",
+		},
+		{
+			name: "avatar",
+			user: &User{ID: "id", Avatar: "hash"},
+			want: "https://cdn.discordapp.com/avatars/id/hash.png",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.user.GetAvatarURL())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package github
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -43,12 +44,15 @@ func NewCustomURL(name, clientID, secret, callbackURL, authURL, tokenURL, profil
 	}
 	return &Provider{
 		Provider: rp,
+		apiURL:   strings.TrimSuffix(profileURL, "/user"),
 	}, nil
 }
 
 // Provider is the [idp.Provider] implementation for GitHub
 type Provider struct {
 	*oauth.Provider
+	// apiURL is the base of the REST API, e.g. to fetch the organizations and teams of the user
+	apiURL string
 }
 
 func newConfig(clientID, secret, callbackURL, authURL, tokenURL string, scopes []string) *oauth2.Config {
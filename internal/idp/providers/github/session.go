@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+)
+
+// pageSize is the maximum count of organizations and teams returned per request
+const pageSize = 100
+
+var (
+	_ idp.Session               = (*Session)(nil)
+	_ idp.SessionSupportsGroups = (*Session)(nil)
+)
+
+// Session extends the [oauth.Session] to fetch the organizations and teams of the user, which are not part of the user endpoint.
+type Session struct {
+	*Provider
+	Code string
+
+	OAuthSession *oauth.Session
+}
+
+// organization is an entry of the organizations of the user
+// https://docs.github.com/en/rest/orgs/orgs?apiVersion=2022-11-28#list-organizations-for-the-authenticated-user
+type organization struct {
+	Login string `json:"login"`
+}
+
+// team is an entry of the teams of the user
+// https://docs.github.com/en/rest/teams/teams?apiVersion=2022-11-28#list-teams-for-the-authenticated-user
+type team struct {
+	Slug         string       `json:"slug"`
+	Organization organization `json:"organization"`
+}
+
+// GetAuth implements the [idp.Session] interface by calling the wrapped [oauth.Session].
+func (s *Session) GetAuth(ctx context.Context) (content string, redirect bool) {
+	return s.oauth().GetAuth(ctx)
+}
+
+// FetchUser implements the [idp.Session] interface by calling the wrapped [oauth.Session].
+func (s *Session) FetchUser(ctx context.Context) (idp.User, error) {
+	return s.oauth().FetchUser(ctx)
+}
+
+// FetchGroups implements the [idp.SessionSupportsGroups] interface.
+// It returns the logins of the organizations and the teams as `organization/team` the user is a member of.
+// The `read:org` scope is required to read private memberships.
+func (s *Session) FetchGroups(ctx context.Context) ([]string, error) {
+	var groups []string
+	for page := 1; ; page++ {
+		var organizations []organization
+		if err := s.get(ctx, "/user/orgs", page, &organizations); err != nil {
+			return nil, err
+		}
+		for _, org := range organizations {
+			groups = append(groups, org.Login)
+		}
+		if len(organizations) < pageSize {
+			break
+		}
+	}
+	for page := 1; ; page++ {
+		var teams []team
+		if err := s.get(ctx, "/user/teams", page, &teams); err != nil {
+			return nil, err
+		}
+		for _, team := range teams {
+			groups = append(groups, team.Organization.Login+"/"+team.Slug)
+		}
+		if len(teams) < pageSize {
+			break
+		}
+	}
+	return groups, nil
+}
+
+func (s *Session) get(ctx context.Context, path string, page int, v any) error {
+	query := url.Values{
+		"per_page": {strconv.Itoa(pageSize)},
+		"page":     {strconv.Itoa(page)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.Provider.apiURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("authorization", s.oauth().Tokens.TokenType+" "+s.oauth().Tokens.AccessToken)
+	return httphelper.HttpRequest(s.Provider.HttpClient(), req, v)
+}
+
+// Tokens returns the [oidc.Tokens] of the underlying [oauth.Session].
+func (s *Session) Tokens() *oidc.Tokens[*oidc.IDTokenClaims] {
+	return s.oauth().Tokens
+}
+
+func (s *Session) oauth() *oauth.Session {
+	if s.OAuthSession != nil {
+		return s.OAuthSession
+	}
+	s.OAuthSession = &oauth.Session{
+		Code:     s.Code,
+		Provider: s.Provider.Provider,
+	}
+	return s.OAuthSession
+}
@@ -214,3 +214,85 @@ func userinfo() *User {
 		UpdatedAt:  time.Date(2023, 01, 10, 11, 10, 35, 0, time.UTC),
 	}
 }
+
+func TestSession_FetchGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		profileURL string
+		httpMock   func()
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:       "organizations error",
+			profileURL: profileURL,
+			httpMock: func() {
+				gock.New("https://api.github.com").
+					Get("/user/orgs").
+					Reply(http.StatusForbidden)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "successful fetch",
+			profileURL: profileURL,
+			httpMock: func() {
+				gock.New("https://api.github.com").
+					Get("/user/orgs").
+					MatchParam("page", "1").
+					Reply(http.StatusOK).
+					JSON([]map[string]any{{"login": "zitadel"}, {"login": "caos"}})
+				gock.New("https://api.github.com").
+					Get("/user/teams").
+					MatchParam("page", "1").
+					Reply(http.StatusOK).
+					JSON([]map[string]any{{"slug": "engineering", "organization": map[string]any{"login": "zitadel"}}})
+			},
+			want: []string{"zitadel", "caos", "zitadel/engineering"},
+		},
+		{
+			name:       "enterprise server",
+			profileURL: "https://github.example.com/api/v3/user",
+			httpMock: func() {
+				gock.New("https://github.example.com").
+					Get("/api/v3/user/orgs").
+					Reply(http.StatusOK).
+					JSON([]map[string]any{{"login": "zitadel"}})
+				gock.New("https://github.example.com").
+					Get("/api/v3/user/teams").
+					Reply(http.StatusOK).
+					JSON([]map[string]any{})
+			},
+			want: []string{"zitadel"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			tt.httpMock()
+
+			provider, err := NewCustomURL(name, "clientID", "clientSecret", "redirectURI", authURL, tokenURL, tt.profileURL, nil)
+			require.NoError(t, err)
+			session := &Session{
+				Provider: provider,
+				OAuthSession: &oauth.Session{
+					Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+						Token: &oauth2.Token{
+							AccessToken: "accessToken",
+							TokenType:   oidc.BearerToken,
+						},
+					},
+					Provider: provider.Provider,
+				},
+			}
+
+			groups, err := session.FetchGroups(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, groups)
+		})
+	}
+}
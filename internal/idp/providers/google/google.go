@@ -45,3 +45,9 @@ type User struct {
 func (u *User) GetPreferredUsername() string {
 	return string(u.GetEmail())
 }
+
+// GetClaimValues implements the [idp.ClaimValuesProvider] interface.
+// It returns the values of the claim of the wrapped [idp.User].
+func (u *User) GetClaimValues(claim string) []string {
+	return idp.ClaimValues(u.User, claim)
+}
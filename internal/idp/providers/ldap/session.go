@@ -61,22 +61,20 @@ func (s *Session) FetchUser(_ context.Context) (_ idp.User, err error) {
 	}
 	s.Entry = user
 
-	return mapLDAPEntryToUser(
-		user,
-		s.Provider.idAttribute,
-		s.Provider.firstNameAttribute,
-		s.Provider.lastNameAttribute,
-		s.Provider.displayNameAttribute,
-		s.Provider.nickNameAttribute,
-		s.Provider.preferredUsernameAttribute,
-		s.Provider.emailAttribute,
-		s.Provider.emailVerifiedAttribute,
-		s.Provider.phoneAttribute,
-		s.Provider.phoneVerifiedAttribute,
-		s.Provider.preferredLanguageAttribute,
-		s.Provider.avatarURLAttribute,
-		s.Provider.profileAttribute,
-	)
+	return s.Provider.mapEntry(user)
+}
+
+func additionalAttributes(user *ldap.Entry, names []string) map[string][]string {
+	if len(names) == 0 {
+		return nil
+	}
+	attributes := make(map[string][]string, len(names))
+	for _, name := range names {
+		if values := user.GetAttributeValues(name); len(values) > 0 {
+			attributes[name] = values
+		}
+	}
+	return attributes
 }
 
 func tryBind(
@@ -0,0 +1,156 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	DefaultPageSize = 500
+
+	memberOfAttribute = "memberOf"
+	// matchingRuleInChain is the Active Directory extensible match rule resolving the group membership transitively
+	matchingRuleInChain = "1.2.840.113556.1.4.1941"
+)
+
+var ErrNoServerReachable = errors.New("no LDAP server reachable")
+
+// DirectoryUser is a user found in the directory by [Provider.SearchUsers].
+type DirectoryUser struct {
+	*User
+	DN     string
+	Groups []string
+}
+
+// SearchUsers pages through the base DN and returns all users matching the user object classes of the provider
+// and the optional filter.
+// If nestedGroups is set, the group membership is resolved transitively (Active Directory only),
+// otherwise the groups are taken from the memberOf attribute.
+func (p *Provider) SearchUsers(ctx context.Context, filter string, pageSize uint32, nestedGroups bool) (users []*DirectoryUser, err error) {
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	err = ErrNoServerReachable
+	for _, server := range p.servers {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		users, err = p.searchUsers(server, filter, pageSize, nestedGroups)
+		if err == nil {
+			return users, nil
+		}
+	}
+	return nil, err
+}
+
+func (p *Provider) searchUsers(server, filter string, pageSize uint32, nestedGroups bool) ([]*DirectoryUser, error) {
+	conn, err := getConnection(server, p.startTLS, p.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.Bind(p.bindDN, p.bindPassword); err != nil {
+		return nil, err
+	}
+
+	attributes := p.getNecessaryAttributes()
+	if !nestedGroups {
+		attributes = append(attributes, memberOfAttribute)
+	}
+	searchRequest := ldap.NewSearchRequest(
+		p.baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(p.timeout.Seconds()), false,
+		syncSearchQuery(p.userObjectClasses, filter),
+		attributes,
+		nil,
+	)
+	sr, err := conn.SearchWithPaging(searchRequest, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*DirectoryUser, 0, len(sr.Entries))
+	for _, entry := range sr.Entries {
+		user, err := p.mapEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		groups := entry.GetAttributeValues(memberOfAttribute)
+		if nestedGroups {
+			groups, err = searchNestedGroups(conn, p.baseDN, entry.DN, pageSize, p.timeout.Seconds())
+			if err != nil {
+				return nil, err
+			}
+		}
+		users = append(users, &DirectoryUser{
+			User:   user,
+			DN:     entry.DN,
+			Groups: groups,
+		})
+	}
+	return users, nil
+}
+
+func (p *Provider) mapEntry(entry *ldap.Entry) (*User, error) {
+	user, err := mapLDAPEntryToUser(
+		entry,
+		p.idAttribute,
+		p.firstNameAttribute,
+		p.lastNameAttribute,
+		p.displayNameAttribute,
+		p.nickNameAttribute,
+		p.preferredUsernameAttribute,
+		p.emailAttribute,
+		p.emailVerifiedAttribute,
+		p.phoneAttribute,
+		p.phoneVerifiedAttribute,
+		p.preferredLanguageAttribute,
+		p.avatarURLAttribute,
+		p.profileAttribute,
+	)
+	if err != nil {
+		return nil, err
+	}
+	user.Attributes = additionalAttributes(entry, p.additionalAttributes)
+	return user, nil
+}
+
+func searchNestedGroups(conn *ldap.Conn, baseDN, userDN string, pageSize uint32, timeout float64) ([]string, error) {
+	searchRequest := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(timeout), false,
+		nestedGroupsSearchQuery(userDN),
+		[]string{"dn"},
+		nil,
+	)
+	sr, err := conn.SearchWithPaging(searchRequest, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]string, len(sr.Entries))
+	for i, entry := range sr.Entries {
+		groups[i] = entry.DN
+	}
+	return groups, nil
+}
+
+func syncSearchQuery(objectClasses []string, filter string) string {
+	queries := make([]string, 0, len(objectClasses)+1)
+	for _, class := range objectClasses {
+		queries = append(queries, "(objectClass="+class+")")
+	}
+	if filter != "" {
+		queries = append(queries, filter)
+	}
+	if len(queries) == 0 {
+		return "(objectClass=*)"
+	}
+	return queriesAndToSearchQuery(queries...)
+}
+
+func nestedGroupsSearchQuery(userDN string) string {
+	return "(member:" + matchingRuleInChain + ":=" + ldap.EscapeFilter(userDN) + ")"
+}
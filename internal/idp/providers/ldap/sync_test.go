@@ -0,0 +1,103 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvider_syncSearchQuery(t *testing.T) {
+	type args struct {
+		objectClasses []string
+		filter        string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "zero",
+			args: args{},
+			want: "(objectClass=*)",
+		},
+		{
+			name: "filter only",
+			args: args{filter: "(department=IT)"},
+			want: "(department=IT)",
+		},
+		{
+			name: "object class only",
+			args: args{objectClasses: []string{"user"}},
+			want: "(objectClass=user)",
+		},
+		{
+			name: "object classes and filter",
+			args: args{objectClasses: []string{"user", "person"}, filter: "(department=IT)"},
+			want: "(&(objectClass=user)(objectClass=person)(department=IT))",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			a.Equal(tt.want, syncSearchQuery(tt.args.objectClasses, tt.args.filter))
+		})
+	}
+}
+
+func TestProvider_nestedGroupsSearchQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		userDN string
+		want   string
+	}{
+		{
+			name:   "plain",
+			userDN: "cn=user,dc=example,dc=com",
+			want:   "(member:1.2.840.113556.1.4.1941:=cn=user,dc=example,dc=com)",
+		},
+		{
+			name:   "escaped",
+			userDN: "cn=user (admin),dc=example,dc=com",
+			want:   `(member:1.2.840.113556.1.4.1941:=cn=user \28admin\29,dc=example,dc=com)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			a.Equal(tt.want, nestedGroupsSearchQuery(tt.userDN))
+		})
+	}
+}
+
+func Test_additionalAttributes(t *testing.T) {
+	entry := ldap.NewEntry("cn=user,dc=example,dc=com", map[string][]string{
+		"memberOf": {"cn=admins,dc=example,dc=com", "cn=users,dc=example,dc=com"},
+		"mail":     {"user@example.com"},
+	})
+	tests := []struct {
+		name  string
+		names []string
+		want  map[string][]string
+	}{
+		{
+			name: "none requested",
+			want: nil,
+		},
+		{
+			name:  "requested",
+			names: []string{"memberOf", "department"},
+			want: map[string][]string{
+				"memberOf": {"cn=admins,dc=example,dc=com", "cn=users,dc=example,dc=com"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, additionalAttributes(entry, tt.names))
+		})
+	}
+}
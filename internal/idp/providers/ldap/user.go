@@ -20,6 +20,8 @@ type User struct {
 	PreferredLanguage language.Tag        `json:"preferredLanguage,omitempty"`
 	AvatarURL         string              `json:"avatarURL,omitempty"`
 	Profile           string              `json:"profile,omitempty"`
+	// Attributes contains the values of the additional attributes requested with [WithAdditionalAttributes].
+	Attributes map[string][]string `json:"attributes,omitempty"`
 }
 
 func NewUser(
@@ -51,6 +53,7 @@ func NewUser(
 		preferredLanguage,
 		avatarURL,
 		profile,
+		nil,
 	}
 }
 
@@ -93,3 +96,9 @@ func (u *User) GetAvatarURL() string {
 func (u *User) GetProfile() string {
 	return u.Profile
 }
+
+// GetClaimValues is an implementation of the [idp.ClaimValuesProvider] interface.
+// It returns the values of the additional attribute, e.g. `memberOf`.
+func (u *User) GetClaimValues(attribute string) []string {
+	return u.Attributes[attribute]
+}
@@ -0,0 +1,158 @@
+package linkedin
+
+import (
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"golang.org/x/oauth2"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+)
+
+const (
+	authURL     = "https://www.linkedin.com/oauth/v2/authorization"
+	tokenURL    = "https://www.linkedin.com/oauth/v2/accessToken"
+	userinfoURL = "https://api.linkedin.com/v2/userinfo"
+	name        = "LinkedIn"
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// New creates a LinkedIn provider using the [oauth.Provider] (OAuth 2.0 generic provider)
+// on the endpoints of "Sign In with LinkedIn using OpenID Connect".
+// If no scopes are provided, `openid profile email` will be requested.
+func New(clientID, secret, callbackURL string, scopes []string, options ...oauth.ProviderOpts) (*Provider, error) {
+	rp, err := oauth.New(
+		newConfig(clientID, secret, callbackURL, scopes),
+		name,
+		userinfoURL,
+		func() idp.User {
+			return new(User)
+		},
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Provider: rp,
+	}, nil
+}
+
+// Provider is the [idp.Provider] implementation for LinkedIn
+type Provider struct {
+	*oauth.Provider
+}
+
+func newConfig(clientID, secret, callbackURL string, scopes []string) *oauth2.Config {
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail}
+	}
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: secret,
+		RedirectURL:  callbackURL,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   authURL,
+			TokenURL:  tokenURL,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		Scopes: scopes,
+	}
+}
+
+// User is a representation of the authenticated LinkedIn user and implements the [idp.User] interface
+// https://learn.microsoft.com/en-us/linkedin/consumer/integrations/self-serve/sign-in-with-linkedin-v2
+type User struct {
+	Subject       string              `json:"sub"`
+	Name          string              `json:"name"`
+	GivenName     string              `json:"given_name"`
+	FamilyName    string              `json:"family_name"`
+	Email         domain.EmailAddress `json:"email"`
+	EmailVerified bool                `json:"email_verified"`
+	Picture       string              `json:"picture"`
+	Locale        Locale              `json:"locale"`
+}
+
+// Locale is the locale of the LinkedIn user, which is returned as object instead of a string
+type Locale struct {
+	Country  string `json:"country"`
+	Language string `json:"language"`
+}
+
+// GetID is an implementation of the [idp.User] interface.
+func (u *User) GetID() string {
+	return u.Subject
+}
+
+// GetFirstName is an implementation of the [idp.User] interface.
+func (u *User) GetFirstName() string {
+	return u.GivenName
+}
+
+// GetLastName is an implementation of the [idp.User] interface.
+func (u *User) GetLastName() string {
+	return u.FamilyName
+}
+
+// GetDisplayName is an implementation of the [idp.User] interface.
+func (u *User) GetDisplayName() string {
+	return u.Name
+}
+
+// GetNickname is an implementation of the [idp.User] interface.
+// It returns an empty string because LinkedIn does not provide the user's nickname.
+func (u *User) GetNickname() string {
+	return ""
+}
+
+// GetPreferredUsername is an implementation of the [idp.User] interface
+// returning the email of the LinkedIn user, since LinkedIn does not provide a username.
+func (u *User) GetPreferredUsername() string {
+	return string(u.Email)
+}
+
+// GetEmail is an implementation of the [idp.User] interface.
+func (u *User) GetEmail() domain.EmailAddress {
+	return u.Email
+}
+
+// IsEmailVerified is an implementation of the [idp.User] interface.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerified
+}
+
+// GetPhone is an implementation of the [idp.User] interface.
+// It returns an empty string because LinkedIn does not provide the user's phone.
+func (u *User) GetPhone() domain.PhoneNumber {
+	return ""
+}
+
+// IsPhoneVerified is an implementation of the [idp.User] interface
+// it returns false because LinkedIn does not provide the user's phone
+func (u *User) IsPhoneVerified() bool {
+	return false
+}
+
+// GetPreferredLanguage is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredLanguage() language.Tag {
+	if u.Locale.Language == "" {
+		return language.Und
+	}
+	if u.Locale.Country == "" {
+		return language.Make(u.Locale.Language)
+	}
+	return language.Make(u.Locale.Language + "-" + u.Locale.Country)
+}
+
+// GetProfile is an implementation of the [idp.User] interface.
+// It returns an empty string because the userinfo endpoint does not provide the profile page of the user.
+func (u *User) GetProfile() string {
+	return ""
+}
+
+// GetAvatarURL is an implementation of the [idp.User] interface.
+func (u *User) GetAvatarURL() string {
+	return u.Picture
+}
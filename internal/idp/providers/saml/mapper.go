@@ -88,3 +88,9 @@ func (u *UserMapper) GetAvatarURL() string {
 func (u *UserMapper) GetProfile() string {
 	return ""
 }
+
+// GetClaimValues is an implementation of the [idp.ClaimValuesProvider] interface.
+// It returns the values of the SAML attribute, e.g. `memberOf`.
+func (u *UserMapper) GetClaimValues(attribute string) []string {
+	return u.Attributes[attribute]
+}
@@ -0,0 +1,148 @@
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+var (
+	ErrMissingIDPSSODescriptor   = errors.New("metadata does not contain an IDPSSODescriptor")
+	ErrMultipleIDPSSODescriptors = errors.New("metadata contains multiple IDPSSODescriptors")
+	ErrMissingSigningCertificate = errors.New("metadata does not contain a signing certificate")
+	ErrInvalidSigningCertificate = errors.New("metadata contains an invalid signing certificate")
+	ErrMissingMetadataSignature  = errors.New("metadata is not signed")
+	ErrInvalidMetadataSignature  = errors.New("metadata is not signed by a trusted certificate")
+)
+
+// ParseMetadata parses the metadata of an identity provider and ensures it can be used for authentication,
+// meaning it contains exactly one IDPSSODescriptor with at least one signing certificate.
+func ParseMetadata(metadata []byte) (*saml.EntityDescriptor, error) {
+	entity, err := samlsp.ParseMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+	switch len(entity.IDPSSODescriptors) {
+	case 0:
+		return nil, ErrMissingIDPSSODescriptor
+	case 1:
+	default:
+		return nil, ErrMultipleIDPSSODescriptors
+	}
+	certificates, err := SigningCertificates(entity)
+	if err != nil {
+		return nil, err
+	}
+	if len(certificates) == 0 {
+		return nil, ErrMissingSigningCertificate
+	}
+	return entity, nil
+}
+
+// VerifyMetadataSignature verifies the enveloped signature of the metadata document
+// and ensures it was created with one of the trusted certificates, which must be valid at the time.
+func VerifyMetadataSignature(metadata []byte, trusted []*x509.Certificate, now time.Time) error {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(metadata); err != nil {
+		return err
+	}
+	root := doc.Root()
+	if root == nil || !hasSignature(root) {
+		return ErrMissingMetadataSignature
+	}
+	for _, certificate := range trusted {
+		validation := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: []*x509.Certificate{certificate}})
+		validation.Clock = dsig.NewFakeClockAt(now)
+		if _, err := validation.Validate(root); err == nil {
+			return nil
+		}
+	}
+	return ErrInvalidMetadataSignature
+}
+
+func hasSignature(el *etree.Element) bool {
+	for _, child := range el.ChildElements() {
+		if child.Tag == "Signature" {
+			return true
+		}
+	}
+	return false
+}
+
+// SigningCertificates returns the certificates the identity provider uses to sign its responses.
+// Key descriptors without a use are used for signing as well.
+func SigningCertificates(entity *saml.EntityDescriptor) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for _, descriptor := range entity.IDPSSODescriptors {
+		for _, key := range descriptor.KeyDescriptors {
+			if key.Use != "" && key.Use != "signing" {
+				continue
+			}
+			for _, data := range key.KeyInfo.X509Data.X509Certificates {
+				certificate, err := parseCertificate(data.Data)
+				if err != nil {
+					return nil, err
+				}
+				if !containsCertificate(certificates, certificate) {
+					certificates = append(certificates, certificate)
+				}
+			}
+		}
+	}
+	return certificates, nil
+}
+
+// AppendSigningCertificates adds the certificates as additional signing keys to the IDPSSODescriptor
+// and returns the marshalled metadata.
+// This allows responses signed with a certificate, which was already removed by the identity provider,
+// to be accepted during a rollover.
+func AppendSigningCertificates(entity *saml.EntityDescriptor, certificates []*x509.Certificate) ([]byte, error) {
+	if len(entity.IDPSSODescriptors) == 0 {
+		return nil, ErrMissingIDPSSODescriptor
+	}
+	descriptor := &entity.IDPSSODescriptors[0]
+	for _, certificate := range certificates {
+		descriptor.KeyDescriptors = append(descriptor.KeyDescriptors, saml.KeyDescriptor{
+			Use: "signing",
+			KeyInfo: saml.KeyInfo{
+				X509Data: saml.X509Data{
+					X509Certificates: []saml.X509Certificate{
+						{Data: base64.StdEncoding.EncodeToString(certificate.Raw)},
+					},
+				},
+			},
+		})
+	}
+	// the signature of the metadata is no longer valid after the modification
+	entity.Signature = nil
+	return xml.Marshal(entity)
+}
+
+func parseCertificate(data string) (*x509.Certificate, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(data), ""))
+	if err != nil {
+		return nil, errors.Join(ErrInvalidSigningCertificate, err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidSigningCertificate, err)
+	}
+	return certificate, nil
+}
+
+func containsCertificate(certificates []*x509.Certificate, certificate *x509.Certificate) bool {
+	for _, c := range certificates {
+		if c.Equal(certificate) {
+			return true
+		}
+	}
+	return false
+}
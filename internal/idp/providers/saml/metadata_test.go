@@ -0,0 +1,207 @@
+package saml
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetadata(t *testing.T) {
+	certificate := testCertificate(t, time.Now().Add(time.Hour))
+	tests := []struct {
+		name     string
+		metadata []byte
+		wantErr  func(error) bool
+	}{
+		{
+			name:     "invalid xml",
+			metadata: []byte(">xml<"),
+			wantErr: func(err error) bool {
+				return err != nil
+			},
+		},
+		{
+			name:     "missing IDPSSODescriptor",
+			metadata: []byte(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com"></EntityDescriptor>`),
+			wantErr: func(err error) bool {
+				return errors.Is(err, ErrMissingIDPSSODescriptor)
+			},
+		},
+		{
+			name:     "missing signing certificate",
+			metadata: testMetadata("https://idp.example.com"),
+			wantErr: func(err error) bool {
+				return errors.Is(err, ErrMissingSigningCertificate)
+			},
+		},
+		{
+			name:     "valid",
+			metadata: testMetadata("https://idp.example.com", certificate),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity, err := ParseMetadata(tt.metadata)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://idp.example.com", entity.EntityID)
+		})
+	}
+}
+
+func TestSigningCertificates(t *testing.T) {
+	first := testCertificate(t, time.Now().Add(time.Hour))
+	second := testCertificate(t, time.Now().Add(2*time.Hour))
+	entity, err := ParseMetadata(testMetadata("https://idp.example.com", first, second, first))
+	require.NoError(t, err)
+
+	certificates, err := SigningCertificates(entity)
+	require.NoError(t, err)
+	require.Len(t, certificates, 2)
+	assert.Equal(t, first, certificates[0].Raw)
+	assert.Equal(t, second, certificates[1].Raw)
+}
+
+func TestAppendSigningCertificates(t *testing.T) {
+	current := testCertificate(t, time.Now().Add(time.Hour))
+	retired := testCertificate(t, time.Now().Add(time.Hour))
+	entity, err := ParseMetadata(testMetadata("https://idp.example.com", current))
+	require.NoError(t, err)
+	retiredCertificate, err := x509.ParseCertificate(retired)
+	require.NoError(t, err)
+
+	metadata, err := AppendSigningCertificates(entity, []*x509.Certificate{retiredCertificate})
+	require.NoError(t, err)
+
+	entity, err = ParseMetadata(metadata)
+	require.NoError(t, err)
+	certificates, err := SigningCertificates(entity)
+	require.NoError(t, err)
+	require.Len(t, certificates, 2)
+	assert.Equal(t, current, certificates[0].Raw)
+	assert.Equal(t, retired, certificates[1].Raw)
+}
+
+func TestVerifyMetadataSignature(t *testing.T) {
+	now := time.Now()
+	trusted, trustedKey := testKeyPair(t, now.Add(time.Hour))
+	other, otherKey := testKeyPair(t, now.Add(time.Hour))
+	expired, expiredKey := testKeyPair(t, now.Add(-time.Hour))
+	metadata := testMetadata("https://idp.example.com", trusted.Raw)
+	tests := []struct {
+		name     string
+		metadata []byte
+		trusted  []*x509.Certificate
+		wantErr  error
+	}{
+		{
+			name:     "unsigned",
+			metadata: metadata,
+			trusted:  []*x509.Certificate{trusted},
+			wantErr:  ErrMissingMetadataSignature,
+		},
+		{
+			name:     "untrusted certificate",
+			metadata: testSignedMetadata(t, metadata, other, otherKey),
+			trusted:  []*x509.Certificate{trusted},
+			wantErr:  ErrInvalidMetadataSignature,
+		},
+		{
+			name:     "expired certificate",
+			metadata: testSignedMetadata(t, metadata, expired, expiredKey),
+			trusted:  []*x509.Certificate{expired},
+			wantErr:  ErrInvalidMetadataSignature,
+		},
+		{
+			name:     "modified",
+			metadata: []byte(strings.Replace(string(testSignedMetadata(t, metadata, trusted, trustedKey)), "https://idp.example.com/sso", "https://evil.example.com/sso", 1)),
+			trusted:  []*x509.Certificate{trusted},
+			wantErr:  ErrInvalidMetadataSignature,
+		},
+		{
+			name:     "trusted certificate",
+			metadata: testSignedMetadata(t, metadata, trusted, trustedKey),
+			trusted:  []*x509.Certificate{other, trusted},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyMetadataSignature(tt.metadata, tt.trusted, now)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func testKeyPair(t *testing.T, notAfter time.Time) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certificate, key
+}
+
+func testSignedMetadata(t *testing.T, metadata []byte, certificate *x509.Certificate, key *rsa.PrivateKey) []byte {
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromBytes(metadata))
+	signing := dsig.NewDefaultSigningContext(dsig.TLSCertKeyStore(tls.Certificate{
+		Certificate: [][]byte{certificate.Raw},
+		PrivateKey:  key,
+	}))
+	signed, err := signing.SignEnveloped(doc.Root())
+	require.NoError(t, err)
+	doc.SetRoot(signed)
+	data, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	return data
+}
+
+func testMetadata(entityID string, certificates ...[]byte) []byte {
+	keyDescriptors := make([]string, len(certificates))
+	for i, certificate := range certificates {
+		keyDescriptors[i] = fmt.Sprintf(`<KeyDescriptor use="signing"><KeyInfo xmlns="http://www.w3.org/2000/09/xmldsig#"><X509Data><X509Certificate>%s</X509Certificate></X509Data></KeyInfo></KeyDescriptor>`, base64.StdEncoding.EncodeToString(certificate))
+	}
+	return []byte(fmt.Sprintf(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="%s"><IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">%s<SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"></SingleSignOnService></IDPSSODescriptor></EntityDescriptor>`, entityID, strings.Join(keyDescriptors, "")))
+}
+
+func testCertificate(t *testing.T, notAfter time.Time) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return certificate
+}
@@ -0,0 +1,102 @@
+package samlrefresh
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	RefresherProjectionTable = "projections.idp_saml_metadata_refresher"
+	RefreshUserID            = "SAML_METADATA_REFRESH"
+)
+
+type refresher struct {
+	commands *command.Commands
+	queries  *query.Queries
+}
+
+func newRefresher(
+	ctx context.Context,
+	handlerCfg handler.Config,
+	commands *command.Commands,
+	queries *query.Queries,
+) *handler.Handler {
+	r := &refresher{
+		commands: commands,
+		queries:  queries,
+	}
+	handlerCfg.TriggerWithoutEvents = r.refreshMetadata
+	return handler.NewHandler(
+		ctx,
+		&handlerCfg,
+		r,
+	)
+}
+
+func (r *refresher) Name() string {
+	return RefresherProjectionTable
+}
+
+func (r *refresher) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{{
+		Aggregate: pseudo.AggregateType,
+		EventReducers: []handler.EventReducer{{
+			Event:  pseudo.ScheduledEventType,
+			Reduce: r.refreshMetadata,
+		}},
+	}}
+}
+
+func (r *refresher) refreshMetadata(event eventstore.Event) (*handler.Statement, error) {
+	scheduledEvent, ok := event.(*pseudo.ScheduledEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "SAMLR-Iez4a", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		for _, instanceID := range scheduledEvent.InstanceIDs {
+			if err := r.refreshInstance(instanceID, scheduledEvent.Timestamp); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
+
+func (r *refresher) refreshInstance(instanceID string, now time.Time) error {
+	ctx := call.WithTimestamp(authz.WithInstanceID(context.Background(), instanceID))
+	instance, err := r.queries.InstanceByID(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = authz.WithInstance(ctx, instance)
+	refreshes, err := r.queries.EnabledSAMLMetadataRefreshes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, refresh := range refreshes {
+		if !refresh.IsDue(now) {
+			continue
+		}
+		// failures of the refresh itself are recorded in the report by the command,
+		// errors returned are unexpected and must not prevent the refresh of the other providers
+		_, err = r.commands.RefreshSAMLProviderMetadata(systemContext(ctx, refresh.ResourceOwner), refresh.ResourceOwner, refresh.IDPID, time.Now())
+		logging.WithFields("instance", instanceID, "idp", refresh.IDPID).OnError(err).Error("unable to refresh saml metadata")
+	}
+	return nil
+}
+
+func systemContext(ctx context.Context, resourceOwner string) context.Context {
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: RefreshUserID, OrgID: resourceOwner})
+}
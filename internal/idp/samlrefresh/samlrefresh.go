@@ -0,0 +1,39 @@
+// Package samlrefresh periodically refreshes the metadata of SAML identity providers
+// with an enabled metadata refresh.
+package samlrefresh
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+type Config struct {
+	// Enabled defines if the scheduled refresh runs on this instance of ZITADEL
+	Enabled bool
+}
+
+var refreshHandler *handler.Handler
+
+func Register(
+	ctx context.Context,
+	customConfig projection.CustomConfig,
+	config Config,
+	commands *command.Commands,
+	queries *query.Queries,
+) {
+	if !config.Enabled {
+		return
+	}
+	refreshHandler = newRefresher(ctx, projection.ApplyCustomConfig(customConfig), commands, queries)
+}
+
+func Start(ctx context.Context) {
+	if refreshHandler == nil {
+		return
+	}
+	refreshHandler.Start(ctx)
+}
@@ -19,6 +19,13 @@ type SessionSupportsMigration interface {
 	RetrievePreviousID() (previousID string, err error)
 }
 
+// SessionSupportsGroups is an optional extension to the Session interface.
+// It can be implemented by sessions of providers which don't return the groups of the user as part of the user info,
+// so they are fetched by additional requests to the provider, e.g. GitHub organizations and teams.
+type SessionSupportsGroups interface {
+	FetchGroups(ctx context.Context) ([]string, error)
+}
+
 func Redirect(redirectURL string) (string, bool) {
 	return redirectURL, true
 }
@@ -0,0 +1,40 @@
+// Package keyrotation periodically generates the successors of the signing keys
+// so that they are published before they are used.
+package keyrotation
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+type Config struct {
+	// Enabled defines if the scheduled rotation runs on this instance of ZITADEL
+	Enabled bool
+}
+
+var rotationHandler *handler.Handler
+
+func Register(
+	ctx context.Context,
+	customConfig projection.CustomConfig,
+	config Config,
+	commands *command.Commands,
+	queries *query.Queries,
+	signingKeyAlgorithm string,
+) {
+	if !config.Enabled {
+		return
+	}
+	rotationHandler = newRotator(ctx, projection.ApplyCustomConfig(customConfig), commands, queries, signingKeyAlgorithm)
+}
+
+func Start(ctx context.Context) {
+	if rotationHandler == nil {
+		return
+	}
+	rotationHandler.Start(ctx)
+}
@@ -0,0 +1,96 @@
+package keyrotation
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	RotatorProjectionTable = "projections.key_rotation"
+	RotationUserID         = "KEY_ROTATION"
+)
+
+type rotator struct {
+	commands  *command.Commands
+	queries   *query.Queries
+	algorithm string
+}
+
+func newRotator(
+	ctx context.Context,
+	handlerCfg handler.Config,
+	commands *command.Commands,
+	queries *query.Queries,
+	algorithm string,
+) *handler.Handler {
+	r := &rotator{
+		commands:  commands,
+		queries:   queries,
+		algorithm: algorithm,
+	}
+	handlerCfg.TriggerWithoutEvents = r.rotateKeys
+	return handler.NewHandler(
+		ctx,
+		&handlerCfg,
+		r,
+	)
+}
+
+func (r *rotator) Name() string {
+	return RotatorProjectionTable
+}
+
+func (r *rotator) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{{
+		Aggregate: pseudo.AggregateType,
+		EventReducers: []handler.EventReducer{{
+			Event:  pseudo.ScheduledEventType,
+			Reduce: r.rotateKeys,
+		}},
+	}}
+}
+
+func (r *rotator) rotateKeys(event eventstore.Event) (*handler.Statement, error) {
+	scheduledEvent, ok := event.(*pseudo.ScheduledEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "KEYR-Sch3d", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		for _, instanceID := range scheduledEvent.InstanceIDs {
+			// a failed rotation is retried on the next run and must not prevent the rotation of the other instances,
+			// the signing key is still generated lazily if no key is available
+			err := r.rotateInstance(instanceID, scheduledEvent.Timestamp)
+			logging.WithFields("instance", instanceID).OnError(err).Error("unable to rotate signing key")
+		}
+		return nil
+	}), nil
+}
+
+func (r *rotator) rotateInstance(instanceID string, now time.Time) error {
+	ctx := call.WithTimestamp(authz.WithInstanceID(context.Background(), instanceID))
+	instance, err := r.queries.InstanceByID(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = authz.SetCtxData(authz.WithInstance(ctx, instance), authz.CtxData{UserID: RotationUserID, OrgID: instanceID})
+	rotated, err := r.commands.RotateSigningKey(ctx, r.algorithm, now)
+	if err != nil {
+		return err
+	}
+	if rotated {
+		logging.WithFields("instance", instanceID).Info("signing key successor generated")
+	}
+	return nil
+}
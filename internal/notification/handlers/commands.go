@@ -19,6 +19,9 @@ type Commands interface {
 	HumanPasswordlessInitCodeSent(ctx context.Context, userID, resourceOwner, codeID string) error
 	PasswordChangeSent(ctx context.Context, orgID, userID string) error
 	HumanPhoneVerificationCodeSent(ctx context.Context, orgID, userID string) error
+	IDPAutoLinkedSent(ctx context.Context, orgID, userID, idpConfigID, externalUserID string) error
+	RefreshTokenReuseDetectedSent(ctx context.Context, orgID, userID, tokenID string) error
+	SAMLCertificateExpiringSent(ctx context.Context, resourceOwner, id string, certificate []byte) error
 	UsageNotificationSent(ctx context.Context, dueEvent *quota.NotificationDueEvent) error
 	MilestonePushed(ctx context.Context, msType milestone.Type, endpoints []string, primaryDomain string) error
 }
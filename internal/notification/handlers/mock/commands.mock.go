@@ -124,6 +124,20 @@ func (mr *MockCommandsMockRecorder) HumanPhoneVerificationCodeSent(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HumanPhoneVerificationCodeSent", reflect.TypeOf((*MockCommands)(nil).HumanPhoneVerificationCodeSent), arg0, arg1, arg2)
 }
 
+// IDPAutoLinkedSent mocks base method.
+func (m *MockCommands) IDPAutoLinkedSent(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IDPAutoLinkedSent", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IDPAutoLinkedSent indicates an expected call of IDPAutoLinkedSent.
+func (mr *MockCommandsMockRecorder) IDPAutoLinkedSent(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IDPAutoLinkedSent", reflect.TypeOf((*MockCommands)(nil).IDPAutoLinkedSent), arg0, arg1, arg2, arg3, arg4)
+}
+
 // MilestonePushed mocks base method.
 func (m *MockCommands) MilestonePushed(arg0 context.Context, arg1 milestone.Type, arg2 []string, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -194,6 +208,34 @@ func (mr *MockCommandsMockRecorder) PasswordCodeSent(arg0, arg1, arg2 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordCodeSent", reflect.TypeOf((*MockCommands)(nil).PasswordCodeSent), arg0, arg1, arg2)
 }
 
+// RefreshTokenReuseDetectedSent mocks base method.
+func (m *MockCommands) RefreshTokenReuseDetectedSent(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshTokenReuseDetectedSent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshTokenReuseDetectedSent indicates an expected call of RefreshTokenReuseDetectedSent.
+func (mr *MockCommandsMockRecorder) RefreshTokenReuseDetectedSent(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokenReuseDetectedSent", reflect.TypeOf((*MockCommands)(nil).RefreshTokenReuseDetectedSent), arg0, arg1, arg2, arg3)
+}
+
+// SAMLCertificateExpiringSent mocks base method.
+func (m *MockCommands) SAMLCertificateExpiringSent(arg0 context.Context, arg1, arg2 string, arg3 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SAMLCertificateExpiringSent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SAMLCertificateExpiringSent indicates an expected call of SAMLCertificateExpiringSent.
+func (mr *MockCommandsMockRecorder) SAMLCertificateExpiringSent(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SAMLCertificateExpiringSent", reflect.TypeOf((*MockCommands)(nil).SAMLCertificateExpiringSent), arg0, arg1, arg2, arg3)
+}
+
 // UsageNotificationSent mocks base method.
 func (m *MockCommands) UsageNotificationSent(arg0 context.Context, arg1 *quota.NotificationDueEvent) error {
 	m.ctrl.T.Helper()
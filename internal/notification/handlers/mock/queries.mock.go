@@ -115,6 +115,41 @@ func (mr *MockQueriesMockRecorder) GetNotifyUserByID(arg0, arg1, arg2 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifyUserByID", reflect.TypeOf((*MockQueries)(nil).GetNotifyUserByID), arg0, arg1, arg2)
 }
 
+// IAMMembers mocks base method.
+func (m *MockQueries) IAMMembers(arg0 context.Context, arg1 *query.IAMMembersQuery) (*query.Members, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IAMMembers", arg0, arg1)
+	ret0, _ := ret[0].(*query.Members)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IAMMembers indicates an expected call of IAMMembers.
+func (mr *MockQueriesMockRecorder) IAMMembers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IAMMembers", reflect.TypeOf((*MockQueries)(nil).IAMMembers), arg0, arg1)
+}
+
+// IDPTemplateByID mocks base method.
+func (m *MockQueries) IDPTemplateByID(arg0 context.Context, arg1 bool, arg2 string, arg3 bool, arg4 ...query.SearchQuery) (*query.IDPTemplate, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IDPTemplateByID", varargs...)
+	ret0, _ := ret[0].(*query.IDPTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IDPTemplateByID indicates an expected call of IDPTemplateByID.
+func (mr *MockQueriesMockRecorder) IDPTemplateByID(arg0, arg1, arg2, arg3 any, arg4 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IDPTemplateByID", reflect.TypeOf((*MockQueries)(nil).IDPTemplateByID), varargs...)
+}
+
 // MailTemplateByOrg mocks base method.
 func (m *MockQueries) MailTemplateByOrg(arg0 context.Context, arg1 string, arg2 bool) (*query.MailTemplate, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +195,21 @@ func (mr *MockQueriesMockRecorder) NotificationProviderByIDAndType(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationProviderByIDAndType", reflect.TypeOf((*MockQueries)(nil).NotificationProviderByIDAndType), arg0, arg1, arg2)
 }
 
+// OrgMembers mocks base method.
+func (m *MockQueries) OrgMembers(arg0 context.Context, arg1 *query.OrgMembersQuery) (*query.Members, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrgMembers", arg0, arg1)
+	ret0, _ := ret[0].(*query.Members)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrgMembers indicates an expected call of OrgMembers.
+func (mr *MockQueriesMockRecorder) OrgMembers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrgMembers", reflect.TypeOf((*MockQueries)(nil).OrgMembers), arg0, arg1)
+}
+
 // SMSProviderConfig mocks base method.
 func (m *MockQueries) SMSProviderConfig(arg0 context.Context, arg1 ...query.SearchQuery) (*query.SMSConfig, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	_ "github.com/zitadel/zitadel/internal/notification/statik"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	PushNotificationsProjectionTable = "projections.notifications_push"
+)
+
+// PushNotifierConfig configures the push gateway, which delivers the push challenges to the devices of the users
+// (e.g. using FCM or APNs).
+type PushNotifierConfig struct {
+	Endpoint string
+	Headers  http.Header
+}
+
+type pushNotifier struct {
+	cfg      PushNotifierConfig
+	commands *command.Commands
+	queries  *NotificationQueries
+	channels types.ChannelChains
+}
+
+func NewPushNotifier(
+	ctx context.Context,
+	pushCfg PushNotifierConfig,
+	handlerCfg handler.Config,
+	commands *command.Commands,
+	queries *NotificationQueries,
+	channels types.ChannelChains,
+) *handler.Handler {
+	return handler.NewHandler(ctx, &handlerCfg, &pushNotifier{
+		cfg:      pushCfg,
+		commands: commands,
+		queries:  queries,
+		channels: channels,
+	})
+}
+
+func (*pushNotifier) Name() string {
+	return PushNotificationsProjectionTable
+}
+
+func (p *pushNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: session.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  session.PushChallengedType,
+					Reduce: p.reducePushChallenged,
+				},
+			},
+		},
+	}
+}
+
+// pushChallenge is sent to the push gateway for every device of the user.
+// The number of the challenge is deliberately not part of it, as the user has to enter it on the device (number matching).
+type pushChallenge struct {
+	SessionID   string    `json:"sessionId"`
+	ChallengeID string    `json:"challengeId"`
+	DeviceID    string    `json:"deviceId"`
+	PushToken   string    `json:"pushToken"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	Origin      string    `json:"origin,omitempty"`
+}
+
+func (p *pushNotifier) reducePushChallenged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.PushChallengedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Eu8ai", "reduce.wrong.event.type %s", session.PushChallengedType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		expiresAt := e.CreatedAt().Add(e.Expiry)
+		if expiresAt.Before(time.Now().UTC()) {
+			return nil
+		}
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := p.queries.IsAlreadyHandled(ctx, event, nil, session.AggregateType, session.PushSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+		for _, device := range e.Devices {
+			err = types.SendJSON(
+				ctx,
+				webhook.Config{
+					CallURL: p.cfg.Endpoint,
+					Method:  http.MethodPost,
+					Headers: p.cfg.Headers,
+				},
+				p.channels,
+				&pushChallenge{
+					SessionID:   e.Aggregate().ID,
+					ChallengeID: e.ChallengeID,
+					DeviceID:    device.DeviceID,
+					PushToken:   device.PushToken,
+					ExpiresAt:   expiresAt,
+					Origin:      e.TriggerOrigin(),
+				},
+				e,
+			).WithoutTemplate()
+			if err != nil {
+				return err
+			}
+		}
+		return p.commands.PushChallengeSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	}), nil
+}
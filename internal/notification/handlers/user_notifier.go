@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,6 +14,9 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/notification/types"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/session"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -98,6 +102,14 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordChangedType,
 					Reduce: u.reducePasswordChanged,
 				},
+				{
+					Event:  user.UserIDPLinkAutoLinkedType,
+					Reduce: u.reduceIDPAutoLinked,
+				},
+				{
+					Event:  user.HumanRefreshTokenReuseDetectedType,
+					Reduce: u.reduceRefreshTokenReuseDetected,
+				},
 				{
 					Event:  user.HumanOTPSMSCodeAddedType,
 					Reduce: u.reduceOTPSMSCodeAdded,
@@ -121,6 +133,24 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 				},
 			},
 		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.SAMLCertificateExpiringEventType,
+					Reduce: u.reduceInstanceSAMLCertificateExpiring,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.SAMLCertificateExpiringEventType,
+					Reduce: u.reduceOrgSAMLCertificateExpiring,
+				},
+			},
+		},
 	}
 }
 
@@ -667,6 +697,204 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 	}), nil
 }
 
+func (u *userNotifier) reduceIDPAutoLinked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserIDPLinkAutoLinkedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Xoo4u", "reduce.wrong.event.type %s", user.UserIDPLinkAutoLinkedType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, map[string]interface{}{"idpConfigId": e.IDPConfigID, "userId": e.ExternalUserID}, user.AggregateType, user.UserIDPLinkAutoLinkedSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		provider, err := u.queries.IDPTemplateByID(ctx, false, e.IDPConfigID, false)
+		if err != nil {
+			return err
+		}
+
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.IDPAutoLinkedMessageType)
+		if err != nil {
+			return err
+		}
+		ctx, err = u.queries.Origin(ctx, e)
+		if err != nil {
+			return err
+		}
+		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+			SendIDPAutoLinked(ctx, notifyUser, provider.Name)
+		if err != nil {
+			return err
+		}
+		return u.commands.IDPAutoLinkedSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID, e.IDPConfigID, e.ExternalUserID)
+	}), nil
+}
+
+func (u *userNotifier) reduceRefreshTokenReuseDetected(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenReuseDetectedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Eo5ei", "reduce.wrong.event.type %s", user.HumanRefreshTokenReuseDetectedType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, map[string]interface{}{"tokenId": e.TokenID}, user.AggregateType, user.HumanRefreshTokenReuseDetectedSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.RefreshTokenReusedMessageType)
+		if err != nil {
+			return err
+		}
+		ctx, err = u.queries.Origin(ctx, e)
+		if err != nil {
+			return err
+		}
+		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+			SendRefreshTokenReused(ctx, notifyUser, e.ClientID)
+		if err != nil {
+			return err
+		}
+		return u.commands.RefreshTokenReuseDetectedSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID, e.TokenID)
+	}), nil
+}
+
+func (u *userNotifier) reduceInstanceSAMLCertificateExpiring(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SAMLCertificateExpiringEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ohb7i", "reduce.wrong.event.type %s", instance.SAMLCertificateExpiringEventType)
+	}
+	return u.reduceSAMLCertificateExpiring(
+		e,
+		&e.SAMLCertificateExpiringEvent,
+		instance.AggregateType,
+		instance.SAMLCertificateExpiringSentEventType,
+		func(ctx context.Context) (*query.Members, error) {
+			return u.queries.IAMMembers(ctx, &query.IAMMembersQuery{})
+		},
+		domain.RoleIAMOwner,
+	), nil
+}
+
+func (u *userNotifier) reduceOrgSAMLCertificateExpiring(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.SAMLCertificateExpiringEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Wai3o", "reduce.wrong.event.type %s", org.SAMLCertificateExpiringEventType)
+	}
+	return u.reduceSAMLCertificateExpiring(
+		e,
+		&e.SAMLCertificateExpiringEvent,
+		org.AggregateType,
+		org.SAMLCertificateExpiringSentEventType,
+		func(ctx context.Context) (*query.Members, error) {
+			return u.queries.OrgMembers(ctx, &query.OrgMembersQuery{OrgID: e.Aggregate().ResourceOwner})
+		},
+		domain.RoleOrgOwner,
+	), nil
+}
+
+// reduceSAMLCertificateExpiring notifies the owners of the instance or organization of the IDP
+// about a signing certificate expiring soon.
+func (u *userNotifier) reduceSAMLCertificateExpiring(
+	event eventstore.Event,
+	e *idp.SAMLCertificateExpiringEvent,
+	aggregateType eventstore.AggregateType,
+	sentType eventstore.EventType,
+	members func(ctx context.Context) (*query.Members, error),
+	ownerRole string,
+) *handler.Statement {
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, map[string]interface{}{"id": e.ID, "certificate": e.Certificate}, aggregateType, sentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		idpTemplate, err := u.queries.IDPTemplateByID(ctx, true, e.ID, false)
+		if err != nil {
+			return err
+		}
+		owners, err := members(ctx)
+		if err != nil {
+			return err
+		}
+		ctx, err = u.queries.Origin(ctx, event)
+		if err != nil {
+			return err
+		}
+		for _, owner := range owners.Members {
+			if !slices.Contains(owner.Roles, ownerRole) {
+				continue
+			}
+			notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, owner.UserID)
+			if err != nil {
+				return err
+			}
+			if notifyUser.LastEmail == "" {
+				continue
+			}
+			colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, notifyUser.ResourceOwner, false)
+			if err != nil {
+				return err
+			}
+			template, err := u.queries.MailTemplateByOrg(ctx, notifyUser.ResourceOwner, false)
+			if err != nil {
+				return err
+			}
+			translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.SAMLCertificateExpiringMessageType)
+			if err != nil {
+				return err
+			}
+			err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, event).
+				SendSAMLCertificateExpiring(ctx, notifyUser, idpTemplate.Name, e.NotAfter)
+			if err != nil {
+				return err
+			}
+		}
+		return u.commands.SAMLCertificateExpiringSent(ctx, event.Aggregate().ResourceOwner, e.ID, e.Certificate)
+	})
+}
+
 func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanPhoneCodeAddedEvent)
 	if !ok {
@@ -16,6 +16,7 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	es_repo_mock "github.com/zitadel/zitadel/internal/eventstore/repository/mock"
@@ -27,6 +28,8 @@ import (
 	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/notification/types"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/user"
 )
 
@@ -1072,6 +1075,202 @@ func Test_userNotifier_reducePasswordChanged(t *testing.T) {
 	}
 }
 
+func Test_userNotifier_reduceIDPAutoLinked(t *testing.T) {
+	expectMailSubject := "Your account was linked with GitHub"
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "asset url with event trigger url",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			givenTemplate := "{{.LogoURL}}"
+			expectContent := fmt.Sprintf("%s%s/%s/%s", eventOrigin, assetsPath, policyID, logoURL)
+			w.message = messages.Email{
+				Recipients: []string{lastEmail},
+				Subject:    expectMailSubject,
+				Content:    expectContent,
+			}
+			queries.EXPECT().IDPTemplateByID(gomock.Any(), gomock.Any(), "idpID", gomock.Any()).Return(&query.IDPTemplate{
+				ID:   "idpID",
+				Name: "GitHub",
+			}, nil)
+			expectTemplateQueries(queries, givenTemplate)
+			commands.EXPECT().IDPAutoLinkedSent(gomock.Any(), orgID, userID, "idpID", "externalUserID").Return(nil)
+			return fields{
+					queries:  queries,
+					commands: commands,
+					es: eventstore.NewEventstore(&eventstore.Config{
+						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+					}),
+				}, args{
+					event: &user.UserIDPLinkAutoLinkedEvent{
+						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+							AggregateID:   userID,
+							ResourceOwner: sql.NullString{String: orgID},
+							CreationDate:  time.Now().UTC(),
+						}),
+						IDPConfigID:       "idpID",
+						ExternalUserID:    "externalUserID",
+						Option:            domain.AutoLinkingOptionEmail,
+						TriggeredAtOrigin: eventOrigin,
+					},
+				}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceIDPAutoLinked(a.event)
+			if w.err != nil {
+				w.err(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			err = stmt.Execute(nil, "")
+			if w.err != nil {
+				w.err(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_userNotifier_reduceRefreshTokenReuseDetected(t *testing.T) {
+	expectMailSubject := "A refresh token of your account was used again"
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "asset url with event trigger url",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			givenTemplate := "{{.LogoURL}}"
+			expectContent := fmt.Sprintf("%s%s/%s/%s", eventOrigin, assetsPath, policyID, logoURL)
+			w.message = messages.Email{
+				Recipients: []string{lastEmail},
+				Subject:    expectMailSubject,
+				Content:    expectContent,
+			}
+			expectTemplateQueries(queries, givenTemplate)
+			commands.EXPECT().RefreshTokenReuseDetectedSent(gomock.Any(), orgID, userID, "tokenID").Return(nil)
+			return fields{
+					queries:  queries,
+					commands: commands,
+					es: eventstore.NewEventstore(&eventstore.Config{
+						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+					}),
+				}, args{
+					event: &user.HumanRefreshTokenReuseDetectedEvent{
+						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+							AggregateID:   userID,
+							ResourceOwner: sql.NullString{String: orgID},
+							CreationDate:  time.Now().UTC(),
+						}),
+						TokenID:           "tokenID",
+						ClientID:          "clientID",
+						UserAgentID:       "userAgentID",
+						TriggeredAtOrigin: eventOrigin,
+					},
+				}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceRefreshTokenReuseDetected(a.event)
+			if w.err != nil {
+				w.err(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			err = stmt.Execute(nil, "")
+			if w.err != nil {
+				w.err(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_userNotifier_reduceOrgSAMLCertificateExpiring(t *testing.T) {
+	expectMailSubject := "A signing certificate of the identity provider SAML expires soon"
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "org owners notified with event trigger url",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			givenTemplate := "{{.LogoURL}}"
+			expectContent := fmt.Sprintf("%s%s/%s/%s", eventOrigin, assetsPath, policyID, logoURL)
+			w.message = messages.Email{
+				Recipients: []string{lastEmail},
+				Subject:    expectMailSubject,
+				Content:    expectContent,
+			}
+			queries.EXPECT().IDPTemplateByID(gomock.Any(), gomock.Any(), "idpID", gomock.Any()).Return(&query.IDPTemplate{
+				ID:   "idpID",
+				Name: "SAML",
+			}, nil)
+			queries.EXPECT().OrgMembers(gomock.Any(), &query.OrgMembersQuery{OrgID: orgID}).Return(&query.Members{
+				Members: []*query.Member{
+					{UserID: userID, Roles: []string{domain.RoleOrgOwner}},
+					{UserID: "manager", Roles: []string{"ORG_USER_MANAGER"}},
+				},
+			}, nil)
+			expectTemplateQueries(queries, givenTemplate)
+			commands.EXPECT().SAMLCertificateExpiringSent(gomock.Any(), orgID, "idpID", []byte("certificate")).Return(nil)
+			return fields{
+					queries:  queries,
+					commands: commands,
+					es: eventstore.NewEventstore(&eventstore.Config{
+						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+					}),
+				}, args{
+					event: &org.SAMLCertificateExpiringEvent{
+						SAMLCertificateExpiringEvent: idp.SAMLCertificateExpiringEvent{
+							BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+								AggregateID:   orgID,
+								ResourceOwner: sql.NullString{String: orgID},
+								CreationDate:  time.Now().UTC(),
+							}),
+							ID:                "idpID",
+							Certificate:       []byte("certificate"),
+							NotAfter:          time.Now().Add(7 * 24 * time.Hour),
+							TriggeredAtOrigin: eventOrigin,
+						},
+					},
+				}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceOrgSAMLCertificateExpiring(a.event)
+			if w.err != nil {
+				w.err(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			err = stmt.Execute(nil, "")
+			if w.err != nil {
+				w.err(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_userNotifier_reduceOTPEmailChallenged(t *testing.T) {
 	expectMailSubject := "Verify One-Time Password"
 	tests := []struct {
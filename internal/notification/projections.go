@@ -17,8 +17,9 @@ var projections []*handler.Handler
 
 func Register(
 	ctx context.Context,
-	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig projection.CustomConfig,
+	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig, pushHandlerCustomConfig projection.CustomConfig,
 	telemetryCfg handlers.TelemetryPusherConfig,
+	pushCfg handlers.PushNotifierConfig,
 	externalDomain string,
 	externalPort uint16,
 	externalSecure bool,
@@ -36,6 +37,9 @@ func Register(
 	if telemetryCfg.Enabled {
 		projections = append(projections, handlers.NewTelemetryPusher(ctx, telemetryCfg, projection.ApplyCustomConfig(telemetryHandlerCustomConfig), commands, q, c))
 	}
+	if pushCfg.Endpoint != "" {
+		projections = append(projections, handlers.NewPushNotifier(ctx, pushCfg, projection.ApplyCustomConfig(pushHandlerCustomConfig), commands, q, c))
+	}
 }
 
 func Start(ctx context.Context) {
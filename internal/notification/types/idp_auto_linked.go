@@ -0,0 +1,17 @@
+package types
+
+import (
+	"context"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendIDPAutoLinked(ctx context.Context, user *query.NotifyUser, idpName string) error {
+	url := console.LoginHintLink(http_utils.ComposedOrigin(ctx), user.PreferredLoginName)
+	args := make(map[string]interface{})
+	args["IDPName"] = idpName
+	return notify(url, args, domain.IDPAutoLinkedMessageType, true)
+}
@@ -0,0 +1,17 @@
+package types
+
+import (
+	"context"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendRefreshTokenReused(ctx context.Context, user *query.NotifyUser, clientID string) error {
+	url := console.LoginHintLink(http_utils.ComposedOrigin(ctx), user.PreferredLoginName)
+	args := make(map[string]interface{})
+	args["ClientID"] = clientID
+	return notify(url, args, domain.RefreshTokenReusedMessageType, true)
+}
@@ -0,0 +1,19 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendSAMLCertificateExpiring(ctx context.Context, user *query.NotifyUser, idpName string, notAfter time.Time) error {
+	url := console.LoginHintLink(http_utils.ComposedOrigin(ctx), user.PreferredLoginName)
+	args := make(map[string]interface{})
+	args["IDPName"] = idpName
+	args["NotAfter"] = notAfter.UTC().Format(time.RFC1123)
+	return notify(url, args, domain.SAMLCertificateExpiringMessageType, true)
+}
@@ -27,6 +27,8 @@ type OIDCSessionAccessTokenReadModel struct {
 	AccessTokenID         string
 	AccessTokenCreation   time.Time
 	AccessTokenExpiration time.Time
+	AuthorizationDetails  domain.AuthorizationDetails
+	CertificateThumbprint string
 }
 
 func newOIDCSessionAccessTokenReadModel(id string) *OIDCSessionAccessTokenReadModel {
@@ -83,6 +85,8 @@ func (wm *OIDCSessionAccessTokenReadModel) reduceAccessTokenAdded(e *oidcsession
 	wm.AccessTokenID = e.ID
 	wm.AccessTokenCreation = e.CreationDate()
 	wm.AccessTokenExpiration = e.CreationDate().Add(e.Lifetime)
+	wm.AuthorizationDetails = e.AuthorizationDetails
+	wm.CertificateThumbprint = e.CertificateThumbprint
 }
 
 func (wm *OIDCSessionAccessTokenReadModel) reduceTokenRevoked(e eventstore.Event) {
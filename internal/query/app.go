@@ -59,6 +59,10 @@ type OIDCApp struct {
 	AdditionalOrigins        database.TextArray[string]
 	AllowedOrigins           database.TextArray[string]
 	SkipNativeAppSuccessPage bool
+	ConsentRequired          bool
+	TLSClientAuthSubjectDN   string
+
+	RefreshTokenReuseGracePeriod time.Duration
 }
 
 type SAMLApp struct {
@@ -243,6 +247,18 @@ var (
 		name:  projection.AppOIDCConfigColumnSkipNativeAppSuccessPage,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnConsentRequired = Column{
+		name:  projection.AppOIDCConfigColumnConsentRequired,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnTLSClientAuthSubjectDN = Column{
+		name:  projection.AppOIDCConfigColumnTLSClientAuthSubjectDN,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRefreshTokenReuseGrace = Column{
+		name:  projection.AppOIDCConfigColumnRefreshTokenReuseGrace,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string) (app *App, err error) {
@@ -457,6 +473,7 @@ func (q *Queries) AppByClientID(ctx context.Context, clientID string) (app *App,
 func (q *Queries) SearchApps(ctx context.Context, queries *AppSearchQueries, withOwnerRemoved bool) (apps *Apps, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
+	ctx = database.WithCallType(ctx, database.CallTypeList)
 
 	query, scan := prepareAppsQuery(ctx, q.client)
 	eq := sq.Eq{AppColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
@@ -544,6 +561,9 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnConsentRequired.identifier(),
+			AppOIDCConfigColumnTLSClientAuthSubjectDN.identifier(),
+			AppOIDCConfigColumnRefreshTokenReuseGrace.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -593,6 +613,9 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
 				&oidcConfig.skipNativeAppSuccessPage,
+				&oidcConfig.consentRequired,
+				&oidcConfig.tlsClientAuthSubjectDN,
+				&oidcConfig.refreshTokenReuseGracePeriod,
 
 				&samlConfig.appID,
 				&samlConfig.entityID,
@@ -714,6 +737,9 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnConsentRequired.identifier(),
+			AppOIDCConfigColumnTLSClientAuthSubjectDN.identifier(),
+			AppOIDCConfigColumnRefreshTokenReuseGrace.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -766,6 +792,9 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					&oidcConfig.clockSkew,
 					&oidcConfig.additionalOrigins,
 					&oidcConfig.skipNativeAppSuccessPage,
+					&oidcConfig.consentRequired,
+					&oidcConfig.tlsClientAuthSubjectDN,
+					&oidcConfig.refreshTokenReuseGracePeriod,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
@@ -838,6 +867,10 @@ type sqlOIDCConfig struct {
 	responseTypes            database.Array[domain.OIDCResponseType]
 	grantTypes               database.Array[domain.OIDCGrantType]
 	skipNativeAppSuccessPage sql.NullBool
+	consentRequired          sql.NullBool
+	tlsClientAuthSubjectDN   sql.NullString
+
+	refreshTokenReuseGracePeriod sql.NullInt64
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -861,6 +894,10 @@ func (c sqlOIDCConfig) set(app *App) {
 		ResponseTypes:            c.responseTypes,
 		GrantTypes:               c.grantTypes,
 		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
+		ConsentRequired:          c.consentRequired.Bool,
+		TLSClientAuthSubjectDN:   c.tlsClientAuthSubjectDN.String,
+
+		RefreshTokenReuseGracePeriod: time.Duration(c.refreshTokenReuseGracePeriod.Int64),
 	}
 	compliance := domain.GetOIDCCompliance(app.OIDCConfig.Version, app.OIDCConfig.AppType, app.OIDCConfig.GrantTypes, app.OIDCConfig.ResponseTypes, app.OIDCConfig.AuthMethodType, app.OIDCConfig.RedirectURIs)
 	app.OIDCConfig.ComplianceProblems = compliance.Problems
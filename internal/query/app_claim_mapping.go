@@ -0,0 +1,258 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	appClaimMappingsTable = table{
+		name:          projection.AppClaimMappingsTable,
+		instanceIDCol: projection.AppClaimMappingsColumnInstanceID,
+	}
+	AppClaimMappingsColumnAppID = Column{
+		name:  projection.AppClaimMappingsColumnAppID,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingsColumnClaimMappings = Column{
+		name:  projection.AppClaimMappingsColumnClaimMappings,
+		table: appClaimMappingsTable,
+	}
+)
+
+type AppClaimMappings struct {
+	AppID         string
+	ProjectID     string
+	ClaimMappings []*domain.ClaimMapping
+}
+
+// AppClaimMappingsByID returns the claim mappings of the app.
+// If no mappings are set, the list is empty.
+func (q *Queries) AppClaimMappingsByID(ctx context.Context, projectID, appID string) (mappings *AppClaimMappings, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareAppClaimMappingsQuery(ctx, q.client)
+	eq := sq.Eq{
+		AppColumnID.identifier():         appID,
+		AppColumnProjectID.identifier():  projectID,
+		AppColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, args, err := stmt.Where(eq).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Oot2e", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		mappings, err = scan(row)
+		return err
+	}, query, args...)
+	return mappings, err
+}
+
+// AppClaimMappingsByClientID returns the claim mappings of the app by its OIDC client_id or SAML app id.
+func (q *Queries) AppClaimMappingsByClientID(ctx context.Context, clientID string) (mappings *AppClaimMappings, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareAppClaimMappingsQuery(ctx, q.client)
+	where := sq.And{
+		sq.Eq{AppColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()},
+		sq.Or{
+			sq.Eq{AppOIDCConfigColumnClientID.identifier(): clientID},
+			sq.Eq{AppSAMLConfigColumnAppID.identifier(): clientID},
+		},
+	}
+	query, args, err := stmt.Where(where).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-aeM1i", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		mappings, err = scan(row)
+		return err
+	}, query, args...)
+	return mappings, err
+}
+
+// ClaimMappingClaims evaluates the claim mappings of the app identified by the clientID
+// for the user and returns the resulting claims of the target.
+// If the app has no mappings for the target, nil is returned without querying any user data.
+func (q *Queries) ClaimMappingClaims(ctx context.Context, clientID, userID string, target domain.ClaimMappingTarget) (_ map[string]any, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	mappings, err := q.AppClaimMappingsByClientID(ctx, clientID)
+	if err != nil {
+		if zerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return domain.EvaluateClaimMappings(ctx, mappings.ClaimMappings, target, &claimMappingValues{
+		queries:   q,
+		userID:    userID,
+		projectID: mappings.ProjectID,
+	})
+}
+
+func prepareAppClaimMappingsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*AppClaimMappings, error)) {
+	return sq.Select(
+			AppColumnID.identifier(),
+			AppColumnProjectID.identifier(),
+			AppClaimMappingsColumnClaimMappings.identifier(),
+		).From(appsTable.identifier()).
+			LeftJoin(join(AppClaimMappingsColumnAppID, AppColumnID)).
+			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppSAMLConfigColumnAppID, AppColumnID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*AppClaimMappings, error) {
+			mappings := new(AppClaimMappings)
+			var claimMappings []byte
+			err := row.Scan(
+				&mappings.AppID,
+				&mappings.ProjectID,
+				&claimMappings,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-ieV7a", "Errors.Project.App.NotExisting")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Chai2", "Errors.Internal")
+			}
+			if len(claimMappings) > 0 {
+				if err = json.Unmarshal(claimMappings, &mappings.ClaimMappings); err != nil {
+					return nil, zerrors.ThrowInternal(err, "QUERY-Tah7e", "Errors.Internal")
+				}
+			}
+			return mappings, nil
+		}
+}
+
+// claimMappingValues lazily queries the values of the user,
+// so only the data used by the mappings is loaded.
+type claimMappingValues struct {
+	queries   *Queries
+	userID    string
+	projectID string
+
+	user *User
+	org  *Org
+}
+
+func (v *claimMappingValues) getUser(ctx context.Context) (_ *User, err error) {
+	if v.user == nil {
+		v.user, err = v.queries.GetUserByID(ctx, false, v.userID)
+	}
+	return v.user, err
+}
+
+func (v *claimMappingValues) UserField(ctx context.Context, field string) (string, error) {
+	user, err := v.getUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	switch field {
+	case domain.ClaimMappingUserFieldID:
+		return user.ID, nil
+	case domain.ClaimMappingUserFieldUsername:
+		return user.Username, nil
+	case domain.ClaimMappingUserFieldPreferredLoginName:
+		return user.PreferredLoginName, nil
+	case domain.ClaimMappingUserFieldDisplayName:
+		if user.Machine != nil {
+			return user.Machine.Name, nil
+		}
+	}
+	if user.Human == nil {
+		return "", nil
+	}
+	switch field {
+	case domain.ClaimMappingUserFieldFirstName:
+		return user.Human.FirstName, nil
+	case domain.ClaimMappingUserFieldLastName:
+		return user.Human.LastName, nil
+	case domain.ClaimMappingUserFieldNickName:
+		return user.Human.NickName, nil
+	case domain.ClaimMappingUserFieldDisplayName:
+		return user.Human.DisplayName, nil
+	case domain.ClaimMappingUserFieldEmail:
+		return string(user.Human.Email), nil
+	case domain.ClaimMappingUserFieldPhone:
+		return string(user.Human.Phone), nil
+	case domain.ClaimMappingUserFieldPreferredLanguage:
+		if user.Human.PreferredLanguage.IsRoot() {
+			return "", nil
+		}
+		return user.Human.PreferredLanguage.String(), nil
+	}
+	return "", nil
+}
+
+func (v *claimMappingValues) UserMetadata(ctx context.Context, key string) ([]byte, error) {
+	metadata, err := v.queries.GetUserMetadataByKey(ctx, false, v.userID, key, false)
+	if err != nil {
+		if zerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return metadata.Value, nil
+}
+
+func (v *claimMappingValues) OrgField(ctx context.Context, field string) (string, error) {
+	if v.org == nil {
+		user, err := v.getUser(ctx)
+		if err != nil {
+			return "", err
+		}
+		v.org, err = v.queries.OrgByID(ctx, false, user.ResourceOwner)
+		if err != nil {
+			return "", err
+		}
+	}
+	switch field {
+	case domain.ClaimMappingOrgFieldID:
+		return v.org.ID, nil
+	case domain.ClaimMappingOrgFieldName:
+		return v.org.Name, nil
+	case domain.ClaimMappingOrgFieldPrimaryDomain:
+		return v.org.Domain, nil
+	}
+	return "", nil
+}
+
+func (v *claimMappingValues) Roles(ctx context.Context) ([]string, error) {
+	projectQuery, err := NewUserGrantProjectIDSearchQuery(v.projectID)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := NewUserGrantUserIDSearchQuery(v.userID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := v.queries.UserGrants(ctx, &UserGrantsQueries{
+		Queries: []SearchQuery{
+			projectQuery,
+			userIDQuery,
+		},
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]string, 0)
+	for _, grant := range grants.UserGrants {
+		roles = append(roles, grant.Roles...)
+	}
+	return roles, nil
+}
@@ -0,0 +1,129 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	expectedAppClaimMappingsQuery = regexp.QuoteMeta(`SELECT projections.apps7.id,` +
+		` projections.apps7.project_id,` +
+		` projections.apps7_claim_mappings.claim_mappings` +
+		` FROM projections.apps7` +
+		` LEFT JOIN projections.apps7_claim_mappings ON projections.apps7.id = projections.apps7_claim_mappings.app_id AND projections.apps7.instance_id = projections.apps7_claim_mappings.instance_id` +
+		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+	appClaimMappingsCols = []string{
+		"id",
+		"project_id",
+		"claim_mappings",
+	}
+)
+
+func Test_AppClaimMappingsPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareAppClaimMappingsQuery no result",
+			prepare: prepareAppClaimMappingsQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					expectedAppClaimMappingsQuery,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AppClaimMappings)(nil),
+		},
+		{
+			name:    "prepareAppClaimMappingsQuery without mappings",
+			prepare: prepareAppClaimMappingsQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedAppClaimMappingsQuery,
+					appClaimMappingsCols,
+					[]driver.Value{
+						"app-id",
+						"project-id",
+						nil,
+					},
+				),
+			},
+			object: &AppClaimMappings{
+				AppID:     "app-id",
+				ProjectID: "project-id",
+			},
+		},
+		{
+			name:    "prepareAppClaimMappingsQuery with mappings",
+			prepare: prepareAppClaimMappingsQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedAppClaimMappingsQuery,
+					appClaimMappingsCols,
+					[]driver.Value{
+						"app-id",
+						"project-id",
+						[]byte(`[{"claim": "tenant", "source": 5, "value": "acme", "targets": [1, 2]}]`),
+					},
+				),
+			},
+			object: &AppClaimMappings{
+				AppID:     "app-id",
+				ProjectID: "project-id",
+				ClaimMappings: []*domain.ClaimMapping{
+					{
+						Claim:   "tenant",
+						Source:  domain.ClaimMappingSourceStatic,
+						Value:   "acme",
+						Targets: []domain.ClaimMappingTarget{domain.ClaimMappingTargetIDToken, domain.ClaimMappingTargetAccessToken},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareAppClaimMappingsQuery sql err",
+			prepare: prepareAppClaimMappingsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedAppClaimMappingsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AppClaimMappings)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
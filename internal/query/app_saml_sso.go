@@ -0,0 +1,129 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	appSAMLSSOTable = table{
+		name:          projection.AppSAMLSSOTable,
+		instanceIDCol: projection.AppSAMLSSOColumnInstanceID,
+	}
+	AppSAMLSSOColumnAppID = Column{
+		name:  projection.AppSAMLSSOColumnAppID,
+		table: appSAMLSSOTable,
+	}
+	AppSAMLSSOColumnIDPInitiatedEnabled = Column{
+		name:  projection.AppSAMLSSOColumnIDPInitiatedEnabled,
+		table: appSAMLSSOTable,
+	}
+	AppSAMLSSOColumnRelayState = Column{
+		name:  projection.AppSAMLSSOColumnRelayState,
+		table: appSAMLSSOTable,
+	}
+	AppSAMLSSOColumnWSFedEnabled = Column{
+		name:  projection.AppSAMLSSOColumnWSFedEnabled,
+		table: appSAMLSSOTable,
+	}
+	AppSAMLSSOColumnWSFedReplyURLs = Column{
+		name:  projection.AppSAMLSSOColumnWSFedReplyURLs,
+		table: appSAMLSSOTable,
+	}
+)
+
+type SAMLSSOSettings struct {
+	AppID     string
+	ProjectID string
+	domain.SAMLSSOSettings
+}
+
+// SAMLSSOSettingsByID returns the IdP-initiated and WS-Federation settings of the SAML app of the project.
+// If no settings are set, all flows are disabled.
+func (q *Queries) SAMLSSOSettingsByID(ctx context.Context, projectID, appID string) (settings *SAMLSSOSettings, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.samlSSOSettings(ctx, sq.Eq{
+		AppColumnID.identifier():         appID,
+		AppColumnProjectID.identifier():  projectID,
+		AppColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	})
+}
+
+// SAMLSSOSettingsByAppID returns the IdP-initiated and WS-Federation settings of the SAML app.
+func (q *Queries) SAMLSSOSettingsByAppID(ctx context.Context, appID string) (settings *SAMLSSOSettings, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.samlSSOSettings(ctx, sq.Eq{
+		AppColumnID.identifier():         appID,
+		AppColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	})
+}
+
+func (q *Queries) samlSSOSettings(ctx context.Context, where sq.Eq) (settings *SAMLSSOSettings, err error) {
+	stmt, scan := prepareSAMLSSOSettingsQuery(ctx, q.client)
+	query, args, err := stmt.Where(where).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ooy4a", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		settings, err = scan(row)
+		return err
+	}, query, args...)
+	return settings, err
+}
+
+func prepareSAMLSSOSettingsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*SAMLSSOSettings, error)) {
+	return sq.Select(
+			AppColumnID.identifier(),
+			AppColumnProjectID.identifier(),
+			AppSAMLSSOColumnIDPInitiatedEnabled.identifier(),
+			AppSAMLSSOColumnRelayState.identifier(),
+			AppSAMLSSOColumnWSFedEnabled.identifier(),
+			AppSAMLSSOColumnWSFedReplyURLs.identifier(),
+		).From(appsTable.identifier()).
+			Join(join(AppSAMLConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppSAMLSSOColumnAppID, AppColumnID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*SAMLSSOSettings, error) {
+			settings := new(SAMLSSOSettings)
+			var (
+				idpInitiatedEnabled sql.NullBool
+				relayState          sql.NullString
+				wsFedEnabled        sql.NullBool
+				wsFedReplyURLs      database.TextArray[string]
+			)
+			err := row.Scan(
+				&settings.AppID,
+				&settings.ProjectID,
+				&idpInitiatedEnabled,
+				&relayState,
+				&wsFedEnabled,
+				&wsFedReplyURLs,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Hai4o", "Errors.Project.App.NotExisting")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Shoo5", "Errors.Internal")
+			}
+			settings.IDPInitiatedEnabled = idpInitiatedEnabled.Bool
+			settings.RelayState = relayState.String
+			settings.WSFedEnabled = wsFedEnabled.Bool
+			settings.WSFedReplyURLs = wsFedReplyURLs
+			return settings, nil
+		}
+}
@@ -0,0 +1,139 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	expectedSAMLSSOSettingsQuery = regexp.QuoteMeta(`SELECT projections.apps7.id,` +
+		` projections.apps7.project_id,` +
+		` projections.apps7_saml_sso.idp_initiated_enabled,` +
+		` projections.apps7_saml_sso.relay_state,` +
+		` projections.apps7_saml_sso.ws_fed_enabled,` +
+		` projections.apps7_saml_sso.ws_fed_reply_urls` +
+		` FROM projections.apps7` +
+		` JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id` +
+		` LEFT JOIN projections.apps7_saml_sso ON projections.apps7.id = projections.apps7_saml_sso.app_id AND projections.apps7.instance_id = projections.apps7_saml_sso.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+	samlSSOSettingsCols = []string{
+		"id",
+		"project_id",
+		"idp_initiated_enabled",
+		"relay_state",
+		"ws_fed_enabled",
+		"ws_fed_reply_urls",
+	}
+)
+
+func Test_SAMLSSOSettingsPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareSAMLSSOSettingsQuery no result",
+			prepare: prepareSAMLSSOSettingsQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					expectedSAMLSSOSettingsQuery,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SAMLSSOSettings)(nil),
+		},
+		{
+			name:    "prepareSAMLSSOSettingsQuery without settings",
+			prepare: prepareSAMLSSOSettingsQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedSAMLSSOSettingsQuery,
+					samlSSOSettingsCols,
+					[]driver.Value{
+						"app-id",
+						"project-id",
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &SAMLSSOSettings{
+				AppID:     "app-id",
+				ProjectID: "project-id",
+			},
+		},
+		{
+			name:    "prepareSAMLSSOSettingsQuery with settings",
+			prepare: prepareSAMLSSOSettingsQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedSAMLSSOSettingsQuery,
+					samlSSOSettingsCols,
+					[]driver.Value{
+						"app-id",
+						"project-id",
+						true,
+						"/portal",
+						true,
+						database.TextArray[string]{"https://sharepoint.example.com/_trust/"},
+					},
+				),
+			},
+			object: &SAMLSSOSettings{
+				AppID:     "app-id",
+				ProjectID: "project-id",
+				SAMLSSOSettings: domain.SAMLSSOSettings{
+					IDPInitiatedEnabled: true,
+					RelayState:          "/portal",
+					WSFedEnabled:        true,
+					WSFedReplyURLs:      []string{"https://sharepoint.example.com/_trust/"},
+				},
+			},
+		},
+		{
+			name:    "prepareSAMLSSOSettingsQuery sql err",
+			prepare: prepareSAMLSSOSettingsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedSAMLSSOSettingsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SAMLSSOSettings)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
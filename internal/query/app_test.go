@@ -15,98 +15,104 @@ import (
 )
 
 var (
-	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps6.id,` +
-		` projections.apps6.name,` +
-		` projections.apps6.project_id,` +
-		` projections.apps6.creation_date,` +
-		` projections.apps6.change_date,` +
-		` projections.apps6.resource_owner,` +
-		` projections.apps6.state,` +
-		` projections.apps6.sequence,` +
+	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps7.id,` +
+		` projections.apps7.name,` +
+		` projections.apps7.project_id,` +
+		` projections.apps7.creation_date,` +
+		` projections.apps7.change_date,` +
+		` projections.apps7.resource_owner,` +
+		` projections.apps7.state,` +
+		` projections.apps7.sequence,` +
 		// api config
-		` projections.apps6_api_configs.app_id,` +
-		` projections.apps6_api_configs.client_id,` +
-		` projections.apps6_api_configs.auth_method,` +
+		` projections.apps7_api_configs.app_id,` +
+		` projections.apps7_api_configs.client_id,` +
+		` projections.apps7_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps6_oidc_configs.app_id,` +
-		` projections.apps6_oidc_configs.version,` +
-		` projections.apps6_oidc_configs.client_id,` +
-		` projections.apps6_oidc_configs.redirect_uris,` +
-		` projections.apps6_oidc_configs.response_types,` +
-		` projections.apps6_oidc_configs.grant_types,` +
-		` projections.apps6_oidc_configs.application_type,` +
-		` projections.apps6_oidc_configs.auth_method_type,` +
-		` projections.apps6_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps6_oidc_configs.is_dev_mode,` +
-		` projections.apps6_oidc_configs.access_token_type,` +
-		` projections.apps6_oidc_configs.access_token_role_assertion,` +
-		` projections.apps6_oidc_configs.id_token_role_assertion,` +
-		` projections.apps6_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps6_oidc_configs.clock_skew,` +
-		` projections.apps6_oidc_configs.additional_origins,` +
-		` projections.apps6_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps7_oidc_configs.app_id,` +
+		` projections.apps7_oidc_configs.version,` +
+		` projections.apps7_oidc_configs.client_id,` +
+		` projections.apps7_oidc_configs.redirect_uris,` +
+		` projections.apps7_oidc_configs.response_types,` +
+		` projections.apps7_oidc_configs.grant_types,` +
+		` projections.apps7_oidc_configs.application_type,` +
+		` projections.apps7_oidc_configs.auth_method_type,` +
+		` projections.apps7_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps7_oidc_configs.is_dev_mode,` +
+		` projections.apps7_oidc_configs.access_token_type,` +
+		` projections.apps7_oidc_configs.access_token_role_assertion,` +
+		` projections.apps7_oidc_configs.id_token_role_assertion,` +
+		` projections.apps7_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps7_oidc_configs.clock_skew,` +
+		` projections.apps7_oidc_configs.additional_origins,` +
+		` projections.apps7_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps7_oidc_configs.consent_required,` +
+		` projections.apps7_oidc_configs.tls_client_auth_subject_dn,` +
+		` projections.apps7_oidc_configs.refresh_token_reuse_grace_period,` +
 		//saml config
-		` projections.apps6_saml_configs.app_id,` +
-		` projections.apps6_saml_configs.entity_id,` +
-		` projections.apps6_saml_configs.metadata,` +
-		` projections.apps6_saml_configs.metadata_url` +
-		` FROM projections.apps6` +
-		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
-		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
+		` projections.apps7_saml_configs.app_id,` +
+		` projections.apps7_saml_configs.entity_id,` +
+		` projections.apps7_saml_configs.metadata,` +
+		` projections.apps7_saml_configs.metadata_url` +
+		` FROM projections.apps7` +
+		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
+		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps6.id,` +
-		` projections.apps6.name,` +
-		` projections.apps6.project_id,` +
-		` projections.apps6.creation_date,` +
-		` projections.apps6.change_date,` +
-		` projections.apps6.resource_owner,` +
-		` projections.apps6.state,` +
-		` projections.apps6.sequence,` +
+	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps7.id,` +
+		` projections.apps7.name,` +
+		` projections.apps7.project_id,` +
+		` projections.apps7.creation_date,` +
+		` projections.apps7.change_date,` +
+		` projections.apps7.resource_owner,` +
+		` projections.apps7.state,` +
+		` projections.apps7.sequence,` +
 		// api config
-		` projections.apps6_api_configs.app_id,` +
-		` projections.apps6_api_configs.client_id,` +
-		` projections.apps6_api_configs.auth_method,` +
+		` projections.apps7_api_configs.app_id,` +
+		` projections.apps7_api_configs.client_id,` +
+		` projections.apps7_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps6_oidc_configs.app_id,` +
-		` projections.apps6_oidc_configs.version,` +
-		` projections.apps6_oidc_configs.client_id,` +
-		` projections.apps6_oidc_configs.redirect_uris,` +
-		` projections.apps6_oidc_configs.response_types,` +
-		` projections.apps6_oidc_configs.grant_types,` +
-		` projections.apps6_oidc_configs.application_type,` +
-		` projections.apps6_oidc_configs.auth_method_type,` +
-		` projections.apps6_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps6_oidc_configs.is_dev_mode,` +
-		` projections.apps6_oidc_configs.access_token_type,` +
-		` projections.apps6_oidc_configs.access_token_role_assertion,` +
-		` projections.apps6_oidc_configs.id_token_role_assertion,` +
-		` projections.apps6_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps6_oidc_configs.clock_skew,` +
-		` projections.apps6_oidc_configs.additional_origins,` +
-		` projections.apps6_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps7_oidc_configs.app_id,` +
+		` projections.apps7_oidc_configs.version,` +
+		` projections.apps7_oidc_configs.client_id,` +
+		` projections.apps7_oidc_configs.redirect_uris,` +
+		` projections.apps7_oidc_configs.response_types,` +
+		` projections.apps7_oidc_configs.grant_types,` +
+		` projections.apps7_oidc_configs.application_type,` +
+		` projections.apps7_oidc_configs.auth_method_type,` +
+		` projections.apps7_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps7_oidc_configs.is_dev_mode,` +
+		` projections.apps7_oidc_configs.access_token_type,` +
+		` projections.apps7_oidc_configs.access_token_role_assertion,` +
+		` projections.apps7_oidc_configs.id_token_role_assertion,` +
+		` projections.apps7_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps7_oidc_configs.clock_skew,` +
+		` projections.apps7_oidc_configs.additional_origins,` +
+		` projections.apps7_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps7_oidc_configs.consent_required,` +
+		` projections.apps7_oidc_configs.tls_client_auth_subject_dn,` +
+		` projections.apps7_oidc_configs.refresh_token_reuse_grace_period,` +
 		//saml config
-		` projections.apps6_saml_configs.app_id,` +
-		` projections.apps6_saml_configs.entity_id,` +
-		` projections.apps6_saml_configs.metadata,` +
-		` projections.apps6_saml_configs.metadata_url,` +
+		` projections.apps7_saml_configs.app_id,` +
+		` projections.apps7_saml_configs.entity_id,` +
+		` projections.apps7_saml_configs.metadata,` +
+		` projections.apps7_saml_configs.metadata_url,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.apps6` +
-		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
-		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
+		` FROM projections.apps7` +
+		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
+		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps6_api_configs.client_id,` +
-		` projections.apps6_oidc_configs.client_id` +
-		` FROM projections.apps6` +
-		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
-		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
+	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps7_api_configs.client_id,` +
+		` projections.apps7_oidc_configs.client_id` +
+		` FROM projections.apps7` +
+		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
+		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps6.project_id` +
-		` FROM projections.apps6` +
-		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
-		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
+	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps7.project_id` +
+		` FROM projections.apps7` +
+		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
+		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	expectedProjectByAppQuery = regexp.QuoteMeta(`SELECT projections.projects4.id,` +
 		` projections.projects4.creation_date,` +
@@ -120,10 +126,10 @@ var (
 		` projections.projects4.has_project_check,` +
 		` projections.projects4.private_labeling_setting` +
 		` FROM projections.projects4` +
-		` JOIN projections.apps6 ON projections.projects4.id = projections.apps6.project_id AND projections.projects4.instance_id = projections.apps6.instance_id` +
-		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
-		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
+		` JOIN projections.apps7 ON projections.projects4.id = projections.apps7.project_id AND projections.projects4.instance_id = projections.apps7.instance_id` +
+		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
+		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	appCols = database.TextArray[string]{
@@ -157,6 +163,9 @@ var (
 		"clock_skew",
 		"additional_origins",
 		"skip_native_app_success_page",
+		"consent_required",
+		"tls_client_auth_subject_dn",
+		"refresh_token_reuse_grace_period",
 		//saml config
 		"app_id",
 		"entity_id",
@@ -228,6 +237,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -294,6 +306,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -363,6 +378,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -434,6 +452,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -520,6 +541,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -606,6 +630,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -692,6 +719,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -778,6 +808,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -864,6 +897,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							true,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -950,6 +986,9 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -987,6 +1026,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1024,6 +1066,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
@@ -1192,6 +1237,9 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
@@ -1250,6 +1298,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1313,6 +1364,9 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							5 * time.Second,
 							// saml config
 							nil,
 							nil,
@@ -1350,6 +1404,8 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+
+					RefreshTokenReuseGracePeriod: 5 * time.Second,
 				},
 			},
 		}, {
@@ -1391,6 +1447,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -1455,6 +1514,9 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -1534,6 +1596,9 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -1613,6 +1678,9 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -1692,6 +1760,9 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
+							"",
+							0,
 							// saml config
 							nil,
 							nil,
@@ -0,0 +1,144 @@
+package query
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// Caches holds the caches of the hot read paths.
+// Cached objects are invalidated by the projections they are read from,
+// after the projection committed the statements of an aggregate.
+type Caches struct {
+	instance    cache.Cache[instanceIndex, string, *Instance]
+	org         cache.Cache[orgIndex, string, *cachedOrg]
+	loginPolicy cache.Cache[loginPolicyIndex, string, *cachedLoginPolicy]
+	oidcClient  cache.Cache[oidcClientIndex, string, *OIDCClient]
+	signingKeys cache.Cache[signingKeysIndex, string, *cachedSigningKeys]
+}
+
+func startCaches(connectors connector.Connectors) (_ *Caches, err error) {
+	caches := new(Caches)
+	caches.instance, err = connector.StartCache[instanceIndex, string, *Instance]("query_instance", instanceIndices, connectors.Config.Instance, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.org, err = connector.StartCache[orgIndex, string, *cachedOrg]("query_org", orgIndices, connectors.Config.Organization, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.loginPolicy, err = connector.StartCache[loginPolicyIndex, string, *cachedLoginPolicy]("query_login_policy", loginPolicyIndices, connectors.Config.LoginPolicy, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.oidcClient, err = connector.StartCache[oidcClientIndex, string, *OIDCClient]("query_oidc_client", oidcClientIndices, connectors.Config.OIDCClient, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.signingKeys, err = connector.StartCache[signingKeysIndex, string, *cachedSigningKeys]("query_signing_keys", signingKeysIndices, connectors.Config.SigningKeys, connectors)
+	if err != nil {
+		return nil, err
+	}
+	return caches, nil
+}
+
+// registerInvalidations must be called after the projections are created and before they are started.
+func (c *Caches) registerInvalidations() {
+	invalidateInstance := invalidateByInstance(c.instance, instanceIndexByID)
+	projection.InstanceProjection.RegisterCacheInvalidation(invalidateInstance)
+	projection.InstanceDomainProjection.RegisterCacheInvalidation(invalidateInstance)
+	projection.SecurityPolicyProjection.RegisterCacheInvalidation(invalidateInstance)
+	projection.LimitsProjection.RegisterCacheInvalidation(invalidateInstance)
+
+	projection.OrgProjection.RegisterCacheInvalidation(invalidateByAggregate(c.org,
+		map[eventstore.AggregateType]orgIndex{
+			org.AggregateType: orgIndexByID,
+		},
+		orgIndexByInstance,
+	))
+
+	invalidateLoginPolicy := invalidateByAggregate(c.loginPolicy,
+		map[eventstore.AggregateType]loginPolicyIndex{
+			org.AggregateType: loginPolicyIndexByOrg,
+		},
+		loginPolicyIndexByInstance,
+	)
+	projection.LoginPolicyProjection.RegisterCacheInvalidation(invalidateLoginPolicy)
+	projection.IDPLoginPolicyLinkProjection.RegisterCacheInvalidation(invalidateLoginPolicy)
+	projection.IDPTemplateProjection.RegisterCacheInvalidation(invalidateLoginPolicy)
+
+	invalidateOIDCClient := invalidateByAggregate(c.oidcClient,
+		map[eventstore.AggregateType]oidcClientIndex{
+			project.AggregateType: oidcClientIndexByProject,
+		},
+		oidcClientIndexByInstance,
+	)
+	projection.AppProjection.RegisterCacheInvalidation(invalidateOIDCClient)
+	projection.ProjectRoleProjection.RegisterCacheInvalidation(invalidateOIDCClient)
+	projection.OIDCSettingsProjection.RegisterCacheInvalidation(invalidateOIDCClient)
+	// the public keys of the applications are part of the client, keys of machine users are not.
+	projection.AuthNKeyProjection.RegisterCacheInvalidation(skipAggregates(invalidateOIDCClient, user.AggregateType))
+
+	projection.KeyProjection.RegisterCacheInvalidation(invalidateByInstance(c.signingKeys, signingKeysIndexByInstance))
+}
+
+// invalidateByInstance invalidates all objects of the instances of the aggregates.
+func invalidateByInstance[I comparable, V cache.Entry[I, string]](c cache.Cache[I, string, V], index I) func(ctx context.Context, aggregates []*eventstore.Aggregate) {
+	return func(ctx context.Context, aggregates []*eventstore.Aggregate) {
+		instanceIDs := make([]string, 0, len(aggregates))
+		for _, aggregate := range aggregates {
+			if !slices.Contains(instanceIDs, aggregate.InstanceID) {
+				instanceIDs = append(instanceIDs, aggregate.InstanceID)
+			}
+		}
+		err := c.Invalidate(ctx, index, instanceIDs...)
+		logging.OnError(err).Warn("cache invalidation failed")
+	}
+}
+
+// skipAggregates removes the aggregates of the types before they are passed to invalidate,
+// because their events don't change the cached objects.
+func skipAggregates(invalidate func(ctx context.Context, aggregates []*eventstore.Aggregate), types ...eventstore.AggregateType) func(ctx context.Context, aggregates []*eventstore.Aggregate) {
+	return func(ctx context.Context, aggregates []*eventstore.Aggregate) {
+		aggregates = slices.DeleteFunc(slices.Clone(aggregates), func(aggregate *eventstore.Aggregate) bool {
+			return slices.Contains(types, aggregate.Type)
+		})
+		if len(aggregates) == 0 {
+			return
+		}
+		invalidate(ctx, aggregates)
+	}
+}
+
+// invalidateByAggregate invalidates the objects of aggregates with a mapped aggregate type
+// using the instance scoped aggregate id as key.
+// All objects of the instance are invalidated for aggregates of other types,
+// as they might contain defaults or belong to a removed instance or organization.
+func invalidateByAggregate[I comparable, V cache.Entry[I, string]](c cache.Cache[I, string, V], aggregateIndices map[eventstore.AggregateType]I, instanceIndex I) func(ctx context.Context, aggregates []*eventstore.Aggregate) {
+	return func(ctx context.Context, aggregates []*eventstore.Aggregate) {
+		for _, aggregate := range aggregates {
+			var err error
+			if index, ok := aggregateIndices[aggregate.Type]; ok {
+				err = c.Invalidate(ctx, index, instanceScopedKey(aggregate.InstanceID, aggregate.ID))
+			} else {
+				err = c.Invalidate(ctx, instanceIndex, aggregate.InstanceID)
+			}
+			logging.OnError(err).Warn("cache invalidation failed")
+		}
+	}
+}
+
+// instanceScopedKey prefixes the id with the instance id,
+// because ids are only unique inside an instance.
+func instanceScopedKey(instanceID, id string) string {
+	return instanceID + ":" + id
+}
@@ -0,0 +1,215 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/gomap"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestInstance_JSON(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	block := true
+	retention := time.Hour
+	want := &Instance{
+		ID:           "instanceID",
+		ChangeDate:   now,
+		CreationDate: now,
+		Sequence:     20211108,
+		Name:         "instance",
+		DefaultOrgID: "orgID",
+		IAMProjectID: "projectID",
+		ConsoleID:    "consoleID",
+		ConsoleAppID: "consoleAppID",
+		DefaultLang:  language.German,
+		Domains: []*InstanceDomain{
+			{
+				CreationDate: now,
+				ChangeDate:   now,
+				Sequence:     20211108,
+				Domain:       "zitadel.ch",
+				InstanceID:   "instanceID",
+				IsPrimary:    true,
+			},
+		},
+		csp: csp{
+			enabled:        true,
+			allowedOrigins: []string{"https://zitadel.ch"},
+		},
+		block:             &block,
+		auditLogRetention: &retention,
+	}
+	data, err := json.Marshal(want.withHost("zitadel.ch:443"))
+	require.NoError(t, err)
+	got := new(Instance)
+	require.NoError(t, json.Unmarshal(data, got))
+
+	assert.Equal(t, want, got)
+	assert.Empty(t, got.RequestedHost(), "requested host must not be encoded")
+	assert.Equal(t, []string{"zitadel.ch"}, got.Keys(instanceIndexByHost))
+	assert.Equal(t, "zitadel.ch:443", got.withHost("zitadel.ch:443").RequestedHost())
+}
+
+func Test_cachedSigningKeys_activeKeys(t *testing.T) {
+	keys := newCachedSigningKeys("instanceID", &PrivateKeys{
+		Keys: []PrivateKey{
+			&privateKey{
+				key: key{
+					id:        "key1",
+					algorithm: "RS256",
+					use:       domain.KeyUsageSigning,
+				},
+				expiry:     testNow.Add(time.Hour),
+				privateKey: &crypto.CryptoValue{KeyID: "keyID", Crypted: []byte("key1")},
+			},
+			&privateKey{
+				key: key{
+					id:        "key2",
+					algorithm: "RS256",
+					use:       domain.KeyUsageSigning,
+				},
+				expiry:     testNow.Add(2 * time.Hour),
+				privateKey: &crypto.CryptoValue{KeyID: "keyID", Crypted: []byte("key2")},
+			},
+		},
+	})
+	data, err := json.Marshal(keys)
+	require.NoError(t, err)
+	cached := new(cachedSigningKeys)
+	require.NoError(t, json.Unmarshal(data, cached))
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want []string
+	}{
+		{
+			name: "all active",
+			t:    testNow,
+			want: []string{"key1", "key2"},
+		},
+		{
+			name: "first expired",
+			t:    testNow.Add(time.Hour),
+			want: []string{"key2"},
+		},
+		{
+			name: "all expired",
+			t:    testNow.Add(2 * time.Hour),
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cached.activeKeys(tt.t)
+			ids := make([]string, len(got.Keys))
+			for i, key := range got.Keys {
+				ids[i] = key.ID()
+				assert.Equal(t, domain.KeyUsageSigning, key.Use())
+				assert.Equal(t, []byte(key.ID()), key.Key().Crypted)
+			}
+			assert.Equal(t, tt.want, ids)
+			assert.Equal(t, uint64(len(tt.want)), got.Count)
+		})
+	}
+}
+
+func Test_invalidateByAggregate(t *testing.T) {
+	tests := []struct {
+		name       string
+		aggregates []*eventstore.Aggregate
+		wantOrgs   []string
+	}{
+		{
+			name: "org aggregate",
+			aggregates: []*eventstore.Aggregate{
+				{Type: org.AggregateType, ID: "org1", InstanceID: "instance1"},
+			},
+			wantOrgs: []string{"org2", "org3"},
+		},
+		{
+			name: "instance aggregate",
+			aggregates: []*eventstore.Aggregate{
+				{Type: instance.AggregateType, ID: "instance1", InstanceID: "instance1"},
+			},
+			wantOrgs: []string{"org3"},
+		},
+		{
+			name: "other aggregate",
+			aggregates: []*eventstore.Aggregate{
+				{Type: user.AggregateType, ID: "user1", InstanceID: "instance2"},
+			},
+			wantOrgs: []string{"org1", "org2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := gomap.NewCache[orgIndex, string, *cachedOrg]("test", orgIndices, &cache.Config{}, gomap.Config{})
+			c.Set(ctx, &cachedOrg{InstanceID: "instance1", Org: &Org{ID: "org1"}})
+			c.Set(ctx, &cachedOrg{InstanceID: "instance1", Org: &Org{ID: "org2"}})
+			c.Set(ctx, &cachedOrg{InstanceID: "instance2", Org: &Org{ID: "org3"}})
+
+			invalidate := invalidateByAggregate(c, map[eventstore.AggregateType]orgIndex{org.AggregateType: orgIndexByID}, orgIndexByInstance)
+			invalidate(ctx, tt.aggregates)
+
+			got := make([]string, 0, 3)
+			for _, key := range []string{"instance1:org1", "instance1:org2", "instance2:org3"} {
+				if cached, ok := c.Get(ctx, orgIndexByID, key); ok {
+					got = append(got, cached.Org.ID)
+				}
+			}
+			assert.Equal(t, tt.wantOrgs, got)
+		})
+	}
+}
+
+func Test_skipAggregates(t *testing.T) {
+	tests := []struct {
+		name       string
+		aggregates []*eventstore.Aggregate
+		want       []*eventstore.Aggregate
+	}{
+		{
+			name: "skipped aggregate",
+			aggregates: []*eventstore.Aggregate{
+				{Type: user.AggregateType, ID: "user1", InstanceID: "instance1"},
+			},
+			want: nil,
+		},
+		{
+			name: "mixed aggregates",
+			aggregates: []*eventstore.Aggregate{
+				{Type: user.AggregateType, ID: "user1", InstanceID: "instance1"},
+				{Type: project.AggregateType, ID: "project1", InstanceID: "instance1"},
+			},
+			want: []*eventstore.Aggregate{
+				{Type: project.AggregateType, ID: "project1", InstanceID: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*eventstore.Aggregate
+			invalidate := skipAggregates(func(_ context.Context, aggregates []*eventstore.Aggregate) {
+				got = aggregates
+			}, user.AggregateType)
+			invalidate(context.Background(), tt.aggregates)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -144,6 +144,23 @@ func (q *Queries) ClearCurrentSequence(ctx context.Context, projectionName strin
 	return nil
 }
 
+// RebuildProjection starts the rebuild of the projection into shadow tables in the background.
+// Only the rows of the instances are replaced if instance ids are passed.
+func (q *Queries) RebuildProjection(ctx context.Context, projectionName string, instanceIDs []string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return projection.StartRebuild(ctx, projectionName, instanceIDs)
+}
+
+// ProjectionRebuildState returns the state of the last rebuild of the projection
+func (q *Queries) ProjectionRebuildState(ctx context.Context, projectionName string) (_ *projection.RebuildState, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return projection.RebuildStatus(ctx, projectionName)
+}
+
 func (q *Queries) checkAndLock(tx *sql.Tx, projectionName string) (name string, err error) {
 	stmt, args, err := sq.Select(CurrentStateColProjectionName.identifier()).
 		From(currentStateTable.identifier()).
@@ -0,0 +1,180 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type IDPAutoLinking struct {
+	IDPID         string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	domain.IDPAutoLinking
+}
+
+var (
+	idpAutoLinkingTable = table{
+		name:          projection.IDPAutoLinkingTable,
+		instanceIDCol: projection.IDPAutoLinkingInstanceIDCol,
+	}
+	IDPAutoLinkingIDPIDCol = Column{
+		name:  projection.IDPAutoLinkingIDPIDCol,
+		table: idpAutoLinkingTable,
+	}
+	IDPAutoLinkingCreationDateCol = Column{
+		name:  projection.IDPAutoLinkingCreationDateCol,
+		table: idpAutoLinkingTable,
+	}
+	IDPAutoLinkingChangeDateCol = Column{
+		name:  projection.IDPAutoLinkingChangeDateCol,
+		table: idpAutoLinkingTable,
+	}
+	IDPAutoLinkingSequenceCol = Column{
+		name:  projection.IDPAutoLinkingSequenceCol,
+		table: idpAutoLinkingTable,
+	}
+	IDPAutoLinkingResourceOwnerCol = Column{
+		name:  projection.IDPAutoLinkingResourceOwnerCol,
+		table: idpAutoLinkingTable,
+	}
+	IDPAutoLinkingInstanceIDCol = Column{
+		name:  projection.IDPAutoLinkingInstanceIDCol,
+		table: idpAutoLinkingTable,
+	}
+	IDPAutoLinkingOptionCol = Column{
+		name:  projection.IDPAutoLinkingOptionCol,
+		table: idpAutoLinkingTable,
+	}
+	IDPAutoLinkingTrustLevelCol = Column{
+		name:  projection.IDPAutoLinkingTrustLevelCol,
+		table: idpAutoLinkingTable,
+	}
+)
+
+// IDPAutoLinkingByIDPID returns the automatic linking of external users to existing users of the provider.
+// The resourceOwner is the instance for instance providers and the organization otherwise.
+func (q *Queries) IDPAutoLinkingByIDPID(ctx context.Context, idpID, resourceOwner string) (linking *IDPAutoLinking, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareIDPAutoLinkingQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		IDPAutoLinkingIDPIDCol.identifier():         idpID,
+		IDPAutoLinkingResourceOwnerCol.identifier(): resourceOwner,
+		IDPAutoLinkingInstanceIDCol.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-ooL1e", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		linking, err = scan(row)
+		return err
+	}, stmt, args...)
+	return linking, err
+}
+
+// IDPAutoLinkingUser returns the only active human user, whose username or verified email address
+// (depending on the option) exactly matches the value asserted by the provider.
+// If the resourceOwner is set, only users of the organization are considered.
+// It returns a NotFound error, if none or more than one user matches.
+func (q *Queries) IDPAutoLinkingUser(ctx context.Context, option domain.AutoLinkingOption, value, resourceOwner string) (_ *User, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	typeQuery, err := NewUserTypeSearchQuery(int32(domain.UserTypeHuman))
+	if err != nil {
+		return nil, err
+	}
+	queries := []SearchQuery{typeQuery}
+	var valueQuery SearchQuery
+	switch option {
+	case domain.AutoLinkingOptionUsername:
+		valueQuery, err = NewUserUsernameSearchQuery(value, TextEquals)
+	case domain.AutoLinkingOptionEmail:
+		valueQuery, err = NewUserEmailSearchQuery(value, TextEquals)
+	case domain.AutoLinkingOptionUnspecified:
+		fallthrough
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Ue7ah", "Errors.IDP.AutoLinking.OptionInvalid")
+	}
+	if err != nil {
+		return nil, err
+	}
+	queries = append(queries, valueQuery)
+	if resourceOwner != "" {
+		ownerQuery, err := NewUserResourceOwnerSearchQuery(resourceOwner, TextEquals)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, ownerQuery)
+	}
+	users, err := q.SearchUsers(ctx, &UserSearchQueries{Queries: queries})
+	if err != nil {
+		return nil, err
+	}
+	var match *User
+	for _, user := range users.Users {
+		if user.State != domain.UserStateActive || user.Human == nil {
+			continue
+		}
+		if option == domain.AutoLinkingOptionEmail && !user.Human.IsEmailVerified {
+			continue
+		}
+		// ambiguous matches must never be linked
+		if match != nil {
+			return nil, zerrors.ThrowNotFound(nil, "QUERY-Ahh9o", "Errors.User.NotFound")
+		}
+		match = user
+	}
+	if match == nil {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-eeH6u", "Errors.User.NotFound")
+	}
+	return match, nil
+}
+
+func prepareIDPAutoLinkingQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*IDPAutoLinking, error)) {
+	return sq.Select(
+			IDPAutoLinkingIDPIDCol.identifier(),
+			IDPAutoLinkingCreationDateCol.identifier(),
+			IDPAutoLinkingChangeDateCol.identifier(),
+			IDPAutoLinkingSequenceCol.identifier(),
+			IDPAutoLinkingResourceOwnerCol.identifier(),
+			IDPAutoLinkingOptionCol.identifier(),
+			IDPAutoLinkingTrustLevelCol.identifier(),
+		).
+			From(idpAutoLinkingTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*IDPAutoLinking, error) {
+			linking := new(IDPAutoLinking)
+			err := row.Scan(
+				&linking.IDPID,
+				&linking.CreationDate,
+				&linking.ChangeDate,
+				&linking.Sequence,
+				&linking.ResourceOwner,
+				&linking.Option,
+				&linking.TrustLevel,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Ri0ai", "Errors.IDP.AutoLinking.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-ahJ8u", "Errors.Internal")
+			}
+			return linking, nil
+		}
+}
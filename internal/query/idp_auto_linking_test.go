@@ -0,0 +1,118 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	idpAutoLinkingQuery = `SELECT projections.idp_auto_linkings.idp_id,` +
+		` projections.idp_auto_linkings.creation_date,` +
+		` projections.idp_auto_linkings.change_date,` +
+		` projections.idp_auto_linkings.sequence,` +
+		` projections.idp_auto_linkings.resource_owner,` +
+		` projections.idp_auto_linkings.option,` +
+		` projections.idp_auto_linkings.trust_level` +
+		` FROM projections.idp_auto_linkings` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	idpAutoLinkingCols = []string{
+		"idp_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"option",
+		"trust_level",
+	}
+)
+
+func Test_IDPAutoLinkingPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareIDPAutoLinkingQuery no result",
+			prepare: prepareIDPAutoLinkingQuery,
+			want: want{
+				sqlExpectations: mockQueryScanErr(
+					regexp.QuoteMeta(idpAutoLinkingQuery),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*IDPAutoLinking)(nil),
+		},
+		{
+			name:    "prepareIDPAutoLinkingQuery found",
+			prepare: prepareIDPAutoLinkingQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpAutoLinkingQuery),
+					idpAutoLinkingCols,
+					[]driver.Value{
+						"idp-id",
+						testNow,
+						testNow,
+						uint64(20211108),
+						"ro",
+						domain.AutoLinkingOptionUsername,
+						domain.IDPTrustLevelFull,
+					},
+				),
+			},
+			object: &IDPAutoLinking{
+				IDPID:         "idp-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211108,
+				ResourceOwner: "ro",
+				IDPAutoLinking: domain.IDPAutoLinking{
+					Option:     domain.AutoLinkingOptionUsername,
+					TrustLevel: domain.IDPTrustLevelFull,
+				},
+			},
+		},
+		{
+			name:    "prepareIDPAutoLinkingQuery sql err",
+			prepare: prepareIDPAutoLinkingQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(idpAutoLinkingQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*IDPAutoLinking)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,451 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type LDAPSync struct {
+	IDPID         string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	domain.LDAPSync
+
+	LastRunStartedAt  time.Time
+	LastRunFinishedAt time.Time
+	LastRunError      string
+}
+
+// IsDue returns true if the synchronization is enabled and its interval elapsed since the start of the last run.
+func (s *LDAPSync) IsDue(now time.Time) bool {
+	return s.Enabled && !s.LastRunStartedAt.Add(s.Interval).After(now)
+}
+
+type LDAPSyncRuns struct {
+	SearchResponse
+	Runs []*LDAPSyncRun
+}
+
+type LDAPSyncRun struct {
+	IDPID         string
+	ResourceOwner string
+	Sequence      uint64
+	FinishedAt    time.Time
+	domain.LDAPSyncReport
+}
+
+type LDAPSyncRunSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *LDAPSyncRunSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+var (
+	ldapSyncTable = table{
+		name:          projection.LDAPSyncTable,
+		instanceIDCol: projection.LDAPSyncInstanceIDCol,
+	}
+	LDAPSyncIDPIDCol = Column{
+		name:  projection.LDAPSyncIDPIDCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncCreationDateCol = Column{
+		name:  projection.LDAPSyncCreationDateCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncChangeDateCol = Column{
+		name:  projection.LDAPSyncChangeDateCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncSequenceCol = Column{
+		name:  projection.LDAPSyncSequenceCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncResourceOwnerCol = Column{
+		name:  projection.LDAPSyncResourceOwnerCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncInstanceIDCol = Column{
+		name:  projection.LDAPSyncInstanceIDCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncEnabledCol = Column{
+		name:  projection.LDAPSyncEnabledCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncIntervalCol = Column{
+		name:  projection.LDAPSyncIntervalCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncFilterCol = Column{
+		name:  projection.LDAPSyncFilterCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncOrgIDCol = Column{
+		name:  projection.LDAPSyncOrgIDCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncDeactivateMissingCol = Column{
+		name:  projection.LDAPSyncDeactivateMissingCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncNestedGroupsCol = Column{
+		name:  projection.LDAPSyncNestedGroupsCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncGroupMappingsCol = Column{
+		name:  projection.LDAPSyncGroupMappingsCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncLastRunStartedAtCol = Column{
+		name:  projection.LDAPSyncLastRunStartedAtCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncLastRunFinishedAtCol = Column{
+		name:  projection.LDAPSyncLastRunFinishedAtCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncLastRunErrorCol = Column{
+		name:  projection.LDAPSyncLastRunErrorCol,
+		table: ldapSyncTable,
+	}
+)
+
+var (
+	ldapSyncRunTable = table{
+		name:          projection.LDAPSyncRunTable,
+		instanceIDCol: projection.LDAPSyncRunInstanceIDCol,
+	}
+	LDAPSyncRunIDPIDCol = Column{
+		name:  projection.LDAPSyncRunIDPIDCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunInstanceIDCol = Column{
+		name:  projection.LDAPSyncRunInstanceIDCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunResourceOwnerCol = Column{
+		name:  projection.LDAPSyncRunResourceOwnerCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunSequenceCol = Column{
+		name:  projection.LDAPSyncRunSequenceCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunStartedAtCol = Column{
+		name:  projection.LDAPSyncRunStartedAtCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunFinishedAtCol = Column{
+		name:  projection.LDAPSyncRunFinishedAtCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunUsersFoundCol = Column{
+		name:  projection.LDAPSyncRunUsersFoundCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunCreatedCol = Column{
+		name:  projection.LDAPSyncRunCreatedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunUpdatedCol = Column{
+		name:  projection.LDAPSyncRunUpdatedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunDeactivatedCol = Column{
+		name:  projection.LDAPSyncRunDeactivatedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunReactivatedCol = Column{
+		name:  projection.LDAPSyncRunReactivatedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunGrantsAddedCol = Column{
+		name:  projection.LDAPSyncRunGrantsAddedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunGrantsChangedCol = Column{
+		name:  projection.LDAPSyncRunGrantsChangedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunGrantsRemovedCol = Column{
+		name:  projection.LDAPSyncRunGrantsRemovedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunFailedCol = Column{
+		name:  projection.LDAPSyncRunFailedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunErrorCol = Column{
+		name:  projection.LDAPSyncRunErrorCol,
+		table: ldapSyncRunTable,
+	}
+)
+
+// LDAPSyncByIDPID returns the directory synchronization settings of the LDAP provider.
+// The resourceOwner is the instance for instance providers and the organization otherwise.
+func (q *Queries) LDAPSyncByIDPID(ctx context.Context, idpID, resourceOwner string) (sync *LDAPSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareLDAPSyncQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		LDAPSyncIDPIDCol.identifier():         idpID,
+		LDAPSyncResourceOwnerCol.identifier(): resourceOwner,
+		LDAPSyncInstanceIDCol.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-ieZ4u", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		sync, err = scan(row)
+		return err
+	}, stmt, args...)
+	return sync, err
+}
+
+// EnabledLDAPSyncs returns all enabled directory synchronizations of the instance.
+func (q *Queries) EnabledLDAPSyncs(ctx context.Context) (syncs []*LDAPSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareLDAPSyncsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		LDAPSyncEnabledCol.identifier():    true,
+		LDAPSyncInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-ahS3o", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		syncs, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-oong7", "Errors.Internal")
+	}
+	return syncs, nil
+}
+
+// SearchLDAPSyncRuns returns the reports of the runs of a directory synchronization.
+func (q *Queries) SearchLDAPSyncRuns(ctx context.Context, queries *LDAPSyncRunSearchQueries) (runs *LDAPSyncRuns, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	ctx = database.WithCallType(ctx, database.CallTypeList)
+
+	query, scan := prepareLDAPSyncRunsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		LDAPSyncRunInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Gie1u", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		runs, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ohch5", "Errors.Internal")
+	}
+	runs.State, err = q.latestState(ctx, ldapSyncTable)
+	return runs, err
+}
+
+func NewLDAPSyncRunIDPIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(LDAPSyncRunIDPIDCol, value, TextEquals)
+}
+
+func NewLDAPSyncRunResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(LDAPSyncRunResourceOwnerCol, value, TextEquals)
+}
+
+func ldapSyncColumns() []string {
+	return []string{
+		LDAPSyncIDPIDCol.identifier(),
+		LDAPSyncCreationDateCol.identifier(),
+		LDAPSyncChangeDateCol.identifier(),
+		LDAPSyncSequenceCol.identifier(),
+		LDAPSyncResourceOwnerCol.identifier(),
+		LDAPSyncEnabledCol.identifier(),
+		LDAPSyncIntervalCol.identifier(),
+		LDAPSyncFilterCol.identifier(),
+		LDAPSyncOrgIDCol.identifier(),
+		LDAPSyncDeactivateMissingCol.identifier(),
+		LDAPSyncNestedGroupsCol.identifier(),
+		LDAPSyncGroupMappingsCol.identifier(),
+		LDAPSyncLastRunStartedAtCol.identifier(),
+		LDAPSyncLastRunFinishedAtCol.identifier(),
+		LDAPSyncLastRunErrorCol.identifier(),
+	}
+}
+
+type ldapSyncScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanLDAPSync(row ldapSyncScanner) (*LDAPSync, error) {
+	sync := new(LDAPSync)
+	var (
+		filter            sql.NullString
+		orgID             sql.NullString
+		groupMappings     []byte
+		lastRunStartedAt  sql.NullTime
+		lastRunFinishedAt sql.NullTime
+		lastRunError      sql.NullString
+	)
+	err := row.Scan(
+		&sync.IDPID,
+		&sync.CreationDate,
+		&sync.ChangeDate,
+		&sync.Sequence,
+		&sync.ResourceOwner,
+		&sync.Enabled,
+		&sync.Interval,
+		&filter,
+		&orgID,
+		&sync.DeactivateMissing,
+		&sync.NestedGroups,
+		&groupMappings,
+		&lastRunStartedAt,
+		&lastRunFinishedAt,
+		&lastRunError,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupMappings) > 0 {
+		if err = json.Unmarshal(groupMappings, &sync.GroupMappings); err != nil {
+			return nil, err
+		}
+	}
+	sync.Filter = filter.String
+	sync.OrgID = orgID.String
+	sync.LastRunStartedAt = lastRunStartedAt.Time
+	sync.LastRunFinishedAt = lastRunFinishedAt.Time
+	sync.LastRunError = lastRunError.String
+	return sync, nil
+}
+
+func prepareLDAPSyncQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*LDAPSync, error)) {
+	return sq.Select(ldapSyncColumns()...).
+			From(ldapSyncTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*LDAPSync, error) {
+			sync, err := scanLDAPSync(row)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Hoo4u", "Errors.IDP.LDAPSync.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ahx0e", "Errors.Internal")
+			}
+			return sync, nil
+		}
+}
+
+func prepareLDAPSyncsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*LDAPSync, error)) {
+	return sq.Select(ldapSyncColumns()...).
+			From(ldapSyncTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*LDAPSync, error) {
+			syncs := make([]*LDAPSync, 0)
+			for rows.Next() {
+				sync, err := scanLDAPSync(rows)
+				if err != nil {
+					return nil, err
+				}
+				syncs = append(syncs, sync)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Eim1a", "Errors.Query.CloseRows")
+			}
+			return syncs, nil
+		}
+}
+
+func prepareLDAPSyncRunsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*LDAPSyncRuns, error)) {
+	return sq.Select(
+			LDAPSyncRunIDPIDCol.identifier(),
+			LDAPSyncRunResourceOwnerCol.identifier(),
+			LDAPSyncRunSequenceCol.identifier(),
+			LDAPSyncRunStartedAtCol.identifier(),
+			LDAPSyncRunFinishedAtCol.identifier(),
+			LDAPSyncRunUsersFoundCol.identifier(),
+			LDAPSyncRunCreatedCol.identifier(),
+			LDAPSyncRunUpdatedCol.identifier(),
+			LDAPSyncRunDeactivatedCol.identifier(),
+			LDAPSyncRunReactivatedCol.identifier(),
+			LDAPSyncRunGrantsAddedCol.identifier(),
+			LDAPSyncRunGrantsChangedCol.identifier(),
+			LDAPSyncRunGrantsRemovedCol.identifier(),
+			LDAPSyncRunFailedCol.identifier(),
+			LDAPSyncRunErrorCol.identifier(),
+			countColumn.identifier(),
+		).
+			From(ldapSyncRunTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*LDAPSyncRuns, error) {
+			runs := make([]*LDAPSyncRun, 0)
+			var count uint64
+			for rows.Next() {
+				run := new(LDAPSyncRun)
+				var runErr sql.NullString
+				err := rows.Scan(
+					&run.IDPID,
+					&run.ResourceOwner,
+					&run.Sequence,
+					&run.StartedAt,
+					&run.FinishedAt,
+					&run.UsersFound,
+					&run.Created,
+					&run.Updated,
+					&run.Deactivated,
+					&run.Reactivated,
+					&run.GrantsAdded,
+					&run.GrantsChanged,
+					&run.GrantsRemoved,
+					&run.Failed,
+					&runErr,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				run.Error = runErr.String
+				runs = append(runs, run)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-ieT8o", "Errors.Query.CloseRows")
+			}
+			return &LDAPSyncRuns{
+				Runs: runs,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
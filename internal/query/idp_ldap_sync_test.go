@@ -0,0 +1,323 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	ldapSyncQuery = `SELECT projections.idp_ldap_syncs.idp_id,` +
+		` projections.idp_ldap_syncs.creation_date,` +
+		` projections.idp_ldap_syncs.change_date,` +
+		` projections.idp_ldap_syncs.sequence,` +
+		` projections.idp_ldap_syncs.resource_owner,` +
+		` projections.idp_ldap_syncs.enabled,` +
+		` projections.idp_ldap_syncs.interval,` +
+		` projections.idp_ldap_syncs.filter,` +
+		` projections.idp_ldap_syncs.org_id,` +
+		` projections.idp_ldap_syncs.deactivate_missing,` +
+		` projections.idp_ldap_syncs.nested_groups,` +
+		` projections.idp_ldap_syncs.group_mappings,` +
+		` projections.idp_ldap_syncs.last_run_started_at,` +
+		` projections.idp_ldap_syncs.last_run_finished_at,` +
+		` projections.idp_ldap_syncs.last_run_error` +
+		` FROM projections.idp_ldap_syncs` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	ldapSyncCols = []string{
+		"idp_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"enabled",
+		"interval",
+		"filter",
+		"org_id",
+		"deactivate_missing",
+		"nested_groups",
+		"group_mappings",
+		"last_run_started_at",
+		"last_run_finished_at",
+		"last_run_error",
+	}
+	ldapSyncRunsQuery = `SELECT projections.idp_ldap_syncs_runs.idp_id,` +
+		` projections.idp_ldap_syncs_runs.resource_owner,` +
+		` projections.idp_ldap_syncs_runs.sequence,` +
+		` projections.idp_ldap_syncs_runs.started_at,` +
+		` projections.idp_ldap_syncs_runs.finished_at,` +
+		` projections.idp_ldap_syncs_runs.users_found,` +
+		` projections.idp_ldap_syncs_runs.created,` +
+		` projections.idp_ldap_syncs_runs.updated,` +
+		` projections.idp_ldap_syncs_runs.deactivated,` +
+		` projections.idp_ldap_syncs_runs.reactivated,` +
+		` projections.idp_ldap_syncs_runs.grants_added,` +
+		` projections.idp_ldap_syncs_runs.grants_changed,` +
+		` projections.idp_ldap_syncs_runs.grants_removed,` +
+		` projections.idp_ldap_syncs_runs.failed,` +
+		` projections.idp_ldap_syncs_runs.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.idp_ldap_syncs_runs` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	ldapSyncRunsCols = []string{
+		"idp_id",
+		"resource_owner",
+		"sequence",
+		"started_at",
+		"finished_at",
+		"users_found",
+		"created",
+		"updated",
+		"deactivated",
+		"reactivated",
+		"grants_added",
+		"grants_changed",
+		"grants_removed",
+		"failed",
+		"error",
+		"count",
+	}
+)
+
+func Test_LDAPSyncPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareLDAPSyncQuery no result",
+			prepare: prepareLDAPSyncQuery,
+			want: want{
+				sqlExpectations: mockQueryScanErr(
+					regexp.QuoteMeta(ldapSyncQuery),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*LDAPSync)(nil),
+		},
+		{
+			name:    "prepareLDAPSyncQuery found",
+			prepare: prepareLDAPSyncQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(ldapSyncQuery),
+					ldapSyncCols,
+					[]driver.Value{
+						"idp-id",
+						testNow,
+						testNow,
+						uint64(20211108),
+						"ro",
+						true,
+						int64(time.Hour),
+						"(department=IT)",
+						nil,
+						true,
+						false,
+						[]byte(`[{"groupDN":"cn=admins,dc=example,dc=com","projectId":"project-id","roleKeys":["admin"]}]`),
+						testNow,
+						testNow,
+						nil,
+					},
+				),
+			},
+			object: &LDAPSync{
+				IDPID:         "idp-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211108,
+				ResourceOwner: "ro",
+				LDAPSync: domain.LDAPSync{
+					Enabled:           true,
+					Interval:          time.Hour,
+					Filter:            "(department=IT)",
+					DeactivateMissing: true,
+					GroupMappings: []*domain.LDAPGroupMapping{
+						{GroupDN: "cn=admins,dc=example,dc=com", ProjectID: "project-id", RoleKeys: []string{"admin"}},
+					},
+				},
+				LastRunStartedAt:  testNow,
+				LastRunFinishedAt: testNow,
+			},
+		},
+		{
+			name:    "prepareLDAPSyncsQuery found",
+			prepare: prepareLDAPSyncsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(ldapSyncQuery),
+					ldapSyncCols,
+					[][]driver.Value{
+						{
+							"idp-id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"ro",
+							true,
+							int64(time.Hour),
+							nil,
+							"org-id",
+							false,
+							false,
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: []*LDAPSync{
+				{
+					IDPID:         "idp-id",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					Sequence:      20211108,
+					ResourceOwner: "ro",
+					LDAPSync: domain.LDAPSync{
+						Enabled:  true,
+						Interval: time.Hour,
+						OrgID:    "org-id",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareLDAPSyncRunsQuery found",
+			prepare: prepareLDAPSyncRunsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(ldapSyncRunsQuery),
+					ldapSyncRunsCols,
+					[][]driver.Value{
+						{
+							"idp-id",
+							"ro",
+							uint64(20211108),
+							testNow,
+							testNow,
+							10,
+							2,
+							3,
+							1,
+							0,
+							2,
+							0,
+							0,
+							1,
+							nil,
+						},
+					},
+				),
+			},
+			object: &LDAPSyncRuns{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Runs: []*LDAPSyncRun{
+					{
+						IDPID:         "idp-id",
+						ResourceOwner: "ro",
+						Sequence:      20211108,
+						FinishedAt:    testNow,
+						LDAPSyncReport: domain.LDAPSyncReport{
+							StartedAt:   testNow,
+							UsersFound:  10,
+							Created:     2,
+							Updated:     3,
+							Deactivated: 1,
+							GrantsAdded: 2,
+							Failed:      1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareLDAPSyncRunsQuery sql err",
+			prepare: prepareLDAPSyncRunsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(ldapSyncRunsQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*LDAPSyncRuns)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func TestLDAPSync_IsDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		sync *LDAPSync
+		want bool
+	}{
+		{
+			name: "disabled",
+			sync: &LDAPSync{LDAPSync: domain.LDAPSync{Interval: time.Hour}},
+			want: false,
+		},
+		{
+			name: "never run",
+			sync: &LDAPSync{LDAPSync: domain.LDAPSync{Enabled: true, Interval: time.Hour}},
+			want: true,
+		},
+		{
+			name: "interval not elapsed",
+			sync: &LDAPSync{
+				LDAPSync:         domain.LDAPSync{Enabled: true, Interval: time.Hour},
+				LastRunStartedAt: now.Add(-30 * time.Minute),
+			},
+			want: false,
+		},
+		{
+			name: "interval elapsed",
+			sync: &LDAPSync{
+				LDAPSync:         domain.LDAPSync{Enabled: true, Interval: time.Hour},
+				LastRunStartedAt: now.Add(-time.Hour),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.sync.IsDue(now))
+		})
+	}
+}
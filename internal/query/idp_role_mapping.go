@@ -0,0 +1,138 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type IDPRoleMapping struct {
+	IDPID         string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	domain.IDPRoleMapping
+}
+
+var (
+	idpRoleMappingTable = table{
+		name:          projection.IDPRoleMappingTable,
+		instanceIDCol: projection.IDPRoleMappingInstanceIDCol,
+	}
+	IDPRoleMappingIDPIDCol = Column{
+		name:  projection.IDPRoleMappingIDPIDCol,
+		table: idpRoleMappingTable,
+	}
+	IDPRoleMappingCreationDateCol = Column{
+		name:  projection.IDPRoleMappingCreationDateCol,
+		table: idpRoleMappingTable,
+	}
+	IDPRoleMappingChangeDateCol = Column{
+		name:  projection.IDPRoleMappingChangeDateCol,
+		table: idpRoleMappingTable,
+	}
+	IDPRoleMappingSequenceCol = Column{
+		name:  projection.IDPRoleMappingSequenceCol,
+		table: idpRoleMappingTable,
+	}
+	IDPRoleMappingResourceOwnerCol = Column{
+		name:  projection.IDPRoleMappingResourceOwnerCol,
+		table: idpRoleMappingTable,
+	}
+	IDPRoleMappingInstanceIDCol = Column{
+		name:  projection.IDPRoleMappingInstanceIDCol,
+		table: idpRoleMappingTable,
+	}
+	IDPRoleMappingClaimCol = Column{
+		name:  projection.IDPRoleMappingClaimCol,
+		table: idpRoleMappingTable,
+	}
+	IDPRoleMappingModeCol = Column{
+		name:  projection.IDPRoleMappingModeCol,
+		table: idpRoleMappingTable,
+	}
+	IDPRoleMappingRulesCol = Column{
+		name:  projection.IDPRoleMappingRulesCol,
+		table: idpRoleMappingTable,
+	}
+)
+
+// IDPRoleMappingByIDPID returns the mapping of claim values to project roles of the provider.
+// The resourceOwner is the instance for instance providers and the organization otherwise.
+func (q *Queries) IDPRoleMappingByIDPID(ctx context.Context, idpID, resourceOwner string) (mapping *IDPRoleMapping, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareIDPRoleMappingQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		IDPRoleMappingIDPIDCol.identifier():         idpID,
+		IDPRoleMappingResourceOwnerCol.identifier(): resourceOwner,
+		IDPRoleMappingInstanceIDCol.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Shoh4", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		mapping, err = scan(row)
+		return err
+	}, stmt, args...)
+	return mapping, err
+}
+
+func prepareIDPRoleMappingQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*IDPRoleMapping, error)) {
+	return sq.Select(
+			IDPRoleMappingIDPIDCol.identifier(),
+			IDPRoleMappingCreationDateCol.identifier(),
+			IDPRoleMappingChangeDateCol.identifier(),
+			IDPRoleMappingSequenceCol.identifier(),
+			IDPRoleMappingResourceOwnerCol.identifier(),
+			IDPRoleMappingClaimCol.identifier(),
+			IDPRoleMappingModeCol.identifier(),
+			IDPRoleMappingRulesCol.identifier(),
+		).
+			From(idpRoleMappingTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*IDPRoleMapping, error) {
+			mapping := new(IDPRoleMapping)
+			var (
+				claim sql.NullString
+				rules []byte
+			)
+			err := row.Scan(
+				&mapping.IDPID,
+				&mapping.CreationDate,
+				&mapping.ChangeDate,
+				&mapping.Sequence,
+				&mapping.ResourceOwner,
+				&claim,
+				&mapping.Mode,
+				&rules,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Ieb0x", "Errors.IDP.RoleMapping.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-oiY3e", "Errors.Internal")
+			}
+			if len(rules) > 0 {
+				if err = json.Unmarshal(rules, &mapping.Rules); err != nil {
+					return nil, zerrors.ThrowInternal(err, "QUERY-Lae4a", "Errors.Internal")
+				}
+			}
+			mapping.Claim = claim.String
+			return mapping, nil
+		}
+}
@@ -0,0 +1,124 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	idpRoleMappingQuery = `SELECT projections.idp_role_mappings.idp_id,` +
+		` projections.idp_role_mappings.creation_date,` +
+		` projections.idp_role_mappings.change_date,` +
+		` projections.idp_role_mappings.sequence,` +
+		` projections.idp_role_mappings.resource_owner,` +
+		` projections.idp_role_mappings.claim,` +
+		` projections.idp_role_mappings.mode,` +
+		` projections.idp_role_mappings.rules` +
+		` FROM projections.idp_role_mappings` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	idpRoleMappingCols = []string{
+		"idp_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"claim",
+		"mode",
+		"rules",
+	}
+)
+
+func Test_IDPRoleMappingPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareIDPRoleMappingQuery no result",
+			prepare: prepareIDPRoleMappingQuery,
+			want: want{
+				sqlExpectations: mockQueryScanErr(
+					regexp.QuoteMeta(idpRoleMappingQuery),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*IDPRoleMapping)(nil),
+		},
+		{
+			name:    "prepareIDPRoleMappingQuery found",
+			prepare: prepareIDPRoleMappingQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpRoleMappingQuery),
+					idpRoleMappingCols,
+					[]driver.Value{
+						"idp-id",
+						testNow,
+						testNow,
+						uint64(20211108),
+						"ro",
+						"groups",
+						domain.IDPRoleMappingModeAddOnly,
+						[]byte(`[{"value":"admins","projectId":"project-id","roleKeys":["admin"]}]`),
+					},
+				),
+			},
+			object: &IDPRoleMapping{
+				IDPID:         "idp-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211108,
+				ResourceOwner: "ro",
+				IDPRoleMapping: domain.IDPRoleMapping{
+					Claim: "groups",
+					Mode:  domain.IDPRoleMappingModeAddOnly,
+					Rules: []*domain.IDPRoleRule{
+						{Value: "admins", ProjectID: "project-id", RoleKeys: []string{"admin"}},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPRoleMappingQuery sql err",
+			prepare: prepareIDPRoleMappingQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(idpRoleMappingQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*IDPRoleMapping)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
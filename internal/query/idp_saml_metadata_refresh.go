@@ -0,0 +1,357 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type SAMLMetadataRefresh struct {
+	IDPID         string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	domain.SAMLMetadataRefresh
+
+	// Certificates are the signing certificates accepted after the last successful refresh.
+	Certificates      []*domain.SAMLCertificate
+	LastRunStartedAt  time.Time
+	LastRunFinishedAt time.Time
+	LastRunError      string
+
+	// CertificateExpiries are the alerts raised for expiring certificates, which are still accepted.
+	CertificateExpiries []*SAMLCertificateExpiry
+}
+
+// SAMLCertificateExpiry is the alert raised for an expiring signing certificate of a SAML provider.
+type SAMLCertificateExpiry struct {
+	Certificate []byte
+	NotAfter    time.Time
+	AlertedAt   time.Time
+}
+
+// ExpiryAlertedAt returns when the alert for the expiring certificate was raised, zero if none was raised.
+func (r *SAMLMetadataRefresh) ExpiryAlertedAt(certificate []byte) time.Time {
+	for _, expiry := range r.CertificateExpiries {
+		if bytes.Equal(expiry.Certificate, certificate) {
+			return expiry.AlertedAt
+		}
+	}
+	return time.Time{}
+}
+
+// IsDue returns true if the refresh is enabled and its interval elapsed since the start of the last run.
+func (r *SAMLMetadataRefresh) IsDue(now time.Time) bool {
+	return r.Enabled && !r.LastRunStartedAt.Add(r.Interval).After(now)
+}
+
+var (
+	samlMetadataRefreshTable = table{
+		name:          projection.SAMLMetadataRefreshTable,
+		instanceIDCol: projection.SAMLMetadataRefreshInstanceIDCol,
+	}
+	SAMLMetadataRefreshIDPIDCol = Column{
+		name:  projection.SAMLMetadataRefreshIDPIDCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshCreationDateCol = Column{
+		name:  projection.SAMLMetadataRefreshCreationDateCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshChangeDateCol = Column{
+		name:  projection.SAMLMetadataRefreshChangeDateCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshSequenceCol = Column{
+		name:  projection.SAMLMetadataRefreshSequenceCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshResourceOwnerCol = Column{
+		name:  projection.SAMLMetadataRefreshResourceOwnerCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshInstanceIDCol = Column{
+		name:  projection.SAMLMetadataRefreshInstanceIDCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshEnabledCol = Column{
+		name:  projection.SAMLMetadataRefreshEnabledCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshURLCol = Column{
+		name:  projection.SAMLMetadataRefreshURLCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshMetadataCertCol = Column{
+		name:  projection.SAMLMetadataRefreshMetadataCertCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshIntervalCol = Column{
+		name:  projection.SAMLMetadataRefreshIntervalCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshRolloverPeriodCol = Column{
+		name:  projection.SAMLMetadataRefreshRolloverPeriodCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshExpiryWarningCol = Column{
+		name:  projection.SAMLMetadataRefreshExpiryWarningCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshCertificatesCol = Column{
+		name:  projection.SAMLMetadataRefreshCertificatesCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshLastRunStartedAtCol = Column{
+		name:  projection.SAMLMetadataRefreshLastRunStartedAtCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshLastRunFinishedAtCol = Column{
+		name:  projection.SAMLMetadataRefreshLastRunFinishedAtCol,
+		table: samlMetadataRefreshTable,
+	}
+	SAMLMetadataRefreshLastRunErrorCol = Column{
+		name:  projection.SAMLMetadataRefreshLastRunErrorCol,
+		table: samlMetadataRefreshTable,
+	}
+
+	samlCertificateExpiryTable = table{
+		name:          projection.SAMLCertificateExpiryTable,
+		instanceIDCol: projection.SAMLCertificateExpiryInstanceIDCol,
+	}
+	SAMLCertificateExpiryIDPIDCol = Column{
+		name:  projection.SAMLCertificateExpiryIDPIDCol,
+		table: samlCertificateExpiryTable,
+	}
+	SAMLCertificateExpiryInstanceIDCol = Column{
+		name:  projection.SAMLCertificateExpiryInstanceIDCol,
+		table: samlCertificateExpiryTable,
+	}
+	SAMLCertificateExpiryCertificateCol = Column{
+		name:  projection.SAMLCertificateExpiryCertificateCol,
+		table: samlCertificateExpiryTable,
+	}
+	SAMLCertificateExpiryNotAfterCol = Column{
+		name:  projection.SAMLCertificateExpiryNotAfterCol,
+		table: samlCertificateExpiryTable,
+	}
+	SAMLCertificateExpiryAlertedAtCol = Column{
+		name:  projection.SAMLCertificateExpiryAlertedAtCol,
+		table: samlCertificateExpiryTable,
+	}
+)
+
+// SAMLMetadataRefreshByIDPID returns the metadata refresh settings and the state of the last refresh of the SAML provider.
+// The resourceOwner is the instance for instance providers and the organization otherwise.
+func (q *Queries) SAMLMetadataRefreshByIDPID(ctx context.Context, idpID, resourceOwner string) (refresh *SAMLMetadataRefresh, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareSAMLMetadataRefreshQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		SAMLMetadataRefreshIDPIDCol.identifier():         idpID,
+		SAMLMetadataRefreshResourceOwnerCol.identifier(): resourceOwner,
+		SAMLMetadataRefreshInstanceIDCol.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Dai5e", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		refresh, err = scan(row)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	refresh.CertificateExpiries, err = q.samlCertificateExpiries(ctx, idpID)
+	if err != nil {
+		return nil, err
+	}
+	return refresh, nil
+}
+
+func (q *Queries) samlCertificateExpiries(ctx context.Context, idpID string) (expiries []*SAMLCertificateExpiry, err error) {
+	query, scan := prepareSAMLCertificateExpiriesQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		SAMLCertificateExpiryIDPIDCol.identifier():      idpID,
+		SAMLCertificateExpiryInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ahz5e", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		expiries, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ohs9e", "Errors.Internal")
+	}
+	return expiries, nil
+}
+
+// EnabledSAMLMetadataRefreshes returns all enabled metadata refreshes of the instance.
+func (q *Queries) EnabledSAMLMetadataRefreshes(ctx context.Context) (refreshes []*SAMLMetadataRefresh, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareSAMLMetadataRefreshesQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		SAMLMetadataRefreshEnabledCol.identifier():    true,
+		SAMLMetadataRefreshInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-eiZ6o", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		refreshes, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ohr3a", "Errors.Internal")
+	}
+	return refreshes, nil
+}
+
+func samlMetadataRefreshColumns() []string {
+	return []string{
+		SAMLMetadataRefreshIDPIDCol.identifier(),
+		SAMLMetadataRefreshCreationDateCol.identifier(),
+		SAMLMetadataRefreshChangeDateCol.identifier(),
+		SAMLMetadataRefreshSequenceCol.identifier(),
+		SAMLMetadataRefreshResourceOwnerCol.identifier(),
+		SAMLMetadataRefreshEnabledCol.identifier(),
+		SAMLMetadataRefreshURLCol.identifier(),
+		SAMLMetadataRefreshMetadataCertCol.identifier(),
+		SAMLMetadataRefreshIntervalCol.identifier(),
+		SAMLMetadataRefreshRolloverPeriodCol.identifier(),
+		SAMLMetadataRefreshExpiryWarningCol.identifier(),
+		SAMLMetadataRefreshCertificatesCol.identifier(),
+		SAMLMetadataRefreshLastRunStartedAtCol.identifier(),
+		SAMLMetadataRefreshLastRunFinishedAtCol.identifier(),
+		SAMLMetadataRefreshLastRunErrorCol.identifier(),
+	}
+}
+
+type samlMetadataRefreshScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSAMLMetadataRefresh(row samlMetadataRefreshScanner) (*SAMLMetadataRefresh, error) {
+	refresh := new(SAMLMetadataRefresh)
+	var (
+		url               sql.NullString
+		metadataCert      sql.NullString
+		certificates      []byte
+		lastRunStartedAt  sql.NullTime
+		lastRunFinishedAt sql.NullTime
+		lastRunError      sql.NullString
+	)
+	err := row.Scan(
+		&refresh.IDPID,
+		&refresh.CreationDate,
+		&refresh.ChangeDate,
+		&refresh.Sequence,
+		&refresh.ResourceOwner,
+		&refresh.Enabled,
+		&url,
+		&metadataCert,
+		&refresh.Interval,
+		&refresh.RolloverPeriod,
+		&refresh.ExpiryWarning,
+		&certificates,
+		&lastRunStartedAt,
+		&lastRunFinishedAt,
+		&lastRunError,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(certificates) > 0 {
+		if err = json.Unmarshal(certificates, &refresh.Certificates); err != nil {
+			return nil, err
+		}
+	}
+	refresh.URL = url.String
+	refresh.MetadataCertificate = metadataCert.String
+	refresh.LastRunStartedAt = lastRunStartedAt.Time
+	refresh.LastRunFinishedAt = lastRunFinishedAt.Time
+	refresh.LastRunError = lastRunError.String
+	return refresh, nil
+}
+
+func prepareSAMLMetadataRefreshQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*SAMLMetadataRefresh, error)) {
+	return sq.Select(samlMetadataRefreshColumns()...).
+			From(samlMetadataRefreshTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*SAMLMetadataRefresh, error) {
+			refresh, err := scanSAMLMetadataRefresh(row)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Ceeb9", "Errors.IDP.SAMLMetadataRefresh.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-uu8Ea", "Errors.Internal")
+			}
+			return refresh, nil
+		}
+}
+
+func prepareSAMLMetadataRefreshesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*SAMLMetadataRefresh, error)) {
+	return sq.Select(samlMetadataRefreshColumns()...).
+			From(samlMetadataRefreshTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*SAMLMetadataRefresh, error) {
+			refreshes := make([]*SAMLMetadataRefresh, 0)
+			for rows.Next() {
+				refresh, err := scanSAMLMetadataRefresh(rows)
+				if err != nil {
+					return nil, err
+				}
+				refreshes = append(refreshes, refresh)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Xoh5i", "Errors.Query.CloseRows")
+			}
+			return refreshes, nil
+		}
+}
+
+func prepareSAMLCertificateExpiriesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*SAMLCertificateExpiry, error)) {
+	return sq.Select(
+			SAMLCertificateExpiryCertificateCol.identifier(),
+			SAMLCertificateExpiryNotAfterCol.identifier(),
+			SAMLCertificateExpiryAlertedAtCol.identifier(),
+		).
+			From(samlCertificateExpiryTable.identifier() + db.Timetravel(call.Took(ctx))).
+			OrderBy(SAMLCertificateExpiryNotAfterCol.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*SAMLCertificateExpiry, error) {
+			expiries := make([]*SAMLCertificateExpiry, 0)
+			for rows.Next() {
+				expiry := new(SAMLCertificateExpiry)
+				if err := rows.Scan(&expiry.Certificate, &expiry.NotAfter, &expiry.AlertedAt); err != nil {
+					return nil, err
+				}
+				expiries = append(expiries, expiry)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-uLo3e", "Errors.Query.CloseRows")
+			}
+			return expiries, nil
+		}
+}
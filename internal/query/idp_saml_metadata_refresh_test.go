@@ -0,0 +1,283 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	samlMetadataRefreshQuery = `SELECT projections.idp_saml_metadata_refreshes.idp_id,` +
+		` projections.idp_saml_metadata_refreshes.creation_date,` +
+		` projections.idp_saml_metadata_refreshes.change_date,` +
+		` projections.idp_saml_metadata_refreshes.sequence,` +
+		` projections.idp_saml_metadata_refreshes.resource_owner,` +
+		` projections.idp_saml_metadata_refreshes.enabled,` +
+		` projections.idp_saml_metadata_refreshes.url,` +
+		` projections.idp_saml_metadata_refreshes.metadata_certificate,` +
+		` projections.idp_saml_metadata_refreshes.interval,` +
+		` projections.idp_saml_metadata_refreshes.rollover_period,` +
+		` projections.idp_saml_metadata_refreshes.expiry_warning,` +
+		` projections.idp_saml_metadata_refreshes.certificates,` +
+		` projections.idp_saml_metadata_refreshes.last_run_started_at,` +
+		` projections.idp_saml_metadata_refreshes.last_run_finished_at,` +
+		` projections.idp_saml_metadata_refreshes.last_run_error` +
+		` FROM projections.idp_saml_metadata_refreshes` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	samlMetadataRefreshCols = []string{
+		"idp_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"enabled",
+		"url",
+		"metadata_certificate",
+		"interval",
+		"rollover_period",
+		"expiry_warning",
+		"certificates",
+		"last_run_started_at",
+		"last_run_finished_at",
+		"last_run_error",
+	}
+	samlCertificateExpiriesQuery = `SELECT projections.idp_saml_metadata_refreshes_certificate_expiries.certificate,` +
+		` projections.idp_saml_metadata_refreshes_certificate_expiries.not_after,` +
+		` projections.idp_saml_metadata_refreshes_certificate_expiries.alerted_at` +
+		` FROM projections.idp_saml_metadata_refreshes_certificate_expiries` +
+		` AS OF SYSTEM TIME '-1 ms'` +
+		` ORDER BY projections.idp_saml_metadata_refreshes_certificate_expiries.not_after`
+	samlCertificateExpiriesCols = []string{
+		"certificate",
+		"not_after",
+		"alerted_at",
+	}
+)
+
+func Test_SAMLMetadataRefreshPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareSAMLMetadataRefreshQuery no result",
+			prepare: prepareSAMLMetadataRefreshQuery,
+			want: want{
+				sqlExpectations: mockQueryScanErr(
+					regexp.QuoteMeta(samlMetadataRefreshQuery),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SAMLMetadataRefresh)(nil),
+		},
+		{
+			name:    "prepareSAMLMetadataRefreshQuery found",
+			prepare: prepareSAMLMetadataRefreshQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(samlMetadataRefreshQuery),
+					samlMetadataRefreshCols,
+					[]driver.Value{
+						"idp-id",
+						testNow,
+						testNow,
+						uint64(20211108),
+						"ro",
+						true,
+						"https://idp.example.com/metadata",
+						nil,
+						int64(time.Hour),
+						int64(24 * time.Hour),
+						int64(7 * 24 * time.Hour),
+						[]byte(`[{"certificate":"Y2VydGlmaWNhdGU=","notAfter":"2025-01-01T00:00:00Z"}]`),
+						testNow,
+						testNow,
+						nil,
+					},
+				),
+			},
+			object: &SAMLMetadataRefresh{
+				IDPID:         "idp-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211108,
+				ResourceOwner: "ro",
+				SAMLMetadataRefresh: domain.SAMLMetadataRefresh{
+					Enabled:        true,
+					URL:            "https://idp.example.com/metadata",
+					Interval:       time.Hour,
+					RolloverPeriod: 24 * time.Hour,
+					ExpiryWarning:  7 * 24 * time.Hour,
+				},
+				Certificates: []*domain.SAMLCertificate{
+					{Certificate: []byte("certificate"), NotAfter: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+				LastRunStartedAt:  testNow,
+				LastRunFinishedAt: testNow,
+			},
+		},
+		{
+			name:    "prepareSAMLMetadataRefreshesQuery found",
+			prepare: prepareSAMLMetadataRefreshesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(samlMetadataRefreshQuery),
+					samlMetadataRefreshCols,
+					[][]driver.Value{
+						{
+							"idp-id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"ro",
+							true,
+							"https://idp.example.com/metadata",
+							nil,
+							int64(time.Hour),
+							int64(0),
+							int64(0),
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: []*SAMLMetadataRefresh{
+				{
+					IDPID:         "idp-id",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					Sequence:      20211108,
+					ResourceOwner: "ro",
+					SAMLMetadataRefresh: domain.SAMLMetadataRefresh{
+						Enabled:  true,
+						URL:      "https://idp.example.com/metadata",
+						Interval: time.Hour,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareSAMLMetadataRefreshesQuery sql err",
+			prepare: prepareSAMLMetadataRefreshesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(samlMetadataRefreshQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]*SAMLMetadataRefresh)(nil),
+		},
+		{
+			name:    "prepareSAMLCertificateExpiriesQuery found",
+			prepare: prepareSAMLCertificateExpiriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(samlCertificateExpiriesQuery),
+					samlCertificateExpiriesCols,
+					[][]driver.Value{
+						{
+							[]byte("certificate"),
+							testNow,
+							testNow,
+						},
+					},
+				),
+			},
+			object: []*SAMLCertificateExpiry{
+				{
+					Certificate: []byte("certificate"),
+					NotAfter:    testNow,
+					AlertedAt:   testNow,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func TestSAMLMetadataRefresh_ExpiryAlertedAt(t *testing.T) {
+	alertedAt := time.Now()
+	refresh := &SAMLMetadataRefresh{
+		CertificateExpiries: []*SAMLCertificateExpiry{
+			{Certificate: []byte("expiring"), NotAfter: alertedAt.Add(time.Hour), AlertedAt: alertedAt},
+		},
+	}
+	assert.Equal(t, alertedAt, refresh.ExpiryAlertedAt([]byte("expiring")))
+	assert.True(t, refresh.ExpiryAlertedAt([]byte("valid")).IsZero())
+}
+
+func TestSAMLMetadataRefresh_IsDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		refresh *SAMLMetadataRefresh
+		want    bool
+	}{
+		{
+			name:    "disabled",
+			refresh: &SAMLMetadataRefresh{SAMLMetadataRefresh: domain.SAMLMetadataRefresh{Interval: time.Hour}},
+			want:    false,
+		},
+		{
+			name:    "never run",
+			refresh: &SAMLMetadataRefresh{SAMLMetadataRefresh: domain.SAMLMetadataRefresh{Enabled: true, Interval: time.Hour}},
+			want:    true,
+		},
+		{
+			name: "interval not elapsed",
+			refresh: &SAMLMetadataRefresh{
+				SAMLMetadataRefresh: domain.SAMLMetadataRefresh{Enabled: true, Interval: time.Hour},
+				LastRunStartedAt:    now.Add(-30 * time.Minute),
+			},
+			want: false,
+		},
+		{
+			name: "interval elapsed",
+			refresh: &SAMLMetadataRefresh{
+				SAMLMetadataRefresh: domain.SAMLMetadataRefresh{Enabled: true, Interval: time.Hour},
+				LastRunStartedAt:    now.Add(-time.Hour),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.refresh.IsDue(now))
+		})
+	}
+}
@@ -48,6 +48,10 @@ type IDPTemplate struct {
 	*LDAPIDPTemplate
 	*AppleIDPTemplate
 	*SAMLIDPTemplate
+	*BitbucketIDPTemplate
+	*SlackIDPTemplate
+	*DiscordIDPTemplate
+	*LinkedInIDPTemplate
 }
 
 type IDPTemplates struct {
@@ -99,6 +103,34 @@ type GitHubIDPTemplate struct {
 	Scopes       database.TextArray[string]
 }
 
+type BitbucketIDPTemplate struct {
+	IDPID        string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       database.TextArray[string]
+}
+
+type SlackIDPTemplate struct {
+	IDPID        string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       database.TextArray[string]
+}
+
+type DiscordIDPTemplate struct {
+	IDPID        string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       database.TextArray[string]
+}
+
+type LinkedInIDPTemplate struct {
+	IDPID        string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       database.TextArray[string]
+}
+
 type GitHubEnterpriseIDPTemplate struct {
 	IDPID                 string
 	ClientID              string
@@ -401,6 +433,114 @@ var (
 	}
 )
 
+var (
+	bitbucketIdpTemplateTable = table{
+		name:          projection.IDPTemplateBitbucketTable,
+		instanceIDCol: projection.BitbucketInstanceIDCol,
+	}
+	BitbucketIDCol = Column{
+		name:  projection.BitbucketIDCol,
+		table: bitbucketIdpTemplateTable,
+	}
+	BitbucketInstanceIDCol = Column{
+		name:  projection.BitbucketInstanceIDCol,
+		table: bitbucketIdpTemplateTable,
+	}
+	BitbucketClientIDCol = Column{
+		name:  projection.BitbucketClientIDCol,
+		table: bitbucketIdpTemplateTable,
+	}
+	BitbucketClientSecretCol = Column{
+		name:  projection.BitbucketClientSecretCol,
+		table: bitbucketIdpTemplateTable,
+	}
+	BitbucketScopesCol = Column{
+		name:  projection.BitbucketScopesCol,
+		table: bitbucketIdpTemplateTable,
+	}
+)
+
+var (
+	slackIdpTemplateTable = table{
+		name:          projection.IDPTemplateSlackTable,
+		instanceIDCol: projection.SlackInstanceIDCol,
+	}
+	SlackIDCol = Column{
+		name:  projection.SlackIDCol,
+		table: slackIdpTemplateTable,
+	}
+	SlackInstanceIDCol = Column{
+		name:  projection.SlackInstanceIDCol,
+		table: slackIdpTemplateTable,
+	}
+	SlackClientIDCol = Column{
+		name:  projection.SlackClientIDCol,
+		table: slackIdpTemplateTable,
+	}
+	SlackClientSecretCol = Column{
+		name:  projection.SlackClientSecretCol,
+		table: slackIdpTemplateTable,
+	}
+	SlackScopesCol = Column{
+		name:  projection.SlackScopesCol,
+		table: slackIdpTemplateTable,
+	}
+)
+
+var (
+	discordIdpTemplateTable = table{
+		name:          projection.IDPTemplateDiscordTable,
+		instanceIDCol: projection.DiscordInstanceIDCol,
+	}
+	DiscordIDCol = Column{
+		name:  projection.DiscordIDCol,
+		table: discordIdpTemplateTable,
+	}
+	DiscordInstanceIDCol = Column{
+		name:  projection.DiscordInstanceIDCol,
+		table: discordIdpTemplateTable,
+	}
+	DiscordClientIDCol = Column{
+		name:  projection.DiscordClientIDCol,
+		table: discordIdpTemplateTable,
+	}
+	DiscordClientSecretCol = Column{
+		name:  projection.DiscordClientSecretCol,
+		table: discordIdpTemplateTable,
+	}
+	DiscordScopesCol = Column{
+		name:  projection.DiscordScopesCol,
+		table: discordIdpTemplateTable,
+	}
+)
+
+var (
+	linkedinIdpTemplateTable = table{
+		name:          projection.IDPTemplateLinkedInTable,
+		instanceIDCol: projection.LinkedInInstanceIDCol,
+	}
+	LinkedInIDCol = Column{
+		name:  projection.LinkedInIDCol,
+		table: linkedinIdpTemplateTable,
+	}
+	LinkedInInstanceIDCol = Column{
+		name:  projection.LinkedInInstanceIDCol,
+		table: linkedinIdpTemplateTable,
+	}
+	LinkedInClientIDCol = Column{
+		name:  projection.LinkedInClientIDCol,
+		table: linkedinIdpTemplateTable,
+	}
+	LinkedInClientSecretCol = Column{
+		name:  projection.LinkedInClientSecretCol,
+		table: linkedinIdpTemplateTable,
+	}
+	LinkedInScopesCol = Column{
+		name:  projection.LinkedInScopesCol,
+		table: linkedinIdpTemplateTable,
+	}
+)
+
 var (
 	githubEnterpriseIdpTemplateTable = table{
 		name:          projection.IDPTemplateGitHubEnterpriseTable,
@@ -737,6 +877,7 @@ func (q *Queries) IDPTemplateByID(ctx context.Context, shouldTriggerBulk bool, i
 func (q *Queries) IDPTemplates(ctx context.Context, queries *IDPTemplateSearchQueries, withOwnerRemoved bool) (idps *IDPTemplates, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
+	ctx = database.WithCallType(ctx, database.CallTypeList)
 
 	query, scan := prepareIDPTemplatesQuery(ctx, q.client)
 	eq := sq.Eq{
@@ -909,6 +1050,26 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			AppleKeyIDCol.identifier(),
 			ApplePrivateKeyCol.identifier(),
 			AppleScopesCol.identifier(),
+			// bitbucket
+			BitbucketIDCol.identifier(),
+			BitbucketClientIDCol.identifier(),
+			BitbucketClientSecretCol.identifier(),
+			BitbucketScopesCol.identifier(),
+			// slack
+			SlackIDCol.identifier(),
+			SlackClientIDCol.identifier(),
+			SlackClientSecretCol.identifier(),
+			SlackScopesCol.identifier(),
+			// discord
+			DiscordIDCol.identifier(),
+			DiscordClientIDCol.identifier(),
+			DiscordClientSecretCol.identifier(),
+			DiscordScopesCol.identifier(),
+			// linkedin
+			LinkedInIDCol.identifier(),
+			LinkedInClientIDCol.identifier(),
+			LinkedInClientSecretCol.identifier(),
+			LinkedInScopesCol.identifier(),
 		).From(idpTemplateTable.identifier()).
 			LeftJoin(join(OAuthIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(OIDCIDCol, IDPTemplateIDCol)).
@@ -921,7 +1082,11 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			LeftJoin(join(GoogleIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol)).
-			LeftJoin(join(AppleIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(AppleIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(BitbucketIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(SlackIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(DiscordIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(LinkedInIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*IDPTemplate, error) {
 			idpTemplate := new(IDPTemplate)
@@ -1023,6 +1188,22 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			appleKeyID := sql.NullString{}
 			applePrivateKey := new(crypto.CryptoValue)
 			appleScopes := database.TextArray[string]{}
+			bitbucketID := sql.NullString{}
+			bitbucketClientID := sql.NullString{}
+			bitbucketClientSecret := new(crypto.CryptoValue)
+			bitbucketScopes := database.TextArray[string]{}
+			slackID := sql.NullString{}
+			slackClientID := sql.NullString{}
+			slackClientSecret := new(crypto.CryptoValue)
+			slackScopes := database.TextArray[string]{}
+			discordID := sql.NullString{}
+			discordClientID := sql.NullString{}
+			discordClientSecret := new(crypto.CryptoValue)
+			discordScopes := database.TextArray[string]{}
+			linkedinID := sql.NullString{}
+			linkedinClientID := sql.NullString{}
+			linkedinClientSecret := new(crypto.CryptoValue)
+			linkedinScopes := database.TextArray[string]{}
 
 			err := row.Scan(
 				&idpTemplate.ID,
@@ -1134,6 +1315,26 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 				&appleKeyID,
 				&applePrivateKey,
 				&appleScopes,
+				// bitbucket
+				&bitbucketID,
+				&bitbucketClientID,
+				&bitbucketClientSecret,
+				&bitbucketScopes,
+				// slack
+				&slackID,
+				&slackClientID,
+				&slackClientSecret,
+				&slackScopes,
+				// discord
+				&discordID,
+				&discordClientID,
+				&discordClientSecret,
+				&discordScopes,
+				// linkedin
+				&linkedinID,
+				&linkedinClientID,
+				&linkedinClientSecret,
+				&linkedinScopes,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -1278,6 +1479,38 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 					Scopes:     appleScopes,
 				}
 			}
+			if bitbucketID.Valid {
+				idpTemplate.BitbucketIDPTemplate = &BitbucketIDPTemplate{
+					IDPID:        bitbucketID.String,
+					ClientID:     bitbucketClientID.String,
+					ClientSecret: bitbucketClientSecret,
+					Scopes:       bitbucketScopes,
+				}
+			}
+			if slackID.Valid {
+				idpTemplate.SlackIDPTemplate = &SlackIDPTemplate{
+					IDPID:        slackID.String,
+					ClientID:     slackClientID.String,
+					ClientSecret: slackClientSecret,
+					Scopes:       slackScopes,
+				}
+			}
+			if discordID.Valid {
+				idpTemplate.DiscordIDPTemplate = &DiscordIDPTemplate{
+					IDPID:        discordID.String,
+					ClientID:     discordClientID.String,
+					ClientSecret: discordClientSecret,
+					Scopes:       discordScopes,
+				}
+			}
+			if linkedinID.Valid {
+				idpTemplate.LinkedInIDPTemplate = &LinkedInIDPTemplate{
+					IDPID:        linkedinID.String,
+					ClientID:     linkedinClientID.String,
+					ClientSecret: linkedinClientSecret,
+					Scopes:       linkedinScopes,
+				}
+			}
 
 			return idpTemplate, nil
 		}
@@ -1394,6 +1627,26 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			AppleKeyIDCol.identifier(),
 			ApplePrivateKeyCol.identifier(),
 			AppleScopesCol.identifier(),
+			// bitbucket
+			BitbucketIDCol.identifier(),
+			BitbucketClientIDCol.identifier(),
+			BitbucketClientSecretCol.identifier(),
+			BitbucketScopesCol.identifier(),
+			// slack
+			SlackIDCol.identifier(),
+			SlackClientIDCol.identifier(),
+			SlackClientSecretCol.identifier(),
+			SlackScopesCol.identifier(),
+			// discord
+			DiscordIDCol.identifier(),
+			DiscordClientIDCol.identifier(),
+			DiscordClientSecretCol.identifier(),
+			DiscordScopesCol.identifier(),
+			// linkedin
+			LinkedInIDCol.identifier(),
+			LinkedInClientIDCol.identifier(),
+			LinkedInClientSecretCol.identifier(),
+			LinkedInScopesCol.identifier(),
 			// count
 			countColumn.identifier(),
 		).From(idpTemplateTable.identifier()).
@@ -1408,7 +1661,11 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			LeftJoin(join(GoogleIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol)).
-			LeftJoin(join(AppleIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(AppleIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(BitbucketIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(SlackIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(DiscordIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(LinkedInIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*IDPTemplates, error) {
 			templates := make([]*IDPTemplate, 0)
@@ -1513,6 +1770,22 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				appleKeyID := sql.NullString{}
 				applePrivateKey := new(crypto.CryptoValue)
 				appleScopes := database.TextArray[string]{}
+				bitbucketID := sql.NullString{}
+				bitbucketClientID := sql.NullString{}
+				bitbucketClientSecret := new(crypto.CryptoValue)
+				bitbucketScopes := database.TextArray[string]{}
+				slackID := sql.NullString{}
+				slackClientID := sql.NullString{}
+				slackClientSecret := new(crypto.CryptoValue)
+				slackScopes := database.TextArray[string]{}
+				discordID := sql.NullString{}
+				discordClientID := sql.NullString{}
+				discordClientSecret := new(crypto.CryptoValue)
+				discordScopes := database.TextArray[string]{}
+				linkedinID := sql.NullString{}
+				linkedinClientID := sql.NullString{}
+				linkedinClientSecret := new(crypto.CryptoValue)
+				linkedinScopes := database.TextArray[string]{}
 
 				err := rows.Scan(
 					&idpTemplate.ID,
@@ -1624,6 +1897,26 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 					&appleKeyID,
 					&applePrivateKey,
 					&appleScopes,
+					// bitbucket
+					&bitbucketID,
+					&bitbucketClientID,
+					&bitbucketClientSecret,
+					&bitbucketScopes,
+					// slack
+					&slackID,
+					&slackClientID,
+					&slackClientSecret,
+					&slackScopes,
+					// discord
+					&discordID,
+					&discordClientID,
+					&discordClientSecret,
+					&discordScopes,
+					// linkedin
+					&linkedinID,
+					&linkedinClientID,
+					&linkedinClientSecret,
+					&linkedinScopes,
 					&count,
 				)
 
@@ -1767,6 +2060,38 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 						Scopes:     appleScopes,
 					}
 				}
+				if bitbucketID.Valid {
+					idpTemplate.BitbucketIDPTemplate = &BitbucketIDPTemplate{
+						IDPID:        bitbucketID.String,
+						ClientID:     bitbucketClientID.String,
+						ClientSecret: bitbucketClientSecret,
+						Scopes:       bitbucketScopes,
+					}
+				}
+				if slackID.Valid {
+					idpTemplate.SlackIDPTemplate = &SlackIDPTemplate{
+						IDPID:        slackID.String,
+						ClientID:     slackClientID.String,
+						ClientSecret: slackClientSecret,
+						Scopes:       slackScopes,
+					}
+				}
+				if discordID.Valid {
+					idpTemplate.DiscordIDPTemplate = &DiscordIDPTemplate{
+						IDPID:        discordID.String,
+						ClientID:     discordClientID.String,
+						ClientSecret: discordClientSecret,
+						Scopes:       discordScopes,
+					}
+				}
+				if linkedinID.Valid {
+					idpTemplate.LinkedInIDPTemplate = &LinkedInIDPTemplate{
+						IDPID:        linkedinID.String,
+						ClientID:     linkedinClientID.String,
+						ClientSecret: linkedinClientSecret,
+						Scopes:       linkedinScopes,
+					}
+				}
 				templates = append(templates, idpTemplate)
 			}
 
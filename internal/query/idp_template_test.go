@@ -16,128 +16,152 @@ import (
 )
 
 var (
-	idpTemplateQuery = `SELECT projections.idp_templates5.id,` +
-		` projections.idp_templates5.resource_owner,` +
-		` projections.idp_templates5.creation_date,` +
-		` projections.idp_templates5.change_date,` +
-		` projections.idp_templates5.sequence,` +
-		` projections.idp_templates5.state,` +
-		` projections.idp_templates5.name,` +
-		` projections.idp_templates5.type,` +
-		` projections.idp_templates5.owner_type,` +
-		` projections.idp_templates5.is_creation_allowed,` +
-		` projections.idp_templates5.is_linking_allowed,` +
-		` projections.idp_templates5.is_auto_creation,` +
-		` projections.idp_templates5.is_auto_update,` +
+	idpTemplateQuery = `SELECT projections.idp_templates6.id,` +
+		` projections.idp_templates6.resource_owner,` +
+		` projections.idp_templates6.creation_date,` +
+		` projections.idp_templates6.change_date,` +
+		` projections.idp_templates6.sequence,` +
+		` projections.idp_templates6.state,` +
+		` projections.idp_templates6.name,` +
+		` projections.idp_templates6.type,` +
+		` projections.idp_templates6.owner_type,` +
+		` projections.idp_templates6.is_creation_allowed,` +
+		` projections.idp_templates6.is_linking_allowed,` +
+		` projections.idp_templates6.is_auto_creation,` +
+		` projections.idp_templates6.is_auto_update,` +
 		// oauth
-		` projections.idp_templates5_oauth2.idp_id,` +
-		` projections.idp_templates5_oauth2.client_id,` +
-		` projections.idp_templates5_oauth2.client_secret,` +
-		` projections.idp_templates5_oauth2.authorization_endpoint,` +
-		` projections.idp_templates5_oauth2.token_endpoint,` +
-		` projections.idp_templates5_oauth2.user_endpoint,` +
-		` projections.idp_templates5_oauth2.scopes,` +
-		` projections.idp_templates5_oauth2.id_attribute,` +
+		` projections.idp_templates6_oauth2.idp_id,` +
+		` projections.idp_templates6_oauth2.client_id,` +
+		` projections.idp_templates6_oauth2.client_secret,` +
+		` projections.idp_templates6_oauth2.authorization_endpoint,` +
+		` projections.idp_templates6_oauth2.token_endpoint,` +
+		` projections.idp_templates6_oauth2.user_endpoint,` +
+		` projections.idp_templates6_oauth2.scopes,` +
+		` projections.idp_templates6_oauth2.id_attribute,` +
 		// oidc
-		` projections.idp_templates5_oidc.idp_id,` +
-		` projections.idp_templates5_oidc.issuer,` +
-		` projections.idp_templates5_oidc.client_id,` +
-		` projections.idp_templates5_oidc.client_secret,` +
-		` projections.idp_templates5_oidc.scopes,` +
-		` projections.idp_templates5_oidc.id_token_mapping,` +
+		` projections.idp_templates6_oidc.idp_id,` +
+		` projections.idp_templates6_oidc.issuer,` +
+		` projections.idp_templates6_oidc.client_id,` +
+		` projections.idp_templates6_oidc.client_secret,` +
+		` projections.idp_templates6_oidc.scopes,` +
+		` projections.idp_templates6_oidc.id_token_mapping,` +
 		// jwt
-		` projections.idp_templates5_jwt.idp_id,` +
-		` projections.idp_templates5_jwt.issuer,` +
-		` projections.idp_templates5_jwt.jwt_endpoint,` +
-		` projections.idp_templates5_jwt.keys_endpoint,` +
-		` projections.idp_templates5_jwt.header_name,` +
+		` projections.idp_templates6_jwt.idp_id,` +
+		` projections.idp_templates6_jwt.issuer,` +
+		` projections.idp_templates6_jwt.jwt_endpoint,` +
+		` projections.idp_templates6_jwt.keys_endpoint,` +
+		` projections.idp_templates6_jwt.header_name,` +
 		// azure
-		` projections.idp_templates5_azure.idp_id,` +
-		` projections.idp_templates5_azure.client_id,` +
-		` projections.idp_templates5_azure.client_secret,` +
-		` projections.idp_templates5_azure.scopes,` +
-		` projections.idp_templates5_azure.tenant,` +
-		` projections.idp_templates5_azure.is_email_verified,` +
+		` projections.idp_templates6_azure.idp_id,` +
+		` projections.idp_templates6_azure.client_id,` +
+		` projections.idp_templates6_azure.client_secret,` +
+		` projections.idp_templates6_azure.scopes,` +
+		` projections.idp_templates6_azure.tenant,` +
+		` projections.idp_templates6_azure.is_email_verified,` +
 		// github
-		` projections.idp_templates5_github.idp_id,` +
-		` projections.idp_templates5_github.client_id,` +
-		` projections.idp_templates5_github.client_secret,` +
-		` projections.idp_templates5_github.scopes,` +
+		` projections.idp_templates6_github.idp_id,` +
+		` projections.idp_templates6_github.client_id,` +
+		` projections.idp_templates6_github.client_secret,` +
+		` projections.idp_templates6_github.scopes,` +
 		// github enterprise
-		` projections.idp_templates5_github_enterprise.idp_id,` +
-		` projections.idp_templates5_github_enterprise.client_id,` +
-		` projections.idp_templates5_github_enterprise.client_secret,` +
-		` projections.idp_templates5_github_enterprise.authorization_endpoint,` +
-		` projections.idp_templates5_github_enterprise.token_endpoint,` +
-		` projections.idp_templates5_github_enterprise.user_endpoint,` +
-		` projections.idp_templates5_github_enterprise.scopes,` +
+		` projections.idp_templates6_github_enterprise.idp_id,` +
+		` projections.idp_templates6_github_enterprise.client_id,` +
+		` projections.idp_templates6_github_enterprise.client_secret,` +
+		` projections.idp_templates6_github_enterprise.authorization_endpoint,` +
+		` projections.idp_templates6_github_enterprise.token_endpoint,` +
+		` projections.idp_templates6_github_enterprise.user_endpoint,` +
+		` projections.idp_templates6_github_enterprise.scopes,` +
 		// gitlab
-		` projections.idp_templates5_gitlab.idp_id,` +
-		` projections.idp_templates5_gitlab.client_id,` +
-		` projections.idp_templates5_gitlab.client_secret,` +
-		` projections.idp_templates5_gitlab.scopes,` +
+		` projections.idp_templates6_gitlab.idp_id,` +
+		` projections.idp_templates6_gitlab.client_id,` +
+		` projections.idp_templates6_gitlab.client_secret,` +
+		` projections.idp_templates6_gitlab.scopes,` +
 		// gitlab self hosted
-		` projections.idp_templates5_gitlab_self_hosted.idp_id,` +
-		` projections.idp_templates5_gitlab_self_hosted.issuer,` +
-		` projections.idp_templates5_gitlab_self_hosted.client_id,` +
-		` projections.idp_templates5_gitlab_self_hosted.client_secret,` +
-		` projections.idp_templates5_gitlab_self_hosted.scopes,` +
+		` projections.idp_templates6_gitlab_self_hosted.idp_id,` +
+		` projections.idp_templates6_gitlab_self_hosted.issuer,` +
+		` projections.idp_templates6_gitlab_self_hosted.client_id,` +
+		` projections.idp_templates6_gitlab_self_hosted.client_secret,` +
+		` projections.idp_templates6_gitlab_self_hosted.scopes,` +
 		// google
-		` projections.idp_templates5_google.idp_id,` +
-		` projections.idp_templates5_google.client_id,` +
-		` projections.idp_templates5_google.client_secret,` +
-		` projections.idp_templates5_google.scopes,` +
+		` projections.idp_templates6_google.idp_id,` +
+		` projections.idp_templates6_google.client_id,` +
+		` projections.idp_templates6_google.client_secret,` +
+		` projections.idp_templates6_google.scopes,` +
 		// saml
-		` projections.idp_templates5_saml.idp_id,` +
-		` projections.idp_templates5_saml.metadata,` +
-		` projections.idp_templates5_saml.key,` +
-		` projections.idp_templates5_saml.certificate,` +
-		` projections.idp_templates5_saml.binding,` +
-		` projections.idp_templates5_saml.with_signed_request,` +
+		` projections.idp_templates6_saml.idp_id,` +
+		` projections.idp_templates6_saml.metadata,` +
+		` projections.idp_templates6_saml.key,` +
+		` projections.idp_templates6_saml.certificate,` +
+		` projections.idp_templates6_saml.binding,` +
+		` projections.idp_templates6_saml.with_signed_request,` +
 		// ldap
-		` projections.idp_templates5_ldap2.idp_id,` +
-		` projections.idp_templates5_ldap2.servers,` +
-		` projections.idp_templates5_ldap2.start_tls,` +
-		` projections.idp_templates5_ldap2.base_dn,` +
-		` projections.idp_templates5_ldap2.bind_dn,` +
-		` projections.idp_templates5_ldap2.bind_password,` +
-		` projections.idp_templates5_ldap2.user_base,` +
-		` projections.idp_templates5_ldap2.user_object_classes,` +
-		` projections.idp_templates5_ldap2.user_filters,` +
-		` projections.idp_templates5_ldap2.timeout,` +
-		` projections.idp_templates5_ldap2.id_attribute,` +
-		` projections.idp_templates5_ldap2.first_name_attribute,` +
-		` projections.idp_templates5_ldap2.last_name_attribute,` +
-		` projections.idp_templates5_ldap2.display_name_attribute,` +
-		` projections.idp_templates5_ldap2.nick_name_attribute,` +
-		` projections.idp_templates5_ldap2.preferred_username_attribute,` +
-		` projections.idp_templates5_ldap2.email_attribute,` +
-		` projections.idp_templates5_ldap2.email_verified,` +
-		` projections.idp_templates5_ldap2.phone_attribute,` +
-		` projections.idp_templates5_ldap2.phone_verified_attribute,` +
-		` projections.idp_templates5_ldap2.preferred_language_attribute,` +
-		` projections.idp_templates5_ldap2.avatar_url_attribute,` +
-		` projections.idp_templates5_ldap2.profile_attribute,` +
+		` projections.idp_templates6_ldap2.idp_id,` +
+		` projections.idp_templates6_ldap2.servers,` +
+		` projections.idp_templates6_ldap2.start_tls,` +
+		` projections.idp_templates6_ldap2.base_dn,` +
+		` projections.idp_templates6_ldap2.bind_dn,` +
+		` projections.idp_templates6_ldap2.bind_password,` +
+		` projections.idp_templates6_ldap2.user_base,` +
+		` projections.idp_templates6_ldap2.user_object_classes,` +
+		` projections.idp_templates6_ldap2.user_filters,` +
+		` projections.idp_templates6_ldap2.timeout,` +
+		` projections.idp_templates6_ldap2.id_attribute,` +
+		` projections.idp_templates6_ldap2.first_name_attribute,` +
+		` projections.idp_templates6_ldap2.last_name_attribute,` +
+		` projections.idp_templates6_ldap2.display_name_attribute,` +
+		` projections.idp_templates6_ldap2.nick_name_attribute,` +
+		` projections.idp_templates6_ldap2.preferred_username_attribute,` +
+		` projections.idp_templates6_ldap2.email_attribute,` +
+		` projections.idp_templates6_ldap2.email_verified,` +
+		` projections.idp_templates6_ldap2.phone_attribute,` +
+		` projections.idp_templates6_ldap2.phone_verified_attribute,` +
+		` projections.idp_templates6_ldap2.preferred_language_attribute,` +
+		` projections.idp_templates6_ldap2.avatar_url_attribute,` +
+		` projections.idp_templates6_ldap2.profile_attribute,` +
 		// apple
-		` projections.idp_templates5_apple.idp_id,` +
-		` projections.idp_templates5_apple.client_id,` +
-		` projections.idp_templates5_apple.team_id,` +
-		` projections.idp_templates5_apple.key_id,` +
-		` projections.idp_templates5_apple.private_key,` +
-		` projections.idp_templates5_apple.scopes` +
-		` FROM projections.idp_templates5` +
-		` LEFT JOIN projections.idp_templates5_oauth2 ON projections.idp_templates5.id = projections.idp_templates5_oauth2.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_oauth2.instance_id` +
-		` LEFT JOIN projections.idp_templates5_oidc ON projections.idp_templates5.id = projections.idp_templates5_oidc.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_oidc.instance_id` +
-		` LEFT JOIN projections.idp_templates5_jwt ON projections.idp_templates5.id = projections.idp_templates5_jwt.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_jwt.instance_id` +
-		` LEFT JOIN projections.idp_templates5_azure ON projections.idp_templates5.id = projections.idp_templates5_azure.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_azure.instance_id` +
-		` LEFT JOIN projections.idp_templates5_github ON projections.idp_templates5.id = projections.idp_templates5_github.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_github.instance_id` +
-		` LEFT JOIN projections.idp_templates5_github_enterprise ON projections.idp_templates5.id = projections.idp_templates5_github_enterprise.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_github_enterprise.instance_id` +
-		` LEFT JOIN projections.idp_templates5_gitlab ON projections.idp_templates5.id = projections.idp_templates5_gitlab.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_gitlab.instance_id` +
-		` LEFT JOIN projections.idp_templates5_gitlab_self_hosted ON projections.idp_templates5.id = projections.idp_templates5_gitlab_self_hosted.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_gitlab_self_hosted.instance_id` +
-		` LEFT JOIN projections.idp_templates5_google ON projections.idp_templates5.id = projections.idp_templates5_google.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_google.instance_id` +
-		` LEFT JOIN projections.idp_templates5_saml ON projections.idp_templates5.id = projections.idp_templates5_saml.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_saml.instance_id` +
-		` LEFT JOIN projections.idp_templates5_ldap2 ON projections.idp_templates5.id = projections.idp_templates5_ldap2.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_ldap2.instance_id` +
-		` LEFT JOIN projections.idp_templates5_apple ON projections.idp_templates5.id = projections.idp_templates5_apple.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_apple.instance_id` +
+		` projections.idp_templates6_apple.idp_id,` +
+		` projections.idp_templates6_apple.client_id,` +
+		` projections.idp_templates6_apple.team_id,` +
+		` projections.idp_templates6_apple.key_id,` +
+		` projections.idp_templates6_apple.private_key,` +
+		` projections.idp_templates6_apple.scopes,` +
+		// bitbucket
+		` projections.idp_templates6_bitbucket.idp_id,` +
+		` projections.idp_templates6_bitbucket.client_id,` +
+		` projections.idp_templates6_bitbucket.client_secret,` +
+		` projections.idp_templates6_bitbucket.scopes,` +
+		// slack
+		` projections.idp_templates6_slack.idp_id,` +
+		` projections.idp_templates6_slack.client_id,` +
+		` projections.idp_templates6_slack.client_secret,` +
+		` projections.idp_templates6_slack.scopes,` +
+		// discord
+		` projections.idp_templates6_discord.idp_id,` +
+		` projections.idp_templates6_discord.client_id,` +
+		` projections.idp_templates6_discord.client_secret,` +
+		` projections.idp_templates6_discord.scopes,` +
+		// linkedin
+		` projections.idp_templates6_linkedin.idp_id,` +
+		` projections.idp_templates6_linkedin.client_id,` +
+		` projections.idp_templates6_linkedin.client_secret,` +
+		` projections.idp_templates6_linkedin.scopes` +
+		` FROM projections.idp_templates6` +
+		` LEFT JOIN projections.idp_templates6_oauth2 ON projections.idp_templates6.id = projections.idp_templates6_oauth2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oauth2.instance_id` +
+		` LEFT JOIN projections.idp_templates6_oidc ON projections.idp_templates6.id = projections.idp_templates6_oidc.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oidc.instance_id` +
+		` LEFT JOIN projections.idp_templates6_jwt ON projections.idp_templates6.id = projections.idp_templates6_jwt.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_jwt.instance_id` +
+		` LEFT JOIN projections.idp_templates6_azure ON projections.idp_templates6.id = projections.idp_templates6_azure.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_azure.instance_id` +
+		` LEFT JOIN projections.idp_templates6_github ON projections.idp_templates6.id = projections.idp_templates6_github.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_github.instance_id` +
+		` LEFT JOIN projections.idp_templates6_github_enterprise ON projections.idp_templates6.id = projections.idp_templates6_github_enterprise.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_github_enterprise.instance_id` +
+		` LEFT JOIN projections.idp_templates6_gitlab ON projections.idp_templates6.id = projections.idp_templates6_gitlab.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab.instance_id` +
+		` LEFT JOIN projections.idp_templates6_gitlab_self_hosted ON projections.idp_templates6.id = projections.idp_templates6_gitlab_self_hosted.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab_self_hosted.instance_id` +
+		` LEFT JOIN projections.idp_templates6_google ON projections.idp_templates6.id = projections.idp_templates6_google.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_google.instance_id` +
+		` LEFT JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id` +
+		` LEFT JOIN projections.idp_templates6_ldap2 ON projections.idp_templates6.id = projections.idp_templates6_ldap2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap2.instance_id` +
+		` LEFT JOIN projections.idp_templates6_apple ON projections.idp_templates6.id = projections.idp_templates6_apple.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_apple.instance_id` +
+		` LEFT JOIN projections.idp_templates6_bitbucket ON projections.idp_templates6.id = projections.idp_templates6_bitbucket.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_bitbucket.instance_id` +
+		` LEFT JOIN projections.idp_templates6_slack ON projections.idp_templates6.id = projections.idp_templates6_slack.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_slack.instance_id` +
+		` LEFT JOIN projections.idp_templates6_discord ON projections.idp_templates6.id = projections.idp_templates6_discord.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_discord.instance_id` +
+		` LEFT JOIN projections.idp_templates6_linkedin ON projections.idp_templates6.id = projections.idp_templates6_linkedin.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_linkedin.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	idpTemplateCols = []string{
 		"id",
@@ -249,130 +273,174 @@ var (
 		"key_id",
 		"private_key",
 		"scopes",
+		// bitbucket config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// slack config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// discord config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// linkedin config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
 	}
-	idpTemplatesQuery = `SELECT projections.idp_templates5.id,` +
-		` projections.idp_templates5.resource_owner,` +
-		` projections.idp_templates5.creation_date,` +
-		` projections.idp_templates5.change_date,` +
-		` projections.idp_templates5.sequence,` +
-		` projections.idp_templates5.state,` +
-		` projections.idp_templates5.name,` +
-		` projections.idp_templates5.type,` +
-		` projections.idp_templates5.owner_type,` +
-		` projections.idp_templates5.is_creation_allowed,` +
-		` projections.idp_templates5.is_linking_allowed,` +
-		` projections.idp_templates5.is_auto_creation,` +
-		` projections.idp_templates5.is_auto_update,` +
+	idpTemplatesQuery = `SELECT projections.idp_templates6.id,` +
+		` projections.idp_templates6.resource_owner,` +
+		` projections.idp_templates6.creation_date,` +
+		` projections.idp_templates6.change_date,` +
+		` projections.idp_templates6.sequence,` +
+		` projections.idp_templates6.state,` +
+		` projections.idp_templates6.name,` +
+		` projections.idp_templates6.type,` +
+		` projections.idp_templates6.owner_type,` +
+		` projections.idp_templates6.is_creation_allowed,` +
+		` projections.idp_templates6.is_linking_allowed,` +
+		` projections.idp_templates6.is_auto_creation,` +
+		` projections.idp_templates6.is_auto_update,` +
 		// oauth
-		` projections.idp_templates5_oauth2.idp_id,` +
-		` projections.idp_templates5_oauth2.client_id,` +
-		` projections.idp_templates5_oauth2.client_secret,` +
-		` projections.idp_templates5_oauth2.authorization_endpoint,` +
-		` projections.idp_templates5_oauth2.token_endpoint,` +
-		` projections.idp_templates5_oauth2.user_endpoint,` +
-		` projections.idp_templates5_oauth2.scopes,` +
-		` projections.idp_templates5_oauth2.id_attribute,` +
+		` projections.idp_templates6_oauth2.idp_id,` +
+		` projections.idp_templates6_oauth2.client_id,` +
+		` projections.idp_templates6_oauth2.client_secret,` +
+		` projections.idp_templates6_oauth2.authorization_endpoint,` +
+		` projections.idp_templates6_oauth2.token_endpoint,` +
+		` projections.idp_templates6_oauth2.user_endpoint,` +
+		` projections.idp_templates6_oauth2.scopes,` +
+		` projections.idp_templates6_oauth2.id_attribute,` +
 		// oidc
-		` projections.idp_templates5_oidc.idp_id,` +
-		` projections.idp_templates5_oidc.issuer,` +
-		` projections.idp_templates5_oidc.client_id,` +
-		` projections.idp_templates5_oidc.client_secret,` +
-		` projections.idp_templates5_oidc.scopes,` +
-		` projections.idp_templates5_oidc.id_token_mapping,` +
+		` projections.idp_templates6_oidc.idp_id,` +
+		` projections.idp_templates6_oidc.issuer,` +
+		` projections.idp_templates6_oidc.client_id,` +
+		` projections.idp_templates6_oidc.client_secret,` +
+		` projections.idp_templates6_oidc.scopes,` +
+		` projections.idp_templates6_oidc.id_token_mapping,` +
 		// jwt
-		` projections.idp_templates5_jwt.idp_id,` +
-		` projections.idp_templates5_jwt.issuer,` +
-		` projections.idp_templates5_jwt.jwt_endpoint,` +
-		` projections.idp_templates5_jwt.keys_endpoint,` +
-		` projections.idp_templates5_jwt.header_name,` +
+		` projections.idp_templates6_jwt.idp_id,` +
+		` projections.idp_templates6_jwt.issuer,` +
+		` projections.idp_templates6_jwt.jwt_endpoint,` +
+		` projections.idp_templates6_jwt.keys_endpoint,` +
+		` projections.idp_templates6_jwt.header_name,` +
 		// azure
-		` projections.idp_templates5_azure.idp_id,` +
-		` projections.idp_templates5_azure.client_id,` +
-		` projections.idp_templates5_azure.client_secret,` +
-		` projections.idp_templates5_azure.scopes,` +
-		` projections.idp_templates5_azure.tenant,` +
-		` projections.idp_templates5_azure.is_email_verified,` +
+		` projections.idp_templates6_azure.idp_id,` +
+		` projections.idp_templates6_azure.client_id,` +
+		` projections.idp_templates6_azure.client_secret,` +
+		` projections.idp_templates6_azure.scopes,` +
+		` projections.idp_templates6_azure.tenant,` +
+		` projections.idp_templates6_azure.is_email_verified,` +
 		// github
-		` projections.idp_templates5_github.idp_id,` +
-		` projections.idp_templates5_github.client_id,` +
-		` projections.idp_templates5_github.client_secret,` +
-		` projections.idp_templates5_github.scopes,` +
+		` projections.idp_templates6_github.idp_id,` +
+		` projections.idp_templates6_github.client_id,` +
+		` projections.idp_templates6_github.client_secret,` +
+		` projections.idp_templates6_github.scopes,` +
 		// github enterprise
-		` projections.idp_templates5_github_enterprise.idp_id,` +
-		` projections.idp_templates5_github_enterprise.client_id,` +
-		` projections.idp_templates5_github_enterprise.client_secret,` +
-		` projections.idp_templates5_github_enterprise.authorization_endpoint,` +
-		` projections.idp_templates5_github_enterprise.token_endpoint,` +
-		` projections.idp_templates5_github_enterprise.user_endpoint,` +
-		` projections.idp_templates5_github_enterprise.scopes,` +
+		` projections.idp_templates6_github_enterprise.idp_id,` +
+		` projections.idp_templates6_github_enterprise.client_id,` +
+		` projections.idp_templates6_github_enterprise.client_secret,` +
+		` projections.idp_templates6_github_enterprise.authorization_endpoint,` +
+		` projections.idp_templates6_github_enterprise.token_endpoint,` +
+		` projections.idp_templates6_github_enterprise.user_endpoint,` +
+		` projections.idp_templates6_github_enterprise.scopes,` +
 		// gitlab
-		` projections.idp_templates5_gitlab.idp_id,` +
-		` projections.idp_templates5_gitlab.client_id,` +
-		` projections.idp_templates5_gitlab.client_secret,` +
-		` projections.idp_templates5_gitlab.scopes,` +
+		` projections.idp_templates6_gitlab.idp_id,` +
+		` projections.idp_templates6_gitlab.client_id,` +
+		` projections.idp_templates6_gitlab.client_secret,` +
+		` projections.idp_templates6_gitlab.scopes,` +
 		// gitlab self hosted
-		` projections.idp_templates5_gitlab_self_hosted.idp_id,` +
-		` projections.idp_templates5_gitlab_self_hosted.issuer,` +
-		` projections.idp_templates5_gitlab_self_hosted.client_id,` +
-		` projections.idp_templates5_gitlab_self_hosted.client_secret,` +
-		` projections.idp_templates5_gitlab_self_hosted.scopes,` +
+		` projections.idp_templates6_gitlab_self_hosted.idp_id,` +
+		` projections.idp_templates6_gitlab_self_hosted.issuer,` +
+		` projections.idp_templates6_gitlab_self_hosted.client_id,` +
+		` projections.idp_templates6_gitlab_self_hosted.client_secret,` +
+		` projections.idp_templates6_gitlab_self_hosted.scopes,` +
 		// google
-		` projections.idp_templates5_google.idp_id,` +
-		` projections.idp_templates5_google.client_id,` +
-		` projections.idp_templates5_google.client_secret,` +
-		` projections.idp_templates5_google.scopes,` +
+		` projections.idp_templates6_google.idp_id,` +
+		` projections.idp_templates6_google.client_id,` +
+		` projections.idp_templates6_google.client_secret,` +
+		` projections.idp_templates6_google.scopes,` +
 		// saml
-		` projections.idp_templates5_saml.idp_id,` +
-		` projections.idp_templates5_saml.metadata,` +
-		` projections.idp_templates5_saml.key,` +
-		` projections.idp_templates5_saml.certificate,` +
-		` projections.idp_templates5_saml.binding,` +
-		` projections.idp_templates5_saml.with_signed_request,` +
+		` projections.idp_templates6_saml.idp_id,` +
+		` projections.idp_templates6_saml.metadata,` +
+		` projections.idp_templates6_saml.key,` +
+		` projections.idp_templates6_saml.certificate,` +
+		` projections.idp_templates6_saml.binding,` +
+		` projections.idp_templates6_saml.with_signed_request,` +
 		// ldap
-		` projections.idp_templates5_ldap2.idp_id,` +
-		` projections.idp_templates5_ldap2.servers,` +
-		` projections.idp_templates5_ldap2.start_tls,` +
-		` projections.idp_templates5_ldap2.base_dn,` +
-		` projections.idp_templates5_ldap2.bind_dn,` +
-		` projections.idp_templates5_ldap2.bind_password,` +
-		` projections.idp_templates5_ldap2.user_base,` +
-		` projections.idp_templates5_ldap2.user_object_classes,` +
-		` projections.idp_templates5_ldap2.user_filters,` +
-		` projections.idp_templates5_ldap2.timeout,` +
-		` projections.idp_templates5_ldap2.id_attribute,` +
-		` projections.idp_templates5_ldap2.first_name_attribute,` +
-		` projections.idp_templates5_ldap2.last_name_attribute,` +
-		` projections.idp_templates5_ldap2.display_name_attribute,` +
-		` projections.idp_templates5_ldap2.nick_name_attribute,` +
-		` projections.idp_templates5_ldap2.preferred_username_attribute,` +
-		` projections.idp_templates5_ldap2.email_attribute,` +
-		` projections.idp_templates5_ldap2.email_verified,` +
-		` projections.idp_templates5_ldap2.phone_attribute,` +
-		` projections.idp_templates5_ldap2.phone_verified_attribute,` +
-		` projections.idp_templates5_ldap2.preferred_language_attribute,` +
-		` projections.idp_templates5_ldap2.avatar_url_attribute,` +
-		` projections.idp_templates5_ldap2.profile_attribute,` +
+		` projections.idp_templates6_ldap2.idp_id,` +
+		` projections.idp_templates6_ldap2.servers,` +
+		` projections.idp_templates6_ldap2.start_tls,` +
+		` projections.idp_templates6_ldap2.base_dn,` +
+		` projections.idp_templates6_ldap2.bind_dn,` +
+		` projections.idp_templates6_ldap2.bind_password,` +
+		` projections.idp_templates6_ldap2.user_base,` +
+		` projections.idp_templates6_ldap2.user_object_classes,` +
+		` projections.idp_templates6_ldap2.user_filters,` +
+		` projections.idp_templates6_ldap2.timeout,` +
+		` projections.idp_templates6_ldap2.id_attribute,` +
+		` projections.idp_templates6_ldap2.first_name_attribute,` +
+		` projections.idp_templates6_ldap2.last_name_attribute,` +
+		` projections.idp_templates6_ldap2.display_name_attribute,` +
+		` projections.idp_templates6_ldap2.nick_name_attribute,` +
+		` projections.idp_templates6_ldap2.preferred_username_attribute,` +
+		` projections.idp_templates6_ldap2.email_attribute,` +
+		` projections.idp_templates6_ldap2.email_verified,` +
+		` projections.idp_templates6_ldap2.phone_attribute,` +
+		` projections.idp_templates6_ldap2.phone_verified_attribute,` +
+		` projections.idp_templates6_ldap2.preferred_language_attribute,` +
+		` projections.idp_templates6_ldap2.avatar_url_attribute,` +
+		` projections.idp_templates6_ldap2.profile_attribute,` +
 		// apple
-		` projections.idp_templates5_apple.idp_id,` +
-		` projections.idp_templates5_apple.client_id,` +
-		` projections.idp_templates5_apple.team_id,` +
-		` projections.idp_templates5_apple.key_id,` +
-		` projections.idp_templates5_apple.private_key,` +
-		` projections.idp_templates5_apple.scopes,` +
+		` projections.idp_templates6_apple.idp_id,` +
+		` projections.idp_templates6_apple.client_id,` +
+		` projections.idp_templates6_apple.team_id,` +
+		` projections.idp_templates6_apple.key_id,` +
+		` projections.idp_templates6_apple.private_key,` +
+		` projections.idp_templates6_apple.scopes,` +
+		// bitbucket
+		` projections.idp_templates6_bitbucket.idp_id,` +
+		` projections.idp_templates6_bitbucket.client_id,` +
+		` projections.idp_templates6_bitbucket.client_secret,` +
+		` projections.idp_templates6_bitbucket.scopes,` +
+		// slack
+		` projections.idp_templates6_slack.idp_id,` +
+		` projections.idp_templates6_slack.client_id,` +
+		` projections.idp_templates6_slack.client_secret,` +
+		` projections.idp_templates6_slack.scopes,` +
+		// discord
+		` projections.idp_templates6_discord.idp_id,` +
+		` projections.idp_templates6_discord.client_id,` +
+		` projections.idp_templates6_discord.client_secret,` +
+		` projections.idp_templates6_discord.scopes,` +
+		// linkedin
+		` projections.idp_templates6_linkedin.idp_id,` +
+		` projections.idp_templates6_linkedin.client_id,` +
+		` projections.idp_templates6_linkedin.client_secret,` +
+		` projections.idp_templates6_linkedin.scopes,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.idp_templates5` +
-		` LEFT JOIN projections.idp_templates5_oauth2 ON projections.idp_templates5.id = projections.idp_templates5_oauth2.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_oauth2.instance_id` +
-		` LEFT JOIN projections.idp_templates5_oidc ON projections.idp_templates5.id = projections.idp_templates5_oidc.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_oidc.instance_id` +
-		` LEFT JOIN projections.idp_templates5_jwt ON projections.idp_templates5.id = projections.idp_templates5_jwt.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_jwt.instance_id` +
-		` LEFT JOIN projections.idp_templates5_azure ON projections.idp_templates5.id = projections.idp_templates5_azure.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_azure.instance_id` +
-		` LEFT JOIN projections.idp_templates5_github ON projections.idp_templates5.id = projections.idp_templates5_github.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_github.instance_id` +
-		` LEFT JOIN projections.idp_templates5_github_enterprise ON projections.idp_templates5.id = projections.idp_templates5_github_enterprise.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_github_enterprise.instance_id` +
-		` LEFT JOIN projections.idp_templates5_gitlab ON projections.idp_templates5.id = projections.idp_templates5_gitlab.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_gitlab.instance_id` +
-		` LEFT JOIN projections.idp_templates5_gitlab_self_hosted ON projections.idp_templates5.id = projections.idp_templates5_gitlab_self_hosted.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_gitlab_self_hosted.instance_id` +
-		` LEFT JOIN projections.idp_templates5_google ON projections.idp_templates5.id = projections.idp_templates5_google.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_google.instance_id` +
-		` LEFT JOIN projections.idp_templates5_saml ON projections.idp_templates5.id = projections.idp_templates5_saml.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_saml.instance_id` +
-		` LEFT JOIN projections.idp_templates5_ldap2 ON projections.idp_templates5.id = projections.idp_templates5_ldap2.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_ldap2.instance_id` +
-		` LEFT JOIN projections.idp_templates5_apple ON projections.idp_templates5.id = projections.idp_templates5_apple.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_apple.instance_id` +
+		` FROM projections.idp_templates6` +
+		` LEFT JOIN projections.idp_templates6_oauth2 ON projections.idp_templates6.id = projections.idp_templates6_oauth2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oauth2.instance_id` +
+		` LEFT JOIN projections.idp_templates6_oidc ON projections.idp_templates6.id = projections.idp_templates6_oidc.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oidc.instance_id` +
+		` LEFT JOIN projections.idp_templates6_jwt ON projections.idp_templates6.id = projections.idp_templates6_jwt.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_jwt.instance_id` +
+		` LEFT JOIN projections.idp_templates6_azure ON projections.idp_templates6.id = projections.idp_templates6_azure.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_azure.instance_id` +
+		` LEFT JOIN projections.idp_templates6_github ON projections.idp_templates6.id = projections.idp_templates6_github.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_github.instance_id` +
+		` LEFT JOIN projections.idp_templates6_github_enterprise ON projections.idp_templates6.id = projections.idp_templates6_github_enterprise.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_github_enterprise.instance_id` +
+		` LEFT JOIN projections.idp_templates6_gitlab ON projections.idp_templates6.id = projections.idp_templates6_gitlab.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab.instance_id` +
+		` LEFT JOIN projections.idp_templates6_gitlab_self_hosted ON projections.idp_templates6.id = projections.idp_templates6_gitlab_self_hosted.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab_self_hosted.instance_id` +
+		` LEFT JOIN projections.idp_templates6_google ON projections.idp_templates6.id = projections.idp_templates6_google.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_google.instance_id` +
+		` LEFT JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id` +
+		` LEFT JOIN projections.idp_templates6_ldap2 ON projections.idp_templates6.id = projections.idp_templates6_ldap2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap2.instance_id` +
+		` LEFT JOIN projections.idp_templates6_apple ON projections.idp_templates6.id = projections.idp_templates6_apple.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_apple.instance_id` +
+		` LEFT JOIN projections.idp_templates6_bitbucket ON projections.idp_templates6.id = projections.idp_templates6_bitbucket.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_bitbucket.instance_id` +
+		` LEFT JOIN projections.idp_templates6_slack ON projections.idp_templates6.id = projections.idp_templates6_slack.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_slack.instance_id` +
+		` LEFT JOIN projections.idp_templates6_discord ON projections.idp_templates6.id = projections.idp_templates6_discord.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_discord.instance_id` +
+		` LEFT JOIN projections.idp_templates6_linkedin ON projections.idp_templates6.id = projections.idp_templates6_linkedin.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_linkedin.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	idpTemplatesCols = []string{
 		"id",
@@ -484,6 +552,26 @@ var (
 		"key_id",
 		"private_key",
 		"scopes",
+		// bitbucket config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// slack config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// discord config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// linkedin config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
 		"count",
 	}
 )
@@ -634,6 +722,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -780,6 +888,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -924,6 +1052,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1067,6 +1215,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1209,6 +1377,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1351,6 +1539,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1494,6 +1702,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1636,6 +1864,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1780,6 +2028,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1942,6 +2210,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						"key_id",
 						nil,
 						database.TextArray[string]{"profile"},
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1969,6 +2257,168 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareIDPTemplateByIDQuery bitbucket idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeBitbucket,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						"idp-id",
+						"client_id",
+						nil,
+						database.TextArray[string]{"profile"},
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeBitbucket,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				BitbucketIDPTemplate: &BitbucketIDPTemplate{
+					IDPID:        "idp-id",
+					ClientID:     "client_id",
+					ClientSecret: nil,
+					Scopes:       []string{"profile"},
+				},
+			},
+		},
 		{
 			name:    "prepareIDPTemplateByIDQuery no config",
 			prepare: prepareIDPTemplateByIDQuery,
@@ -2086,6 +2536,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// slack
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// linkedin
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -2258,6 +2728,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// slack
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
+							// linkedin
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -2429,6 +2919,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// slack
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
+							// linkedin
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -2574,6 +3084,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// slack
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
+							// linkedin
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-saml",
@@ -2685,6 +3215,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// slack
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
+							// linkedin
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-google",
@@ -2796,6 +3346,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// slack
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
+							// linkedin
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-oauth",
@@ -2907,6 +3477,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// slack
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
+							// linkedin
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-oidc",
@@ -3018,6 +3608,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// slack
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
+							// linkedin
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-jwt",
@@ -3129,6 +3739,26 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// slack
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
+							// linkedin
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -3,7 +3,9 @@ package query
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"slices"
 	"strings"
 	"time"
 
@@ -196,21 +198,77 @@ func (q *InstanceSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder
 func (q *Queries) SearchInstances(ctx context.Context, queries *InstanceSearchQueries) (instances *Instances, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
-
+	ctx = database.WithCallType(ctx, database.CallTypeList)
+
+	// the instances of all shards are merged before they are sorted and paginated,
+	// so each shard returns the instances up to the end of the requested page
+	shardQueries := *queries
+	shardQueries.Offset = 0
+	if queries.Limit > 0 {
+		shardQueries.Limit = queries.Offset + queries.Limit
+	}
 	filter, query, scan := prepareInstancesQuery(ctx, q.client)
-	stmt, args, err := query(queries.toQuery(filter)).ToSql()
+	stmt, args, err := query(shardQueries.toQuery(filter)).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-M9fow", "Errors.Query.SQLStatement")
 	}
 
-	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
-		instances, err = scan(rows)
-		return err
-	}, stmt, args...)
-	if err != nil {
-		return nil, zerrors.ThrowInternal(err, "QUERY-3j98f", "Errors.Internal")
+	instances = new(Instances)
+	for _, shard := range q.client.Shards() {
+		var shardInstances *Instances
+		err = q.client.QueryContext(database.WithShard(ctx, shard), func(rows *sql.Rows) error {
+			shardInstances, err = scan(rows)
+			return err
+		}, stmt, args...)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "QUERY-3j98f", "Errors.Internal")
+		}
+		instances.appendShard(shardInstances)
+	}
+	instances.paginate(&queries.SearchRequest)
+	return instances, nil
+}
+
+// paginate sorts the merged instances of all shards and applies the offset and limit of the request
+func (i *Instances) paginate(req *SearchRequest) {
+	if compare := compareInstances(req.SortingColumn); compare != nil {
+		slices.SortStableFunc(i.Instances, func(a, b *Instance) int {
+			if req.Asc {
+				return compare(a, b)
+			}
+			return compare(b, a)
+		})
+	}
+	i.Instances = i.Instances[min(req.Offset, uint64(len(i.Instances))):]
+	if req.Limit > 0 && uint64(len(i.Instances)) > req.Limit {
+		i.Instances = i.Instances[:req.Limit]
+	}
+}
+
+func compareInstances(column Column) func(a, b *Instance) int {
+	switch column {
+	case InstanceColumnID:
+		return func(a, b *Instance) int { return strings.Compare(a.ID, b.ID) }
+	case InstanceColumnName:
+		return func(a, b *Instance) int { return strings.Compare(a.Name, b.Name) }
+	case InstanceColumnCreationDate:
+		return func(a, b *Instance) int { return a.CreationDate.Compare(b.CreationDate) }
+	default:
+		return nil
 	}
-	return instances, err
+}
+
+// appendShard adds the instances of a shard,
+// instances found on multiple shards while they are moved are added once
+func (i *Instances) appendShard(shard *Instances) {
+	for _, instance := range shard.Instances {
+		if slices.ContainsFunc(i.Instances, func(added *Instance) bool { return added.ID == instance.ID }) {
+			shard.Count--
+			continue
+		}
+		i.Instances = append(i.Instances, instance)
+	}
+	i.Count += shard.Count
 }
 
 func (q *Queries) Instance(ctx context.Context, shouldTriggerBulk bool) (instance *Instance, err error) {
@@ -239,24 +297,39 @@ func (q *Queries) Instance(ctx context.Context, shouldTriggerBulk bool) (instanc
 	return instance, err
 }
 
-func (q *Queries) InstanceByHost(ctx context.Context, host string) (instance authz.Instance, err error) {
+func (q *Queries) InstanceByHost(ctx context.Context, host string) (_ authz.Instance, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	domain := strings.Split(host, ":")[0] //remove possible port
+	if instance, ok := q.caches.instance.Get(ctx, instanceIndexByHost, domain); ok {
+		return instance.withHost(host), nil
+	}
+
 	stmt, scan := prepareAuthzInstanceQuery(ctx, q.client, host)
-	host = strings.Split(host, ":")[0] //remove possible port
 	query, args, err := stmt.Where(sq.Eq{
-		InstanceDomainDomainCol.identifier(): host,
+		InstanceDomainDomainCol.identifier(): domain,
 	}).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-SAfg2", "Errors.Query.SQLStatement")
 	}
 
-	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
-		instance, err = scan(rows)
-		return err
-	}, query, args...)
-	return instance, err
+	// the instance isn't known yet, so the shards are queried until it's found
+	var instance *Instance
+	for _, shard := range q.client.Shards() {
+		err = q.client.QueryContext(database.WithShard(ctx, shard), func(rows *sql.Rows) error {
+			instance, err = scan(rows)
+			return err
+		}, query, args...)
+		if !zerrors.IsNotFound(err) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	q.caches.instance.Set(ctx, instance)
+	return instance, nil
 }
 
 func (q *Queries) InstanceByID(ctx context.Context) (_ authz.Instance, err error) {
@@ -600,3 +673,106 @@ func prepareAuthzInstanceQuery(ctx context.Context, db prepareDatabase, host str
 			return instance, nil
 		}
 }
+
+type instanceIndex string
+
+const (
+	instanceIndexByID   instanceIndex = "id"
+	instanceIndexByHost instanceIndex = "host"
+)
+
+var instanceIndices = []instanceIndex{
+	instanceIndexByID,
+	instanceIndexByHost,
+}
+
+// Keys implements [cache.Entry]
+func (i *Instance) Keys(index instanceIndex) []string {
+	switch index {
+	case instanceIndexByID:
+		return []string{i.ID}
+	case instanceIndexByHost:
+		domains := make([]string, len(i.Domains))
+		for j, domain := range i.Domains {
+			domains[j] = domain.Domain
+		}
+		return domains
+	default:
+		return nil
+	}
+}
+
+// withHost returns a copy of the cached instance for the requested host.
+func (i *Instance) withHost(host string) *Instance {
+	instance := *i
+	instance.host = host
+	return &instance
+}
+
+// cachedInstance is the encoded representation of [Instance],
+// so the unexported fields are kept by cache connectors which encode the object.
+// The requested host is not stored, as it is set on each request.
+type cachedInstance struct {
+	ID                string
+	ChangeDate        time.Time
+	CreationDate      time.Time
+	Sequence          uint64
+	Name              string
+	DefaultOrgID      string
+	IAMProjectID      string
+	ConsoleID         string
+	ConsoleAppID      string
+	DefaultLang       language.Tag
+	Domains           []*InstanceDomain
+	CSPEnabled        bool
+	AllowedOrigins    database.TextArray[string]
+	Block             *bool
+	AuditLogRetention *time.Duration
+}
+
+func (i *Instance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&cachedInstance{
+		ID:                i.ID,
+		ChangeDate:        i.ChangeDate,
+		CreationDate:      i.CreationDate,
+		Sequence:          i.Sequence,
+		Name:              i.Name,
+		DefaultOrgID:      i.DefaultOrgID,
+		IAMProjectID:      i.IAMProjectID,
+		ConsoleID:         i.ConsoleID,
+		ConsoleAppID:      i.ConsoleAppID,
+		DefaultLang:       i.DefaultLang,
+		Domains:           i.Domains,
+		CSPEnabled:        i.csp.enabled,
+		AllowedOrigins:    i.csp.allowedOrigins,
+		Block:             i.block,
+		AuditLogRetention: i.auditLogRetention,
+	})
+}
+
+func (i *Instance) UnmarshalJSON(data []byte) error {
+	cached := new(cachedInstance)
+	if err := json.Unmarshal(data, cached); err != nil {
+		return err
+	}
+	*i = Instance{
+		ID:           cached.ID,
+		ChangeDate:   cached.ChangeDate,
+		CreationDate: cached.CreationDate,
+		Sequence:     cached.Sequence,
+		Name:         cached.Name,
+		DefaultOrgID: cached.DefaultOrgID,
+		IAMProjectID: cached.IAMProjectID,
+		ConsoleID:    cached.ConsoleID,
+		ConsoleAppID: cached.ConsoleAppID,
+		DefaultLang:  cached.DefaultLang,
+		Domains:      cached.Domains,
+		csp: csp{
+			enabled:        cached.CSPEnabled,
+			allowedOrigins: cached.AllowedOrigins,
+		},
+		block:             cached.Block,
+		auditLogRetention: cached.AuditLogRetention,
+	}
+	return nil
+}
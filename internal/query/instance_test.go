@@ -402,3 +402,59 @@ func Test_InstancePrepares(t *testing.T) {
 		})
 	}
 }
+
+func TestInstances_paginate(t *testing.T) {
+	merged := func() *Instances {
+		// instances of two shards, each sorted by name
+		return &Instances{
+			Instances: []*Instance{{ID: "1", Name: "a"}, {ID: "3", Name: "c"}, {ID: "2", Name: "b"}, {ID: "4", Name: "d"}},
+		}
+	}
+	ids := func(instances *Instances) []string {
+		ids := make([]string, len(instances.Instances))
+		for i, instance := range instances.Instances {
+			ids[i] = instance.ID
+		}
+		return ids
+	}
+	tests := []struct {
+		name string
+		req  *SearchRequest
+		want []string
+	}{
+		{
+			name: "no pagination",
+			req:  &SearchRequest{},
+			want: []string{"1", "3", "2", "4"},
+		},
+		{
+			name: "sorted ascending",
+			req:  &SearchRequest{SortingColumn: InstanceColumnName, Asc: true},
+			want: []string{"1", "2", "3", "4"},
+		},
+		{
+			name: "sorted descending",
+			req:  &SearchRequest{SortingColumn: InstanceColumnID},
+			want: []string{"4", "3", "2", "1"},
+		},
+		{
+			name: "page after merge",
+			req:  &SearchRequest{SortingColumn: InstanceColumnName, Asc: true, Offset: 1, Limit: 2},
+			want: []string{"2", "3"},
+		},
+		{
+			name: "offset exceeds instances",
+			req:  &SearchRequest{Offset: 5, Limit: 2},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instances := merged()
+			instances.paginate(tt.req)
+			if got := ids(instances); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paginate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
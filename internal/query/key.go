@@ -4,9 +4,11 @@ import (
 	"context"
 	"crypto/rsa"
 	"database/sql"
+	"slices"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
@@ -215,15 +217,31 @@ func (q *Queries) ActivePrivateSigningKey(ctx context.Context, t time.Time) (key
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	stmt, scan := preparePrivateKeysQuery(ctx, q.client)
 	if t.IsZero() {
 		t = time.Now()
 	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if cached, ok := q.caches.signingKeys.Get(ctx, signingKeysIndexByInstance, instanceID); ok {
+		if keys := cached.activeKeys(t); len(keys.Keys) > 0 {
+			active, err := q.withoutRevokedKeys(ctx, keys.Keys)
+			if err != nil {
+				return nil, err
+			}
+			if len(active) == len(keys.Keys) {
+				return keys, nil
+			}
+			// the keys were revoked on another node, which only invalidated its own cache
+			err = q.caches.signingKeys.Invalidate(ctx, signingKeysIndexByInstance, instanceID)
+			logging.OnError(err).Warn("unable to invalidate revoked signing keys")
+		}
+	}
+
+	stmt, scan := preparePrivateKeysQuery(ctx, q.client)
 	query, args, err := stmt.Where(
 		sq.And{
 			sq.Eq{
 				KeyColUse.identifier():        domain.KeyUsageSigning,
-				KeyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+				KeyColInstanceID.identifier(): instanceID,
 			},
 			sq.Gt{KeyPrivateColExpiry.identifier(): t},
 		}).OrderBy(KeyPrivateColExpiry.identifier()).ToSql()
@@ -238,6 +256,16 @@ func (q *Queries) ActivePrivateSigningKey(ctx context.Context, t time.Time) (key
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-WRFG4", "Errors.Internal")
 	}
+	// the projection might not have reduced the revocation yet
+	if keys.Keys, err = q.withoutRevokedKeys(ctx, keys.Keys); err != nil {
+		return nil, err
+	}
+	keys.Count = uint64(len(keys.Keys))
+	// the state is only needed by the caller to generate a new key,
+	// so only non-empty key sets are cached
+	if len(keys.Keys) > 0 {
+		q.caches.signingKeys.Set(ctx, newCachedSigningKeys(instanceID, keys))
+	}
 	keys.State, err = q.latestState(ctx, keyTable)
 	if !zerrors.IsNotFound(err) {
 		return keys, err
@@ -359,6 +387,7 @@ type PublicKeyReadModel struct {
 	Key       *crypto.CryptoValue
 	Expiry    time.Time
 	Usage     domain.KeyUsage
+	Revoked   bool
 }
 
 func NewPublicKeyReadModel(keyID, resourceOwner string) *PublicKeyReadModel {
@@ -382,6 +411,12 @@ func (wm *PublicKeyReadModel) Reduce() error {
 			wm.Key = e.PublicKey.Key
 			wm.Expiry = e.PublicKey.Expiry
 			wm.Usage = e.Usage
+		case *keypair.RevokedEvent:
+			if e.RevokeAt.IsZero() {
+				wm.Revoked = true
+			} else if e.RevokeAt.Before(wm.Expiry) {
+				wm.Expiry = e.RevokeAt
+			}
 		default:
 		}
 	}
@@ -395,7 +430,7 @@ func (wm *PublicKeyReadModel) Query() *eventstore.SearchQueryBuilder {
 		AddQuery().
 		AggregateTypes(keypair.AggregateType).
 		AggregateIDs(wm.AggregateID).
-		EventTypes(keypair.AddedEventType).
+		EventTypes(keypair.AddedEventType, keypair.RevokedEventType).
 		Builder()
 }
 
@@ -407,7 +442,7 @@ func (q *Queries) GetPublicKeyByID(ctx context.Context, keyID string) (_ PublicK
 	if err := q.eventstore.FilterToQueryReducer(ctx, model); err != nil {
 		return nil, err
 	}
-	if model.Algorithm == "" || model.Key == nil {
+	if model.Algorithm == "" || model.Key == nil || model.Revoked {
 		return nil, zerrors.ThrowNotFound(err, "QUERY-Ahf7x", "Errors.Key.NotFound")
 	}
 	keyValue, err := crypto.Decrypt(model.Key, q.keyEncryptionAlgorithm)
@@ -433,3 +468,156 @@ func (q *Queries) GetPublicKeyByID(ctx context.Context, keyID string) (_ PublicK
 		publicKey: publicKey,
 	}, nil
 }
+
+// revokedKeysReadModel collects the revoked keys of the instance
+type revokedKeysReadModel struct {
+	eventstore.ReadModel
+
+	keyIDs  []string
+	Revoked []string
+}
+
+func (rm *revokedKeysReadModel) Reduce() error {
+	now := time.Now()
+	for _, event := range rm.Events {
+		if e, ok := event.(*keypair.RevokedEvent); ok && !e.RevokedAt().After(now) {
+			rm.Revoked = append(rm.Revoked, event.Aggregate().ID)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *revokedKeysReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(rm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(keypair.AggregateType).
+		AggregateIDs(rm.keyIDs...).
+		EventTypes(keypair.RevokedEventType).
+		Builder()
+}
+
+// RevokedKeyIDs returns the ids of the passed keys which are revoked.
+// The events are read instead of the projection and caches,
+// so a revocation is effective on all nodes as soon as it's pushed.
+func (q *Queries) RevokedKeyIDs(ctx context.Context, keyIDs ...string) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if len(keyIDs) == 0 {
+		return nil, nil
+	}
+	model := &revokedKeysReadModel{
+		ReadModel: eventstore.ReadModel{
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+		keyIDs: keyIDs,
+	}
+	if err := q.eventstore.FilterToQueryReducer(ctx, model); err != nil {
+		return nil, err
+	}
+	return model.Revoked, nil
+}
+
+func (q *Queries) withoutRevokedKeys(ctx context.Context, keys []PrivateKey) ([]PrivateKey, error) {
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = key.ID()
+	}
+	revoked, err := q.RevokedKeyIDs(ctx, ids...)
+	if err != nil || len(revoked) == 0 {
+		return keys, err
+	}
+	return slices.DeleteFunc(keys, func(key PrivateKey) bool {
+		return slices.Contains(revoked, key.ID())
+	}), nil
+}
+
+type signingKeysIndex string
+
+const (
+	signingKeysIndexByInstance signingKeysIndex = "instance"
+)
+
+var signingKeysIndices = []signingKeysIndex{
+	signingKeysIndexByInstance,
+}
+
+// cachedSigningKeys are the private signing keys of an instance
+// which were active when the keys were cached.
+type cachedSigningKeys struct {
+	InstanceID  string
+	PrivateKeys []*cachedPrivateKey
+}
+
+type cachedPrivateKey struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	Algorithm     string
+	Use           domain.KeyUsage
+	Expiry        time.Time
+	Key           *crypto.CryptoValue
+}
+
+func newCachedSigningKeys(instanceID string, keys *PrivateKeys) *cachedSigningKeys {
+	cached := &cachedSigningKeys{
+		InstanceID:  instanceID,
+		PrivateKeys: make([]*cachedPrivateKey, 0, len(keys.Keys)),
+	}
+	for _, key := range keys.Keys {
+		privateKey, ok := key.(*privateKey)
+		if !ok {
+			continue
+		}
+		cached.PrivateKeys = append(cached.PrivateKeys, &cachedPrivateKey{
+			ID:            privateKey.id,
+			CreationDate:  privateKey.creationDate,
+			ChangeDate:    privateKey.changeDate,
+			Sequence:      privateKey.sequence,
+			ResourceOwner: privateKey.resourceOwner,
+			Algorithm:     privateKey.algorithm,
+			Use:           privateKey.use,
+			Expiry:        privateKey.expiry,
+			Key:           privateKey.privateKey,
+		})
+	}
+	return cached
+}
+
+// Keys implements [cache.Entry]
+func (k *cachedSigningKeys) Keys(index signingKeysIndex) []string {
+	if index == signingKeysIndexByInstance {
+		return []string{k.InstanceID}
+	}
+	return nil
+}
+
+// activeKeys returns the keys which expire after t, ordered by expiry.
+func (k *cachedSigningKeys) activeKeys(t time.Time) *PrivateKeys {
+	keys := &PrivateKeys{
+		Keys: make([]PrivateKey, 0, len(k.PrivateKeys)),
+	}
+	for _, cached := range k.PrivateKeys {
+		if !cached.Expiry.After(t) {
+			continue
+		}
+		keys.Keys = append(keys.Keys, &privateKey{
+			key: key{
+				id:            cached.ID,
+				creationDate:  cached.CreationDate,
+				changeDate:    cached.ChangeDate,
+				sequence:      cached.Sequence,
+				resourceOwner: cached.ResourceOwner,
+				algorithm:     cached.Algorithm,
+				use:           cached.Use,
+			},
+			expiry:     cached.Expiry,
+			privateKey: cached.Key,
+		})
+	}
+	keys.Count = uint64(len(keys.Keys))
+	return keys
+}
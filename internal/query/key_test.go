@@ -294,6 +294,49 @@ func TestQueries_GetPublicKeyByID(t *testing.T) {
 			),
 			wantErr: zerrors.ThrowNotFound(nil, "QUERY-Ahf7x", "Errors.Key.NotFound"),
 		},
+		{
+			name: "revoked",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(key_repo.NewAddedEvent(context.Background(),
+						&eventstore.Aggregate{
+							ID:            "keyID",
+							Type:          key_repo.AggregateType,
+							ResourceOwner: "instanceID",
+							InstanceID:    "instanceID",
+							Version:       key_repo.AggregateVersion,
+						},
+						domain.KeyUsageSigning, "alg",
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "alg",
+							KeyID:      "keyID",
+							Crypted:    []byte("private"),
+						},
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "alg",
+							KeyID:      "keyID",
+							Crypted:    []byte("public"),
+						},
+						future,
+						future,
+					)),
+					eventFromEventPusher(key_repo.NewRevokedEvent(context.Background(),
+						&eventstore.Aggregate{
+							ID:            "keyID",
+							Type:          key_repo.AggregateType,
+							ResourceOwner: "instanceID",
+							InstanceID:    "instanceID",
+							Version:       key_repo.AggregateVersion,
+						},
+						"compromised",
+						time.Time{},
+					)),
+				),
+			),
+			wantErr: zerrors.ThrowNotFound(nil, "QUERY-Ahf7x", "Errors.Key.NotFound"),
+		},
 		{
 			name: "decrypt error",
 			eventstore: expectEventstore(
@@ -454,3 +497,69 @@ func TestQueries_GetPublicKeyByID(t *testing.T) {
 		})
 	}
 }
+
+func TestQueries_RevokedKeyIDs(t *testing.T) {
+	revokedEvent := func(id string, revokeAt time.Time) eventstore.Event {
+		return eventFromEventPusher(key_repo.NewRevokedEvent(context.Background(),
+			&eventstore.Aggregate{
+				ID:            id,
+				Type:          key_repo.AggregateType,
+				ResourceOwner: "instanceID",
+				InstanceID:    "instanceID",
+				Version:       key_repo.AggregateVersion,
+			},
+			"compromised",
+			revokeAt,
+		))
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		keyIDs     []string
+		want       []string
+		wantErr    error
+	}{
+		{
+			name:       "no keys",
+			eventstore: expectEventstore(),
+		},
+		{
+			name: "filter error",
+			eventstore: expectEventstore(
+				expectFilterError(io.ErrClosedPipe),
+			),
+			keyIDs:  []string{"key1"},
+			wantErr: io.ErrClosedPipe,
+		},
+		{
+			name: "revoked",
+			eventstore: expectEventstore(
+				expectFilter(revokedEvent("key2", time.Time{})),
+			),
+			keyIDs: []string{"key1", "key2"},
+			want:   []string{"key2"},
+		},
+		{
+			name: "revoked after pre-publication",
+			eventstore: expectEventstore(
+				expectFilter(
+					revokedEvent("key1", time.Now().Add(-time.Minute)),
+					revokedEvent("key2", time.Now().Add(time.Hour)),
+				),
+			),
+			keyIDs: []string{"key1", "key2"},
+			want:   []string{"key1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queries{
+				eventstore: tt.eventstore(t),
+			}
+			ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+			got, err := q.RevokedKeyIDs(ctx, tt.keyIDs...)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
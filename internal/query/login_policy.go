@@ -179,7 +179,14 @@ func (q *Queries) LoginPolicyByID(ctx context.Context, shouldTriggerBulk bool, o
 		logging.OnError(err).Debug("trigger failed")
 		traceSpan.EndWithError(err)
 	}
-	eq := sq.Eq{LoginPolicyColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	// policies of removed owners are only needed for administrative reads and are not cached
+	if !withOwnerRemoved {
+		if cached, ok := q.caches.loginPolicy.Get(ctx, loginPolicyIndexByOrg, instanceScopedKey(instanceID, orgID)); ok {
+			return cached.Policy, nil
+		}
+	}
+	eq := sq.Eq{LoginPolicyColumnInstanceID.identifier(): instanceID}
 	if !withOwnerRemoved {
 		eq[LoginPolicyColumnOwnerRemoved.identifier()] = false
 	}
@@ -190,7 +197,7 @@ func (q *Queries) LoginPolicyByID(ctx context.Context, shouldTriggerBulk bool, o
 			eq,
 			sq.Or{
 				sq.Eq{LoginPolicyColumnOrgID.identifier(): orgID},
-				sq.Eq{LoginPolicyColumnOrgID.identifier(): authz.GetInstance(ctx).InstanceID()},
+				sq.Eq{LoginPolicyColumnOrgID.identifier(): instanceID},
 			},
 		}).Limit(1).OrderBy(LoginPolicyColumnIsDefault.identifier()).ToSql()
 	if err != nil {
@@ -204,7 +211,17 @@ func (q *Queries) LoginPolicyByID(ctx context.Context, shouldTriggerBulk bool, o
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-SWgr3", "Errors.Internal")
 	}
-	return policy, q.addLinksToLoginPolicy(ctx, policy)
+	if err = q.addLinksToLoginPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	if !withOwnerRemoved {
+		q.caches.loginPolicy.Set(ctx, &cachedLoginPolicy{
+			InstanceID: instanceID,
+			OrgID:      orgID,
+			Policy:     policy,
+		})
+	}
+	return policy, nil
 }
 
 func (q *Queries) addLinksToLoginPolicy(ctx context.Context, policy *LoginPolicy) error {
@@ -472,3 +489,35 @@ func prepareLoginPolicyMFAsQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			return p, nil
 		}
 }
+
+type loginPolicyIndex string
+
+const (
+	loginPolicyIndexByOrg      loginPolicyIndex = "org"
+	loginPolicyIndexByInstance loginPolicyIndex = "instance"
+)
+
+var loginPolicyIndices = []loginPolicyIndex{
+	loginPolicyIndexByOrg,
+	loginPolicyIndexByInstance,
+}
+
+// cachedLoginPolicy is the login policy resolved for the requested organization,
+// which is either the policy of the organization or the default policy of the instance.
+type cachedLoginPolicy struct {
+	InstanceID string
+	OrgID      string
+	Policy     *LoginPolicy
+}
+
+// Keys implements [cache.Entry]
+func (p *cachedLoginPolicy) Keys(index loginPolicyIndex) []string {
+	switch index {
+	case loginPolicyIndexByOrg:
+		return []string{instanceScopedKey(p.InstanceID, p.OrgID)}
+	case loginPolicyIndexByInstance:
+		return []string{p.InstanceID}
+	default:
+		return nil
+	}
+}
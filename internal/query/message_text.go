@@ -33,6 +33,9 @@ type MessageTexts struct {
 	DomainClaimed            MessageText
 	PasswordlessRegistration MessageText
 	PasswordChange           MessageText
+	IDPAutoLinked            MessageText
+	RefreshTokenReused       MessageText
+	SAMLCertificateExpiring  MessageText
 }
 
 type MessageText struct {
@@ -346,6 +349,12 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *MessageText {
 		return &m.PasswordlessRegistration
 	case domain.PasswordChangeMessageType:
 		return &m.PasswordChange
+	case domain.IDPAutoLinkedMessageType:
+		return &m.IDPAutoLinked
+	case domain.RefreshTokenReusedMessageType:
+		return &m.RefreshTokenReused
+	case domain.SAMLCertificateExpiringMessageType:
+		return &m.SAMLCertificateExpiring
 	}
 	return nil
 }
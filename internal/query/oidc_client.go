@@ -34,10 +34,14 @@ type OIDCClient struct {
 	IDTokenUserinfoAssertion bool                       `json:"id_token_userinfo_assertion,omitempty"`
 	ClockSkew                time.Duration              `json:"clock_skew,omitempty"`
 	AdditionalOrigins        []string                   `json:"additional_origins,omitempty"`
+	TLSClientAuthSubjectDN   string                     `json:"tls_client_auth_subject_dn,omitempty"`
 	PublicKeys               map[string][]byte          `json:"public_keys,omitempty"`
 	ProjectID                string                     `json:"project_id,omitempty"`
 	ProjectRoleKeys          []string                   `json:"project_role_keys,omitempty"`
 	Settings                 *OIDCSettings              `json:"settings,omitempty"`
+
+	// RefreshTokenReuseGracePeriod overrides the default grace period if set
+	RefreshTokenReuseGracePeriod time.Duration `json:"refresh_token_reuse_grace_period,omitempty"`
 }
 
 //go:embed embed/oidc_client_by_id.sql
@@ -47,8 +51,16 @@ func (q *Queries) GetOIDCClientByID(ctx context.Context, clientID string, getKey
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	// public keys expire, clients including them are not cached
+	if !getKeys {
+		if cached, ok := q.caches.oidcClient.Get(ctx, oidcClientIndexByClientID, instanceScopedKey(instanceID, clientID)); ok {
+			return cached, nil
+		}
+	}
+
 	client, err = database.QueryJSONObject[OIDCClient](ctx, q.client, oidcClientQuery,
-		authz.GetInstance(ctx).InstanceID(), clientID, getKeys,
+		instanceID, clientID, getKeys,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, zerrors.ThrowNotFound(err, "QUERY-wu6Ee", "Errors.App.NotFound")
@@ -56,5 +68,36 @@ func (q *Queries) GetOIDCClientByID(ctx context.Context, clientID string, getKey
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-ieR7R", "Errors.Internal")
 	}
+	if !getKeys {
+		q.caches.oidcClient.Set(ctx, client)
+	}
 	return client, err
 }
+
+type oidcClientIndex string
+
+const (
+	oidcClientIndexByClientID oidcClientIndex = "client_id"
+	oidcClientIndexByProject  oidcClientIndex = "project"
+	oidcClientIndexByInstance oidcClientIndex = "instance"
+)
+
+var oidcClientIndices = []oidcClientIndex{
+	oidcClientIndexByClientID,
+	oidcClientIndexByProject,
+	oidcClientIndexByInstance,
+}
+
+// Keys implements [cache.Entry]
+func (c *OIDCClient) Keys(index oidcClientIndex) []string {
+	switch index {
+	case oidcClientIndexByClientID:
+		return []string{instanceScopedKey(c.InstanceID, c.ClientID)}
+	case oidcClientIndexByProject:
+		return []string{instanceScopedKey(c.InstanceID, c.ProjectID)}
+	case oidcClientIndexByInstance:
+		return []string{c.InstanceID}
+	default:
+		return nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 	_ "embed"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,6 +27,8 @@ var (
 	testdataOidcClientSecret string
 	//go:embed testdata/oidc_client_no_settings.json
 	testdataOidcClientNoSettings string
+	//go:embed testdata/oidc_client_tls.json
+	testdataOidcClientTLS string
 )
 
 func TestQueries_GetOIDCClientByID(t *testing.T) {
@@ -188,6 +191,32 @@ low2kyJov38V4Uk2I8kuXpLcnrpw5Tio2ooiUE27b0vHZqBKOei9Uo88qCrn3EKx
 				Settings:                 nil,
 			},
 		},
+		{
+			name: "tls client",
+			mock: mockQuery(expQuery, cols, []driver.Value{testdataOidcClientTLS}, "instanceID", "clientID", true),
+			want: &OIDCClient{
+				InstanceID:             "230690539048009730",
+				AppID:                  "236647088211886082",
+				State:                  domain.AppStateActive,
+				ClientID:               "236647088211951618@tests",
+				ClientSecret:           nil,
+				RedirectURIs:           []string{"http://localhost:9999/auth/callback"},
+				ResponseTypes:          []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:             []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+				ApplicationType:        domain.OIDCApplicationTypeWeb,
+				AuthMethodType:         domain.OIDCAuthMethodTypeTLSClientAuth,
+				PostLogoutRedirectURIs: []string{"https://example.com/logout"},
+				AccessTokenType:        domain.OIDCTokenTypeBearer,
+				TLSClientAuthSubjectDN: "CN=client,O=ZITADEL",
+				ProjectID:              "236645808328409090",
+
+				RefreshTokenReuseGracePeriod: 5 * time.Second,
+				Settings: &OIDCSettings{
+					AccessTokenLifetime: 43200000000000,
+					IdTokenLifetime:     43200000000000,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
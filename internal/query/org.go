@@ -12,6 +12,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
 	domain_pkg "github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -103,10 +104,15 @@ func (q *Queries) OrgByID(ctx context.Context, shouldTriggerBulk bool, id string
 		traceSpan.EndWithError(err)
 	}
 
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if cached, ok := q.caches.org.Get(ctx, orgIndexByID, instanceScopedKey(instanceID, id)); ok {
+		return cached.Org, nil
+	}
+
 	stmt, scan := prepareOrgQuery(ctx, q.client)
 	query, args, err := stmt.Where(sq.Eq{
 		OrgColumnID.identifier():         id,
-		OrgColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		OrgColumnInstanceID.identifier(): instanceID,
 	}).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-AWx52", "Errors.Query.SQLStatement")
@@ -116,7 +122,14 @@ func (q *Queries) OrgByID(ctx context.Context, shouldTriggerBulk bool, id string
 		org, err = scan(row)
 		return err
 	}, query, args...)
-	return org, err
+	if err != nil {
+		return nil, err
+	}
+	q.caches.org.Set(ctx, &cachedOrg{
+		InstanceID: instanceID,
+		Org:        org,
+	})
+	return org, nil
 }
 
 func (q *Queries) OrgByPrimaryDomain(ctx context.Context, domain string) (org *Org, err error) {
@@ -217,6 +230,7 @@ func (q *Queries) ExistsOrg(ctx context.Context, id, domain string) (verifiedID
 func (q *Queries) SearchOrgs(ctx context.Context, queries *OrgSearchQueries) (orgs *Orgs, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
+	ctx = database.WithCallType(ctx, database.CallTypeList)
 
 	query, scan := prepareOrgsQuery(ctx, q.client)
 	stmt, args, err := queries.toQuery(query).
@@ -393,3 +407,32 @@ func prepareOrgUniqueQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			return isUnique, err
 		}
 }
+
+type orgIndex string
+
+const (
+	orgIndexByID       orgIndex = "id"
+	orgIndexByInstance orgIndex = "instance"
+)
+
+var orgIndices = []orgIndex{
+	orgIndexByID,
+	orgIndexByInstance,
+}
+
+type cachedOrg struct {
+	InstanceID string
+	Org        *Org
+}
+
+// Keys implements [cache.Entry]
+func (o *cachedOrg) Keys(index orgIndex) []string {
+	switch index {
+	case orgIndexByID:
+		return []string{instanceScopedKey(o.InstanceID, o.Org.ID)}
+	case orgIndexByInstance:
+		return []string{o.InstanceID}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,211 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type OrgDomainIDPRouting struct {
+	OrgID        string
+	Domain       string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+	domain.OrgDomainIDPRouting
+}
+
+var (
+	orgDomainIDPRoutingTable = table{
+		name:          projection.OrgDomainIDPRoutingTable,
+		instanceIDCol: projection.OrgDomainIDPRoutingInstanceIDCol,
+	}
+	OrgDomainIDPRoutingOrgIDCol = Column{
+		name:  projection.OrgDomainIDPRoutingOrgIDCol,
+		table: orgDomainIDPRoutingTable,
+	}
+	OrgDomainIDPRoutingInstanceIDCol = Column{
+		name:  projection.OrgDomainIDPRoutingInstanceIDCol,
+		table: orgDomainIDPRoutingTable,
+	}
+	OrgDomainIDPRoutingCreationDateCol = Column{
+		name:  projection.OrgDomainIDPRoutingCreationDateCol,
+		table: orgDomainIDPRoutingTable,
+	}
+	OrgDomainIDPRoutingChangeDateCol = Column{
+		name:  projection.OrgDomainIDPRoutingChangeDateCol,
+		table: orgDomainIDPRoutingTable,
+	}
+	OrgDomainIDPRoutingSequenceCol = Column{
+		name:  projection.OrgDomainIDPRoutingSequenceCol,
+		table: orgDomainIDPRoutingTable,
+	}
+	OrgDomainIDPRoutingDomainCol = Column{
+		name:  projection.OrgDomainIDPRoutingDomainCol,
+		table: orgDomainIDPRoutingTable,
+	}
+	OrgDomainIDPRoutingIDPIDsCol = Column{
+		name:  projection.OrgDomainIDPRoutingIDPIDsCol,
+		table: orgDomainIDPRoutingTable,
+	}
+	OrgDomainIDPRoutingForceCol = Column{
+		name:  projection.OrgDomainIDPRoutingForceCol,
+		table: orgDomainIDPRoutingTable,
+	}
+)
+
+// DomainIDPRoutingByDomain returns the routing of the verified organization domain used for home realm discovery.
+// Routings without any remaining identity provider are treated as not found.
+func (q *Queries) DomainIDPRoutingByDomain(ctx context.Context, orgDomain string) (routing *OrgDomainIDPRouting, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareOrgDomainIDPRoutingQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		OrgDomainIDPRoutingDomainCol.identifier():     orgDomain,
+		OrgDomainIDPRoutingInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Oof4e", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		routing, err = scan(row)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	// all providers of the routing were removed
+	if len(routing.IDPIDs) == 0 {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Iej0o", "Errors.Org.Domain.IDPRouting.NotFound")
+	}
+	return routing, nil
+}
+
+// OrgDomainIDPRoutings returns the routings of all domains of the organization.
+func (q *Queries) OrgDomainIDPRoutings(ctx context.Context, orgID string) (routings []*OrgDomainIDPRouting, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareOrgDomainIDPRoutingsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		OrgDomainIDPRoutingOrgIDCol.identifier():      orgID,
+		OrgDomainIDPRoutingInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).OrderBy(OrgDomainIDPRoutingDomainCol.identifier()).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-ahG6i", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		routings, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ohb1i", "Errors.Internal")
+	}
+	return routings, nil
+}
+
+// OrgDomainIDPRoutingsByDomain returns the routings of the organization by their lower-cased domain.
+// Routings without any remaining identity provider are omitted.
+func (q *Queries) OrgDomainIDPRoutingsByDomain(ctx context.Context, orgID string) (_ map[string]*domain.OrgDomainIDPRouting, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	routings, err := q.OrgDomainIDPRoutings(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	byDomain := make(map[string]*domain.OrgDomainIDPRouting, len(routings))
+	for _, routing := range routings {
+		if len(routing.IDPIDs) == 0 {
+			continue
+		}
+		byDomain[strings.ToLower(routing.Domain)] = &routing.OrgDomainIDPRouting
+	}
+	return byDomain, nil
+}
+
+func orgDomainIDPRoutingColumns() []string {
+	return []string{
+		OrgDomainIDPRoutingOrgIDCol.identifier(),
+		OrgDomainIDPRoutingDomainCol.identifier(),
+		OrgDomainIDPRoutingCreationDateCol.identifier(),
+		OrgDomainIDPRoutingChangeDateCol.identifier(),
+		OrgDomainIDPRoutingSequenceCol.identifier(),
+		OrgDomainIDPRoutingIDPIDsCol.identifier(),
+		OrgDomainIDPRoutingForceCol.identifier(),
+	}
+}
+
+type orgDomainIDPRoutingScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOrgDomainIDPRouting(row orgDomainIDPRoutingScanner) (*OrgDomainIDPRouting, error) {
+	routing := new(OrgDomainIDPRouting)
+	var idpIDs database.TextArray[string]
+	err := row.Scan(
+		&routing.OrgID,
+		&routing.Domain,
+		&routing.CreationDate,
+		&routing.ChangeDate,
+		&routing.Sequence,
+		&idpIDs,
+		&routing.Force,
+	)
+	if err != nil {
+		return nil, err
+	}
+	routing.IDPIDs = idpIDs
+	return routing, nil
+}
+
+func prepareOrgDomainIDPRoutingQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*OrgDomainIDPRouting, error)) {
+	return sq.Select(orgDomainIDPRoutingColumns()...).
+			From(orgDomainIDPRoutingTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*OrgDomainIDPRouting, error) {
+			routing, err := scanOrgDomainIDPRouting(row)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Ji4ie", "Errors.Org.Domain.IDPRouting.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ug3ae", "Errors.Internal")
+			}
+			return routing, nil
+		}
+}
+
+func prepareOrgDomainIDPRoutingsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*OrgDomainIDPRouting, error)) {
+	return sq.Select(orgDomainIDPRoutingColumns()...).
+			From(orgDomainIDPRoutingTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*OrgDomainIDPRouting, error) {
+			routings := make([]*OrgDomainIDPRouting, 0)
+			for rows.Next() {
+				routing, err := scanOrgDomainIDPRouting(rows)
+				if err != nil {
+					return nil, err
+				}
+				routings = append(routings, routing)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Quo4i", "Errors.Query.CloseRows")
+			}
+			return routings, nil
+		}
+}
@@ -0,0 +1,172 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	orgDomainIDPRoutingQuery = `SELECT projections.org_domain_idp_routings.org_id,` +
+		` projections.org_domain_idp_routings.domain,` +
+		` projections.org_domain_idp_routings.creation_date,` +
+		` projections.org_domain_idp_routings.change_date,` +
+		` projections.org_domain_idp_routings.sequence,` +
+		` projections.org_domain_idp_routings.idp_ids,` +
+		` projections.org_domain_idp_routings.force` +
+		` FROM projections.org_domain_idp_routings` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	orgDomainIDPRoutingCols = []string{
+		"org_id",
+		"domain",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"idp_ids",
+		"force",
+	}
+)
+
+func Test_OrgDomainIDPRoutingPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareOrgDomainIDPRoutingQuery no result",
+			prepare: prepareOrgDomainIDPRoutingQuery,
+			want: want{
+				sqlExpectations: mockQueryScanErr(
+					regexp.QuoteMeta(orgDomainIDPRoutingQuery),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*OrgDomainIDPRouting)(nil),
+		},
+		{
+			name:    "prepareOrgDomainIDPRoutingQuery found",
+			prepare: prepareOrgDomainIDPRoutingQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(orgDomainIDPRoutingQuery),
+					orgDomainIDPRoutingCols,
+					[]driver.Value{
+						"org-id",
+						"partner.com",
+						testNow,
+						testNow,
+						uint64(20211108),
+						database.TextArray[string]{"idp-1", "idp-2"},
+						true,
+					},
+				),
+			},
+			object: &OrgDomainIDPRouting{
+				OrgID:        "org-id",
+				Domain:       "partner.com",
+				CreationDate: testNow,
+				ChangeDate:   testNow,
+				Sequence:     20211108,
+				OrgDomainIDPRouting: domain.OrgDomainIDPRouting{
+					IDPIDs: []string{"idp-1", "idp-2"},
+					Force:  true,
+				},
+			},
+		},
+		{
+			name:    "prepareOrgDomainIDPRoutingsQuery found",
+			prepare: prepareOrgDomainIDPRoutingsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(orgDomainIDPRoutingQuery),
+					orgDomainIDPRoutingCols,
+					[][]driver.Value{
+						{
+							"org-id",
+							"partner.com",
+							testNow,
+							testNow,
+							uint64(20211108),
+							database.TextArray[string]{"idp-1"},
+							false,
+						},
+						{
+							"org-id",
+							"partner.ch",
+							testNow,
+							testNow,
+							uint64(20211109),
+							database.TextArray[string]{"idp-2"},
+							true,
+						},
+					},
+				),
+			},
+			object: []*OrgDomainIDPRouting{
+				{
+					OrgID:        "org-id",
+					Domain:       "partner.com",
+					CreationDate: testNow,
+					ChangeDate:   testNow,
+					Sequence:     20211108,
+					OrgDomainIDPRouting: domain.OrgDomainIDPRouting{
+						IDPIDs: []string{"idp-1"},
+					},
+				},
+				{
+					OrgID:        "org-id",
+					Domain:       "partner.ch",
+					CreationDate: testNow,
+					ChangeDate:   testNow,
+					Sequence:     20211109,
+					OrgDomainIDPRouting: domain.OrgDomainIDPRouting{
+						IDPIDs: []string{"idp-2"},
+						Force:  true,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareOrgDomainIDPRoutingsQuery sql err",
+			prepare: prepareOrgDomainIDPRoutingsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(orgDomainIDPRoutingQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]*OrgDomainIDPRouting)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package query
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// PersonalDataErasure reports the personal data of an aggregate which is unreadable since its data key was erased
+type PersonalDataErasure struct {
+	AggregateType string
+	AggregateID   string
+	ErasedAt      time.Time
+	ErasedBy      string
+	Events        []*ErasedPersonalData
+}
+
+// ErasedPersonalData are the fields of the events of an event type which are unreadable
+type ErasedPersonalData struct {
+	EventType string
+	Count     uint64
+	// PlaintextCount is the count of the events pushed before the personal data was encrypted,
+	// their fields are still readable
+	PlaintextCount uint64
+	Fields         []string
+}
+
+// PersonalDataErasures returns the erasures of the personal data of the instance, newest first
+func (q *Queries) PersonalDataErasures(ctx context.Context) (_ []*PersonalDataErasure, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	erasures, err := q.eventstore.PersonalDataErasures(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*PersonalDataErasure, len(erasures))
+	for i, erasure := range erasures {
+		result[i] = personalDataErasureFromEventstore(erasure)
+	}
+	return result, nil
+}
+
+func personalDataErasureFromEventstore(erasure *eventstore.PersonalDataErasure) *PersonalDataErasure {
+	events := make([]*ErasedPersonalData, 0, len(erasure.Events)+len(erasure.PlaintextEvents))
+	byType := make(map[eventstore.EventType]*ErasedPersonalData, cap(events))
+	eventsOfType := func(eventType eventstore.EventType) *ErasedPersonalData {
+		if event, ok := byType[eventType]; ok {
+			return event
+		}
+		event := &ErasedPersonalData{
+			EventType: string(eventType),
+			Fields:    eventstore.PersonalDataFields(eventType),
+		}
+		byType[eventType] = event
+		events = append(events, event)
+		return event
+	}
+	for eventType, count := range erasure.Events {
+		eventsOfType(eventType).Count = count
+	}
+	for eventType, count := range erasure.PlaintextEvents {
+		eventsOfType(eventType).PlaintextCount = count
+	}
+	slices.SortFunc(events, func(a, b *ErasedPersonalData) int {
+		return strings.Compare(a.EventType, b.EventType)
+	})
+	return &PersonalDataErasure{
+		AggregateType: string(erasure.AggregateType),
+		AggregateID:   erasure.AggregateID,
+		ErasedAt:      erasure.ErasedAt,
+		ErasedBy:      erasure.ErasedBy,
+		Events:        events,
+	}
+}
@@ -0,0 +1,54 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func Test_personalDataErasureFromEventstore(t *testing.T) {
+	eventType := eventstore.EventType("test.personal_data.erased")
+	eventstore.RegisterPersonalData(eventType, "firstName", "email")
+	erasedAt := time.Now()
+
+	got := personalDataErasureFromEventstore(&eventstore.PersonalDataErasure{
+		InstanceID:    "instance",
+		AggregateType: "user",
+		AggregateID:   "user1",
+		ErasedAt:      erasedAt,
+		ErasedBy:      "admin",
+		Events: map[eventstore.EventType]uint64{
+			eventType:           2,
+			"test.unregistered": 1,
+		},
+		PlaintextEvents: map[eventstore.EventType]uint64{
+			eventType:     1,
+			"test.legacy": 3,
+		},
+	})
+	assert.Equal(t, &PersonalDataErasure{
+		AggregateType: "user",
+		AggregateID:   "user1",
+		ErasedAt:      erasedAt,
+		ErasedBy:      "admin",
+		Events: []*ErasedPersonalData{
+			{
+				EventType:      "test.legacy",
+				PlaintextCount: 3,
+			},
+			{
+				EventType:      string(eventType),
+				Count:          2,
+				PlaintextCount: 1,
+				Fields:         []string{"firstName", "email"},
+			},
+			{
+				EventType: "test.unregistered",
+				Count:     1,
+			},
+		},
+	}, got)
+}
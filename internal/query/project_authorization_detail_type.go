@@ -0,0 +1,247 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	authorizationDetailTypesTable = table{
+		name:          projection.ProjectAuthorizationDetailTypeProjectionTable,
+		instanceIDCol: projection.ProjectAuthorizationDetailTypeColumnInstanceID,
+	}
+	AuthorizationDetailTypeColumnCreationDate = Column{
+		name:  projection.ProjectAuthorizationDetailTypeColumnCreationDate,
+		table: authorizationDetailTypesTable,
+	}
+	AuthorizationDetailTypeColumnChangeDate = Column{
+		name:  projection.ProjectAuthorizationDetailTypeColumnChangeDate,
+		table: authorizationDetailTypesTable,
+	}
+	AuthorizationDetailTypeColumnResourceOwner = Column{
+		name:  projection.ProjectAuthorizationDetailTypeColumnResourceOwner,
+		table: authorizationDetailTypesTable,
+	}
+	AuthorizationDetailTypeColumnInstanceID = Column{
+		name:  projection.ProjectAuthorizationDetailTypeColumnInstanceID,
+		table: authorizationDetailTypesTable,
+	}
+	AuthorizationDetailTypeColumnSequence = Column{
+		name:  projection.ProjectAuthorizationDetailTypeColumnSequence,
+		table: authorizationDetailTypesTable,
+	}
+	AuthorizationDetailTypeColumnProjectID = Column{
+		name:  projection.ProjectAuthorizationDetailTypeColumnProjectID,
+		table: authorizationDetailTypesTable,
+	}
+	AuthorizationDetailTypeColumnType = Column{
+		name:  projection.ProjectAuthorizationDetailTypeColumnType,
+		table: authorizationDetailTypesTable,
+	}
+	AuthorizationDetailTypeColumnDisplayName = Column{
+		name:  projection.ProjectAuthorizationDetailTypeColumnDisplayName,
+		table: authorizationDetailTypesTable,
+	}
+	AuthorizationDetailTypeColumnActions = Column{
+		name:  projection.ProjectAuthorizationDetailTypeColumnActions,
+		table: authorizationDetailTypesTable,
+	}
+	AuthorizationDetailTypeColumnLocations = Column{
+		name:  projection.ProjectAuthorizationDetailTypeColumnLocations,
+		table: authorizationDetailTypesTable,
+	}
+)
+
+type AuthorizationDetailTypes struct {
+	SearchResponse
+	AuthorizationDetailTypes []*AuthorizationDetailType
+}
+
+type AuthorizationDetailType struct {
+	ProjectID     string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	Type        string
+	DisplayName string
+	Actions     database.TextArray[string]
+	Locations   database.TextArray[string]
+}
+
+// ToDomain returns the registered types, so they can be used to validate requested authorization details.
+func (t *AuthorizationDetailTypes) ToDomain() []*domain.AuthorizationDetailType {
+	types := make([]*domain.AuthorizationDetailType, len(t.AuthorizationDetailTypes))
+	for i, detailType := range t.AuthorizationDetailTypes {
+		types[i] = &domain.AuthorizationDetailType{
+			ObjectRoot: models.ObjectRoot{
+				AggregateID:   detailType.ProjectID,
+				ResourceOwner: detailType.ResourceOwner,
+				Sequence:      detailType.Sequence,
+				CreationDate:  detailType.CreationDate,
+				ChangeDate:    detailType.ChangeDate,
+			},
+			Type:        detailType.Type,
+			DisplayName: detailType.DisplayName,
+			Actions:     detailType.Actions,
+			Locations:   detailType.Locations,
+		}
+	}
+	return types
+}
+
+type AuthorizationDetailTypeSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *Queries) SearchAuthorizationDetailTypes(ctx context.Context, shouldTriggerBulk bool, queries *AuthorizationDetailTypeSearchQueries) (types *AuthorizationDetailTypes, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	ctx = database.WithCallType(ctx, database.CallTypeList)
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerAuthorizationDetailTypeProjection")
+		ctx, err = projection.AuthorizationDetailTypeProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+
+	eq := sq.Eq{AuthorizationDetailTypeColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+
+	query, scan := prepareAuthorizationDetailTypesQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-Eeth8", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		types, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-ohD4u", "Errors.Internal")
+	}
+	types.State, err = q.latestState(ctx, authorizationDetailTypesTable)
+	return types, err
+}
+
+// AuthorizationDetailTypesByProjectID returns all types registered on the project.
+func (q *Queries) AuthorizationDetailTypesByProjectID(ctx context.Context, projectID string) (_ *AuthorizationDetailTypes, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	queries := new(AuthorizationDetailTypeSearchQueries)
+	if err = queries.AppendProjectIDQuery(projectID); err != nil {
+		return nil, err
+	}
+	return q.SearchAuthorizationDetailTypes(ctx, false, queries)
+}
+
+func NewAuthorizationDetailTypeProjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(AuthorizationDetailTypeColumnProjectID, value, TextEquals)
+}
+
+func NewAuthorizationDetailTypeResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(AuthorizationDetailTypeColumnResourceOwner, value, TextEquals)
+}
+
+func NewAuthorizationDetailTypeTypeSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(AuthorizationDetailTypeColumnType, value, method)
+}
+
+func NewAuthorizationDetailTypeDisplayNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(AuthorizationDetailTypeColumnDisplayName, value, method)
+}
+
+func (r *AuthorizationDetailTypeSearchQueries) AppendProjectIDQuery(projectID string) error {
+	query, err := NewAuthorizationDetailTypeProjectIDSearchQuery(projectID)
+	if err != nil {
+		return err
+	}
+	r.Queries = append(r.Queries, query)
+	return nil
+}
+
+func (r *AuthorizationDetailTypeSearchQueries) AppendMyResourceOwnerQuery(orgID string) error {
+	query, err := NewAuthorizationDetailTypeResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return err
+	}
+	r.Queries = append(r.Queries, query)
+	return nil
+}
+
+func (q *AuthorizationDetailTypeSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func prepareAuthorizationDetailTypesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*AuthorizationDetailTypes, error)) {
+	return sq.Select(
+			AuthorizationDetailTypeColumnProjectID.identifier(),
+			AuthorizationDetailTypeColumnCreationDate.identifier(),
+			AuthorizationDetailTypeColumnChangeDate.identifier(),
+			AuthorizationDetailTypeColumnResourceOwner.identifier(),
+			AuthorizationDetailTypeColumnSequence.identifier(),
+			AuthorizationDetailTypeColumnType.identifier(),
+			AuthorizationDetailTypeColumnDisplayName.identifier(),
+			AuthorizationDetailTypeColumnActions.identifier(),
+			AuthorizationDetailTypeColumnLocations.identifier(),
+			countColumn.identifier()).
+			From(authorizationDetailTypesTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*AuthorizationDetailTypes, error) {
+			types := make([]*AuthorizationDetailType, 0)
+			var count uint64
+			for rows.Next() {
+				detailType := new(AuthorizationDetailType)
+				err := rows.Scan(
+					&detailType.ProjectID,
+					&detailType.CreationDate,
+					&detailType.ChangeDate,
+					&detailType.ResourceOwner,
+					&detailType.Sequence,
+					&detailType.Type,
+					&detailType.DisplayName,
+					&detailType.Actions,
+					&detailType.Locations,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				types = append(types, detailType)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ahc0u", "Errors.Query.CloseRows")
+			}
+
+			return &AuthorizationDetailTypes{
+				AuthorizationDetailTypes: types,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
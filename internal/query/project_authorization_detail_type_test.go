@@ -0,0 +1,128 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	prepareAuthorizationDetailTypesStmt = `SELECT projections.project_authorization_detail_types.project_id,` +
+		` projections.project_authorization_detail_types.creation_date,` +
+		` projections.project_authorization_detail_types.change_date,` +
+		` projections.project_authorization_detail_types.resource_owner,` +
+		` projections.project_authorization_detail_types.sequence,` +
+		` projections.project_authorization_detail_types.type,` +
+		` projections.project_authorization_detail_types.display_name,` +
+		` projections.project_authorization_detail_types.actions,` +
+		` projections.project_authorization_detail_types.locations,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.project_authorization_detail_types` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareAuthorizationDetailTypesCols = []string{
+		"project_id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"type",
+		"display_name",
+		"actions",
+		"locations",
+		"count",
+	}
+)
+
+func Test_AuthorizationDetailTypePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareAuthorizationDetailTypesQuery no result",
+			prepare: prepareAuthorizationDetailTypesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareAuthorizationDetailTypesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &AuthorizationDetailTypes{AuthorizationDetailTypes: []*AuthorizationDetailType{}},
+		},
+		{
+			name:    "prepareAuthorizationDetailTypesQuery one result",
+			prepare: prepareAuthorizationDetailTypesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareAuthorizationDetailTypesStmt),
+					prepareAuthorizationDetailTypesCols,
+					[][]driver.Value{
+						{
+							"project-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211111),
+							"payment_initiation",
+							"Payment",
+							database.TextArray[string]{"initiate"},
+							database.TextArray[string]{"https://bank.example.com"},
+						},
+					},
+				),
+			},
+			object: &AuthorizationDetailTypes{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				AuthorizationDetailTypes: []*AuthorizationDetailType{
+					{
+						ProjectID:     "project-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211111,
+						Type:          "payment_initiation",
+						DisplayName:   "Payment",
+						Actions:       database.TextArray[string]{"initiate"},
+						Locations:     database.TextArray[string]{"https://bank.example.com"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareAuthorizationDetailTypesQuery sql err",
+			prepare: prepareAuthorizationDetailTypesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareAuthorizationDetailTypesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AuthorizationDetailTypes)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -15,7 +15,7 @@ import (
 )
 
 const (
-	AppProjectionTable = "projections.apps6"
+	AppProjectionTable = "projections.apps7"
 	AppAPITable        = AppProjectionTable + "_" + appAPITableSuffix
 	AppOIDCTable       = AppProjectionTable + "_" + appOIDCTableSuffix
 	AppSAMLTable       = AppProjectionTable + "_" + appSAMLTableSuffix
@@ -57,6 +57,9 @@ const (
 	AppOIDCConfigColumnClockSkew                = "clock_skew"
 	AppOIDCConfigColumnAdditionalOrigins        = "additional_origins"
 	AppOIDCConfigColumnSkipNativeAppSuccessPage = "skip_native_app_success_page"
+	AppOIDCConfigColumnConsentRequired          = "consent_required"
+	AppOIDCConfigColumnTLSClientAuthSubjectDN   = "tls_client_auth_subject_dn"
+	AppOIDCConfigColumnRefreshTokenReuseGrace   = "refresh_token_reuse_grace_period"
 
 	appSAMLTableSuffix             = "saml_configs"
 	AppSAMLConfigColumnAppID       = "app_id"
@@ -64,6 +67,21 @@ const (
 	AppSAMLConfigColumnEntityID    = "entity_id"
 	AppSAMLConfigColumnMetadata    = "metadata"
 	AppSAMLConfigColumnMetadataURL = "metadata_url"
+
+	AppClaimMappingsTable               = AppProjectionTable + "_" + appClaimMappingsTableSuffix
+	appClaimMappingsTableSuffix         = "claim_mappings"
+	AppClaimMappingsColumnAppID         = "app_id"
+	AppClaimMappingsColumnInstanceID    = "instance_id"
+	AppClaimMappingsColumnClaimMappings = "claim_mappings"
+
+	AppSAMLSSOTable                     = AppProjectionTable + "_" + appSAMLSSOTableSuffix
+	appSAMLSSOTableSuffix               = "saml_sso"
+	AppSAMLSSOColumnAppID               = "app_id"
+	AppSAMLSSOColumnInstanceID          = "instance_id"
+	AppSAMLSSOColumnIDPInitiatedEnabled = "idp_initiated_enabled"
+	AppSAMLSSOColumnRelayState          = "relay_state"
+	AppSAMLSSOColumnWSFedEnabled        = "ws_fed_enabled"
+	AppSAMLSSOColumnWSFedReplyURLs      = "ws_fed_reply_urls"
 )
 
 type appProjection struct{}
@@ -124,6 +142,9 @@ func (*appProjection) Init() *old_handler.Check {
 			handler.NewColumn(AppOIDCConfigColumnClockSkew, handler.ColumnTypeInt64, handler.Default(0)),
 			handler.NewColumn(AppOIDCConfigColumnAdditionalOrigins, handler.ColumnTypeTextArray, handler.Nullable()),
 			handler.NewColumn(AppOIDCConfigColumnSkipNativeAppSuccessPage, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnConsentRequired, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnTLSClientAuthSubjectDN, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppOIDCConfigColumnRefreshTokenReuseGrace, handler.ColumnTypeInt64, handler.Default(0)),
 		},
 			handler.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -142,6 +163,27 @@ func (*appProjection) Init() *old_handler.Check {
 			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 			handler.WithIndex(handler.NewIndex("entity_id", []string{AppSAMLConfigColumnEntityID})),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(AppClaimMappingsColumnAppID, handler.ColumnTypeText),
+			handler.NewColumn(AppClaimMappingsColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(AppClaimMappingsColumnClaimMappings, handler.ColumnTypeJSONB),
+		},
+			handler.NewPrimaryKey(AppClaimMappingsColumnInstanceID, AppClaimMappingsColumnAppID),
+			appClaimMappingsTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(AppSAMLSSOColumnAppID, handler.ColumnTypeText),
+			handler.NewColumn(AppSAMLSSOColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(AppSAMLSSOColumnIDPInitiatedEnabled, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppSAMLSSOColumnRelayState, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppSAMLSSOColumnWSFedEnabled, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppSAMLSSOColumnWSFedReplyURLs, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(AppSAMLSSOColumnInstanceID, AppSAMLSSOColumnAppID),
+			appSAMLSSOTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 	)
 }
 
@@ -206,6 +248,14 @@ func (p *appProjection) Reducers() []handler.AggregateReducer {
 					Event:  project.SAMLConfigChangedType,
 					Reduce: p.reduceSAMLConfigChanged,
 				},
+				{
+					Event:  project.ApplicationClaimMappingsSetType,
+					Reduce: p.reduceClaimMappingsSet,
+				},
+				{
+					Event:  project.SAMLSSOSettingsSetType,
+					Reduce: p.reduceSAMLSSOSettingsSet,
+				},
 			},
 		},
 		{
@@ -464,6 +514,9 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnClockSkew, e.ClockSkew),
 				handler.NewCol(AppOIDCConfigColumnAdditionalOrigins, database.TextArray[string](e.AdditionalOrigins)),
 				handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, e.SkipNativeAppSuccessPage),
+				handler.NewCol(AppOIDCConfigColumnConsentRequired, e.ConsentRequired),
+				handler.NewCol(AppOIDCConfigColumnTLSClientAuthSubjectDN, e.TLSClientAuthSubjectDN),
+				handler.NewCol(AppOIDCConfigColumnRefreshTokenReuseGrace, e.RefreshTokenReuseGracePeriod),
 			},
 			handler.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -532,6 +585,15 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.SkipNativeAppSuccessPage != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, *e.SkipNativeAppSuccessPage))
 	}
+	if e.ConsentRequired != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnConsentRequired, *e.ConsentRequired))
+	}
+	if e.TLSClientAuthSubjectDN != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnTLSClientAuthSubjectDN, *e.TLSClientAuthSubjectDN))
+	}
+	if e.RefreshTokenReuseGracePeriod != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRefreshTokenReuseGrace, *e.RefreshTokenReuseGracePeriod))
+	}
 
 	if len(cols) == 0 {
 		return handler.NewNoOpStatement(e), nil
@@ -678,3 +740,83 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 		),
 	), nil
 }
+
+func (p *appProjection) reduceClaimMappingsSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ApplicationClaimMappingsSetEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ou3ie", "reduce.wrong.event.type %s", project.ApplicationClaimMappingsSetType)
+	}
+
+	claimMappingsStatement := handler.AddDeleteStatement(
+		[]handler.Condition{
+			handler.NewCond(AppClaimMappingsColumnAppID, e.AppID),
+			handler.NewCond(AppClaimMappingsColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		handler.WithTableSuffix(appClaimMappingsTableSuffix),
+	)
+	if len(e.ClaimMappings) > 0 {
+		claimMappingsStatement = handler.AddUpsertStatement(
+			[]handler.Column{
+				handler.NewCol(AppClaimMappingsColumnInstanceID, nil),
+				handler.NewCol(AppClaimMappingsColumnAppID, nil),
+			},
+			[]handler.Column{
+				handler.NewCol(AppClaimMappingsColumnAppID, e.AppID),
+				handler.NewCol(AppClaimMappingsColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewJSONCol(AppClaimMappingsColumnClaimMappings, e.ClaimMappings),
+			},
+			handler.WithTableSuffix(appClaimMappingsTableSuffix),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		claimMappingsStatement,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(AppColumnChangeDate, e.CreationDate()),
+				handler.NewCol(AppColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(AppColumnID, e.AppID),
+				handler.NewCond(AppColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
+func (p *appProjection) reduceSAMLSSOSettingsSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.SAMLSSOSettingsSetEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ahl4e", "reduce.wrong.event.type %s", project.SAMLSSOSettingsSetType)
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpsertStatement(
+			[]handler.Column{
+				handler.NewCol(AppSAMLSSOColumnInstanceID, nil),
+				handler.NewCol(AppSAMLSSOColumnAppID, nil),
+			},
+			[]handler.Column{
+				handler.NewCol(AppSAMLSSOColumnAppID, e.AppID),
+				handler.NewCol(AppSAMLSSOColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(AppSAMLSSOColumnIDPInitiatedEnabled, e.IDPInitiatedEnabled),
+				handler.NewCol(AppSAMLSSOColumnRelayState, e.RelayState),
+				handler.NewCol(AppSAMLSSOColumnWSFedEnabled, e.WSFedEnabled),
+				handler.NewCol(AppSAMLSSOColumnWSFedReplyURLs, database.TextArray[string](e.WSFedReplyURLs)),
+			},
+			handler.WithTableSuffix(appSAMLSSOTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(AppColumnChangeDate, e.CreationDate()),
+				handler.NewCol(AppColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(AppColumnID, e.AppID),
+				handler.NewCond(AppColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
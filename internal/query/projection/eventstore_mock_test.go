@@ -17,6 +17,7 @@ type mockEventStore struct {
 	filterCounter       int
 	pushResponse        [][]eventstore.Event
 	pushCounter         int
+	latestSequence      float64
 }
 
 func newMockEventStore() *mockEventStore {
@@ -49,3 +50,7 @@ func (m *mockEventStore) Push(ctx context.Context, cmds ...eventstore.Command) (
 	m.pushCounter++
 	return m.pushResponse[m.pushCounter-1], nil
 }
+
+func (m *mockEventStore) LatestSequence(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) (float64, error) {
+	return m.latestSequence, nil
+}
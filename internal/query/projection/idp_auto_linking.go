@@ -0,0 +1,167 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	IDPAutoLinkingTable = "projections.idp_auto_linkings"
+
+	IDPAutoLinkingIDPIDCol         = "idp_id"
+	IDPAutoLinkingCreationDateCol  = "creation_date"
+	IDPAutoLinkingChangeDateCol    = "change_date"
+	IDPAutoLinkingSequenceCol      = "sequence"
+	IDPAutoLinkingResourceOwnerCol = "resource_owner"
+	IDPAutoLinkingInstanceIDCol    = "instance_id"
+	IDPAutoLinkingOptionCol        = "option"
+	IDPAutoLinkingTrustLevelCol    = "trust_level"
+)
+
+type idpAutoLinkingProjection struct{}
+
+func newIDPAutoLinkingProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(idpAutoLinkingProjection))
+}
+
+func (*idpAutoLinkingProjection) Name() string {
+	return IDPAutoLinkingTable
+}
+
+func (*idpAutoLinkingProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(IDPAutoLinkingIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPAutoLinkingCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPAutoLinkingChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPAutoLinkingSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(IDPAutoLinkingResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPAutoLinkingInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPAutoLinkingOptionCol, handler.ColumnTypeEnum, handler.Default(0)),
+			handler.NewColumn(IDPAutoLinkingTrustLevelCol, handler.ColumnTypeEnum, handler.Default(0)),
+		},
+			handler.NewPrimaryKey(IDPAutoLinkingInstanceIDCol, IDPAutoLinkingIDPIDCol),
+		),
+	)
+}
+
+func (p *idpAutoLinkingProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.IDPAutoLinkingSetEventType,
+					Reduce: p.reduceAutoLinkingSet,
+				},
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(IDPAutoLinkingInstanceIDCol),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.IDPAutoLinkingSetEventType,
+					Reduce: p.reduceAutoLinkingSet,
+				},
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *idpAutoLinkingProjection) reduceAutoLinkingSet(event eventstore.Event) (*handler.Statement, error) {
+	var linkingEvent idp.AutoLinkingSetEvent
+	switch e := event.(type) {
+	case *org.IDPAutoLinkingSetEvent:
+		linkingEvent = e.AutoLinkingSetEvent
+	case *instance.IDPAutoLinkingSetEvent:
+		linkingEvent = e.AutoLinkingSetEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Thai9", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPAutoLinkingSetEventType, instance.IDPAutoLinkingSetEventType})
+	}
+
+	if !linkingEvent.IsEnabled() {
+		return handler.NewDeleteStatement(
+			&linkingEvent,
+			[]handler.Condition{
+				handler.NewCond(IDPAutoLinkingIDPIDCol, linkingEvent.ID),
+				handler.NewCond(IDPAutoLinkingInstanceIDCol, linkingEvent.Aggregate().InstanceID),
+			},
+		), nil
+	}
+
+	return handler.NewUpsertStatement(
+		&linkingEvent,
+		[]handler.Column{
+			handler.NewCol(IDPAutoLinkingInstanceIDCol, nil),
+			handler.NewCol(IDPAutoLinkingIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(IDPAutoLinkingIDPIDCol, linkingEvent.ID),
+			handler.NewCol(IDPAutoLinkingInstanceIDCol, linkingEvent.Aggregate().InstanceID),
+			handler.NewCol(IDPAutoLinkingResourceOwnerCol, linkingEvent.Aggregate().ResourceOwner),
+			handler.NewCol(IDPAutoLinkingCreationDateCol, handler.OnlySetValueOnInsert(IDPAutoLinkingTable, linkingEvent.CreationDate())),
+			handler.NewCol(IDPAutoLinkingChangeDateCol, linkingEvent.CreationDate()),
+			handler.NewCol(IDPAutoLinkingSequenceCol, linkingEvent.Sequence()),
+			handler.NewCol(IDPAutoLinkingOptionCol, linkingEvent.Option),
+			handler.NewCol(IDPAutoLinkingTrustLevelCol, linkingEvent.TrustLevel),
+		},
+	), nil
+}
+
+func (p *idpAutoLinkingProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var removedEvent idp.RemovedEvent
+	switch e := event.(type) {
+	case *org.IDPRemovedEvent:
+		removedEvent = e.RemovedEvent
+	case *instance.IDPRemovedEvent:
+		removedEvent = e.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-xoo6E", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRemovedEventType, instance.IDPRemovedEventType})
+	}
+
+	return handler.NewDeleteStatement(
+		&removedEvent,
+		[]handler.Condition{
+			handler.NewCond(IDPAutoLinkingIDPIDCol, removedEvent.ID),
+			handler.NewCond(IDPAutoLinkingInstanceIDCol, removedEvent.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *idpAutoLinkingProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ue5ph", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(IDPAutoLinkingInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(IDPAutoLinkingResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
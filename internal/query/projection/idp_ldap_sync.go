@@ -0,0 +1,315 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	LDAPSyncTable    = "projections.idp_ldap_syncs"
+	LDAPSyncRunTable = LDAPSyncTable + "_" + ldapSyncRunTableSuffix
+
+	LDAPSyncIDPIDCol             = "idp_id"
+	LDAPSyncCreationDateCol      = "creation_date"
+	LDAPSyncChangeDateCol        = "change_date"
+	LDAPSyncSequenceCol          = "sequence"
+	LDAPSyncResourceOwnerCol     = "resource_owner"
+	LDAPSyncInstanceIDCol        = "instance_id"
+	LDAPSyncEnabledCol           = "enabled"
+	LDAPSyncIntervalCol          = "interval"
+	LDAPSyncFilterCol            = "filter"
+	LDAPSyncOrgIDCol             = "org_id"
+	LDAPSyncDeactivateMissingCol = "deactivate_missing"
+	LDAPSyncNestedGroupsCol      = "nested_groups"
+	LDAPSyncGroupMappingsCol     = "group_mappings"
+	LDAPSyncLastRunStartedAtCol  = "last_run_started_at"
+	LDAPSyncLastRunFinishedAtCol = "last_run_finished_at"
+	LDAPSyncLastRunErrorCol      = "last_run_error"
+
+	ldapSyncRunTableSuffix      = "runs"
+	LDAPSyncRunIDPIDCol         = "idp_id"
+	LDAPSyncRunInstanceIDCol    = "instance_id"
+	LDAPSyncRunResourceOwnerCol = "resource_owner"
+	LDAPSyncRunSequenceCol      = "sequence"
+	LDAPSyncRunStartedAtCol     = "started_at"
+	LDAPSyncRunFinishedAtCol    = "finished_at"
+	LDAPSyncRunUsersFoundCol    = "users_found"
+	LDAPSyncRunCreatedCol       = "created"
+	LDAPSyncRunUpdatedCol       = "updated"
+	LDAPSyncRunDeactivatedCol   = "deactivated"
+	LDAPSyncRunReactivatedCol   = "reactivated"
+	LDAPSyncRunGrantsAddedCol   = "grants_added"
+	LDAPSyncRunGrantsChangedCol = "grants_changed"
+	LDAPSyncRunGrantsRemovedCol = "grants_removed"
+	LDAPSyncRunFailedCol        = "failed"
+	LDAPSyncRunErrorCol         = "error"
+)
+
+type ldapSyncProjection struct{}
+
+func newLDAPSyncProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(ldapSyncProjection))
+}
+
+func (*ldapSyncProjection) Name() string {
+	return LDAPSyncTable
+}
+
+func (*ldapSyncProjection) Init() *old_handler.Check {
+	return handler.NewMultiTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(LDAPSyncIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncEnabledCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(LDAPSyncIntervalCol, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncFilterCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(LDAPSyncOrgIDCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(LDAPSyncDeactivateMissingCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(LDAPSyncNestedGroupsCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(LDAPSyncGroupMappingsCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(LDAPSyncLastRunStartedAtCol, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(LDAPSyncLastRunFinishedAtCol, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(LDAPSyncLastRunErrorCol, handler.ColumnTypeText, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(LDAPSyncInstanceIDCol, LDAPSyncIDPIDCol),
+			handler.WithIndex(handler.NewIndex("enabled", []string{LDAPSyncInstanceIDCol, LDAPSyncEnabledCol})),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(LDAPSyncRunIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncRunInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncRunResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncRunSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncRunStartedAtCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncRunFinishedAtCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncRunUsersFoundCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunCreatedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunUpdatedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunDeactivatedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunReactivatedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunGrantsAddedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunGrantsChangedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunGrantsRemovedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunFailedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunErrorCol, handler.ColumnTypeText, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(LDAPSyncRunInstanceIDCol, LDAPSyncRunIDPIDCol, LDAPSyncRunSequenceCol),
+			ldapSyncRunTableSuffix,
+		),
+	)
+}
+
+func (p *ldapSyncProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.LDAPSyncSetEventType,
+					Reduce: p.reduceLDAPSyncSet,
+				},
+				{
+					Event:  instance.LDAPSyncFinishedEventType,
+					Reduce: p.reduceLDAPSyncFinished,
+				},
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: p.reduceInstanceRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.LDAPSyncSetEventType,
+					Reduce: p.reduceLDAPSyncSet,
+				},
+				{
+					Event:  org.LDAPSyncFinishedEventType,
+					Reduce: p.reduceLDAPSyncFinished,
+				},
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *ldapSyncProjection) reduceLDAPSyncSet(event eventstore.Event) (*handler.Statement, error) {
+	var syncEvent idp.LDAPSyncSetEvent
+	switch e := event.(type) {
+	case *org.LDAPSyncSetEvent:
+		syncEvent = e.LDAPSyncSetEvent
+	case *instance.LDAPSyncSetEvent:
+		syncEvent = e.LDAPSyncSetEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Lah5i", "reduce.wrong.event.type %v", []eventstore.EventType{org.LDAPSyncSetEventType, instance.LDAPSyncSetEventType})
+	}
+
+	return handler.NewUpsertStatement(
+		&syncEvent,
+		[]handler.Column{
+			handler.NewCol(LDAPSyncInstanceIDCol, nil),
+			handler.NewCol(LDAPSyncIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(LDAPSyncIDPIDCol, syncEvent.ID),
+			handler.NewCol(LDAPSyncInstanceIDCol, syncEvent.Aggregate().InstanceID),
+			handler.NewCol(LDAPSyncResourceOwnerCol, syncEvent.Aggregate().ResourceOwner),
+			handler.NewCol(LDAPSyncCreationDateCol, handler.OnlySetValueOnInsert(LDAPSyncTable, syncEvent.CreationDate())),
+			handler.NewCol(LDAPSyncChangeDateCol, syncEvent.CreationDate()),
+			handler.NewCol(LDAPSyncSequenceCol, syncEvent.Sequence()),
+			handler.NewCol(LDAPSyncEnabledCol, syncEvent.Enabled),
+			handler.NewCol(LDAPSyncIntervalCol, syncEvent.Interval),
+			handler.NewCol(LDAPSyncFilterCol, syncEvent.Filter),
+			handler.NewCol(LDAPSyncOrgIDCol, syncEvent.OrgID),
+			handler.NewCol(LDAPSyncDeactivateMissingCol, syncEvent.DeactivateMissing),
+			handler.NewCol(LDAPSyncNestedGroupsCol, syncEvent.NestedGroups),
+			handler.NewJSONCol(LDAPSyncGroupMappingsCol, syncEvent.GroupMappings),
+		},
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceLDAPSyncFinished(event eventstore.Event) (*handler.Statement, error) {
+	var finishedEvent idp.LDAPSyncFinishedEvent
+	switch e := event.(type) {
+	case *org.LDAPSyncFinishedEvent:
+		finishedEvent = e.LDAPSyncFinishedEvent
+	case *instance.LDAPSyncFinishedEvent:
+		finishedEvent = e.LDAPSyncFinishedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Eeph3", "reduce.wrong.event.type %v", []eventstore.EventType{org.LDAPSyncFinishedEventType, instance.LDAPSyncFinishedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&finishedEvent,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(LDAPSyncLastRunStartedAtCol, finishedEvent.StartedAt),
+				handler.NewCol(LDAPSyncLastRunFinishedAtCol, finishedEvent.CreationDate()),
+				handler.NewCol(LDAPSyncLastRunErrorCol, finishedEvent.Error),
+			},
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncIDPIDCol, finishedEvent.ID),
+				handler.NewCond(LDAPSyncInstanceIDCol, finishedEvent.Aggregate().InstanceID),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(LDAPSyncRunIDPIDCol, finishedEvent.ID),
+				handler.NewCol(LDAPSyncRunInstanceIDCol, finishedEvent.Aggregate().InstanceID),
+				handler.NewCol(LDAPSyncRunResourceOwnerCol, finishedEvent.Aggregate().ResourceOwner),
+				handler.NewCol(LDAPSyncRunSequenceCol, finishedEvent.Sequence()),
+				handler.NewCol(LDAPSyncRunStartedAtCol, finishedEvent.StartedAt),
+				handler.NewCol(LDAPSyncRunFinishedAtCol, finishedEvent.CreationDate()),
+				handler.NewCol(LDAPSyncRunUsersFoundCol, finishedEvent.UsersFound),
+				handler.NewCol(LDAPSyncRunCreatedCol, finishedEvent.Created),
+				handler.NewCol(LDAPSyncRunUpdatedCol, finishedEvent.Updated),
+				handler.NewCol(LDAPSyncRunDeactivatedCol, finishedEvent.Deactivated),
+				handler.NewCol(LDAPSyncRunReactivatedCol, finishedEvent.Reactivated),
+				handler.NewCol(LDAPSyncRunGrantsAddedCol, finishedEvent.GrantsAdded),
+				handler.NewCol(LDAPSyncRunGrantsChangedCol, finishedEvent.GrantsChanged),
+				handler.NewCol(LDAPSyncRunGrantsRemovedCol, finishedEvent.GrantsRemoved),
+				handler.NewCol(LDAPSyncRunFailedCol, finishedEvent.Failed),
+				handler.NewCol(LDAPSyncRunErrorCol, finishedEvent.Error),
+			},
+			handler.WithTableSuffix(ldapSyncRunTableSuffix),
+		),
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var removedEvent idp.RemovedEvent
+	switch e := event.(type) {
+	case *org.IDPRemovedEvent:
+		removedEvent = e.RemovedEvent
+	case *instance.IDPRemovedEvent:
+		removedEvent = e.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Shu4a", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRemovedEventType, instance.IDPRemovedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&removedEvent,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncIDPIDCol, removedEvent.ID),
+				handler.NewCond(LDAPSyncInstanceIDCol, removedEvent.Aggregate().InstanceID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncRunIDPIDCol, removedEvent.ID),
+				handler.NewCond(LDAPSyncRunInstanceIDCol, removedEvent.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(ldapSyncRunTableSuffix),
+		),
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-ieN6a", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(LDAPSyncResourceOwnerCol, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncRunInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(LDAPSyncRunResourceOwnerCol, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(ldapSyncRunTableSuffix),
+		),
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceInstanceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.InstanceRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Xai9o", "reduce.wrong.event.type %s", instance.InstanceRemovedEventType)
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncInstanceIDCol, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncRunInstanceIDCol, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(ldapSyncRunTableSuffix),
+		),
+	), nil
+}
@@ -0,0 +1,245 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestLDAPSyncProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceLDAPSyncSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.LDAPSyncSetEventType,
+						instance.AggregateType,
+						[]byte(`{
+	"id": "idp-id",
+	"enabled": true,
+	"interval": 3600000000000,
+	"filter": "(department=IT)",
+	"orgId": "org-id",
+	"deactivateMissing": true,
+	"nestedGroups": true,
+	"groupMappings": [{"groupDN": "cn=admins,dc=example,dc=com", "projectId": "project-id", "roleKeys": ["admin"]}]
+}`),
+					), instance.LDAPSyncSetEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceLDAPSyncSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs (idp_id, instance_id, resource_owner, creation_date, change_date, sequence, enabled, interval, filter, org_id, deactivate_missing, nested_groups, group_mappings) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, enabled, interval, filter, org_id, deactivate_missing, nested_groups, group_mappings) = (EXCLUDED.resource_owner, projections.idp_ldap_syncs.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.enabled, EXCLUDED.interval, EXCLUDED.filter, EXCLUDED.org_id, EXCLUDED.deactivate_missing, EXCLUDED.nested_groups, EXCLUDED.group_mappings)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								true,
+								time.Hour,
+								"(department=IT)",
+								"org-id",
+								true,
+								true,
+								[]byte(`[{"groupDN":"cn=admins,dc=example,dc=com","projectId":"project-id","roleKeys":["admin"]}]`),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceLDAPSyncFinished",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.LDAPSyncFinishedEventType,
+						org.AggregateType,
+						[]byte(`{
+	"id": "idp-id",
+	"startedAt": "2024-01-01T00:00:00Z",
+	"usersFound": 10,
+	"created": 2,
+	"updated": 3,
+	"deactivated": 1,
+	"grantsAdded": 2,
+	"failed": 1
+}`),
+					), org.LDAPSyncFinishedEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceLDAPSyncFinished,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idp_ldap_syncs SET (last_run_started_at, last_run_finished_at, last_run_error) = ($1, $2, $3) WHERE (idp_id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+								anyArg{},
+								"",
+								"idp-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs_runs (idp_id, instance_id, resource_owner, sequence, started_at, finished_at, users_found, created, updated, deactivated, reactivated, grants_added, grants_changed, grants_removed, failed, error) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"ro-id",
+								uint64(15),
+								time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+								anyArg{},
+								uint32(10),
+								uint32(2),
+								uint32(3),
+								uint32(1),
+								uint32(0),
+								uint32(2),
+								uint32(0),
+								uint32(0),
+								uint32(1),
+								"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceIDPRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.IDPRemovedEventType,
+						org.AggregateType,
+						[]byte(`{"id": "idp-id"}`),
+					), org.IDPRemovedEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceIDPRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (idp_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs_runs WHERE (idp_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs_runs WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceInstanceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs_runs WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, LDAPSyncTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	IDPRoleMappingTable = "projections.idp_role_mappings"
+
+	IDPRoleMappingIDPIDCol         = "idp_id"
+	IDPRoleMappingCreationDateCol  = "creation_date"
+	IDPRoleMappingChangeDateCol    = "change_date"
+	IDPRoleMappingSequenceCol      = "sequence"
+	IDPRoleMappingResourceOwnerCol = "resource_owner"
+	IDPRoleMappingInstanceIDCol    = "instance_id"
+	IDPRoleMappingClaimCol         = "claim"
+	IDPRoleMappingModeCol          = "mode"
+	IDPRoleMappingRulesCol         = "rules"
+)
+
+type idpRoleMappingProjection struct{}
+
+func newIDPRoleMappingProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(idpRoleMappingProjection))
+}
+
+func (*idpRoleMappingProjection) Name() string {
+	return IDPRoleMappingTable
+}
+
+func (*idpRoleMappingProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(IDPRoleMappingIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPRoleMappingCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPRoleMappingChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPRoleMappingSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(IDPRoleMappingResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPRoleMappingInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPRoleMappingClaimCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(IDPRoleMappingModeCol, handler.ColumnTypeEnum, handler.Default(0)),
+			handler.NewColumn(IDPRoleMappingRulesCol, handler.ColumnTypeJSONB, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(IDPRoleMappingInstanceIDCol, IDPRoleMappingIDPIDCol),
+		),
+	)
+}
+
+func (p *idpRoleMappingProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.IDPRoleMappingSetEventType,
+					Reduce: p.reduceRoleMappingSet,
+				},
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(IDPRoleMappingInstanceIDCol),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.IDPRoleMappingSetEventType,
+					Reduce: p.reduceRoleMappingSet,
+				},
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *idpRoleMappingProjection) reduceRoleMappingSet(event eventstore.Event) (*handler.Statement, error) {
+	var mappingEvent idp.RoleMappingSetEvent
+	switch e := event.(type) {
+	case *org.IDPRoleMappingSetEvent:
+		mappingEvent = e.RoleMappingSetEvent
+	case *instance.IDPRoleMappingSetEvent:
+		mappingEvent = e.RoleMappingSetEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ahd0u", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRoleMappingSetEventType, instance.IDPRoleMappingSetEventType})
+	}
+
+	if !mappingEvent.IsEnabled() {
+		return handler.NewDeleteStatement(
+			&mappingEvent,
+			[]handler.Condition{
+				handler.NewCond(IDPRoleMappingIDPIDCol, mappingEvent.ID),
+				handler.NewCond(IDPRoleMappingInstanceIDCol, mappingEvent.Aggregate().InstanceID),
+			},
+		), nil
+	}
+
+	return handler.NewUpsertStatement(
+		&mappingEvent,
+		[]handler.Column{
+			handler.NewCol(IDPRoleMappingInstanceIDCol, nil),
+			handler.NewCol(IDPRoleMappingIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(IDPRoleMappingIDPIDCol, mappingEvent.ID),
+			handler.NewCol(IDPRoleMappingInstanceIDCol, mappingEvent.Aggregate().InstanceID),
+			handler.NewCol(IDPRoleMappingResourceOwnerCol, mappingEvent.Aggregate().ResourceOwner),
+			handler.NewCol(IDPRoleMappingCreationDateCol, handler.OnlySetValueOnInsert(IDPRoleMappingTable, mappingEvent.CreationDate())),
+			handler.NewCol(IDPRoleMappingChangeDateCol, mappingEvent.CreationDate()),
+			handler.NewCol(IDPRoleMappingSequenceCol, mappingEvent.Sequence()),
+			handler.NewCol(IDPRoleMappingClaimCol, mappingEvent.Claim),
+			handler.NewCol(IDPRoleMappingModeCol, mappingEvent.Mode),
+			handler.NewJSONCol(IDPRoleMappingRulesCol, mappingEvent.Rules),
+		},
+	), nil
+}
+
+func (p *idpRoleMappingProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var removedEvent idp.RemovedEvent
+	switch e := event.(type) {
+	case *org.IDPRemovedEvent:
+		removedEvent = e.RemovedEvent
+	case *instance.IDPRemovedEvent:
+		removedEvent = e.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-eiF4o", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRemovedEventType, instance.IDPRemovedEventType})
+	}
+
+	return handler.NewDeleteStatement(
+		&removedEvent,
+		[]handler.Condition{
+			handler.NewCond(IDPRoleMappingIDPIDCol, removedEvent.ID),
+			handler.NewCond(IDPRoleMappingInstanceIDCol, removedEvent.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *idpRoleMappingProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Aik8e", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(IDPRoleMappingInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(IDPRoleMappingResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,184 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestIDPRoleMappingProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceRoleMappingSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.IDPRoleMappingSetEventType,
+						instance.AggregateType,
+						[]byte(`{
+	"id": "idp-id",
+	"claim": "groups",
+	"mode": 2,
+	"rules": [{"value": "admins", "projectId": "project-id", "roleKeys": ["admin"]}]
+}`),
+					), instance.IDPRoleMappingSetEventMapper),
+			},
+			reduce: (&idpRoleMappingProjection{}).reduceRoleMappingSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_role_mappings (idp_id, instance_id, resource_owner, creation_date, change_date, sequence, claim, mode, rules) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, claim, mode, rules) = (EXCLUDED.resource_owner, projections.idp_role_mappings.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.claim, EXCLUDED.mode, EXCLUDED.rules)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"groups",
+								domain.IDPRoleMappingModeReconcile,
+								[]byte(`[{"value":"admins","projectId":"project-id","roleKeys":["admin"]}]`),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceRoleMappingSet, disabled",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.IDPRoleMappingSetEventType,
+						org.AggregateType,
+						[]byte(`{"id": "idp-id"}`),
+					), org.IDPRoleMappingSetEventMapper),
+			},
+			reduce: (&idpRoleMappingProjection{}).reduceRoleMappingSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_role_mappings WHERE (idp_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceIDPRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.IDPRemovedEventType,
+						org.AggregateType,
+						[]byte(`{"id": "idp-id"}`),
+					), org.IDPRemovedEventMapper),
+			},
+			reduce: (&idpRoleMappingProjection{}).reduceIDPRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_role_mappings WHERE (idp_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&idpRoleMappingProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_role_mappings WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(IDPRoleMappingInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_role_mappings WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, IDPRoleMappingTable, tt.want)
+		})
+	}
+}
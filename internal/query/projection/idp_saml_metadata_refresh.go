@@ -0,0 +1,297 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	SAMLMetadataRefreshTable = "projections.idp_saml_metadata_refreshes"
+
+	SAMLMetadataRefreshIDPIDCol             = "idp_id"
+	SAMLMetadataRefreshCreationDateCol      = "creation_date"
+	SAMLMetadataRefreshChangeDateCol        = "change_date"
+	SAMLMetadataRefreshSequenceCol          = "sequence"
+	SAMLMetadataRefreshResourceOwnerCol     = "resource_owner"
+	SAMLMetadataRefreshInstanceIDCol        = "instance_id"
+	SAMLMetadataRefreshEnabledCol           = "enabled"
+	SAMLMetadataRefreshURLCol               = "url"
+	SAMLMetadataRefreshMetadataCertCol      = "metadata_certificate"
+	SAMLMetadataRefreshIntervalCol          = "interval"
+	SAMLMetadataRefreshRolloverPeriodCol    = "rollover_period"
+	SAMLMetadataRefreshExpiryWarningCol     = "expiry_warning"
+	SAMLMetadataRefreshCertificatesCol      = "certificates"
+	SAMLMetadataRefreshLastRunStartedAtCol  = "last_run_started_at"
+	SAMLMetadataRefreshLastRunFinishedAtCol = "last_run_finished_at"
+	SAMLMetadataRefreshLastRunErrorCol      = "last_run_error"
+
+	SAMLCertificateExpirySuffix         = "certificate_expiries"
+	SAMLCertificateExpiryTable          = SAMLMetadataRefreshTable + "_" + SAMLCertificateExpirySuffix
+	SAMLCertificateExpiryIDPIDCol       = "idp_id"
+	SAMLCertificateExpiryInstanceIDCol  = "instance_id"
+	SAMLCertificateExpiryFingerprintCol = "fingerprint"
+	SAMLCertificateExpiryCertificateCol = "certificate"
+	SAMLCertificateExpiryNotAfterCol    = "not_after"
+	SAMLCertificateExpiryAlertedAtCol   = "alerted_at"
+)
+
+type samlMetadataRefreshProjection struct{}
+
+func newSAMLMetadataRefreshProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(samlMetadataRefreshProjection))
+}
+
+func (*samlMetadataRefreshProjection) Name() string {
+	return SAMLMetadataRefreshTable
+}
+
+func (*samlMetadataRefreshProjection) Init() *old_handler.Check {
+	return handler.NewMultiTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(SAMLMetadataRefreshIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(SAMLMetadataRefreshCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(SAMLMetadataRefreshChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(SAMLMetadataRefreshSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(SAMLMetadataRefreshResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(SAMLMetadataRefreshInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(SAMLMetadataRefreshEnabledCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(SAMLMetadataRefreshURLCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(SAMLMetadataRefreshMetadataCertCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(SAMLMetadataRefreshIntervalCol, handler.ColumnTypeInt64),
+			handler.NewColumn(SAMLMetadataRefreshRolloverPeriodCol, handler.ColumnTypeInt64),
+			handler.NewColumn(SAMLMetadataRefreshExpiryWarningCol, handler.ColumnTypeInt64),
+			handler.NewColumn(SAMLMetadataRefreshCertificatesCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SAMLMetadataRefreshLastRunStartedAtCol, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(SAMLMetadataRefreshLastRunFinishedAtCol, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(SAMLMetadataRefreshLastRunErrorCol, handler.ColumnTypeText, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(SAMLMetadataRefreshInstanceIDCol, SAMLMetadataRefreshIDPIDCol),
+			handler.WithIndex(handler.NewIndex("enabled", []string{SAMLMetadataRefreshInstanceIDCol, SAMLMetadataRefreshEnabledCol})),
+		),
+		// the alerts raised for expiring signing certificates, which are still accepted
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(SAMLCertificateExpiryIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(SAMLCertificateExpiryInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(SAMLCertificateExpiryFingerprintCol, handler.ColumnTypeText),
+			handler.NewColumn(SAMLCertificateExpiryCertificateCol, handler.ColumnTypeBytes),
+			handler.NewColumn(SAMLCertificateExpiryNotAfterCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(SAMLCertificateExpiryAlertedAtCol, handler.ColumnTypeTimestamp),
+		},
+			handler.NewPrimaryKey(SAMLCertificateExpiryInstanceIDCol, SAMLCertificateExpiryIDPIDCol, SAMLCertificateExpiryFingerprintCol),
+			SAMLCertificateExpirySuffix,
+			handler.WithForeignKey(handler.NewForeignKey(
+				"refresh",
+				[]string{SAMLCertificateExpiryInstanceIDCol, SAMLCertificateExpiryIDPIDCol},
+				[]string{SAMLMetadataRefreshInstanceIDCol, SAMLMetadataRefreshIDPIDCol},
+			)),
+		),
+	)
+}
+
+func (p *samlMetadataRefreshProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.SAMLMetadataRefreshSetEventType,
+					Reduce: p.reduceSAMLMetadataRefreshSet,
+				},
+				{
+					Event:  instance.SAMLMetadataRefreshedEventType,
+					Reduce: p.reduceSAMLMetadataRefreshed,
+				},
+				{
+					Event:  instance.SAMLCertificateExpiringEventType,
+					Reduce: p.reduceSAMLCertificateExpiring,
+				},
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(SAMLMetadataRefreshInstanceIDCol),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.SAMLMetadataRefreshSetEventType,
+					Reduce: p.reduceSAMLMetadataRefreshSet,
+				},
+				{
+					Event:  org.SAMLMetadataRefreshedEventType,
+					Reduce: p.reduceSAMLMetadataRefreshed,
+				},
+				{
+					Event:  org.SAMLCertificateExpiringEventType,
+					Reduce: p.reduceSAMLCertificateExpiring,
+				},
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *samlMetadataRefreshProjection) reduceSAMLMetadataRefreshSet(event eventstore.Event) (*handler.Statement, error) {
+	var setEvent idp.SAMLMetadataRefreshSetEvent
+	switch e := event.(type) {
+	case *org.SAMLMetadataRefreshSetEvent:
+		setEvent = e.SAMLMetadataRefreshSetEvent
+	case *instance.SAMLMetadataRefreshSetEvent:
+		setEvent = e.SAMLMetadataRefreshSetEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ieg5o", "reduce.wrong.event.type %v", []eventstore.EventType{org.SAMLMetadataRefreshSetEventType, instance.SAMLMetadataRefreshSetEventType})
+	}
+
+	return handler.NewUpsertStatement(
+		&setEvent,
+		[]handler.Column{
+			handler.NewCol(SAMLMetadataRefreshInstanceIDCol, nil),
+			handler.NewCol(SAMLMetadataRefreshIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(SAMLMetadataRefreshIDPIDCol, setEvent.ID),
+			handler.NewCol(SAMLMetadataRefreshInstanceIDCol, setEvent.Aggregate().InstanceID),
+			handler.NewCol(SAMLMetadataRefreshResourceOwnerCol, setEvent.Aggregate().ResourceOwner),
+			handler.NewCol(SAMLMetadataRefreshCreationDateCol, handler.OnlySetValueOnInsert(SAMLMetadataRefreshTable, setEvent.CreationDate())),
+			handler.NewCol(SAMLMetadataRefreshChangeDateCol, setEvent.CreationDate()),
+			handler.NewCol(SAMLMetadataRefreshSequenceCol, setEvent.Sequence()),
+			handler.NewCol(SAMLMetadataRefreshEnabledCol, setEvent.Enabled),
+			handler.NewCol(SAMLMetadataRefreshURLCol, setEvent.URL),
+			handler.NewCol(SAMLMetadataRefreshMetadataCertCol, setEvent.MetadataCertificate),
+			handler.NewCol(SAMLMetadataRefreshIntervalCol, setEvent.Interval),
+			handler.NewCol(SAMLMetadataRefreshRolloverPeriodCol, setEvent.RolloverPeriod),
+			handler.NewCol(SAMLMetadataRefreshExpiryWarningCol, setEvent.ExpiryWarning),
+		},
+	), nil
+}
+
+func (p *samlMetadataRefreshProjection) reduceSAMLMetadataRefreshed(event eventstore.Event) (*handler.Statement, error) {
+	var refreshedEvent idp.SAMLMetadataRefreshedEvent
+	switch e := event.(type) {
+	case *org.SAMLMetadataRefreshedEvent:
+		refreshedEvent = e.SAMLMetadataRefreshedEvent
+	case *instance.SAMLMetadataRefreshedEvent:
+		refreshedEvent = e.SAMLMetadataRefreshedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-aeX4j", "reduce.wrong.event.type %v", []eventstore.EventType{org.SAMLMetadataRefreshedEventType, instance.SAMLMetadataRefreshedEventType})
+	}
+
+	columns := []handler.Column{
+		handler.NewCol(SAMLMetadataRefreshLastRunStartedAtCol, refreshedEvent.StartedAt),
+		handler.NewCol(SAMLMetadataRefreshLastRunFinishedAtCol, refreshedEvent.CreationDate()),
+		handler.NewCol(SAMLMetadataRefreshLastRunErrorCol, refreshedEvent.Error),
+	}
+	conditions := []handler.Condition{
+		handler.NewCond(SAMLMetadataRefreshIDPIDCol, refreshedEvent.ID),
+		handler.NewCond(SAMLMetadataRefreshInstanceIDCol, refreshedEvent.Aggregate().InstanceID),
+	}
+	// a failed refresh keeps the previous metadata, so the certificates are still the accepted ones
+	if refreshedEvent.Error != "" {
+		return handler.NewUpdateStatement(&refreshedEvent, columns, conditions), nil
+	}
+	fingerprints := make([]string, len(refreshedEvent.Certificates))
+	for i, certificate := range refreshedEvent.Certificates {
+		fingerprints[i] = certificate.Fingerprint()
+	}
+	return handler.NewMultiStatement(
+		&refreshedEvent,
+		handler.AddUpdateStatement(
+			append(columns, handler.NewJSONCol(SAMLMetadataRefreshCertificatesCol, refreshedEvent.Certificates)),
+			conditions,
+		),
+		// the alerts of certificates which are no longer accepted are obsolete
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(SAMLCertificateExpiryIDPIDCol, refreshedEvent.ID),
+				handler.NewCond(SAMLCertificateExpiryInstanceIDCol, refreshedEvent.Aggregate().InstanceID),
+				handler.Not(handler.NewOneOfTextCond(SAMLCertificateExpiryFingerprintCol, fingerprints)),
+			},
+			handler.WithTableSuffix(SAMLCertificateExpirySuffix),
+		),
+	), nil
+}
+
+func (p *samlMetadataRefreshProjection) reduceSAMLCertificateExpiring(event eventstore.Event) (*handler.Statement, error) {
+	var expiringEvent idp.SAMLCertificateExpiringEvent
+	switch e := event.(type) {
+	case *org.SAMLCertificateExpiringEvent:
+		expiringEvent = e.SAMLCertificateExpiringEvent
+	case *instance.SAMLCertificateExpiringEvent:
+		expiringEvent = e.SAMLCertificateExpiringEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Oov4a", "reduce.wrong.event.type %v", []eventstore.EventType{org.SAMLCertificateExpiringEventType, instance.SAMLCertificateExpiringEventType})
+	}
+
+	return handler.NewUpsertStatement(
+		&expiringEvent,
+		[]handler.Column{
+			handler.NewCol(SAMLCertificateExpiryInstanceIDCol, nil),
+			handler.NewCol(SAMLCertificateExpiryIDPIDCol, nil),
+			handler.NewCol(SAMLCertificateExpiryFingerprintCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(SAMLCertificateExpiryInstanceIDCol, expiringEvent.Aggregate().InstanceID),
+			handler.NewCol(SAMLCertificateExpiryIDPIDCol, expiringEvent.ID),
+			handler.NewCol(SAMLCertificateExpiryFingerprintCol, domain.SAMLCertificateFingerprint(expiringEvent.Certificate)),
+			handler.NewCol(SAMLCertificateExpiryCertificateCol, expiringEvent.Certificate),
+			handler.NewCol(SAMLCertificateExpiryNotAfterCol, expiringEvent.NotAfter),
+			handler.NewCol(SAMLCertificateExpiryAlertedAtCol, expiringEvent.CreationDate()),
+		},
+		handler.WithTableSuffix(SAMLCertificateExpirySuffix),
+	), nil
+}
+
+func (p *samlMetadataRefreshProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var removedEvent idp.RemovedEvent
+	switch e := event.(type) {
+	case *org.IDPRemovedEvent:
+		removedEvent = e.RemovedEvent
+	case *instance.IDPRemovedEvent:
+		removedEvent = e.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ohm6e", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRemovedEventType, instance.IDPRemovedEventType})
+	}
+
+	return handler.NewDeleteStatement(
+		&removedEvent,
+		[]handler.Condition{
+			handler.NewCond(SAMLMetadataRefreshIDPIDCol, removedEvent.ID),
+			handler.NewCond(SAMLMetadataRefreshInstanceIDCol, removedEvent.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *samlMetadataRefreshProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ahr8u", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SAMLMetadataRefreshInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(SAMLMetadataRefreshResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,277 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestSAMLMetadataRefreshProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceSAMLMetadataRefreshSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.SAMLMetadataRefreshSetEventType,
+						instance.AggregateType,
+						[]byte(`{
+	"id": "idp-id",
+	"enabled": true,
+	"url": "https://idp.example.com/metadata",
+	"metadataCertificate": "certificate",
+	"interval": 3600000000000,
+	"rolloverPeriod": 86400000000000,
+	"expiryWarning": 604800000000000
+}`),
+					), instance.SAMLMetadataRefreshSetEventMapper),
+			},
+			reduce: (&samlMetadataRefreshProjection{}).reduceSAMLMetadataRefreshSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_saml_metadata_refreshes (idp_id, instance_id, resource_owner, creation_date, change_date, sequence, enabled, url, metadata_certificate, interval, rollover_period, expiry_warning) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, enabled, url, metadata_certificate, interval, rollover_period, expiry_warning) = (EXCLUDED.resource_owner, projections.idp_saml_metadata_refreshes.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.enabled, EXCLUDED.url, EXCLUDED.metadata_certificate, EXCLUDED.interval, EXCLUDED.rollover_period, EXCLUDED.expiry_warning)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								true,
+								"https://idp.example.com/metadata",
+								"certificate",
+								time.Hour,
+								24 * time.Hour,
+								7 * 24 * time.Hour,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceSAMLMetadataRefreshed",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.SAMLMetadataRefreshedEventType,
+						org.AggregateType,
+						[]byte(`{
+	"id": "idp-id",
+	"startedAt": "2024-01-01T00:00:00Z",
+	"metadataChanged": true,
+	"certificates": [{"certificate": "Y2VydGlmaWNhdGU=", "notAfter": "2025-01-01T00:00:00Z"}]
+}`),
+					), org.SAMLMetadataRefreshedEventMapper),
+			},
+			reduce: (&samlMetadataRefreshProjection{}).reduceSAMLMetadataRefreshed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idp_saml_metadata_refreshes SET (last_run_started_at, last_run_finished_at, last_run_error, certificates) = ($1, $2, $3, $4) WHERE (idp_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+								anyArg{},
+								"",
+								[]byte(`[{"certificate":"Y2VydGlmaWNhdGU=","notAfter":"2025-01-01T00:00:00Z","retiredAt":"0001-01-01T00:00:00Z"}]`),
+								"idp-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.idp_saml_metadata_refreshes_certificate_expiries WHERE (idp_id = $1) AND (instance_id = $2) AND (NOT (fingerprint = ANY($3)))",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								database.TextArray[string]{"03d66dd08835c1ca3f128cceacd1f31ac94163096b20f445ae84285bc0832d72"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceSAMLMetadataRefreshed, failed",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.SAMLMetadataRefreshedEventType,
+						org.AggregateType,
+						[]byte(`{
+	"id": "idp-id",
+	"startedAt": "2024-01-01T00:00:00Z",
+	"error": "unreachable"
+}`),
+					), org.SAMLMetadataRefreshedEventMapper),
+			},
+			reduce: (&samlMetadataRefreshProjection{}).reduceSAMLMetadataRefreshed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idp_saml_metadata_refreshes SET (last_run_started_at, last_run_finished_at, last_run_error) = ($1, $2, $3) WHERE (idp_id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+								anyArg{},
+								"unreachable",
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceSAMLCertificateExpiring",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.SAMLCertificateExpiringEventType,
+						instance.AggregateType,
+						[]byte(`{
+	"id": "idp-id",
+	"certificate": "Y2VydGlmaWNhdGU=",
+	"notAfter": "2025-01-01T00:00:00Z"
+}`),
+					), instance.SAMLCertificateExpiringEventMapper),
+			},
+			reduce: (&samlMetadataRefreshProjection{}).reduceSAMLCertificateExpiring,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_saml_metadata_refreshes_certificate_expiries (instance_id, idp_id, fingerprint, certificate, not_after, alerted_at) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (instance_id, idp_id, fingerprint) DO UPDATE SET (certificate, not_after, alerted_at) = (EXCLUDED.certificate, EXCLUDED.not_after, EXCLUDED.alerted_at)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"idp-id",
+								"03d66dd08835c1ca3f128cceacd1f31ac94163096b20f445ae84285bc0832d72",
+								[]byte("certificate"),
+								time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceIDPRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.IDPRemovedEventType,
+						instance.AggregateType,
+						[]byte(`{"id": "idp-id"}`),
+					), instance.IDPRemovedEventMapper),
+			},
+			reduce: (&samlMetadataRefreshProjection{}).reduceIDPRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_saml_metadata_refreshes WHERE (idp_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&samlMetadataRefreshProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_saml_metadata_refreshes WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(SAMLMetadataRefreshInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_saml_metadata_refreshes WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, SAMLMetadataRefreshTable, tt.want)
+		})
+	}
+}
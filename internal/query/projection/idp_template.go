@@ -17,12 +17,16 @@ import (
 )
 
 const (
-	IDPTemplateTable                 = "projections.idp_templates5"
+	IDPTemplateTable                 = "projections.idp_templates6"
 	IDPTemplateOAuthTable            = IDPTemplateTable + "_" + IDPTemplateOAuthSuffix
 	IDPTemplateOIDCTable             = IDPTemplateTable + "_" + IDPTemplateOIDCSuffix
 	IDPTemplateJWTTable              = IDPTemplateTable + "_" + IDPTemplateJWTSuffix
 	IDPTemplateAzureADTable          = IDPTemplateTable + "_" + IDPTemplateAzureADSuffix
 	IDPTemplateGitHubTable           = IDPTemplateTable + "_" + IDPTemplateGitHubSuffix
+	IDPTemplateBitbucketTable        = IDPTemplateTable + "_" + IDPTemplateBitbucketSuffix
+	IDPTemplateSlackTable            = IDPTemplateTable + "_" + IDPTemplateSlackSuffix
+	IDPTemplateDiscordTable          = IDPTemplateTable + "_" + IDPTemplateDiscordSuffix
+	IDPTemplateLinkedInTable         = IDPTemplateTable + "_" + IDPTemplateLinkedInSuffix
 	IDPTemplateGitHubEnterpriseTable = IDPTemplateTable + "_" + IDPTemplateGitHubEnterpriseSuffix
 	IDPTemplateGitLabTable           = IDPTemplateTable + "_" + IDPTemplateGitLabSuffix
 	IDPTemplateGitLabSelfHostedTable = IDPTemplateTable + "_" + IDPTemplateGitLabSelfHostedSuffix
@@ -36,6 +40,10 @@ const (
 	IDPTemplateJWTSuffix              = "jwt"
 	IDPTemplateAzureADSuffix          = "azure"
 	IDPTemplateGitHubSuffix           = "github"
+	IDPTemplateBitbucketSuffix        = "bitbucket"
+	IDPTemplateSlackSuffix            = "slack"
+	IDPTemplateDiscordSuffix          = "discord"
+	IDPTemplateLinkedInSuffix         = "linkedin"
 	IDPTemplateGitHubEnterpriseSuffix = "github_enterprise"
 	IDPTemplateGitLabSuffix           = "gitlab"
 	IDPTemplateGitLabSelfHostedSuffix = "gitlab_self_hosted"
@@ -99,6 +107,30 @@ const (
 	GitHubClientSecretCol = "client_secret"
 	GitHubScopesCol       = "scopes"
 
+	BitbucketIDCol           = "idp_id"
+	BitbucketInstanceIDCol   = "instance_id"
+	BitbucketClientIDCol     = "client_id"
+	BitbucketClientSecretCol = "client_secret"
+	BitbucketScopesCol       = "scopes"
+
+	SlackIDCol           = "idp_id"
+	SlackInstanceIDCol   = "instance_id"
+	SlackClientIDCol     = "client_id"
+	SlackClientSecretCol = "client_secret"
+	SlackScopesCol       = "scopes"
+
+	DiscordIDCol           = "idp_id"
+	DiscordInstanceIDCol   = "instance_id"
+	DiscordClientIDCol     = "client_id"
+	DiscordClientSecretCol = "client_secret"
+	DiscordScopesCol       = "scopes"
+
+	LinkedInIDCol           = "idp_id"
+	LinkedInInstanceIDCol   = "instance_id"
+	LinkedInClientIDCol     = "client_id"
+	LinkedInClientSecretCol = "client_secret"
+	LinkedInScopesCol       = "scopes"
+
 	GitHubEnterpriseIDCol                    = "idp_id"
 	GitHubEnterpriseInstanceIDCol            = "instance_id"
 	GitHubEnterpriseClientIDCol              = "client_id"
@@ -266,6 +298,50 @@ func (*idpTemplateProjection) Init() *old_handler.Check {
 			IDPTemplateGitHubSuffix,
 			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(BitbucketIDCol, handler.ColumnTypeText),
+			handler.NewColumn(BitbucketInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(BitbucketClientIDCol, handler.ColumnTypeText),
+			handler.NewColumn(BitbucketClientSecretCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(BitbucketScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(BitbucketInstanceIDCol, BitbucketIDCol),
+			IDPTemplateBitbucketSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(SlackIDCol, handler.ColumnTypeText),
+			handler.NewColumn(SlackInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(SlackClientIDCol, handler.ColumnTypeText),
+			handler.NewColumn(SlackClientSecretCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(SlackScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(SlackInstanceIDCol, SlackIDCol),
+			IDPTemplateSlackSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(DiscordIDCol, handler.ColumnTypeText),
+			handler.NewColumn(DiscordInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(DiscordClientIDCol, handler.ColumnTypeText),
+			handler.NewColumn(DiscordClientSecretCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(DiscordScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(DiscordInstanceIDCol, DiscordIDCol),
+			IDPTemplateDiscordSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(LinkedInIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LinkedInInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LinkedInClientIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LinkedInClientSecretCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(LinkedInScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(LinkedInInstanceIDCol, LinkedInIDCol),
+			IDPTemplateLinkedInSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 		handler.NewSuffixedTable([]*handler.InitColumn{
 			handler.NewColumn(GitHubEnterpriseIDCol, handler.ColumnTypeText),
 			handler.NewColumn(GitHubEnterpriseInstanceIDCol, handler.ColumnTypeText),
@@ -450,6 +526,38 @@ func (p *idpTemplateProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.GitHubIDPChangedEventType,
 					Reduce: p.reduceGitHubIDPChanged,
 				},
+				{
+					Event:  instance.BitbucketIDPAddedEventType,
+					Reduce: p.reduceBitbucketIDPAdded,
+				},
+				{
+					Event:  instance.BitbucketIDPChangedEventType,
+					Reduce: p.reduceBitbucketIDPChanged,
+				},
+				{
+					Event:  instance.SlackIDPAddedEventType,
+					Reduce: p.reduceSlackIDPAdded,
+				},
+				{
+					Event:  instance.SlackIDPChangedEventType,
+					Reduce: p.reduceSlackIDPChanged,
+				},
+				{
+					Event:  instance.DiscordIDPAddedEventType,
+					Reduce: p.reduceDiscordIDPAdded,
+				},
+				{
+					Event:  instance.DiscordIDPChangedEventType,
+					Reduce: p.reduceDiscordIDPChanged,
+				},
+				{
+					Event:  instance.LinkedInIDPAddedEventType,
+					Reduce: p.reduceLinkedInIDPAdded,
+				},
+				{
+					Event:  instance.LinkedInIDPChangedEventType,
+					Reduce: p.reduceLinkedInIDPChanged,
+				},
 				{
 					Event:  instance.GitHubEnterpriseIDPAddedEventType,
 					Reduce: p.reduceGitHubEnterpriseIDPAdded,
@@ -595,6 +703,38 @@ func (p *idpTemplateProjection) Reducers() []handler.AggregateReducer {
 					Event:  org.GitHubIDPChangedEventType,
 					Reduce: p.reduceGitHubIDPChanged,
 				},
+				{
+					Event:  org.BitbucketIDPAddedEventType,
+					Reduce: p.reduceBitbucketIDPAdded,
+				},
+				{
+					Event:  org.BitbucketIDPChangedEventType,
+					Reduce: p.reduceBitbucketIDPChanged,
+				},
+				{
+					Event:  org.SlackIDPAddedEventType,
+					Reduce: p.reduceSlackIDPAdded,
+				},
+				{
+					Event:  org.SlackIDPChangedEventType,
+					Reduce: p.reduceSlackIDPChanged,
+				},
+				{
+					Event:  org.DiscordIDPAddedEventType,
+					Reduce: p.reduceDiscordIDPAdded,
+				},
+				{
+					Event:  org.DiscordIDPChangedEventType,
+					Reduce: p.reduceDiscordIDPChanged,
+				},
+				{
+					Event:  org.LinkedInIDPAddedEventType,
+					Reduce: p.reduceLinkedInIDPAdded,
+				},
+				{
+					Event:  org.LinkedInIDPChangedEventType,
+					Reduce: p.reduceLinkedInIDPChanged,
+				},
 				{
 					Event:  org.GitHubEnterpriseIDPAddedEventType,
 					Reduce: p.reduceGitHubEnterpriseIDPAdded,
@@ -817,7 +957,7 @@ func (p *idpTemplateProjection) reduceOIDCIDPChanged(event eventstore.Event) (*h
 	case *instance.OIDCIDPChangedEvent:
 		idpEvent = e.OIDCIDPChangedEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.OIDCIDPChangedEventType, instance.OIDCIDPChangedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-dn2oN", "reduce.wrong.event.type %v", []eventstore.EventType{org.OIDCIDPChangedEventType, instance.OIDCIDPChangedEventType})
 	}
 
 	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
@@ -858,7 +998,7 @@ func (p *idpTemplateProjection) reduceOIDCIDPMigratedAzureAD(event eventstore.Ev
 	case *instance.OIDCIDPMigratedAzureADEvent:
 		idpEvent = e.OIDCIDPMigratedAzureADEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.OIDCIDPMigratedAzureADEventType, instance.OIDCIDPMigratedAzureADEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-y1jLm", "reduce.wrong.event.type %v", []eventstore.EventType{org.OIDCIDPMigratedAzureADEventType, instance.OIDCIDPMigratedAzureADEventType})
 	}
 
 	return handler.NewMultiStatement(
@@ -909,7 +1049,7 @@ func (p *idpTemplateProjection) reduceOIDCIDPMigratedGoogle(event eventstore.Eve
 	case *instance.OIDCIDPMigratedGoogleEvent:
 		idpEvent = e.OIDCIDPMigratedGoogleEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.OIDCIDPMigratedGoogleEventType, instance.OIDCIDPMigratedGoogleEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-HJD3b", "reduce.wrong.event.type %v", []eventstore.EventType{org.OIDCIDPMigratedGoogleEventType, instance.OIDCIDPMigratedGoogleEventType})
 	}
 
 	return handler.NewMultiStatement(
@@ -1006,7 +1146,7 @@ func (p *idpTemplateProjection) reduceJWTIDPChanged(event eventstore.Event) (*ha
 	case *instance.JWTIDPChangedEvent:
 		idpEvent = e.JWTIDPChangedEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.JWTIDPChangedEventType, instance.JWTIDPChangedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-6mmWt", "reduce.wrong.event.type %v", []eventstore.EventType{org.JWTIDPChangedEventType, instance.JWTIDPChangedEventType})
 	}
 
 	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
@@ -1154,7 +1294,7 @@ func (p *idpTemplateProjection) reduceOldOIDCConfigChanged(event eventstore.Even
 	case *instance.IDPOIDCConfigChangedEvent:
 		idpEvent = e.OIDCConfigChangedEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.OIDCIDPChangedEventType, instance.OIDCIDPChangedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-1pKjK", "reduce.wrong.event.type %v", []eventstore.EventType{org.OIDCIDPChangedEventType, instance.OIDCIDPChangedEventType})
 	}
 
 	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
@@ -1248,7 +1388,7 @@ func (p *idpTemplateProjection) reduceOldJWTConfigChanged(event eventstore.Event
 	case *instance.IDPJWTConfigChangedEvent:
 		idpEvent = e.JWTConfigChangedEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.JWTIDPChangedEventType, instance.JWTIDPChangedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-P3W48", "reduce.wrong.event.type %v", []eventstore.EventType{org.JWTIDPChangedEventType, instance.JWTIDPChangedEventType})
 	}
 
 	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
@@ -1353,7 +1493,7 @@ func (p *idpTemplateProjection) reduceAzureADIDPChanged(event eventstore.Event)
 	case *instance.AzureADIDPChangedEvent:
 		idpEvent = e.AzureADIDPChangedEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.AzureADIDPChangedEventType, instance.AzureADIDPChangedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-8sigD", "reduce.wrong.event.type %v", []eventstore.EventType{org.AzureADIDPChangedEventType, instance.AzureADIDPChangedEventType})
 	}
 
 	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
@@ -1397,7 +1537,7 @@ func (p *idpTemplateProjection) reduceGitHubIDPAdded(event eventstore.Event) (*h
 		idpEvent = e.GitHubIDPAddedEvent
 		idpOwnerType = domain.IdentityProviderTypeSystem
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-x9a022b", "reduce.wrong.event.type %v", []eventstore.EventType{org.GitHubIDPAddedEventType, instance.GitHubIDPAddedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-XYV7j", "reduce.wrong.event.type %v", []eventstore.EventType{org.GitHubIDPAddedEventType, instance.GitHubIDPAddedEventType})
 	}
 
 	return handler.NewMultiStatement(
@@ -1491,7 +1631,7 @@ func (p *idpTemplateProjection) reduceGitHubIDPChanged(event eventstore.Event) (
 	case *instance.GitHubIDPChangedEvent:
 		idpEvent = e.GitHubIDPChangedEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.GitHubIDPChangedEventType, instance.GitHubIDPChangedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-H5ruM", "reduce.wrong.event.type %v", []eventstore.EventType{org.GitHubIDPChangedEventType, instance.GitHubIDPChangedEventType})
 	}
 
 	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
@@ -1576,7 +1716,7 @@ func (p *idpTemplateProjection) reduceGitLabIDPAdded(event eventstore.Event) (*h
 		idpEvent = e.GitLabIDPAddedEvent
 		idpOwnerType = domain.IdentityProviderTypeSystem
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-x9a022b", "reduce.wrong.event.type %v", []eventstore.EventType{org.GitLabIDPAddedEventType, instance.GitLabIDPAddedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-1rPka", "reduce.wrong.event.type %v", []eventstore.EventType{org.GitLabIDPAddedEventType, instance.GitLabIDPAddedEventType})
 	}
 
 	return handler.NewMultiStatement(
@@ -1620,7 +1760,7 @@ func (p *idpTemplateProjection) reduceGitLabIDPChanged(event eventstore.Event) (
 	case *instance.GitLabIDPChangedEvent:
 		idpEvent = e.GitLabIDPChangedEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.GitLabIDPChangedEventType, instance.GitLabIDPChangedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-MXP5m", "reduce.wrong.event.type %v", []eventstore.EventType{org.GitLabIDPChangedEventType, instance.GitLabIDPChangedEventType})
 	}
 
 	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
@@ -1797,7 +1937,7 @@ func (p *idpTemplateProjection) reduceGoogleIDPChanged(event eventstore.Event) (
 	case *instance.GoogleIDPChangedEvent:
 		idpEvent = e.GoogleIDPChangedEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.GoogleIDPChangedEventType, instance.GoogleIDPChangedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-17IJe", "reduce.wrong.event.type %v", []eventstore.EventType{org.GoogleIDPChangedEventType, instance.GoogleIDPChangedEventType})
 	}
 
 	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
@@ -1904,7 +2044,7 @@ func (p *idpTemplateProjection) reduceLDAPIDPChanged(event eventstore.Event) (*h
 	case *instance.LDAPIDPChangedEvent:
 		idpEvent = e.LDAPIDPChangedEvent
 	default:
-		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-p1582ks", "reduce.wrong.event.type %v", []eventstore.EventType{org.LDAPIDPChangedEventType, instance.LDAPIDPChangedEventType})
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-b22FO", "reduce.wrong.event.type %v", []eventstore.EventType{org.LDAPIDPChangedEventType, instance.LDAPIDPChangedEventType})
 	}
 
 	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
@@ -2294,6 +2434,414 @@ func reduceGitHubIDPChangedColumns(idpEvent idp.GitHubIDPChangedEvent) []handler
 	return oauthCols
 }
 
+func (p *idpTemplateProjection) reduceBitbucketIDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.BitbucketIDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.BitbucketIDPAddedEvent:
+		idpEvent = e.BitbucketIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.BitbucketIDPAddedEvent:
+		idpEvent = e.BitbucketIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-WO3L0", "reduce.wrong.event.type %v", []eventstore.EventType{org.BitbucketIDPAddedEventType, instance.BitbucketIDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeBitbucket),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(BitbucketIDCol, idpEvent.ID),
+				handler.NewCol(BitbucketInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(BitbucketClientIDCol, idpEvent.ClientID),
+				handler.NewCol(BitbucketClientSecretCol, idpEvent.ClientSecret),
+				handler.NewCol(BitbucketScopesCol, database.TextArray[string](idpEvent.Scopes)),
+			},
+			handler.WithTableSuffix(IDPTemplateBitbucketSuffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceBitbucketIDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.BitbucketIDPChangedEvent
+	switch e := event.(type) {
+	case *org.BitbucketIDPChangedEvent:
+		idpEvent = e.BitbucketIDPChangedEvent
+	case *instance.BitbucketIDPChangedEvent:
+		idpEvent = e.BitbucketIDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-kMHa3", "reduce.wrong.event.type %v", []eventstore.EventType{org.BitbucketIDPChangedEventType, instance.BitbucketIDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	bitbucketCols := reduceBitbucketIDPChangedColumns(idpEvent)
+	if len(bitbucketCols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				bitbucketCols,
+				[]handler.Condition{
+					handler.NewCond(BitbucketIDCol, idpEvent.ID),
+					handler.NewCond(BitbucketInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateBitbucketSuffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
+func reduceBitbucketIDPChangedColumns(idpEvent idp.BitbucketIDPChangedEvent) []handler.Column {
+	oauthCols := make([]handler.Column, 0, 3)
+	if idpEvent.ClientID != nil {
+		oauthCols = append(oauthCols, handler.NewCol(BitbucketClientIDCol, *idpEvent.ClientID))
+	}
+	if idpEvent.ClientSecret != nil {
+		oauthCols = append(oauthCols, handler.NewCol(BitbucketClientSecretCol, *idpEvent.ClientSecret))
+	}
+	if idpEvent.Scopes != nil {
+		oauthCols = append(oauthCols, handler.NewCol(BitbucketScopesCol, database.TextArray[string](idpEvent.Scopes)))
+	}
+	return oauthCols
+}
+
+func (p *idpTemplateProjection) reduceSlackIDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.SlackIDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.SlackIDPAddedEvent:
+		idpEvent = e.SlackIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.SlackIDPAddedEvent:
+		idpEvent = e.SlackIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-06uCj", "reduce.wrong.event.type %v", []eventstore.EventType{org.SlackIDPAddedEventType, instance.SlackIDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeSlack),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SlackIDCol, idpEvent.ID),
+				handler.NewCol(SlackInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(SlackClientIDCol, idpEvent.ClientID),
+				handler.NewCol(SlackClientSecretCol, idpEvent.ClientSecret),
+				handler.NewCol(SlackScopesCol, database.TextArray[string](idpEvent.Scopes)),
+			},
+			handler.WithTableSuffix(IDPTemplateSlackSuffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceSlackIDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.SlackIDPChangedEvent
+	switch e := event.(type) {
+	case *org.SlackIDPChangedEvent:
+		idpEvent = e.SlackIDPChangedEvent
+	case *instance.SlackIDPChangedEvent:
+		idpEvent = e.SlackIDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-ocBd6", "reduce.wrong.event.type %v", []eventstore.EventType{org.SlackIDPChangedEventType, instance.SlackIDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	slackCols := reduceSlackIDPChangedColumns(idpEvent)
+	if len(slackCols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				slackCols,
+				[]handler.Condition{
+					handler.NewCond(SlackIDCol, idpEvent.ID),
+					handler.NewCond(SlackInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateSlackSuffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
+func reduceSlackIDPChangedColumns(idpEvent idp.SlackIDPChangedEvent) []handler.Column {
+	oauthCols := make([]handler.Column, 0, 3)
+	if idpEvent.ClientID != nil {
+		oauthCols = append(oauthCols, handler.NewCol(SlackClientIDCol, *idpEvent.ClientID))
+	}
+	if idpEvent.ClientSecret != nil {
+		oauthCols = append(oauthCols, handler.NewCol(SlackClientSecretCol, *idpEvent.ClientSecret))
+	}
+	if idpEvent.Scopes != nil {
+		oauthCols = append(oauthCols, handler.NewCol(SlackScopesCol, database.TextArray[string](idpEvent.Scopes)))
+	}
+	return oauthCols
+}
+
+func (p *idpTemplateProjection) reduceDiscordIDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.DiscordIDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.DiscordIDPAddedEvent:
+		idpEvent = e.DiscordIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.DiscordIDPAddedEvent:
+		idpEvent = e.DiscordIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ykb71", "reduce.wrong.event.type %v", []eventstore.EventType{org.DiscordIDPAddedEventType, instance.DiscordIDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeDiscord),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(DiscordIDCol, idpEvent.ID),
+				handler.NewCol(DiscordInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(DiscordClientIDCol, idpEvent.ClientID),
+				handler.NewCol(DiscordClientSecretCol, idpEvent.ClientSecret),
+				handler.NewCol(DiscordScopesCol, database.TextArray[string](idpEvent.Scopes)),
+			},
+			handler.WithTableSuffix(IDPTemplateDiscordSuffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceDiscordIDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.DiscordIDPChangedEvent
+	switch e := event.(type) {
+	case *org.DiscordIDPChangedEvent:
+		idpEvent = e.DiscordIDPChangedEvent
+	case *instance.DiscordIDPChangedEvent:
+		idpEvent = e.DiscordIDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-GYd1v", "reduce.wrong.event.type %v", []eventstore.EventType{org.DiscordIDPChangedEventType, instance.DiscordIDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	discordCols := reduceDiscordIDPChangedColumns(idpEvent)
+	if len(discordCols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				discordCols,
+				[]handler.Condition{
+					handler.NewCond(DiscordIDCol, idpEvent.ID),
+					handler.NewCond(DiscordInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateDiscordSuffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
+func reduceDiscordIDPChangedColumns(idpEvent idp.DiscordIDPChangedEvent) []handler.Column {
+	oauthCols := make([]handler.Column, 0, 3)
+	if idpEvent.ClientID != nil {
+		oauthCols = append(oauthCols, handler.NewCol(DiscordClientIDCol, *idpEvent.ClientID))
+	}
+	if idpEvent.ClientSecret != nil {
+		oauthCols = append(oauthCols, handler.NewCol(DiscordClientSecretCol, *idpEvent.ClientSecret))
+	}
+	if idpEvent.Scopes != nil {
+		oauthCols = append(oauthCols, handler.NewCol(DiscordScopesCol, database.TextArray[string](idpEvent.Scopes)))
+	}
+	return oauthCols
+}
+
+func (p *idpTemplateProjection) reduceLinkedInIDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.LinkedInIDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.LinkedInIDPAddedEvent:
+		idpEvent = e.LinkedInIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.LinkedInIDPAddedEvent:
+		idpEvent = e.LinkedInIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-sEp78", "reduce.wrong.event.type %v", []eventstore.EventType{org.LinkedInIDPAddedEventType, instance.LinkedInIDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeLinkedIn),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(LinkedInIDCol, idpEvent.ID),
+				handler.NewCol(LinkedInInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(LinkedInClientIDCol, idpEvent.ClientID),
+				handler.NewCol(LinkedInClientSecretCol, idpEvent.ClientSecret),
+				handler.NewCol(LinkedInScopesCol, database.TextArray[string](idpEvent.Scopes)),
+			},
+			handler.WithTableSuffix(IDPTemplateLinkedInSuffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceLinkedInIDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.LinkedInIDPChangedEvent
+	switch e := event.(type) {
+	case *org.LinkedInIDPChangedEvent:
+		idpEvent = e.LinkedInIDPChangedEvent
+	case *instance.LinkedInIDPChangedEvent:
+		idpEvent = e.LinkedInIDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-cs0cu", "reduce.wrong.event.type %v", []eventstore.EventType{org.LinkedInIDPChangedEventType, instance.LinkedInIDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	linkedinCols := reduceLinkedInIDPChangedColumns(idpEvent)
+	if len(linkedinCols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				linkedinCols,
+				[]handler.Condition{
+					handler.NewCond(LinkedInIDCol, idpEvent.ID),
+					handler.NewCond(LinkedInInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateLinkedInSuffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
+func reduceLinkedInIDPChangedColumns(idpEvent idp.LinkedInIDPChangedEvent) []handler.Column {
+	oauthCols := make([]handler.Column, 0, 3)
+	if idpEvent.ClientID != nil {
+		oauthCols = append(oauthCols, handler.NewCol(LinkedInClientIDCol, *idpEvent.ClientID))
+	}
+	if idpEvent.ClientSecret != nil {
+		oauthCols = append(oauthCols, handler.NewCol(LinkedInClientSecretCol, *idpEvent.ClientSecret))
+	}
+	if idpEvent.Scopes != nil {
+		oauthCols = append(oauthCols, handler.NewCol(LinkedInScopesCol, database.TextArray[string](idpEvent.Scopes)))
+	}
+	return oauthCols
+}
+
 func reduceGitHubEnterpriseIDPChangedColumns(idpEvent idp.GitHubEnterpriseIDPChangedEvent) []handler.Column {
 	oauthCols := make([]handler.Column, 0, 6)
 	if idpEvent.ClientID != nil {
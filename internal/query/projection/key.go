@@ -124,6 +124,10 @@ func (p *keyProjection) Reducers() []handler.AggregateReducer {
 					Event:  keypair.AddedCertificateEventType,
 					Reduce: p.reduceCertificateAdded,
 				},
+				{
+					Event:  keypair.RevokedEventType,
+					Reduce: p.reduceKeyPairRevoked,
+				},
 			},
 		},
 		{
@@ -217,3 +221,96 @@ func (p *keyProjection) reduceCertificateAdded(event eventstore.Event) (*handler
 
 	return handler.NewMultiStatement(e, creates...), nil
 }
+
+func (p *keyProjection) reduceKeyPairRevoked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*keypair.RevokedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Rv0k3", "reduce.wrong.event.type %s", keypair.RevokedEventType)
+	}
+	if !e.RevokeAt.IsZero() {
+		return reduceKeyPairRevokedAt(e), nil
+	}
+	return handler.NewMultiStatement(e,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(KeyColumnChangeDate, e.CreationDate()),
+				handler.NewCol(KeyColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(KeyColumnID, e.Aggregate().ID),
+				handler.NewCond(KeyColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(KeyPrivateColumnID, e.Aggregate().ID),
+				handler.NewCond(KeyPrivateColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(privateKeyTableSuffix),
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(KeyPublicColumnID, e.Aggregate().ID),
+				handler.NewCond(KeyPublicColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(publicKeyTableSuffix),
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(CertificateColumnID, e.Aggregate().ID),
+				handler.NewCond(CertificateColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(certificateTableSuffix),
+		),
+	), nil
+}
+
+// reduceKeyPairRevokedAt shortens the use and publication of the key pair to end at the time of the revocation,
+// later expiries are kept, as the key pair must not be used or published again.
+func reduceKeyPairRevokedAt(e *keypair.RevokedEvent) *handler.Statement {
+	return handler.NewMultiStatement(e,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(KeyColumnChangeDate, e.CreationDate()),
+				handler.NewCol(KeyColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(KeyColumnID, e.Aggregate().ID),
+				handler.NewCond(KeyColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(KeyPrivateColumnExpiry, e.RevokeAt),
+			},
+			[]handler.Condition{
+				handler.NewCond(KeyPrivateColumnID, e.Aggregate().ID),
+				handler.NewCond(KeyPrivateColumnInstanceID, e.Aggregate().InstanceID),
+				handler.Not(handler.NewLessThanCond(KeyPrivateColumnExpiry, e.RevokeAt)),
+			},
+			handler.WithTableSuffix(privateKeyTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(KeyPublicColumnExpiry, e.RevokeAt),
+			},
+			[]handler.Condition{
+				handler.NewCond(KeyPublicColumnID, e.Aggregate().ID),
+				handler.NewCond(KeyPublicColumnInstanceID, e.Aggregate().InstanceID),
+				handler.Not(handler.NewLessThanCond(KeyPublicColumnExpiry, e.RevokeAt)),
+			},
+			handler.WithTableSuffix(publicKeyTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(CertificateColumnExpiry, e.RevokeAt),
+			},
+			[]handler.Condition{
+				handler.NewCond(CertificateColumnID, e.Aggregate().ID),
+				handler.NewCond(CertificateColumnInstanceID, e.Aggregate().InstanceID),
+				handler.Not(handler.NewLessThanCond(CertificateColumnExpiry, e.RevokeAt)),
+			},
+			handler.WithTableSuffix(certificateTableSuffix),
+		),
+	)
+}
@@ -154,6 +154,112 @@ func TestKeyProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceKeyPairRevoked",
+			args: args{
+				event: getEvent(
+					testEvent(
+						keypair.RevokedEventType,
+						keypair.AggregateType,
+						[]byte(`{"reason": "compromised"}`),
+					), keypair.RevokedEventMapper),
+			},
+			reduce: (&keyProjection{}).reduceKeyPairRevoked,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("key_pair"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.keys4 SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.keys4_private WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.keys4_public WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.keys4_certificate WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceKeyPairRevoked revoke at",
+			args: args{
+				event: getEvent(
+					testEvent(
+						keypair.RevokedEventType,
+						keypair.AggregateType,
+						[]byte(`{"reason": "compromised", "revokeAt": "2026-01-01T00:00:00Z"}`),
+					), keypair.RevokedEventMapper),
+			},
+			reduce: (&keyProjection{}).reduceKeyPairRevoked,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("key_pair"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.keys4 SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.keys4_private SET expiry = $1 WHERE (id = $2) AND (instance_id = $3) AND (NOT (expiry < $4))",
+							expectedArgs: []interface{}{
+								time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+								"agg-id",
+								"instance-id",
+								time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.keys4_public SET expiry = $1 WHERE (id = $2) AND (instance_id = $3) AND (NOT (expiry < $4))",
+							expectedArgs: []interface{}{
+								time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+								"agg-id",
+								"instance-id",
+								time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.keys4_certificate SET expiry = $1 WHERE (id = $2) AND (instance_id = $3) AND (NOT (expiry < $4))",
+							expectedArgs: []interface{}{
+								time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+								"agg-id",
+								"instance-id",
+								time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
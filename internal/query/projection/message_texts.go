@@ -272,7 +272,10 @@ func isMessageTemplate(template string) bool {
 		template == domain.VerifyEmailOTPMessageType ||
 		template == domain.DomainClaimedMessageType ||
 		template == domain.PasswordlessRegistrationMessageType ||
-		template == domain.PasswordChangeMessageType
+		template == domain.PasswordChangeMessageType ||
+		template == domain.IDPAutoLinkedMessageType ||
+		template == domain.RefreshTokenReusedMessageType ||
+		template == domain.SAMLCertificateExpiringMessageType
 }
 func isTitle(key string) bool {
 	return key == domain.MessageTitle
@@ -0,0 +1,190 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	OrgDomainIDPRoutingTable = "projections.org_domain_idp_routings"
+
+	OrgDomainIDPRoutingOrgIDCol        = "org_id"
+	OrgDomainIDPRoutingInstanceIDCol   = "instance_id"
+	OrgDomainIDPRoutingCreationDateCol = "creation_date"
+	OrgDomainIDPRoutingChangeDateCol   = "change_date"
+	OrgDomainIDPRoutingSequenceCol     = "sequence"
+	OrgDomainIDPRoutingDomainCol       = "domain"
+	OrgDomainIDPRoutingIDPIDsCol       = "idp_ids"
+	OrgDomainIDPRoutingForceCol        = "force"
+)
+
+type orgDomainIDPRoutingProjection struct{}
+
+func newOrgDomainIDPRoutingProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(orgDomainIDPRoutingProjection))
+}
+
+func (*orgDomainIDPRoutingProjection) Name() string {
+	return OrgDomainIDPRoutingTable
+}
+
+func (*orgDomainIDPRoutingProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(OrgDomainIDPRoutingOrgIDCol, handler.ColumnTypeText),
+			handler.NewColumn(OrgDomainIDPRoutingInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(OrgDomainIDPRoutingCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(OrgDomainIDPRoutingChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(OrgDomainIDPRoutingSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(OrgDomainIDPRoutingDomainCol, handler.ColumnTypeText),
+			handler.NewColumn(OrgDomainIDPRoutingIDPIDsCol, handler.ColumnTypeTextArray),
+			handler.NewColumn(OrgDomainIDPRoutingForceCol, handler.ColumnTypeBool, handler.Default(false)),
+		},
+			handler.NewPrimaryKey(OrgDomainIDPRoutingInstanceIDCol, OrgDomainIDPRoutingOrgIDCol, OrgDomainIDPRoutingDomainCol),
+			handler.WithIndex(handler.NewIndex("domain", []string{OrgDomainIDPRoutingInstanceIDCol, OrgDomainIDPRoutingDomainCol})),
+		),
+	)
+}
+
+func (p *orgDomainIDPRoutingProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgDomainIDPRoutingSetEventType,
+					Reduce: p.reduceRoutingSet,
+				},
+				{
+					Event:  org.OrgDomainIDPRoutingRemovedEventType,
+					Reduce: p.reduceRoutingRemoved,
+				},
+				{
+					Event:  org.OrgDomainRemovedEventType,
+					Reduce: p.reduceDomainRemoved,
+				},
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(OrgDomainIDPRoutingInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *orgDomainIDPRoutingProjection) reduceRoutingSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.DomainIDPRoutingSetEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Aeph4", "reduce.wrong.event.type %s", org.OrgDomainIDPRoutingSetEventType)
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OrgDomainIDPRoutingInstanceIDCol, nil),
+			handler.NewCol(OrgDomainIDPRoutingOrgIDCol, nil),
+			handler.NewCol(OrgDomainIDPRoutingDomainCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(OrgDomainIDPRoutingOrgIDCol, e.Aggregate().ID),
+			handler.NewCol(OrgDomainIDPRoutingInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(OrgDomainIDPRoutingDomainCol, e.Domain),
+			handler.NewCol(OrgDomainIDPRoutingCreationDateCol, handler.OnlySetValueOnInsert(OrgDomainIDPRoutingTable, e.CreationDate())),
+			handler.NewCol(OrgDomainIDPRoutingChangeDateCol, e.CreationDate()),
+			handler.NewCol(OrgDomainIDPRoutingSequenceCol, e.Sequence()),
+			handler.NewCol(OrgDomainIDPRoutingIDPIDsCol, database.TextArray[string](e.IDPIDs)),
+			handler.NewCol(OrgDomainIDPRoutingForceCol, e.Force),
+		},
+	), nil
+}
+
+func (p *orgDomainIDPRoutingProjection) reduceRoutingRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.DomainIDPRoutingRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-uM4oh", "reduce.wrong.event.type %s", org.OrgDomainIDPRoutingRemovedEventType)
+	}
+	return p.deleteDomainRouting(e, e.Domain), nil
+}
+
+func (p *orgDomainIDPRoutingProjection) reduceDomainRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.DomainRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Vie7o", "reduce.wrong.event.type %s", org.OrgDomainRemovedEventType)
+	}
+	return p.deleteDomainRouting(e, e.Domain), nil
+}
+
+func (p *orgDomainIDPRoutingProjection) deleteDomainRouting(event eventstore.Event, orgDomain string) *handler.Statement {
+	return handler.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(OrgDomainIDPRoutingOrgIDCol, event.Aggregate().ID),
+			handler.NewCond(OrgDomainIDPRoutingDomainCol, orgDomain),
+			handler.NewCond(OrgDomainIDPRoutingInstanceIDCol, event.Aggregate().InstanceID),
+		},
+	)
+}
+
+// reduceIDPRemoved removes the provider from all routings it is part of.
+// Instance providers can be routed to by any organization, therefore the resource owner is not part of the condition.
+func (p *orgDomainIDPRoutingProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var removedEvent idp.RemovedEvent
+	switch e := event.(type) {
+	case *org.IDPRemovedEvent:
+		removedEvent = e.RemovedEvent
+	case *instance.IDPRemovedEvent:
+		removedEvent = e.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ooph6", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRemovedEventType, instance.IDPRemovedEventType})
+	}
+
+	return handler.NewUpdateStatement(
+		&removedEvent,
+		[]handler.Column{
+			handler.NewArrayRemoveCol(OrgDomainIDPRoutingIDPIDsCol, removedEvent.ID),
+		},
+		[]handler.Condition{
+			handler.NewTextArrayContainsCond(OrgDomainIDPRoutingIDPIDsCol, removedEvent.ID),
+			handler.NewCond(OrgDomainIDPRoutingInstanceIDCol, removedEvent.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *orgDomainIDPRoutingProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Chah3", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(OrgDomainIDPRoutingInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(OrgDomainIDPRoutingOrgIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,208 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestOrgDomainIDPRoutingProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceRoutingSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgDomainIDPRoutingSetEventType,
+						org.AggregateType,
+						[]byte(`{"domain": "partner.com", "idpIds": ["idp-1", "idp-2"], "force": true}`),
+					), org.DomainIDPRoutingSetEventMapper),
+			},
+			reduce: (&orgDomainIDPRoutingProjection{}).reduceRoutingSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.org_domain_idp_routings (org_id, instance_id, domain, creation_date, change_date, sequence, idp_ids, force) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (instance_id, org_id, domain) DO UPDATE SET (creation_date, change_date, sequence, idp_ids, force) = (projections.org_domain_idp_routings.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.idp_ids, EXCLUDED.force)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"partner.com",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								database.TextArray[string]{"idp-1", "idp-2"},
+								true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRoutingRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgDomainIDPRoutingRemovedEventType,
+						org.AggregateType,
+						[]byte(`{"domain": "partner.com"}`),
+					), org.DomainIDPRoutingRemovedEventMapper),
+			},
+			reduce: (&orgDomainIDPRoutingProjection{}).reduceRoutingRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.org_domain_idp_routings WHERE (org_id = $1) AND (domain = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"partner.com",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDomainRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgDomainRemovedEventType,
+						org.AggregateType,
+						[]byte(`{"domain": "partner.com"}`),
+					), org.DomainRemovedEventMapper),
+			},
+			reduce: (&orgDomainIDPRoutingProjection{}).reduceDomainRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.org_domain_idp_routings WHERE (org_id = $1) AND (domain = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"partner.com",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceIDPRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.IDPRemovedEventType,
+						instance.AggregateType,
+						[]byte(`{"id": "idp-1"}`),
+					), instance.IDPRemovedEventMapper),
+			},
+			reduce: (&orgDomainIDPRoutingProjection{}).reduceIDPRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.org_domain_idp_routings SET idp_ids = array_remove(idp_ids, $1) WHERE (idp_ids @> $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"idp-1",
+								database.TextArray[string]{"idp-1"},
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&orgDomainIDPRoutingProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.org_domain_idp_routings WHERE (instance_id = $1) AND (org_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(OrgDomainIDPRoutingInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.org_domain_idp_routings WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, OrgDomainIDPRoutingTable, tt.want)
+		})
+	}
+}
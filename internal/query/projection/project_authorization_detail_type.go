@@ -0,0 +1,199 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	ProjectAuthorizationDetailTypeProjectionTable = "projections.project_authorization_detail_types"
+
+	ProjectAuthorizationDetailTypeColumnProjectID     = "project_id"
+	ProjectAuthorizationDetailTypeColumnType          = "type"
+	ProjectAuthorizationDetailTypeColumnCreationDate  = "creation_date"
+	ProjectAuthorizationDetailTypeColumnChangeDate    = "change_date"
+	ProjectAuthorizationDetailTypeColumnSequence      = "sequence"
+	ProjectAuthorizationDetailTypeColumnResourceOwner = "resource_owner"
+	ProjectAuthorizationDetailTypeColumnInstanceID    = "instance_id"
+	ProjectAuthorizationDetailTypeColumnDisplayName   = "display_name"
+	ProjectAuthorizationDetailTypeColumnActions       = "actions"
+	ProjectAuthorizationDetailTypeColumnLocations     = "locations"
+)
+
+type projectAuthorizationDetailTypeProjection struct{}
+
+func newProjectAuthorizationDetailTypeProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(projectAuthorizationDetailTypeProjection))
+}
+
+func (*projectAuthorizationDetailTypeProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(ProjectAuthorizationDetailTypeColumnProjectID, handler.ColumnTypeText),
+			handler.NewColumn(ProjectAuthorizationDetailTypeColumnType, handler.ColumnTypeText),
+			handler.NewColumn(ProjectAuthorizationDetailTypeColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(ProjectAuthorizationDetailTypeColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(ProjectAuthorizationDetailTypeColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(ProjectAuthorizationDetailTypeColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(ProjectAuthorizationDetailTypeColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(ProjectAuthorizationDetailTypeColumnDisplayName, handler.ColumnTypeText),
+			handler.NewColumn(ProjectAuthorizationDetailTypeColumnActions, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(ProjectAuthorizationDetailTypeColumnLocations, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(ProjectAuthorizationDetailTypeColumnInstanceID, ProjectAuthorizationDetailTypeColumnProjectID, ProjectAuthorizationDetailTypeColumnType),
+		),
+	)
+}
+
+func (*projectAuthorizationDetailTypeProjection) Name() string {
+	return ProjectAuthorizationDetailTypeProjectionTable
+}
+
+func (p *projectAuthorizationDetailTypeProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: project.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  project.AuthorizationDetailTypeAddedType,
+					Reduce: p.reduceAuthorizationDetailTypeAdded,
+				},
+				{
+					Event:  project.AuthorizationDetailTypeChangedType,
+					Reduce: p.reduceAuthorizationDetailTypeChanged,
+				},
+				{
+					Event:  project.AuthorizationDetailTypeRemovedType,
+					Reduce: p.reduceAuthorizationDetailTypeRemoved,
+				},
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ProjectAuthorizationDetailTypeColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *projectAuthorizationDetailTypeProjection) reduceAuthorizationDetailTypeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.AuthorizationDetailTypeAddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Eib6o", "reduce.wrong.event.type %s", project.AuthorizationDetailTypeAddedType)
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ProjectAuthorizationDetailTypeColumnType, e.DetailType),
+			handler.NewCol(ProjectAuthorizationDetailTypeColumnProjectID, e.Aggregate().ID),
+			handler.NewCol(ProjectAuthorizationDetailTypeColumnCreationDate, e.CreationDate()),
+			handler.NewCol(ProjectAuthorizationDetailTypeColumnChangeDate, e.CreationDate()),
+			handler.NewCol(ProjectAuthorizationDetailTypeColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(ProjectAuthorizationDetailTypeColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(ProjectAuthorizationDetailTypeColumnSequence, e.Sequence()),
+			handler.NewCol(ProjectAuthorizationDetailTypeColumnDisplayName, e.DisplayName),
+			handler.NewCol(ProjectAuthorizationDetailTypeColumnActions, database.TextArray[string](e.Actions)),
+			handler.NewCol(ProjectAuthorizationDetailTypeColumnLocations, database.TextArray[string](e.Locations)),
+		},
+	), nil
+}
+
+func (p *projectAuthorizationDetailTypeProjection) reduceAuthorizationDetailTypeChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.AuthorizationDetailTypeChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-ieD0a", "reduce.wrong.event.type %s", project.AuthorizationDetailTypeChangedType)
+	}
+	if e.DisplayName == nil && e.Actions == nil && e.Locations == nil {
+		return handler.NewNoOpStatement(e), nil
+	}
+	columns := make([]handler.Column, 0, 5)
+	columns = append(columns, handler.NewCol(ProjectAuthorizationDetailTypeColumnChangeDate, e.CreationDate()),
+		handler.NewCol(ProjectAuthorizationDetailTypeColumnSequence, e.Sequence()))
+	if e.DisplayName != nil {
+		columns = append(columns, handler.NewCol(ProjectAuthorizationDetailTypeColumnDisplayName, *e.DisplayName))
+	}
+	if e.Actions != nil {
+		columns = append(columns, handler.NewCol(ProjectAuthorizationDetailTypeColumnActions, database.TextArray[string](*e.Actions)))
+	}
+	if e.Locations != nil {
+		columns = append(columns, handler.NewCol(ProjectAuthorizationDetailTypeColumnLocations, database.TextArray[string](*e.Locations)))
+	}
+	return handler.NewUpdateStatement(
+		e,
+		columns,
+		[]handler.Condition{
+			handler.NewCond(ProjectAuthorizationDetailTypeColumnType, e.DetailType),
+			handler.NewCond(ProjectAuthorizationDetailTypeColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(ProjectAuthorizationDetailTypeColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *projectAuthorizationDetailTypeProjection) reduceAuthorizationDetailTypeRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.AuthorizationDetailTypeRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ahph2", "reduce.wrong.event.type %s", project.AuthorizationDetailTypeRemovedType)
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ProjectAuthorizationDetailTypeColumnType, e.DetailType),
+			handler.NewCond(ProjectAuthorizationDetailTypeColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(ProjectAuthorizationDetailTypeColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *projectAuthorizationDetailTypeProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ohz8i", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ProjectAuthorizationDetailTypeColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(ProjectAuthorizationDetailTypeColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *projectAuthorizationDetailTypeProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Quo6e", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ProjectAuthorizationDetailTypeColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(ProjectAuthorizationDetailTypeColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
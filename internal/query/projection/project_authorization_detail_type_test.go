@@ -0,0 +1,204 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestProjectAuthorizationDetailTypeProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceAuthorizationDetailTypeAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.AuthorizationDetailTypeAddedType,
+						project.AggregateType,
+						[]byte(`{"type": "payment_initiation", "displayName": "Payment", "actions": ["initiate"], "locations": ["https://bank.example.com"]}`),
+					), project.AuthorizationDetailTypeAddedEventMapper),
+			},
+			reduce: (&projectAuthorizationDetailTypeProjection{}).reduceAuthorizationDetailTypeAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.project_authorization_detail_types (type, project_id, creation_date, change_date, resource_owner, instance_id, sequence, display_name, actions, locations) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedArgs: []interface{}{
+								"payment_initiation",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								"Payment",
+								database.TextArray[string]{"initiate"},
+								database.TextArray[string]{"https://bank.example.com"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceAuthorizationDetailTypeChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.AuthorizationDetailTypeChangedType,
+						project.AggregateType,
+						[]byte(`{"type": "payment_initiation", "displayName": "Payment Initiation", "actions": ["initiate", "status"]}`),
+					), project.AuthorizationDetailTypeChangedEventMapper),
+			},
+			reduce: (&projectAuthorizationDetailTypeProjection{}).reduceAuthorizationDetailTypeChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.project_authorization_detail_types SET (change_date, sequence, display_name, actions) = ($1, $2, $3, $4) WHERE (type = $5) AND (project_id = $6) AND (instance_id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"Payment Initiation",
+								database.TextArray[string]{"initiate", "status"},
+								"payment_initiation",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceAuthorizationDetailTypeChanged no changes",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.AuthorizationDetailTypeChangedType,
+						project.AggregateType,
+						[]byte(`{"type": "payment_initiation"}`),
+					), project.AuthorizationDetailTypeChangedEventMapper),
+			},
+			reduce: (&projectAuthorizationDetailTypeProjection{}).reduceAuthorizationDetailTypeChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer:      &testExecuter{},
+			},
+		},
+		{
+			name: "reduceAuthorizationDetailTypeRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.AuthorizationDetailTypeRemovedType,
+						project.AggregateType,
+						[]byte(`{"type": "payment_initiation"}`),
+					), project.AuthorizationDetailTypeRemovedEventMapper),
+			},
+			reduce: (&projectAuthorizationDetailTypeProjection{}).reduceAuthorizationDetailTypeRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.project_authorization_detail_types WHERE (type = $1) AND (project_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"payment_initiation",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ProjectRemovedType,
+						project.AggregateType,
+						nil,
+					), project.ProjectRemovedEventMapper),
+			},
+			reduce: (&projectAuthorizationDetailTypeProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.project_authorization_detail_types WHERE (project_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "org.reduceOwnerRemoved",
+			reduce: (&projectAuthorizationDetailTypeProjection{}).reduceOwnerRemoved,
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.project_authorization_detail_types WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, ProjectAuthorizationDetailTypeProjectionTable, tt.want)
+		})
+	}
+}
@@ -31,13 +31,19 @@ var (
 	LabelPolicyProjection               *handler.Handler
 	ProjectGrantProjection              *handler.Handler
 	ProjectRoleProjection               *handler.Handler
+	AuthorizationDetailTypeProjection   *handler.Handler
 	OrgDomainProjection                 *handler.Handler
+	OrgDomainIDPRoutingProjection       *handler.Handler
 	LoginPolicyProjection               *handler.Handler
 	IDPProjection                       *handler.Handler
 	AppProjection                       *handler.Handler
 	IDPUserLinkProjection               *handler.Handler
 	IDPLoginPolicyLinkProjection        *handler.Handler
 	IDPTemplateProjection               *handler.Handler
+	LDAPSyncProjection                  *handler.Handler
+	IDPRoleMappingProjection            *handler.Handler
+	IDPAutoLinkingProjection            *handler.Handler
+	SAMLMetadataRefreshProjection       *handler.Handler
 	MailTemplateProjection              *handler.Handler
 	MessageTextProjection               *handler.Handler
 	CustomTextProjection                *handler.Handler
@@ -62,6 +68,7 @@ var (
 	KeyProjection                       *handler.Handler
 	SecurityPolicyProjection            *handler.Handler
 	NotificationPolicyProjection        *handler.Handler
+	WebAuthNAttestationPolicyProjection *handler.Handler
 	NotificationsProjection             interface{}
 	NotificationsQuotaProjection        interface{}
 	TelemetryPusherProjection           interface{}
@@ -72,6 +79,7 @@ var (
 	QuotaProjection                     *quotaProjection
 	LimitsProjection                    *handler.Handler
 	RestrictionsProjection              *handler.Handler
+	UserConsentProjection               *handler.Handler
 )
 
 type projection interface {
@@ -110,13 +118,19 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	LabelPolicyProjection = newLabelPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["label_policy"]))
 	ProjectGrantProjection = newProjectGrantProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["project_grants"]))
 	ProjectRoleProjection = newProjectRoleProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["project_roles"]))
+	AuthorizationDetailTypeProjection = newProjectAuthorizationDetailTypeProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["project_authorization_detail_types"]))
 	OrgDomainProjection = newOrgDomainProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_domains"]))
+	OrgDomainIDPRoutingProjection = newOrgDomainIDPRoutingProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_domain_idp_routings"]))
 	LoginPolicyProjection = newLoginPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["login_policies"]))
 	IDPProjection = newIDPProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idps"]))
 	AppProjection = newAppProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["apps"]))
 	IDPUserLinkProjection = newIDPUserLinkProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_user_links"]))
 	IDPLoginPolicyLinkProjection = newIDPLoginPolicyLinkProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_login_policy_links"]))
 	IDPTemplateProjection = newIDPTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_templates"]))
+	LDAPSyncProjection = newLDAPSyncProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_ldap_syncs"]))
+	IDPRoleMappingProjection = newIDPRoleMappingProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_role_mappings"]))
+	IDPAutoLinkingProjection = newIDPAutoLinkingProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_auto_linkings"]))
+	SAMLMetadataRefreshProjection = newSAMLMetadataRefreshProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_saml_metadata_refreshes"]))
 	MailTemplateProjection = newMailTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["mail_templates"]))
 	MessageTextProjection = newMessageTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["message_texts"]))
 	CustomTextProjection = newCustomTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_texts"]))
@@ -141,6 +155,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
 	SecurityPolicyProjection = newSecurityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["security_policies"]))
 	NotificationPolicyProjection = newNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policies"]))
+	WebAuthNAttestationPolicyProjection = newWebAuthNAttestationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["webauthn_attestation_policies"]))
 	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_auth"]))
 	SessionProjection = newSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sessions"]))
 	AuthRequestProjection = newAuthRequestProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["auth_requests"]))
@@ -148,6 +163,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	QuotaProjection = newQuotaProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["quotas"]))
 	LimitsProjection = newLimitsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["limits"]))
 	RestrictionsProjection = newRestrictionsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["restrictions"]))
+	UserConsentProjection = newUserConsentProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_consents"]))
 	newProjectionsList()
 	return nil
 }
@@ -219,10 +235,16 @@ func newProjectionsList() {
 		LabelPolicyProjection,
 		ProjectGrantProjection,
 		ProjectRoleProjection,
+		AuthorizationDetailTypeProjection,
 		OrgDomainProjection,
+		OrgDomainIDPRoutingProjection,
 		LoginPolicyProjection,
 		IDPProjection,
 		IDPTemplateProjection,
+		LDAPSyncProjection,
+		IDPRoleMappingProjection,
+		IDPAutoLinkingProjection,
+		SAMLMetadataRefreshProjection,
 		AppProjection,
 		IDPUserLinkProjection,
 		IDPLoginPolicyLinkProjection,
@@ -250,6 +272,7 @@ func newProjectionsList() {
 		KeyProjection,
 		SecurityPolicyProjection,
 		NotificationPolicyProjection,
+		WebAuthNAttestationPolicyProjection,
 		DeviceAuthProjection,
 		SessionProjection,
 		AuthRequestProjection,
@@ -257,5 +280,6 @@ func newProjectionsList() {
 		QuotaProjection.handler,
 		LimitsProjection,
 		RestrictionsProjection,
+		UserConsentProjection,
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
@@ -294,7 +295,16 @@ func (q *quotaProjection) IncrementUsage(ctx context.Context, unit quota.Unit, i
 		return 0, nil
 	}
 
-	err = q.client.DB.QueryRowContext(
+	// the usage is stored on the shard of the instance and rejected while the instance is moved
+	instanceCtx := authz.WithInstanceID(ctx, instanceID)
+	if err = q.client.CheckWritable(instanceCtx); err != nil {
+		return 0, err
+	}
+	client, err := q.client.ShardPool(instanceCtx)
+	if err != nil {
+		return 0, err
+	}
+	err = client.QueryRowContext(
 		ctx,
 		incrementQuotaStatement,
 		instanceID, unit, periodStart, count,
@@ -0,0 +1,95 @@
+package projection
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const projectionSchema = "projections."
+
+type rebuilder interface {
+	Rebuild(ctx context.Context, instanceIDs []string, progress func(*handler.RebuildProgress)) error
+	StartRebuild(ctx context.Context, instanceIDs []string) error
+	RebuildState(ctx context.Context) (*handler.RebuildState, error)
+	RebuildProgress(ctx context.Context, instanceIDs []string) (*handler.RebuildProgress, error)
+}
+
+// RebuildState is the state of a rebuild started by [StartRebuild]
+type RebuildState struct {
+	Running     bool
+	Err         error
+	InstanceIDs []string
+	Progress    *handler.RebuildProgress
+}
+
+// Rebuild rebuilds the projection into shadow tables and replaces the tables as soon as all events are reduced.
+// Only the rows of the instances are replaced if instance ids are passed.
+func Rebuild(ctx context.Context, name string, instanceIDs []string, progress func(*handler.RebuildProgress)) error {
+	projection, err := rebuildProjection(name)
+	if err != nil {
+		return err
+	}
+	return projection.Rebuild(ctx, instanceIDs, progress)
+}
+
+// StartRebuild starts the rebuild of the projection in the background.
+// The rebuild is claimed in the database, so it fails if any process already rebuilds the projection.
+// The state of the rebuild is returned by [RebuildStatus].
+func StartRebuild(ctx context.Context, name string, instanceIDs []string) error {
+	projection, err := rebuildProjection(name)
+	if err != nil {
+		return err
+	}
+	return projection.StartRebuild(ctx, instanceIDs)
+}
+
+// RebuildStatus returns the state of the last rebuild of the projection
+// including the progress of the shadow projection if it's still running.
+func RebuildStatus(ctx context.Context, name string) (*RebuildState, error) {
+	projection, err := rebuildProjection(name)
+	if err != nil {
+		return nil, err
+	}
+	current, err := projection.RebuildState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state := &RebuildState{
+		Running:     current.Running,
+		Err:         current.Err,
+		InstanceIDs: current.InstanceIDs,
+	}
+	if !state.Running {
+		return state, nil
+	}
+	state.Progress, err = projection.RebuildProgress(ctx, state.InstanceIDs)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func rebuildProjection(name string) (rebuilder, error) {
+	name = projectionName(name)
+	for _, projection := range projections {
+		if projection.String() != name {
+			continue
+		}
+		if r, ok := projection.(rebuilder); ok {
+			return r, nil
+		}
+		return nil, zerrors.ThrowPreconditionFailed(nil, "PROJE-Rb1Ns", "Errors.ProjectionName.RebuildNotSupported")
+	}
+	return nil, zerrors.ThrowNotFound(nil, "PROJE-Rb1Nf", "Errors.ProjectionName.Invalid")
+}
+
+// projectionName adds the schema if it's missing
+func projectionName(name string) string {
+	if strings.Contains(name, ".") {
+		return name
+	}
+	return projectionSchema + name
+}
@@ -0,0 +1,50 @@
+package projection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_rebuildProjection(t *testing.T) {
+	previous := projections
+	t.Cleanup(func() { projections = previous })
+	projections = []projection{
+		handler.NewHandler(context.Background(), &handler.Config{}, &orgMetadataProjection{}),
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr func(error) bool
+	}{
+		{
+			name:  "with schema",
+			input: OrgMetadataProjectionTable,
+		},
+		{
+			name:  "without schema",
+			input: "org_metadata2",
+		},
+		{
+			name:    "unknown",
+			input:   "projections.unknown",
+			wantErr: zerrors.IsNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rebuildProjection(tt.input)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
@@ -78,6 +78,10 @@ func (p *userAuthMethodProjection) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanMFAOTPAddedType,
 					Reduce: p.reduceInitAuthMethod,
 				},
+				{
+					Event:  user.HumanPushDeviceAddedType,
+					Reduce: p.reduceInitAuthMethod,
+				},
 				{
 					Event:  user.HumanPasswordlessTokenVerifiedType,
 					Reduce: p.reduceActivateEvent,
@@ -90,6 +94,10 @@ func (p *userAuthMethodProjection) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanMFAOTPVerifiedType,
 					Reduce: p.reduceActivateEvent,
 				},
+				{
+					Event:  user.HumanPushDeviceVerifiedType,
+					Reduce: p.reduceActivateEvent,
+				},
 				{
 					Event:  user.HumanOTPSMSAddedType,
 					Reduce: p.reduceAddAuthMethod,
@@ -110,6 +118,10 @@ func (p *userAuthMethodProjection) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanMFAOTPRemovedType,
 					Reduce: p.reduceRemoveAuthMethod,
 				},
+				{
+					Event:  user.HumanPushDeviceRemovedType,
+					Reduce: p.reduceRemoveAuthMethod,
+				},
 				{
 					Event:  user.HumanOTPSMSRemovedType,
 					Reduce: p.reduceRemoveAuthMethod,
@@ -161,6 +173,9 @@ func (p *userAuthMethodProjection) reduceInitAuthMethod(event eventstore.Event)
 		tokenID = e.WebAuthNTokenID
 	case *user.HumanOTPAddedEvent:
 		methodType = domain.UserAuthMethodTypeTOTP
+	case *user.HumanPushDeviceAddedEvent:
+		methodType = domain.UserAuthMethodTypePush
+		tokenID = e.DeviceID
 	default:
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType})
 	}
@@ -204,6 +219,10 @@ func (p *userAuthMethodProjection) reduceActivateEvent(event eventstore.Event) (
 		name = e.WebAuthNTokenName
 	case *user.HumanOTPVerifiedEvent:
 		methodType = domain.UserAuthMethodTypeTOTP
+	case *user.HumanPushDeviceVerifiedEvent:
+		methodType = domain.UserAuthMethodTypePush
+		tokenID = e.DeviceID
+		name = e.Name
 
 	default:
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType})
@@ -267,6 +286,9 @@ func (p *userAuthMethodProjection) reduceRemoveAuthMethod(event eventstore.Event
 		tokenID = e.WebAuthNTokenID
 	case *user.HumanOTPRemovedEvent:
 		methodType = domain.UserAuthMethodTypeTOTP
+	case *user.HumanPushDeviceRemovedEvent:
+		methodType = domain.UserAuthMethodTypePush
+		tokenID = e.DeviceID
 	case *user.HumanOTPSMSRemovedEvent,
 		*user.HumanPhoneRemovedEvent:
 		methodType = domain.UserAuthMethodTypeOTPSMS
@@ -206,6 +206,43 @@ func TestUserAuthMethodProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceVerifiedPush",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.HumanPushDeviceVerifiedType,
+						user.AggregateType,
+						[]byte(`{
+						"deviceId": "device-id",
+						"name": "name"
+					}`),
+					), eventstore.GenericEventMapper[user.HumanPushDeviceVerifiedEvent]),
+			},
+			reduce: (&userAuthMethodProjection{}).reduceActivateEvent,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_auth_methods4 SET (change_date, sequence, name, state) = ($1, $2, $3, $4) WHERE (user_id = $5) AND (method_type = $6) AND (resource_owner = $7) AND (token_id = $8) AND (instance_id = $9)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"name",
+								domain.MFAStateReady,
+								"agg-id",
+								domain.UserAuthMethodTypePush,
+								"ro-id",
+								"device-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceVerifiedTOTP",
 			args: args{
@@ -371,6 +408,37 @@ func TestUserAuthMethodProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceRemovePush",
+			args: args{
+				event: getEvent(testEvent(
+					user.HumanPushDeviceRemovedType,
+					user.AggregateType,
+					[]byte(`{
+						"deviceId": "device-id"
+					}`),
+				), eventstore.GenericEventMapper[user.HumanPushDeviceRemovedEvent]),
+			},
+			reduce: (&userAuthMethodProjection{}).reduceRemoveAuthMethod,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_auth_methods4 WHERE (user_id = $1) AND (method_type = $2) AND (resource_owner = $3) AND (instance_id = $4) AND (token_id = $5)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								domain.UserAuthMethodTypePush,
+								"ro-id",
+								"instance-id",
+								"device-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceRemoveTOTP",
 			args: args{
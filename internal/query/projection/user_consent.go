@@ -0,0 +1,166 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	UserConsentProjectionTable = "projections.user_consents"
+
+	UserConsentColumnUserID        = "user_id"
+	UserConsentColumnCreationDate  = "creation_date"
+	UserConsentColumnChangeDate    = "change_date"
+	UserConsentColumnSequence      = "sequence"
+	UserConsentColumnResourceOwner = "resource_owner"
+	UserConsentColumnInstanceID    = "instance_id"
+	UserConsentColumnClientID      = "client_id"
+	UserConsentColumnProjectID     = "project_id"
+	UserConsentColumnScopes        = "scopes"
+)
+
+type userConsentProjection struct{}
+
+func newUserConsentProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(userConsentProjection))
+}
+
+func (*userConsentProjection) Name() string {
+	return UserConsentProjectionTable
+}
+
+func (*userConsentProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(UserConsentColumnUserID, handler.ColumnTypeText),
+			handler.NewColumn(UserConsentColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(UserConsentColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(UserConsentColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(UserConsentColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(UserConsentColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(UserConsentColumnClientID, handler.ColumnTypeText),
+			handler.NewColumn(UserConsentColumnProjectID, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(UserConsentColumnScopes, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(UserConsentColumnInstanceID, UserConsentColumnUserID, UserConsentColumnClientID),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{UserConsentColumnResourceOwner})),
+		),
+	)
+}
+
+func (p *userConsentProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.ConsentGrantedType,
+					Reduce: p.reduceConsentGranted,
+				},
+				{
+					Event:  user.ConsentRevokedType,
+					Reduce: p.reduceConsentRevoked,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(UserConsentColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *userConsentProjection) reduceConsentGranted(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.ConsentGrantedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Iem4a", "reduce.wrong.event.type %s", user.ConsentGrantedType)
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserConsentColumnInstanceID, nil),
+			handler.NewCol(UserConsentColumnUserID, nil),
+			handler.NewCol(UserConsentColumnClientID, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(UserConsentColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(UserConsentColumnUserID, e.Aggregate().ID),
+			handler.NewCol(UserConsentColumnClientID, e.ClientID),
+			handler.NewCol(UserConsentColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(UserConsentColumnCreationDate, handler.OnlySetValueOnInsert(UserConsentProjectionTable, e.CreationDate())),
+			handler.NewCol(UserConsentColumnChangeDate, e.CreationDate()),
+			handler.NewCol(UserConsentColumnSequence, e.Sequence()),
+			handler.NewCol(UserConsentColumnProjectID, e.ProjectID),
+			handler.NewCol(UserConsentColumnScopes, database.TextArray[string](e.Scopes)),
+		},
+	), nil
+}
+
+func (p *userConsentProjection) reduceConsentRevoked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.ConsentRevokedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Thai0", "reduce.wrong.event.type %s", user.ConsentRevokedType)
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserConsentColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserConsentColumnUserID, e.Aggregate().ID),
+			handler.NewCond(UserConsentColumnClientID, e.ClientID),
+		},
+	), nil
+}
+
+func (p *userConsentProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Doh3e", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserConsentColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserConsentColumnUserID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *userConsentProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Wu6ie", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserConsentColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserConsentColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
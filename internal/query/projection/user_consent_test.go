@@ -0,0 +1,187 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestUserConsentProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceConsentGranted",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.ConsentGrantedType,
+						user.AggregateType,
+						[]byte(`{
+						"clientId": "client-id",
+						"projectId": "project-id",
+						"scopes": ["email", "openid"]
+					}`),
+					), user.ConsentGrantedEventMapper),
+			},
+			reduce: (&userConsentProjection{}).reduceConsentGranted,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_consents (instance_id, user_id, client_id, resource_owner, creation_date, change_date, sequence, project_id, scopes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (instance_id, user_id, client_id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, project_id, scopes) = (EXCLUDED.resource_owner, projections.user_consents.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.project_id, EXCLUDED.scopes)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"client-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"project-id",
+								database.TextArray[string]{"email", "openid"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceConsentRevoked",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.ConsentRevokedType,
+						user.AggregateType,
+						[]byte(`{
+						"clientId": "client-id"
+					}`),
+					), user.ConsentRevokedEventMapper),
+			},
+			reduce: (&userConsentProjection{}).reduceConsentRevoked,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_consents WHERE (instance_id = $1) AND (user_id = $2) AND (client_id = $3)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"client-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.UserRemovedType,
+						user.AggregateType,
+						nil,
+					), user.UserRemovedEventMapper),
+			},
+			reduce: (&userConsentProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_consents WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "org reduceOwnerRemoved",
+			reduce: (&userConsentProjection{}).reduceOwnerRemoved,
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_consents WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(UserConsentColumnInstanceID),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_consents WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, UserConsentProjectionTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	WebAuthNAttestationPolicyTable = "projections.webauthn_attestation_policies"
+
+	WebAuthNAttestationPolicyIDCol                 = "id"
+	WebAuthNAttestationPolicyCreationDateCol       = "creation_date"
+	WebAuthNAttestationPolicyChangeDateCol         = "change_date"
+	WebAuthNAttestationPolicySequenceCol           = "sequence"
+	WebAuthNAttestationPolicyIsDefaultCol          = "is_default"
+	WebAuthNAttestationPolicyResourceOwnerCol      = "resource_owner"
+	WebAuthNAttestationPolicyInstanceIDCol         = "instance_id"
+	WebAuthNAttestationPolicyRequireAttestationCol = "require_attestation"
+	WebAuthNAttestationPolicyRequireMetadataCol    = "require_metadata"
+	WebAuthNAttestationPolicyAllowedAAGUIDsCol     = "allowed_aaguids"
+	WebAuthNAttestationPolicyDeniedAAGUIDsCol      = "denied_aaguids"
+)
+
+type webAuthNAttestationPolicyProjection struct{}
+
+func newWebAuthNAttestationPolicyProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(webAuthNAttestationPolicyProjection))
+}
+
+func (*webAuthNAttestationPolicyProjection) Name() string {
+	return WebAuthNAttestationPolicyTable
+}
+
+func (*webAuthNAttestationPolicyProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(WebAuthNAttestationPolicyIDCol, handler.ColumnTypeText),
+			handler.NewColumn(WebAuthNAttestationPolicyCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(WebAuthNAttestationPolicyChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(WebAuthNAttestationPolicySequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(WebAuthNAttestationPolicyIsDefaultCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(WebAuthNAttestationPolicyResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(WebAuthNAttestationPolicyInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(WebAuthNAttestationPolicyRequireAttestationCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(WebAuthNAttestationPolicyRequireMetadataCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(WebAuthNAttestationPolicyAllowedAAGUIDsCol, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(WebAuthNAttestationPolicyDeniedAAGUIDsCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(WebAuthNAttestationPolicyInstanceIDCol, WebAuthNAttestationPolicyIDCol),
+		),
+	)
+}
+
+func (p *webAuthNAttestationPolicyProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.WebAuthNAttestationPolicySetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(WebAuthNAttestationPolicyInstanceIDCol),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.WebAuthNAttestationPolicySetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.WebAuthNAttestationPolicyRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *webAuthNAttestationPolicyProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.WebAuthNAttestationPolicySetEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.WebAuthNAttestationPolicySetEvent:
+		policyEvent = e.WebAuthNAttestationPolicySetEvent
+		isDefault = false
+	case *instance.WebAuthNAttestationPolicySetEvent:
+		policyEvent = e.WebAuthNAttestationPolicySetEvent
+		isDefault = true
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-Nie5a", "reduce.wrong.event.type %v", []eventstore.EventType{org.WebAuthNAttestationPolicySetEventType, instance.WebAuthNAttestationPolicySetEventType})
+	}
+	return handler.NewUpsertStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(WebAuthNAttestationPolicyInstanceIDCol, nil),
+			handler.NewCol(WebAuthNAttestationPolicyIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(WebAuthNAttestationPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCol(WebAuthNAttestationPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
+			handler.NewCol(WebAuthNAttestationPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
+			handler.NewCol(WebAuthNAttestationPolicyCreationDateCol, handler.OnlySetValueOnInsert(WebAuthNAttestationPolicyTable, policyEvent.CreationDate())),
+			handler.NewCol(WebAuthNAttestationPolicyChangeDateCol, policyEvent.CreationDate()),
+			handler.NewCol(WebAuthNAttestationPolicySequenceCol, policyEvent.Sequence()),
+			handler.NewCol(WebAuthNAttestationPolicyIsDefaultCol, isDefault),
+			handler.NewCol(WebAuthNAttestationPolicyRequireAttestationCol, policyEvent.RequireAttestation),
+			handler.NewCol(WebAuthNAttestationPolicyRequireMetadataCol, policyEvent.RequireMetadata),
+			handler.NewCol(WebAuthNAttestationPolicyAllowedAAGUIDsCol, database.TextArray[string](policyEvent.AllowedAAGUIDs)),
+			handler.NewCol(WebAuthNAttestationPolicyDeniedAAGUIDsCol, database.TextArray[string](policyEvent.DeniedAAGUIDs)),
+		},
+	), nil
+}
+
+func (p *webAuthNAttestationPolicyProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	policyEvent, ok := event.(*org.WebAuthNAttestationPolicyRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-ueT4e", "reduce.wrong.event.type %s", org.WebAuthNAttestationPolicyRemovedEventType)
+	}
+	return handler.NewDeleteStatement(
+		policyEvent,
+		[]handler.Condition{
+			handler.NewCond(WebAuthNAttestationPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCond(WebAuthNAttestationPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *webAuthNAttestationPolicyProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-Ohd3e", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(WebAuthNAttestationPolicyInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(WebAuthNAttestationPolicyResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
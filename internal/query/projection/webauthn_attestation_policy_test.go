@@ -0,0 +1,194 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestWebAuthNAttestationPolicyProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.WebAuthNAttestationPolicySetEventType,
+						instance.AggregateType,
+						[]byte(`{
+	"requireAttestation": true,
+	"requireMetadata": true,
+	"allowedAaguids": ["cb69481e-8ff7-4039-93ec-0a2729a154a8"]
+}`),
+					), instance.WebAuthNAttestationPolicySetEventMapper),
+			},
+			reduce: (&webAuthNAttestationPolicyProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.webauthn_attestation_policies (id, instance_id, resource_owner, creation_date, change_date, sequence, is_default, require_attestation, require_metadata, allowed_aaguids, denied_aaguids) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (instance_id, id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, is_default, require_attestation, require_metadata, allowed_aaguids, denied_aaguids) = (EXCLUDED.resource_owner, projections.webauthn_attestation_policies.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.is_default, EXCLUDED.require_attestation, EXCLUDED.require_metadata, EXCLUDED.allowed_aaguids, EXCLUDED.denied_aaguids)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								true,
+								true,
+								true,
+								database.TextArray[string]{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+								database.TextArray[string](nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.WebAuthNAttestationPolicySetEventType,
+						org.AggregateType,
+						[]byte(`{"deniedAaguids": ["cb69481e-8ff7-4039-93ec-0a2729a154a8"]}`),
+					), org.WebAuthNAttestationPolicySetEventMapper),
+			},
+			reduce: (&webAuthNAttestationPolicyProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.webauthn_attestation_policies (id, instance_id, resource_owner, creation_date, change_date, sequence, is_default, require_attestation, require_metadata, allowed_aaguids, denied_aaguids) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (instance_id, id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, is_default, require_attestation, require_metadata, allowed_aaguids, denied_aaguids) = (EXCLUDED.resource_owner, projections.webauthn_attestation_policies.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.is_default, EXCLUDED.require_attestation, EXCLUDED.require_metadata, EXCLUDED.allowed_aaguids, EXCLUDED.denied_aaguids)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								false,
+								false,
+								false,
+								database.TextArray[string](nil),
+								database.TextArray[string]{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.WebAuthNAttestationPolicyRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.WebAuthNAttestationPolicyRemovedEventMapper),
+			},
+			reduce: (&webAuthNAttestationPolicyProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.webauthn_attestation_policies WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&webAuthNAttestationPolicyProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.webauthn_attestation_policies WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(WebAuthNAttestationPolicyInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.webauthn_attestation_policies WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, WebAuthNAttestationPolicyTable, tt.want)
+		})
+	}
+}
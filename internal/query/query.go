@@ -11,6 +11,7 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache/connector"
 	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
@@ -38,6 +39,7 @@ type Queries struct {
 	zitadelRoles                        []authz.RoleMapping
 	multifactors                        domain.MultifactorConfigs
 	defaultAuditLogRetention            time.Duration
+	caches                              *Caches
 }
 
 func StartQueries(
@@ -52,6 +54,7 @@ func StartQueries(
 	permissionCheck func(q *Queries) domain.PermissionCheck,
 	defaultAuditLogRetention time.Duration,
 	systemAPIUsers map[string]*authz.SystemAPIUser,
+	cacheConnectors connector.Connectors,
 	startProjections bool,
 ) (repo *Queries, err error) {
 	repo = &Queries{
@@ -79,6 +82,11 @@ func StartQueries(
 	if err != nil {
 		return nil, err
 	}
+	repo.caches, err = startCaches(cacheConnectors)
+	if err != nil {
+		return nil, err
+	}
+	repo.caches.registerInvalidations()
 	if startProjections {
 		projection.Start(ctx)
 	}
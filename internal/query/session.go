@@ -42,6 +42,7 @@ type Session struct {
 	TOTPFactor     SessionTOTPFactor
 	OTPSMSFactor   SessionOTPFactor
 	OTPEmailFactor SessionOTPFactor
+	PushFactor     SessionPushFactor
 	Metadata       map[string][]byte
 	UserAgent      domain.UserAgent
 	Expiration     time.Time
@@ -76,6 +77,10 @@ type SessionOTPFactor struct {
 	OTPCheckedAt time.Time
 }
 
+type SessionPushFactor struct {
+	PushCheckedAt time.Time
+}
+
 type SessionsSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -166,6 +171,10 @@ var (
 		name:  projection.SessionColumnOTPEmailCheckedAt,
 		table: sessionsTable,
 	}
+	SessionColumnPushCheckedAt = Column{
+		name:  projection.SessionColumnPushCheckedAt,
+		table: sessionsTable,
+	}
 	SessionColumnMetadata = Column{
 		name:  projection.SessionColumnMetadata,
 		table: sessionsTable,
@@ -238,6 +247,7 @@ func (q *Queries) SessionByID(ctx context.Context, shouldTriggerBulk bool, id, s
 func (q *Queries) SearchSessions(ctx context.Context, queries *SessionsSearchQueries) (sessions *Sessions, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
+	ctx = database.WithCallType(ctx, database.CallTypeList)
 
 	query, scan := prepareSessionsQuery(ctx, q.client)
 	stmt, args, err := queries.toQuery(query).
@@ -302,6 +312,7 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			SessionColumnTOTPCheckedAt.identifier(),
 			SessionColumnOTPSMSCheckedAt.identifier(),
 			SessionColumnOTPEmailCheckedAt.identifier(),
+			SessionColumnPushCheckedAt.identifier(),
 			SessionColumnMetadata.identifier(),
 			SessionColumnToken.identifier(),
 			SessionColumnUserAgentFingerprintID.identifier(),
@@ -329,6 +340,7 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 				totpCheckedAt       sql.NullTime
 				otpSMSCheckedAt     sql.NullTime
 				otpEmailCheckedAt   sql.NullTime
+				pushCheckedAt       sql.NullTime
 				metadata            database.Map[[]byte]
 				token               sql.NullString
 				userAgentIP         sql.NullString
@@ -356,6 +368,7 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 				&totpCheckedAt,
 				&otpSMSCheckedAt,
 				&otpEmailCheckedAt,
+				&pushCheckedAt,
 				&metadata,
 				&token,
 				&session.UserAgent.FingerprintID,
@@ -384,6 +397,7 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			session.TOTPFactor.TOTPCheckedAt = totpCheckedAt.Time
 			session.OTPSMSFactor.OTPCheckedAt = otpSMSCheckedAt.Time
 			session.OTPEmailFactor.OTPCheckedAt = otpEmailCheckedAt.Time
+			session.PushFactor.PushCheckedAt = pushCheckedAt.Time
 			session.Metadata = metadata
 			session.UserAgent.Header = http.Header(userAgentHeader)
 			if userAgentIP.Valid {
@@ -415,6 +429,7 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 			SessionColumnTOTPCheckedAt.identifier(),
 			SessionColumnOTPSMSCheckedAt.identifier(),
 			SessionColumnOTPEmailCheckedAt.identifier(),
+			SessionColumnPushCheckedAt.identifier(),
 			SessionColumnMetadata.identifier(),
 			SessionColumnExpiration.identifier(),
 			countColumn.identifier(),
@@ -441,6 +456,7 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 					totpCheckedAt       sql.NullTime
 					otpSMSCheckedAt     sql.NullTime
 					otpEmailCheckedAt   sql.NullTime
+					pushCheckedAt       sql.NullTime
 					metadata            database.Map[[]byte]
 					expiration          sql.NullTime
 				)
@@ -465,6 +481,7 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 					&totpCheckedAt,
 					&otpSMSCheckedAt,
 					&otpEmailCheckedAt,
+					&pushCheckedAt,
 					&metadata,
 					&expiration,
 					&sessions.Count,
@@ -485,6 +502,7 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 				session.TOTPFactor.TOTPCheckedAt = totpCheckedAt.Time
 				session.OTPSMSFactor.OTPCheckedAt = otpSMSCheckedAt.Time
 				session.OTPEmailFactor.OTPCheckedAt = otpEmailCheckedAt.Time
+				session.PushFactor.PushCheckedAt = pushCheckedAt.Time
 				session.Metadata = metadata
 				session.Expiration = expiration.Time
 
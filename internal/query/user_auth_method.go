@@ -172,6 +172,31 @@ func (q *Queries) ListActiveUserAuthMethodTypes(ctx context.Context, userID stri
 	return userAuthMethodTypes, err
 }
 
+// ListUserPasskeys returns the verified passkeys of the user.
+// Users can always list their own passkeys, for other users the user.read permission is required.
+func (q *Queries) ListUserPasskeys(ctx context.Context, userID string) (_ *AuthMethods, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	ctxData := authz.GetCtxData(ctx)
+	if ctxData.UserID != userID {
+		if err := q.checkPermission(ctx, domain.PermissionUserRead, ctxData.OrgID, userID); err != nil {
+			return nil, err
+		}
+	}
+	queries := new(UserAuthMethodSearchQueries)
+	if err = queries.AppendUserIDQuery(userID); err != nil {
+		return nil, err
+	}
+	if err = queries.AppendAuthMethodQuery(domain.UserAuthMethodTypePasswordless); err != nil {
+		return nil, err
+	}
+	if err = queries.AppendStateQuery(domain.MFAStateReady); err != nil {
+		return nil, err
+	}
+	return q.SearchUserAuthMethods(ctx, queries, false)
+}
+
 func (q *Queries) ListUserAuthMethodTypesRequired(ctx context.Context, userID string) (userAuthMethodTypes []domain.UserAuthMethodType, forceMFA, forceMFALocalOnly bool, err error) {
 	ctxData := authz.GetCtxData(ctx)
 	if ctxData.UserID != userID {
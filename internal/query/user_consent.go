@@ -0,0 +1,249 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type UserConsents struct {
+	SearchResponse
+	Consents []*UserConsent
+}
+
+type UserConsent struct {
+	UserID        string
+	ClientID      string
+	ProjectID     string
+	Scopes        database.TextArray[string]
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+}
+
+type UserConsentSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+var (
+	userConsentTable = table{
+		name:          projection.UserConsentProjectionTable,
+		instanceIDCol: projection.UserConsentColumnInstanceID,
+	}
+	UserConsentUserIDCol = Column{
+		name:  projection.UserConsentColumnUserID,
+		table: userConsentTable,
+	}
+	UserConsentClientIDCol = Column{
+		name:  projection.UserConsentColumnClientID,
+		table: userConsentTable,
+	}
+	UserConsentProjectIDCol = Column{
+		name:  projection.UserConsentColumnProjectID,
+		table: userConsentTable,
+	}
+	UserConsentScopesCol = Column{
+		name:  projection.UserConsentColumnScopes,
+		table: userConsentTable,
+	}
+	UserConsentCreationDateCol = Column{
+		name:  projection.UserConsentColumnCreationDate,
+		table: userConsentTable,
+	}
+	UserConsentChangeDateCol = Column{
+		name:  projection.UserConsentColumnChangeDate,
+		table: userConsentTable,
+	}
+	UserConsentResourceOwnerCol = Column{
+		name:  projection.UserConsentColumnResourceOwner,
+		table: userConsentTable,
+	}
+	UserConsentInstanceIDCol = Column{
+		name:  projection.UserConsentColumnInstanceID,
+		table: userConsentTable,
+	}
+	UserConsentSequenceCol = Column{
+		name:  projection.UserConsentColumnSequence,
+		table: userConsentTable,
+	}
+)
+
+// UserConsentByClientID returns the consent the user granted to the client.
+// It's used during authorization and therefore does not check any permission.
+func (q *Queries) UserConsentByClientID(ctx context.Context, shouldTriggerBulk bool, userID, clientID string) (consent *UserConsent, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerUserConsentProjection")
+		ctx, err = projection.UserConsentProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+
+	query, scan := prepareUserConsentQuery(ctx, q.client)
+	eq := sq.Eq{
+		UserConsentUserIDCol.identifier():     userID,
+		UserConsentClientIDCol.identifier():   clientID,
+		UserConsentInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-ooW4e", "Errors.Query.SQLStatment")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		consent, err = scan(row)
+		return err
+	}, stmt, args...)
+	return consent, err
+}
+
+// SearchUserConsents returns the consents the user granted to clients.
+// Other users than the user itself need the user.read permission.
+func (q *Queries) SearchUserConsents(ctx context.Context, userID string, queries *UserConsentSearchQueries) (consents *UserConsents, err error) {
+	ctxData := authz.GetCtxData(ctx)
+	if ctxData.UserID != userID {
+		if err := q.checkPermission(ctx, domain.PermissionUserRead, ctxData.OrgID, userID); err != nil {
+			return nil, err
+		}
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	ctx = database.WithCallType(ctx, database.CallTypeList)
+
+	query, scan := prepareUserConsentsQuery(ctx, q.client)
+	eq := sq.Eq{
+		UserConsentUserIDCol.identifier():     userID,
+		UserConsentInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-Lah9a", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		consents, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	consents.State, err = q.latestState(ctx, userConsentTable)
+	return consents, err
+}
+
+func (q *UserConsentSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewUserConsentClientIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserConsentClientIDCol, value, TextEquals)
+}
+
+func NewUserConsentProjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserConsentProjectIDCol, value, TextEquals)
+}
+
+func prepareUserConsentQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*UserConsent, error)) {
+	return sq.Select(
+			UserConsentUserIDCol.identifier(),
+			UserConsentClientIDCol.identifier(),
+			UserConsentProjectIDCol.identifier(),
+			UserConsentScopesCol.identifier(),
+			UserConsentCreationDateCol.identifier(),
+			UserConsentChangeDateCol.identifier(),
+			UserConsentResourceOwnerCol.identifier(),
+			UserConsentSequenceCol.identifier(),
+		).
+			From(userConsentTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*UserConsent, error) {
+			c := new(UserConsent)
+			err := row.Scan(
+				&c.UserID,
+				&c.ClientID,
+				&c.ProjectID,
+				&c.Scopes,
+				&c.CreationDate,
+				&c.ChangeDate,
+				&c.ResourceOwner,
+				&c.Sequence,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-eiP4o", "Errors.User.Consent.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ahc1o", "Errors.Internal")
+			}
+			return c, nil
+		}
+}
+
+func prepareUserConsentsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*UserConsents, error)) {
+	return sq.Select(
+			UserConsentUserIDCol.identifier(),
+			UserConsentClientIDCol.identifier(),
+			UserConsentProjectIDCol.identifier(),
+			UserConsentScopesCol.identifier(),
+			UserConsentCreationDateCol.identifier(),
+			UserConsentChangeDateCol.identifier(),
+			UserConsentResourceOwnerCol.identifier(),
+			UserConsentSequenceCol.identifier(),
+			countColumn.identifier(),
+		).
+			From(userConsentTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserConsents, error) {
+			consents := make([]*UserConsent, 0)
+			var count uint64
+			for rows.Next() {
+				c := new(UserConsent)
+				err := rows.Scan(
+					&c.UserID,
+					&c.ClientID,
+					&c.ProjectID,
+					&c.Scopes,
+					&c.CreationDate,
+					&c.ChangeDate,
+					&c.ResourceOwner,
+					&c.Sequence,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				consents = append(consents, c)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Zoh8u", "Errors.Query.CloseRows")
+			}
+
+			return &UserConsents{
+				Consents: consents,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
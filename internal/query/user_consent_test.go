@@ -0,0 +1,220 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	userConsentQuery = `SELECT projections.user_consents.user_id,` +
+		` projections.user_consents.client_id,` +
+		` projections.user_consents.project_id,` +
+		` projections.user_consents.scopes,` +
+		` projections.user_consents.creation_date,` +
+		` projections.user_consents.change_date,` +
+		` projections.user_consents.resource_owner,` +
+		` projections.user_consents.sequence` +
+		` FROM projections.user_consents` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	userConsentCols = []string{
+		"user_id",
+		"client_id",
+		"project_id",
+		"scopes",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+	}
+	userConsentsQuery = `SELECT projections.user_consents.user_id,` +
+		` projections.user_consents.client_id,` +
+		` projections.user_consents.project_id,` +
+		` projections.user_consents.scopes,` +
+		` projections.user_consents.creation_date,` +
+		` projections.user_consents.change_date,` +
+		` projections.user_consents.resource_owner,` +
+		` projections.user_consents.sequence,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.user_consents` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	userConsentsCols = append(userConsentCols, "count")
+)
+
+func Test_UserConsentPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserConsentQuery no result",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQueryScanErr(
+					regexp.QuoteMeta(userConsentQuery),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserConsent)(nil),
+		},
+		{
+			name:    "prepareUserConsentQuery found",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(userConsentQuery),
+					userConsentCols,
+					[]driver.Value{
+						"user-id",
+						"client-id",
+						"project-id",
+						database.TextArray[string]{"email", "openid"},
+						testNow,
+						testNow,
+						"resource_owner",
+						uint64(20211108),
+					},
+				),
+			},
+			object: &UserConsent{
+				UserID:        "user-id",
+				ClientID:      "client-id",
+				ProjectID:     "project-id",
+				Scopes:        database.TextArray[string]{"email", "openid"},
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "resource_owner",
+				Sequence:      20211108,
+			},
+		},
+		{
+			name:    "prepareUserConsentQuery sql err",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(userConsentQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserConsent)(nil),
+		},
+		{
+			name:    "prepareUserConsentsQuery no result",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(userConsentsQuery),
+					nil,
+					nil,
+				),
+			},
+			object: &UserConsents{Consents: []*UserConsent{}},
+		},
+		{
+			name:    "prepareUserConsentsQuery multiple results",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(userConsentsQuery),
+					userConsentsCols,
+					[][]driver.Value{
+						{
+							"user-id",
+							"client-id",
+							"project-id",
+							database.TextArray[string]{"openid"},
+							testNow,
+							testNow,
+							"resource_owner",
+							uint64(20211108),
+						},
+						{
+							"user-id",
+							"client-id2",
+							"project-id",
+							database.TextArray[string]{"email", "openid"},
+							testNow,
+							testNow,
+							"resource_owner",
+							uint64(20211109),
+						},
+					},
+				),
+			},
+			object: &UserConsents{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Consents: []*UserConsent{
+					{
+						UserID:        "user-id",
+						ClientID:      "client-id",
+						ProjectID:     "project-id",
+						Scopes:        database.TextArray[string]{"openid"},
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "resource_owner",
+						Sequence:      20211108,
+					},
+					{
+						UserID:        "user-id",
+						ClientID:      "client-id2",
+						ProjectID:     "project-id",
+						Scopes:        database.TextArray[string]{"email", "openid"},
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "resource_owner",
+						Sequence:      20211109,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserConsentsQuery sql err",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(userConsentsQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserConsents)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type WebAuthNAttestationPolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+
+	RequireAttestation bool
+	RequireMetadata    bool
+	AllowedAAGUIDs     database.TextArray[string]
+	DeniedAAGUIDs      database.TextArray[string]
+
+	IsDefault bool
+}
+
+var (
+	webAuthNAttestationPolicyTable = table{
+		name:          projection.WebAuthNAttestationPolicyTable,
+		instanceIDCol: projection.WebAuthNAttestationPolicyInstanceIDCol,
+	}
+	WebAuthNAttestationPolicyColID = Column{
+		name:  projection.WebAuthNAttestationPolicyIDCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+	WebAuthNAttestationPolicyColSequence = Column{
+		name:  projection.WebAuthNAttestationPolicySequenceCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+	WebAuthNAttestationPolicyColCreationDate = Column{
+		name:  projection.WebAuthNAttestationPolicyCreationDateCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+	WebAuthNAttestationPolicyColChangeDate = Column{
+		name:  projection.WebAuthNAttestationPolicyChangeDateCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+	WebAuthNAttestationPolicyColResourceOwner = Column{
+		name:  projection.WebAuthNAttestationPolicyResourceOwnerCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+	WebAuthNAttestationPolicyColInstanceID = Column{
+		name:  projection.WebAuthNAttestationPolicyInstanceIDCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+	WebAuthNAttestationPolicyColRequireAttestation = Column{
+		name:  projection.WebAuthNAttestationPolicyRequireAttestationCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+	WebAuthNAttestationPolicyColRequireMetadata = Column{
+		name:  projection.WebAuthNAttestationPolicyRequireMetadataCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+	WebAuthNAttestationPolicyColAllowedAAGUIDs = Column{
+		name:  projection.WebAuthNAttestationPolicyAllowedAAGUIDsCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+	WebAuthNAttestationPolicyColDeniedAAGUIDs = Column{
+		name:  projection.WebAuthNAttestationPolicyDeniedAAGUIDsCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+	WebAuthNAttestationPolicyColIsDefault = Column{
+		name:  projection.WebAuthNAttestationPolicyIsDefaultCol,
+		table: webAuthNAttestationPolicyTable,
+	}
+)
+
+func (q *Queries) WebAuthNAttestationPolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string) (policy *WebAuthNAttestationPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerWebAuthNAttestationPolicyProjection")
+		ctx, err = projection.WebAuthNAttestationPolicyProjection.Trigger(ctx, handler.WithAwaitRunning())
+		traceSpan.EndWithError(err)
+		if err != nil {
+			return nil, err
+		}
+	}
+	stmt, scan := prepareWebAuthNAttestationPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(
+		sq.And{
+			sq.Eq{WebAuthNAttestationPolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			sq.Or{
+				sq.Eq{WebAuthNAttestationPolicyColID.identifier(): orgID},
+				sq.Eq{WebAuthNAttestationPolicyColID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			},
+		}).
+		OrderBy(WebAuthNAttestationPolicyColIsDefault.identifier()).Limit(1).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ing5u", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		policy, err = scan(row)
+		return err
+	}, query, args...)
+	if zerrors.IsNotFound(err) {
+		return emptyWebAuthNAttestationPolicy(ctx), nil
+	}
+	return policy, err
+}
+
+func (q *Queries) DefaultWebAuthNAttestationPolicy(ctx context.Context, shouldTriggerBulk bool) (policy *WebAuthNAttestationPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerWebAuthNAttestationPolicyProjection")
+		ctx, err = projection.WebAuthNAttestationPolicyProjection.Trigger(ctx, handler.WithAwaitRunning())
+		traceSpan.EndWithError(err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stmt, scan := prepareWebAuthNAttestationPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		WebAuthNAttestationPolicyColID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		WebAuthNAttestationPolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).
+		OrderBy(WebAuthNAttestationPolicyColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Hoo4e", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		policy, err = scan(row)
+		return err
+	}, query, args...)
+	if zerrors.IsNotFound(err) {
+		return emptyWebAuthNAttestationPolicy(ctx), nil
+	}
+	return policy, err
+}
+
+// emptyWebAuthNAttestationPolicy is returned if neither the organization nor the instance configured a policy,
+// which accepts any authenticator.
+func emptyWebAuthNAttestationPolicy(ctx context.Context) *WebAuthNAttestationPolicy {
+	return &WebAuthNAttestationPolicy{
+		ID:            authz.GetInstance(ctx).InstanceID(),
+		ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		IsDefault:     true,
+	}
+}
+
+func prepareWebAuthNAttestationPolicyQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*WebAuthNAttestationPolicy, error)) {
+	return sq.Select(
+			WebAuthNAttestationPolicyColID.identifier(),
+			WebAuthNAttestationPolicyColSequence.identifier(),
+			WebAuthNAttestationPolicyColCreationDate.identifier(),
+			WebAuthNAttestationPolicyColChangeDate.identifier(),
+			WebAuthNAttestationPolicyColResourceOwner.identifier(),
+			WebAuthNAttestationPolicyColRequireAttestation.identifier(),
+			WebAuthNAttestationPolicyColRequireMetadata.identifier(),
+			WebAuthNAttestationPolicyColAllowedAAGUIDs.identifier(),
+			WebAuthNAttestationPolicyColDeniedAAGUIDs.identifier(),
+			WebAuthNAttestationPolicyColIsDefault.identifier(),
+		).
+			From(webAuthNAttestationPolicyTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*WebAuthNAttestationPolicy, error) {
+			policy := new(WebAuthNAttestationPolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.RequireAttestation,
+				&policy.RequireMetadata,
+				&policy.AllowedAAGUIDs,
+				&policy.DeniedAAGUIDs,
+				&policy.IsDefault,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Wae4i", "Errors.Policy.WebAuthNAttestation.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Aiph7", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
@@ -0,0 +1,128 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	webAuthNAttestationPolicyStmt = regexp.QuoteMeta(`SELECT projections.webauthn_attestation_policies.id,` +
+		` projections.webauthn_attestation_policies.sequence,` +
+		` projections.webauthn_attestation_policies.creation_date,` +
+		` projections.webauthn_attestation_policies.change_date,` +
+		` projections.webauthn_attestation_policies.resource_owner,` +
+		` projections.webauthn_attestation_policies.require_attestation,` +
+		` projections.webauthn_attestation_policies.require_metadata,` +
+		` projections.webauthn_attestation_policies.allowed_aaguids,` +
+		` projections.webauthn_attestation_policies.denied_aaguids,` +
+		` projections.webauthn_attestation_policies.is_default` +
+		` FROM projections.webauthn_attestation_policies` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+	webAuthNAttestationPolicyCols = []string{
+		"id",
+		"sequence",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"require_attestation",
+		"require_metadata",
+		"allowed_aaguids",
+		"denied_aaguids",
+		"is_default",
+	}
+)
+
+func Test_WebAuthNAttestationPolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareWebAuthNAttestationPolicyQuery no result",
+			prepare: prepareWebAuthNAttestationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					webAuthNAttestationPolicyStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*WebAuthNAttestationPolicy)(nil),
+		},
+		{
+			name:    "prepareWebAuthNAttestationPolicyQuery found",
+			prepare: prepareWebAuthNAttestationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					webAuthNAttestationPolicyStmt,
+					webAuthNAttestationPolicyCols,
+					[]driver.Value{
+						"pol-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						"ro",
+						true,
+						true,
+						database.TextArray[string]{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+						database.TextArray[string]{"ee882879-721c-4913-9775-3dfcce97072a"},
+						false,
+					},
+				),
+			},
+			object: &WebAuthNAttestationPolicy{
+				ID:                 "pol-id",
+				CreationDate:       testNow,
+				ChangeDate:         testNow,
+				Sequence:           20211109,
+				ResourceOwner:      "ro",
+				RequireAttestation: true,
+				RequireMetadata:    true,
+				AllowedAAGUIDs:     database.TextArray[string]{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+				DeniedAAGUIDs:      database.TextArray[string]{"ee882879-721c-4913-9775-3dfcce97072a"},
+				IsDefault:          false,
+			},
+		},
+		{
+			name:    "prepareWebAuthNAttestationPolicyQuery sql err",
+			prepare: prepareWebAuthNAttestationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					webAuthNAttestationPolicyStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*WebAuthNAttestationPolicy)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
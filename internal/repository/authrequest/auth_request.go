@@ -36,6 +36,8 @@ type AddedEvent struct {
 	MaxAge        *time.Duration            `json:"max_age,omitempty"`
 	LoginHint     *string                   `json:"login_hint,omitempty"`
 	HintUserID    *string                   `json:"hint_user_id,omitempty"`
+
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorization_details,omitempty"`
 }
 
 func (e *AddedEvent) Payload() interface{} {
@@ -62,6 +64,7 @@ func NewAddedEvent(ctx context.Context,
 	maxAge *time.Duration,
 	loginHint,
 	hintUserID *string,
+	authorizationDetails domain.AuthorizationDetails,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -83,6 +86,8 @@ func NewAddedEvent(ctx context.Context,
 		MaxAge:        maxAge,
 		LoginHint:     loginHint,
 		HintUserID:    hintUserID,
+
+		AuthorizationDetails: authorizationDetails,
 	}
 }
 
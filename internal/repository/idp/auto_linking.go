@@ -0,0 +1,48 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AutoLinkingSetEvent replaces the automatic linking of external users to existing users of a provider.
+type AutoLinkingSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+	domain.IDPAutoLinking
+}
+
+func NewAutoLinkingSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	linking domain.IDPAutoLinking,
+) *AutoLinkingSetEvent {
+	return &AutoLinkingSetEvent{
+		BaseEvent:      *base,
+		ID:             id,
+		IDPAutoLinking: linking,
+	}
+}
+
+func (e *AutoLinkingSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *AutoLinkingSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func AutoLinkingSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &AutoLinkingSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Phoo5", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
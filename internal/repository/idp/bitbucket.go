@@ -0,0 +1,140 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type BitbucketIDPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID           string              `json:"id"`
+	Name         string              `json:"name,omitempty"`
+	ClientID     string              `json:"clientId,omitempty"`
+	ClientSecret *crypto.CryptoValue `json:"clientSecret,omitempty"`
+	Scopes       []string            `json:"scopes,omitempty"`
+	Options
+}
+
+func NewBitbucketIDPAddedEvent(
+	base *eventstore.BaseEvent,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options Options,
+) *BitbucketIDPAddedEvent {
+	return &BitbucketIDPAddedEvent{
+		BaseEvent:    *base,
+		ID:           id,
+		Name:         name,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		Options:      options,
+	}
+}
+
+func (e *BitbucketIDPAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *BitbucketIDPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func BitbucketIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &BitbucketIDPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-7fjME", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type BitbucketIDPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID           string              `json:"id"`
+	Name         *string             `json:"name,omitempty"`
+	ClientID     *string             `json:"clientId,omitempty"`
+	ClientSecret *crypto.CryptoValue `json:"clientSecret,omitempty"`
+	Scopes       []string            `json:"scopes,omitempty"`
+	OptionChanges
+}
+
+func NewBitbucketIDPChangedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	changes []BitbucketIDPChanges,
+) (*BitbucketIDPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IDP-K7td3", "Errors.NoChangesFound")
+	}
+	changedEvent := &BitbucketIDPChangedEvent{
+		BaseEvent: *base,
+		ID:        id,
+	}
+	for _, change := range changes {
+		change(changedEvent)
+	}
+	return changedEvent, nil
+}
+
+type BitbucketIDPChanges func(*BitbucketIDPChangedEvent)
+
+func ChangeBitbucketName(name string) func(*BitbucketIDPChangedEvent) {
+	return func(e *BitbucketIDPChangedEvent) {
+		e.Name = &name
+	}
+}
+func ChangeBitbucketClientID(clientID string) func(*BitbucketIDPChangedEvent) {
+	return func(e *BitbucketIDPChangedEvent) {
+		e.ClientID = &clientID
+	}
+}
+
+func ChangeBitbucketClientSecret(clientSecret *crypto.CryptoValue) func(*BitbucketIDPChangedEvent) {
+	return func(e *BitbucketIDPChangedEvent) {
+		e.ClientSecret = clientSecret
+	}
+}
+
+func ChangeBitbucketOptions(options OptionChanges) func(*BitbucketIDPChangedEvent) {
+	return func(e *BitbucketIDPChangedEvent) {
+		e.OptionChanges = options
+	}
+}
+
+func ChangeBitbucketScopes(scopes []string) func(*BitbucketIDPChangedEvent) {
+	return func(e *BitbucketIDPChangedEvent) {
+		e.Scopes = scopes
+	}
+}
+
+func (e *BitbucketIDPChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *BitbucketIDPChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func BitbucketIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &BitbucketIDPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-fM1Og", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
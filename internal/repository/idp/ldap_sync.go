@@ -0,0 +1,89 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// LDAPSyncSetEvent replaces the directory synchronization settings of an LDAP provider.
+type LDAPSyncSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+	domain.LDAPSync
+}
+
+func NewLDAPSyncSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	sync domain.LDAPSync,
+) *LDAPSyncSetEvent {
+	return &LDAPSyncSetEvent{
+		BaseEvent: *base,
+		ID:        id,
+		LDAPSync:  sync,
+	}
+}
+
+func (e *LDAPSyncSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPSyncSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LDAPSyncSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LDAPSyncSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Aeg4u", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+// LDAPSyncFinishedEvent reports the result of a run of the directory synchronization of an LDAP provider.
+type LDAPSyncFinishedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+	domain.LDAPSyncReport
+}
+
+func NewLDAPSyncFinishedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	report domain.LDAPSyncReport,
+) *LDAPSyncFinishedEvent {
+	return &LDAPSyncFinishedEvent{
+		BaseEvent:      *base,
+		ID:             id,
+		LDAPSyncReport: report,
+	}
+}
+
+func (e *LDAPSyncFinishedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPSyncFinishedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LDAPSyncFinishedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LDAPSyncFinishedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-ooR5i", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,140 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type LinkedInIDPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID           string              `json:"id"`
+	Name         string              `json:"name,omitempty"`
+	ClientID     string              `json:"clientId,omitempty"`
+	ClientSecret *crypto.CryptoValue `json:"clientSecret,omitempty"`
+	Scopes       []string            `json:"scopes,omitempty"`
+	Options
+}
+
+func NewLinkedInIDPAddedEvent(
+	base *eventstore.BaseEvent,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options Options,
+) *LinkedInIDPAddedEvent {
+	return &LinkedInIDPAddedEvent{
+		BaseEvent:    *base,
+		ID:           id,
+		Name:         name,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		Options:      options,
+	}
+}
+
+func (e *LinkedInIDPAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LinkedInIDPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LinkedInIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LinkedInIDPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-rMHl9", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type LinkedInIDPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID           string              `json:"id"`
+	Name         *string             `json:"name,omitempty"`
+	ClientID     *string             `json:"clientId,omitempty"`
+	ClientSecret *crypto.CryptoValue `json:"clientSecret,omitempty"`
+	Scopes       []string            `json:"scopes,omitempty"`
+	OptionChanges
+}
+
+func NewLinkedInIDPChangedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	changes []LinkedInIDPChanges,
+) (*LinkedInIDPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IDP-zC1ya", "Errors.NoChangesFound")
+	}
+	changedEvent := &LinkedInIDPChangedEvent{
+		BaseEvent: *base,
+		ID:        id,
+	}
+	for _, change := range changes {
+		change(changedEvent)
+	}
+	return changedEvent, nil
+}
+
+type LinkedInIDPChanges func(*LinkedInIDPChangedEvent)
+
+func ChangeLinkedInName(name string) func(*LinkedInIDPChangedEvent) {
+	return func(e *LinkedInIDPChangedEvent) {
+		e.Name = &name
+	}
+}
+func ChangeLinkedInClientID(clientID string) func(*LinkedInIDPChangedEvent) {
+	return func(e *LinkedInIDPChangedEvent) {
+		e.ClientID = &clientID
+	}
+}
+
+func ChangeLinkedInClientSecret(clientSecret *crypto.CryptoValue) func(*LinkedInIDPChangedEvent) {
+	return func(e *LinkedInIDPChangedEvent) {
+		e.ClientSecret = clientSecret
+	}
+}
+
+func ChangeLinkedInOptions(options OptionChanges) func(*LinkedInIDPChangedEvent) {
+	return func(e *LinkedInIDPChangedEvent) {
+		e.OptionChanges = options
+	}
+}
+
+func ChangeLinkedInScopes(scopes []string) func(*LinkedInIDPChangedEvent) {
+	return func(e *LinkedInIDPChangedEvent) {
+		e.Scopes = scopes
+	}
+}
+
+func (e *LinkedInIDPChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LinkedInIDPChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LinkedInIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LinkedInIDPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-dNN11", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
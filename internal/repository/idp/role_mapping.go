@@ -0,0 +1,48 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// RoleMappingSetEvent replaces the mapping of claim values of external users to project roles of a provider.
+type RoleMappingSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+	domain.IDPRoleMapping
+}
+
+func NewRoleMappingSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	mapping domain.IDPRoleMapping,
+) *RoleMappingSetEvent {
+	return &RoleMappingSetEvent{
+		BaseEvent:      *base,
+		ID:             id,
+		IDPRoleMapping: mapping,
+	}
+}
+
+func (e *RoleMappingSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *RoleMappingSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func RoleMappingSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &RoleMappingSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-ieT5a", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,185 @@
+package idp
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SAMLMetadataRefreshSetEvent replaces the metadata refresh settings of a SAML provider.
+type SAMLMetadataRefreshSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+	domain.SAMLMetadataRefresh
+}
+
+func NewSAMLMetadataRefreshSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	refresh domain.SAMLMetadataRefresh,
+) *SAMLMetadataRefreshSetEvent {
+	return &SAMLMetadataRefreshSetEvent{
+		BaseEvent:           *base,
+		ID:                  id,
+		SAMLMetadataRefresh: refresh,
+	}
+}
+
+func (e *SAMLMetadataRefreshSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLMetadataRefreshSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SAMLMetadataRefreshSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &SAMLMetadataRefreshSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Pha4e", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+// SAMLMetadataRefreshedEvent reports the result of a refresh of the metadata of a SAML provider.
+type SAMLMetadataRefreshedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+	domain.SAMLMetadataRefreshReport
+}
+
+func NewSAMLMetadataRefreshedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	report domain.SAMLMetadataRefreshReport,
+) *SAMLMetadataRefreshedEvent {
+	return &SAMLMetadataRefreshedEvent{
+		BaseEvent:                 *base,
+		ID:                        id,
+		SAMLMetadataRefreshReport: report,
+	}
+}
+
+func (e *SAMLMetadataRefreshedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLMetadataRefreshedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SAMLMetadataRefreshedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &SAMLMetadataRefreshedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-ooJ6u", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+// SAMLCertificateExpiringEvent alerts that a signing certificate of a SAML provider expires soon.
+// It's raised once per certificate.
+type SAMLCertificateExpiringEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID          string    `json:"id"`
+	Certificate []byte    `json:"certificate,omitempty"`
+	NotAfter    time.Time `json:"notAfter,omitempty"`
+
+	TriggeredAtOrigin string `json:"triggerOrigin,omitempty"`
+}
+
+func NewSAMLCertificateExpiringEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	certificate []byte,
+	notAfter time.Time,
+	triggeredAtOrigin string,
+) *SAMLCertificateExpiringEvent {
+	return &SAMLCertificateExpiringEvent{
+		BaseEvent:         *base,
+		ID:                id,
+		Certificate:       certificate,
+		NotAfter:          notAfter,
+		TriggeredAtOrigin: triggeredAtOrigin,
+	}
+}
+
+func (e *SAMLCertificateExpiringEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func (e *SAMLCertificateExpiringEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLCertificateExpiringEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SAMLCertificateExpiringEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &SAMLCertificateExpiringEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Ahj3o", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+// SAMLCertificateExpiringSentEvent records that the administrators were notified about an expiring signing certificate.
+type SAMLCertificateExpiringSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID          string `json:"id"`
+	Certificate []byte `json:"certificate,omitempty"`
+}
+
+func NewSAMLCertificateExpiringSentEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	certificate []byte,
+) *SAMLCertificateExpiringSentEvent {
+	return &SAMLCertificateExpiringSentEvent{
+		BaseEvent:   *base,
+		ID:          id,
+		Certificate: certificate,
+	}
+}
+
+func (e *SAMLCertificateExpiringSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLCertificateExpiringSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SAMLCertificateExpiringSentEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &SAMLCertificateExpiringSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Ees4u", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
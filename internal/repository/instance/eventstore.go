@@ -82,6 +82,14 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, AzureADIDPChangedEventType, AzureADIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GitHubIDPAddedEventType, GitHubIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GitHubIDPChangedEventType, GitHubIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, BitbucketIDPAddedEventType, BitbucketIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, BitbucketIDPChangedEventType, BitbucketIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SlackIDPAddedEventType, SlackIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SlackIDPChangedEventType, SlackIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, DiscordIDPAddedEventType, DiscordIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, DiscordIDPChangedEventType, DiscordIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, LinkedInIDPAddedEventType, LinkedInIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, LinkedInIDPChangedEventType, LinkedInIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GitHubEnterpriseIDPAddedEventType, GitHubEnterpriseIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GitHubEnterpriseIDPChangedEventType, GitHubEnterpriseIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GitLabIDPAddedEventType, GitLabIDPAddedEventMapper)
@@ -92,6 +100,14 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, GoogleIDPChangedEventType, GoogleIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LDAPIDPAddedEventType, LDAPIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LDAPIDPChangedEventType, LDAPIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, LDAPSyncSetEventType, LDAPSyncSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, LDAPSyncFinishedEventType, LDAPSyncFinishedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPRoleMappingSetEventType, IDPRoleMappingSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPAutoLinkingSetEventType, IDPAutoLinkingSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SAMLMetadataRefreshSetEventType, SAMLMetadataRefreshSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SAMLMetadataRefreshedEventType, SAMLMetadataRefreshedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SAMLCertificateExpiringEventType, SAMLCertificateExpiringEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SAMLCertificateExpiringSentEventType, SAMLCertificateExpiringSentEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, AppleIDPAddedEventType, AppleIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, AppleIDPChangedEventType, AppleIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPAddedEventType, SAMLIDPAddedEventMapper)
@@ -119,4 +135,5 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, InstanceRemovedEventType, InstanceRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, NotificationPolicyAddedEventType, NotificationPolicyAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, NotificationPolicyChangedEventType, NotificationPolicyChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, WebAuthNAttestationPolicySetEventType, WebAuthNAttestationPolicySetEventMapper)
 }
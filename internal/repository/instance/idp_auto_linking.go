@@ -0,0 +1,45 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+)
+
+const (
+	IDPAutoLinkingSetEventType eventstore.EventType = "instance.idp.auto.linking.set"
+)
+
+type IDPAutoLinkingSetEvent struct {
+	idp.AutoLinkingSetEvent
+}
+
+func NewIDPAutoLinkingSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	linking domain.IDPAutoLinking,
+) *IDPAutoLinkingSetEvent {
+	return &IDPAutoLinkingSetEvent{
+		AutoLinkingSetEvent: *idp.NewAutoLinkingSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPAutoLinkingSetEventType,
+			),
+			id,
+			linking,
+		),
+	}
+}
+
+func IDPAutoLinkingSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.AutoLinkingSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPAutoLinkingSetEvent{AutoLinkingSetEvent: *e.(*idp.AutoLinkingSetEvent)}, nil
+}
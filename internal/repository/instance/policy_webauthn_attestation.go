@@ -0,0 +1,41 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	WebAuthNAttestationPolicySetEventType = instanceEventTypePrefix + policy.WebAuthNAttestationPolicySetEventType
+)
+
+type WebAuthNAttestationPolicySetEvent struct {
+	policy.WebAuthNAttestationPolicySetEvent
+}
+
+func NewWebAuthNAttestationPolicySetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attestationPolicy domain.WebAuthNAttestationPolicy,
+) *WebAuthNAttestationPolicySetEvent {
+	return &WebAuthNAttestationPolicySetEvent{
+		WebAuthNAttestationPolicySetEvent: *policy.NewWebAuthNAttestationPolicySetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				WebAuthNAttestationPolicySetEventType),
+			attestationPolicy),
+	}
+}
+
+func WebAuthNAttestationPolicySetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := policy.WebAuthNAttestationPolicySetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthNAttestationPolicySetEvent{WebAuthNAttestationPolicySetEvent: *e.(*policy.WebAuthNAttestationPolicySetEvent)}, nil
+}
@@ -0,0 +1,70 @@
+package keypair
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	RevokedEventType = eventTypePrefix + "revoked"
+)
+
+// RevokedEvent immediately ends the use and the publication of the key pair and its certificate,
+// e.g. after the private key was compromised.
+// If RevokeAt is set, the key pair is used and published until then,
+// so its successor can be published before it's used.
+type RevokedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Reason   string    `json:"reason,omitempty"`
+	RevokeAt time.Time `json:"revokeAt,omitempty"`
+}
+
+// RevokedAt returns the time from which on the key pair is revoked
+func (e *RevokedEvent) RevokedAt() time.Time {
+	if e.RevokeAt.IsZero() {
+		return e.CreationDate()
+	}
+	return e.RevokeAt
+}
+
+func (e *RevokedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *RevokedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewRevokedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	reason string,
+	revokeAt time.Time,
+) *RevokedEvent {
+	return &RevokedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RevokedEventType,
+		),
+		Reason:   reason,
+		RevokeAt: revokeAt,
+	}
+}
+
+func RevokedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &RevokedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KEY-Rv0kd", "unable to unmarshal key pair revoked")
+	}
+
+	return e, nil
+}
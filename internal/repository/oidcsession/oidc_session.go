@@ -16,8 +16,25 @@ const (
 	RefreshTokenAddedType   = oidcSessionEventPrefix + "refresh_token.added"
 	RefreshTokenRenewedType = oidcSessionEventPrefix + "refresh_token.renewed"
 	RefreshTokenRevokedType = oidcSessionEventPrefix + "refresh_token.revoked"
+
+	// UniqueRefreshTokenRotation ensures that a refresh token of an OIDC session is only rotated once,
+	// even if the token is presented concurrently
+	UniqueRefreshTokenRotation = "oidc_session_refresh_token_rotation"
 )
 
+func NewAddRefreshTokenRotationUniqueConstraint(oidcSessionID, rotatedRefreshTokenID string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueRefreshTokenRotation,
+		oidcSessionID+":"+rotatedRefreshTokenID,
+		"Errors.User.RefreshToken.AlreadyRotated")
+}
+
+func NewRemoveRefreshTokenRotationUniqueConstraint(oidcSessionID, rotatedRefreshTokenID string) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueRefreshTokenRotation,
+		oidcSessionID+":"+rotatedRefreshTokenID)
+}
+
 type AddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -28,6 +45,8 @@ type AddedEvent struct {
 	Scope       []string                    `json:"scope"`
 	AuthMethods []domain.UserAuthMethodType `json:"authMethods"`
 	AuthTime    time.Time                   `json:"authTime"`
+
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorizationDetails,omitempty"`
 }
 
 func (e *AddedEvent) Payload() interface{} {
@@ -51,6 +70,7 @@ func NewAddedEvent(ctx context.Context,
 	scope []string,
 	authMethods []domain.UserAuthMethodType,
 	authTime time.Time,
+	authorizationDetails domain.AuthorizationDetails,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -65,6 +85,8 @@ func NewAddedEvent(ctx context.Context,
 		Scope:       scope,
 		AuthMethods: authMethods,
 		AuthTime:    authTime,
+
+		AuthorizationDetails: authorizationDetails,
 	}
 }
 
@@ -74,6 +96,10 @@ type AccessTokenAddedEvent struct {
 	ID       string        `json:"id"`
 	Scope    []string      `json:"scope"`
 	Lifetime time.Duration `json:"lifetime"`
+
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorizationDetails,omitempty"`
+	// CertificateThumbprint is the `x5t#S256` of the client certificate the token is bound to
+	CertificateThumbprint string `json:"certificateThumbprint,omitempty"`
 }
 
 func (e *AccessTokenAddedEvent) Payload() interface{} {
@@ -94,6 +120,8 @@ func NewAccessTokenAddedEvent(
 	id string,
 	scope []string,
 	lifetime time.Duration,
+	authorizationDetails domain.AuthorizationDetails,
+	certificateThumbprint string,
 ) *AccessTokenAddedEvent {
 	return &AccessTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -104,6 +132,9 @@ func NewAccessTokenAddedEvent(
 		ID:       id,
 		Scope:    scope,
 		Lifetime: lifetime,
+
+		AuthorizationDetails:  authorizationDetails,
+		CertificateThumbprint: certificateThumbprint,
 	}
 }
 
@@ -180,6 +211,10 @@ type RefreshTokenRenewedEvent struct {
 
 	ID           string        `json:"id"`
 	IdleLifetime time.Duration `json:"idleLifetime"`
+	// RotatedID is the id of the refresh token replaced by this renewal
+	RotatedID string `json:"rotatedID,omitempty"`
+
+	previousRotatedID string
 }
 
 func (e *RefreshTokenRenewedEvent) Payload() interface{} {
@@ -187,7 +222,17 @@ func (e *RefreshTokenRenewedEvent) Payload() interface{} {
 }
 
 func (e *RefreshTokenRenewedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
-	return nil
+	if e.RotatedID == "" {
+		return nil
+	}
+	constraints := []*eventstore.UniqueConstraint{
+		NewAddRefreshTokenRotationUniqueConstraint(e.Aggregate().ID, e.RotatedID),
+	}
+	// the previous rotation can't be renewed anymore, because the token changed
+	if e.previousRotatedID != "" {
+		constraints = append(constraints, NewRemoveRefreshTokenRotationUniqueConstraint(e.Aggregate().ID, e.previousRotatedID))
+	}
+	return constraints
 }
 
 func (e *RefreshTokenRenewedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
@@ -199,6 +244,8 @@ func NewRefreshTokenRenewedEvent(
 	aggregate *eventstore.Aggregate,
 	id string,
 	idleLifetime time.Duration,
+	rotatedID,
+	previousRotatedID string,
 ) *RefreshTokenRenewedEvent {
 	return &RefreshTokenRenewedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -206,8 +253,10 @@ func NewRefreshTokenRenewedEvent(
 			aggregate,
 			RefreshTokenRenewedType,
 		),
-		ID:           id,
-		IdleLifetime: idleLifetime,
+		ID:                id,
+		IdleLifetime:      idleLifetime,
+		RotatedID:         rotatedID,
+		previousRotatedID: previousRotatedID,
 	}
 }
 
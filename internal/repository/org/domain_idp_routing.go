@@ -0,0 +1,91 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	domainIDPRoutingEventPrefix         = domainEventPrefix + "idp.routing."
+	OrgDomainIDPRoutingSetEventType     = domainIDPRoutingEventPrefix + "set"
+	OrgDomainIDPRoutingRemovedEventType = domainIDPRoutingEventPrefix + "removed"
+)
+
+type DomainIDPRoutingSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Domain string `json:"domain,omitempty"`
+	domain.OrgDomainIDPRouting
+}
+
+func (e *DomainIDPRoutingSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *DomainIDPRoutingSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewDomainIDPRoutingSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, orgDomain string, routing domain.OrgDomainIDPRouting) *DomainIDPRoutingSetEvent {
+	return &DomainIDPRoutingSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OrgDomainIDPRoutingSetEventType,
+		),
+		Domain:              orgDomain,
+		OrgDomainIDPRouting: routing,
+	}
+}
+
+func DomainIDPRoutingSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	routingSet := &DomainIDPRoutingSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(routingSet)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ORG-eiN5a", "unable to unmarshal org domain idp routing set")
+	}
+
+	return routingSet, nil
+}
+
+type DomainIDPRoutingRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Domain string `json:"domain,omitempty"`
+}
+
+func (e *DomainIDPRoutingRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *DomainIDPRoutingRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewDomainIDPRoutingRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, orgDomain string) *DomainIDPRoutingRemovedEvent {
+	return &DomainIDPRoutingRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OrgDomainIDPRoutingRemovedEventType,
+		),
+		Domain: orgDomain,
+	}
+}
+
+func DomainIDPRoutingRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	routingRemoved := &DomainIDPRoutingRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(routingRemoved)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ORG-Ahb4e", "unable to unmarshal org domain idp routing removed")
+	}
+
+	return routingRemoved, nil
+}
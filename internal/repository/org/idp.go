@@ -22,6 +22,14 @@ const (
 	AzureADIDPChangedEventType          eventstore.EventType = "org.idp.azure.changed"
 	GitHubIDPAddedEventType             eventstore.EventType = "org.idp.github.added"
 	GitHubIDPChangedEventType           eventstore.EventType = "org.idp.github.changed"
+	BitbucketIDPAddedEventType          eventstore.EventType = "org.idp.bitbucket.added"
+	BitbucketIDPChangedEventType        eventstore.EventType = "org.idp.bitbucket.changed"
+	SlackIDPAddedEventType              eventstore.EventType = "org.idp.slack.added"
+	SlackIDPChangedEventType            eventstore.EventType = "org.idp.slack.changed"
+	DiscordIDPAddedEventType            eventstore.EventType = "org.idp.discord.added"
+	DiscordIDPChangedEventType          eventstore.EventType = "org.idp.discord.changed"
+	LinkedInIDPAddedEventType           eventstore.EventType = "org.idp.linkedin.added"
+	LinkedInIDPChangedEventType         eventstore.EventType = "org.idp.linkedin.changed"
 	GitHubEnterpriseIDPAddedEventType   eventstore.EventType = "org.idp.github_enterprise.added"
 	GitHubEnterpriseIDPChangedEventType eventstore.EventType = "org.idp.github_enterprise.changed"
 	GitLabIDPAddedEventType             eventstore.EventType = "org.idp.gitlab.added"
@@ -521,6 +529,310 @@ func GitHubIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, erro
 	return &GitHubIDPChangedEvent{GitHubIDPChangedEvent: *e.(*idp.GitHubIDPChangedEvent)}, nil
 }
 
+type BitbucketIDPAddedEvent struct {
+	idp.BitbucketIDPAddedEvent
+}
+
+func NewBitbucketIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *BitbucketIDPAddedEvent {
+
+	return &BitbucketIDPAddedEvent{
+		BitbucketIDPAddedEvent: *idp.NewBitbucketIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				BitbucketIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func BitbucketIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.BitbucketIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BitbucketIDPAddedEvent{BitbucketIDPAddedEvent: *e.(*idp.BitbucketIDPAddedEvent)}, nil
+}
+
+type BitbucketIDPChangedEvent struct {
+	idp.BitbucketIDPChangedEvent
+}
+
+func NewBitbucketIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.BitbucketIDPChanges,
+) (*BitbucketIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewBitbucketIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			BitbucketIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &BitbucketIDPChangedEvent{BitbucketIDPChangedEvent: *changedEvent}, nil
+}
+
+func BitbucketIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.BitbucketIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BitbucketIDPChangedEvent{BitbucketIDPChangedEvent: *e.(*idp.BitbucketIDPChangedEvent)}, nil
+}
+
+type SlackIDPAddedEvent struct {
+	idp.SlackIDPAddedEvent
+}
+
+func NewSlackIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *SlackIDPAddedEvent {
+
+	return &SlackIDPAddedEvent{
+		SlackIDPAddedEvent: *idp.NewSlackIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				SlackIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func SlackIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.SlackIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SlackIDPAddedEvent{SlackIDPAddedEvent: *e.(*idp.SlackIDPAddedEvent)}, nil
+}
+
+type SlackIDPChangedEvent struct {
+	idp.SlackIDPChangedEvent
+}
+
+func NewSlackIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.SlackIDPChanges,
+) (*SlackIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewSlackIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SlackIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &SlackIDPChangedEvent{SlackIDPChangedEvent: *changedEvent}, nil
+}
+
+func SlackIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.SlackIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SlackIDPChangedEvent{SlackIDPChangedEvent: *e.(*idp.SlackIDPChangedEvent)}, nil
+}
+
+type DiscordIDPAddedEvent struct {
+	idp.DiscordIDPAddedEvent
+}
+
+func NewDiscordIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *DiscordIDPAddedEvent {
+
+	return &DiscordIDPAddedEvent{
+		DiscordIDPAddedEvent: *idp.NewDiscordIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				DiscordIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func DiscordIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.DiscordIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiscordIDPAddedEvent{DiscordIDPAddedEvent: *e.(*idp.DiscordIDPAddedEvent)}, nil
+}
+
+type DiscordIDPChangedEvent struct {
+	idp.DiscordIDPChangedEvent
+}
+
+func NewDiscordIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.DiscordIDPChanges,
+) (*DiscordIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewDiscordIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DiscordIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &DiscordIDPChangedEvent{DiscordIDPChangedEvent: *changedEvent}, nil
+}
+
+func DiscordIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.DiscordIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiscordIDPChangedEvent{DiscordIDPChangedEvent: *e.(*idp.DiscordIDPChangedEvent)}, nil
+}
+
+type LinkedInIDPAddedEvent struct {
+	idp.LinkedInIDPAddedEvent
+}
+
+func NewLinkedInIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *LinkedInIDPAddedEvent {
+
+	return &LinkedInIDPAddedEvent{
+		LinkedInIDPAddedEvent: *idp.NewLinkedInIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				LinkedInIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func LinkedInIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LinkedInIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkedInIDPAddedEvent{LinkedInIDPAddedEvent: *e.(*idp.LinkedInIDPAddedEvent)}, nil
+}
+
+type LinkedInIDPChangedEvent struct {
+	idp.LinkedInIDPChangedEvent
+}
+
+func NewLinkedInIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.LinkedInIDPChanges,
+) (*LinkedInIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewLinkedInIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LinkedInIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &LinkedInIDPChangedEvent{LinkedInIDPChangedEvent: *changedEvent}, nil
+}
+
+func LinkedInIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LinkedInIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkedInIDPChangedEvent{LinkedInIDPChangedEvent: *e.(*idp.LinkedInIDPChangedEvent)}, nil
+}
+
 type GitHubEnterpriseIDPAddedEvent struct {
 	idp.GitHubEnterpriseIDPAddedEvent
 }
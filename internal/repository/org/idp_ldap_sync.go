@@ -0,0 +1,78 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+)
+
+const (
+	LDAPSyncSetEventType      eventstore.EventType = "org.idp.ldap.sync.set"
+	LDAPSyncFinishedEventType eventstore.EventType = "org.idp.ldap.sync.finished"
+)
+
+type LDAPSyncSetEvent struct {
+	idp.LDAPSyncSetEvent
+}
+
+func NewLDAPSyncSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	sync domain.LDAPSync,
+) *LDAPSyncSetEvent {
+	return &LDAPSyncSetEvent{
+		LDAPSyncSetEvent: *idp.NewLDAPSyncSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				LDAPSyncSetEventType,
+			),
+			id,
+			sync,
+		),
+	}
+}
+
+func LDAPSyncSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LDAPSyncSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LDAPSyncSetEvent{LDAPSyncSetEvent: *e.(*idp.LDAPSyncSetEvent)}, nil
+}
+
+type LDAPSyncFinishedEvent struct {
+	idp.LDAPSyncFinishedEvent
+}
+
+func NewLDAPSyncFinishedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	report domain.LDAPSyncReport,
+) *LDAPSyncFinishedEvent {
+	return &LDAPSyncFinishedEvent{
+		LDAPSyncFinishedEvent: *idp.NewLDAPSyncFinishedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				LDAPSyncFinishedEventType,
+			),
+			id,
+			report,
+		),
+	}
+}
+
+func LDAPSyncFinishedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LDAPSyncFinishedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LDAPSyncFinishedEvent{LDAPSyncFinishedEvent: *e.(*idp.LDAPSyncFinishedEvent)}, nil
+}
@@ -0,0 +1,45 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+)
+
+const (
+	IDPRoleMappingSetEventType eventstore.EventType = "org.idp.role.mapping.set"
+)
+
+type IDPRoleMappingSetEvent struct {
+	idp.RoleMappingSetEvent
+}
+
+func NewIDPRoleMappingSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	mapping domain.IDPRoleMapping,
+) *IDPRoleMappingSetEvent {
+	return &IDPRoleMappingSetEvent{
+		RoleMappingSetEvent: *idp.NewRoleMappingSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPRoleMappingSetEventType,
+			),
+			id,
+			mapping,
+		),
+	}
+}
+
+func IDPRoleMappingSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.RoleMappingSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPRoleMappingSetEvent{RoleMappingSetEvent: *e.(*idp.RoleMappingSetEvent)}, nil
+}
@@ -0,0 +1,150 @@
+package org
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+)
+
+const (
+	SAMLMetadataRefreshSetEventType  eventstore.EventType = "org.idp.saml.metadata.refresh.set"
+	SAMLMetadataRefreshedEventType   eventstore.EventType = "org.idp.saml.metadata.refreshed"
+	SAMLCertificateExpiringEventType eventstore.EventType = "org.idp.saml.certificate.expiring"
+
+	SAMLCertificateExpiringSentEventType eventstore.EventType = "org.idp.saml.certificate.expiring.sent"
+)
+
+type SAMLMetadataRefreshSetEvent struct {
+	idp.SAMLMetadataRefreshSetEvent
+}
+
+func NewSAMLMetadataRefreshSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	refresh domain.SAMLMetadataRefresh,
+) *SAMLMetadataRefreshSetEvent {
+	return &SAMLMetadataRefreshSetEvent{
+		SAMLMetadataRefreshSetEvent: *idp.NewSAMLMetadataRefreshSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				SAMLMetadataRefreshSetEventType,
+			),
+			id,
+			refresh,
+		),
+	}
+}
+
+func SAMLMetadataRefreshSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.SAMLMetadataRefreshSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SAMLMetadataRefreshSetEvent{SAMLMetadataRefreshSetEvent: *e.(*idp.SAMLMetadataRefreshSetEvent)}, nil
+}
+
+type SAMLMetadataRefreshedEvent struct {
+	idp.SAMLMetadataRefreshedEvent
+}
+
+func NewSAMLMetadataRefreshedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	report domain.SAMLMetadataRefreshReport,
+) *SAMLMetadataRefreshedEvent {
+	return &SAMLMetadataRefreshedEvent{
+		SAMLMetadataRefreshedEvent: *idp.NewSAMLMetadataRefreshedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				SAMLMetadataRefreshedEventType,
+			),
+			id,
+			report,
+		),
+	}
+}
+
+func SAMLMetadataRefreshedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.SAMLMetadataRefreshedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SAMLMetadataRefreshedEvent{SAMLMetadataRefreshedEvent: *e.(*idp.SAMLMetadataRefreshedEvent)}, nil
+}
+
+type SAMLCertificateExpiringEvent struct {
+	idp.SAMLCertificateExpiringEvent
+}
+
+func NewSAMLCertificateExpiringEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	certificate []byte,
+	notAfter time.Time,
+) *SAMLCertificateExpiringEvent {
+	return &SAMLCertificateExpiringEvent{
+		SAMLCertificateExpiringEvent: *idp.NewSAMLCertificateExpiringEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				SAMLCertificateExpiringEventType,
+			),
+			id,
+			certificate,
+			notAfter,
+			http.ComposedOrigin(ctx),
+		),
+	}
+}
+
+func SAMLCertificateExpiringEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.SAMLCertificateExpiringEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SAMLCertificateExpiringEvent{SAMLCertificateExpiringEvent: *e.(*idp.SAMLCertificateExpiringEvent)}, nil
+}
+
+type SAMLCertificateExpiringSentEvent struct {
+	idp.SAMLCertificateExpiringSentEvent
+}
+
+func NewSAMLCertificateExpiringSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	certificate []byte,
+) *SAMLCertificateExpiringSentEvent {
+	return &SAMLCertificateExpiringSentEvent{
+		SAMLCertificateExpiringSentEvent: *idp.NewSAMLCertificateExpiringSentEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				SAMLCertificateExpiringSentEventType,
+			),
+			id,
+			certificate,
+		),
+	}
+}
+
+func SAMLCertificateExpiringSentEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.SAMLCertificateExpiringSentEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SAMLCertificateExpiringSentEvent{SAMLCertificateExpiringSentEvent: *e.(*idp.SAMLCertificateExpiringSentEvent)}, nil
+}
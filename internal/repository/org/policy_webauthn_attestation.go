@@ -0,0 +1,70 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+var (
+	WebAuthNAttestationPolicySetEventType     = orgEventTypePrefix + policy.WebAuthNAttestationPolicySetEventType
+	WebAuthNAttestationPolicyRemovedEventType = orgEventTypePrefix + policy.WebAuthNAttestationPolicyRemovedEventType
+)
+
+type WebAuthNAttestationPolicySetEvent struct {
+	policy.WebAuthNAttestationPolicySetEvent
+}
+
+func NewWebAuthNAttestationPolicySetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attestationPolicy domain.WebAuthNAttestationPolicy,
+) *WebAuthNAttestationPolicySetEvent {
+	return &WebAuthNAttestationPolicySetEvent{
+		WebAuthNAttestationPolicySetEvent: *policy.NewWebAuthNAttestationPolicySetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				WebAuthNAttestationPolicySetEventType),
+			attestationPolicy,
+		),
+	}
+}
+
+func WebAuthNAttestationPolicySetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := policy.WebAuthNAttestationPolicySetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthNAttestationPolicySetEvent{WebAuthNAttestationPolicySetEvent: *e.(*policy.WebAuthNAttestationPolicySetEvent)}, nil
+}
+
+type WebAuthNAttestationPolicyRemovedEvent struct {
+	policy.WebAuthNAttestationPolicyRemovedEvent
+}
+
+func NewWebAuthNAttestationPolicyRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *WebAuthNAttestationPolicyRemovedEvent {
+	return &WebAuthNAttestationPolicyRemovedEvent{
+		WebAuthNAttestationPolicyRemovedEvent: *policy.NewWebAuthNAttestationPolicyRemovedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				WebAuthNAttestationPolicyRemovedEventType),
+		),
+	}
+}
+
+func WebAuthNAttestationPolicyRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := policy.WebAuthNAttestationPolicyRemovedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthNAttestationPolicyRemovedEvent{WebAuthNAttestationPolicyRemovedEvent: *e.(*policy.WebAuthNAttestationPolicyRemovedEvent)}, nil
+}
@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	WebAuthNAttestationPolicySetEventType     = "policy.webauthn.attestation.set"
+	WebAuthNAttestationPolicyRemovedEventType = "policy.webauthn.attestation.removed"
+)
+
+// WebAuthNAttestationPolicySetEvent replaces the restrictions of authenticators users are able to register.
+type WebAuthNAttestationPolicySetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	domain.WebAuthNAttestationPolicy
+}
+
+func (e *WebAuthNAttestationPolicySetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *WebAuthNAttestationPolicySetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewWebAuthNAttestationPolicySetEvent(
+	base *eventstore.BaseEvent,
+	policy domain.WebAuthNAttestationPolicy,
+) *WebAuthNAttestationPolicySetEvent {
+	return &WebAuthNAttestationPolicySetEvent{
+		BaseEvent:                 *base,
+		WebAuthNAttestationPolicy: policy,
+	}
+}
+
+func WebAuthNAttestationPolicySetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &WebAuthNAttestationPolicySetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "POLIC-Aeng7", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type WebAuthNAttestationPolicyRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *WebAuthNAttestationPolicyRemovedEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *WebAuthNAttestationPolicyRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewWebAuthNAttestationPolicyRemovedEvent(base *eventstore.BaseEvent) *WebAuthNAttestationPolicyRemovedEvent {
+	return &WebAuthNAttestationPolicyRemovedEvent{
+		BaseEvent: *base,
+	}
+}
+
+func WebAuthNAttestationPolicyRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &WebAuthNAttestationPolicyRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
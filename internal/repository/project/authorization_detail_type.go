@@ -0,0 +1,197 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	UniqueAuthorizationDetailType             = "project_authorization_detail_type"
+	authorizationDetailTypeEventTypePrefix    = projectEventTypePrefix + "authorization_detail_type."
+	AuthorizationDetailTypeAddedType          = authorizationDetailTypeEventTypePrefix + "added"
+	AuthorizationDetailTypeChangedType        = authorizationDetailTypeEventTypePrefix + "changed"
+	AuthorizationDetailTypeRemovedType        = authorizationDetailTypeEventTypePrefix + "removed"
+	authorizationDetailTypeAlreadyExistsError = "Errors.Project.AuthorizationDetailType.AlreadyExists"
+)
+
+func NewAddAuthorizationDetailTypeUniqueConstraint(detailType, projectID string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueAuthorizationDetailType,
+		fmt.Sprintf("%s:%s", detailType, projectID),
+		authorizationDetailTypeAlreadyExistsError)
+}
+
+func NewRemoveAuthorizationDetailTypeUniqueConstraint(detailType, projectID string) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueAuthorizationDetailType,
+		fmt.Sprintf("%s:%s", detailType, projectID))
+}
+
+type AuthorizationDetailTypeAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DetailType  string   `json:"type,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Actions     []string `json:"actions,omitempty"`
+	Locations   []string `json:"locations,omitempty"`
+}
+
+func (e *AuthorizationDetailTypeAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *AuthorizationDetailTypeAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddAuthorizationDetailTypeUniqueConstraint(e.DetailType, e.Aggregate().ID)}
+}
+
+func NewAuthorizationDetailTypeAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	detailType,
+	displayName string,
+	actions,
+	locations []string,
+) *AuthorizationDetailTypeAddedEvent {
+	return &AuthorizationDetailTypeAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AuthorizationDetailTypeAddedType,
+		),
+		DetailType:  detailType,
+		DisplayName: displayName,
+		Actions:     actions,
+		Locations:   locations,
+	}
+}
+
+func AuthorizationDetailTypeAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &AuthorizationDetailTypeAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "PROJECT-Yoh3a", "unable to unmarshal project authorization detail type")
+	}
+
+	return e, nil
+}
+
+type AuthorizationDetailTypeChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DetailType  string    `json:"type,omitempty"`
+	DisplayName *string   `json:"displayName,omitempty"`
+	Actions     *[]string `json:"actions,omitempty"`
+	Locations   *[]string `json:"locations,omitempty"`
+}
+
+func (e *AuthorizationDetailTypeChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *AuthorizationDetailTypeChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewAuthorizationDetailTypeChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	detailType string,
+	changes []AuthorizationDetailTypeChanges,
+) (*AuthorizationDetailTypeChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "PROJECT-ahS7o", "Errors.NoChangesFound")
+	}
+	changeEvent := &AuthorizationDetailTypeChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AuthorizationDetailTypeChangedType,
+		),
+		DetailType: detailType,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type AuthorizationDetailTypeChanges func(event *AuthorizationDetailTypeChangedEvent)
+
+func ChangeAuthorizationDetailTypeDisplayName(displayName string) func(event *AuthorizationDetailTypeChangedEvent) {
+	return func(e *AuthorizationDetailTypeChangedEvent) {
+		e.DisplayName = &displayName
+	}
+}
+
+func ChangeAuthorizationDetailTypeActions(actions []string) func(event *AuthorizationDetailTypeChangedEvent) {
+	return func(e *AuthorizationDetailTypeChangedEvent) {
+		e.Actions = &actions
+	}
+}
+
+func ChangeAuthorizationDetailTypeLocations(locations []string) func(event *AuthorizationDetailTypeChangedEvent) {
+	return func(e *AuthorizationDetailTypeChangedEvent) {
+		e.Locations = &locations
+	}
+}
+
+func AuthorizationDetailTypeChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &AuthorizationDetailTypeChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "PROJECT-Eiy2u", "unable to unmarshal project authorization detail type")
+	}
+
+	return e, nil
+}
+
+type AuthorizationDetailTypeRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DetailType string `json:"type,omitempty"`
+}
+
+func (e *AuthorizationDetailTypeRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *AuthorizationDetailTypeRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemoveAuthorizationDetailTypeUniqueConstraint(e.DetailType, e.Aggregate().ID)}
+}
+
+func NewAuthorizationDetailTypeRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	detailType string,
+) *AuthorizationDetailTypeRemovedEvent {
+	return &AuthorizationDetailTypeRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AuthorizationDetailTypeRemovedType,
+		),
+		DetailType: detailType,
+	}
+}
+
+func AuthorizationDetailTypeRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &AuthorizationDetailTypeRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "PROJECT-ohB3i", "unable to unmarshal project authorization detail type")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,60 @@
+package project
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	ApplicationClaimMappingsSetType = applicationEventTypePrefix + "claim_mappings.set"
+)
+
+// ApplicationClaimMappingsSetEvent replaces all claim mappings of an application.
+// An empty list removes all mappings.
+type ApplicationClaimMappingsSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID         string                 `json:"appId"`
+	ClaimMappings []*domain.ClaimMapping `json:"claimMappings,omitempty"`
+}
+
+func (e *ApplicationClaimMappingsSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ApplicationClaimMappingsSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewApplicationClaimMappingsSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+	claimMappings []*domain.ClaimMapping,
+) *ApplicationClaimMappingsSetEvent {
+	return &ApplicationClaimMappingsSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApplicationClaimMappingsSetType,
+		),
+		AppID:         appID,
+		ClaimMappings: claimMappings,
+	}
+}
+
+func ApplicationClaimMappingsSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ApplicationClaimMappingsSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "PROJECT-Eic5a", "unable to unmarshal application claim mappings")
+	}
+
+	return e, nil
+}
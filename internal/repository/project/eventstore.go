@@ -17,6 +17,9 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, RoleAddedType, RoleAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, RoleChangedType, RoleChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, RoleRemovedType, RoleRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, AuthorizationDetailTypeAddedType, AuthorizationDetailTypeAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, AuthorizationDetailTypeChangedType, AuthorizationDetailTypeChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, AuthorizationDetailTypeRemovedType, AuthorizationDetailTypeRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GrantAddedType, GrantAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GrantChangedType, GrantChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GrantCascadeChangedType, GrantCascadeChangedEventMapper)
@@ -32,6 +35,7 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, ApplicationRemovedType, ApplicationRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, ApplicationDeactivatedType, ApplicationDeactivatedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, ApplicationReactivatedType, ApplicationReactivatedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, ApplicationClaimMappingsSetType, ApplicationClaimMappingsSetEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, OIDCConfigAddedType, OIDCConfigAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, OIDCConfigChangedType, OIDCConfigChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, OIDCConfigSecretChangedType, OIDCConfigSecretChangedEventMapper)
@@ -44,4 +48,5 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, ApplicationKeyRemovedEventType, ApplicationKeyRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLConfigAddedType, SAMLConfigAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLConfigChangedType, SAMLConfigChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SAMLSSOSettingsSetType, SAMLSSOSettingsSetEventMapper)
 }
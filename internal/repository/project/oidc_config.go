@@ -39,6 +39,10 @@ type OIDCConfigAddedEvent struct {
 	ClockSkew                time.Duration              `json:"clockSkew,omitempty"`
 	AdditionalOrigins        []string                   `json:"additionalOrigins,omitempty"`
 	SkipNativeAppSuccessPage bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	ConsentRequired          bool                       `json:"consentRequired,omitempty"`
+	TLSClientAuthSubjectDN   string                     `json:"tlsClientAuthSubjectDn,omitempty"`
+	// RefreshTokenReuseGracePeriod overrides the default grace period for presenting the preceding refresh token again
+	RefreshTokenReuseGracePeriod time.Duration `json:"refreshTokenReuseGracePeriod,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Payload() interface{} {
@@ -70,6 +74,9 @@ func NewOIDCConfigAddedEvent(
 	clockSkew time.Duration,
 	additionalOrigins []string,
 	skipNativeAppSuccessPage bool,
+	consentRequired bool,
+	tlsClientAuthSubjectDN string,
+	refreshTokenReuseGracePeriod time.Duration,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -95,6 +102,10 @@ func NewOIDCConfigAddedEvent(
 		ClockSkew:                clockSkew,
 		AdditionalOrigins:        additionalOrigins,
 		SkipNativeAppSuccessPage: skipNativeAppSuccessPage,
+		ConsentRequired:          consentRequired,
+		TLSClientAuthSubjectDN:   tlsClientAuthSubjectDN,
+
+		RefreshTokenReuseGracePeriod: refreshTokenReuseGracePeriod,
 	}
 }
 
@@ -180,7 +191,16 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 			return false
 		}
 	}
-	return e.SkipNativeAppSuccessPage == c.SkipNativeAppSuccessPage
+	if e.SkipNativeAppSuccessPage != c.SkipNativeAppSuccessPage {
+		return false
+	}
+	if e.ConsentRequired != c.ConsentRequired {
+		return false
+	}
+	if e.TLSClientAuthSubjectDN != c.TLSClientAuthSubjectDN {
+		return false
+	}
+	return e.RefreshTokenReuseGracePeriod == c.RefreshTokenReuseGracePeriod
 }
 
 func OIDCConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
@@ -215,6 +235,10 @@ type OIDCConfigChangedEvent struct {
 	ClockSkew                *time.Duration              `json:"clockSkew,omitempty"`
 	AdditionalOrigins        *[]string                   `json:"additionalOrigins,omitempty"`
 	SkipNativeAppSuccessPage *bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	ConsentRequired          *bool                       `json:"consentRequired,omitempty"`
+	TLSClientAuthSubjectDN   *string                     `json:"tlsClientAuthSubjectDn,omitempty"`
+
+	RefreshTokenReuseGracePeriod *time.Duration `json:"refreshTokenReuseGracePeriod,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Payload() interface{} {
@@ -341,6 +365,24 @@ func ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage bool) func(event *O
 	}
 }
 
+func ChangeConsentRequired(consentRequired bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.ConsentRequired = &consentRequired
+	}
+}
+
+func ChangeTLSClientAuthSubjectDN(tlsClientAuthSubjectDN string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.TLSClientAuthSubjectDN = &tlsClientAuthSubjectDN
+	}
+}
+
+func ChangeRefreshTokenReuseGracePeriod(refreshTokenReuseGracePeriod time.Duration) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RefreshTokenReuseGracePeriod = &refreshTokenReuseGracePeriod
+	}
+}
+
 func OIDCConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
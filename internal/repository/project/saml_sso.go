@@ -0,0 +1,67 @@
+package project
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	SAMLSSOSettingsSetType = applicationEventTypePrefix + "config.saml.sso.set"
+)
+
+// SAMLSSOSettingsSetEvent replaces the IdP-initiated and WS-Federation settings of a SAML application.
+type SAMLSSOSettingsSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID               string   `json:"appId"`
+	IDPInitiatedEnabled bool     `json:"idpInitiatedEnabled,omitempty"`
+	RelayState          string   `json:"relayState,omitempty"`
+	WSFedEnabled        bool     `json:"wsFedEnabled,omitempty"`
+	WSFedReplyURLs      []string `json:"wsFedReplyUrls,omitempty"`
+}
+
+func (e *SAMLSSOSettingsSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLSSOSettingsSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewSAMLSSOSettingsSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+	idpInitiatedEnabled bool,
+	relayState string,
+	wsFedEnabled bool,
+	wsFedReplyURLs []string,
+) *SAMLSSOSettingsSetEvent {
+	return &SAMLSSOSettingsSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLSSOSettingsSetType,
+		),
+		AppID:               appID,
+		IDPInitiatedEnabled: idpInitiatedEnabled,
+		RelayState:          relayState,
+		WSFedEnabled:        wsFedEnabled,
+		WSFedReplyURLs:      wsFedReplyURLs,
+	}
+}
+
+func SAMLSSOSettingsSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &SAMLSSOSettingsSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "SAML-Eiw0a", "unable to unmarshal saml sso settings")
+	}
+
+	return e, nil
+}
@@ -16,6 +16,11 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, OTPEmailChallengedType, eventstore.GenericEventMapper[OTPEmailChallengedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, OTPEmailSentType, eventstore.GenericEventMapper[OTPEmailSentEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, OTPEmailCheckedType, eventstore.GenericEventMapper[OTPEmailCheckedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, PushChallengedType, eventstore.GenericEventMapper[PushChallengedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, PushSentType, eventstore.GenericEventMapper[PushSentEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, PushApprovedType, eventstore.GenericEventMapper[PushApprovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, PushDeniedType, eventstore.GenericEventMapper[PushDeniedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, PushCheckedType, eventstore.GenericEventMapper[PushCheckedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, TokenSetType, TokenSetEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MetadataSetType, MetadataSetEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LifetimeSetType, eventstore.GenericEventMapper[LifetimeSetEvent])
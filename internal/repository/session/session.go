@@ -26,6 +26,11 @@ const (
 	OTPEmailChallengedType = sessionEventPrefix + "otp.email.challenged"
 	OTPEmailSentType       = sessionEventPrefix + "otp.email.sent"
 	OTPEmailCheckedType    = sessionEventPrefix + "otp.email.checked"
+	PushChallengedType     = sessionEventPrefix + "push.challenged"
+	PushSentType           = sessionEventPrefix + "push.sent"
+	PushApprovedType       = sessionEventPrefix + "push.approved"
+	PushDeniedType         = sessionEventPrefix + "push.denied"
+	PushCheckedType        = sessionEventPrefix + "push.checked"
 	TokenSetType           = sessionEventPrefix + "token.set"
 	MetadataSetType        = sessionEventPrefix + "metadata.set"
 	LifetimeSetType        = sessionEventPrefix + "lifetime.set"
@@ -529,6 +534,202 @@ func NewOTPEmailCheckedEvent(
 	}
 }
 
+// PushChallengeDevice is a device of the user the push challenge is sent to.
+type PushChallengeDevice struct {
+	DeviceID  string `json:"deviceId"`
+	PushToken string `json:"pushToken"`
+}
+
+type PushChallengedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ChallengeID string        `json:"challengeId"`
+	Expiry      time.Duration `json:"expiry"`
+	// Number is displayed to the user and has to be entered on the device to approve the challenge (number matching)
+	Number            uint32                `json:"number"`
+	Devices           []PushChallengeDevice `json:"devices"`
+	TriggeredAtOrigin string                `json:"triggerOrigin,omitempty"`
+}
+
+func (e *PushChallengedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *PushChallengedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *PushChallengedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func (e *PushChallengedEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func NewPushChallengedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	challengeID string,
+	expiry time.Duration,
+	number uint32,
+	devices []PushChallengeDevice,
+) *PushChallengedEvent {
+	return &PushChallengedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushChallengedType,
+		),
+		ChallengeID:       challengeID,
+		Expiry:            expiry,
+		Number:            number,
+		Devices:           devices,
+		TriggeredAtOrigin: http.ComposedOrigin(ctx),
+	}
+}
+
+type PushSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *PushSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *PushSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *PushSentEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewPushSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *PushSentEvent {
+	return &PushSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushSentType,
+		),
+	}
+}
+
+type PushApprovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ChallengeID string `json:"challengeId"`
+	DeviceID    string `json:"deviceId"`
+}
+
+func (e *PushApprovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *PushApprovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *PushApprovedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewPushApprovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	challengeID,
+	deviceID string,
+) *PushApprovedEvent {
+	return &PushApprovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushApprovedType,
+		),
+		ChallengeID: challengeID,
+		DeviceID:    deviceID,
+	}
+}
+
+type PushDeniedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ChallengeID string `json:"challengeId"`
+	DeviceID    string `json:"deviceId"`
+	// NumberMismatch is set if the user approved the challenge on the device, but entered the wrong number
+	NumberMismatch bool `json:"numberMismatch,omitempty"`
+}
+
+func (e *PushDeniedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *PushDeniedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *PushDeniedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewPushDeniedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	challengeID,
+	deviceID string,
+	numberMismatch bool,
+) *PushDeniedEvent {
+	return &PushDeniedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushDeniedType,
+		),
+		ChallengeID:    challengeID,
+		DeviceID:       deviceID,
+		NumberMismatch: numberMismatch,
+	}
+}
+
+type PushCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CheckedAt time.Time `json:"checkedAt"`
+	DeviceID  string    `json:"deviceId"`
+}
+
+func (e *PushCheckedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *PushCheckedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *PushCheckedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewPushCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	checkedAt time.Time,
+	deviceID string,
+) *PushCheckedEvent {
+	return &PushCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushCheckedType,
+		),
+		CheckedAt: checkedAt,
+		DeviceID:  deviceID,
+	}
+}
+
 type TokenSetEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -0,0 +1,104 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	consentEventPrefix = userEventTypePrefix + "consent."
+	ConsentGrantedType = consentEventPrefix + "granted"
+	ConsentRevokedType = consentEventPrefix + "revoked"
+)
+
+type ConsentGrantedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID  string   `json:"clientId"`
+	ProjectID string   `json:"projectId,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+}
+
+func (e *ConsentGrantedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ConsentGrantedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+// NewConsentGrantedEvent records the scopes the user consented to for the client.
+// The scopes replace any previously granted scopes of the client.
+func NewConsentGrantedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID,
+	projectID string,
+	scopes []string,
+) *ConsentGrantedEvent {
+	return &ConsentGrantedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ConsentGrantedType,
+		),
+		ClientID:  clientID,
+		ProjectID: projectID,
+		Scopes:    scopes,
+	}
+}
+
+func ConsentGrantedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	consentGranted := &ConsentGrantedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(consentGranted)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "USER-Ohg5u", "unable to unmarshal consent granted")
+	}
+
+	return consentGranted, nil
+}
+
+type ConsentRevokedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID string `json:"clientId"`
+}
+
+func (e *ConsentRevokedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ConsentRevokedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewConsentRevokedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID string,
+) *ConsentRevokedEvent {
+	return &ConsentRevokedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ConsentRevokedType,
+		),
+		ClientID: clientID,
+	}
+}
+
+func ConsentRevokedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	consentRevoked := &ConsentRevokedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(consentRevoked)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "USER-aeT0k", "unable to unmarshal consent revoked")
+	}
+
+	return consentRevoked, nil
+}
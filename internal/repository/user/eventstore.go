@@ -49,6 +49,8 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, MetadataSetType, MetadataSetEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MetadataRemovedType, MetadataRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MetadataRemovedAllType, MetadataRemovedAllEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, ConsentGrantedType, ConsentGrantedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, ConsentRevokedType, ConsentRevokedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanAddedType, HumanAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanRegisteredType, HumanRegisteredEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInitialCodeAddedType, HumanInitialCodeAddedEventMapper)
@@ -69,6 +71,8 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLoginCheckSucceededType, UserIDPCheckSucceededEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPExternalIDMigratedType, eventstore.GenericEventMapper[UserIDPExternalIDMigratedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPExternalUsernameChangedType, eventstore.GenericEventMapper[UserIDPExternalUsernameEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLinkAutoLinkedType, eventstore.GenericEventMapper[UserIDPLinkAutoLinkedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLinkAutoLinkedSentType, eventstore.GenericEventMapper[UserIDPLinkAutoLinkedSentEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanEmailChangedType, HumanEmailChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanEmailVerifiedType, HumanEmailVerifiedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanEmailVerificationFailedType, HumanEmailVerificationFailedEventMapper)
@@ -102,6 +106,9 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanOTPEmailCodeSentType, eventstore.GenericEventMapper[HumanOTPEmailCodeSentEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanOTPEmailCheckSucceededType, eventstore.GenericEventMapper[HumanOTPEmailCheckSucceededEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanOTPEmailCheckFailedType, eventstore.GenericEventMapper[HumanOTPEmailCheckFailedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanPushDeviceAddedType, eventstore.GenericEventMapper[HumanPushDeviceAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanPushDeviceVerifiedType, eventstore.GenericEventMapper[HumanPushDeviceVerifiedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanPushDeviceRemovedType, eventstore.GenericEventMapper[HumanPushDeviceRemovedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanU2FTokenAddedType, HumanU2FAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanU2FTokenVerifiedType, HumanU2FVerifiedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanU2FTokenSignCountChangedType, HumanU2FSignCountChangedEventMapper)
@@ -124,6 +131,8 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanRefreshTokenAddedType, HumanRefreshTokenAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanRefreshTokenRenewedType, HumanRefreshTokenRenewedEventEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanRefreshTokenRemovedType, HumanRefreshTokenRemovedEventEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanRefreshTokenReuseDetectedType, HumanRefreshTokenReuseDetectedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanRefreshTokenReuseDetectedSentType, eventstore.GenericEventMapper[HumanRefreshTokenReuseDetectedSentEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineAddedEventType, MachineAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineChangedEventType, MachineChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineKeyAddedEventType, MachineKeyAddedEventMapper)
@@ -134,4 +143,43 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretRemovedType, MachineSecretRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretCheckSucceededType, MachineSecretCheckSucceededEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretCheckFailedType, MachineSecretCheckFailedEventMapper)
+
+	// the personal data is erased as soon as the user is removed, see [UserRemovedEvent.ErasesPersonalData]
+	for _, eventType := range []eventstore.EventType{UserV1AddedType, UserV1RegisteredType, HumanAddedType, HumanRegisteredType} {
+		eventstore.RegisterPersonalData(eventType, profilePersonalData...)
+		eventstore.RegisterPersonalData(eventType, emailPersonalData, phonePersonalData)
+		eventstore.RegisterPersonalData(eventType, addressPersonalData...)
+	}
+	eventstore.RegisterPersonalData(UserV1ProfileChangedType, profilePersonalData...)
+	eventstore.RegisterPersonalData(HumanProfileChangedType, profilePersonalData...)
+	eventstore.RegisterPersonalData(UserV1EmailChangedType, emailPersonalData)
+	eventstore.RegisterPersonalData(HumanEmailChangedType, emailPersonalData)
+	eventstore.RegisterPersonalData(UserV1PhoneChangedType, phonePersonalData)
+	eventstore.RegisterPersonalData(HumanPhoneChangedType, phonePersonalData)
+	eventstore.RegisterPersonalData(UserV1AddressChangedType, addressPersonalData...)
+	eventstore.RegisterPersonalData(HumanAddressChangedType, addressPersonalData...)
+	for _, eventType := range []eventstore.EventType{UserV1AddedType, UserV1RegisteredType, HumanAddedType, HumanRegisteredType, MachineAddedEventType, UserDomainClaimedType, UserUserNameChangedType} {
+		eventstore.RegisterPersonalData(eventType, userNamePersonalData)
+	}
+	eventstore.RegisterPersonalData(UserIDPLinkAddedType, externalUserIDPersonalData, displayNamePersonalData)
+	for _, eventType := range []eventstore.EventType{UserIDPLinkRemovedType, UserIDPLinkCascadeRemovedType, UserIDPLinkAutoLinkedType, UserIDPLinkAutoLinkedSentType} {
+		eventstore.RegisterPersonalData(eventType, externalUserIDPersonalData)
+	}
+	eventstore.RegisterPersonalData(UserIDPExternalUsernameChangedType, externalUserIDPersonalData, externalUsernamePersonalData)
+	eventstore.RegisterPersonalData(UserIDPExternalIDMigratedType, "previousId", "newId")
 }
+
+// fields of the payloads containing personal data
+var (
+	profilePersonalData = []string{"firstName", "lastName", "nickName", "displayName"}
+	addressPersonalData = []string{"country", "locality", "postalCode", "region", "streetAddress"}
+)
+
+const (
+	emailPersonalData            = "email"
+	phonePersonalData            = "phone"
+	userNamePersonalData         = "userName"
+	displayNamePersonalData      = "displayName"
+	externalUserIDPersonalData   = "userId"
+	externalUsernamePersonalData = "username"
+)
@@ -3,6 +3,8 @@ package user
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -17,6 +19,8 @@ const (
 	UserIDPLinkCascadeRemovedType      = UserIDPLinkEventPrefix + "cascade.removed"
 	UserIDPExternalIDMigratedType      = UserIDPLinkEventPrefix + "id.migrated"
 	UserIDPExternalUsernameChangedType = UserIDPLinkEventPrefix + "username.changed"
+	UserIDPLinkAutoLinkedType          = UserIDPLinkEventPrefix + "auto.linked"
+	UserIDPLinkAutoLinkedSentType      = UserIDPLinkEventPrefix + "auto.link.sent"
 
 	UserIDPLoginCheckSucceededType = idpLoginEventPrefix + "check.succeeded"
 )
@@ -287,3 +291,84 @@ func NewUserIDPExternalUsernameEvent(
 		ExternalUsername: externalUsername,
 	}
 }
+
+// UserIDPLinkAutoLinkedEvent records that the previously added link was created automatically,
+// because the provider asserted a matching email address or username.
+type UserIDPLinkAutoLinkedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	IDPConfigID          string                   `json:"idpConfigId"`
+	ExternalUserID       string                   `json:"userId"`
+	Option               domain.AutoLinkingOption `json:"option"`
+	TriggeredAtOrigin    string                   `json:"triggerOrigin,omitempty"`
+}
+
+func (e *UserIDPLinkAutoLinkedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLinkAutoLinkedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLinkAutoLinkedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func (e *UserIDPLinkAutoLinkedEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func NewUserIDPLinkAutoLinkedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	externalUserID string,
+	option domain.AutoLinkingOption,
+) *UserIDPLinkAutoLinkedEvent {
+	return &UserIDPLinkAutoLinkedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLinkAutoLinkedType,
+		),
+		IDPConfigID:       idpConfigID,
+		ExternalUserID:    externalUserID,
+		Option:            option,
+		TriggeredAtOrigin: http.ComposedOrigin(ctx),
+	}
+}
+
+type UserIDPLinkAutoLinkedSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	IDPConfigID          string `json:"idpConfigId"`
+	ExternalUserID       string `json:"userId"`
+}
+
+func (e *UserIDPLinkAutoLinkedSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLinkAutoLinkedSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLinkAutoLinkedSentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLinkAutoLinkedSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	externalUserID string,
+) *UserIDPLinkAutoLinkedSentEvent {
+	return &UserIDPLinkAutoLinkedSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLinkAutoLinkedSentType,
+		),
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+	}
+}
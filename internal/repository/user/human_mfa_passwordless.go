@@ -77,7 +77,9 @@ func NewHumanPasswordlessVerifiedEvent(
 	publicKey,
 	aaguid []byte,
 	signCount uint32,
-	userAgentID string,
+	userAgentID,
+	authenticatorName,
+	authenticatorIcon string,
 ) *HumanPasswordlessVerifiedEvent {
 	return &HumanPasswordlessVerifiedEvent{
 		HumanWebAuthNVerifiedEvent: *NewHumanWebAuthNVerifiedEvent(
@@ -94,6 +96,8 @@ func NewHumanPasswordlessVerifiedEvent(
 			aaguid,
 			signCount,
 			userAgentID,
+			authenticatorName,
+			authenticatorIcon,
 		),
 	}
 }
@@ -0,0 +1,131 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	pushDeviceEventPrefix       = mfaEventPrefix + "push.device."
+	HumanPushDeviceAddedType    = pushDeviceEventPrefix + "added"
+	HumanPushDeviceVerifiedType = pushDeviceEventPrefix + "verified"
+	HumanPushDeviceRemovedType  = pushDeviceEventPrefix + "removed"
+)
+
+type HumanPushDeviceAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DeviceID string `json:"deviceId"`
+	Name     string `json:"name,omitempty"`
+	// PublicKey is the PKIX (DER) encoded public key of the device, which signs the responses to push challenges
+	PublicKey []byte `json:"publicKey"`
+	// PushToken identifies the device at the push channel (e.g. a FCM registration token or APNs device token)
+	PushToken string `json:"pushToken"`
+	// Challenge has to be signed by the device to verify the registration
+	Challenge string `json:"challenge"`
+}
+
+func (e *HumanPushDeviceAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanPushDeviceAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanPushDeviceAddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanPushDeviceAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	deviceID,
+	name string,
+	publicKey []byte,
+	pushToken,
+	challenge string,
+) *HumanPushDeviceAddedEvent {
+	return &HumanPushDeviceAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPushDeviceAddedType,
+		),
+		DeviceID:  deviceID,
+		Name:      name,
+		PublicKey: publicKey,
+		PushToken: pushToken,
+		Challenge: challenge,
+	}
+}
+
+type HumanPushDeviceVerifiedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DeviceID string `json:"deviceId"`
+	Name     string `json:"name,omitempty"`
+}
+
+func (e *HumanPushDeviceVerifiedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanPushDeviceVerifiedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanPushDeviceVerifiedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanPushDeviceVerifiedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	deviceID,
+	name string,
+) *HumanPushDeviceVerifiedEvent {
+	return &HumanPushDeviceVerifiedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPushDeviceVerifiedType,
+		),
+		DeviceID: deviceID,
+		Name:     name,
+	}
+}
+
+type HumanPushDeviceRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DeviceID string `json:"deviceId"`
+}
+
+func (e *HumanPushDeviceRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanPushDeviceRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanPushDeviceRemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanPushDeviceRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	deviceID string,
+) *HumanPushDeviceRemovedEvent {
+	return &HumanPushDeviceRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPushDeviceRemovedType,
+		),
+		DeviceID: deviceID,
+	}
+}
@@ -66,7 +66,9 @@ func NewHumanU2FVerifiedEvent(
 	publicKey,
 	aaguid []byte,
 	signCount uint32,
-	userAgentID string,
+	userAgentID,
+	authenticatorName,
+	authenticatorIcon string,
 ) *HumanU2FVerifiedEvent {
 	return &HumanU2FVerifiedEvent{
 		HumanWebAuthNVerifiedEvent: *NewHumanWebAuthNVerifiedEvent(
@@ -83,6 +85,8 @@ func NewHumanU2FVerifiedEvent(
 			aaguid,
 			signCount,
 			userAgentID,
+			authenticatorName,
+			authenticatorIcon,
 		),
 	}
 }
@@ -58,6 +58,9 @@ type HumanWebAuthNVerifiedEvent struct {
 	SignCount         uint32 `json:"signCount"`
 	WebAuthNTokenName string `json:"webAuthNTokenName"`
 	UserAgentID       string `json:"userAgentID,omitempty"`
+	// AuthenticatorName and AuthenticatorIcon are provided by the FIDO Metadata Service, if the authenticator is listed
+	AuthenticatorName string `json:"authenticatorName,omitempty"`
+	AuthenticatorIcon string `json:"authenticatorIcon,omitempty"`
 }
 
 func (e *HumanWebAuthNVerifiedEvent) Payload() interface{} {
@@ -77,7 +80,9 @@ func NewHumanWebAuthNVerifiedEvent(
 	publicKey,
 	aaguid []byte,
 	signCount uint32,
-	userAgentID string,
+	userAgentID,
+	authenticatorName,
+	authenticatorIcon string,
 ) *HumanWebAuthNVerifiedEvent {
 	return &HumanWebAuthNVerifiedEvent{
 		BaseEvent:         *base,
@@ -89,6 +94,8 @@ func NewHumanWebAuthNVerifiedEvent(
 		SignCount:         signCount,
 		WebAuthNTokenName: webAuthNTokenName,
 		UserAgentID:       userAgentID,
+		AuthenticatorName: authenticatorName,
+		AuthenticatorIcon: authenticatorIcon,
 	}
 }
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -13,8 +15,28 @@ const (
 	HumanRefreshTokenAddedType   = refreshTokenEventPrefix + "added"
 	HumanRefreshTokenRenewedType = refreshTokenEventPrefix + "renewed"
 	HumanRefreshTokenRemovedType = refreshTokenEventPrefix + "removed"
+
+	HumanRefreshTokenReuseDetectedType     = refreshTokenEventPrefix + "reuse.detected"
+	HumanRefreshTokenReuseDetectedSentType = refreshTokenEventPrefix + "reuse.detected.sent"
+
+	// UniqueRefreshTokenRotation ensures that a token value of a refresh token is only rotated once,
+	// even if the token is presented concurrently
+	UniqueRefreshTokenRotation = "refresh_token_rotation"
 )
 
+func NewAddRefreshTokenRotationUniqueConstraint(tokenID, rotatedRefreshToken string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueRefreshTokenRotation,
+		tokenID+":"+rotatedRefreshToken,
+		"Errors.User.RefreshToken.AlreadyRotated")
+}
+
+func NewRemoveRefreshTokenRotationUniqueConstraint(tokenID, rotatedRefreshToken string) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueRefreshTokenRotation,
+		tokenID+":"+rotatedRefreshToken)
+}
+
 type HumanRefreshTokenAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -28,6 +50,8 @@ type HumanRefreshTokenAddedEvent struct {
 	IdleExpiration        time.Duration `json:"idleExpiration"`
 	Expiration            time.Duration `json:"expiration"`
 	PreferredLanguage     string        `json:"preferredLanguage"`
+	// AuthorizationDetails are granted by the auth request, access tokens of the refresh token can only narrow them down
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorizationDetails,omitempty"`
 }
 
 func (e *HumanRefreshTokenAddedEvent) Payload() interface{} {
@@ -55,6 +79,7 @@ func NewHumanRefreshTokenAddedEvent(
 	authTime time.Time,
 	idleExpiration,
 	expiration time.Duration,
+	authorizationDetails domain.AuthorizationDetails,
 ) *HumanRefreshTokenAddedEvent {
 	return &HumanRefreshTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -72,6 +97,7 @@ func NewHumanRefreshTokenAddedEvent(
 		IdleExpiration:        idleExpiration,
 		Expiration:            expiration,
 		PreferredLanguage:     preferredLanguage,
+		AuthorizationDetails:  authorizationDetails,
 	}
 }
 
@@ -93,6 +119,10 @@ type HumanRefreshTokenRenewedEvent struct {
 	TokenID        string        `json:"tokenId"`
 	RefreshToken   string        `json:"refreshToken"`
 	IdleExpiration time.Duration `json:"idleExpiration"`
+	// RotatedRefreshToken is the token value replaced by the renewal
+	RotatedRefreshToken string `json:"rotatedRefreshToken,omitempty"`
+
+	previousRotatedRefreshToken string
 }
 
 func (e *HumanRefreshTokenRenewedEvent) Payload() interface{} {
@@ -100,7 +130,14 @@ func (e *HumanRefreshTokenRenewedEvent) Payload() interface{} {
 }
 
 func (e *HumanRefreshTokenRenewedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
-	return nil
+	constraints := []*eventstore.UniqueConstraint{
+		NewAddRefreshTokenRotationUniqueConstraint(e.TokenID, e.RotatedRefreshToken),
+	}
+	// the previous rotation can't be renewed anymore, because the token value changed
+	if e.previousRotatedRefreshToken != "" {
+		constraints = append(constraints, NewRemoveRefreshTokenRotationUniqueConstraint(e.TokenID, e.previousRotatedRefreshToken))
+	}
+	return constraints
 }
 
 func (e *HumanRefreshTokenRenewedEvent) Assets() []*eventstore.Asset {
@@ -111,7 +148,9 @@ func NewHumanRefreshTokenRenewedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	tokenID,
-	refreshToken string,
+	refreshToken,
+	rotatedRefreshToken,
+	previousRotatedRefreshToken string,
 	idleExpiration time.Duration,
 ) *HumanRefreshTokenRenewedEvent {
 	return &HumanRefreshTokenRenewedEvent{
@@ -120,9 +159,11 @@ func NewHumanRefreshTokenRenewedEvent(
 			aggregate,
 			HumanRefreshTokenRenewedType,
 		),
-		TokenID:        tokenID,
-		IdleExpiration: idleExpiration,
-		RefreshToken:   refreshToken,
+		TokenID:                     tokenID,
+		IdleExpiration:              idleExpiration,
+		RefreshToken:                refreshToken,
+		RotatedRefreshToken:         rotatedRefreshToken,
+		previousRotatedRefreshToken: previousRotatedRefreshToken,
 	}
 }
 
@@ -182,3 +223,96 @@ func HumanRefreshTokenRemovedEventEventMapper(event eventstore.Event) (eventstor
 
 	return tokenAdded, nil
 }
+
+// HumanRefreshTokenReuseDetectedEvent is pushed if an already rotated refresh token is presented again.
+// The token family is revoked in the same transaction.
+type HumanRefreshTokenReuseDetectedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID           string `json:"tokenId"`
+	ClientID          string `json:"clientId"`
+	UserAgentID       string `json:"userAgentId"`
+	TriggeredAtOrigin string `json:"triggerOrigin,omitempty"`
+}
+
+func (e *HumanRefreshTokenReuseDetectedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanRefreshTokenReuseDetectedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanRefreshTokenReuseDetectedEvent) Assets() []*eventstore.Asset {
+	return nil
+}
+
+func (e *HumanRefreshTokenReuseDetectedEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func NewHumanRefreshTokenReuseDetectedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID,
+	clientID,
+	userAgentID string,
+) *HumanRefreshTokenReuseDetectedEvent {
+	return &HumanRefreshTokenReuseDetectedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRefreshTokenReuseDetectedType,
+		),
+		TokenID:           tokenID,
+		ClientID:          clientID,
+		UserAgentID:       userAgentID,
+		TriggeredAtOrigin: http.ComposedOrigin(ctx),
+	}
+}
+
+func HumanRefreshTokenReuseDetectedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	reuseDetected := &HumanRefreshTokenReuseDetectedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(reuseDetected)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "USER-iey2U", "unable to unmarshal refresh token reuse detected")
+	}
+
+	return reuseDetected, nil
+}
+
+// HumanRefreshTokenReuseDetectedSentEvent records that the user was notified about the reused refresh token.
+type HumanRefreshTokenReuseDetectedSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenId"`
+}
+
+func (e *HumanRefreshTokenReuseDetectedSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanRefreshTokenReuseDetectedSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanRefreshTokenReuseDetectedSentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanRefreshTokenReuseDetectedSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *HumanRefreshTokenReuseDetectedSentEvent {
+	return &HumanRefreshTokenReuseDetectedSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRefreshTokenReuseDetectedSentType,
+		),
+		TokenID: tokenID,
+	}
+}
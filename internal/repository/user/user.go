@@ -181,6 +181,11 @@ func (e *UserRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
 	return events
 }
 
+// ErasesPersonalData implements [eventstore.PersonalDataEraser]
+func (e *UserRemovedEvent) ErasesPersonalData() bool {
+	return true
+}
+
 func NewUserRemovedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
@@ -217,6 +222,10 @@ type UserTokenAddedEvent struct {
 	Scopes            []string  `json:"scopes"`
 	Expiration        time.Time `json:"expiration"`
 	PreferredLanguage string    `json:"preferredLanguage"`
+	// CertificateThumbprint is the `x5t#S256` of the client certificate the token is bound to
+	CertificateThumbprint string `json:"certificateThumbprint,omitempty"`
+	// AuthorizationDetails are the details of a rich authorization request (RFC 9396) the token is granted
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorizationDetails,omitempty"`
 }
 
 func (e *UserTokenAddedEvent) Payload() interface{} {
@@ -238,6 +247,8 @@ func NewUserTokenAddedEvent(
 	audience,
 	scopes []string,
 	expiration time.Time,
+	certificateThumbprint string,
+	authorizationDetails domain.AuthorizationDetails,
 ) *UserTokenAddedEvent {
 	return &UserTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -245,14 +256,16 @@ func NewUserTokenAddedEvent(
 			aggregate,
 			UserTokenAddedType,
 		),
-		TokenID:           tokenID,
-		ApplicationID:     applicationID,
-		UserAgentID:       userAgentID,
-		RefreshTokenID:    refreshTokenID,
-		Audience:          audience,
-		Scopes:            scopes,
-		Expiration:        expiration,
-		PreferredLanguage: preferredLanguage,
+		TokenID:               tokenID,
+		ApplicationID:         applicationID,
+		UserAgentID:           userAgentID,
+		RefreshTokenID:        refreshTokenID,
+		Audience:              audience,
+		Scopes:                scopes,
+		Expiration:            expiration,
+		PreferredLanguage:     preferredLanguage,
+		CertificateThumbprint: certificateThumbprint,
+		AuthorizationDetails:  authorizationDetails,
 	}
 }
 
@@ -0,0 +1,500 @@
+// Package sharding moves instances between the database clusters they are placed on.
+package sharding
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	defaultBatchSize = 1000
+	// defaultLockTTL is how long the lock of a moved instance is valid without being renewed
+	defaultLockTTL = time.Minute
+)
+
+// Projection is triggered before the projected rows of an instance are copied
+type Projection interface {
+	Trigger(ctx context.Context, opts ...handler.TriggerOpt) (context.Context, error)
+}
+
+// Mover moves instances to another shard while they keep running.
+//
+// The events are streamed to the target shard until the instance is locked,
+// pushing events and writing other rows of a locked instance is rejected, see [database.DB.CheckWritable].
+// After the remaining events are streamed, the projections are triggered
+// and all other rows of the instance are copied before the instance is placed on the target shard.
+//
+// The lock is renewed while the instance is moved, if the process moving it stops the lock becomes stale.
+// A stale move is aborted by starting a new move of the instance, see [Mover.Start].
+type Mover struct {
+	client      *database.DB
+	projections []Projection
+	batchSize   uint32
+	// lockWait is awaited after the instance is locked,
+	// so that all processes observe the lock and writes started before are finished
+	lockWait time.Duration
+	// awaitOpenTransactions ensures that no event of an open transaction is skipped by the cursor
+	awaitOpenTransactions string
+	wait                  func(ctx context.Context, d time.Duration) error
+	// moving contains the instances moved by this process
+	moving sync.Map
+
+	// lockTTL is how long the lock of the instance is valid, it's renewed after a third of it
+	lockTTL   time.Duration
+	lockOwner func() (string, error)
+}
+
+// NewMover creates a mover, pushTimeout is the timeout of the eventstore for pushing events
+func NewMover(client *database.DB, projections []Projection, batchSize uint32, pushTimeout time.Duration) *Mover {
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+	m := &Mover{
+		client:      client,
+		projections: projections,
+		batchSize:   batchSize,
+		lockWait:    max(client.PlacementCacheTTL(), pushTimeout),
+		wait:        wait,
+		lockTTL:     defaultLockTTL,
+		lockOwner:   id.SonyFlakeGenerator().Next,
+	}
+	switch client.Type() {
+	case "cockroach":
+		m.awaitOpenTransactions = ` AND hlc_to_timestamp("position") < (SELECT COALESCE(MIN(start), NOW())::TIMESTAMP FROM crdb_internal.cluster_transactions where application_name = '` + dialect.EventstorePusherAppName + `')`
+	case "postgres":
+		m.awaitOpenTransactions = ` AND "position" < (SELECT COALESCE(EXTRACT(EPOCH FROM min(xact_start)), EXTRACT(EPOCH FROM now())) FROM pg_stat_activity WHERE datname = current_database() AND application_name = '` + dialect.EventstorePusherAppName + `' AND state <> 'idle')`
+	}
+	return m
+}
+
+// Start checks if the instance can be moved to the target shard and moves it in the background.
+// The move continues if ctx is canceled, e.g. by the client of the request starting it, its progress is logged.
+// The rows of the instance are removed from the current shard after the instance is placed on the target shard.
+//
+// If the instance is locked by a stale move, the stale move is aborted first:
+// the rows it copied are removed from its target and the instance is unlocked on its current shard.
+// The instance is only unlocked if target is the shard it's placed on.
+func (m *Mover) Start(ctx context.Context, instanceID, target string) error {
+	placement, err := m.placement(ctx, instanceID, target)
+	if err != nil {
+		return err
+	}
+	if _, moving := m.moving.LoadOrStore(instanceID, target); moving {
+		return zerrors.ThrowPreconditionFailed(nil, "SHARD-Mv4Mv", "Errors.Instance.Moving")
+	}
+	go func() {
+		defer m.moving.Delete(instanceID)
+		ctx := context.WithoutCancel(ctx)
+		logger := logging.WithFields("instance", instanceID, "source", placement.Shard, "target", target)
+		if placement.Locked {
+			err := m.abort(ctx, placement)
+			logger.OnError(err).Error("stale move of instance not aborted")
+			if err != nil || placement.Shard == target {
+				return
+			}
+		}
+		err := m.move(ctx, instanceID, placement.Shard, target)
+		logger.OnError(err).Error("instance not moved")
+	}()
+	return nil
+}
+
+// placement returns the placement of the instance moved to target
+func (m *Mover) placement(ctx context.Context, instanceID, target string) (*database.Placement, error) {
+	if !slices.Contains(m.client.Shards(), target) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SHARD-Mv1Tg", "Errors.Instance.Shard.NotFound")
+	}
+	placement, err := m.client.Placement(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if placement.Locked {
+		if !placement.StaleLock(time.Now()) {
+			return nil, zerrors.ThrowPreconditionFailed(nil, "SHARD-Mv2Lk", "Errors.Instance.Moving")
+		}
+		return placement, nil
+	}
+	if placement.Shard == target {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "SHARD-Mv3Sm", "Errors.Instance.Shard.Current")
+	}
+	return placement, nil
+}
+
+// abort takes over the stale lock of the placement, removes the rows copied to the target of the stale move
+// and unlocks the instance on its current shard
+func (m *Mover) abort(ctx context.Context, placement *database.Placement) error {
+	owner, err := m.lockOwner()
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SHARD-Ab1Id", "Errors.Internal")
+	}
+	if err = m.client.RenewPlacementLock(ctx, placement.InstanceID, placement.LockOwner, owner, time.Now().Add(m.lockTTL)); err != nil {
+		return err
+	}
+	ctx, release := m.keepLock(ctx, placement.InstanceID, owner)
+	defer release()
+	logging.WithFields("instance", placement.InstanceID, "owner", placement.LockOwner, "target", placement.LockTarget).Info("aborting stale move of instance")
+
+	// locks created before their target was stored can't be cleaned up
+	if placement.LockTarget != "" && placement.LockTarget != placement.Shard {
+		tables, err := m.instanceTables(ctx, placement.LockTarget)
+		if err != nil {
+			return err
+		}
+		if err = m.removeInstance(ctx, placement.InstanceID, placement.LockTarget, tables); err != nil {
+			return err
+		}
+	}
+	return m.client.ReleasePlacementLock(ctx, placement.InstanceID, owner, placement.Shard)
+}
+
+// keepLock renews the lock of the instance until release is called.
+// The returned context is canceled if the lock can't be renewed, so that the instance isn't changed without holding it.
+func (m *Mover) keepLock(ctx context.Context, instanceID, owner string) (_ context.Context, release func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(m.lockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := m.client.RenewPlacementLock(ctx, instanceID, owner, owner, time.Now().Add(m.lockTTL)); err != nil {
+					select {
+					case <-done:
+						// the lock was released while it was renewed
+						return
+					default:
+					}
+					logging.WithFields("instance", instanceID).WithError(err).Warn("lock of instance lost")
+					cancel(err)
+					return
+				}
+			}
+		}
+	}()
+	return ctx, func() {
+		close(done)
+		cancel(nil)
+	}
+}
+
+func (m *Mover) move(ctx context.Context, instanceID, source, target string) error {
+	logger := logging.WithFields("instance", instanceID, "source", source, "target", target)
+
+	// the events are streamed while the instance is running to keep the locked period short
+	cursor := &eventCursor{position: "0"}
+	copied, err := m.copyEvents(ctx, instanceID, source, target, cursor)
+	if err != nil {
+		return err
+	}
+	logger.WithField("events", copied).Info("events copied, locking instance")
+
+	tables, err := m.moveLocked(ctx, instanceID, source, target, cursor)
+	if err != nil {
+		return err
+	}
+	logger.Info("instance moved")
+
+	// the instance is removed from the source only after all processes route to the target
+	if err := m.wait(ctx, m.lockWait); err != nil {
+		logger.WithError(err).Warn("rows of the instance not removed from source")
+		return nil
+	}
+	logger.OnError(m.removeInstance(ctx, instanceID, source, tables)).Warn("rows of the instance not removed from source")
+	return nil
+}
+
+// moveLocked locks the instance, copies its remaining events and all other rows
+// and places it on the target shard while holding the lock
+func (m *Mover) moveLocked(ctx context.Context, instanceID, source, target string, cursor *eventCursor) (tables []*table, err error) {
+	owner, err := m.lockOwner()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "SHARD-Mv5Id", "Errors.Internal")
+	}
+	err = m.client.SetPlacement(ctx, &database.Placement{
+		InstanceID: instanceID,
+		Shard:      source,
+		Locked:     true,
+		LockOwner:  owner,
+		LockTarget: target,
+		LockExpiry: time.Now().Add(m.lockTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	lockCtx, release := m.keepLock(ctx, instanceID, owner)
+	defer func() {
+		release()
+		if err == nil {
+			return
+		}
+		// the instance is unlocked even if ctx is canceled, a lock taken over by another process is kept
+		unlockErr := m.client.ReleasePlacementLock(context.WithoutCancel(ctx), instanceID, owner, source)
+		logging.WithFields("instance", instanceID, "source", source).OnError(unlockErr).Error("unable to unlock instance")
+	}()
+
+	// all processes must observe the lock and finish their writes before the remaining events are copied
+	if err = m.wait(lockCtx, m.lockWait); err != nil {
+		return nil, err
+	}
+	if _, err = m.copyEvents(lockCtx, instanceID, source, target, cursor); err != nil {
+		return nil, err
+	}
+	if err = m.checkEvents(lockCtx, instanceID, source, target); err != nil {
+		return nil, err
+	}
+	if err = m.triggerProjections(lockCtx, instanceID, source); err != nil {
+		return nil, err
+	}
+	if tables, err = m.instanceTables(lockCtx, source); err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		if err = m.copyTable(lockCtx, instanceID, source, target, table); err != nil {
+			return nil, err
+		}
+	}
+	if err = m.client.ReleasePlacementLock(lockCtx, instanceID, owner, target); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+const (
+	selectEventsStmt = `SELECT to_json(e)::TEXT, e."position"::TEXT, e.in_tx_order FROM eventstore.events2 e` +
+		` WHERE e.instance_id = $1 AND (e."position", e.in_tx_order) > ($2::DECIMAL, $3)`
+	orderEventsStmt  = ` ORDER BY e."position", e.in_tx_order LIMIT $4`
+	insertEventsStmt = `INSERT INTO eventstore.events2 SELECT * FROM json_populate_recordset(NULL::eventstore.events2, $1::JSON)` +
+		` ON CONFLICT DO NOTHING`
+	countEventsStmt = `SELECT COUNT(*) FROM eventstore.events2 WHERE instance_id = $1`
+)
+
+type eventCursor struct {
+	position  string
+	inTxOrder int32
+}
+
+// copyEvents streams the events of the instance following the cursor ordered by their position,
+// events already copied are skipped
+func (m *Mover) copyEvents(ctx context.Context, instanceID, source, target string, cursor *eventCursor) (count int, err error) {
+	var (
+		sourceCtx  = database.WithPrimary(database.WithShard(ctx, source))
+		targetCtx  = database.WithShard(ctx, target)
+		selectStmt = selectEventsStmt + m.awaitOpenTransactions + orderEventsStmt
+	)
+	for {
+		events := make([]string, 0, m.batchSize)
+		err = m.client.QueryContext(sourceCtx, func(rows *sql.Rows) error {
+			for rows.Next() {
+				var event string
+				if err := rows.Scan(&event, &cursor.position, &cursor.inTxOrder); err != nil {
+					return err
+				}
+				events = append(events, event)
+			}
+			return rows.Err()
+		}, selectStmt, instanceID, cursor.position, cursor.inTxOrder, m.batchSize)
+		if err != nil {
+			return count, zerrors.ThrowInternal(err, "SHARD-Ev1Rd", "Errors.Internal")
+		}
+		if len(events) == 0 {
+			return count, nil
+		}
+		if _, err = m.client.ExecContext(targetCtx, insertEventsStmt, jsonArray(events)); err != nil {
+			return count, zerrors.ThrowInternal(err, "SHARD-Ev2Wr", "Errors.Internal")
+		}
+		count += len(events)
+	}
+}
+
+// checkEvents ensures that all events of the locked instance are copied
+func (m *Mover) checkEvents(ctx context.Context, instanceID, source, target string) error {
+	var sourceCount, targetCount int
+	err := m.client.QueryRowContext(database.WithPrimary(database.WithShard(ctx, source)), func(row *sql.Row) error {
+		return row.Scan(&sourceCount)
+	}, countEventsStmt, instanceID)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SHARD-Ev3Ct", "Errors.Internal")
+	}
+	err = m.client.QueryRowContext(database.WithPrimary(database.WithShard(ctx, target)), func(row *sql.Row) error {
+		return row.Scan(&targetCount)
+	}, countEventsStmt, instanceID)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SHARD-Ev4Ct", "Errors.Internal")
+	}
+	if sourceCount != targetCount {
+		return zerrors.ThrowInternalf(nil, "SHARD-Ev5Ct", "copied %d of %d events", targetCount, sourceCount)
+	}
+	return nil
+}
+
+// triggerProjections brings the projected rows of the instance on the source up to date before they are copied
+func (m *Mover) triggerProjections(ctx context.Context, instanceID, source string) error {
+	ctx = database.WithShard(authz.WithInstanceID(ctx, instanceID), source)
+	for _, projection := range m.projections {
+		if _, err := projection.Trigger(ctx, handler.WithAwaitRunning()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	// the events are streamed by [Mover.copyEvents], the placements are stored on the default shard
+	// rowid is the hidden primary key of cockroach tables without primary key
+	selectInstanceTablesStmt = `SELECT c.table_schema, c.table_name, c.column_name FROM information_schema.columns c` +
+		` JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name` +
+		` WHERE t.table_type = 'BASE TABLE' AND c.table_schema IN ('eventstore', 'projections', 'system', 'auth', 'adminapi', 'logstore')` +
+		` AND c.is_generated = 'NEVER' AND c.column_name <> 'rowid'` +
+		` AND NOT (c.table_schema = 'eventstore' AND c.table_name IN ('events', 'events2'))` +
+		` AND NOT (c.table_schema = 'system' AND c.table_name = 'instance_placements')` +
+		` ORDER BY c.table_schema, c.table_name, c.ordinal_position`
+	instanceIDColumn = "instance_id"
+)
+
+type table struct {
+	schema, name string
+	columns      []string
+}
+
+func (t *table) identifier() string {
+	return quoteIdentifier(t.schema) + "." + quoteIdentifier(t.name)
+}
+
+func (t *table) columnList() string {
+	columns := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		columns[i] = quoteIdentifier(column)
+	}
+	return strings.Join(columns, ", ")
+}
+
+// isState reports if the table stores the progress of handlers
+func (t *table) isState() bool {
+	return t.name == "current_states" || t.name == "current_sequences"
+}
+
+// instanceTables returns the tables containing rows of instances.
+// The states of the handlers are copied first, so that handlers reduce events again
+// instead of skipping them if they still write while the tables are copied.
+func (m *Mover) instanceTables(ctx context.Context, shard string) ([]*table, error) {
+	var tables []*table
+	err := m.client.QueryContext(database.WithPrimary(database.WithShard(ctx, shard)), func(rows *sql.Rows) error {
+		for rows.Next() {
+			var schema, name, column string
+			if err := rows.Scan(&schema, &name, &column); err != nil {
+				return err
+			}
+			if len(tables) == 0 || tables[len(tables)-1].schema != schema || tables[len(tables)-1].name != name {
+				tables = append(tables, &table{schema: schema, name: name})
+			}
+			tables[len(tables)-1].columns = append(tables[len(tables)-1].columns, column)
+		}
+		return rows.Err()
+	}, selectInstanceTablesStmt)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "SHARD-Tb1Rd", "Errors.Internal")
+	}
+	tables = slices.DeleteFunc(tables, func(t *table) bool {
+		return !slices.Contains(t.columns, instanceIDColumn)
+	})
+	slices.SortStableFunc(tables, func(a, b *table) int {
+		switch {
+		case a.isState() == b.isState():
+			return 0
+		case a.isState():
+			return -1
+		default:
+			return 1
+		}
+	})
+	return tables, nil
+}
+
+// copyTable copies the rows of the instance in batches, rows already copied are skipped
+func (m *Mover) copyTable(ctx context.Context, instanceID, source, target string, t *table) error {
+	targetCtx := database.WithShard(ctx, target)
+	insertStmt := "INSERT INTO " + t.identifier() + " (" + t.columnList() + ") SELECT " + t.columnList() +
+		" FROM json_populate_recordset(NULL::" + t.identifier() + ", $1::JSON) ON CONFLICT DO NOTHING"
+	insert := func(rows []string) error {
+		if len(rows) == 0 {
+			return nil
+		}
+		_, err := m.client.ExecContext(targetCtx, insertStmt, jsonArray(rows))
+		return err
+	}
+	err := m.client.QueryContext(database.WithPrimary(database.WithShard(ctx, source)), func(rows *sql.Rows) error {
+		batch := make([]string, 0, m.batchSize)
+		for rows.Next() {
+			var row string
+			if err := rows.Scan(&row); err != nil {
+				return err
+			}
+			if batch = append(batch, row); len(batch) < int(m.batchSize) {
+				continue
+			}
+			if err := insert(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return insert(batch)
+	}, "SELECT to_json(r)::TEXT FROM (SELECT "+t.columnList()+" FROM "+t.identifier()+" WHERE instance_id = $1) r", instanceID)
+	if err != nil {
+		return zerrors.ThrowInternalf(err, "SHARD-Tb2Cp", "unable to copy %s", t.identifier())
+	}
+	return nil
+}
+
+// removeInstance deletes the rows of the moved instance from the source
+func (m *Mover) removeInstance(ctx context.Context, instanceID, source string, tables []*table) error {
+	tx, err := m.client.BeginTx(database.WithShard(ctx, source), nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SHARD-Rm1Tx", "Errors.Internal")
+	}
+	for _, t := range append(tables, &table{schema: "eventstore", name: "events2"}) {
+		if _, err = tx.ExecContext(ctx, "DELETE FROM "+t.identifier()+" WHERE instance_id = $1", instanceID); err != nil {
+			_ = tx.Rollback()
+			return zerrors.ThrowInternalf(err, "SHARD-Rm2Dl", "unable to remove rows of %s", t.identifier())
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return zerrors.ThrowInternal(err, "SHARD-Rm3Cm", "Errors.Internal")
+	}
+	return nil
+}
+
+func jsonArray(objects []string) string {
+	return "[" + strings.Join(objects, ",") + "]"
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
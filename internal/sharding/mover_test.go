@@ -0,0 +1,96 @@
+package sharding
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestMover_Start_preconditions(t *testing.T) {
+	m := &Mover{client: new(database.DB), batchSize: 2}
+	err := m.Start(context.Background(), "instance", "unknown")
+	assert.True(t, zerrors.IsErrorInvalidArgument(err))
+
+	err = m.Start(context.Background(), "instance", database.DefaultShard)
+	assert.True(t, zerrors.IsPreconditionFailed(err))
+}
+
+func TestMover_copyEvents(t *testing.T) {
+	selectStmt := selectEventsStmt + orderEventsStmt
+	client := mock.NewSQLMock(t,
+		mock.ExpectBegin(nil),
+		mock.ExpectQuery(selectStmt,
+			mock.WithQueryArgs("instance", "0", int32(0), uint32(2)),
+			mock.WithQueryResult([]string{"event", "position", "in_tx_order"}, [][]driver.Value{
+				{`{"sequence":1}`, "1.1", 0},
+				{`{"sequence":2}`, "1.1", 1},
+			}),
+		),
+		mock.ExpectCommit(nil),
+		mock.ExcpectExec(insertEventsStmt,
+			mock.WithExecArgs(`[{"sequence":1},{"sequence":2}]`),
+			mock.WithExecRowsAffected(2),
+		),
+		mock.ExpectBegin(nil),
+		mock.ExpectQuery(selectStmt,
+			mock.WithQueryArgs("instance", "1.1", int32(1), uint32(2)),
+			mock.WithQueryResult([]string{"event", "position", "in_tx_order"}, [][]driver.Value{
+				{`{"sequence":3}`, "2.5", 0},
+			}),
+		),
+		mock.ExpectCommit(nil),
+		mock.ExcpectExec(insertEventsStmt,
+			mock.WithExecArgs(`[{"sequence":3}]`),
+			mock.WithExecRowsAffected(1),
+		),
+		mock.ExpectBegin(nil),
+		mock.ExpectQuery(selectStmt,
+			mock.WithQueryArgs("instance", "2.5", int32(0), uint32(2)),
+			mock.WithQueryResult([]string{"event", "position", "in_tx_order"}, nil),
+		),
+		mock.ExpectCommit(nil),
+	)
+	m := &Mover{client: &database.DB{DB: client.DB}, batchSize: 2}
+	cursor := &eventCursor{position: "0"}
+
+	count, err := m.copyEvents(context.Background(), "instance", database.DefaultShard, database.DefaultShard, cursor)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, &eventCursor{position: "2.5", inTxOrder: 0}, cursor)
+	client.Assert(t)
+}
+
+func TestMover_instanceTables(t *testing.T) {
+	client := mock.NewSQLMock(t,
+		mock.ExpectBegin(nil),
+		mock.ExpectQuery(selectInstanceTablesStmt,
+			mock.WithQueryResult([]string{"table_schema", "table_name", "column_name"}, [][]driver.Value{
+				{"projections", "apps7", "id"},
+				{"projections", "apps7", "instance_id"},
+				{"projections", "current_states", "projection_name"},
+				{"projections", "current_states", "instance_id"},
+				{"projections", "locks", "projection_name"},
+				{"system", "assets", "instance_id"},
+				{"system", "assets", "data"},
+			}),
+		),
+		mock.ExpectCommit(nil),
+	)
+	m := &Mover{client: &database.DB{DB: client.DB}}
+
+	tables, err := m.instanceTables(context.Background(), database.DefaultShard)
+	require.NoError(t, err)
+	assert.Equal(t, []*table{
+		{schema: "projections", name: "current_states", columns: []string{"projection_name", "instance_id"}},
+		{schema: "projections", name: "apps7", columns: []string{"id", "instance_id"}},
+		{schema: "system", name: "assets", columns: []string{"instance_id", "data"}},
+	}, tables)
+	client.Assert(t)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/Masterminds/squirrel"
 
+	z_db "github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/static"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -30,14 +31,18 @@ const (
 )
 
 type crdbStorage struct {
-	client *sql.DB
+	client *z_db.DB
 }
 
-func NewStorage(client *sql.DB, _ map[string]interface{}) (static.Storage, error) {
+func NewStorage(client *z_db.DB, _ map[string]interface{}) (static.Storage, error) {
 	return &crdbStorage{client: client}, nil
 }
 
 func (c *crdbStorage) PutObject(ctx context.Context, instanceID, location, resourceOwner, name, contentType string, objectType static.ObjectType, object io.Reader, objectSize int64) (*static.Asset, error) {
+	// assets of instances which are moved to another shard must not be written
+	if err := c.client.CheckWritable(ctx); err != nil {
+		return nil, err
+	}
 	data, err := io.ReadAll(object)
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "DATAB-Dfwvq", "Errors.Internal")
@@ -56,7 +61,11 @@ func (c *crdbStorage) PutObject(ctx context.Context, instanceID, location, resou
 	}
 	var hash string
 	var updatedAt time.Time
-	err = c.client.QueryRowContext(ctx, stmt, args...).Scan(&hash, &updatedAt)
+	client, err := c.client.ShardPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = client.QueryRowContext(ctx, stmt, args...).Scan(&hash, &updatedAt)
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "DATAB-D2g2q", "Errors.Internal")
 	}
@@ -90,7 +99,11 @@ func (c *crdbStorage) GetObject(ctx context.Context, instanceID, resourceOwner,
 		ResourceOwner: resourceOwner,
 		Name:          name,
 	}
-	err = c.client.QueryRowContext(ctx, query, args...).
+	client, err := c.client.ShardPool(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = client.QueryRowContext(ctx, query, args...).
 		Scan(
 			&data,
 			&asset.ContentType,
@@ -129,7 +142,11 @@ func (c *crdbStorage) GetObjectInfo(ctx context.Context, instanceID, resourceOwn
 		ResourceOwner: resourceOwner,
 		Name:          name,
 	}
-	err = c.client.QueryRowContext(ctx, query, args...).
+	client, err := c.client.ShardPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = client.QueryRowContext(ctx, query, args...).
 		Scan(
 			&asset.ContentType,
 			&asset.Location,
@@ -144,6 +161,9 @@ func (c *crdbStorage) GetObjectInfo(ctx context.Context, instanceID, resourceOwn
 }
 
 func (c *crdbStorage) RemoveObject(ctx context.Context, instanceID, resourceOwner, name string) error {
+	if err := c.client.CheckWritable(ctx); err != nil {
+		return err
+	}
 	stmt, args, err := squirrel.Delete(assetsTable).
 		Where(squirrel.Eq{
 			AssetColInstanceID:    instanceID,
@@ -163,6 +183,9 @@ func (c *crdbStorage) RemoveObject(ctx context.Context, instanceID, resourceOwne
 }
 
 func (c *crdbStorage) RemoveObjects(ctx context.Context, instanceID, resourceOwner string, objectType static.ObjectType) error {
+	if err := c.client.CheckWritable(ctx); err != nil {
+		return err
+	}
 	stmt, args, err := squirrel.Delete(assetsTable).
 		Where(squirrel.Eq{
 			AssetColInstanceID:    instanceID,
@@ -197,3 +220,48 @@ func (c *crdbStorage) RemoveInstanceObjects(ctx context.Context, instanceID stri
 	}
 	return nil
 }
+
+func (c *crdbStorage) ListObjects(ctx context.Context, instanceID string) ([]*static.Asset, error) {
+	query, args, err := squirrel.Select(AssetColResourceOwner, AssetColName, AssetColType, AssetColContentType, "length("+AssetColData+")", AssetColHash, AssetColUpdatedAt).
+		From(assetsTable).
+		Where(squirrel.Eq{
+			AssetColInstanceID: instanceID,
+		}).
+		OrderBy(AssetColResourceOwner, AssetColName).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "DATAB-Lst1q", "Errors.Internal")
+	}
+	client, err := c.client.ShardPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "DATAB-Lst2r", "Errors.Assets.Object.ListFailed")
+	}
+	defer rows.Close()
+	var assets []*static.Asset
+	for rows.Next() {
+		var objectType string
+		asset := &static.Asset{InstanceID: instanceID}
+		if err = rows.Scan(
+			&asset.ResourceOwner,
+			&asset.Name,
+			&objectType,
+			&asset.ContentType,
+			&asset.Size,
+			&asset.Hash,
+			&asset.LastModified,
+		); err != nil {
+			return nil, zerrors.ThrowInternal(err, "DATAB-Lst3s", "Errors.Assets.Object.ListFailed")
+		}
+		asset.ObjectType = static.ObjectTypeFromString(objectType)
+		assets = append(assets, asset)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "DATAB-Lst4e", "Errors.Assets.Object.ListFailed")
+	}
+	return assets, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 
+	z_db "github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/static"
 )
 
@@ -37,6 +38,10 @@ const (
 		" AND resource_owner = $3"
 	removeInstanceObjectsStmt = "DELETE FROM system.assets" +
 		" WHERE instance_id = $1"
+	listObjectsStmt = "SELECT resource_owner, name, asset_type, content_type, length(data), hash, updated_at" +
+		" FROM system.assets" +
+		" WHERE instance_id = $1" +
+		" ORDER BY resource_owner, name"
 )
 
 func Test_crdbStorage_CreateObject(t *testing.T) {
@@ -271,9 +276,83 @@ func Test_crdbStorage_RemoveInstanceObjects(t *testing.T) {
 	}
 }
 
+func Test_crdbStorage_ListObjects(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  db
+		want    []*static.Asset
+		wantErr bool
+	}{
+		{
+			name: "query error",
+			client: prepareDB(t,
+				expectQueryErr(listObjectsStmt, sql.ErrConnDone, "instanceID"),
+			),
+			wantErr: true,
+		},
+		{
+			name: "no objects",
+			client: prepareDB(t,
+				expectQuery(listObjectsStmt, []string{"resource_owner", "name", "asset_type", "content_type", "length", "hash", "updated_at"}, nil, "instanceID"),
+			),
+			want: nil,
+		},
+		{
+			name: "objects",
+			client: prepareDB(t,
+				expectQuery(listObjectsStmt,
+					[]string{"resource_owner", "name", "asset_type", "content_type", "length", "hash", "updated_at"},
+					[][]driver.Value{
+						{"org", "avatar", "0", "image/png", 4, "md5Hash", testNow},
+						{"org", "policy/label/logo", "1", "image/svg+xml", 10, "md5Hash2", testNow},
+					},
+					"instanceID",
+				),
+			),
+			want: []*static.Asset{
+				{
+					InstanceID:    "instanceID",
+					ResourceOwner: "org",
+					Name:          "avatar",
+					ObjectType:    static.ObjectTypeUserAvatar,
+					ContentType:   "image/png",
+					Size:          4,
+					Hash:          "md5Hash",
+					LastModified:  testNow,
+				},
+				{
+					InstanceID:    "instanceID",
+					ResourceOwner: "org",
+					Name:          "policy/label/logo",
+					ObjectType:    static.ObjectTypeStyling,
+					ContentType:   "image/svg+xml",
+					Size:          10,
+					Hash:          "md5Hash2",
+					LastModified:  testNow,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &crdbStorage{
+				client: tt.client.db,
+			}
+			got, err := c.ListObjects(context.Background(), "instanceID")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListObjects() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListObjects() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 type db struct {
 	mock sqlmock.Sqlmock
-	db   *sql.DB
+	db   *z_db.DB
 }
 
 func prepareDB(t *testing.T, expectations ...expectation) db {
@@ -287,7 +366,7 @@ func prepareDB(t *testing.T, expectations ...expectation) db {
 	}
 	return db{
 		mock: mock,
-		db:   client,
+		db:   &z_db.DB{DB: client},
 	}
 }
 
@@ -5,6 +5,7 @@
 //
 //	mockgen -source storage.go -destination ./mock/storage_mock.go -package mock
 //
+
 // Package mock is a generated GoMock package.
 package mock
 
@@ -71,6 +72,21 @@ func (mr *MockStorageMockRecorder) GetObjectInfo(ctx, instanceID, resourceOwner,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectInfo", reflect.TypeOf((*MockStorage)(nil).GetObjectInfo), ctx, instanceID, resourceOwner, name)
 }
 
+// ListObjects mocks base method.
+func (m *MockStorage) ListObjects(ctx context.Context, instanceID string) ([]*static.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjects", ctx, instanceID)
+	ret0, _ := ret[0].([]*static.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjects indicates an expected call of ListObjects.
+func (mr *MockStorageMockRecorder) ListObjects(ctx, instanceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockStorage)(nil).ListObjects), ctx, instanceID)
+}
+
 // PutObject mocks base method.
 func (m *MockStorage) PutObject(ctx context.Context, instanceID, location, resourceOwner, name, contentType string, objectType static.ObjectType, object io.Reader, objectSize int64) (*static.Asset, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +141,34 @@ func (m *Minio) RemoveInstanceObjects(ctx context.Context, instanceID string) er
 	return m.Client.RemoveBucket(ctx, bucketName)
 }
 
+func (m *Minio) ListObjects(ctx context.Context, instanceID string) ([]*static.Asset, error) {
+	bucketName := m.prefixBucketName(instanceID)
+	exists, err := m.Client.BucketExists(ctx, bucketName)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "MINIO-Lst1b", "Errors.Assets.Bucket.Internal")
+	}
+	if !exists {
+		return nil, nil
+	}
+	objects, cancel := m.listObjects(ctx, bucketName, "", true)
+	defer cancel()
+	var assets []*static.Asset
+	for object := range objects {
+		if object.Err != nil {
+			return nil, zerrors.ThrowInternal(object.Err, "MINIO-Lst2o", "Errors.Assets.Object.ListFailed")
+		}
+		// the objects are stored as resourceOwner/name
+		resourceOwner, name, _ := strings.Cut(object.Key, "/")
+		asset := m.objectToAssetInfo(instanceID, resourceOwner, object)
+		asset.Name = name
+		if strings.HasPrefix(name, domain.LabelPolicyPrefix+"/") {
+			asset.ObjectType = static.ObjectTypeStyling
+		}
+		assets = append(assets, asset)
+	}
+	return assets, nil
+}
+
 func (m *Minio) createBucket(ctx context.Context, name, location string) error {
 	if location == "" {
 		location = m.Location
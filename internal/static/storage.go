@@ -2,12 +2,13 @@ package static
 
 import (
 	"context"
-	"database/sql"
 	"io"
 	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
 )
 
-type CreateStorage func(client *sql.DB, rawConfig map[string]interface{}) (Storage, error)
+type CreateStorage func(client *database.DB, rawConfig map[string]interface{}) (Storage, error)
 
 type Storage interface {
 	PutObject(ctx context.Context, instanceID, location, resourceOwner, name, contentType string, objectType ObjectType, object io.Reader, objectSize int64) (*Asset, error)
@@ -16,6 +17,8 @@ type Storage interface {
 	RemoveObject(ctx context.Context, instanceID, resourceOwner, name string) error
 	RemoveObjects(ctx context.Context, instanceID, resourceOwner string, objectType ObjectType) error
 	RemoveInstanceObjects(ctx context.Context, instanceID string) error
+	// ListObjects returns the infos of all objects of the instance, e.g. to export the instance
+	ListObjects(ctx context.Context, instanceID string) ([]*Asset, error)
 	//TODO: add functionality to move asset location
 }
 
@@ -26,6 +29,14 @@ const (
 	ObjectTypeStyling
 )
 
+// ObjectTypeFromString parses the value of [ObjectType.String]
+func ObjectTypeFromString(value string) ObjectType {
+	if value == ObjectTypeStyling.String() {
+		return ObjectTypeStyling
+	}
+	return ObjectTypeUserAvatar
+}
+
 func (o ObjectType) String() string {
 	switch o {
 	case ObjectTypeUserAvatar:
@@ -46,6 +57,7 @@ type Asset struct {
 	LastModified  time.Time
 	Location      string
 	ContentType   string
+	ObjectType    ObjectType
 }
 
 func (a *Asset) VersionedName() string {
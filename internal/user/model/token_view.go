@@ -8,20 +8,22 @@ import (
 )
 
 type TokenView struct {
-	ID                string
-	CreationDate      time.Time
-	ChangeDate        time.Time
-	ResourceOwner     string
-	UserID            string
-	ApplicationID     string
-	UserAgentID       string
-	Audience          []string
-	Expiration        time.Time
-	Scopes            []string
-	Sequence          uint64
-	PreferredLanguage string
-	RefreshTokenID    string
-	IsPAT             bool
+	ID                    string
+	CreationDate          time.Time
+	ChangeDate            time.Time
+	ResourceOwner         string
+	UserID                string
+	ApplicationID         string
+	UserAgentID           string
+	Audience              []string
+	Expiration            time.Time
+	Scopes                []string
+	Sequence              uint64
+	PreferredLanguage     string
+	RefreshTokenID        string
+	IsPAT                 bool
+	CertificateThumbprint string
+	AuthorizationDetails  domain.AuthorizationDetails
 }
 
 type TokenSearchRequest struct {
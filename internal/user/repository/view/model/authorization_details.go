@@ -0,0 +1,34 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/jackc/pgtype"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// AuthorizationDetails stores the [domain.AuthorizationDetails] of a token as JSONB
+type AuthorizationDetails domain.AuthorizationDetails
+
+// Scan implements the [database/sql.Scanner] interface.
+func (d *AuthorizationDetails) Scan(src any) error {
+	bytea := new(pgtype.Bytea)
+	if err := bytea.Scan(src); err != nil {
+		return err
+	}
+	if len(bytea.Bytes) == 0 {
+		*d = nil
+		return nil
+	}
+	return json.Unmarshal(bytea.Bytes, (*domain.AuthorizationDetails)(d))
+}
+
+// Value implements the [database/sql/driver.Valuer] interface.
+func (d AuthorizationDetails) Value() (driver.Value, error) {
+	if len(d) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(domain.AuthorizationDetails(d))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	user_repo "github.com/zitadel/zitadel/internal/repository/user"
 	usr_model "github.com/zitadel/zitadel/internal/user/model"
@@ -24,40 +25,44 @@ const (
 )
 
 type TokenView struct {
-	ID                string                     `json:"tokenId" gorm:"column:id;primary_key"`
-	CreationDate      time.Time                  `json:"-" gorm:"column:creation_date"`
-	ChangeDate        time.Time                  `json:"-" gorm:"column:change_date"`
-	ResourceOwner     string                     `json:"-" gorm:"column:resource_owner"`
-	UserID            string                     `json:"-" gorm:"column:user_id"`
-	ApplicationID     string                     `json:"applicationId" gorm:"column:application_id"`
-	UserAgentID       string                     `json:"userAgentId" gorm:"column:user_agent_id"`
-	Audience          database.TextArray[string] `json:"audience" gorm:"column:audience"`
-	Scopes            database.TextArray[string] `json:"scopes" gorm:"column:scopes"`
-	Expiration        time.Time                  `json:"expiration" gorm:"column:expiration"`
-	Sequence          uint64                     `json:"-" gorm:"column:sequence"`
-	PreferredLanguage string                     `json:"preferredLanguage" gorm:"column:preferred_language"`
-	RefreshTokenID    string                     `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
-	IsPAT             bool                       `json:"-" gorm:"is_pat"`
-	Deactivated       bool                       `json:"-" gorm:"-"`
-	InstanceID        string                     `json:"instanceID" gorm:"column:instance_id;primary_key"`
+	ID                    string                     `json:"tokenId" gorm:"column:id;primary_key"`
+	CreationDate          time.Time                  `json:"-" gorm:"column:creation_date"`
+	ChangeDate            time.Time                  `json:"-" gorm:"column:change_date"`
+	ResourceOwner         string                     `json:"-" gorm:"column:resource_owner"`
+	UserID                string                     `json:"-" gorm:"column:user_id"`
+	ApplicationID         string                     `json:"applicationId" gorm:"column:application_id"`
+	UserAgentID           string                     `json:"userAgentId" gorm:"column:user_agent_id"`
+	Audience              database.TextArray[string] `json:"audience" gorm:"column:audience"`
+	Scopes                database.TextArray[string] `json:"scopes" gorm:"column:scopes"`
+	Expiration            time.Time                  `json:"expiration" gorm:"column:expiration"`
+	Sequence              uint64                     `json:"-" gorm:"column:sequence"`
+	PreferredLanguage     string                     `json:"preferredLanguage" gorm:"column:preferred_language"`
+	RefreshTokenID        string                     `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
+	IsPAT                 bool                       `json:"-" gorm:"is_pat"`
+	CertificateThumbprint string                     `json:"certificateThumbprint,omitempty" gorm:"column:certificate_thumbprint"`
+	AuthorizationDetails  AuthorizationDetails       `json:"authorizationDetails,omitempty" gorm:"column:authorization_details"`
+	Deactivated           bool                       `json:"-" gorm:"-"`
+	InstanceID            string                     `json:"instanceID" gorm:"column:instance_id;primary_key"`
 }
 
 func TokenViewToModel(token *TokenView) *usr_model.TokenView {
 	return &usr_model.TokenView{
-		ID:                token.ID,
-		CreationDate:      token.CreationDate,
-		ChangeDate:        token.ChangeDate,
-		ResourceOwner:     token.ResourceOwner,
-		UserID:            token.UserID,
-		ApplicationID:     token.ApplicationID,
-		UserAgentID:       token.UserAgentID,
-		Audience:          token.Audience,
-		Scopes:            token.Scopes,
-		Expiration:        token.Expiration,
-		Sequence:          token.Sequence,
-		PreferredLanguage: token.PreferredLanguage,
-		RefreshTokenID:    token.RefreshTokenID,
-		IsPAT:             token.IsPAT,
+		ID:                    token.ID,
+		CreationDate:          token.CreationDate,
+		ChangeDate:            token.ChangeDate,
+		ResourceOwner:         token.ResourceOwner,
+		UserID:                token.UserID,
+		ApplicationID:         token.ApplicationID,
+		UserAgentID:           token.UserAgentID,
+		Audience:              token.Audience,
+		Scopes:                token.Scopes,
+		Expiration:            token.Expiration,
+		Sequence:              token.Sequence,
+		PreferredLanguage:     token.PreferredLanguage,
+		RefreshTokenID:        token.RefreshTokenID,
+		IsPAT:                 token.IsPAT,
+		CertificateThumbprint: token.CertificateThumbprint,
+		AuthorizationDetails:  domain.AuthorizationDetails(token.AuthorizationDetails),
 	}
 }
 
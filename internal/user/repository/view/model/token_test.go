@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestTokenView_AppendEvent_authorizationDetails(t *testing.T) {
+	details := domain.AuthorizationDetails{
+		{
+			Type:      "payment_initiation",
+			Locations: []string{"https://bank.example.com/payments"},
+			Actions:   []string{"initiate"},
+			Fields:    map[string]any{"instructedAmount": map[string]any{"currency": "EUR", "amount": "123.50"}},
+		},
+	}
+	data, err := json.Marshal(&user.UserTokenAddedEvent{
+		TokenID:              "tokenID",
+		ApplicationID:        "clientID",
+		AuthorizationDetails: details,
+	})
+	require.NoError(t, err)
+
+	view := new(TokenView)
+	err = view.AppendEvent(&es_models.Event{
+		CreationDate:  now(),
+		Typ:           user.UserTokenAddedType,
+		AggregateID:   "userID",
+		ResourceOwner: "orgID",
+		Data:          data,
+	})
+	require.NoError(t, err)
+	token := TokenViewToModel(view)
+	assert.Equal(t, "tokenID", token.ID)
+	assert.Equal(t, details, token.AuthorizationDetails)
+
+	// the details are stored in the view to be returned by the introspection
+	value, err := view.AuthorizationDetails.Value()
+	require.NoError(t, err)
+	scanned := new(AuthorizationDetails)
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, view.AuthorizationDetails, *scanned)
+}
+
+func TestAuthorizationDetails_Value_empty(t *testing.T) {
+	value, err := AuthorizationDetails(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	scanned := AuthorizationDetails{{Type: "payment_initiation"}}
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+}
@@ -0,0 +1,71 @@
+package webauthn
+
+import (
+	"crypto/x509"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// checkAuthenticator checks the authenticator of the created credential against the attestation policy
+// and returns its metadata, if it's listed in the FIDO Metadata Service.
+// The signature of the attestation statement itself is already verified on creation of the credential.
+func (w *Config) checkAuthenticator(credentialData *protocol.ParsedCredentialCreationData, credential *webauthn.Credential, policy *domain.WebAuthNAttestationPolicy) (*MetadataEntry, error) {
+	chain := attestationCertificates(credentialData.Response.AttestationObject)
+	var leaf *x509.Certificate
+	if len(chain) > 0 {
+		leaf = chain[0]
+	}
+	authenticator := w.Metadata.Authenticator(credential.Authenticator.AAGUID, leaf)
+	if !policy.IsEnabled() {
+		return authenticator, nil
+	}
+	if err := policy.CheckAAGUID(credential.Authenticator.AAGUID); err != nil {
+		return nil, err
+	}
+	// the AAGUID is asserted by the authenticator itself,
+	// it's only proven by an attestation certificate of the manufacturer listed in the metadata
+	proveAAGUID := policy.RequireMetadata || len(policy.AllowedAAGUIDs) > 0
+	// without an attestation certificate (none or self attestation) the AAGUID is not proven by the manufacturer
+	if (policy.RequireAttestation || proveAAGUID) && len(chain) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "WEBAU-Eeph3", "Errors.User.WebAuthN.AttestationRequired")
+	}
+	if !proveAAGUID {
+		return authenticator, nil
+	}
+	if authenticator == nil || (policy.RequireMetadata && authenticator.Undesired) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "WEBAU-xoo0E", "Errors.User.WebAuthN.AuthenticatorNotCertified")
+	}
+	if !authenticator.VerifyAttestation(chain) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "WEBAU-ohR2u", "Errors.User.WebAuthN.AuthenticatorNotCertified")
+	}
+	return authenticator, nil
+}
+
+// attestationCertificates returns the certificate chain (x5c) of the attestation statement.
+// It's empty for the none and self attestation.
+func attestationCertificates(attestation protocol.AttestationObject) []*x509.Certificate {
+	if attestation.Format == "none" {
+		return nil
+	}
+	x5c, ok := attestation.AttStatement["x5c"].([]interface{})
+	if !ok {
+		return nil
+	}
+	chain := make([]*x509.Certificate, 0, len(x5c))
+	for _, raw := range x5c {
+		der, ok := raw.([]byte)
+		if !ok {
+			return nil
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil
+		}
+		chain = append(chain, cert)
+	}
+	return chain
+}
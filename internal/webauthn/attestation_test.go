@@ -0,0 +1,188 @@
+package webauthn
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestConfig_checkAuthenticator(t *testing.T) {
+	root := newTestCertificate(t, "root", nil, true)
+	attestation := newTestCertificate(t, "attestation", root, false)
+	other := newTestCertificate(t, "other", nil, true)
+
+	listed := uuid.MustParse("cb69481e-8ff7-4039-93ec-0a2729a154a8")
+	revoked := uuid.MustParse("ee882879-721c-4913-9775-3dfcce97072a")
+	untrusted := uuid.MustParse("fa2b99dc-9e39-4257-8f92-4a30d23c4118")
+	unlisted := uuid.MustParse("08987058-cadc-4b81-b6e1-30de50dcbe96")
+	listedEntry := &MetadataEntry{AAGUID: listed, Description: "Security Key", Icon: "icon", RootCertificates: []*x509.Certificate{root.cert}}
+	config := &Config{
+		Metadata: &Metadata{
+			byAAGUID: map[uuid.UUID]*MetadataEntry{
+				listed:    listedEntry,
+				revoked:   {AAGUID: revoked, Undesired: true, RootCertificates: []*x509.Certificate{root.cert}},
+				untrusted: {AAGUID: untrusted, RootCertificates: []*x509.Certificate{other.cert}},
+			},
+		},
+	}
+
+	packed := protocol.AttestationObject{
+		Format:       "packed",
+		AttStatement: map[string]interface{}{"x5c": []interface{}{attestation.cert.Raw}},
+	}
+	selfAttestation := protocol.AttestationObject{
+		Format:       "packed",
+		AttStatement: map[string]interface{}{"sig": []byte("sig")},
+	}
+	none := protocol.AttestationObject{Format: "none"}
+
+	tests := []struct {
+		name        string
+		attestation protocol.AttestationObject
+		aaguid      uuid.UUID
+		policy      *domain.WebAuthNAttestationPolicy
+		want        *MetadataEntry
+		wantErr     error
+	}{
+		{
+			name:        "no policy, listed",
+			attestation: none,
+			aaguid:      listed,
+			want:        listedEntry,
+		},
+		{
+			name:        "no policy, unlisted",
+			attestation: none,
+			aaguid:      unlisted,
+		},
+		{
+			name:        "aaguid not allowed",
+			attestation: packed,
+			aaguid:      listed,
+			policy:      &domain.WebAuthNAttestationPolicy{AllowedAAGUIDs: []string{unlisted.String()}},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "DOMAIN-aeN3i", "Errors.User.WebAuthN.AuthenticatorNotAllowed"),
+		},
+		{
+			name:        "attestation required, none",
+			attestation: none,
+			aaguid:      listed,
+			policy:      &domain.WebAuthNAttestationPolicy{RequireAttestation: true},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "WEBAU-Eeph3", "Errors.User.WebAuthN.AttestationRequired"),
+		},
+		{
+			name:        "attestation required, self attestation",
+			attestation: selfAttestation,
+			aaguid:      listed,
+			policy:      &domain.WebAuthNAttestationPolicy{RequireAttestation: true},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "WEBAU-Eeph3", "Errors.User.WebAuthN.AttestationRequired"),
+		},
+		{
+			name:        "attestation required, ok",
+			attestation: packed,
+			aaguid:      unlisted,
+			policy:      &domain.WebAuthNAttestationPolicy{RequireAttestation: true},
+		},
+		{
+			name:        "metadata required, unlisted",
+			attestation: packed,
+			aaguid:      unlisted,
+			policy:      &domain.WebAuthNAttestationPolicy{RequireMetadata: true},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "WEBAU-xoo0E", "Errors.User.WebAuthN.AuthenticatorNotCertified"),
+		},
+		{
+			name:        "metadata required, undesired status",
+			attestation: packed,
+			aaguid:      revoked,
+			policy:      &domain.WebAuthNAttestationPolicy{RequireMetadata: true},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "WEBAU-xoo0E", "Errors.User.WebAuthN.AuthenticatorNotCertified"),
+		},
+		{
+			name:        "metadata required, attestation of other manufacturer",
+			attestation: packed,
+			aaguid:      untrusted,
+			policy:      &domain.WebAuthNAttestationPolicy{RequireMetadata: true},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "WEBAU-ohR2u", "Errors.User.WebAuthN.AuthenticatorNotCertified"),
+		},
+		{
+			name:        "metadata required, none",
+			attestation: none,
+			aaguid:      listed,
+			policy:      &domain.WebAuthNAttestationPolicy{RequireMetadata: true},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "WEBAU-Eeph3", "Errors.User.WebAuthN.AttestationRequired"),
+		},
+		{
+			name:        "metadata required, self attestation",
+			attestation: selfAttestation,
+			aaguid:      listed,
+			policy:      &domain.WebAuthNAttestationPolicy{RequireMetadata: true},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "WEBAU-Eeph3", "Errors.User.WebAuthN.AttestationRequired"),
+		},
+		{
+			name:        "aaguid allowed, none",
+			attestation: none,
+			aaguid:      listed,
+			policy:      &domain.WebAuthNAttestationPolicy{AllowedAAGUIDs: []string{listed.String()}},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "WEBAU-Eeph3", "Errors.User.WebAuthN.AttestationRequired"),
+		},
+		{
+			name:        "aaguid allowed, unlisted",
+			attestation: packed,
+			aaguid:      unlisted,
+			policy:      &domain.WebAuthNAttestationPolicy{AllowedAAGUIDs: []string{unlisted.String()}},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "WEBAU-xoo0E", "Errors.User.WebAuthN.AuthenticatorNotCertified"),
+		},
+		{
+			name:        "aaguid allowed, attestation of other manufacturer",
+			attestation: packed,
+			aaguid:      untrusted,
+			policy:      &domain.WebAuthNAttestationPolicy{AllowedAAGUIDs: []string{untrusted.String()}},
+			wantErr:     zerrors.ThrowPreconditionFailed(nil, "WEBAU-ohR2u", "Errors.User.WebAuthN.AuthenticatorNotCertified"),
+		},
+		{
+			name:        "aaguid allowed, ok",
+			attestation: packed,
+			aaguid:      listed,
+			policy:      &domain.WebAuthNAttestationPolicy{AllowedAAGUIDs: []string{listed.String()}},
+			want:        listedEntry,
+		},
+		{
+			name:        "aaguid denied, none",
+			attestation: none,
+			aaguid:      listed,
+			policy:      &domain.WebAuthNAttestationPolicy{DeniedAAGUIDs: []string{unlisted.String()}},
+			want:        listedEntry,
+		},
+		{
+			name:        "attestation and metadata required, ok",
+			attestation: packed,
+			aaguid:      listed,
+			policy:      &domain.WebAuthNAttestationPolicy{RequireAttestation: true, RequireMetadata: true, AllowedAAGUIDs: []string{listed.String()}},
+			want:        listedEntry,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credentialData := &protocol.ParsedCredentialCreationData{
+				Response: protocol.ParsedAttestationResponse{AttestationObject: tt.attestation},
+			}
+			credential := &webauthn.Credential{
+				Authenticator: webauthn.Authenticator{AAGUID: tt.aaguid[:]},
+			}
+			got, err := config.checkAuthenticator(credentialData, credential, tt.policy)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
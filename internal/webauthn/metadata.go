@@ -0,0 +1,277 @@
+package webauthn
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-webauthn/webauthn/metadata"
+	"github.com/google/uuid"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// MetadataConfig defines where the BLOB of the FIDO Metadata Service (MDS) is loaded from.
+// The metadata is only loaded if either the Path or the URL is set.
+type MetadataConfig struct {
+	// Path of a local copy of the BLOB, takes precedence over the URL
+	Path string
+	// URL of the BLOB, e.g. https://mds3.fidoalliance.org/
+	URL string
+	// RootCertificate (PEM) the signing certificate of the BLOB must chain to,
+	// defaults to the root certificate of the FIDO Alliance MDS
+	RootCertificate string
+	// RefreshInterval reloads the BLOB periodically, if greater than zero
+	RefreshInterval time.Duration
+}
+
+// MetadataEntry describes an authenticator listed in the FIDO Metadata Service.
+type MetadataEntry struct {
+	AAGUID      uuid.UUID
+	Description string
+	// Icon is a data url of a PNG image
+	Icon string
+	// Undesired is set if any status report of the authenticator is undesired, e.g. it's revoked or compromised
+	Undesired        bool
+	RootCertificates []*x509.Certificate
+}
+
+// Metadata contains the authenticators of a verified BLOB of the FIDO Metadata Service.
+type Metadata struct {
+	mu               sync.RWMutex
+	byAAGUID         map[uuid.UUID]*MetadataEntry
+	byKeyIdentifiers map[string]*MetadataEntry
+}
+
+// LoadMetadata loads and verifies the BLOB of the FIDO Metadata Service.
+// It returns nil if no source is configured.
+func LoadMetadata(ctx context.Context, config MetadataConfig) (*Metadata, error) {
+	if config.Path == "" && config.URL == "" {
+		return nil, nil
+	}
+	root, err := metadataRootCertificate(config.RootCertificate)
+	if err != nil {
+		return nil, err
+	}
+	m := new(Metadata)
+	if err = m.load(ctx, config, root); err != nil {
+		return nil, err
+	}
+	if config.RefreshInterval > 0 {
+		go m.refresh(ctx, config, root)
+	}
+	return m, nil
+}
+
+func (m *Metadata) refresh(ctx context.Context, config MetadataConfig, root *x509.Certificate) {
+	ticker := time.NewTicker(config.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// the previously loaded metadata is kept, if the BLOB is not available or invalid
+			logging.OnError(m.load(ctx, config, root)).Warn("unable to refresh fido metadata")
+		}
+	}
+}
+
+func (m *Metadata) load(ctx context.Context, config MetadataConfig, root *x509.Certificate) error {
+	blob, err := readMetadataBLOB(ctx, config)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseMetadata(blob, root, time.Now())
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.byAAGUID = parsed.byAAGUID
+	m.byKeyIdentifiers = parsed.byKeyIdentifiers
+	return nil
+}
+
+func readMetadataBLOB(ctx context.Context, config MetadataConfig) ([]byte, error) {
+	if config.Path != "" {
+		blob, err := os.ReadFile(config.Path)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "WEBAU-ahD4e", "Errors.User.WebAuthN.MetadataUnavailable")
+		}
+		return blob, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.URL, nil)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WEBAU-Eis7u", "Errors.User.WebAuthN.MetadataUnavailable")
+	}
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WEBAU-uPh0a", "Errors.User.WebAuthN.MetadataUnavailable")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, zerrors.ThrowInternalf(nil, "WEBAU-Ma9ie", "Errors.User.WebAuthN.MetadataUnavailable: status %d", resp.StatusCode)
+	}
+	blob, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WEBAU-ieB1u", "Errors.User.WebAuthN.MetadataUnavailable")
+	}
+	return blob, nil
+}
+
+func metadataRootCertificate(rootPEM string) (*x509.Certificate, error) {
+	if rootPEM == "" {
+		der, err := base64.StdEncoding.DecodeString(metadata.ProductionMDSRoot)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "WEBAU-Xoh2e", "Errors.User.WebAuthN.MetadataInvalid")
+		}
+		return x509.ParseCertificate(der)
+	}
+	block, _ := pem.Decode([]byte(rootPEM))
+	if block == nil {
+		return nil, zerrors.ThrowInternal(nil, "WEBAU-Ahv5i", "Errors.User.WebAuthN.MetadataInvalid")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WEBAU-shie5", "Errors.User.WebAuthN.MetadataInvalid")
+	}
+	return cert, nil
+}
+
+// ParseMetadata verifies the signature of the BLOB (a JWT) and its certificate chain (x5c header) against the root certificate
+// and returns the authenticators listed in the payload.
+func ParseMetadata(blob []byte, root *x509.Certificate, now time.Time) (*Metadata, error) {
+	jws, err := jose.ParseSigned(string(blob))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WEBAU-Ooc8i", "Errors.User.WebAuthN.MetadataInvalid")
+	}
+	if len(jws.Signatures) != 1 {
+		return nil, zerrors.ThrowInternal(nil, "WEBAU-aiV0e", "Errors.User.WebAuthN.MetadataInvalid")
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	chains, err := jws.Signatures[0].Protected.Certificates(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: now,
+	})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WEBAU-Quo6j", "Errors.User.WebAuthN.MetadataInvalid")
+	}
+	payload, err := jws.Verify(chains[0][0].PublicKey)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WEBAU-neeW7", "Errors.User.WebAuthN.MetadataInvalid")
+	}
+	var parsed metadata.MetadataBLOBPayload
+	if err = json.Unmarshal(payload, &parsed); err != nil {
+		return nil, zerrors.ThrowInternal(err, "WEBAU-Iez8o", "Errors.User.WebAuthN.MetadataInvalid")
+	}
+	m := &Metadata{
+		byAAGUID:         make(map[uuid.UUID]*MetadataEntry, len(parsed.Entries)),
+		byKeyIdentifiers: make(map[string]*MetadataEntry),
+	}
+	for _, payloadEntry := range parsed.Entries {
+		entry := metadataEntry(payloadEntry)
+		if entry.AAGUID != uuid.Nil {
+			m.byAAGUID[entry.AAGUID] = entry
+		}
+		for _, keyIdentifier := range payloadEntry.AttestationCertificateKeyIdentifiers {
+			m.byKeyIdentifiers[keyIdentifier] = entry
+		}
+	}
+	return m, nil
+}
+
+func metadataEntry(payloadEntry metadata.MetadataBLOBPayloadEntry) *MetadataEntry {
+	entry := &MetadataEntry{
+		Description: payloadEntry.MetadataStatement.Description,
+		Icon:        payloadEntry.MetadataStatement.Icon,
+	}
+	entry.AAGUID, _ = uuid.Parse(payloadEntry.AaGUID)
+	for _, report := range payloadEntry.StatusReports {
+		if metadata.IsUndesiredAuthenticatorStatus(report.Status) {
+			entry.Undesired = true
+		}
+	}
+	for _, rootCertificate := range payloadEntry.MetadataStatement.AttestationRootCertificates {
+		der, err := base64.StdEncoding.DecodeString(rootCertificate)
+		if err != nil {
+			continue
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			continue
+		}
+		entry.RootCertificates = append(entry.RootCertificates, cert)
+	}
+	return entry
+}
+
+// Authenticator returns the metadata of the authenticator with the AAGUID.
+// Authenticators without AAGUID (FIDO U2F) are identified by the key identifier of their attestation certificate.
+func (m *Metadata) Authenticator(aaguid []byte, attestationCert *x509.Certificate) *MetadataEntry {
+	if m == nil {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if id, err := uuid.FromBytes(aaguid); err == nil && id != uuid.Nil {
+		return m.byAAGUID[id]
+	}
+	if attestationCert == nil {
+		return nil
+	}
+	keyIdentifier, err := attestationCertificateKeyIdentifier(attestationCert)
+	if err != nil {
+		return nil
+	}
+	return m.byKeyIdentifiers[keyIdentifier]
+}
+
+// attestationCertificateKeyIdentifier returns the hex encoded SHA-1 hash of the public key of the certificate,
+// as used by the FIDO Metadata Service.
+func attestationCertificateKeyIdentifier(cert *x509.Certificate) (string, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(cert.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return "", err
+	}
+	hash := sha1.Sum(spki.PublicKey.Bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// VerifyAttestation checks that the attestation certificate chains to a root certificate of the authenticator.
+func (e *MetadataEntry) VerifyAttestation(chain []*x509.Certificate) bool {
+	if len(chain) == 0 || len(e.RootCertificates) == 0 {
+		return false
+	}
+	roots := x509.NewCertPool()
+	for _, root := range e.RootCertificates {
+		roots.AddCert(root)
+	}
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range chain[1:] {
+		intermediates.AddCert(intermediate)
+	}
+	_, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil
+}
@@ -0,0 +1,215 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-webauthn/webauthn/metadata"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCertificate(t *testing.T, name string, parent *testCertificate, isCA bool) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCertificate{cert: cert, key: key}
+}
+
+func signTestMetadata(t *testing.T, signer *testCertificate, chain []*testCertificate, payload metadata.MetadataBLOBPayload) []byte {
+	x5c := make([]string, len(chain))
+	for i, cert := range chain {
+		x5c[i] = base64.StdEncoding.EncodeToString(cert.cert.Raw)
+	}
+	joseSigner, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: signer.key},
+		(&jose.SignerOptions{}).WithHeader("x5c", x5c),
+	)
+	require.NoError(t, err)
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	jws, err := joseSigner.Sign(data)
+	require.NoError(t, err)
+	blob, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return []byte(blob)
+}
+
+func TestParseMetadata(t *testing.T) {
+	root := newTestCertificate(t, "root", nil, true)
+	intermediate := newTestCertificate(t, "intermediate", root, true)
+	signer := newTestCertificate(t, "signer", intermediate, false)
+	otherRoot := newTestCertificate(t, "other", nil, true)
+	attestationRoot := newTestCertificate(t, "attestation root", nil, true)
+
+	aaguid := uuid.MustParse("cb69481e-8ff7-4039-93ec-0a2729a154a8")
+	revoked := uuid.MustParse("ee882879-721c-4913-9775-3dfcce97072a")
+	payload := metadata.MetadataBLOBPayload{
+		Number: 1,
+		Entries: []metadata.MetadataBLOBPayloadEntry{
+			{
+				AaGUID: aaguid.String(),
+				MetadataStatement: metadata.MetadataStatement{
+					Description:                 "Security Key",
+					Icon:                        "data:image/png;base64,iVBORw0KGgo=",
+					AttestationRootCertificates: []string{base64.StdEncoding.EncodeToString(attestationRoot.cert.Raw)},
+				},
+				StatusReports: []metadata.StatusReport{{Status: metadata.FidoCertified}},
+			},
+			{
+				AaGUID: revoked.String(),
+				MetadataStatement: metadata.MetadataStatement{
+					Description: "Revoked Key",
+				},
+				StatusReports: []metadata.StatusReport{{Status: metadata.FidoCertified}, {Status: metadata.Revoked}},
+			},
+			{
+				AttestationCertificateKeyIdentifiers: []string{"bf7bcaa0d0c6187a8c6abbdd16a15640e7c7bde2"},
+				MetadataStatement: metadata.MetadataStatement{
+					Description: "U2F Key",
+				},
+			},
+		},
+	}
+
+	t.Run("invalid blob", func(t *testing.T) {
+		_, err := ParseMetadata([]byte("invalid"), root.cert, time.Now())
+		assert.Error(t, err)
+	})
+	t.Run("untrusted root", func(t *testing.T) {
+		blob := signTestMetadata(t, signer, []*testCertificate{signer, intermediate}, payload)
+		_, err := ParseMetadata(blob, otherRoot.cert, time.Now())
+		assert.Error(t, err)
+	})
+	t.Run("expired chain", func(t *testing.T) {
+		blob := signTestMetadata(t, signer, []*testCertificate{signer, intermediate}, payload)
+		_, err := ParseMetadata(blob, root.cert, time.Now().Add(2*time.Hour))
+		assert.Error(t, err)
+	})
+	t.Run("signature not of leaf", func(t *testing.T) {
+		blob := signTestMetadata(t, intermediate, []*testCertificate{signer, intermediate}, payload)
+		_, err := ParseMetadata(blob, root.cert, time.Now())
+		assert.Error(t, err)
+	})
+	t.Run("ok", func(t *testing.T) {
+		blob := signTestMetadata(t, signer, []*testCertificate{signer, intermediate}, payload)
+		m, err := ParseMetadata(blob, root.cert, time.Now())
+		require.NoError(t, err)
+
+		entry := m.Authenticator(aaguid[:], nil)
+		require.NotNil(t, entry)
+		assert.Equal(t, "Security Key", entry.Description)
+		assert.Equal(t, "data:image/png;base64,iVBORw0KGgo=", entry.Icon)
+		assert.False(t, entry.Undesired)
+		require.Len(t, entry.RootCertificates, 1)
+
+		entry = m.Authenticator(revoked[:], nil)
+		require.NotNil(t, entry)
+		assert.True(t, entry.Undesired)
+
+		unknown := uuid.New()
+		assert.Nil(t, m.Authenticator(unknown[:], nil))
+		assert.Nil(t, m.Authenticator(make([]byte, 16), nil))
+	})
+}
+
+func TestMetadata_Authenticator_nil(t *testing.T) {
+	var m *Metadata
+	id := uuid.New()
+	assert.Nil(t, m.Authenticator(id[:], nil))
+}
+
+func TestMetadata_Authenticator_keyIdentifier(t *testing.T) {
+	attestation := newTestCertificate(t, "u2f", nil, false)
+	keyIdentifier, err := attestationCertificateKeyIdentifier(attestation.cert)
+	require.NoError(t, err)
+	entry := &MetadataEntry{Description: "U2F Key"}
+	m := &Metadata{
+		byAAGUID:         map[uuid.UUID]*MetadataEntry{},
+		byKeyIdentifiers: map[string]*MetadataEntry{keyIdentifier: entry},
+	}
+	assert.Equal(t, entry, m.Authenticator(make([]byte, 16), attestation.cert))
+	assert.Nil(t, m.Authenticator(make([]byte, 16), newTestCertificate(t, "other", nil, false).cert))
+}
+
+func TestMetadataEntry_VerifyAttestation(t *testing.T) {
+	root := newTestCertificate(t, "root", nil, true)
+	intermediate := newTestCertificate(t, "intermediate", root, true)
+	attestation := newTestCertificate(t, "attestation", intermediate, false)
+	other := newTestCertificate(t, "other", nil, true)
+
+	tests := []struct {
+		name  string
+		entry *MetadataEntry
+		chain []*x509.Certificate
+		want  bool
+	}{
+		{
+			name:  "no chain",
+			entry: &MetadataEntry{RootCertificates: []*x509.Certificate{root.cert}},
+		},
+		{
+			name:  "no roots",
+			entry: &MetadataEntry{},
+			chain: []*x509.Certificate{attestation.cert, intermediate.cert},
+		},
+		{
+			name:  "other root",
+			entry: &MetadataEntry{RootCertificates: []*x509.Certificate{other.cert}},
+			chain: []*x509.Certificate{attestation.cert, intermediate.cert},
+		},
+		{
+			name:  "missing intermediate",
+			entry: &MetadataEntry{RootCertificates: []*x509.Certificate{root.cert}},
+			chain: []*x509.Certificate{attestation.cert},
+		},
+		{
+			name:  "chain to root",
+			entry: &MetadataEntry{RootCertificates: []*x509.Certificate{root.cert}},
+			chain: []*x509.Certificate{attestation.cert, intermediate.cert},
+			want:  true,
+		},
+		{
+			name:  "intermediate as trust anchor",
+			entry: &MetadataEntry{RootCertificates: []*x509.Certificate{other.cert, intermediate.cert}},
+			chain: []*x509.Certificate{attestation.cert},
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.entry.VerifyAttestation(tt.chain))
+		})
+	}
+}
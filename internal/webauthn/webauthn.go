@@ -19,6 +19,8 @@ import (
 type Config struct {
 	DisplayName    string
 	ExternalSecure bool
+	// Metadata of the FIDO Metadata Service, used to check and describe the authenticators on registration
+	Metadata *Metadata
 }
 
 type webUser struct {
@@ -53,12 +55,22 @@ func (u *webUser) WebAuthnCredentials() []webauthn.Credential {
 	return u.credentials
 }
 
-func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, accountName string, authType domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement, rpID string, webAuthNs ...*domain.WebAuthNToken) (*domain.WebAuthNToken, error) {
+func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, accountName string, authType domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement, attestationPolicy *domain.WebAuthNAttestationPolicy, rpID string, webAuthNs ...*domain.WebAuthNToken) (*domain.WebAuthNToken, error) {
 	webAuthNServer, err := w.serverFromContext(ctx, rpID, "")
 	if err != nil {
 		return nil, err
 	}
 	creds := WebAuthNsToCredentials(webAuthNs, rpID)
+	residentKey := protocol.ResidentKeyRequirementDiscouraged
+	if userVerification == domain.UserVerificationRequirementRequired {
+		// passkeys should be discoverable, so they can be used without entering the username first
+		residentKey = protocol.ResidentKeyRequirementPreferred
+	}
+	conveyance := protocol.PreferNoAttestation
+	if attestationPolicy.IsEnabled() {
+		// without the attestation, browsers might anonymize the AAGUID of the authenticator
+		conveyance = protocol.PreferDirectAttestation
+	}
 	existing := make([]protocol.CredentialDescriptor, len(creds))
 	for i, cred := range creds {
 		existing[i] = protocol.CredentialDescriptor{
@@ -75,8 +87,9 @@ func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, acco
 		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
 			UserVerification:        UserVerificationFromDomain(userVerification),
 			AuthenticatorAttachment: AuthenticatorAttachmentFromDomain(authType),
+			ResidentKey:             residentKey,
 		}),
-		webauthn.WithConveyancePreference(protocol.PreferNoAttestation),
+		webauthn.WithConveyancePreference(conveyance),
 		webauthn.WithExclusions(existing),
 	)
 	if err != nil {
@@ -95,7 +108,7 @@ func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, acco
 	}, nil
 }
 
-func (w *Config) FinishRegistration(ctx context.Context, user *domain.Human, webAuthN *domain.WebAuthNToken, tokenName string, credData []byte, isLoginUI bool) (*domain.WebAuthNToken, error) {
+func (w *Config) FinishRegistration(ctx context.Context, user *domain.Human, webAuthN *domain.WebAuthNToken, tokenName string, credData []byte, isLoginUI bool, attestationPolicy *domain.WebAuthNAttestationPolicy) (*domain.WebAuthNToken, error) {
 	if webAuthN == nil {
 		return nil, zerrors.ThrowInternal(nil, "WEBAU-5M9so", "Errors.User.WebAuthN.NotFound")
 	}
@@ -119,6 +132,14 @@ func (w *Config) FinishRegistration(ctx context.Context, user *domain.Human, web
 		logging.WithFields("error", tryExtractProtocolErrMsg(err)).Debug("webauthn credential could not be created")
 		return nil, zerrors.ThrowInternal(err, "WEBAU-3Vb9s", "Errors.User.WebAuthN.CreateCredentialFailed")
 	}
+	authenticator, err := w.checkAuthenticator(credentialData, credential, attestationPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if authenticator != nil {
+		webAuthN.AuthenticatorName = authenticator.Description
+		webAuthN.AuthenticatorIcon = authenticator.Icon
+	}
 
 	webAuthN.KeyID = credential.ID
 	webAuthN.PublicKey = credential.PublicKey
@@ -156,6 +177,44 @@ func (w *Config) BeginLogin(ctx context.Context, user *domain.Human, userVerific
 	}, nil
 }
 
+// BeginDiscoverableLogin starts a login with a client-side discoverable credential (passkey),
+// where the user is not known beforehand, but identified by the user handle of the assertion.
+func (w *Config) BeginDiscoverableLogin(ctx context.Context, userVerification domain.UserVerificationRequirement, rpID string) (*domain.WebAuthNLogin, error) {
+	webAuthNServer, err := w.serverFromContext(ctx, rpID, "")
+	if err != nil {
+		return nil, err
+	}
+	assertion, sessionData, err := webAuthNServer.BeginDiscoverableLogin(webauthn.WithUserVerification(UserVerificationFromDomain(userVerification)))
+	if err != nil {
+		logging.WithFields("error", tryExtractProtocolErrMsg(err)).Debug("webauthn discoverable login could not be started")
+		return nil, zerrors.ThrowInternal(err, "WEBAU-Shoo4", "Errors.User.WebAuthN.BeginLoginFailed")
+	}
+	cred, err := json.Marshal(assertion)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WEBAU-eiP4o", "Errors.User.WebAuthN.MarshalError")
+	}
+	return &domain.WebAuthNLogin{
+		Challenge:               sessionData.Challenge,
+		CredentialAssertionData: cred,
+		UserVerification:        userVerification,
+		RPID:                    webAuthNServer.Config.RPID,
+	}, nil
+}
+
+// UserIDFromAssertion returns the user handle of the assertion,
+// which is the ID of the user the (discoverable) credential was registered for.
+func UserIDFromAssertion(credData []byte) (string, error) {
+	assertionData, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(credData))
+	if err != nil {
+		logging.WithFields("error", tryExtractProtocolErrMsg(err)).Debug("webauthn assertion could not be parsed")
+		return "", zerrors.ThrowInvalidArgument(err, "WEBAU-Ohx3u", "Errors.User.WebAuthN.ValidateLoginFailed")
+	}
+	if len(assertionData.Response.UserHandle) == 0 {
+		return "", zerrors.ThrowInvalidArgument(nil, "WEBAU-Wai6d", "Errors.User.WebAuthN.ValidateLoginFailed")
+	}
+	return string(assertionData.Response.UserHandle), nil
+}
+
 func (w *Config) FinishLogin(ctx context.Context, user *domain.Human, webAuthN *domain.WebAuthNLogin, credData []byte, webAuthNs ...*domain.WebAuthNToken) (*webauthn.Credential, error) {
 	assertionData, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(credData))
 	if err != nil {
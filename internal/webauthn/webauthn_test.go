@@ -2,6 +2,7 @@ package webauthn
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 
 	"github.com/go-webauthn/webauthn/webauthn"
@@ -69,3 +70,51 @@ func TestConfig_serverFromContext(t *testing.T) {
 		})
 	}
 }
+
+func TestUserIDFromAssertion(t *testing.T) {
+	assertion := func(userHandle string) []byte {
+		enc := base64.RawURLEncoding.EncodeToString
+		authenticatorData := make([]byte, 37)
+		authenticatorData[32] = 0x05 // user present and verified
+		return []byte(`{
+			"id": "` + enc([]byte("credential")) + `",
+			"rawId": "` + enc([]byte("credential")) + `",
+			"type": "public-key",
+			"response": {
+				"authenticatorData": "` + enc(authenticatorData) + `",
+				"clientDataJSON": "` + enc([]byte(`{"type":"webauthn.get","challenge":"challenge","origin":"https://example.com"}`)) + `",
+				"signature": "` + enc([]byte("signature")) + `",
+				"userHandle": "` + enc([]byte(userHandle)) + `"
+			}
+		}`)
+	}
+	tests := []struct {
+		name     string
+		credData []byte
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "invalid assertion",
+			credData: []byte("invalid"),
+			wantErr:  zerrors.ThrowInvalidArgument(nil, "WEBAU-Ohx3u", "Errors.User.WebAuthN.ValidateLoginFailed"),
+		},
+		{
+			name:     "missing user handle",
+			credData: assertion(""),
+			wantErr:  zerrors.ThrowInvalidArgument(nil, "WEBAU-Wai6d", "Errors.User.WebAuthN.ValidateLoginFailed"),
+		},
+		{
+			name:     "user handle",
+			credData: assertion("userID"),
+			want:     "userID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserIDFromAssertion(tt.credData)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}